@@ -0,0 +1,10 @@
+-- Drop trigger
+DROP TRIGGER IF EXISTS update_drivers_updated_at ON drivers;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_drivers_deleted_at;
+DROP INDEX IF EXISTS idx_drivers_status;
+DROP INDEX IF EXISTS idx_drivers_user_id;
+
+-- Drop drivers table
+DROP TABLE IF EXISTS drivers;
@@ -0,0 +1,29 @@
+-- Create drivers table (1:1 driver profile for a user account)
+CREATE TABLE IF NOT EXISTS drivers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    license_number VARCHAR(50) NOT NULL,
+    license_class VARCHAR(10) NOT NULL,
+    license_expiry DATE NOT NULL,
+    employment_type VARCHAR(20) NOT NULL,
+    home_depot VARCHAR(255),
+    emergency_contact_name VARCHAR(255),
+    emergency_contact_phone VARCHAR(20),
+    status VARCHAR(20) NOT NULL DEFAULT 'available',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+-- Only one active driver profile per user
+CREATE UNIQUE INDEX IF NOT EXISTS idx_drivers_user_id ON drivers(user_id) WHERE deleted_at IS NULL;
+
+-- Create index on status for availability lookups
+CREATE INDEX IF NOT EXISTS idx_drivers_status ON drivers(status);
+
+-- Create index on deleted_at for soft deletes
+CREATE INDEX IF NOT EXISTS idx_drivers_deleted_at ON drivers(deleted_at);
+
+-- Create trigger for drivers table
+CREATE TRIGGER update_drivers_updated_at BEFORE UPDATE ON drivers
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
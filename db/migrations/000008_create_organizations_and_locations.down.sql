@@ -0,0 +1,12 @@
+-- Drop locations
+DROP TRIGGER IF EXISTS update_locations_updated_at ON locations;
+DROP INDEX IF EXISTS idx_locations_deleted_at;
+DROP INDEX IF EXISTS idx_locations_postcode;
+DROP INDEX IF EXISTS idx_locations_organization_id;
+DROP TABLE IF EXISTS locations;
+
+-- Drop organizations
+DROP TRIGGER IF EXISTS update_organizations_updated_at ON organizations;
+DROP INDEX IF EXISTS idx_organizations_deleted_at;
+DROP INDEX IF EXISTS idx_organizations_tax_id;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,50 @@
+-- Create organizations table
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    tax_id VARCHAR(13),
+    branch_code VARCHAR(5) NOT NULL DEFAULT '00000',
+    phone VARCHAR(20),
+    email VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_organizations_tax_id ON organizations(tax_id);
+CREATE INDEX IF NOT EXISTS idx_organizations_deleted_at ON organizations(deleted_at);
+
+CREATE TRIGGER update_organizations_updated_at BEFORE UPDATE ON organizations
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create locations table (organization address books with structured Thai addresses)
+CREATE TABLE IF NOT EXISTS locations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id),
+    name VARCHAR(255) NOT NULL,
+    code VARCHAR(50),
+    house_number VARCHAR(50),
+    moo VARCHAR(10),
+    soi VARCHAR(255),
+    road VARCHAR(255),
+    subdistrict VARCHAR(100) NOT NULL,
+    district VARCHAR(100) NOT NULL,
+    province VARCHAR(100) NOT NULL,
+    postcode VARCHAR(5) NOT NULL,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    contact_name VARCHAR(255),
+    contact_phone VARCHAR(20),
+    opening_hours JSONB NOT NULL DEFAULT '[]',
+    notes TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_locations_organization_id ON locations(organization_id);
+CREATE INDEX IF NOT EXISTS idx_locations_postcode ON locations(postcode);
+CREATE INDEX IF NOT EXISTS idx_locations_deleted_at ON locations(deleted_at);
+
+CREATE TRIGGER update_locations_updated_at BEFORE UPDATE ON locations
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
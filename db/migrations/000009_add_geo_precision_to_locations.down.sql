@@ -0,0 +1,2 @@
+-- Drop geo_precision column
+ALTER TABLE locations DROP COLUMN IF EXISTS geo_precision;
@@ -0,0 +1,4 @@
+-- Record how precisely each location's pin was placed (manual, rooftop, street, subdistrict, postcode, approximate)
+ALTER TABLE locations ADD COLUMN IF NOT EXISTS geo_precision VARCHAR(20);
+
+UPDATE locations SET geo_precision = 'manual' WHERE latitude IS NOT NULL AND longitude IS NOT NULL;
@@ -0,0 +1,25 @@
+-- Drop trip_stops
+DROP INDEX IF EXISTS idx_trip_stops_shipment_id;
+DROP TABLE IF EXISTS trip_stops;
+
+-- Drop trips
+DROP TRIGGER IF EXISTS update_trips_updated_at ON trips;
+DROP INDEX IF EXISTS idx_trips_status;
+DROP INDEX IF EXISTS idx_trips_co_driver_id;
+DROP INDEX IF EXISTS idx_trips_driver_schedule;
+DROP INDEX IF EXISTS idx_trips_vehicle_schedule;
+DROP TABLE IF EXISTS trips;
+
+-- Drop shipments
+DROP TRIGGER IF EXISTS update_shipments_updated_at ON shipments;
+DROP INDEX IF EXISTS idx_shipments_deleted_at;
+DROP INDEX IF EXISTS idx_shipments_status;
+DROP INDEX IF EXISTS idx_shipments_organization_id;
+DROP TABLE IF EXISTS shipments;
+
+-- Drop vehicles
+DROP TRIGGER IF EXISTS update_vehicles_updated_at ON vehicles;
+DROP INDEX IF EXISTS idx_vehicles_deleted_at;
+DROP INDEX IF EXISTS idx_vehicles_status;
+DROP INDEX IF EXISTS idx_vehicles_plate;
+DROP TABLE IF EXISTS vehicles;
@@ -0,0 +1,92 @@
+-- Create vehicles table
+CREATE TABLE IF NOT EXISTS vehicles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    plate_number VARCHAR(20) NOT NULL,
+    plate_province VARCHAR(100) NOT NULL,
+    type VARCHAR(10) NOT NULL,
+    brand VARCHAR(100),
+    model VARCHAR(100),
+    year INTEGER,
+    max_weight_kg DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_volume_m3 DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_pallets INTEGER NOT NULL DEFAULT 0,
+    home_depot VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+-- A plate is unique within its province
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vehicles_plate ON vehicles(plate_number, plate_province) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_vehicles_status ON vehicles(status);
+CREATE INDEX IF NOT EXISTS idx_vehicles_deleted_at ON vehicles(deleted_at);
+
+CREATE TRIGGER update_vehicles_updated_at BEFORE UPDATE ON vehicles
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create shipments table
+CREATE TABLE IF NOT EXISTS shipments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id),
+    reference VARCHAR(100),
+    pickup_location_id UUID NOT NULL REFERENCES locations(id),
+    delivery_location_id UUID NOT NULL REFERENCES locations(id),
+    weight_kg DOUBLE PRECISION NOT NULL DEFAULT 0,
+    volume_m3 DOUBLE PRECISION NOT NULL DEFAULT 0,
+    pallets INTEGER NOT NULL DEFAULT 0,
+    pickup_from TIMESTAMP,
+    pickup_to TIMESTAMP,
+    deliver_from TIMESTAMP,
+    deliver_to TIMESTAMP,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    notes TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipments_organization_id ON shipments(organization_id);
+CREATE INDEX IF NOT EXISTS idx_shipments_status ON shipments(status);
+CREATE INDEX IF NOT EXISTS idx_shipments_deleted_at ON shipments(deleted_at);
+
+CREATE TRIGGER update_shipments_updated_at BEFORE UPDATE ON shipments
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create trips table
+CREATE TABLE IF NOT EXISTS trips (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    vehicle_id UUID NOT NULL REFERENCES vehicles(id),
+    driver_id UUID NOT NULL REFERENCES drivers(id),
+    co_driver_id UUID REFERENCES drivers(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'planned',
+    planned_start TIMESTAMP NOT NULL,
+    planned_end TIMESTAMP NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP,
+    CHECK (planned_end > planned_start)
+);
+
+-- Indexes for overlap checks on vehicles and drivers
+CREATE INDEX IF NOT EXISTS idx_trips_vehicle_schedule ON trips(vehicle_id, planned_start, planned_end);
+CREATE INDEX IF NOT EXISTS idx_trips_driver_schedule ON trips(driver_id, planned_start, planned_end);
+CREATE INDEX IF NOT EXISTS idx_trips_co_driver_id ON trips(co_driver_id);
+CREATE INDEX IF NOT EXISTS idx_trips_status ON trips(status);
+
+CREATE TRIGGER update_trips_updated_at BEFORE UPDATE ON trips
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create trip_stops table (ordered pickups and deliveries of a trip)
+CREATE TABLE IF NOT EXISTS trip_stops (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trip_id UUID NOT NULL REFERENCES trips(id) ON DELETE CASCADE,
+    sequence INTEGER NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    shipment_id UUID NOT NULL REFERENCES shipments(id),
+    location_id UUID NOT NULL REFERENCES locations(id),
+    planned_arrival TIMESTAMP,
+    UNIQUE (trip_id, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_trip_stops_shipment_id ON trip_stops(shipment_id);
@@ -0,0 +1,10 @@
+-- Drop diesel_prices
+DROP TRIGGER IF EXISTS update_diesel_prices_updated_at ON diesel_prices;
+DROP INDEX IF EXISTS idx_diesel_prices_effective_date;
+DROP TABLE IF EXISTS diesel_prices;
+
+-- Drop rate_cards
+DROP TRIGGER IF EXISTS update_rate_cards_updated_at ON rate_cards;
+DROP INDEX IF EXISTS idx_rate_cards_effective;
+DROP INDEX IF EXISTS idx_rate_cards_version;
+DROP TABLE IF EXISTS rate_cards;
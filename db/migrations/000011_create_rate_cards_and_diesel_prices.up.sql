@@ -0,0 +1,37 @@
+-- Create rate_cards table: one row per immutable version of a tariff.
+-- organization_id NULL is the standard tariff used when a customer has no card of its own.
+CREATE TABLE IF NOT EXISTS rate_cards (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID REFERENCES organizations(id),
+    name VARCHAR(255) NOT NULL,
+    version INTEGER NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'THB',
+    effective_from TIMESTAMP NOT NULL,
+    effective_to TIMESTAMP,
+    volumetric_factor DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rules JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP
+);
+
+-- Versions are numbered per card name within an organization (or the standard tariff)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_rate_cards_version
+    ON rate_cards(COALESCE(organization_id, '00000000-0000-0000-0000-000000000000'::uuid), name, version);
+CREATE INDEX IF NOT EXISTS idx_rate_cards_effective ON rate_cards(organization_id, effective_from);
+
+CREATE TRIGGER update_rate_cards_updated_at BEFORE UPDATE ON rate_cards
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create diesel_prices table: reference price for fuel surcharges
+CREATE TABLE IF NOT EXISTS diesel_prices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    effective_date DATE NOT NULL,
+    price_per_litre DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_diesel_prices_effective_date ON diesel_prices(effective_date);
+
+CREATE TRIGGER update_diesel_prices_updated_at BEFORE UPDATE ON diesel_prices
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,26 @@
+-- Drop tender_events
+DROP TABLE IF EXISTS tender_events;
+
+-- Drop tender_offers
+DROP INDEX IF EXISTS idx_tender_offers_deadline;
+DROP INDEX IF EXISTS idx_tender_offers_carrier_id;
+DROP TABLE IF EXISTS tender_offers;
+
+-- Drop tenders
+DROP TRIGGER IF EXISTS update_tenders_updated_at ON tenders;
+DROP INDEX IF EXISTS idx_tenders_awarded_carrier_id;
+DROP INDEX IF EXISTS idx_tenders_status;
+DROP INDEX IF EXISTS idx_tenders_live_trip;
+DROP INDEX IF EXISTS idx_tenders_live_shipment;
+DROP TABLE IF EXISTS tenders;
+
+-- Drop carrier_users
+DROP INDEX IF EXISTS idx_carrier_users_user_id;
+DROP TABLE IF EXISTS carrier_users;
+
+-- Drop carriers
+DROP TRIGGER IF EXISTS update_carriers_updated_at ON carriers;
+DROP INDEX IF EXISTS idx_carriers_deleted_at;
+DROP INDEX IF EXISTS idx_carriers_status;
+DROP INDEX IF EXISTS idx_carriers_tax_id;
+DROP TABLE IF EXISTS carriers;
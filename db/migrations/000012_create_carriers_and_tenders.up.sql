@@ -0,0 +1,97 @@
+-- Create carriers table (subcontracted transport companies)
+CREATE TABLE IF NOT EXISTS carriers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    tax_id VARCHAR(13),
+    branch_code VARCHAR(5) NOT NULL DEFAULT '00000',
+    contact_name VARCHAR(255),
+    contact_phone VARCHAR(20),
+    contact_email VARCHAR(255),
+    fleet_types JSONB NOT NULL DEFAULT '[]',
+    service_areas JSONB NOT NULL DEFAULT '[]',
+    insurance_expiry DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_carriers_tax_id ON carriers(tax_id);
+CREATE INDEX IF NOT EXISTS idx_carriers_status ON carriers(status);
+CREATE INDEX IF NOT EXISTS idx_carriers_deleted_at ON carriers(deleted_at);
+
+CREATE TRIGGER update_carriers_updated_at BEFORE UPDATE ON carriers
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create carrier_users table (user accounts acting for a carrier)
+CREATE TABLE IF NOT EXISTS carrier_users (
+    carrier_id UUID NOT NULL REFERENCES carriers(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (carrier_id, user_id)
+);
+
+-- A user acts for at most one carrier
+CREATE UNIQUE INDEX IF NOT EXISTS idx_carrier_users_user_id ON carrier_users(user_id);
+
+-- Create tenders table (a shipment or trip offered to carriers in rank order)
+CREATE TABLE IF NOT EXISTS tenders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shipment_id UUID REFERENCES shipments(id),
+    trip_id UUID REFERENCES trips(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    response_window_seconds INTEGER NOT NULL,
+    vehicle_type VARCHAR(10),
+    origin_province VARCHAR(100),
+    destination_province VARCHAR(100),
+    pickup_at TIMESTAMP,
+    deliver_by TIMESTAMP,
+    weight_kg DOUBLE PRECISION NOT NULL DEFAULT 0,
+    volume_m3 DOUBLE PRECISION NOT NULL DEFAULT 0,
+    pallets INTEGER NOT NULL DEFAULT 0,
+    notes TEXT,
+    awarded_carrier_id UUID REFERENCES carriers(id),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP,
+    CHECK ((shipment_id IS NULL) <> (trip_id IS NULL))
+);
+
+-- At most one live tender per shipment or trip
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tenders_live_shipment ON tenders(shipment_id) WHERE status IN ('open', 'accepted');
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tenders_live_trip ON tenders(trip_id) WHERE status IN ('open', 'accepted');
+CREATE INDEX IF NOT EXISTS idx_tenders_status ON tenders(status);
+CREATE INDEX IF NOT EXISTS idx_tenders_awarded_carrier_id ON tenders(awarded_carrier_id);
+
+CREATE TRIGGER update_tenders_updated_at BEFORE UPDATE ON tenders
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create tender_offers table (one row per carrier in the waterfall)
+CREATE TABLE IF NOT EXISTS tender_offers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUID NOT NULL REFERENCES tenders(id) ON DELETE CASCADE,
+    carrier_id UUID NOT NULL REFERENCES carriers(id),
+    rank INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    offered_at TIMESTAMP,
+    deadline TIMESTAMP,
+    responded_at TIMESTAMP,
+    reason TEXT,
+    UNIQUE (tender_id, rank),
+    UNIQUE (tender_id, carrier_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tender_offers_carrier_id ON tender_offers(carrier_id);
+-- Sweeps for offers past their deadline
+CREATE INDEX IF NOT EXISTS idx_tender_offers_deadline ON tender_offers(deadline) WHERE status = 'offered';
+
+-- Create tender_events table (append-only log of tender state changes)
+CREATE TABLE IF NOT EXISTS tender_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tender_id UUID NOT NULL REFERENCES tenders(id) ON DELETE CASCADE,
+    sequence INTEGER NOT NULL,
+    carrier_id UUID REFERENCES carriers(id),
+    type VARCHAR(20) NOT NULL,
+    note TEXT,
+    occurred_at TIMESTAMP NOT NULL,
+    UNIQUE (tender_id, sequence)
+);
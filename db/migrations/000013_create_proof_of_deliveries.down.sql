@@ -0,0 +1,5 @@
+-- Drop proof_of_deliveries
+DROP INDEX IF EXISTS idx_proof_of_deliveries_shipment_id;
+DROP INDEX IF EXISTS idx_proof_of_deliveries_trip_id;
+DROP INDEX IF EXISTS idx_proof_of_deliveries_stop_id;
+DROP TABLE IF EXISTS proof_of_deliveries;
@@ -0,0 +1,23 @@
+-- Create proof_of_deliveries table (signature, photos and position captured at a delivery stop)
+CREATE TABLE IF NOT EXISTS proof_of_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trip_id UUID NOT NULL REFERENCES trips(id),
+    stop_id UUID NOT NULL REFERENCES trip_stops(id),
+    shipment_id UUID NOT NULL REFERENCES shipments(id),
+    driver_id UUID NOT NULL REFERENCES drivers(id),
+    recipient_name VARCHAR(255) NOT NULL,
+    signature_key VARCHAR(512) NOT NULL,
+    photo_keys JSONB NOT NULL DEFAULT '[]',
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    accuracy_m DOUBLE PRECISION,
+    distance_m DOUBLE PRECISION,
+    captured_at TIMESTAMP NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- A stop is delivered once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_proof_of_deliveries_stop_id ON proof_of_deliveries(stop_id);
+CREATE INDEX IF NOT EXISTS idx_proof_of_deliveries_trip_id ON proof_of_deliveries(trip_id);
+CREATE INDEX IF NOT EXISTS idx_proof_of_deliveries_shipment_id ON proof_of_deliveries(shipment_id);
@@ -0,0 +1,6 @@
+-- Drop vehicle_positions
+DROP TABLE IF EXISTS vehicle_positions;
+
+-- Drop vehicles.gps_device_id
+DROP INDEX IF EXISTS idx_vehicles_gps_device_id;
+ALTER TABLE vehicles DROP COLUMN IF EXISTS gps_device_id;
@@ -0,0 +1,19 @@
+-- Telematics unit fitted to each vehicle
+ALTER TABLE vehicles ADD COLUMN IF NOT EXISTS gps_device_id VARCHAR(64);
+
+-- A device reports for one vehicle at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vehicles_gps_device_id ON vehicles(gps_device_id) WHERE deleted_at IS NULL;
+
+-- Create vehicle_positions table (GPS history; one fix per vehicle and timestamp)
+CREATE TABLE IF NOT EXISTS vehicle_positions (
+    vehicle_id UUID NOT NULL REFERENCES vehicles(id),
+    recorded_at TIMESTAMP NOT NULL,
+    device_id VARCHAR(64),
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    speed_kmh DOUBLE PRECISION,
+    heading DOUBLE PRECISION,
+    odometer_km DOUBLE PRECISION,
+    received_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (vehicle_id, recorded_at)
+);
@@ -0,0 +1,15 @@
+-- Drop geofence_events
+DROP INDEX IF EXISTS idx_geofence_events_trip_id;
+DROP INDEX IF EXISTS idx_geofence_events_geofence_id;
+DROP INDEX IF EXISTS idx_geofence_events_vehicle_id;
+DROP TABLE IF EXISTS geofence_events;
+
+-- Drop geofences
+DROP INDEX IF EXISTS idx_geofences_deleted_at;
+DROP INDEX IF EXISTS idx_geofences_location_id;
+DROP TABLE IF EXISTS geofences;
+
+-- Drop trip_stops progress columns
+ALTER TABLE trip_stops DROP COLUMN IF EXISTS departed_at;
+ALTER TABLE trip_stops DROP COLUMN IF EXISTS arrived_at;
+ALTER TABLE trip_stops DROP COLUMN IF EXISTS status;
@@ -0,0 +1,44 @@
+-- Track the vehicle's progress through each trip stop
+ALTER TABLE trip_stops ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'pending';
+ALTER TABLE trip_stops ADD COLUMN IF NOT EXISTS arrived_at TIMESTAMP;
+ALTER TABLE trip_stops ADD COLUMN IF NOT EXISTS departed_at TIMESTAMP;
+
+-- Create geofences table (circle or polygon around a location)
+CREATE TABLE IF NOT EXISTS geofences (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    location_id UUID NOT NULL REFERENCES locations(id),
+    name VARCHAR(255) NOT NULL,
+    shape VARCHAR(20) NOT NULL,
+    center_lat DOUBLE PRECISION,
+    center_lng DOUBLE PRECISION,
+    radius_m DOUBLE PRECISION,
+    polygon JSONB NOT NULL DEFAULT '[]',
+    dwell_seconds INTEGER NOT NULL DEFAULT 120,
+    exit_buffer_m DOUBLE PRECISION NOT NULL DEFAULT 30,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_geofences_location_id ON geofences(location_id);
+CREATE INDEX IF NOT EXISTS idx_geofences_deleted_at ON geofences(deleted_at);
+
+-- Create geofence_events table (enter, dwell and exit of vehicles)
+CREATE TABLE IF NOT EXISTS geofence_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    geofence_id UUID NOT NULL REFERENCES geofences(id),
+    location_id UUID NOT NULL REFERENCES locations(id),
+    vehicle_id UUID NOT NULL REFERENCES vehicles(id),
+    trip_id UUID REFERENCES trips(id),
+    stop_id UUID REFERENCES trip_stops(id) ON DELETE SET NULL,
+    type VARCHAR(20) NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_geofence_events_vehicle_id ON geofence_events(vehicle_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_geofence_events_geofence_id ON geofence_events(geofence_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_geofence_events_trip_id ON geofence_events(trip_id);
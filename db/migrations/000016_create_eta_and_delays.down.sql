@@ -0,0 +1,11 @@
+-- Drop stop_delays
+DROP INDEX IF EXISTS idx_stop_delays_trip_id;
+DROP TABLE IF EXISTS stop_delays;
+
+-- Drop lane_speeds
+DROP TABLE IF EXISTS lane_speeds;
+
+-- Drop trip_stops ETA columns
+ALTER TABLE trip_stops DROP COLUMN IF EXISTS delayed_at;
+ALTER TABLE trip_stops DROP COLUMN IF EXISTS eta;
+ALTER TABLE trip_stops DROP COLUMN IF EXISTS service_minutes;
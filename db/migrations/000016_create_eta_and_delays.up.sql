@@ -0,0 +1,29 @@
+-- Planned service time and predicted arrival of each trip stop
+ALTER TABLE trip_stops ADD COLUMN IF NOT EXISTS service_minutes INTEGER;
+ALTER TABLE trip_stops ADD COLUMN IF NOT EXISTS eta TIMESTAMP;
+ALTER TABLE trip_stops ADD COLUMN IF NOT EXISTS delayed_at TIMESTAMP;
+
+-- Create lane_speeds table (observed average speed between provinces by departure hour)
+CREATE TABLE IF NOT EXISTS lane_speeds (
+    origin_province VARCHAR(100) NOT NULL,
+    destination_province VARCHAR(100) NOT NULL,
+    hour SMALLINT NOT NULL CHECK (hour BETWEEN 0 AND 23),
+    samples INTEGER NOT NULL,
+    speed_kmh DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (origin_province, destination_province, hour)
+);
+
+-- Create stop_delays table (predicted arrivals that miss the stop's time window)
+CREATE TABLE IF NOT EXISTS stop_delays (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trip_id UUID NOT NULL REFERENCES trips(id),
+    stop_id UUID NOT NULL REFERENCES trip_stops(id) ON DELETE CASCADE,
+    shipment_id UUID NOT NULL REFERENCES shipments(id),
+    vehicle_id UUID NOT NULL REFERENCES vehicles(id),
+    eta TIMESTAMP NOT NULL,
+    window_end TIMESTAMP NOT NULL,
+    detected_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_stop_delays_trip_id ON stop_delays(trip_id);
@@ -0,0 +1,7 @@
+-- Drop shipment_status_history
+DROP INDEX IF EXISTS idx_shipment_status_history_shipment_id;
+DROP TABLE IF EXISTS shipment_status_history;
+
+-- Drop shipments tracking number
+DROP INDEX IF EXISTS idx_shipments_tracking_number;
+ALTER TABLE shipments DROP COLUMN IF EXISTS tracking_number;
@@ -0,0 +1,16 @@
+-- Public tracking number of each shipment; existing shipments get one derived from their ID
+ALTER TABLE shipments ADD COLUMN IF NOT EXISTS tracking_number VARCHAR(32);
+UPDATE shipments SET tracking_number = 'TH' || upper(substr(md5(id::text), 1, 10)) WHERE tracking_number IS NULL;
+ALTER TABLE shipments ALTER COLUMN tracking_number SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shipments_tracking_number ON shipments(tracking_number);
+
+-- Create shipment_status_history table (every status a shipment moved to after it was created)
+CREATE TABLE IF NOT EXISTS shipment_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shipment_id UUID NOT NULL REFERENCES shipments(id),
+    status VARCHAR(20) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipment_status_history_shipment_id ON shipment_status_history(shipment_id, changed_at);
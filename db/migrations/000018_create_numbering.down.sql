@@ -0,0 +1,13 @@
+-- Drop proof_of_deliveries document number
+DROP INDEX IF EXISTS idx_proof_of_deliveries_document_number;
+ALTER TABLE proof_of_deliveries DROP COLUMN IF EXISTS document_number;
+
+-- Drop trips trip number
+DROP INDEX IF EXISTS idx_trips_trip_number;
+ALTER TABLE trips DROP COLUMN IF EXISTS trip_number;
+
+-- Drop number_sequences
+DROP TABLE IF EXISTS number_sequences;
+
+-- Drop numbering_formats
+DROP TABLE IF EXISTS numbering_formats;
@@ -0,0 +1,43 @@
+-- Create numbering_formats table (an organization's own number format per document type)
+CREATE TABLE IF NOT EXISTS numbering_formats (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    document_type VARCHAR(30) NOT NULL,
+    format VARCHAR(100) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (organization_id, document_type)
+);
+
+-- Create number_sequences table (last value issued per organization, document type and period).
+-- Default formats count under the nil organization ID; the period is empty for sequences that never restart.
+CREATE TABLE IF NOT EXISTS number_sequences (
+    organization_id UUID NOT NULL,
+    document_type VARCHAR(30) NOT NULL,
+    period VARCHAR(10) NOT NULL DEFAULT '',
+    last_value BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (organization_id, document_type, period)
+);
+
+-- Trip numbers; existing trips are numbered in the order they were created
+ALTER TABLE trips ADD COLUMN IF NOT EXISTS trip_number VARCHAR(40);
+UPDATE trips SET trip_number = numbered.trip_number
+FROM (
+    SELECT id, 'TRP-' || lpad(row_number() OVER (ORDER BY created_at, id)::text, 8, '0') AS trip_number
+    FROM trips
+) AS numbered
+WHERE trips.id = numbered.id AND trips.trip_number IS NULL;
+ALTER TABLE trips ALTER COLUMN trip_number SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_trips_trip_number ON trips(trip_number);
+
+-- Proof of delivery document numbers; existing proofs are numbered in the order they were captured
+ALTER TABLE proof_of_deliveries ADD COLUMN IF NOT EXISTS document_number VARCHAR(40);
+UPDATE proof_of_deliveries SET document_number = numbered.document_number
+FROM (
+    SELECT id, 'POD-' || lpad(row_number() OVER (ORDER BY created_at, id)::text, 8, '0') AS document_number
+    FROM proof_of_deliveries
+) AS numbered
+WHERE proof_of_deliveries.id = numbered.id AND proof_of_deliveries.document_number IS NULL;
+ALTER TABLE proof_of_deliveries ALTER COLUMN document_number SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_proof_of_deliveries_document_number ON proof_of_deliveries(document_number);
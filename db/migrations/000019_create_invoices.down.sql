@@ -0,0 +1,15 @@
+-- Drop invoice_lines
+DROP INDEX IF EXISTS idx_invoice_lines_shipment_id;
+DROP INDEX IF EXISTS idx_invoice_lines_invoice_id;
+DROP TABLE IF EXISTS invoice_lines;
+
+-- Drop invoices
+DROP TRIGGER IF EXISTS update_invoices_updated_at ON invoices;
+DROP INDEX IF EXISTS idx_invoices_credited_invoice_id;
+DROP INDEX IF EXISTS idx_invoices_organization_id;
+DROP INDEX IF EXISTS idx_invoices_number;
+DROP TABLE IF EXISTS invoices;
+
+-- Drop billing terms of customers
+ALTER TABLE organizations DROP COLUMN IF EXISTS withholds_tax;
+ALTER TABLE organizations DROP COLUMN IF EXISTS credit_term_days;
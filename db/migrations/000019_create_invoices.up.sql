@@ -0,0 +1,58 @@
+-- Billing terms of customers
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS credit_term_days INTEGER;
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS withholds_tax BOOLEAN NOT NULL DEFAULT true;
+
+-- Create invoices table (invoices and the credit notes that correct them)
+CREATE TABLE IF NOT EXISTS invoices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    number VARCHAR(40),
+    kind VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    organization_id UUID NOT NULL REFERENCES organizations(id),
+    credited_invoice_id UUID REFERENCES invoices(id),
+    period_from DATE NOT NULL,
+    period_to DATE NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    credit_term_days INTEGER NOT NULL DEFAULT 0,
+    issue_date DATE,
+    due_date DATE,
+    subtotal NUMERIC(14,2) NOT NULL DEFAULT 0,
+    vat_rate NUMERIC(5,4) NOT NULL,
+    vat_amount NUMERIC(14,2) NOT NULL DEFAULT 0,
+    total NUMERIC(14,2) NOT NULL DEFAULT 0,
+    withholding_rate NUMERIC(5,4) NOT NULL DEFAULT 0,
+    withholding_tax NUMERIC(14,2) NOT NULL DEFAULT 0,
+    amount_due NUMERIC(14,2) NOT NULL DEFAULT 0,
+    reason TEXT,
+    notes TEXT,
+    paid_at TIMESTAMP,
+    payment_reference VARCHAR(100),
+    voided_at TIMESTAMP,
+    void_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- Numbers are unique once issued; drafts have none
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_number ON invoices(number) WHERE number IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_invoices_organization_id ON invoices(organization_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_invoices_credited_invoice_id ON invoices(credited_invoice_id);
+
+CREATE TRIGGER update_invoices_updated_at BEFORE UPDATE ON invoices
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create invoice_lines table
+CREATE TABLE IF NOT EXISTS invoice_lines (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    sequence INTEGER NOT NULL,
+    shipment_id UUID REFERENCES shipments(id),
+    service_date TIMESTAMP,
+    description VARCHAR(500) NOT NULL,
+    quantity NUMERIC(14,3) NOT NULL,
+    unit_price NUMERIC(14,2) NOT NULL,
+    amount NUMERIC(14,2) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoice_lines_invoice_id ON invoice_lines(invoice_id, sequence);
+CREATE INDEX IF NOT EXISTS idx_invoice_lines_shipment_id ON invoice_lines(shipment_id);
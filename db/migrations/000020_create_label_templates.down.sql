@@ -0,0 +1,6 @@
+-- Drop shipment_pieces
+DROP INDEX IF EXISTS idx_shipment_pieces_sscc;
+DROP TABLE IF EXISTS shipment_pieces;
+
+-- Drop label_templates
+DROP TABLE IF EXISTS label_templates;
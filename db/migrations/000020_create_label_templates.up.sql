@@ -0,0 +1,22 @@
+-- Create label_templates table (how an organization's shipping labels are printed)
+CREATE TABLE IF NOT EXISTS label_templates (
+    organization_id UUID PRIMARY KEY REFERENCES organizations(id) ON DELETE CASCADE,
+    size VARCHAR(10) NOT NULL,
+    dpi INTEGER NOT NULL,
+    zpl_font VARCHAR(100) NOT NULL DEFAULT '',
+    gs1_company_prefix VARCHAR(10) NOT NULL DEFAULT '',
+    sscc_extension SMALLINT NOT NULL DEFAULT 0,
+    footer VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- Create shipment_pieces table (labelled handling units with the SSCC printed on their label)
+CREATE TABLE IF NOT EXISTS shipment_pieces (
+    shipment_id UUID NOT NULL REFERENCES shipments(id) ON DELETE CASCADE,
+    piece INTEGER NOT NULL,
+    sscc VARCHAR(18) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (shipment_id, piece)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shipment_pieces_sscc ON shipment_pieces(sscc) WHERE sscc <> '';
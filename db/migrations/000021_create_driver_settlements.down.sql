@@ -0,0 +1,19 @@
+-- Drop driver_settlement_adjustments
+DROP INDEX IF EXISTS idx_driver_settlement_adjustments_settlement_id;
+DROP TABLE IF EXISTS driver_settlement_adjustments;
+
+-- Drop driver_settlement_lines
+DROP INDEX IF EXISTS idx_driver_settlement_lines_trip_id;
+DROP INDEX IF EXISTS idx_driver_settlement_lines_settlement_id;
+DROP TABLE IF EXISTS driver_settlement_lines;
+
+-- Drop driver_settlements
+DROP TRIGGER IF EXISTS update_driver_settlements_updated_at ON driver_settlements;
+DROP INDEX IF EXISTS idx_driver_settlements_driver_id;
+DROP INDEX IF EXISTS idx_driver_settlements_number;
+DROP TABLE IF EXISTS driver_settlements;
+
+-- Drop driver_pay_rules
+DROP TRIGGER IF EXISTS update_driver_pay_rules_updated_at ON driver_pay_rules;
+DROP INDEX IF EXISTS idx_driver_pay_rules_effective;
+DROP TABLE IF EXISTS driver_pay_rules;
@@ -0,0 +1,88 @@
+-- Create driver_pay_rules table (what a driver earns per trip from a date on, optionally per vehicle type)
+CREATE TABLE IF NOT EXISTS driver_pay_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    vehicle_type VARCHAR(10) NOT NULL DEFAULT '',
+    base_per_trip NUMERIC(12,2) NOT NULL DEFAULT 0,
+    per_km NUMERIC(12,2) NOT NULL DEFAULT 0,
+    per_drop NUMERIC(12,2) NOT NULL DEFAULT 0,
+    overnight_allowance NUMERIC(12,2) NOT NULL DEFAULT 0,
+    overtime_rate NUMERIC(12,2) NOT NULL DEFAULT 0,
+    standard_hours NUMERIC(5,2) NOT NULL DEFAULT 0,
+    effective_from TIMESTAMP NOT NULL,
+    effective_to TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_driver_pay_rules_effective ON driver_pay_rules(vehicle_type, effective_from);
+
+CREATE TRIGGER update_driver_pay_rules_updated_at BEFORE UPDATE ON driver_pay_rules
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create driver_settlements table (a driver's pay statement for a period)
+CREATE TABLE IF NOT EXISTS driver_settlements (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    number VARCHAR(40),
+    driver_id UUID NOT NULL REFERENCES drivers(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    period_from DATE NOT NULL,
+    period_to DATE NOT NULL,
+    gross_pay NUMERIC(14,2) NOT NULL DEFAULT 0,
+    advances NUMERIC(14,2) NOT NULL DEFAULT 0,
+    deductions NUMERIC(14,2) NOT NULL DEFAULT 0,
+    net_pay NUMERIC(14,2) NOT NULL DEFAULT 0,
+    notes TEXT,
+    approved_at TIMESTAMP,
+    approved_by UUID REFERENCES users(id),
+    voided_at TIMESTAMP,
+    void_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- Numbers are unique once approved; drafts have none
+CREATE UNIQUE INDEX IF NOT EXISTS idx_driver_settlements_number ON driver_settlements(number) WHERE number IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_driver_settlements_driver_id ON driver_settlements(driver_id, period_from);
+
+CREATE TRIGGER update_driver_settlements_updated_at BEFORE UPDATE ON driver_settlements
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create driver_settlement_lines table (the pay for one trip)
+CREATE TABLE IF NOT EXISTS driver_settlement_lines (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    settlement_id UUID NOT NULL REFERENCES driver_settlements(id) ON DELETE CASCADE,
+    sequence INTEGER NOT NULL,
+    trip_id UUID NOT NULL REFERENCES trips(id),
+    trip_number VARCHAR(40) NOT NULL,
+    co_driver BOOLEAN NOT NULL DEFAULT false,
+    vehicle_type VARCHAR(10),
+    pay_rule_id UUID NOT NULL REFERENCES driver_pay_rules(id),
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP NOT NULL,
+    distance_km NUMERIC(10,1) NOT NULL DEFAULT 0,
+    drops INTEGER NOT NULL DEFAULT 0,
+    overnights INTEGER NOT NULL DEFAULT 0,
+    overtime_hours NUMERIC(6,2) NOT NULL DEFAULT 0,
+    base_pay NUMERIC(12,2) NOT NULL DEFAULT 0,
+    distance_pay NUMERIC(12,2) NOT NULL DEFAULT 0,
+    drop_pay NUMERIC(12,2) NOT NULL DEFAULT 0,
+    overnight_pay NUMERIC(12,2) NOT NULL DEFAULT 0,
+    overtime_pay NUMERIC(12,2) NOT NULL DEFAULT 0,
+    amount NUMERIC(12,2) NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_driver_settlement_lines_settlement_id ON driver_settlement_lines(settlement_id, sequence);
+CREATE INDEX IF NOT EXISTS idx_driver_settlement_lines_trip_id ON driver_settlement_lines(trip_id);
+
+-- Create driver_settlement_adjustments table (advances and deductions)
+CREATE TABLE IF NOT EXISTS driver_settlement_adjustments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    settlement_id UUID NOT NULL REFERENCES driver_settlements(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    description VARCHAR(500) NOT NULL,
+    amount NUMERIC(12,2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_driver_settlement_adjustments_settlement_id ON driver_settlement_adjustments(settlement_id);
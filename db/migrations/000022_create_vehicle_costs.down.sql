@@ -0,0 +1,14 @@
+-- Drop vehicle_expenses
+DROP TRIGGER IF EXISTS update_vehicle_expenses_updated_at ON vehicle_expenses;
+DROP INDEX IF EXISTS idx_vehicle_expenses_incurred_at;
+DROP INDEX IF EXISTS idx_vehicle_expenses_vehicle_id;
+DROP TABLE IF EXISTS vehicle_expenses;
+
+-- Drop fuel_logs
+DROP TRIGGER IF EXISTS update_fuel_logs_updated_at ON fuel_logs;
+DROP INDEX IF EXISTS idx_fuel_logs_filled_at;
+DROP INDEX IF EXISTS idx_fuel_logs_vehicle_id;
+DROP TABLE IF EXISTS fuel_logs;
+
+-- Drop vehicles fuel baseline
+ALTER TABLE vehicles DROP COLUMN IF EXISTS fuel_baseline_km_per_liter;
@@ -0,0 +1,56 @@
+-- Expected fuel efficiency of a vehicle; zero uses the default for its type
+ALTER TABLE vehicles ADD COLUMN IF NOT EXISTS fuel_baseline_km_per_liter NUMERIC(6,2) NOT NULL DEFAULT 0;
+
+-- Create fuel_logs table (fuel fill-ups, rated on full tanks)
+CREATE TABLE IF NOT EXISTS fuel_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    vehicle_id UUID NOT NULL REFERENCES vehicles(id),
+    driver_id UUID REFERENCES drivers(id),
+    filled_at TIMESTAMP NOT NULL,
+    liters NUMERIC(10,2) NOT NULL,
+    price_per_liter NUMERIC(10,2) NOT NULL,
+    amount NUMERIC(12,2) NOT NULL,
+    odometer_km NUMERIC(10,1) NOT NULL,
+    station VARCHAR(255),
+    full_tank BOOLEAN NOT NULL DEFAULT true,
+    receipt_key VARCHAR(500),
+    notes TEXT,
+    distance_km NUMERIC(10,1) NOT NULL DEFAULT 0,
+    efficiency_liters NUMERIC(10,2) NOT NULL DEFAULT 0,
+    km_per_liter NUMERIC(6,2),
+    baseline_km_per_liter NUMERIC(6,2) NOT NULL DEFAULT 0,
+    deviation NUMERIC(6,3),
+    flagged BOOLEAN NOT NULL DEFAULT false,
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_fuel_logs_vehicle_id ON fuel_logs(vehicle_id, filled_at);
+CREATE INDEX IF NOT EXISTS idx_fuel_logs_filled_at ON fuel_logs(filled_at);
+
+CREATE TRIGGER update_fuel_logs_updated_at BEFORE UPDATE ON fuel_logs
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create vehicle_expenses table (tolls, repairs, fines and other running costs)
+CREATE TABLE IF NOT EXISTS vehicle_expenses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    vehicle_id UUID NOT NULL REFERENCES vehicles(id),
+    driver_id UUID REFERENCES drivers(id),
+    trip_id UUID REFERENCES trips(id),
+    type VARCHAR(20) NOT NULL,
+    incurred_at TIMESTAMP NOT NULL,
+    amount NUMERIC(12,2) NOT NULL,
+    description TEXT,
+    reference VARCHAR(100),
+    receipt_key VARCHAR(500),
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vehicle_expenses_vehicle_id ON vehicle_expenses(vehicle_id, incurred_at);
+CREATE INDEX IF NOT EXISTS idx_vehicle_expenses_incurred_at ON vehicle_expenses(incurred_at);
+
+CREATE TRIGGER update_vehicle_expenses_updated_at BEFORE UPDATE ON vehicle_expenses
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,20 @@
+-- Drop maintenance_schedules
+DROP TRIGGER IF EXISTS update_maintenance_schedules_updated_at ON maintenance_schedules;
+DROP INDEX IF EXISTS idx_maintenance_schedules_status;
+DROP INDEX IF EXISTS idx_maintenance_schedules_vehicle_plan;
+DROP TABLE IF EXISTS maintenance_schedules;
+
+-- Drop work_order_parts
+DROP INDEX IF EXISTS idx_work_order_parts_work_order_id;
+DROP TABLE IF EXISTS work_order_parts;
+
+-- Drop work_orders
+DROP TRIGGER IF EXISTS update_work_orders_updated_at ON work_orders;
+DROP INDEX IF EXISTS idx_work_orders_vehicle_id;
+DROP INDEX IF EXISTS idx_work_orders_number;
+DROP TABLE IF EXISTS work_orders;
+
+-- Drop maintenance_plans
+DROP TRIGGER IF EXISTS update_maintenance_plans_updated_at ON maintenance_plans;
+DROP INDEX IF EXISTS idx_maintenance_plans_vehicle_type;
+DROP TABLE IF EXISTS maintenance_plans;
@@ -0,0 +1,83 @@
+-- Create maintenance_plans table (preventive services per vehicle type, by distance, time or both)
+CREATE TABLE IF NOT EXISTS maintenance_plans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    vehicle_type VARCHAR(10) NOT NULL,
+    interval_km NUMERIC(10,1) NOT NULL DEFAULT 0,
+    interval_days INTEGER NOT NULL DEFAULT 0,
+    description TEXT,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_maintenance_plans_vehicle_type ON maintenance_plans(vehicle_type) WHERE active;
+
+CREATE TRIGGER update_maintenance_plans_updated_at BEFORE UPDATE ON maintenance_plans
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create work_orders table (maintenance and repair work on a vehicle)
+CREATE TABLE IF NOT EXISTS work_orders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    number VARCHAR(40) NOT NULL,
+    vehicle_id UUID NOT NULL REFERENCES vehicles(id),
+    plan_id UUID REFERENCES maintenance_plans(id),
+    type VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    workshop VARCHAR(255),
+    description TEXT,
+    odometer_km NUMERIC(10,1) NOT NULL DEFAULT 0,
+    parts_cost NUMERIC(12,2) NOT NULL DEFAULT 0,
+    labor_cost NUMERIC(12,2) NOT NULL DEFAULT 0,
+    total_cost NUMERIC(12,2) NOT NULL DEFAULT 0,
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    downtime_hours NUMERIC(8,2) NOT NULL DEFAULT 0,
+    notes TEXT,
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_work_orders_number ON work_orders(number);
+CREATE INDEX IF NOT EXISTS idx_work_orders_vehicle_id ON work_orders(vehicle_id, created_at);
+
+CREATE TRIGGER update_work_orders_updated_at BEFORE UPDATE ON work_orders
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create work_order_parts table (parts fitted under a work order)
+CREATE TABLE IF NOT EXISTS work_order_parts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    work_order_id UUID NOT NULL REFERENCES work_orders(id) ON DELETE CASCADE,
+    sequence INTEGER NOT NULL,
+    part_number VARCHAR(100),
+    name VARCHAR(255) NOT NULL,
+    quantity NUMERIC(10,2) NOT NULL,
+    unit_cost NUMERIC(12,2) NOT NULL,
+    amount NUMERIC(12,2) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_work_order_parts_work_order_id ON work_order_parts(work_order_id, sequence);
+
+-- Create maintenance_schedules table (when each vehicle is next due for each plan)
+CREATE TABLE IF NOT EXISTS maintenance_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    vehicle_id UUID NOT NULL REFERENCES vehicles(id),
+    plan_id UUID NOT NULL REFERENCES maintenance_plans(id),
+    last_service_at TIMESTAMP NOT NULL,
+    last_service_km NUMERIC(10,1) NOT NULL DEFAULT 0,
+    last_work_order_id UUID REFERENCES work_orders(id),
+    current_km NUMERIC(10,1) NOT NULL DEFAULT 0,
+    next_due_at TIMESTAMP,
+    next_due_km NUMERIC(10,1),
+    status VARCHAR(20) NOT NULL DEFAULT 'ok',
+    checked_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_maintenance_schedules_vehicle_plan ON maintenance_schedules(vehicle_id, plan_id);
+CREATE INDEX IF NOT EXISTS idx_maintenance_schedules_status ON maintenance_schedules(status, next_due_at);
+
+CREATE TRIGGER update_maintenance_schedules_updated_at BEFORE UPDATE ON maintenance_schedules
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,5 @@
+-- Drop compliance_documents
+DROP TRIGGER IF EXISTS update_compliance_documents_updated_at ON compliance_documents;
+DROP INDEX IF EXISTS idx_compliance_documents_expires_on;
+DROP INDEX IF EXISTS idx_compliance_documents_owner;
+DROP TABLE IF EXISTS compliance_documents;
@@ -0,0 +1,23 @@
+-- Create compliance_documents table (expiring permits, policies and certificates of vehicles, drivers and carriers)
+CREATE TABLE IF NOT EXISTS compliance_documents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_type VARCHAR(20) NOT NULL,
+    owner_id UUID NOT NULL,
+    type VARCHAR(40) NOT NULL,
+    number VARCHAR(100),
+    issued_on DATE,
+    expires_on DATE NOT NULL,
+    file_key VARCHAR(512),
+    notes TEXT,
+    reminded_days INTEGER,
+    reminded_at TIMESTAMP,
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_compliance_documents_owner ON compliance_documents(owner_type, owner_id, type, expires_on DESC);
+CREATE INDEX IF NOT EXISTS idx_compliance_documents_expires_on ON compliance_documents(expires_on);
+
+CREATE TRIGGER update_compliance_documents_updated_at BEFORE UPDATE ON compliance_documents
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,12 @@
+-- Drop dock_appointments
+DROP TRIGGER IF EXISTS update_dock_appointments_updated_at ON dock_appointments;
+DROP INDEX IF EXISTS idx_dock_appointments_carrier_id;
+DROP INDEX IF EXISTS idx_dock_appointments_trip_id;
+DROP INDEX IF EXISTS idx_dock_appointments_location_start;
+DROP INDEX IF EXISTS idx_dock_appointments_dock_start;
+DROP TABLE IF EXISTS dock_appointments;
+
+-- Drop docks
+DROP TRIGGER IF EXISTS update_docks_updated_at ON docks;
+DROP INDEX IF EXISTS idx_docks_location_id;
+DROP TABLE IF EXISTS docks;
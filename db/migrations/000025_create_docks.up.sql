@@ -0,0 +1,53 @@
+-- Create docks table (loading bays of a site, bookable in slots during their operating hours)
+CREATE TABLE IF NOT EXISTS docks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    location_id UUID NOT NULL REFERENCES locations(id),
+    name VARCHAR(100) NOT NULL,
+    code VARCHAR(50),
+    direction VARCHAR(10) NOT NULL DEFAULT 'both',
+    capacity INTEGER NOT NULL DEFAULT 1,
+    slot_minutes INTEGER NOT NULL DEFAULT 30,
+    operating_hours JSONB NOT NULL DEFAULT '[]',
+    vehicle_types JSONB NOT NULL DEFAULT '[]',
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_docks_location_id ON docks(location_id);
+
+CREATE TRIGGER update_docks_updated_at BEFORE UPDATE ON docks
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create dock_appointments table (inbound and outbound bookings with check-in and check-out times)
+CREATE TABLE IF NOT EXISTS dock_appointments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    dock_id UUID NOT NULL REFERENCES docks(id),
+    location_id UUID NOT NULL REFERENCES locations(id),
+    direction VARCHAR(10) NOT NULL,
+    trip_id UUID REFERENCES trips(id),
+    carrier_id UUID REFERENCES carriers(id),
+    vehicle_type VARCHAR(10),
+    plate_number VARCHAR(20),
+    driver_name VARCHAR(255),
+    reference VARCHAR(100),
+    start_at TIMESTAMP NOT NULL,
+    end_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'booked',
+    checked_in_at TIMESTAMP,
+    checked_out_at TIMESTAMP,
+    cancel_reason TEXT,
+    notes TEXT,
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    CHECK (end_at > start_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dock_appointments_dock_start ON dock_appointments(dock_id, start_at);
+CREATE INDEX IF NOT EXISTS idx_dock_appointments_location_start ON dock_appointments(location_id, start_at);
+CREATE INDEX IF NOT EXISTS idx_dock_appointments_trip_id ON dock_appointments(trip_id);
+CREATE INDEX IF NOT EXISTS idx_dock_appointments_carrier_id ON dock_appointments(carrier_id);
+
+CREATE TRIGGER update_dock_appointments_updated_at BEFORE UPDATE ON dock_appointments
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
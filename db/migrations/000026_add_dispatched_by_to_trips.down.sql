@@ -0,0 +1,3 @@
+-- Drop index and column
+DROP INDEX IF EXISTS idx_trips_dispatched_by;
+ALTER TABLE trips DROP COLUMN IF EXISTS dispatched_by;
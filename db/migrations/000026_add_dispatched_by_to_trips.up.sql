@@ -0,0 +1,4 @@
+-- Add the dispatcher responsible for a trip, set when it is dispatched
+ALTER TABLE trips ADD COLUMN IF NOT EXISTS dispatched_by UUID REFERENCES users(id);
+
+CREATE INDEX IF NOT EXISTS idx_trips_dispatched_by ON trips(dispatched_by);
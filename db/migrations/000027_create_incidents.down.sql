@@ -0,0 +1,8 @@
+-- Drop incidents
+DROP TRIGGER IF EXISTS update_incidents_updated_at ON incidents;
+DROP INDEX IF EXISTS idx_incidents_open_due_at;
+DROP INDEX IF EXISTS idx_incidents_owner_id;
+DROP INDEX IF EXISTS idx_incidents_shipment_id;
+DROP INDEX IF EXISTS idx_incidents_trip_id;
+DROP INDEX IF EXISTS idx_incidents_document_number;
+DROP TABLE IF EXISTS incidents;
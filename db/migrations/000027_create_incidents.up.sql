@@ -0,0 +1,40 @@
+-- Create incidents table (damage, shortage, accidents, breakdowns and refused deliveries on trips and shipments)
+CREATE TABLE IF NOT EXISTS incidents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    document_number VARCHAR(50) NOT NULL,
+    category VARCHAR(30) NOT NULL,
+    severity VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    trip_id UUID REFERENCES trips(id),
+    -- not a foreign key: the stops of a planned trip are replaced when it is re-planned
+    stop_id UUID,
+    shipment_id UUID REFERENCES shipments(id),
+    description TEXT NOT NULL,
+    photo_keys JSONB NOT NULL DEFAULT '[]',
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    location_id UUID REFERENCES locations(id),
+    reported_by UUID NOT NULL REFERENCES users(id),
+    occurred_at TIMESTAMP NOT NULL,
+    owner_id UUID REFERENCES users(id),
+    due_at TIMESTAMP NOT NULL,
+    breached_at TIMESTAMP,
+    resolution TEXT,
+    resolved_by UUID REFERENCES users(id),
+    resolved_at TIMESTAMP,
+    closed_at TIMESTAMP,
+    cancel_reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    CHECK (trip_id IS NOT NULL OR shipment_id IS NOT NULL)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_incidents_document_number ON incidents(document_number);
+CREATE INDEX IF NOT EXISTS idx_incidents_trip_id ON incidents(trip_id);
+CREATE INDEX IF NOT EXISTS idx_incidents_shipment_id ON incidents(shipment_id);
+CREATE INDEX IF NOT EXISTS idx_incidents_owner_id ON incidents(owner_id, status);
+-- Open incidents are swept for SLA breaches
+CREATE INDEX IF NOT EXISTS idx_incidents_open_due_at ON incidents(due_at) WHERE status IN ('open', 'in_progress');
+
+CREATE TRIGGER update_incidents_updated_at BEFORE UPDATE ON incidents
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,12 @@
+-- Drop delivery attempts
+DROP INDEX IF EXISTS idx_delivery_attempts_leg_id;
+DROP INDEX IF EXISTS idx_delivery_attempts_trip_id;
+DROP INDEX IF EXISTS idx_delivery_attempts_shipment_id;
+DROP INDEX IF EXISTS idx_delivery_attempts_stop_id;
+DROP TABLE IF EXISTS delivery_attempts;
+
+-- Drop shipment legs
+DROP INDEX IF EXISTS idx_shipments_parent_id;
+ALTER TABLE shipments DROP COLUMN IF EXISTS attempt;
+ALTER TABLE shipments DROP COLUMN IF EXISTS leg;
+ALTER TABLE shipments DROP COLUMN IF EXISTS parent_id;
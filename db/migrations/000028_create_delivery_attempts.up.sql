@@ -0,0 +1,34 @@
+-- Shipments booked to follow up a failed delivery: another attempt or a return to the pickup location
+ALTER TABLE shipments ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES shipments(id);
+ALTER TABLE shipments ADD COLUMN IF NOT EXISTS leg VARCHAR(20) NOT NULL DEFAULT 'original';
+ALTER TABLE shipments ADD COLUMN IF NOT EXISTS attempt INTEGER NOT NULL DEFAULT 1;
+
+-- A shipment is followed up once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shipments_parent_id ON shipments(parent_id) WHERE parent_id IS NOT NULL;
+
+-- Create delivery_attempts table (failed deliveries with their reason, evidence photo and position)
+CREATE TABLE IF NOT EXISTS delivery_attempts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trip_id UUID NOT NULL REFERENCES trips(id),
+    stop_id UUID NOT NULL REFERENCES trip_stops(id),
+    shipment_id UUID NOT NULL REFERENCES shipments(id),
+    driver_id UUID NOT NULL REFERENCES drivers(id),
+    attempt INTEGER NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    photo_key VARCHAR(512) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    accuracy_m DOUBLE PRECISION,
+    distance_m DOUBLE PRECISION,
+    attempted_at TIMESTAMP NOT NULL,
+    notes TEXT,
+    leg_id UUID NOT NULL REFERENCES shipments(id),
+    leg VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- A stop and a shipment fail once; a failed shipment is carried on by its leg
+CREATE UNIQUE INDEX IF NOT EXISTS idx_delivery_attempts_stop_id ON delivery_attempts(stop_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_delivery_attempts_shipment_id ON delivery_attempts(shipment_id);
+CREATE INDEX IF NOT EXISTS idx_delivery_attempts_trip_id ON delivery_attempts(trip_id);
+CREATE INDEX IF NOT EXISTS idx_delivery_attempts_leg_id ON delivery_attempts(leg_id);
@@ -0,0 +1,4 @@
+-- Drop index and columns
+DROP INDEX IF EXISTS idx_trips_carrier_id;
+ALTER TABLE trips DROP COLUMN IF EXISTS tender_id;
+ALTER TABLE trips DROP COLUMN IF EXISTS carrier_id;
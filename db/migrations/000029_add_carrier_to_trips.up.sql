@@ -0,0 +1,5 @@
+-- Record the carrier a trip was awarded to through a tender; an awarded trip can no longer be re-planned or tendered
+ALTER TABLE trips ADD COLUMN IF NOT EXISTS carrier_id UUID REFERENCES carriers(id);
+ALTER TABLE trips ADD COLUMN IF NOT EXISTS tender_id UUID REFERENCES tenders(id);
+
+CREATE INDEX IF NOT EXISTS idx_trips_carrier_id ON trips(carrier_id);
@@ -0,0 +1,4 @@
+-- Drop notifications
+DROP TRIGGER IF EXISTS update_notifications_updated_at ON notifications;
+DROP INDEX IF EXISTS idx_notifications_pending;
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,22 @@
+-- Create notifications table: the outbox of messages to users, recorded with the change they announce and
+-- delivered on their realtime channels afterwards
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    type VARCHAR(50) NOT NULL,
+    channels JSONB NOT NULL DEFAULT '[]',
+    data JSONB NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- The dispatcher polls for pending notifications that are due
+CREATE INDEX IF NOT EXISTS idx_notifications_pending ON notifications(next_attempt_at) WHERE status = 'pending';
+
+CREATE TRIGGER update_notifications_updated_at BEFORE UPDATE ON notifications
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,2 @@
+-- Drop role from users table
+ALTER TABLE users DROP COLUMN IF EXISTS role;
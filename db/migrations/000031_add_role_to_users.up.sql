@@ -0,0 +1,2 @@
+-- Add role to users table; back-office staff are granted the staff role here, self-registered users stay plain users
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user';
@@ -0,0 +1,56 @@
+package dto
+
+// CarrierRequest represents a request to create or update a carrier
+type CarrierRequest struct {
+	Name            string   `json:"name" validate:"required,max=255"`
+	TaxID           string   `json:"tax_id" validate:"omitempty,len=13,numeric"`
+	BranchCode      string   `json:"branch_code" validate:"omitempty,len=5,numeric"`
+	ContactName     string   `json:"contact_name" validate:"omitempty,max=255"`
+	ContactPhone    string   `json:"contact_phone" validate:"omitempty,max=20"`
+	ContactEmail    string   `json:"contact_email" validate:"omitempty,email"`
+	FleetTypes      []string `json:"fleet_types" validate:"omitempty,dive,oneof=4w 6w 10w 18w"`
+	ServiceAreas    []string `json:"service_areas" validate:"omitempty,dive,required,max=100"`
+	InsuranceExpiry string   `json:"insurance_expiry" validate:"omitempty,datetime=2006-01-02"`
+	Status          string   `json:"status" validate:"omitempty,oneof=active suspended"`
+}
+
+// ListCarriersQuery represents query parameters for listing carriers
+type ListCarriersQuery struct {
+	PaginationQuery
+	Status      string `query:"status" validate:"omitempty,oneof=active suspended"`
+	VehicleType string `query:"vehicle_type" validate:"omitempty,oneof=4w 6w 10w 18w"`
+	Province    string `query:"province" validate:"omitempty,max=100"`
+	Search      string `query:"search" validate:"omitempty,max=100"`
+}
+
+// AddCarrierUserRequest represents a request to let a user account act for a carrier
+type AddCarrierUserRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+}
+
+// CarrierResponse represents carrier information in responses
+type CarrierResponse struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	TaxID           string   `json:"tax_id"`
+	BranchCode      string   `json:"branch_code"`
+	ContactName     string   `json:"contact_name"`
+	ContactPhone    string   `json:"contact_phone"`
+	ContactEmail    string   `json:"contact_email"`
+	FleetTypes      []string `json:"fleet_types"`
+	ServiceAreas    []string `json:"service_areas"`
+	InsuranceExpiry *string  `json:"insurance_expiry"`
+	Insured         bool     `json:"insured"`
+	Status          string   `json:"status"`
+	CreatedAt       string   `json:"created_at"`
+	UpdatedAt       string   `json:"updated_at"`
+}
+
+// CarrierUserResponse represents a user account acting for a carrier
+type CarrierUserResponse struct {
+	UserID      string  `json:"user_id"`
+	FirstName   string  `json:"first_name"`
+	LastName    string  `json:"last_name"`
+	Email       *string `json:"email"`
+	PhoneNumber *string `json:"phone_number"`
+}
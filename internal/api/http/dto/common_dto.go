@@ -0,0 +1,42 @@
+package dto
+
+import "time"
+
+// DateLayout is the layout used for calendar dates in requests and responses
+const DateLayout = "2006-01-02"
+
+// PaginationQuery represents common pagination query parameters
+type PaginationQuery struct {
+	Limit  int `query:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int `query:"offset" validate:"omitempty,min=0"`
+}
+
+// GetLimit returns the requested page size or the default
+func (q PaginationQuery) GetLimit() int {
+	if q.Limit == 0 {
+		return 20
+	}
+	return q.Limit
+}
+
+// ParseTimestamp parses an RFC 3339 timestamp from a request field that has passed the
+// `datetime=2006-01-02T15:04:05Z07:00` validator. An empty value yields nil.
+func ParseTimestamp(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// FormatTimestamp formats an optional timestamp as RFC 3339
+func FormatTimestamp(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
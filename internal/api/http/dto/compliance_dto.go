@@ -0,0 +1,67 @@
+package dto
+
+// ComplianceUploadURLRequest represents a request for a presigned URL to upload the file of a compliance document
+type ComplianceUploadURLRequest struct {
+	OwnerType   string `json:"owner_type" validate:"required,oneof=vehicle driver carrier"`
+	OwnerID     string `json:"owner_id" validate:"required,uuid"`
+	ContentType string `json:"content_type" validate:"required,oneof=application/pdf image/jpeg image/png"`
+}
+
+// CreateComplianceDocumentRequest represents a compliance document to record. The file key is the object_key
+// returned with the upload URL for the same owner.
+type CreateComplianceDocumentRequest struct {
+	OwnerType string `json:"owner_type" validate:"required,oneof=vehicle driver carrier"`
+	OwnerID   string `json:"owner_id" validate:"required,uuid"`
+	Type      string `json:"type" validate:"required,oneof=vehicle_registration compulsory_insurance vehicle_insurance vehicle_tax gps_certification driver_license carrier_license other"`
+	Number    string `json:"number" validate:"omitempty,max=100"`
+	IssuedOn  string `json:"issued_on" validate:"omitempty,datetime=2006-01-02"`
+	ExpiresOn string `json:"expires_on" validate:"required,datetime=2006-01-02"`
+	FileKey   string `json:"file_key" validate:"omitempty,max=512"`
+	Notes     string `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// UpdateComplianceDocumentRequest represents the details of a compliance document to replace
+type UpdateComplianceDocumentRequest struct {
+	Number    string `json:"number" validate:"omitempty,max=100"`
+	IssuedOn  string `json:"issued_on" validate:"omitempty,datetime=2006-01-02"`
+	ExpiresOn string `json:"expires_on" validate:"required,datetime=2006-01-02"`
+	FileKey   string `json:"file_key" validate:"omitempty,max=512"`
+	Notes     string `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// ListComplianceDocumentsQuery represents query parameters for listing compliance documents.
+// expiring_within keeps the documents that count for their owner and expire within as many days, expired ones included.
+type ListComplianceDocumentsQuery struct {
+	PaginationQuery
+	OwnerType      string `query:"owner_type" validate:"omitempty,oneof=vehicle driver carrier"`
+	OwnerID        string `query:"owner_id" validate:"omitempty,uuid"`
+	Type           string `query:"type" validate:"omitempty,oneof=vehicle_registration compulsory_insurance vehicle_insurance vehicle_tax gps_certification driver_license carrier_license other"`
+	ExpiringWithin *int   `query:"expiring_within" validate:"omitempty,min=0,max=3650"`
+}
+
+// ComplianceDocumentResponse represents a compliance document in responses. days_left is negative once expired;
+// reminded_days is the days-before-expiry stage of the last reminder sent, zero once the expiry was announced.
+type ComplianceDocumentResponse struct {
+	ID           string  `json:"id"`
+	OwnerType    string  `json:"owner_type"`
+	OwnerID      string  `json:"owner_id"`
+	Type         string  `json:"type"`
+	Number       string  `json:"number"`
+	IssuedOn     *string `json:"issued_on"`
+	ExpiresOn    string  `json:"expires_on"`
+	DaysLeft     int     `json:"days_left"`
+	Status       string  `json:"status"`
+	FileKey      string  `json:"file_key,omitempty"`
+	FileURL      string  `json:"file_url,omitempty"`
+	Notes        string  `json:"notes"`
+	RemindedDays *int    `json:"reminded_days"`
+	RemindedAt   *string `json:"reminded_at"`
+	CreatedBy    *string `json:"created_by"`
+	CreatedAt    string  `json:"created_at"`
+	UpdatedAt    string  `json:"updated_at"`
+}
+
+// ComplianceReminderResponse represents the outcome of a reminder run
+type ComplianceReminderResponse struct {
+	Sent int `json:"sent"`
+}
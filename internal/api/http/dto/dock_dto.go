@@ -0,0 +1,146 @@
+package dto
+
+// DockRequest represents a dock to create or replace. Operating hours are in local time; a weekday without
+// hours is closed. A zero capacity or slot length uses the defaults of 1 truck and 30 minutes.
+type DockRequest struct {
+	LocationID     string                `json:"location_id" validate:"required,uuid"`
+	Name           string                `json:"name" validate:"required,max=100"`
+	Code           string                `json:"code" validate:"omitempty,max=50"`
+	Direction      string                `json:"direction" validate:"required,oneof=inbound outbound both"`
+	Capacity       int                   `json:"capacity" validate:"omitempty,min=0,max=50"`
+	SlotMinutes    int                   `json:"slot_minutes" validate:"omitempty,min=5,max=480"`
+	OperatingHours []OpeningHoursRequest `json:"operating_hours" validate:"omitempty,dive"`
+	VehicleTypes   []string              `json:"vehicle_types" validate:"omitempty,dive,oneof=4w 6w 10w 18w"`
+	Active         bool                  `json:"active"`
+}
+
+// ListDocksQuery represents query parameters for listing docks. direction keeps the docks serving it.
+type ListDocksQuery struct {
+	PaginationQuery
+	LocationID string `query:"location_id" validate:"omitempty,uuid"`
+	Direction  string `query:"direction" validate:"omitempty,oneof=inbound outbound"`
+	Active     *bool  `query:"active"`
+}
+
+// DockSlotsQuery represents the calendar day to list a dock's slots for
+type DockSlotsQuery struct {
+	Date string `query:"date" validate:"required,datetime=2006-01-02"`
+}
+
+// DockResponse represents a dock in responses
+type DockResponse struct {
+	ID             string                 `json:"id"`
+	LocationID     string                 `json:"location_id"`
+	Name           string                 `json:"name"`
+	Code           string                 `json:"code"`
+	Direction      string                 `json:"direction"`
+	Capacity       int                    `json:"capacity"`
+	SlotMinutes    int                    `json:"slot_minutes"`
+	OperatingHours []OpeningHoursResponse `json:"operating_hours"`
+	VehicleTypes   []string               `json:"vehicle_types"`
+	Active         bool                   `json:"active"`
+	CreatedAt      string                 `json:"created_at"`
+	UpdatedAt      string                 `json:"updated_at"`
+}
+
+// DockSlotResponse represents one slot of a dock and how many trucks are booked in it
+type DockSlotResponse struct {
+	Start     string `json:"start"`
+	End       string `json:"end"`
+	Booked    int    `json:"booked"`
+	Capacity  int    `json:"capacity"`
+	Available bool   `json:"available"`
+}
+
+// BookDockAppointmentRequest represents a dock appointment to book. Without end_at the appointment lasts one slot;
+// the vehicle type and plate default to those of the trip's vehicle.
+type BookDockAppointmentRequest struct {
+	DockID      string `json:"dock_id" validate:"required,uuid"`
+	Direction   string `json:"direction" validate:"required,oneof=inbound outbound"`
+	TripID      string `json:"trip_id" validate:"omitempty,uuid"`
+	VehicleType string `json:"vehicle_type" validate:"omitempty,oneof=4w 6w 10w 18w"`
+	PlateNumber string `json:"plate_number" validate:"omitempty,max=20"`
+	DriverName  string `json:"driver_name" validate:"omitempty,max=255"`
+	Reference   string `json:"reference" validate:"omitempty,max=100"`
+	StartAt     string `json:"start_at" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	EndAt       string `json:"end_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Notes       string `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// RescheduleDockAppointmentRequest represents a new dock or period for a booked appointment.
+// Without dock_id it stays at its dock; without end_at it keeps its length.
+type RescheduleDockAppointmentRequest struct {
+	DockID  string `json:"dock_id" validate:"omitempty,uuid"`
+	StartAt string `json:"start_at" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	EndAt   string `json:"end_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// DockCheckRequest represents the time a truck checked in or out; now when empty
+type DockCheckRequest struct {
+	At string `json:"at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// CancelDockAppointmentRequest represents the reason for cancelling a dock appointment
+type CancelDockAppointmentRequest struct {
+	Reason string `json:"reason" validate:"omitempty,max=500"`
+}
+
+// ListDockAppointmentsQuery represents query parameters for listing dock appointments; from and to bound the booked start
+type ListDockAppointmentsQuery struct {
+	PaginationQuery
+	LocationID string `query:"location_id" validate:"omitempty,uuid"`
+	DockID     string `query:"dock_id" validate:"omitempty,uuid"`
+	TripID     string `query:"trip_id" validate:"omitempty,uuid"`
+	Status     string `query:"status" validate:"omitempty,oneof=booked checked_in completed cancelled no_show"`
+	From       string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To         string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// DockTurnaroundQuery represents query parameters for a turnaround report; from and to bound the booked start
+type DockTurnaroundQuery struct {
+	LocationID string `query:"location_id" validate:"omitempty,uuid"`
+	DockID     string `query:"dock_id" validate:"omitempty,uuid"`
+	From       string `query:"from" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	To         string `query:"to" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// DockAppointmentResponse represents a dock appointment in responses. wait_minutes is from the booked start to
+// check-in, negative when early; turnaround_minutes is from check-in to check-out.
+type DockAppointmentResponse struct {
+	ID                string   `json:"id"`
+	DockID            string   `json:"dock_id"`
+	LocationID        string   `json:"location_id"`
+	Direction         string   `json:"direction"`
+	TripID            *string  `json:"trip_id"`
+	CarrierID         *string  `json:"carrier_id"`
+	VehicleType       string   `json:"vehicle_type"`
+	PlateNumber       string   `json:"plate_number"`
+	DriverName        string   `json:"driver_name"`
+	Reference         string   `json:"reference"`
+	StartAt           string   `json:"start_at"`
+	EndAt             string   `json:"end_at"`
+	Status            string   `json:"status"`
+	CheckedInAt       *string  `json:"checked_in_at"`
+	CheckedOutAt      *string  `json:"checked_out_at"`
+	WaitMinutes       *float64 `json:"wait_minutes"`
+	TurnaroundMinutes *float64 `json:"turnaround_minutes"`
+	CancelReason      string   `json:"cancel_reason,omitempty"`
+	Notes             string   `json:"notes"`
+	CreatedBy         *string  `json:"created_by"`
+	CreatedAt         string   `json:"created_at"`
+	UpdatedAt         string   `json:"updated_at"`
+}
+
+// DockTurnaroundResponse summarizes the appointments of a dock over a period
+type DockTurnaroundResponse struct {
+	DockID               string  `json:"dock_id"`
+	DockName             string  `json:"dock_name"`
+	LocationID           string  `json:"location_id"`
+	Appointments         int     `json:"appointments"`
+	Completed            int     `json:"completed"`
+	NoShows              int     `json:"no_shows"`
+	Cancelled            int     `json:"cancelled"`
+	AvgWaitMinutes       float64 `json:"avg_wait_minutes"`
+	AvgTurnaroundMinutes float64 `json:"avg_turnaround_minutes"`
+	MaxTurnaroundMinutes float64 `json:"max_turnaround_minutes"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+// DocumentLinkResponse represents a generated PDF with a short-lived download URL
+type DocumentLinkResponse struct {
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+}
@@ -0,0 +1,64 @@
+package dto
+
+// DriverProfileRequest contains the driver-specific profile fields
+type DriverProfileRequest struct {
+	LicenseNumber         string `json:"license_number" validate:"required,max=50"`
+	LicenseClass          string `json:"license_class" validate:"required,oneof=B1 B2 B3 B4 T1 T2 T3 T4"`
+	LicenseExpiry         string `json:"license_expiry" validate:"required,datetime=2006-01-02"`
+	EmploymentType        string `json:"employment_type" validate:"required,oneof=employee contractor"`
+	HomeDepot             string `json:"home_depot" validate:"omitempty,max=255"`
+	EmergencyContactName  string `json:"emergency_contact_name" validate:"omitempty,max=255"`
+	EmergencyContactPhone string `json:"emergency_contact_phone" validate:"omitempty,e164"`
+}
+
+// CreateDriverRequest represents a request to create a driver profile for an existing user
+type CreateDriverRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+	DriverProfileRequest
+}
+
+// InviteDriverRequest represents a request to invite a driver with a phone-only account
+type InviteDriverRequest struct {
+	PhoneNumber string `json:"phone_number" validate:"required,e164"`
+	FirstName   string `json:"first_name" validate:"required"`
+	LastName    string `json:"last_name" validate:"required"`
+	DriverProfileRequest
+}
+
+// UpdateDriverRequest represents a request to update a driver profile
+type UpdateDriverRequest struct {
+	DriverProfileRequest
+}
+
+// UpdateDriverStatusRequest represents a request to change a driver's status
+type UpdateDriverStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=available on_duty off_duty on_leave"`
+}
+
+// ListDriversQuery represents query parameters for listing drivers
+type ListDriversQuery struct {
+	PaginationQuery
+	Status string `query:"status" validate:"omitempty,oneof=available on_duty off_duty on_leave"`
+	Search string `query:"search" validate:"omitempty,max=100"`
+}
+
+// DriverResponse represents driver information in responses
+type DriverResponse struct {
+	ID                    string  `json:"id"`
+	UserID                string  `json:"user_id"`
+	FirstName             string  `json:"first_name"`
+	LastName              string  `json:"last_name"`
+	PhoneNumber           string  `json:"phone_number"`
+	Email                 *string `json:"email"`
+	LicenseNumber         string  `json:"license_number"`
+	LicenseClass          string  `json:"license_class"`
+	LicenseExpiry         string  `json:"license_expiry"`
+	LicenseValid          bool    `json:"license_valid"`
+	EmploymentType        string  `json:"employment_type"`
+	HomeDepot             string  `json:"home_depot"`
+	EmergencyContactName  string  `json:"emergency_contact_name"`
+	EmergencyContactPhone string  `json:"emergency_contact_phone"`
+	Status                string  `json:"status"`
+	CreatedAt             string  `json:"created_at"`
+	UpdatedAt             string  `json:"updated_at"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+// StopDelayResponse represents a stop whose predicted arrival missed its time window
+type StopDelayResponse struct {
+	ID           string `json:"id"`
+	TripID       string `json:"trip_id"`
+	StopID       string `json:"stop_id"`
+	ShipmentID   string `json:"shipment_id"`
+	VehicleID    string `json:"vehicle_id"`
+	ETA          string `json:"eta"`
+	WindowEnd    string `json:"window_end"`
+	DelayMinutes int    `json:"delay_minutes"`
+	DetectedAt   string `json:"detected_at"`
+}
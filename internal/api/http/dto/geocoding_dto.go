@@ -0,0 +1,26 @@
+package dto
+
+// GeocodeQuery represents query parameters for forward geocoding
+type GeocodeQuery struct {
+	Address     string `query:"address" validate:"omitempty,max=500"`
+	Subdistrict string `query:"subdistrict" validate:"omitempty,max=100"`
+	District    string `query:"district" validate:"omitempty,max=100"`
+	Province    string `query:"province" validate:"required_without=Postcode,omitempty,max=100"`
+	Postcode    string `query:"postcode" validate:"omitempty,len=5,numeric"`
+}
+
+// ReverseGeocodeQuery represents query parameters for reverse geocoding
+type ReverseGeocodeQuery struct {
+	Lat *float64 `query:"lat" validate:"required,latitude"`
+	Lng *float64 `query:"lng" validate:"required,longitude"`
+}
+
+// GeocodeResponse represents a geocoded position in responses
+type GeocodeResponse struct {
+	Latitude         float64 `json:"latitude"`
+	Longitude        float64 `json:"longitude"`
+	FormattedAddress string  `json:"formatted_address"`
+	Precision        string  `json:"precision"`
+	Approximate      bool    `json:"approximate"`
+	Provider         string  `json:"provider"`
+}
@@ -0,0 +1,67 @@
+package dto
+
+// CoordinateRequest represents a WGS84 coordinate in requests
+type CoordinateRequest struct {
+	Latitude  *float64 `json:"lat" validate:"required,latitude"`
+	Longitude *float64 `json:"lng" validate:"required,longitude"`
+}
+
+// GeofenceRequest represents geofence details. A circle needs radius_m and may omit center to be
+// drawn around the location's pin; a polygon needs at least three vertices.
+type GeofenceRequest struct {
+	Name         string              `json:"name" validate:"required,max=255"`
+	Shape        string              `json:"shape" validate:"required,oneof=circle polygon"`
+	Center       *CoordinateRequest  `json:"center"`
+	RadiusM      float64             `json:"radius_m" validate:"required_if=Shape circle,omitempty,min=10,max=50000"`
+	Polygon      []CoordinateRequest `json:"polygon" validate:"required_if=Shape polygon,omitempty,min=3,max=500,dive"`
+	DwellSeconds *int                `json:"dwell_seconds" validate:"omitempty,min=0,max=86400" example:"120"`
+	ExitBufferM  *float64            `json:"exit_buffer_m" validate:"omitempty,min=0,max=1000" example:"30"`
+	Active       *bool               `json:"active"`
+}
+
+// ListGeofenceEventsQuery represents query parameters for listing geofence events
+type ListGeofenceEventsQuery struct {
+	VehicleID  string `query:"vehicle_id" validate:"omitempty,uuid"`
+	GeofenceID string `query:"geofence_id" validate:"omitempty,uuid"`
+	LocationID string `query:"location_id" validate:"omitempty,uuid"`
+	TripID     string `query:"trip_id" validate:"omitempty,uuid"`
+	From       string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To         string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	PaginationQuery
+}
+
+// CoordinateResponse represents a WGS84 coordinate in responses
+type CoordinateResponse struct {
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lng"`
+}
+
+// GeofenceResponse represents geofence information in responses
+type GeofenceResponse struct {
+	ID           string               `json:"id"`
+	LocationID   string               `json:"location_id"`
+	Name         string               `json:"name"`
+	Shape        string               `json:"shape"`
+	Center       *CoordinateResponse  `json:"center,omitempty"`
+	RadiusM      float64              `json:"radius_m,omitempty"`
+	Polygon      []CoordinateResponse `json:"polygon,omitempty"`
+	DwellSeconds int                  `json:"dwell_seconds"`
+	ExitBufferM  float64              `json:"exit_buffer_m"`
+	Active       bool                 `json:"active"`
+	CreatedAt    string               `json:"created_at"`
+	UpdatedAt    string               `json:"updated_at"`
+}
+
+// GeofenceEventResponse represents a geofence event in responses
+type GeofenceEventResponse struct {
+	ID         string  `json:"id"`
+	GeofenceID string  `json:"geofence_id"`
+	LocationID string  `json:"location_id"`
+	VehicleID  string  `json:"vehicle_id"`
+	TripID     *string `json:"trip_id"`
+	StopID     *string `json:"stop_id"`
+	Type       string  `json:"type" example:"dwell"`
+	OccurredAt string  `json:"occurred_at"`
+	Latitude   float64 `json:"lat"`
+	Longitude  float64 `json:"lng"`
+}
@@ -0,0 +1,97 @@
+package dto
+
+// IncidentUploadURLsRequest represents a request for presigned URLs to upload incident photos
+type IncidentUploadURLsRequest struct {
+	ContentTypes []string `json:"content_types" validate:"required,min=1,max=10,dive,oneof=image/jpeg image/png"`
+}
+
+// CreateIncidentRequest represents an incident reported against a trip, one of its stops or a shipment.
+// The photo keys are the object_key values returned with the upload URLs. The owner defaults to the
+// dispatcher of the trip; occurred_at defaults to now.
+type CreateIncidentRequest struct {
+	Category    string   `json:"category" validate:"required,oneof=damage shortage accident breakdown refused_delivery other"`
+	Severity    string   `json:"severity" validate:"required,oneof=low medium high critical"`
+	TripID      string   `json:"trip_id" validate:"omitempty,uuid"`
+	StopID      string   `json:"stop_id" validate:"omitempty,uuid"`
+	ShipmentID  string   `json:"shipment_id" validate:"omitempty,uuid"`
+	Description string   `json:"description" validate:"required,max=2000"`
+	PhotoKeys   []string `json:"photo_keys" validate:"max=10,dive,required,max=512"`
+	Latitude    *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude   *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
+	OccurredAt  string   `json:"occurred_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	OwnerID     string   `json:"owner_id" validate:"omitempty,uuid"`
+}
+
+// UpdateIncidentRequest represents the details of an open incident to replace
+type UpdateIncidentRequest struct {
+	Category    string `json:"category" validate:"required,oneof=damage shortage accident breakdown refused_delivery other"`
+	Severity    string `json:"severity" validate:"required,oneof=low medium high critical"`
+	Description string `json:"description" validate:"required,max=2000"`
+	OwnerID     string `json:"owner_id" validate:"omitempty,uuid"`
+}
+
+// AddIncidentPhotosRequest represents photos to attach to an incident
+type AddIncidentPhotosRequest struct {
+	PhotoKeys []string `json:"photo_keys" validate:"required,min=1,max=10,dive,required,max=512"`
+}
+
+// ResolveIncidentRequest represents how an incident was resolved
+type ResolveIncidentRequest struct {
+	Resolution string `json:"resolution" validate:"required,max=2000"`
+}
+
+// CancelIncidentRequest represents why an incident is withdrawn
+type CancelIncidentRequest struct {
+	Reason string `json:"reason" validate:"omitempty,max=500"`
+}
+
+// ListIncidentsQuery represents query parameters for listing incidents.
+// open keeps the incidents awaiting a resolution; overdue keeps those open past their SLA.
+type ListIncidentsQuery struct {
+	PaginationQuery
+	TripID     string `query:"trip_id" validate:"omitempty,uuid"`
+	ShipmentID string `query:"shipment_id" validate:"omitempty,uuid"`
+	OwnerID    string `query:"owner_id" validate:"omitempty,uuid"`
+	Category   string `query:"category" validate:"omitempty,oneof=damage shortage accident breakdown refused_delivery other"`
+	Severity   string `query:"severity" validate:"omitempty,oneof=low medium high critical"`
+	Status     string `query:"status" validate:"omitempty,oneof=open in_progress resolved closed cancelled"`
+	Open       bool   `query:"open"`
+	Overdue    bool   `query:"overdue"`
+}
+
+// IncidentResponse represents an incident with short-lived download URLs for its photos.
+// due_at is when it must be resolved by under its SLA; breached_at is when it was found open past due_at.
+type IncidentResponse struct {
+	ID             string   `json:"id"`
+	DocumentNumber string   `json:"document_number" example:"INC-2610-00017"`
+	Category       string   `json:"category"`
+	Severity       string   `json:"severity"`
+	Status         string   `json:"status"`
+	TripID         *string  `json:"trip_id"`
+	StopID         *string  `json:"stop_id"`
+	ShipmentID     *string  `json:"shipment_id"`
+	Description    string   `json:"description"`
+	PhotoKeys      []string `json:"photo_keys"`
+	PhotoURLs      []string `json:"photo_urls"`
+	Latitude       *float64 `json:"latitude"`
+	Longitude      *float64 `json:"longitude"`
+	LocationID     *string  `json:"location_id"`
+	ReportedBy     string   `json:"reported_by"`
+	OccurredAt     string   `json:"occurred_at"`
+	OwnerID        *string  `json:"owner_id"`
+	DueAt          string   `json:"due_at"`
+	Overdue        bool     `json:"overdue"`
+	BreachedAt     *string  `json:"breached_at"`
+	Resolution     string   `json:"resolution"`
+	ResolvedBy     *string  `json:"resolved_by"`
+	ResolvedAt     *string  `json:"resolved_at"`
+	ClosedAt       *string  `json:"closed_at"`
+	CancelReason   string   `json:"cancel_reason,omitempty"`
+	CreatedAt      string   `json:"created_at"`
+	UpdatedAt      string   `json:"updated_at"`
+}
+
+// IncidentSLACheckResponse represents the outcome of an SLA check
+type IncidentSLACheckResponse struct {
+	Breached int `json:"breached"`
+}
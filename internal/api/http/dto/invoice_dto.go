@@ -0,0 +1,88 @@
+package dto
+
+// CreateInvoiceRequest represents a request to bill a customer's shipments delivered in a billing period
+type CreateInvoiceRequest struct {
+	OrganizationID string `json:"organization_id" validate:"required,uuid"`
+	PeriodFrom     string `json:"period_from" validate:"required,datetime=2006-01-02"`
+	PeriodTo       string `json:"period_to" validate:"required,datetime=2006-01-02"`
+	Notes          string `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// CreditNoteLineRequest represents one amount credited on a credit note
+type CreditNoteLineRequest struct {
+	Description string  `json:"description" validate:"omitempty,max=500"`
+	ShipmentID  string  `json:"shipment_id" validate:"omitempty,uuid"`
+	Amount      float64 `json:"amount" validate:"gt=0"`
+}
+
+// CreateCreditNoteRequest represents a correction of an issued invoice.
+// Without lines, the whole invoice is credited.
+type CreateCreditNoteRequest struct {
+	Reason string                  `json:"reason" validate:"required,max=500"`
+	Lines  []CreditNoteLineRequest `json:"lines" validate:"omitempty,max=500,dive"`
+	Notes  string                  `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// PayInvoiceRequest represents a customer's payment of an invoice
+type PayInvoiceRequest struct {
+	PaidAt    string `json:"paid_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Reference string `json:"reference" validate:"omitempty,max=100"`
+}
+
+// VoidInvoiceRequest represents a request to cancel an invoice or credit note
+type VoidInvoiceRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// ListInvoicesQuery represents query parameters for listing invoices
+type ListInvoicesQuery struct {
+	PaginationQuery
+	OrganizationID string `query:"organization_id" validate:"omitempty,uuid"`
+	Kind           string `query:"kind" validate:"omitempty,oneof=invoice credit_note"`
+	Status         string `query:"status" validate:"omitempty,oneof=draft issued paid void"`
+	Search         string `query:"search" validate:"omitempty,max=50"`
+}
+
+// InvoiceLineResponse represents an invoice line in responses
+type InvoiceLineResponse struct {
+	Sequence    int     `json:"sequence"`
+	ShipmentID  *string `json:"shipment_id"`
+	ServiceDate *string `json:"service_date"`
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+}
+
+// InvoiceResponse represents an invoice or credit note in responses
+type InvoiceResponse struct {
+	ID                string                `json:"id"`
+	Number            string                `json:"number"`
+	Kind              string                `json:"kind"`
+	Status            string                `json:"status"`
+	OrganizationID    string                `json:"organization_id"`
+	CreditedInvoiceID *string               `json:"credited_invoice_id"`
+	PeriodFrom        string                `json:"period_from"`
+	PeriodTo          string                `json:"period_to"`
+	Currency          string                `json:"currency"`
+	CreditTermDays    int                   `json:"credit_term_days"`
+	IssueDate         *string               `json:"issue_date"`
+	DueDate           *string               `json:"due_date"`
+	Overdue           bool                  `json:"overdue"`
+	Lines             []InvoiceLineResponse `json:"lines"`
+	Subtotal          float64               `json:"subtotal"`
+	VATRate           float64               `json:"vat_rate"`
+	VATAmount         float64               `json:"vat_amount"`
+	Total             float64               `json:"total"`
+	WithholdingRate   float64               `json:"withholding_rate"`
+	WithholdingTax    float64               `json:"withholding_tax"`
+	AmountDue         float64               `json:"amount_due"`
+	Reason            string                `json:"reason"`
+	Notes             string                `json:"notes"`
+	PaidAt            *string               `json:"paid_at"`
+	PaymentReference  string                `json:"payment_reference"`
+	VoidedAt          *string               `json:"voided_at"`
+	VoidReason        string                `json:"void_reason"`
+	CreatedAt         string                `json:"created_at"`
+	UpdatedAt         string                `json:"updated_at"`
+}
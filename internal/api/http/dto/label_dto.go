@@ -0,0 +1,28 @@
+package dto
+
+// LabelTemplateRequest represents a request to set an organization's shipping label template
+type LabelTemplateRequest struct {
+	Size             string `json:"size" validate:"required,oneof=4x6 a6" example:"4x6"`
+	DPI              int    `json:"dpi" validate:"omitempty,oneof=203 300 600" example:"203"`
+	ZPLFont          string `json:"zpl_font" validate:"max=42" example:"E:SARABUN.TTF"`
+	GS1CompanyPrefix string `json:"gs1_company_prefix" validate:"omitempty,numeric,min=7,max=10" example:"8850000"`
+	SSCCExtension    int    `json:"sscc_extension" validate:"min=0,max=9"`
+	Footer           string `json:"footer" validate:"max=255" example:"Fragile - handle with care"`
+}
+
+// LabelTemplateResponse represents the label template of an organization in responses
+type LabelTemplateResponse struct {
+	Size             string  `json:"size" example:"4x6"`
+	DPI              int     `json:"dpi" example:"203"`
+	ZPLFont          string  `json:"zpl_font" example:"E:SARABUN.TTF"`
+	GS1CompanyPrefix string  `json:"gs1_company_prefix" example:"8850000"`
+	SSCCExtension    int     `json:"sscc_extension"`
+	Footer           string  `json:"footer"`
+	Custom           bool    `json:"custom"`
+	UpdatedAt        *string `json:"updated_at"`
+}
+
+// LabelQuery represents the output format of printed labels
+type LabelQuery struct {
+	Format string `query:"format" validate:"omitempty,oneof=zpl pdf"`
+}
@@ -0,0 +1,98 @@
+package dto
+
+// CargoSpaceRequest represents a vehicle cargo space in millimeters
+type CargoSpaceRequest struct {
+	LengthMM    int     `json:"length_mm" validate:"required,min=1,max=20000"`
+	WidthMM     int     `json:"width_mm" validate:"required,min=1,max=3000"`
+	HeightMM    int     `json:"height_mm" validate:"required,min=1,max=4500"`
+	MaxWeightKg float64 `json:"max_weight_kg" validate:"min=0"`
+	FrontAxleMM *int    `json:"front_axle_mm" validate:"required_with=RearAxleMM"`
+	RearAxleMM  *int    `json:"rear_axle_mm" validate:"required_with=FrontAxleMM"`
+}
+
+// HandlingUnitRequest represents a type of pallet, crate or carton to load
+type HandlingUnitRequest struct {
+	ID                string  `json:"id" validate:"required,max=100"`
+	Quantity          int     `json:"quantity" validate:"required,min=1"`
+	LengthMM          int     `json:"length_mm" validate:"required,min=1"`
+	WidthMM           int     `json:"width_mm" validate:"required,min=1"`
+	HeightMM          int     `json:"height_mm" validate:"required,min=1"`
+	WeightKg          float64 `json:"weight_kg" validate:"min=0"`
+	Stackable         bool    `json:"stackable"`
+	OrientationLocked bool    `json:"orientation_locked"`
+}
+
+// LoadPlanRequest represents a request to plan how cargo fits a vehicle
+type LoadPlanRequest struct {
+	Space CargoSpaceRequest     `json:"space" validate:"required"`
+	Units []HandlingUnitRequest `json:"units" validate:"required,min=1,max=200,dive"`
+}
+
+// CargoPlacementResponse represents a placed unit; positions are from the front-left floor corner
+type CargoPlacementResponse struct {
+	UnitID   string  `json:"unit_id"`
+	Sequence int     `json:"sequence"`
+	XMM      int     `json:"x_mm"`
+	YMM      int     `json:"y_mm"`
+	ZMM      int     `json:"z_mm"`
+	LengthMM int     `json:"length_mm"`
+	WidthMM  int     `json:"width_mm"`
+	HeightMM int     `json:"height_mm"`
+	Rotated  bool    `json:"rotated"`
+	WeightKg float64 `json:"weight_kg"`
+}
+
+// CargoLeftoverResponse represents a unit that did not fit
+type CargoLeftoverResponse struct {
+	UnitID   string `json:"unit_id"`
+	Sequence int    `json:"sequence"`
+	Reason   string `json:"reason"`
+}
+
+// CargoPointResponse represents a position in the cargo space
+type CargoPointResponse struct {
+	XMM float64 `json:"x_mm"`
+	YMM float64 `json:"y_mm"`
+	ZMM float64 `json:"z_mm"`
+}
+
+// AxleLoadResponse represents the cargo load per axle
+type AxleLoadResponse struct {
+	FrontKg float64 `json:"front_kg"`
+	RearKg  float64 `json:"rear_kg"`
+}
+
+// LayoutRectResponse represents a unit drawn on a layout view, in millimeters from the view's top-left
+type LayoutRectResponse struct {
+	UnitID   string `json:"unit_id"`
+	Sequence int    `json:"sequence"`
+	X        int    `json:"x"`
+	Y        int    `json:"y"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	Color    string `json:"color"`
+}
+
+// LayoutViewResponse represents a 2D projection of the cargo space, rectangles in drawing order
+type LayoutViewResponse struct {
+	Name   string               `json:"name"`
+	Width  int                  `json:"width"`
+	Height int                  `json:"height"`
+	Rects  []LayoutRectResponse `json:"rects"`
+}
+
+// LoadPlanResponse represents a packing plan
+type LoadPlanResponse struct {
+	Placements      []CargoPlacementResponse `json:"placements"`
+	Leftovers       []CargoLeftoverResponse  `json:"leftovers"`
+	VolumeFillPct   float64                  `json:"volume_fill_pct"`
+	WeightFillPct   float64                  `json:"weight_fill_pct"`
+	UsedLengthMM    int                      `json:"used_length_mm"`
+	TotalWeightKg   float64                  `json:"total_weight_kg"`
+	CenterOfGravity CargoPointResponse       `json:"center_of_gravity"`
+	LateralOffsetMM float64                  `json:"lateral_offset_mm"`
+	Axles           *AxleLoadResponse        `json:"axles"`
+	Warnings        []string                 `json:"warnings"`
+	Layout          []LayoutViewResponse     `json:"layout"`
+	SVG             string                   `json:"svg"`
+}
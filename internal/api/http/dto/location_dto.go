@@ -0,0 +1,87 @@
+package dto
+
+// OpeningHoursRequest represents the receiving hours of a location on one weekday
+type OpeningHoursRequest struct {
+	Weekday int    `json:"weekday" validate:"min=0,max=6"`
+	Open    string `json:"open" validate:"required,datetime=15:04"`
+	Close   string `json:"close" validate:"required,datetime=15:04"`
+}
+
+// LocationRequest represents a request to create or update a saved location
+type LocationRequest struct {
+	Name         string                `json:"name" validate:"required,max=255"`
+	Code         string                `json:"code" validate:"omitempty,max=50"`
+	HouseNumber  string                `json:"house_number" validate:"omitempty,max=50"`
+	Moo          string                `json:"moo" validate:"omitempty,max=10"`
+	Soi          string                `json:"soi" validate:"omitempty,max=255"`
+	Road         string                `json:"road" validate:"omitempty,max=255"`
+	Subdistrict  string                `json:"subdistrict" validate:"required"`
+	District     string                `json:"district" validate:"required"`
+	Province     string                `json:"province" validate:"required"`
+	Postcode     string                `json:"postcode" validate:"required,len=5,numeric"`
+	Latitude     *float64              `json:"latitude" validate:"omitempty,latitude"`
+	Longitude    *float64              `json:"longitude" validate:"omitempty,longitude"`
+	ContactName  string                `json:"contact_name" validate:"omitempty,max=255"`
+	ContactPhone string                `json:"contact_phone" validate:"omitempty,max=20"`
+	OpeningHours []OpeningHoursRequest `json:"opening_hours" validate:"omitempty,dive"`
+	Notes        string                `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// ListLocationsQuery represents query parameters for searching an address book
+type ListLocationsQuery struct {
+	PaginationQuery
+	Search string `query:"search" validate:"omitempty,max=100"`
+}
+
+// OpeningHoursResponse represents the receiving hours of a location on one weekday
+type OpeningHoursResponse struct {
+	Weekday int    `json:"weekday"`
+	Open    string `json:"open"`
+	Close   string `json:"close"`
+}
+
+// LocationResponse represents saved location information in responses.
+// address_warnings is returned on create and update, e.g. address_unlisted when the district or
+// subdistrict is not in the address dataset and was saved as entered.
+type LocationResponse struct {
+	ID               string                 `json:"id"`
+	OrganizationID   string                 `json:"organization_id"`
+	Name             string                 `json:"name"`
+	Code             string                 `json:"code"`
+	HouseNumber      string                 `json:"house_number"`
+	Moo              string                 `json:"moo"`
+	Soi              string                 `json:"soi"`
+	Road             string                 `json:"road"`
+	Subdistrict      string                 `json:"subdistrict"`
+	District         string                 `json:"district"`
+	Province         string                 `json:"province"`
+	Postcode         string                 `json:"postcode"`
+	FormattedAddress string                 `json:"formatted_address"`
+	Latitude         *float64               `json:"latitude"`
+	Longitude        *float64               `json:"longitude"`
+	GeoPrecision     string                 `json:"geo_precision"`
+	ContactName      string                 `json:"contact_name"`
+	ContactPhone     string                 `json:"contact_phone"`
+	OpeningHours     []OpeningHoursResponse `json:"opening_hours"`
+	Notes            string                 `json:"notes"`
+	AddressWarnings  []string               `json:"address_warnings,omitempty"`
+	CreatedAt        string                 `json:"created_at"`
+	UpdatedAt        string                 `json:"updated_at"`
+}
+
+// AdminNameResponse represents a Thai administrative name with its English transliteration
+type AdminNameResponse struct {
+	TH string `json:"th"`
+	EN string `json:"en"`
+}
+
+// AdminAreaResponse represents a Thai subdistrict with its parent areas and postcode
+type AdminAreaResponse struct {
+	SubdistrictTH string `json:"subdistrict_th"`
+	SubdistrictEN string `json:"subdistrict_en"`
+	DistrictTH    string `json:"district_th"`
+	DistrictEN    string `json:"district_en"`
+	ProvinceTH    string `json:"province_th"`
+	ProvinceEN    string `json:"province_en"`
+	Postcode      string `json:"postcode"`
+}
@@ -0,0 +1,150 @@
+package dto
+
+// MaintenancePlanRequest represents a maintenance plan to create or replace. The service is due every
+// interval_km, every interval_days, or whichever comes first when both are set; active defaults to true.
+type MaintenancePlanRequest struct {
+	Name         string  `json:"name" validate:"required,max=100"`
+	VehicleType  string  `json:"vehicle_type" validate:"required,oneof=4w 6w 10w 18w"`
+	IntervalKm   float64 `json:"interval_km" validate:"min=0,lte=1000000"`
+	IntervalDays int     `json:"interval_days" validate:"min=0,lte=3650"`
+	Description  string  `json:"description" validate:"omitempty,max=1000"`
+	Active       *bool   `json:"active"`
+}
+
+// ListMaintenancePlansQuery represents query parameters for listing maintenance plans
+type ListMaintenancePlansQuery struct {
+	PaginationQuery
+	VehicleType string `query:"vehicle_type" validate:"omitempty,oneof=4w 6w 10w 18w"`
+	Active      string `query:"active" validate:"omitempty,oneof=true false"`
+}
+
+// ListMaintenanceSchedulesQuery represents query parameters for listing maintenance schedules
+type ListMaintenanceSchedulesQuery struct {
+	PaginationQuery
+	VehicleID string `query:"vehicle_id" validate:"omitempty,uuid"`
+	Status    string `query:"status" validate:"omitempty,oneof=ok due overdue"`
+}
+
+// WorkOrderPartRequest represents a part fitted under a work order
+type WorkOrderPartRequest struct {
+	PartNumber string  `json:"part_number" validate:"omitempty,max=100"`
+	Name       string  `json:"name" validate:"required,max=255"`
+	Quantity   float64 `json:"quantity" validate:"gt=0"`
+	UnitCost   float64 `json:"unit_cost" validate:"min=0"`
+}
+
+// CreateWorkOrderRequest represents a work order to open. A preventive work order needs the plan it services.
+type CreateWorkOrderRequest struct {
+	VehicleID   string                 `json:"vehicle_id" validate:"required,uuid"`
+	PlanID      string                 `json:"plan_id" validate:"omitempty,uuid"`
+	Type        string                 `json:"type" validate:"required,oneof=preventive corrective"`
+	Workshop    string                 `json:"workshop" validate:"omitempty,max=255"`
+	Description string                 `json:"description" validate:"omitempty,max=1000"`
+	OdometerKm  float64                `json:"odometer_km" validate:"min=0"`
+	Parts       []WorkOrderPartRequest `json:"parts" validate:"max=200,dive"`
+	LaborCost   float64                `json:"labor_cost" validate:"min=0"`
+	Notes       string                 `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// UpdateWorkOrderRequest represents the details of an open or in-progress work order to replace
+type UpdateWorkOrderRequest struct {
+	Workshop    string                 `json:"workshop" validate:"omitempty,max=255"`
+	Description string                 `json:"description" validate:"omitempty,max=1000"`
+	OdometerKm  float64                `json:"odometer_km" validate:"min=0"`
+	Parts       []WorkOrderPartRequest `json:"parts" validate:"max=200,dive"`
+	LaborCost   float64                `json:"labor_cost" validate:"min=0"`
+	Notes       string                 `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// StartWorkOrderRequest represents when the vehicle went off the road; without started_at it is now
+type StartWorkOrderRequest struct {
+	StartedAt string `json:"started_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// CompleteWorkOrderRequest represents the completion of a work order. Without completed_at it is now;
+// started_at applies to a work order that was never started, and without odometer_km the reading taken
+// when the work order was opened is kept.
+type CompleteWorkOrderRequest struct {
+	CompletedAt string  `json:"completed_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	StartedAt   string  `json:"started_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	OdometerKm  float64 `json:"odometer_km" validate:"min=0"`
+}
+
+// ListWorkOrdersQuery represents query parameters for listing work orders
+type ListWorkOrdersQuery struct {
+	PaginationQuery
+	VehicleID string `query:"vehicle_id" validate:"omitempty,uuid"`
+	Status    string `query:"status" validate:"omitempty,oneof=open in_progress completed cancelled"`
+	Type      string `query:"type" validate:"omitempty,oneof=preventive corrective"`
+	Search    string `query:"search" validate:"omitempty,max=100"`
+}
+
+// MaintenancePlanResponse represents a maintenance plan in responses
+type MaintenancePlanResponse struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	VehicleType  string  `json:"vehicle_type"`
+	IntervalKm   float64 `json:"interval_km"`
+	IntervalDays int     `json:"interval_days"`
+	Description  string  `json:"description"`
+	Active       bool    `json:"active"`
+	CreatedAt    string  `json:"created_at"`
+	UpdatedAt    string  `json:"updated_at"`
+}
+
+// MaintenanceScheduleResponse represents when a vehicle is next due for the service of a plan.
+// remaining_km is negative once the vehicle has run past the due distance.
+type MaintenanceScheduleResponse struct {
+	ID              string   `json:"id"`
+	VehicleID       string   `json:"vehicle_id"`
+	PlanID          string   `json:"plan_id"`
+	PlanName        string   `json:"plan_name"`
+	LastServiceAt   string   `json:"last_service_at"`
+	LastServiceKm   float64  `json:"last_service_km"`
+	LastWorkOrderID *string  `json:"last_work_order_id"`
+	CurrentKm       float64  `json:"current_km"`
+	NextDueAt       *string  `json:"next_due_at"`
+	NextDueKm       *float64 `json:"next_due_km"`
+	RemainingKm     *float64 `json:"remaining_km"`
+	Status          string   `json:"status"`
+	CheckedAt       string   `json:"checked_at"`
+}
+
+// MaintenanceCheckResponse represents the outcome of a maintenance schedule check
+type MaintenanceCheckResponse struct {
+	Alerts int `json:"alerts"`
+}
+
+// WorkOrderPartResponse represents a part fitted under a work order in responses
+type WorkOrderPartResponse struct {
+	Sequence   int     `json:"sequence"`
+	PartNumber string  `json:"part_number"`
+	Name       string  `json:"name"`
+	Quantity   float64 `json:"quantity"`
+	UnitCost   float64 `json:"unit_cost"`
+	Amount     float64 `json:"amount"`
+}
+
+// WorkOrderResponse represents a work order in responses
+type WorkOrderResponse struct {
+	ID            string                  `json:"id"`
+	Number        string                  `json:"number"`
+	VehicleID     string                  `json:"vehicle_id"`
+	PlanID        *string                 `json:"plan_id"`
+	Type          string                  `json:"type"`
+	Status        string                  `json:"status"`
+	Workshop      string                  `json:"workshop"`
+	Description   string                  `json:"description"`
+	OdometerKm    float64                 `json:"odometer_km"`
+	Parts         []WorkOrderPartResponse `json:"parts"`
+	PartsCost     float64                 `json:"parts_cost"`
+	LaborCost     float64                 `json:"labor_cost"`
+	TotalCost     float64                 `json:"total_cost"`
+	StartedAt     *string                 `json:"started_at"`
+	CompletedAt   *string                 `json:"completed_at"`
+	DowntimeHours float64                 `json:"downtime_hours"`
+	Notes         string                  `json:"notes"`
+	CreatedBy     *string                 `json:"created_by"`
+	CreatedAt     string                  `json:"created_at"`
+	UpdatedAt     string                  `json:"updated_at"`
+}
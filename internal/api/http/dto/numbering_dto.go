@@ -0,0 +1,15 @@
+package dto
+
+// NumberingFormatRequest represents a request to set an organization's number format for a document type
+type NumberingFormatRequest struct {
+	Format string `json:"format" validate:"required,max=100" example:"TMS-{YYMM}-{seq:6}{mod11}"`
+}
+
+// NumberingFormatResponse represents the number format of a document type in responses
+type NumberingFormatResponse struct {
+	DocumentType string  `json:"document_type" example:"shipment"`
+	Format       string  `json:"format" example:"TMS-{YYMM}-{seq:6}{mod11}"`
+	Custom       bool    `json:"custom"`
+	Example      string  `json:"example" example:"TMS-2603-0000014"`
+	UpdatedAt    *string `json:"updated_at"`
+}
@@ -0,0 +1,32 @@
+package dto
+
+// OrganizationRequest represents a request to create or update an organization
+type OrganizationRequest struct {
+	Name           string `json:"name" validate:"required,max=255"`
+	TaxID          string `json:"tax_id" validate:"omitempty,len=13,numeric"`
+	BranchCode     string `json:"branch_code" validate:"omitempty,len=5,numeric"`
+	Phone          string `json:"phone" validate:"omitempty,max=20"`
+	Email          string `json:"email" validate:"omitempty,email"`
+	CreditTermDays *int   `json:"credit_term_days" validate:"omitempty,min=0,max=365"` // omit for the default term
+	WithholdsTax   *bool  `json:"withholds_tax"`                                       // defaults to true
+}
+
+// ListOrganizationsQuery represents query parameters for listing organizations
+type ListOrganizationsQuery struct {
+	PaginationQuery
+	Search string `query:"search" validate:"omitempty,max=100"`
+}
+
+// OrganizationResponse represents organization information in responses
+type OrganizationResponse struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	TaxID          string `json:"tax_id"`
+	BranchCode     string `json:"branch_code"`
+	Phone          string `json:"phone"`
+	Email          string `json:"email"`
+	CreditTermDays *int   `json:"credit_term_days"`
+	WithholdsTax   bool   `json:"withholds_tax"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+}
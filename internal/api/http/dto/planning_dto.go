@@ -0,0 +1,94 @@
+package dto
+
+// PlanningDepotRequest represents a depot where vehicles start and end
+type PlanningDepotRequest struct {
+	ID        string   `json:"id" validate:"required,max=100"`
+	Latitude  *float64 `json:"latitude" validate:"required,latitude"`
+	Longitude *float64 `json:"longitude" validate:"required,longitude"`
+}
+
+// PlanningStopRequest represents a drop to be routed
+type PlanningStopRequest struct {
+	ID             string   `json:"id" validate:"required,max=100"`
+	Latitude       *float64 `json:"latitude" validate:"required,latitude"`
+	Longitude      *float64 `json:"longitude" validate:"required,longitude"`
+	WeightKg       float64  `json:"weight_kg" validate:"min=0"`
+	VolumeM3       float64  `json:"volume_m3" validate:"min=0"`
+	Pallets        int      `json:"pallets" validate:"min=0"`
+	ServiceMinutes int      `json:"service_minutes" validate:"min=0,max=1440"`
+	WindowStart    string   `json:"window_start" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	WindowEnd      string   `json:"window_end" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// PlanningVehicleRequest represents a vehicle available to the plan. Zero capacities are unlimited.
+type PlanningVehicleRequest struct {
+	ID          string  `json:"id" validate:"required,max=100"`
+	DepotID     string  `json:"depot_id" validate:"required"`
+	MaxWeightKg float64 `json:"max_weight_kg" validate:"min=0"`
+	MaxVolumeM3 float64 `json:"max_volume_m3" validate:"min=0"`
+	MaxPallets  int     `json:"max_pallets" validate:"min=0"`
+	ShiftStart  string  `json:"shift_start" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	ShiftEnd    string  `json:"shift_end" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// TravelMatrixRequest holds travel distances (meters) and durations (seconds) between nodes,
+// indexed depots first and then stops, in request order
+type TravelMatrixRequest struct {
+	DistancesM [][]float64 `json:"distances_m" validate:"required"`
+	DurationsS [][]float64 `json:"durations_s" validate:"required"`
+}
+
+// OptimizeRequest represents a routing problem to optimize
+type OptimizeRequest struct {
+	Depots           []PlanningDepotRequest   `json:"depots" validate:"required,min=1,max=20,dive"`
+	Stops            []PlanningStopRequest    `json:"stops" validate:"required,min=1,max=2000,dive"`
+	Vehicles         []PlanningVehicleRequest `json:"vehicles" validate:"required,min=1,max=500,dive"`
+	Matrix           *TravelMatrixRequest     `json:"matrix" validate:"omitempty"`
+	TimeLimitSeconds int                      `json:"time_limit_seconds" validate:"omitempty,min=1,max=60"`
+}
+
+// PlannedVisitResponse represents a scheduled stop on a proposed route
+type PlannedVisitResponse struct {
+	StopID       string `json:"stop_id"`
+	Arrival      string `json:"arrival"`
+	ServiceStart string `json:"service_start"`
+	Departure    string `json:"departure"`
+}
+
+// PlannedRouteResponse represents the proposed trip of one vehicle
+type PlannedRouteResponse struct {
+	VehicleID string                 `json:"vehicle_id"`
+	Visits    []PlannedVisitResponse `json:"visits"`
+	DistanceM float64                `json:"distance_m"`
+	Start     string                 `json:"start"`
+	End       string                 `json:"end"`
+	WeightKg  float64                `json:"weight_kg"`
+	VolumeM3  float64                `json:"volume_m3"`
+	Pallets   int                    `json:"pallets"`
+}
+
+// UnassignedStopResponse represents a stop left out of the plan
+type UnassignedStopResponse struct {
+	StopID string `json:"stop_id"`
+	Reason string `json:"reason"`
+}
+
+// RoutingSolutionResponse represents a proposed plan
+type RoutingSolutionResponse struct {
+	Routes         []PlannedRouteResponse   `json:"routes"`
+	Unassigned     []UnassignedStopResponse `json:"unassigned"`
+	TotalDistanceM float64                  `json:"total_distance_m"`
+	Iterations     int                      `json:"iterations"`
+}
+
+// PlanningJobResponse represents an optimization job in responses
+type PlanningJobResponse struct {
+	ID               string                   `json:"id"`
+	Status           string                   `json:"status"`
+	TimeLimitSeconds int                      `json:"time_limit_seconds"`
+	Error            string                   `json:"error,omitempty"`
+	Solution         *RoutingSolutionResponse `json:"solution"`
+	CreatedAt        string                   `json:"created_at"`
+	StartedAt        *string                  `json:"started_at"`
+	CompletedAt      *string                  `json:"completed_at"`
+}
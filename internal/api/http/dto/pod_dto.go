@@ -0,0 +1,89 @@
+package dto
+
+// PODUploadURLsRequest represents a request for presigned URLs to upload a stop's signature and photos
+type PODUploadURLsRequest struct {
+	SignatureContentType string   `json:"signature_content_type" validate:"required,oneof=image/jpeg image/png"`
+	PhotoContentTypes    []string `json:"photo_content_types" validate:"max=10,dive,oneof=image/jpeg image/png"`
+}
+
+// PODUploadURLsResponse represents the presigned upload URLs for a stop's signature and photos
+type PODUploadURLsResponse struct {
+	Signature PresignUploadResponse   `json:"signature"`
+	Photos    []PresignUploadResponse `json:"photos"`
+}
+
+// SubmitPODRequest represents a proof of delivery captured by the driver app.
+// The keys are the object_key values returned with the upload URLs.
+type SubmitPODRequest struct {
+	RecipientName string   `json:"recipient_name" validate:"required,max=255"`
+	SignatureKey  string   `json:"signature_key" validate:"required,max=512"`
+	PhotoKeys     []string `json:"photo_keys" validate:"max=10,dive,required,max=512"`
+	Latitude      *float64 `json:"latitude" validate:"required,latitude"`
+	Longitude     *float64 `json:"longitude" validate:"required,longitude"`
+	AccuracyM     *float64 `json:"accuracy_m" validate:"omitempty,min=0"`
+	CapturedAt    string   `json:"captured_at" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	Notes         string   `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// PODResponse represents a proof of delivery with short-lived download URLs for its files
+type PODResponse struct {
+	ID             string   `json:"id"`
+	DocumentNumber string   `json:"document_number" example:"POD-2603-000128"`
+	TripID         string   `json:"trip_id"`
+	StopID         string   `json:"stop_id"`
+	ShipmentID     string   `json:"shipment_id"`
+	DriverID       string   `json:"driver_id"`
+	RecipientName  string   `json:"recipient_name"`
+	SignatureKey   string   `json:"signature_key"`
+	SignatureURL   string   `json:"signature_url"`
+	PhotoKeys      []string `json:"photo_keys"`
+	PhotoURLs      []string `json:"photo_urls"`
+	Latitude       float64  `json:"latitude"`
+	Longitude      float64  `json:"longitude"`
+	AccuracyM      *float64 `json:"accuracy_m"`
+	DistanceM      *float64 `json:"distance_m"`
+	CapturedAt     string   `json:"captured_at"`
+	Notes          string   `json:"notes"`
+	CreatedAt      string   `json:"created_at"`
+}
+
+// AttemptUploadURLRequest represents a request for a presigned URL to upload the evidence photo of a failed delivery
+type AttemptUploadURLRequest struct {
+	ContentType string `json:"content_type" validate:"required,oneof=image/jpeg image/png"`
+}
+
+// FailedAttemptRequest represents a failed delivery recorded by the driver app.
+// The photo key is the object_key returned with the upload URL.
+type FailedAttemptRequest struct {
+	Reason      string   `json:"reason" validate:"required,oneof=nobody_home wrong_address refused closed"`
+	PhotoKey    string   `json:"photo_key" validate:"required,max=512"`
+	Latitude    *float64 `json:"latitude" validate:"required,latitude"`
+	Longitude   *float64 `json:"longitude" validate:"required,longitude"`
+	AccuracyM   *float64 `json:"accuracy_m" validate:"omitempty,min=0"`
+	AttemptedAt string   `json:"attempted_at" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	Notes       string   `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// FailedAttemptResponse represents a failed delivery with a short-lived download URL for its photo.
+// leg_id is the reattempt or return shipment booked to follow it up.
+type FailedAttemptResponse struct {
+	ID                string   `json:"id"`
+	TripID            string   `json:"trip_id"`
+	StopID            string   `json:"stop_id"`
+	ShipmentID        string   `json:"shipment_id"`
+	DriverID          string   `json:"driver_id"`
+	Attempt           int      `json:"attempt" example:"1"`
+	Reason            string   `json:"reason" example:"nobody_home"`
+	PhotoKey          string   `json:"photo_key"`
+	PhotoURL          string   `json:"photo_url"`
+	Latitude          float64  `json:"latitude"`
+	Longitude         float64  `json:"longitude"`
+	AccuracyM         *float64 `json:"accuracy_m"`
+	DistanceM         *float64 `json:"distance_m"`
+	AttemptedAt       string   `json:"attempted_at"`
+	Notes             string   `json:"notes"`
+	LegID             string   `json:"leg_id"`
+	Leg               string   `json:"leg" example:"reattempt"`
+	LegTrackingNumber string   `json:"leg_tracking_number" example:"TH7KQ2M9XRW4"`
+	CreatedAt         string   `json:"created_at"`
+}
@@ -0,0 +1,217 @@
+package dto
+
+// RateZoneRequest represents a pricing zone made of provinces and postcode prefixes
+type RateZoneRequest struct {
+	Code             string   `json:"code" validate:"required,max=20,ne=*"`
+	Provinces        []string `json:"provinces" validate:"dive,max=100"`
+	PostcodePrefixes []string `json:"postcode_prefixes" validate:"dive,min=1,max=5,numeric"`
+}
+
+// DistanceBandRequest represents a distance band: a flat amount plus a per-km rate up to a distance.
+// up_to_km 0 means no upper bound.
+type DistanceBandRequest struct {
+	UpToKm float64 `json:"up_to_km" validate:"min=0"`
+	Amount float64 `json:"amount" validate:"min=0"`
+	PerKm  float64 `json:"per_km" validate:"min=0"`
+}
+
+// WeightBreakRequest represents a per-kg rate from a minimum chargeable weight
+type WeightBreakRequest struct {
+	MinKg float64 `json:"min_kg" validate:"min=0"`
+	PerKg float64 `json:"per_kg" validate:"min=0"`
+}
+
+// RateLaneRequest represents zone-to-zone pricing; "*" matches any zone
+type RateLaneRequest struct {
+	OriginZone      string                `json:"origin_zone" validate:"required,max=20"`
+	DestinationZone string                `json:"destination_zone" validate:"required,max=20"`
+	VehicleType     string                `json:"vehicle_type" validate:"omitempty,oneof=4w 6w 10w 18w"`
+	BaseCharge      float64               `json:"base_charge" validate:"min=0"`
+	DistanceBands   []DistanceBandRequest `json:"distance_bands" validate:"dive"`
+	WeightBreaks    []WeightBreakRequest  `json:"weight_breaks" validate:"dive"`
+	PerPallet       float64               `json:"per_pallet" validate:"min=0"`
+	MinCharge       float64               `json:"min_charge" validate:"min=0"`
+}
+
+// FuelSurchargeRequest represents a surcharge of step_percent per full step (THB/litre) of diesel above base_price
+type FuelSurchargeRequest struct {
+	BasePrice   float64 `json:"base_price" validate:"gt=0"`
+	Step        float64 `json:"step" validate:"gt=0"`
+	StepPercent float64 `json:"step_percent" validate:"gt=0,max=100"`
+}
+
+// AccessorialRateRequest represents the price of an additional service
+type AccessorialRateRequest struct {
+	Code        string  `json:"code" validate:"required,max=50"`
+	Description string  `json:"description" validate:"omitempty,max=255"`
+	Unit        string  `json:"unit" validate:"required,oneof=per_shipment per_drop per_hour"`
+	Amount      float64 `json:"amount" validate:"min=0"`
+	FreeUnits   float64 `json:"free_units" validate:"min=0"`
+}
+
+// CreateRateCardRequest represents a new rate card version. Without organization_id it is the standard tariff.
+type CreateRateCardRequest struct {
+	OrganizationID   string                   `json:"organization_id" validate:"omitempty,uuid"`
+	Name             string                   `json:"name" validate:"required,max=255"`
+	Currency         string                   `json:"currency" validate:"omitempty,len=3,uppercase"`
+	EffectiveFrom    string                   `json:"effective_from" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	EffectiveTo      string                   `json:"effective_to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	VolumetricFactor float64                  `json:"volumetric_factor" validate:"min=0"`
+	Zones            []RateZoneRequest        `json:"zones" validate:"dive"`
+	Lanes            []RateLaneRequest        `json:"lanes" validate:"required,min=1,dive"`
+	Fuel             *FuelSurchargeRequest    `json:"fuel_surcharge"`
+	Accessorials     []AccessorialRateRequest `json:"accessorials" validate:"dive"`
+}
+
+// ListRateCardsQuery represents query parameters for listing rate cards
+type ListRateCardsQuery struct {
+	PaginationQuery
+	OrganizationID string `query:"organization_id" validate:"omitempty,uuid"`
+	Standard       bool   `query:"standard"`
+	EffectiveAt    string `query:"effective_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Search         string `query:"search" validate:"omitempty,max=100"`
+}
+
+// RateZoneResponse represents a pricing zone in responses
+type RateZoneResponse struct {
+	Code             string   `json:"code"`
+	Provinces        []string `json:"provinces"`
+	PostcodePrefixes []string `json:"postcode_prefixes"`
+}
+
+// RateLaneResponse represents zone-to-zone pricing in responses
+type RateLaneResponse struct {
+	OriginZone      string                 `json:"origin_zone"`
+	DestinationZone string                 `json:"destination_zone"`
+	VehicleType     string                 `json:"vehicle_type"`
+	BaseCharge      float64                `json:"base_charge"`
+	DistanceBands   []DistanceBandResponse `json:"distance_bands"`
+	WeightBreaks    []WeightBreakResponse  `json:"weight_breaks"`
+	PerPallet       float64                `json:"per_pallet"`
+	MinCharge       float64                `json:"min_charge"`
+}
+
+// DistanceBandResponse represents a distance band in responses
+type DistanceBandResponse struct {
+	UpToKm float64 `json:"up_to_km"`
+	Amount float64 `json:"amount"`
+	PerKm  float64 `json:"per_km"`
+}
+
+// WeightBreakResponse represents a weight break in responses
+type WeightBreakResponse struct {
+	MinKg float64 `json:"min_kg"`
+	PerKg float64 `json:"per_kg"`
+}
+
+// FuelSurchargeResponse represents a fuel surcharge rule in responses
+type FuelSurchargeResponse struct {
+	BasePrice   float64 `json:"base_price"`
+	Step        float64 `json:"step"`
+	StepPercent float64 `json:"step_percent"`
+}
+
+// AccessorialRateResponse represents an accessorial price in responses
+type AccessorialRateResponse struct {
+	Code        string  `json:"code"`
+	Description string  `json:"description"`
+	Unit        string  `json:"unit"`
+	Amount      float64 `json:"amount"`
+	FreeUnits   float64 `json:"free_units"`
+}
+
+// RateCardResponse represents a rate card version in responses
+type RateCardResponse struct {
+	ID               string                    `json:"id"`
+	OrganizationID   *string                   `json:"organization_id"`
+	Name             string                    `json:"name"`
+	Version          int                       `json:"version"`
+	Currency         string                    `json:"currency"`
+	EffectiveFrom    string                    `json:"effective_from"`
+	EffectiveTo      *string                   `json:"effective_to"`
+	VolumetricFactor float64                   `json:"volumetric_factor"`
+	Zones            []RateZoneResponse        `json:"zones"`
+	Lanes            []RateLaneResponse        `json:"lanes"`
+	Fuel             *FuelSurchargeResponse    `json:"fuel_surcharge"`
+	Accessorials     []AccessorialRateResponse `json:"accessorials"`
+	CreatedAt        string                    `json:"created_at"`
+}
+
+// CreateDieselPriceRequest represents the reference diesel price from a date on
+type CreateDieselPriceRequest struct {
+	EffectiveDate string  `json:"effective_date" validate:"required,datetime=2006-01-02"`
+	PricePerLitre float64 `json:"price_per_litre" validate:"gt=0"`
+}
+
+// DieselPriceResponse represents a diesel price in responses
+type DieselPriceResponse struct {
+	ID            string  `json:"id"`
+	EffectiveDate string  `json:"effective_date"`
+	PricePerLitre float64 `json:"price_per_litre"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+// AccessorialQuantityRequest represents a requested additional service: drops, hours or 1 for per-shipment services
+type AccessorialQuantityRequest struct {
+	Code     string  `json:"code" validate:"required,max=50"`
+	Quantity float64 `json:"quantity" validate:"min=0"`
+}
+
+// QuoteShipmentRequest represents options for quoting a booked shipment
+type QuoteShipmentRequest struct {
+	VehicleType  string                       `json:"vehicle_type" validate:"omitempty,oneof=4w 6w 10w 18w"`
+	DistanceKm   *float64                     `json:"distance_km" validate:"omitempty,min=0"`
+	Accessorials []AccessorialQuantityRequest `json:"accessorials" validate:"dive"`
+	PricedAt     string                       `json:"priced_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// PricingAddressRequest represents one end of a simulated lane
+type PricingAddressRequest struct {
+	Province  string   `json:"province" validate:"required_without=Postcode,omitempty,max=100"`
+	Postcode  string   `json:"postcode" validate:"omitempty,len=5,numeric"`
+	Latitude  *float64 `json:"latitude" validate:"omitempty,latitude"`
+	Longitude *float64 `json:"longitude" validate:"omitempty,longitude"`
+}
+
+// SimulateQuoteRequest represents a what-if quote. rate_card_id prices against a specific version,
+// otherwise the organization's effective card and then the standard tariff are used.
+type SimulateQuoteRequest struct {
+	RateCardID     string                       `json:"rate_card_id" validate:"omitempty,uuid"`
+	OrganizationID string                       `json:"organization_id" validate:"omitempty,uuid"`
+	Origin         PricingAddressRequest        `json:"origin"`
+	Destination    PricingAddressRequest        `json:"destination"`
+	VehicleType    string                       `json:"vehicle_type" validate:"omitempty,oneof=4w 6w 10w 18w"`
+	DistanceKm     *float64                     `json:"distance_km" validate:"omitempty,min=0"`
+	WeightKg       float64                      `json:"weight_kg" validate:"min=0"`
+	VolumeM3       float64                      `json:"volume_m3" validate:"min=0"`
+	Pallets        int                          `json:"pallets" validate:"min=0"`
+	Accessorials   []AccessorialQuantityRequest `json:"accessorials" validate:"dive"`
+	PricedAt       string                       `json:"priced_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// QuoteLineResponse represents one charge on a quote with the rule behind it
+type QuoteLineResponse struct {
+	Code      string  `json:"code"`
+	Quantity  float64 `json:"quantity"`
+	UnitPrice float64 `json:"unit_price"`
+	Amount    float64 `json:"amount"`
+	Rule      string  `json:"rule"`
+}
+
+// QuoteResponse represents a line-itemized quote
+type QuoteResponse struct {
+	ShipmentID        *string             `json:"shipment_id"`
+	RateCardID        string              `json:"rate_card_id"`
+	RateCardName      string              `json:"rate_card_name"`
+	RateCardVersion   int                 `json:"rate_card_version"`
+	Currency          string              `json:"currency"`
+	OriginZone        string              `json:"origin_zone"`
+	DestinationZone   string              `json:"destination_zone"`
+	DistanceKm        float64             `json:"distance_km"`
+	DistanceEstimated bool                `json:"distance_estimated"`
+	ChargeableKg      float64             `json:"chargeable_kg"`
+	DieselPrice       *float64            `json:"diesel_price"`
+	PricedAt          string              `json:"priced_at"`
+	Lines             []QuoteLineResponse `json:"lines"`
+	Total             float64             `json:"total"`
+}
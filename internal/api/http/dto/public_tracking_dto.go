@@ -0,0 +1,47 @@
+package dto
+
+// TrackShipmentQuery represents the challenge answers a consignee may give to see a tracking page
+type TrackShipmentQuery struct {
+	Postcode    string `query:"postcode" validate:"omitempty,len=5,numeric"`
+	PhoneSuffix string `query:"phone_suffix" validate:"omitempty,len=4,numeric"`
+}
+
+// TrackingEventResponse represents one milestone on a tracking page
+type TrackingEventResponse struct {
+	Code       string `json:"code" example:"picked_up"`
+	OccurredAt string `json:"occurred_at"`
+	District   string `json:"district,omitempty"`
+	Province   string `json:"province,omitempty"`
+}
+
+// TrackingPageResponse represents the public view of a shipment. After a failed delivery, reschedulable tells
+// whether the consignee may pick when the next attempt is made.
+type TrackingPageResponse struct {
+	TrackingNumber string                  `json:"tracking_number" example:"TH7KQ2M9XRW4"`
+	Status         string                  `json:"status" example:"in_transit"`
+	Recipient      string                  `json:"recipient" example:"S****** J*****"`
+	District       string                  `json:"district"`
+	Province       string                  `json:"province"`
+	ETAFrom        *string                 `json:"eta_from"`
+	ETATo          *string                 `json:"eta_to"`
+	DeliveredAt    *string                 `json:"delivered_at"`
+	Reschedulable  bool                    `json:"reschedulable"`
+	Events         []TrackingEventResponse `json:"events"`
+}
+
+// RescheduleDeliveryRequest represents when a consignee wants a failed delivery attempted again: either a whole
+// date, or a window from deliver_from to deliver_to. The postcode or phone_suffix challenge is always required.
+type RescheduleDeliveryRequest struct {
+	Postcode    string `json:"postcode" validate:"required_without=PhoneSuffix,omitempty,len=5,numeric"`
+	PhoneSuffix string `json:"phone_suffix" validate:"required_without=Postcode,omitempty,len=4,numeric"`
+	Date        string `json:"date" validate:"required_without_all=DeliverFrom DeliverTo,omitempty,datetime=2006-01-02" example:"2026-10-21"`
+	DeliverFrom string `json:"deliver_from" validate:"required_with=DeliverTo,omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	DeliverTo   string `json:"deliver_to" validate:"required_with=DeliverFrom,omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// RescheduleDeliveryResponse represents the agreed window of the next delivery attempt
+type RescheduleDeliveryResponse struct {
+	TrackingNumber string `json:"tracking_number" example:"TH7KQ2M9XRW4"`
+	DeliverFrom    string `json:"deliver_from"`
+	DeliverTo      string `json:"deliver_to"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+// StreamQuery represents query parameters for opening a stream
+type StreamQuery struct {
+	Channels string `query:"channels" example:"trip:4b0c6b9e-0a51-4c55-9a36-0d2d1a3f4e11"` // comma-separated
+}
+
+// StreamCommand represents a message sent by a WebSocket client to change its subscriptions
+type StreamCommand struct {
+	Action   string   `json:"action" validate:"required,oneof=subscribe unsubscribe"`
+	Channels []string `json:"channels" validate:"required,min=1,max=100"`
+}
+
+// StreamReply represents the server's answer to a StreamCommand
+type StreamReply struct {
+	Type     string              `json:"type" example:"subscribed"` // subscribed, unsubscribed, error
+	Channels []string            `json:"channels,omitempty"`
+	Error    string              `json:"error,omitempty"`
+	Details  map[string][]string `json:"details,omitempty"`
+}
+
+// StreamEventResponse documents an event pushed to stream clients
+type StreamEventResponse struct {
+	Channel    string                 `json:"channel" example:"vehicle:9c1f6a2e-3c0b-4f7e-8a51-2f0d6b1e7c44"`
+	Type       string                 `json:"type" example:"vehicle.position"`
+	Data       map[string]interface{} `json:"data"`
+	OccurredAt string                 `json:"occurred_at" example:"2026-01-15T08:30:00Z"`
+}
@@ -0,0 +1,131 @@
+package dto
+
+// DriverPayRuleRequest represents a driver pay rule to create or replace. Without vehicle_type it applies
+// to every vehicle type without a rule of its own.
+type DriverPayRuleRequest struct {
+	Name               string  `json:"name" validate:"required,max=100"`
+	VehicleType        string  `json:"vehicle_type" validate:"omitempty,oneof=4w 6w 10w 18w"`
+	BasePerTrip        float64 `json:"base_per_trip" validate:"min=0"`
+	PerKm              float64 `json:"per_km" validate:"min=0"`
+	PerDrop            float64 `json:"per_drop" validate:"min=0"`
+	OvernightAllowance float64 `json:"overnight_allowance" validate:"min=0"`
+	OvertimeRate       float64 `json:"overtime_rate" validate:"min=0"`
+	StandardHours      float64 `json:"standard_hours" validate:"min=0,max=24"`
+	EffectiveFrom      string  `json:"effective_from" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	EffectiveTo        string  `json:"effective_to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// ListDriverPayRulesQuery represents query parameters for listing driver pay rules
+type ListDriverPayRulesQuery struct {
+	PaginationQuery
+	VehicleType string `query:"vehicle_type" validate:"omitempty,oneof=4w 6w 10w 18w"`
+	EffectiveAt string `query:"effective_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// DriverPayRuleResponse represents a driver pay rule in responses
+type DriverPayRuleResponse struct {
+	ID                 string  `json:"id"`
+	Name               string  `json:"name"`
+	VehicleType        string  `json:"vehicle_type"`
+	BasePerTrip        float64 `json:"base_per_trip"`
+	PerKm              float64 `json:"per_km"`
+	PerDrop            float64 `json:"per_drop"`
+	OvernightAllowance float64 `json:"overnight_allowance"`
+	OvertimeRate       float64 `json:"overtime_rate"`
+	StandardHours      float64 `json:"standard_hours"`
+	EffectiveFrom      string  `json:"effective_from"`
+	EffectiveTo        *string `json:"effective_to"`
+	CreatedAt          string  `json:"created_at"`
+	UpdatedAt          string  `json:"updated_at"`
+}
+
+// SettlementAdjustmentRequest represents an advance or deduction taken off a driver's pay
+type SettlementAdjustmentRequest struct {
+	Kind        string  `json:"kind" validate:"required,oneof=advance deduction"`
+	Description string  `json:"description" validate:"required,max=500"`
+	Amount      float64 `json:"amount" validate:"gt=0"`
+}
+
+// CreateDriverSettlementRequest represents a request to settle a driver's completed trips of a period
+type CreateDriverSettlementRequest struct {
+	DriverID    string                        `json:"driver_id" validate:"required,uuid"`
+	PeriodFrom  string                        `json:"period_from" validate:"required,datetime=2006-01-02"`
+	PeriodTo    string                        `json:"period_to" validate:"required,datetime=2006-01-02"`
+	Adjustments []SettlementAdjustmentRequest `json:"adjustments" validate:"omitempty,max=100,dive"`
+	Notes       string                        `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// VoidDriverSettlementRequest represents a request to cancel a driver settlement
+type VoidDriverSettlementRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// ListDriverSettlementsQuery represents query parameters for listing driver settlements
+type ListDriverSettlementsQuery struct {
+	PaginationQuery
+	DriverID   string `query:"driver_id" validate:"omitempty,uuid"`
+	Status     string `query:"status" validate:"omitempty,oneof=draft approved void"`
+	PeriodFrom string `query:"period_from" validate:"omitempty,datetime=2006-01-02"`
+	PeriodTo   string `query:"period_to" validate:"omitempty,datetime=2006-01-02"`
+	Search     string `query:"search" validate:"omitempty,max=50"`
+}
+
+// ExportDriverSettlementsQuery represents the pay period exported for payroll
+type ExportDriverSettlementsQuery struct {
+	PeriodFrom string `query:"period_from" validate:"required,datetime=2006-01-02"`
+	PeriodTo   string `query:"period_to" validate:"required,datetime=2006-01-02"`
+}
+
+// SettlementLineResponse represents the pay for one trip in responses
+type SettlementLineResponse struct {
+	Sequence      int     `json:"sequence"`
+	TripID        string  `json:"trip_id"`
+	TripNumber    string  `json:"trip_number"`
+	CoDriver      bool    `json:"co_driver"`
+	VehicleType   string  `json:"vehicle_type"`
+	PayRuleID     string  `json:"pay_rule_id"`
+	StartedAt     string  `json:"started_at"`
+	EndedAt       string  `json:"ended_at"`
+	DistanceKm    float64 `json:"distance_km"`
+	Drops         int     `json:"drops"`
+	Overnights    int     `json:"overnights"`
+	OvertimeHours float64 `json:"overtime_hours"`
+	BasePay       float64 `json:"base_pay"`
+	DistancePay   float64 `json:"distance_pay"`
+	DropPay       float64 `json:"drop_pay"`
+	OvernightPay  float64 `json:"overnight_pay"`
+	OvertimePay   float64 `json:"overtime_pay"`
+	Amount        float64 `json:"amount"`
+}
+
+// SettlementAdjustmentResponse represents an advance or deduction in responses
+type SettlementAdjustmentResponse struct {
+	ID          string  `json:"id"`
+	Kind        string  `json:"kind"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	CreatedAt   string  `json:"created_at"`
+}
+
+// DriverSettlementResponse represents a driver settlement in responses
+type DriverSettlementResponse struct {
+	ID          string                         `json:"id"`
+	Number      string                         `json:"number"`
+	DriverID    string                         `json:"driver_id"`
+	Status      string                         `json:"status"`
+	PeriodFrom  string                         `json:"period_from"`
+	PeriodTo    string                         `json:"period_to"`
+	Lines       []SettlementLineResponse       `json:"lines"`
+	Adjustments []SettlementAdjustmentResponse `json:"adjustments"`
+	GrossPay    float64                        `json:"gross_pay"`
+	Advances    float64                        `json:"advances"`
+	Deductions  float64                        `json:"deductions"`
+	NetPay      float64                        `json:"net_pay"`
+	Notes       string                         `json:"notes"`
+	ApprovedAt  *string                        `json:"approved_at"`
+	ApprovedBy  *string                        `json:"approved_by"`
+	VoidedAt    *string                        `json:"voided_at"`
+	VoidReason  string                         `json:"void_reason"`
+	CreatedAt   string                         `json:"created_at"`
+	UpdatedAt   string                         `json:"updated_at"`
+}
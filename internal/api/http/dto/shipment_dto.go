@@ -0,0 +1,84 @@
+package dto
+
+// ShipmentRequest represents a request to book or update a shipment
+type ShipmentRequest struct {
+	Reference          string  `json:"reference" validate:"omitempty,max=100"`
+	PickupLocationID   string  `json:"pickup_location_id" validate:"required,uuid"`
+	DeliveryLocationID string  `json:"delivery_location_id" validate:"required,uuid,nefield=PickupLocationID"`
+	WeightKg           float64 `json:"weight_kg" validate:"gte=0"`
+	VolumeM3           float64 `json:"volume_m3" validate:"gte=0"`
+	Pallets            int     `json:"pallets" validate:"gte=0"`
+	PickupFrom         string  `json:"pickup_from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	PickupTo           string  `json:"pickup_to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	DeliverFrom        string  `json:"deliver_from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	DeliverTo          string  `json:"deliver_to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Notes              string  `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// CreateShipmentRequest represents a request to book a shipment for an organization
+type CreateShipmentRequest struct {
+	OrganizationID string `json:"organization_id" validate:"required,uuid"`
+	ShipmentRequest
+}
+
+// ListShipmentsQuery represents query parameters for listing shipments
+type ListShipmentsQuery struct {
+	PaginationQuery
+	OrganizationID string `query:"organization_id" validate:"omitempty,uuid"`
+	Status         string `query:"status" validate:"omitempty,oneof=pending planned in_transit delivered failed cancelled"`
+	Search         string `query:"search" validate:"omitempty,max=100"`
+}
+
+// ShipmentResponse represents shipment information in responses.
+// A reattempt or return leg follows up the failed delivery of its parent; attempt counts the attempts at
+// delivering to its delivery location.
+type ShipmentResponse struct {
+	ID                 string  `json:"id"`
+	OrganizationID     string  `json:"organization_id"`
+	TrackingNumber     string  `json:"tracking_number" example:"TH7KQ2M9XRW4"`
+	Reference          string  `json:"reference"`
+	PickupLocationID   string  `json:"pickup_location_id"`
+	DeliveryLocationID string  `json:"delivery_location_id"`
+	WeightKg           float64 `json:"weight_kg"`
+	VolumeM3           float64 `json:"volume_m3"`
+	Pallets            int     `json:"pallets"`
+	PickupFrom         *string `json:"pickup_from"`
+	PickupTo           *string `json:"pickup_to"`
+	DeliverFrom        *string `json:"deliver_from"`
+	DeliverTo          *string `json:"deliver_to"`
+	Status             string  `json:"status"`
+	Notes              string  `json:"notes"`
+	ParentID           *string `json:"parent_id"`
+	Leg                string  `json:"leg"`
+	Attempt            int     `json:"attempt"`
+	CreatedAt          string  `json:"created_at"`
+	UpdatedAt          string  `json:"updated_at"`
+}
+
+// ShipmentTimelineIncidentResponse summarizes an open incident on a shipment timeline
+type ShipmentTimelineIncidentResponse struct {
+	ID             string `json:"id"`
+	DocumentNumber string `json:"document_number" example:"INC-2610-00017"`
+	Category       string `json:"category"`
+	Severity       string `json:"severity"`
+	Status         string `json:"status"`
+	DueAt          string `json:"due_at"`
+	Overdue        bool   `json:"overdue"`
+}
+
+// ShipmentTimelineEventResponse represents one event in the life of a shipment. type is one of created,
+// status_changed, stop_arrived, stop_departed, delayed, delivery_failed or incident; status is set on status
+// changes, stop_type on stop events, eta on delays, incident on incidents, and failure_reason and leg_id on
+// failed deliveries.
+type ShipmentTimelineEventResponse struct {
+	Type          string                            `json:"type"`
+	OccurredAt    string                            `json:"occurred_at"`
+	Status        string                            `json:"status,omitempty"`
+	TripID        *string                           `json:"trip_id,omitempty"`
+	StopID        *string                           `json:"stop_id,omitempty"`
+	StopType      string                            `json:"stop_type,omitempty"`
+	ETA           *string                           `json:"eta,omitempty"`
+	Incident      *ShipmentTimelineIncidentResponse `json:"incident,omitempty"`
+	FailureReason string                            `json:"failure_reason,omitempty" example:"nobody_home"`
+	LegID         *string                           `json:"leg_id,omitempty"`
+}
@@ -0,0 +1,97 @@
+package dto
+
+// CreateTenderRequest represents a request to offer a shipment or a trip to carriers.
+// carrier_ids are offered one at a time in the given order.
+type CreateTenderRequest struct {
+	ShipmentID            string   `json:"shipment_id" validate:"required_without=TripID,excluded_with=TripID,omitempty,uuid"`
+	TripID                string   `json:"trip_id" validate:"required_without=ShipmentID,omitempty,uuid"`
+	CarrierIDs            []string `json:"carrier_ids" validate:"required,min=1,max=20,dive,uuid"`
+	ResponseWindowMinutes int      `json:"response_window_minutes" validate:"omitempty,min=5,max=4320"`
+	VehicleType           string   `json:"vehicle_type" validate:"omitempty,oneof=4w 6w 10w 18w"`
+	Notes                 string   `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// CancelTenderRequest represents a request to withdraw an open tender
+type CancelTenderRequest struct {
+	Reason string `json:"reason" validate:"omitempty,max=500"`
+}
+
+// RejectTenderRequest represents a carrier declining a tender offer
+type RejectTenderRequest struct {
+	Reason string `json:"reason" validate:"omitempty,max=500"`
+}
+
+// ListTendersQuery represents query parameters for listing tenders
+type ListTendersQuery struct {
+	PaginationQuery
+	Status     string `query:"status" validate:"omitempty,oneof=open accepted exhausted cancelled"`
+	ShipmentID string `query:"shipment_id" validate:"omitempty,uuid"`
+	TripID     string `query:"trip_id" validate:"omitempty,uuid"`
+	CarrierID  string `query:"carrier_id" validate:"omitempty,uuid"`
+}
+
+// ListCarrierTendersQuery represents query parameters for a carrier listing its tenders
+type ListCarrierTendersQuery struct {
+	PaginationQuery
+	Status string `query:"status" validate:"omitempty,oneof=open accepted exhausted cancelled"`
+}
+
+// TenderSummaryResponse describes the tendered work
+type TenderSummaryResponse struct {
+	VehicleType         string  `json:"vehicle_type"`
+	OriginProvince      string  `json:"origin_province"`
+	DestinationProvince string  `json:"destination_province"`
+	PickupAt            *string `json:"pickup_at"`
+	DeliverBy           *string `json:"deliver_by"`
+	WeightKg            float64 `json:"weight_kg"`
+	VolumeM3            float64 `json:"volume_m3"`
+	Pallets             int     `json:"pallets"`
+}
+
+// TenderOfferResponse represents one carrier's offer in responses
+type TenderOfferResponse struct {
+	ID          string  `json:"id"`
+	CarrierID   string  `json:"carrier_id"`
+	Rank        int     `json:"rank"`
+	Status      string  `json:"status"`
+	OfferedAt   *string `json:"offered_at"`
+	Deadline    *string `json:"deadline"`
+	RespondedAt *string `json:"responded_at"`
+	Reason      string  `json:"reason"`
+}
+
+// TenderEventResponse represents a tender state change in responses
+type TenderEventResponse struct {
+	Type       string  `json:"type"`
+	CarrierID  *string `json:"carrier_id"`
+	Note       string  `json:"note"`
+	OccurredAt string  `json:"occurred_at"`
+}
+
+// TenderResponse represents tender information in responses
+type TenderResponse struct {
+	ID                    string                `json:"id"`
+	ShipmentID            *string               `json:"shipment_id"`
+	TripID                *string               `json:"trip_id"`
+	Status                string                `json:"status"`
+	ResponseWindowMinutes int                   `json:"response_window_minutes"`
+	Summary               TenderSummaryResponse `json:"summary"`
+	Notes                 string                `json:"notes"`
+	AwardedCarrierID      *string               `json:"awarded_carrier_id"`
+	Offers                []TenderOfferResponse `json:"offers"`
+	Events                []TenderEventResponse `json:"events"`
+	CreatedAt             string                `json:"created_at"`
+	UpdatedAt             string                `json:"updated_at"`
+}
+
+// CarrierTenderResponse represents a tender as seen by the carrier it was offered to
+type CarrierTenderResponse struct {
+	ID         string                `json:"id"`
+	ShipmentID *string               `json:"shipment_id"`
+	TripID     *string               `json:"trip_id"`
+	Status     string                `json:"status"`
+	Summary    TenderSummaryResponse `json:"summary"`
+	Notes      string                `json:"notes"`
+	Offer      TenderOfferResponse   `json:"offer"`
+	Awarded    bool                  `json:"awarded"`
+}
@@ -0,0 +1,74 @@
+package dto
+
+// PositionRequest represents one GPS fix. The vehicle is identified by vehicle_id or by the
+// gps_device_id registered on the vehicle.
+type PositionRequest struct {
+	VehicleID  string   `json:"vehicle_id" validate:"required_without=DeviceID,omitempty,uuid"`
+	DeviceID   string   `json:"device_id" validate:"required_without=VehicleID,omitempty,max=64"`
+	Timestamp  string   `json:"timestamp" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	Latitude   *float64 `json:"lat" validate:"required,latitude"`
+	Longitude  *float64 `json:"lng" validate:"required,longitude"`
+	SpeedKmh   *float64 `json:"speed_kmh" validate:"omitempty,min=0,max=300"`
+	Heading    *float64 `json:"heading" validate:"omitempty,min=0,lt=360"`
+	OdometerKm *float64 `json:"odometer_km" validate:"omitempty,min=0"`
+}
+
+// IngestPositionsRequest represents a batch of GPS fixes, in any order
+type IngestPositionsRequest struct {
+	Positions []PositionRequest `json:"positions" validate:"required,min=1,max=1000,dive"`
+}
+
+// RejectedPositionResponse identifies a fix that was not accepted by its index in the batch
+type RejectedPositionResponse struct {
+	Index  int    `json:"index"`
+	Reason string `json:"reason" example:"unknown_device"`
+}
+
+// IngestPositionsResponse represents the result of ingesting a batch of fixes
+type IngestPositionsResponse struct {
+	Accepted   int                        `json:"accepted"`
+	Duplicates int                        `json:"duplicates"`
+	Rejected   []RejectedPositionResponse `json:"rejected"`
+}
+
+// FleetPositionsQuery represents query parameters for live fleet positions
+type FleetPositionsQuery struct {
+	VehicleIDs []string `query:"vehicle_ids" validate:"omitempty,max=500,dive,uuid"`
+}
+
+// TrackQuery represents query parameters for a vehicle track
+type TrackQuery struct {
+	From       string   `query:"from" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	To         string   `query:"to" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	ToleranceM *float64 `query:"tolerance_m" validate:"omitempty,min=0,max=1000"`
+}
+
+// PositionResponse represents a GPS fix in responses
+type PositionResponse struct {
+	Timestamp  string   `json:"timestamp"`
+	Latitude   float64  `json:"lat"`
+	Longitude  float64  `json:"lng"`
+	SpeedKmh   *float64 `json:"speed_kmh"`
+	Heading    *float64 `json:"heading"`
+	OdometerKm *float64 `json:"odometer_km"`
+}
+
+// FleetPositionResponse represents the latest known position of a vehicle
+type FleetPositionResponse struct {
+	VehicleID     string           `json:"vehicle_id"`
+	PlateNumber   string           `json:"plate_number"`
+	PlateProvince string           `json:"plate_province"`
+	Position      PositionResponse `json:"position"`
+}
+
+// TrackResponse represents a vehicle's simplified track
+type TrackResponse struct {
+	VehicleID string             `json:"vehicle_id"`
+	From      string             `json:"from"`
+	To        string             `json:"to"`
+	RawPoints int                `json:"raw_points"`
+	DistanceM float64            `json:"distance_m"`
+	Truncated bool               `json:"truncated"`
+	NextFrom  *string            `json:"next_from"` // when truncated, pass as from to continue the track
+	Points    []PositionResponse `json:"points"`
+}
@@ -0,0 +1,70 @@
+package dto
+
+// TripStopRequest represents one pickup or delivery on a trip, in visiting order
+type TripStopRequest struct {
+	Type           string `json:"type" validate:"required,oneof=pickup delivery"`
+	ShipmentID     string `json:"shipment_id" validate:"required,uuid"`
+	PlannedArrival string `json:"planned_arrival" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	ServiceMinutes *int   `json:"service_minutes" validate:"omitempty,min=0,max=1440"`
+}
+
+// TripRequest represents a request to plan or re-plan a trip
+type TripRequest struct {
+	VehicleID    string            `json:"vehicle_id" validate:"required,uuid"`
+	DriverID     string            `json:"driver_id" validate:"required,uuid"`
+	CoDriverID   string            `json:"co_driver_id" validate:"omitempty,uuid"`
+	PlannedStart string            `json:"planned_start" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	PlannedEnd   string            `json:"planned_end" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	Notes        string            `json:"notes" validate:"omitempty,max=1000"`
+	Stops        []TripStopRequest `json:"stops" validate:"required,min=1,dive"`
+}
+
+// UpdateTripStatusRequest represents a request to move a trip through its lifecycle
+type UpdateTripStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=planned dispatched in_progress completed cancelled"`
+}
+
+// ListTripsQuery represents query parameters for listing trips
+type ListTripsQuery struct {
+	PaginationQuery
+	Status    string `query:"status" validate:"omitempty,oneof=planned dispatched in_progress completed cancelled"`
+	VehicleID string `query:"vehicle_id" validate:"omitempty,uuid"`
+	DriverID  string `query:"driver_id" validate:"omitempty,uuid"`
+	From      string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To        string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// TripStopResponse represents a trip stop in responses
+type TripStopResponse struct {
+	ID             string  `json:"id"`
+	Sequence       int     `json:"sequence"`
+	Type           string  `json:"type"`
+	ShipmentID     string  `json:"shipment_id"`
+	LocationID     string  `json:"location_id"`
+	PlannedArrival *string `json:"planned_arrival"`
+	ServiceMinutes *int    `json:"service_minutes"`
+	Status         string  `json:"status"`
+	ArrivedAt      *string `json:"arrived_at"`
+	DepartedAt     *string `json:"departed_at"`
+	ETA            *string `json:"eta"`
+	DelayedAt      *string `json:"delayed_at"`
+}
+
+// TripResponse represents trip information in responses
+type TripResponse struct {
+	ID           string             `json:"id"`
+	TripNumber   string             `json:"trip_number" example:"TRP-2603-00042"`
+	VehicleID    string             `json:"vehicle_id"`
+	DriverID     string             `json:"driver_id"`
+	CoDriverID   *string            `json:"co_driver_id"`
+	Status       string             `json:"status"`
+	PlannedStart string             `json:"planned_start"`
+	PlannedEnd   string             `json:"planned_end"`
+	Notes        string             `json:"notes"`
+	DispatchedBy *string            `json:"dispatched_by"`
+	CarrierID    *string            `json:"carrier_id"` // set once the trip is awarded through a tender
+	TenderID     *string            `json:"tender_id"`
+	Stops        []TripStopResponse `json:"stops"`
+	CreatedAt    string             `json:"created_at"`
+	UpdatedAt    string             `json:"updated_at"`
+}
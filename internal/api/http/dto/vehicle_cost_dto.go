@@ -0,0 +1,134 @@
+package dto
+
+// ReceiptUploadURLRequest represents a request for a presigned URL to upload a receipt photo
+type ReceiptUploadURLRequest struct {
+	ContentType string `json:"content_type" validate:"required,oneof=image/jpeg image/png"`
+}
+
+// FuelLogRequest represents a fuel fill-up to record. Without amount it is worked out from the litres and price;
+// full_tank defaults to true. The receipt key is the object_key returned with the receipt upload URL.
+type FuelLogRequest struct {
+	DriverID      string  `json:"driver_id" validate:"omitempty,uuid"`
+	FilledAt      string  `json:"filled_at" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	Liters        float64 `json:"liters" validate:"gt=0,lte=2000"`
+	PricePerLiter float64 `json:"price_per_liter" validate:"gt=0,lte=1000"`
+	Amount        float64 `json:"amount" validate:"min=0"`
+	OdometerKm    float64 `json:"odometer_km" validate:"min=0"`
+	Station       string  `json:"station" validate:"omitempty,max=255"`
+	FullTank      *bool   `json:"full_tank"`
+	ReceiptKey    string  `json:"receipt_key" validate:"omitempty,max=512"`
+	Notes         string  `json:"notes" validate:"omitempty,max=1000"`
+}
+
+// VehicleExpenseRequest represents a vehicle expense to record
+type VehicleExpenseRequest struct {
+	DriverID    string  `json:"driver_id" validate:"omitempty,uuid"`
+	TripID      string  `json:"trip_id" validate:"omitempty,uuid"`
+	Type        string  `json:"type" validate:"required,oneof=toll parking repair maintenance tyre fine other"`
+	IncurredAt  string  `json:"incurred_at" validate:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	Amount      float64 `json:"amount" validate:"gt=0"`
+	Description string  `json:"description" validate:"omitempty,max=500"`
+	Reference   string  `json:"reference" validate:"omitempty,max=100"`
+	ReceiptKey  string  `json:"receipt_key" validate:"omitempty,max=512"`
+}
+
+// ListFuelLogsQuery represents query parameters for listing a vehicle's fill-ups
+type ListFuelLogsQuery struct {
+	PaginationQuery
+	From string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To   string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// ListVehicleExpensesQuery represents query parameters for listing a vehicle's expenses
+type ListVehicleExpensesQuery struct {
+	PaginationQuery
+	Type string `query:"type" validate:"omitempty,oneof=toll parking repair maintenance tyre fine other"`
+	From string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To   string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// VehicleCostReportQuery represents the period, and optionally the vehicle, of a cost report
+type VehicleCostReportQuery struct {
+	VehicleID  string `query:"vehicle_id" validate:"omitempty,uuid"`
+	PeriodFrom string `query:"period_from" validate:"required,datetime=2006-01-02"`
+	PeriodTo   string `query:"period_to" validate:"required,datetime=2006-01-02"`
+}
+
+// FuelLogResponse represents a fuel fill-up in responses. km_per_liter is null unless the fill-up is a
+// full tank following an earlier full tank; flagged fill-ups deviate from the baseline beyond the tolerance.
+type FuelLogResponse struct {
+	ID                 string   `json:"id"`
+	VehicleID          string   `json:"vehicle_id"`
+	DriverID           *string  `json:"driver_id"`
+	FilledAt           string   `json:"filled_at"`
+	Liters             float64  `json:"liters"`
+	PricePerLiter      float64  `json:"price_per_liter"`
+	Amount             float64  `json:"amount"`
+	OdometerKm         float64  `json:"odometer_km"`
+	Station            string   `json:"station"`
+	FullTank           bool     `json:"full_tank"`
+	ReceiptKey         string   `json:"receipt_key"`
+	ReceiptURL         string   `json:"receipt_url"`
+	Notes              string   `json:"notes"`
+	DistanceKm         float64  `json:"distance_km"`
+	KmPerLiter         *float64 `json:"km_per_liter"`
+	BaselineKmPerLiter float64  `json:"baseline_km_per_liter"`
+	Deviation          *float64 `json:"deviation"`
+	Flagged            bool     `json:"flagged"`
+	CreatedBy          *string  `json:"created_by"`
+	CreatedAt          string   `json:"created_at"`
+	UpdatedAt          string   `json:"updated_at"`
+}
+
+// VehicleExpenseResponse represents a vehicle expense in responses
+type VehicleExpenseResponse struct {
+	ID          string  `json:"id"`
+	VehicleID   string  `json:"vehicle_id"`
+	DriverID    *string `json:"driver_id"`
+	TripID      *string `json:"trip_id"`
+	Type        string  `json:"type"`
+	IncurredAt  string  `json:"incurred_at"`
+	Amount      float64 `json:"amount"`
+	Description string  `json:"description"`
+	Reference   string  `json:"reference"`
+	ReceiptKey  string  `json:"receipt_key"`
+	ReceiptURL  string  `json:"receipt_url"`
+	CreatedBy   *string `json:"created_by"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+// ExpenseTotalResponse represents a vehicle's expenses of one type in a cost report
+type ExpenseTotalResponse struct {
+	Type   string  `json:"type"`
+	Count  int     `json:"count"`
+	Amount float64 `json:"amount"`
+}
+
+// VehicleCostResponse represents a vehicle's running costs over a report period
+type VehicleCostResponse struct {
+	VehicleID          string                 `json:"vehicle_id"`
+	PlateNumber        string                 `json:"plate_number"`
+	VehicleType        string                 `json:"vehicle_type"`
+	Fills              int                    `json:"fills"`
+	Liters             float64                `json:"liters"`
+	FuelCost           float64                `json:"fuel_cost"`
+	DistanceKm         float64                `json:"distance_km"`
+	KmPerLiter         *float64               `json:"km_per_liter"`
+	BaselineKmPerLiter float64                `json:"baseline_km_per_liter"`
+	FlaggedFills       int                    `json:"flagged_fills"`
+	Expenses           []ExpenseTotalResponse `json:"expenses"`
+	ExpenseCost        float64                `json:"expense_cost"`
+	TotalCost          float64                `json:"total_cost"`
+	CostPerKm          *float64               `json:"cost_per_km"`
+}
+
+// VehicleCostReportResponse represents running costs per vehicle over a period
+type VehicleCostReportResponse struct {
+	PeriodFrom  string                `json:"period_from"`
+	PeriodTo    string                `json:"period_to"`
+	Vehicles    []VehicleCostResponse `json:"vehicles"`
+	FuelCost    float64               `json:"fuel_cost"`
+	ExpenseCost float64               `json:"expense_cost"`
+	TotalCost   float64               `json:"total_cost"`
+}
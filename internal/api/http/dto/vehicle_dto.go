@@ -0,0 +1,50 @@
+package dto
+
+// VehicleRequest represents a request to register or update a vehicle
+type VehicleRequest struct {
+	PlateNumber   string  `json:"plate_number" validate:"required,max=20"`
+	PlateProvince string  `json:"plate_province" validate:"required,max=100"`
+	Type          string  `json:"type" validate:"required,oneof=4w 6w 10w 18w"`
+	Brand         string  `json:"brand" validate:"omitempty,max=100"`
+	Model         string  `json:"model" validate:"omitempty,max=100"`
+	Year          int     `json:"year" validate:"omitempty,min=1950,max=2100"`
+	MaxWeightKg   float64 `json:"max_weight_kg" validate:"required,gt=0"`
+	MaxVolumeM3   float64 `json:"max_volume_m3" validate:"omitempty,gte=0"`
+	MaxPallets    int     `json:"max_pallets" validate:"omitempty,gte=0"`
+	HomeDepot     string  `json:"home_depot" validate:"omitempty,max=255"`
+	GPSDeviceID   string  `json:"gps_device_id" validate:"omitempty,max=64"`
+	FuelBaseline  float64 `json:"fuel_baseline_km_per_liter" validate:"omitempty,gte=0,lte=100"`
+}
+
+// UpdateVehicleStatusRequest represents a request to change a vehicle's status
+type UpdateVehicleStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=active maintenance inactive"`
+}
+
+// ListVehiclesQuery represents query parameters for listing vehicles
+type ListVehiclesQuery struct {
+	PaginationQuery
+	Status string `query:"status" validate:"omitempty,oneof=active maintenance inactive"`
+	Type   string `query:"type" validate:"omitempty,oneof=4w 6w 10w 18w"`
+	Search string `query:"search" validate:"omitempty,max=100"`
+}
+
+// VehicleResponse represents vehicle information in responses
+type VehicleResponse struct {
+	ID            string  `json:"id"`
+	PlateNumber   string  `json:"plate_number"`
+	PlateProvince string  `json:"plate_province"`
+	Type          string  `json:"type"`
+	Brand         string  `json:"brand"`
+	Model         string  `json:"model"`
+	Year          int     `json:"year"`
+	MaxWeightKg   float64 `json:"max_weight_kg"`
+	MaxVolumeM3   float64 `json:"max_volume_m3"`
+	MaxPallets    int     `json:"max_pallets"`
+	HomeDepot     string  `json:"home_depot"`
+	GPSDeviceID   string  `json:"gps_device_id"`
+	FuelBaseline  float64 `json:"fuel_baseline_km_per_liter"`
+	Status        string  `json:"status"`
+	CreatedAt     string  `json:"created_at"`
+	UpdatedAt     string  `json:"updated_at"`
+}
@@ -0,0 +1,376 @@
+package carrier
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/carrier"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles carrier requests
+type Handler struct {
+	useCase *carrier.CarrierUseCase
+}
+
+// NewHandler creates a new carrier handler
+func NewHandler(useCase *carrier.CarrierUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Create godoc
+// @Summary Create carrier
+// @Description Register a subcontracted carrier
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CarrierRequest true "Carrier details"
+// @Success 201 {object} httpresponse.Response{data=dto.CarrierResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carriers [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req dto.CarrierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input, err := toCarrierInput(req)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Create(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toCarrierResponse(result), "Carrier created successfully")
+}
+
+// List godoc
+// @Summary List carriers
+// @Description List carriers by status, fleet type and service area
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param status query string false "Status" Enums(active, suspended)
+// @Param vehicle_type query string false "Operates vehicle type" Enums(4w, 6w, 10w, 18w)
+// @Param province query string false "Serves province"
+// @Param search query string false "Search by name or tax ID"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.CarrierResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carriers [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	var query dto.ListCarriersQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := carrier.ListCarriersInput{
+		Province: query.Province,
+		Search:   query.Search,
+		Limit:    query.GetLimit(),
+		Offset:   query.Offset,
+	}
+	if query.Status != "" {
+		status := entity.CarrierStatus(query.Status)
+		input.Status = &status
+	}
+	if query.VehicleType != "" {
+		vehicleType := entity.VehicleType(query.VehicleType)
+		input.VehicleType = &vehicleType
+	}
+
+	results, total, err := h.useCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.CarrierResponse, len(results))
+	for i, r := range results {
+		data[i] = toCarrierResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// Get godoc
+// @Summary Get carrier
+// @Description Get a carrier by ID
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Carrier ID"
+// @Success 200 {object} httpresponse.Response{data=dto.CarrierResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carriers/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid carrier ID"))
+	}
+
+	result, err := h.useCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toCarrierResponse(result), "Carrier retrieved successfully")
+}
+
+// Update godoc
+// @Summary Update carrier
+// @Description Update a carrier
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Carrier ID"
+// @Param request body dto.CarrierRequest true "Carrier details"
+// @Success 200 {object} httpresponse.Response{data=dto.CarrierResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carriers/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid carrier ID"))
+	}
+
+	var req dto.CarrierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input, err := toCarrierInput(req)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Update(c.Context(), id, input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toCarrierResponse(result), "Carrier updated successfully")
+}
+
+// Delete godoc
+// @Summary Delete carrier
+// @Description Soft delete a carrier
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Carrier ID"
+// @Success 200 {object} httpresponse.Response
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carriers/{id} [delete]
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid carrier ID"))
+	}
+
+	if err := h.useCase.Delete(c.Context(), id); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, nil, "Carrier deleted successfully")
+}
+
+// ListUsers godoc
+// @Summary List carrier users
+// @Description List the user accounts that act for a carrier on the carrier API
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Carrier ID"
+// @Success 200 {object} httpresponse.Response{data=[]dto.CarrierUserResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carriers/{id}/users [get]
+func (h *Handler) ListUsers(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid carrier ID"))
+	}
+
+	results, err := h.useCase.ListUsers(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.CarrierUserResponse, len(results))
+	for i, r := range results {
+		data[i] = toCarrierUserResponse(r)
+	}
+
+	return httpresponse.Success(c, data, "Carrier users retrieved successfully")
+}
+
+// AddUser godoc
+// @Summary Add carrier user
+// @Description Let an existing user account act for the carrier. A user acts for at most one carrier.
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Carrier ID"
+// @Param request body dto.AddCarrierUserRequest true "User"
+// @Success 201 {object} httpresponse.Response{data=dto.CarrierUserResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carriers/{id}/users [post]
+func (h *Handler) AddUser(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid carrier ID"))
+	}
+
+	var req dto.AddCarrierUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.AddUser(c.Context(), id, uuid.MustParse(req.UserID))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toCarrierUserResponse(result), "Carrier user added successfully")
+}
+
+// RemoveUser godoc
+// @Summary Remove carrier user
+// @Description Revoke a user account's access to the carrier API
+// @Tags carriers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Carrier ID"
+// @Param userId path string true "User ID"
+// @Success 200 {object} httpresponse.Response
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carriers/{id}/users/{userId} [delete]
+func (h *Handler) RemoveUser(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid carrier ID"))
+	}
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid user ID"))
+	}
+
+	if err := h.useCase.RemoveUser(c.Context(), id, userID); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, nil, "Carrier user removed successfully")
+}
+
+func toCarrierInput(req dto.CarrierRequest) (carrier.CarrierInput, error) {
+	input := carrier.CarrierInput{
+		Name:         req.Name,
+		TaxID:        req.TaxID,
+		BranchCode:   req.BranchCode,
+		ContactName:  req.ContactName,
+		ContactPhone: req.ContactPhone,
+		ContactEmail: req.ContactEmail,
+		FleetTypes:   make([]entity.VehicleType, len(req.FleetTypes)),
+		ServiceAreas: req.ServiceAreas,
+		Status:       entity.CarrierStatus(req.Status),
+	}
+	for i, t := range req.FleetTypes {
+		input.FleetTypes[i] = entity.VehicleType(t)
+	}
+	if req.InsuranceExpiry != "" {
+		expiry, err := time.Parse(dto.DateLayout, req.InsuranceExpiry)
+		if err != nil {
+			return carrier.CarrierInput{}, apierror.NewBadRequestError("Invalid insurance expiry date")
+		}
+		input.InsuranceExpiry = &expiry
+	}
+	return input, nil
+}
+
+func toCarrierResponse(c *carrier.CarrierOutput) dto.CarrierResponse {
+	resp := dto.CarrierResponse{
+		ID:           c.ID.String(),
+		Name:         c.Name,
+		TaxID:        c.TaxID,
+		BranchCode:   c.BranchCode,
+		ContactName:  c.ContactName,
+		ContactPhone: c.ContactPhone,
+		ContactEmail: c.ContactEmail,
+		FleetTypes:   make([]string, len(c.FleetTypes)),
+		ServiceAreas: c.ServiceAreas,
+		Insured:      c.Insured,
+		Status:       string(c.Status),
+		CreatedAt:    c.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    c.UpdatedAt.Format(time.RFC3339),
+	}
+	for i, t := range c.FleetTypes {
+		resp.FleetTypes[i] = string(t)
+	}
+	if resp.ServiceAreas == nil {
+		resp.ServiceAreas = []string{}
+	}
+	if c.InsuranceExpiry != nil {
+		expiry := c.InsuranceExpiry.Format(dto.DateLayout)
+		resp.InsuranceExpiry = &expiry
+	}
+	return resp
+}
+
+func toCarrierUserResponse(u *carrier.CarrierUserOutput) dto.CarrierUserResponse {
+	return dto.CarrierUserResponse{
+		UserID:      u.UserID.String(),
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		Email:       u.Email,
+		PhoneNumber: u.PhoneNumber,
+	}
+}
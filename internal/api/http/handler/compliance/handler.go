@@ -0,0 +1,334 @@
+package compliance
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/api/http/middleware"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/compliance"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles compliance document requests
+type Handler struct {
+	useCase *compliance.ComplianceUseCase
+}
+
+// NewHandler creates a new compliance handler
+func NewHandler(useCase *compliance.ComplianceUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// UploadURL godoc
+// @Summary Get compliance document upload URL
+// @Description Get a presigned URL to upload the scan of a vehicle's, driver's or carrier's document.
+// @Description Pass the returned object_key as file_key when recording the document.
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.ComplianceUploadURLRequest true "Owner and content type"
+// @Success 200 {object} httpresponse.Response{data=dto.PresignUploadResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/compliance-documents/upload-url [post]
+func (h *Handler) UploadURL(c *fiber.Ctx) error {
+	var req dto.ComplianceUploadURLRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.UploadURL(c.Context(), entity.ComplianceOwnerType(req.OwnerType), uuid.MustParse(req.OwnerID), req.ContentType)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, dto.PresignUploadResponse{
+		UploadURL: result.UploadURL,
+		ObjectKey: result.ObjectKey,
+	}, "Upload URL generated successfully")
+}
+
+// Create godoc
+// @Summary Create compliance document
+// @Description Record a vehicle's, driver's or carrier's registration, insurance, tax, certificate or license.
+// @Description A document expiring after the owner's others of its type supersedes them.
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateComplianceDocumentRequest true "Compliance document"
+// @Success 201 {object} httpresponse.Response{data=dto.ComplianceDocumentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/compliance-documents [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	var req dto.CreateComplianceDocumentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	expiresOn, _ := time.Parse(dto.DateLayout, req.ExpiresOn)
+	result, err := h.useCase.Create(c.Context(), compliance.DocumentInput{
+		OwnerType: entity.ComplianceOwnerType(req.OwnerType),
+		OwnerID:   uuid.MustParse(req.OwnerID),
+		Type:      entity.ComplianceDocumentType(req.Type),
+		Number:    req.Number,
+		IssuedOn:  parseOptionalDate(req.IssuedOn),
+		ExpiresOn: expiresOn,
+		FileKey:   req.FileKey,
+		Notes:     req.Notes,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toDocumentResponse(result), "Compliance document created successfully")
+}
+
+// List godoc
+// @Summary List compliance documents
+// @Description List compliance documents, soonest expiry first. expiring_within keeps the documents that count
+// @Description for their owner, superseded ones left out, and expire within as many days, expired ones included.
+// @Tags compliance
+// @Produce json
+// @Security Bearer
+// @Param owner_type query string false "Owner type" Enums(vehicle, driver, carrier)
+// @Param owner_id query string false "Owner ID"
+// @Param type query string false "Document type"
+// @Param expiring_within query int false "Days until expiry"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} httpresponse.Response{data=[]dto.ComplianceDocumentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/compliance-documents [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	var query dto.ListComplianceDocumentsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := compliance.ListDocumentsInput{
+		OwnerID:        parseOptionalID(query.OwnerID),
+		ExpiringWithin: query.ExpiringWithin,
+		Limit:          query.GetLimit(),
+		Offset:         query.Offset,
+	}
+	if query.OwnerType != "" {
+		ownerType := entity.ComplianceOwnerType(query.OwnerType)
+		input.OwnerType = &ownerType
+	}
+	if query.Type != "" {
+		docType := entity.ComplianceDocumentType(query.Type)
+		input.Type = &docType
+	}
+
+	results, total, err := h.useCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.ComplianceDocumentResponse, len(results))
+	for i, r := range results {
+		data[i] = toDocumentResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// Get godoc
+// @Summary Get compliance document
+// @Description Get a compliance document by ID with a link to download its file
+// @Tags compliance
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Compliance document ID"
+// @Success 200 {object} httpresponse.Response{data=dto.ComplianceDocumentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/compliance-documents/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid compliance document ID"))
+	}
+
+	result, err := h.useCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toDocumentResponse(result), "Compliance document retrieved successfully")
+}
+
+// Update godoc
+// @Summary Update compliance document
+// @Description Replace the details of a compliance document; its owner and type cannot change.
+// @Description A new expiry date starts its reminders over.
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Compliance document ID"
+// @Param request body dto.UpdateComplianceDocumentRequest true "Compliance document"
+// @Success 200 {object} httpresponse.Response{data=dto.ComplianceDocumentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/compliance-documents/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid compliance document ID"))
+	}
+
+	var req dto.UpdateComplianceDocumentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	expiresOn, _ := time.Parse(dto.DateLayout, req.ExpiresOn)
+	result, err := h.useCase.Update(c.Context(), id, compliance.UpdateDocumentInput{
+		Number:    req.Number,
+		IssuedOn:  parseOptionalDate(req.IssuedOn),
+		ExpiresOn: expiresOn,
+		FileKey:   req.FileKey,
+		Notes:     req.Notes,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toDocumentResponse(result), "Compliance document updated successfully")
+}
+
+// Delete godoc
+// @Summary Delete compliance document
+// @Description Delete a compliance document, e.g. one recorded in error; the owner's previous document of its type counts again
+// @Tags compliance
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Compliance document ID"
+// @Success 200 {object} httpresponse.Response
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/compliance-documents/{id} [delete]
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid compliance document ID"))
+	}
+
+	if err := h.useCase.Delete(c.Context(), id); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, nil, "Compliance document deleted successfully")
+}
+
+// SendReminders godoc
+// @Summary Send compliance reminders
+// @Description Remind of expiring and expired compliance documents now rather than at the daily run.
+// @Description Each reminder stage of a document is announced once on its owner's realtime channel.
+// @Tags compliance
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} httpresponse.Response{data=dto.ComplianceReminderResponse}
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/compliance/reminders [post]
+func (h *Handler) SendReminders(c *fiber.Ctx) error {
+	sent, err := h.useCase.SendReminders(c.Context())
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, dto.ComplianceReminderResponse{Sent: sent}, "Compliance reminders sent successfully")
+}
+
+func parseOptionalID(value string) *uuid.UUID {
+	if value == "" {
+		return nil
+	}
+	id := uuid.MustParse(value)
+	return &id
+}
+
+func formatOptionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func parseOptionalDate(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, _ := time.Parse(dto.DateLayout, value)
+	return &t
+}
+
+func toDocumentResponse(d *compliance.DocumentOutput) dto.ComplianceDocumentResponse {
+	var issuedOn *string
+	if d.IssuedOn != nil {
+		s := d.IssuedOn.Format(dto.DateLayout)
+		issuedOn = &s
+	}
+
+	return dto.ComplianceDocumentResponse{
+		ID:           d.ID.String(),
+		OwnerType:    string(d.OwnerType),
+		OwnerID:      d.OwnerID.String(),
+		Type:         string(d.Type),
+		Number:       d.Number,
+		IssuedOn:     issuedOn,
+		ExpiresOn:    d.ExpiresOn.Format(dto.DateLayout),
+		DaysLeft:     d.DaysLeft,
+		Status:       string(d.Status),
+		FileKey:      d.FileKey,
+		FileURL:      d.FileURL,
+		Notes:        d.Notes,
+		RemindedDays: d.RemindedDays,
+		RemindedAt:   dto.FormatTimestamp(d.RemindedAt),
+		CreatedBy:    formatOptionalID(d.CreatedBy),
+		CreatedAt:    d.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    d.UpdatedAt.Format(time.RFC3339),
+	}
+}
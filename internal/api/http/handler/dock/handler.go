@@ -0,0 +1,910 @@
+package dock
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/api/http/middleware"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/dock"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles dock and dock appointment requests
+type Handler struct {
+	dockUseCase        *dock.DockUseCase
+	appointmentUseCase *dock.AppointmentUseCase
+}
+
+// NewHandler creates a new dock handler
+func NewHandler(dockUseCase *dock.DockUseCase, appointmentUseCase *dock.AppointmentUseCase) *Handler {
+	return &Handler{dockUseCase: dockUseCase, appointmentUseCase: appointmentUseCase}
+}
+
+// Create godoc
+// @Summary Create dock
+// @Description Add a loading bay to a site with its operating hours, slot length and how many trucks it serves at once
+// @Tags docks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.DockRequest true "Dock"
+// @Success 201 {object} httpresponse.Response{data=dto.DockResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/docks [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req dto.DockRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.dockUseCase.Create(c.Context(), toDockInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toDockResponse(result), "Dock created successfully")
+}
+
+// List godoc
+// @Summary List docks
+// @Description List docks by site and name. direction keeps the docks serving it, including those serving both.
+// @Tags docks
+// @Produce json
+// @Security Bearer
+// @Param location_id query string false "Site (location) ID"
+// @Param direction query string false "Direction" Enums(inbound, outbound)
+// @Param active query bool false "Active"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} httpresponse.Response{data=[]dto.DockResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/docks [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	input, err := parseListDocksQuery(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	results, total, err := h.dockUseCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.DockResponse, len(results))
+	for i, r := range results {
+		data[i] = toDockResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// Get godoc
+// @Summary Get dock
+// @Description Get a dock by ID
+// @Tags docks
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Dock ID"
+// @Success 200 {object} httpresponse.Response{data=dto.DockResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/docks/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid dock ID"))
+	}
+
+	result, err := h.dockUseCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toDockResponse(result), "Dock retrieved successfully")
+}
+
+// Update godoc
+// @Summary Update dock
+// @Description Replace a dock's details. Appointments already booked are kept even if they no longer fit its hours.
+// @Tags docks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Dock ID"
+// @Param request body dto.DockRequest true "Dock"
+// @Success 200 {object} httpresponse.Response{data=dto.DockResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/docks/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid dock ID"))
+	}
+
+	var req dto.DockRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.dockUseCase.Update(c.Context(), id, toDockInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toDockResponse(result), "Dock updated successfully")
+}
+
+// Slots godoc
+// @Summary List dock slots
+// @Description List a dock's slots on a day with how many trucks are booked in each.
+// @Description A slot is available while the dock is active, has room in it and the slot has not started.
+// @Tags docks
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Dock ID"
+// @Param date query string true "Day (YYYY-MM-DD)"
+// @Success 200 {object} httpresponse.Response{data=[]dto.DockSlotResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/docks/{id}/slots [get]
+func (h *Handler) Slots(c *fiber.Ctx) error {
+	return h.slots(c)
+}
+
+// CarrierListDocks godoc
+// @Summary List docks
+// @Description List the active docks carriers can book, by site and name
+// @Tags carrier-portal
+// @Produce json
+// @Security Bearer
+// @Param location_id query string false "Site (location) ID"
+// @Param direction query string false "Direction" Enums(inbound, outbound)
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} httpresponse.Response{data=[]dto.DockResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carrier/docks [get]
+func (h *Handler) CarrierListDocks(c *fiber.Ctx) error {
+	input, err := parseListDocksQuery(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+	active := true
+	input.Active = &active
+
+	results, total, err := h.dockUseCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.DockResponse, len(results))
+	for i, r := range results {
+		data[i] = toDockResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// CarrierSlots godoc
+// @Summary List dock slots
+// @Description List a dock's slots on a day with how many trucks are booked in each, to find an open slot to book
+// @Tags carrier-portal
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Dock ID"
+// @Param date query string true "Day (YYYY-MM-DD)"
+// @Success 200 {object} httpresponse.Response{data=[]dto.DockSlotResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carrier/docks/{id}/slots [get]
+func (h *Handler) CarrierSlots(c *fiber.Ctx) error {
+	return h.slots(c)
+}
+
+// Book godoc
+// @Summary Book dock appointment
+// @Description Book a dock for a truck. The appointment must start on a slot boundary within the dock's operating hours,
+// @Description the dock must have room in every slot it covers, and a trip cannot be booked at two docks at the same time.
+// @Description Concurrent bookings of a dock are serialized, so a slot is never booked beyond the dock's capacity.
+// @Tags docks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.BookDockAppointmentRequest true "Appointment"
+// @Success 201 {object} httpresponse.Response{data=dto.DockAppointmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/dock-appointments [post]
+func (h *Handler) Book(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	input, err := parseBookRequest(c, userID)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.appointmentUseCase.Book(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toAppointmentResponse(result), "Dock appointment booked successfully")
+}
+
+// CarrierBook godoc
+// @Summary Book dock appointment
+// @Description Book an open dock slot for a trip awarded to the current user's carrier. The same slot and capacity
+// @Description rules apply as for staff bookings.
+// @Tags carrier-portal
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.BookDockAppointmentRequest true "Appointment; trip_id is required"
+// @Success 201 {object} httpresponse.Response{data=dto.DockAppointmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carrier/dock-appointments [post]
+func (h *Handler) CarrierBook(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	input, err := parseBookRequest(c, userID)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.appointmentUseCase.CarrierBook(c.Context(), userID, input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toAppointmentResponse(result), "Dock appointment booked successfully")
+}
+
+// ListAppointments godoc
+// @Summary List dock appointments
+// @Description List dock appointments by booked start
+// @Tags docks
+// @Produce json
+// @Security Bearer
+// @Param location_id query string false "Site (location) ID"
+// @Param dock_id query string false "Dock ID"
+// @Param trip_id query string false "Trip ID"
+// @Param status query string false "Status" Enums(booked, checked_in, completed, cancelled, no_show)
+// @Param from query string false "Booked start from (RFC 3339)"
+// @Param to query string false "Booked start before (RFC 3339)"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} httpresponse.Response{data=[]dto.DockAppointmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/dock-appointments [get]
+func (h *Handler) ListAppointments(c *fiber.Ctx) error {
+	input, err := parseListAppointmentsQuery(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	results, total, err := h.appointmentUseCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Paginated(c, toAppointmentResponses(results), total, input.Limit, input.Offset)
+}
+
+// CarrierListAppointments godoc
+// @Summary List my dock appointments
+// @Description List the dock appointments booked by the current user's carrier, by booked start
+// @Tags carrier-portal
+// @Produce json
+// @Security Bearer
+// @Param location_id query string false "Site (location) ID"
+// @Param dock_id query string false "Dock ID"
+// @Param trip_id query string false "Trip ID"
+// @Param status query string false "Status" Enums(booked, checked_in, completed, cancelled, no_show)
+// @Param from query string false "Booked start from (RFC 3339)"
+// @Param to query string false "Booked start before (RFC 3339)"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} httpresponse.Response{data=[]dto.DockAppointmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carrier/dock-appointments [get]
+func (h *Handler) CarrierListAppointments(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	input, err := parseListAppointmentsQuery(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	results, total, err := h.appointmentUseCase.CarrierList(c.Context(), userID, input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Paginated(c, toAppointmentResponses(results), total, input.Limit, input.Offset)
+}
+
+// GetAppointment godoc
+// @Summary Get dock appointment
+// @Description Get a dock appointment by ID
+// @Tags docks
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Dock appointment ID"
+// @Success 200 {object} httpresponse.Response{data=dto.DockAppointmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/dock-appointments/{id} [get]
+func (h *Handler) GetAppointment(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid dock appointment ID"))
+	}
+
+	result, err := h.appointmentUseCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toAppointmentResponse(result), "Dock appointment retrieved successfully")
+}
+
+// Reschedule godoc
+// @Summary Reschedule dock appointment
+// @Description Move a booked appointment to another period, or another dock at the same site, under the booking rules
+// @Tags docks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Dock appointment ID"
+// @Param request body dto.RescheduleDockAppointmentRequest true "New dock or period"
+// @Success 200 {object} httpresponse.Response{data=dto.DockAppointmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/dock-appointments/{id} [put]
+func (h *Handler) Reschedule(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid dock appointment ID"))
+	}
+
+	var req dto.RescheduleDockAppointmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	startAt, err := time.Parse(time.RFC3339, req.StartAt)
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid start time"))
+	}
+
+	result, err := h.appointmentUseCase.Reschedule(c.Context(), id, dock.RescheduleInput{
+		DockID:  parseOptionalID(req.DockID),
+		StartAt: startAt,
+		EndAt:   dto.ParseTimestamp(req.EndAt),
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toAppointmentResponse(result), "Dock appointment rescheduled successfully")
+}
+
+// CheckIn godoc
+// @Summary Check in truck
+// @Description Record the truck's arrival at the site; the wait is measured from the booked start
+// @Tags docks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Dock appointment ID"
+// @Param request body dto.DockCheckRequest false "Arrival time; now when empty"
+// @Success 200 {object} httpresponse.Response{data=dto.DockAppointmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/dock-appointments/{id}/check-in [post]
+func (h *Handler) CheckIn(c *fiber.Ctx) error {
+	id, at, err := parseCheckRequest(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.appointmentUseCase.CheckIn(c.Context(), id, at)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toAppointmentResponse(result), "Truck checked in successfully")
+}
+
+// CheckOut godoc
+// @Summary Check out truck
+// @Description Record the truck leaving the site, completing the appointment; the turnaround is measured from check-in
+// @Tags docks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Dock appointment ID"
+// @Param request body dto.DockCheckRequest false "Departure time; now when empty"
+// @Success 200 {object} httpresponse.Response{data=dto.DockAppointmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/dock-appointments/{id}/check-out [post]
+func (h *Handler) CheckOut(c *fiber.Ctx) error {
+	id, at, err := parseCheckRequest(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.appointmentUseCase.CheckOut(c.Context(), id, at)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toAppointmentResponse(result), "Truck checked out successfully")
+}
+
+// Cancel godoc
+// @Summary Cancel dock appointment
+// @Description Cancel a booked appointment, releasing its slots
+// @Tags docks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Dock appointment ID"
+// @Param request body dto.CancelDockAppointmentRequest false "Reason"
+// @Success 200 {object} httpresponse.Response{data=dto.DockAppointmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/dock-appointments/{id}/cancel [post]
+func (h *Handler) Cancel(c *fiber.Ctx) error {
+	id, reason, err := parseCancelRequest(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.appointmentUseCase.Cancel(c.Context(), id, reason)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toAppointmentResponse(result), "Dock appointment cancelled successfully")
+}
+
+// CarrierCancel godoc
+// @Summary Cancel my dock appointment
+// @Description Cancel an appointment booked by the current user's carrier, releasing its slots
+// @Tags carrier-portal
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Dock appointment ID"
+// @Param request body dto.CancelDockAppointmentRequest false "Reason"
+// @Success 200 {object} httpresponse.Response{data=dto.DockAppointmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carrier/dock-appointments/{id}/cancel [post]
+func (h *Handler) CarrierCancel(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	id, reason, err := parseCancelRequest(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.appointmentUseCase.CarrierCancel(c.Context(), userID, id, reason)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toAppointmentResponse(result), "Dock appointment cancelled successfully")
+}
+
+// NoShow godoc
+// @Summary Mark dock appointment no-show
+// @Description Release the slots of an appointment the truck never came to
+// @Tags docks
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Dock appointment ID"
+// @Success 200 {object} httpresponse.Response{data=dto.DockAppointmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/dock-appointments/{id}/no-show [post]
+func (h *Handler) NoShow(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid dock appointment ID"))
+	}
+
+	result, err := h.appointmentUseCase.MarkNoShow(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toAppointmentResponse(result), "Dock appointment marked as no-show successfully")
+}
+
+// Turnaround godoc
+// @Summary Dock turnaround report
+// @Description Report per dock how many trucks were booked over a period, how many came, and their average wait
+// @Description after the booked start and average and longest time at the site
+// @Tags docks
+// @Produce json
+// @Security Bearer
+// @Param location_id query string false "Site (location) ID"
+// @Param dock_id query string false "Dock ID"
+// @Param from query string true "Booked start from (RFC 3339)"
+// @Param to query string true "Booked start before (RFC 3339)"
+// @Success 200 {object} httpresponse.Response{data=[]dto.DockTurnaroundResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/dock-appointments/turnaround [get]
+func (h *Handler) Turnaround(c *fiber.Ctx) error {
+	var query dto.DockTurnaroundQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	from, err := time.Parse(time.RFC3339, query.From)
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid period start"))
+	}
+	to, err := time.Parse(time.RFC3339, query.To)
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid period end"))
+	}
+
+	results, err := h.appointmentUseCase.Turnaround(c.Context(), dock.TurnaroundInput{
+		LocationID: parseOptionalID(query.LocationID),
+		DockID:     parseOptionalID(query.DockID),
+		From:       from,
+		To:         to,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.DockTurnaroundResponse, len(results))
+	for i, r := range results {
+		data[i] = dto.DockTurnaroundResponse{
+			DockID:               r.DockID.String(),
+			DockName:             r.DockName,
+			LocationID:           r.LocationID.String(),
+			Appointments:         r.Appointments,
+			Completed:            r.Completed,
+			NoShows:              r.NoShows,
+			Cancelled:            r.Cancelled,
+			AvgWaitMinutes:       r.AvgWaitMinutes,
+			AvgTurnaroundMinutes: r.AvgTurnaroundMinutes,
+			MaxTurnaroundMinutes: r.MaxTurnaroundMinutes,
+		}
+	}
+
+	return httpresponse.Success(c, data, "Turnaround report generated successfully")
+}
+
+func (h *Handler) slots(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid dock ID"))
+	}
+
+	var query dto.DockSlotsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	day, err := time.Parse(dto.DateLayout, query.Date)
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid date"))
+	}
+
+	results, err := h.dockUseCase.Slots(c.Context(), id, day)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.DockSlotResponse, len(results))
+	for i, s := range results {
+		data[i] = dto.DockSlotResponse{
+			Start:     s.Start.Format(time.RFC3339),
+			End:       s.End.Format(time.RFC3339),
+			Booked:    s.Booked,
+			Capacity:  s.Capacity,
+			Available: s.Available,
+		}
+	}
+
+	return httpresponse.Success(c, data, "Dock slots retrieved successfully")
+}
+
+func parseListDocksQuery(c *fiber.Ctx) (dock.ListDocksInput, error) {
+	var query dto.ListDocksQuery
+	if err := c.QueryParser(&query); err != nil {
+		return dock.ListDocksInput{}, err
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return dock.ListDocksInput{}, err
+	}
+
+	input := dock.ListDocksInput{
+		LocationID: parseOptionalID(query.LocationID),
+		Active:     query.Active,
+		Limit:      query.GetLimit(),
+		Offset:     query.Offset,
+	}
+	if query.Direction != "" {
+		direction := entity.DockDirection(query.Direction)
+		input.Direction = &direction
+	}
+	return input, nil
+}
+
+func parseBookRequest(c *fiber.Ctx, userID uuid.UUID) (dock.AppointmentInput, error) {
+	var req dto.BookDockAppointmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return dock.AppointmentInput{}, err
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return dock.AppointmentInput{}, err
+	}
+
+	startAt, err := time.Parse(time.RFC3339, req.StartAt)
+	if err != nil {
+		return dock.AppointmentInput{}, apierror.NewBadRequestError("Invalid start time")
+	}
+
+	return dock.AppointmentInput{
+		DockID:      uuid.MustParse(req.DockID),
+		Direction:   entity.DockDirection(req.Direction),
+		TripID:      parseOptionalID(req.TripID),
+		VehicleType: entity.VehicleType(req.VehicleType),
+		PlateNumber: req.PlateNumber,
+		DriverName:  req.DriverName,
+		Reference:   req.Reference,
+		StartAt:     startAt,
+		EndAt:       dto.ParseTimestamp(req.EndAt),
+		Notes:       req.Notes,
+		CreatedBy:   userID,
+	}, nil
+}
+
+func parseListAppointmentsQuery(c *fiber.Ctx) (dock.ListAppointmentsInput, error) {
+	var query dto.ListDockAppointmentsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return dock.ListAppointmentsInput{}, err
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return dock.ListAppointmentsInput{}, err
+	}
+
+	input := dock.ListAppointmentsInput{
+		LocationID: parseOptionalID(query.LocationID),
+		DockID:     parseOptionalID(query.DockID),
+		TripID:     parseOptionalID(query.TripID),
+		From:       dto.ParseTimestamp(query.From),
+		To:         dto.ParseTimestamp(query.To),
+		Limit:      query.GetLimit(),
+		Offset:     query.Offset,
+	}
+	if query.Status != "" {
+		status := entity.DockAppointmentStatus(query.Status)
+		input.Status = &status
+	}
+	return input, nil
+}
+
+func parseCheckRequest(c *fiber.Ctx) (uuid.UUID, *time.Time, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, nil, apierror.NewBadRequestError("Invalid dock appointment ID")
+	}
+
+	var req dto.DockCheckRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return uuid.Nil, nil, err
+		}
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return uuid.Nil, nil, err
+	}
+
+	return id, dto.ParseTimestamp(req.At), nil
+}
+
+func parseCancelRequest(c *fiber.Ctx) (uuid.UUID, string, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, "", apierror.NewBadRequestError("Invalid dock appointment ID")
+	}
+
+	var req dto.CancelDockAppointmentRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return uuid.Nil, "", err
+		}
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return uuid.Nil, "", err
+	}
+
+	return id, req.Reason, nil
+}
+
+func parseOptionalID(value string) *uuid.UUID {
+	if value == "" {
+		return nil
+	}
+	id := uuid.MustParse(value)
+	return &id
+}
+
+func formatOptionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func toDockInput(req dto.DockRequest) dock.DockInput {
+	hours := make([]entity.OpeningHours, len(req.OperatingHours))
+	for i, h := range req.OperatingHours {
+		hours[i] = entity.OpeningHours{Weekday: time.Weekday(h.Weekday), Open: h.Open, Close: h.Close}
+	}
+	vehicleTypes := make([]entity.VehicleType, len(req.VehicleTypes))
+	for i, t := range req.VehicleTypes {
+		vehicleTypes[i] = entity.VehicleType(t)
+	}
+
+	return dock.DockInput{
+		LocationID:     uuid.MustParse(req.LocationID),
+		Name:           req.Name,
+		Code:           req.Code,
+		Direction:      entity.DockDirection(req.Direction),
+		Capacity:       req.Capacity,
+		SlotMinutes:    req.SlotMinutes,
+		OperatingHours: hours,
+		VehicleTypes:   vehicleTypes,
+		Active:         req.Active,
+	}
+}
+
+func toDockResponse(d *dock.DockOutput) dto.DockResponse {
+	hours := make([]dto.OpeningHoursResponse, len(d.OperatingHours))
+	for i, h := range d.OperatingHours {
+		hours[i] = dto.OpeningHoursResponse{Weekday: int(h.Weekday), Open: h.Open, Close: h.Close}
+	}
+	vehicleTypes := make([]string, len(d.VehicleTypes))
+	for i, t := range d.VehicleTypes {
+		vehicleTypes[i] = string(t)
+	}
+
+	return dto.DockResponse{
+		ID:             d.ID.String(),
+		LocationID:     d.LocationID.String(),
+		Name:           d.Name,
+		Code:           d.Code,
+		Direction:      string(d.Direction),
+		Capacity:       d.Capacity,
+		SlotMinutes:    d.SlotMinutes,
+		OperatingHours: hours,
+		VehicleTypes:   vehicleTypes,
+		Active:         d.Active,
+		CreatedAt:      d.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      d.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toAppointmentResponses(results []*dock.AppointmentOutput) []dto.DockAppointmentResponse {
+	data := make([]dto.DockAppointmentResponse, len(results))
+	for i, r := range results {
+		data[i] = toAppointmentResponse(r)
+	}
+	return data
+}
+
+func toAppointmentResponse(a *dock.AppointmentOutput) dto.DockAppointmentResponse {
+	return dto.DockAppointmentResponse{
+		ID:                a.ID.String(),
+		DockID:            a.DockID.String(),
+		LocationID:        a.LocationID.String(),
+		Direction:         string(a.Direction),
+		TripID:            formatOptionalID(a.TripID),
+		CarrierID:         formatOptionalID(a.CarrierID),
+		VehicleType:       string(a.VehicleType),
+		PlateNumber:       a.PlateNumber,
+		DriverName:        a.DriverName,
+		Reference:         a.Reference,
+		StartAt:           a.StartAt.Format(time.RFC3339),
+		EndAt:             a.EndAt.Format(time.RFC3339),
+		Status:            string(a.Status),
+		CheckedInAt:       dto.FormatTimestamp(a.CheckedInAt),
+		CheckedOutAt:      dto.FormatTimestamp(a.CheckedOutAt),
+		WaitMinutes:       a.WaitMinutes,
+		TurnaroundMinutes: a.TurnaroundMinutes,
+		CancelReason:      a.CancelReason,
+		Notes:             a.Notes,
+		CreatedBy:         formatOptionalID(a.CreatedBy),
+		CreatedAt:         a.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         a.UpdatedAt.Format(time.RFC3339),
+	}
+}
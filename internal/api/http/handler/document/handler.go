@@ -0,0 +1,106 @@
+package document
+
+import (
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/usecase/document"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles printable document requests
+type Handler struct {
+	useCase *document.DocumentUseCase
+}
+
+// NewHandler creates a new document handler
+func NewHandler(useCase *document.DocumentUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Invoice godoc
+// @Summary Get invoice PDF
+// @Description Generate the invoice/tax invoice or credit note as a PDF with Thai text and Buddhist-era dates.
+// @Description The document is stored and a short-lived download URL is returned; unchanged documents keep their stored file.
+// @Tags documents
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} httpresponse.Response{data=dto.DocumentLinkResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/invoices/{id}/pdf [get]
+func (h *Handler) Invoice(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid invoice ID"))
+	}
+
+	result, err := h.useCase.Invoice(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toDocumentLinkResponse(result), "Invoice document generated successfully")
+}
+
+// Waybill godoc
+// @Summary Get waybill PDF
+// @Description Generate the waybill of a shipment as a PDF with a Code 128 barcode of the tracking number
+// @Description and a QR code of the public tracking page. A short-lived download URL is returned.
+// @Tags documents
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Shipment ID"
+// @Success 200 {object} httpresponse.Response{data=dto.DocumentLinkResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/shipments/{id}/waybill [get]
+func (h *Handler) Waybill(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid shipment ID"))
+	}
+
+	result, err := h.useCase.Waybill(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toDocumentLinkResponse(result), "Waybill generated successfully")
+}
+
+// DeliveryNote godoc
+// @Summary Get delivery note PDF
+// @Description Generate the delivery note the consignee signs on receipt as a PDF. Once delivered, it shows
+// @Description the recipient and proof of delivery number. A short-lived download URL is returned.
+// @Tags documents
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Shipment ID"
+// @Success 200 {object} httpresponse.Response{data=dto.DocumentLinkResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/shipments/{id}/delivery-note [get]
+func (h *Handler) DeliveryNote(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid shipment ID"))
+	}
+
+	result, err := h.useCase.DeliveryNote(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toDocumentLinkResponse(result), "Delivery note generated successfully")
+}
+
+func toDocumentLinkResponse(o *document.DocumentOutput) dto.DocumentLinkResponse {
+	return dto.DocumentLinkResponse{Filename: o.Filename, URL: o.URL}
+}
@@ -0,0 +1,325 @@
+package driver
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/driver"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles driver management requests
+type Handler struct {
+	useCase *driver.DriverUseCase
+}
+
+// NewHandler creates a new driver handler
+func NewHandler(useCase *driver.DriverUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Create godoc
+// @Summary Create driver profile
+// @Description Create a driver profile linked to an existing user account
+// @Tags drivers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateDriverRequest true "Driver details"
+// @Success 201 {object} httpresponse.Response{data=dto.DriverResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/drivers [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req dto.CreateDriverRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	profile, err := toProfileInput(req.DriverProfileRequest)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Create(c.Context(), driver.CreateDriverInput{
+		UserID:       uuid.MustParse(req.UserID),
+		ProfileInput: profile,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toDriverResponse(result), "Driver created successfully")
+}
+
+// Invite godoc
+// @Summary Invite driver
+// @Description Create a phone-only user account together with its driver profile
+// @Tags drivers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.InviteDriverRequest true "Driver invitation details"
+// @Success 201 {object} httpresponse.Response{data=dto.DriverResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/drivers/invite [post]
+func (h *Handler) Invite(c *fiber.Ctx) error {
+	var req dto.InviteDriverRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	profile, err := toProfileInput(req.DriverProfileRequest)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Invite(c.Context(), driver.InviteDriverInput{
+		PhoneNumber:  req.PhoneNumber,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		ProfileInput: profile,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toDriverResponse(result), "Driver invited successfully")
+}
+
+// List godoc
+// @Summary List drivers
+// @Description List drivers with optional status filter and search
+// @Tags drivers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param status query string false "Driver status" Enums(available, on_duty, off_duty, on_leave)
+// @Param search query string false "Search by name, phone or license number"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.DriverResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/drivers [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	var query dto.ListDriversQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := driver.ListDriversInput{
+		Search: query.Search,
+		Limit:  query.GetLimit(),
+		Offset: query.Offset,
+	}
+	if query.Status != "" {
+		status := entity.DriverStatus(query.Status)
+		input.Status = &status
+	}
+
+	results, total, err := h.useCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.DriverResponse, len(results))
+	for i, r := range results {
+		data[i] = toDriverResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// Get godoc
+// @Summary Get driver
+// @Description Get a driver by ID
+// @Tags drivers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Driver ID"
+// @Success 200 {object} httpresponse.Response{data=dto.DriverResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/drivers/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid driver ID"))
+	}
+
+	result, err := h.useCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toDriverResponse(result), "Driver retrieved successfully")
+}
+
+// Update godoc
+// @Summary Update driver
+// @Description Update a driver's profile
+// @Tags drivers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Driver ID"
+// @Param request body dto.UpdateDriverRequest true "Driver details"
+// @Success 200 {object} httpresponse.Response{data=dto.DriverResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/drivers/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid driver ID"))
+	}
+
+	var req dto.UpdateDriverRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	profile, err := toProfileInput(req.DriverProfileRequest)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Update(c.Context(), id, driver.UpdateDriverInput{ProfileInput: profile})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toDriverResponse(result), "Driver updated successfully")
+}
+
+// UpdateStatus godoc
+// @Summary Update driver status
+// @Description Change a driver's working status
+// @Tags drivers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Driver ID"
+// @Param request body dto.UpdateDriverStatusRequest true "New status"
+// @Success 200 {object} httpresponse.Response{data=dto.DriverResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/drivers/{id}/status [patch]
+func (h *Handler) UpdateStatus(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid driver ID"))
+	}
+
+	var req dto.UpdateDriverStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.UpdateStatus(c.Context(), id, entity.DriverStatus(req.Status))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toDriverResponse(result), "Driver status updated successfully")
+}
+
+// Delete godoc
+// @Summary Delete driver
+// @Description Delete a driver profile. The linked user account is kept.
+// @Tags drivers
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Driver ID"
+// @Success 200 {object} httpresponse.Response
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/drivers/{id} [delete]
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid driver ID"))
+	}
+
+	if err := h.useCase.Delete(c.Context(), id); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, nil, "Driver deleted successfully")
+}
+
+func toProfileInput(req dto.DriverProfileRequest) (driver.ProfileInput, error) {
+	expiry, err := time.Parse(dto.DateLayout, req.LicenseExpiry)
+	if err != nil {
+		return driver.ProfileInput{}, apierror.NewBadRequestError("Invalid license expiry date")
+	}
+
+	return driver.ProfileInput{
+		LicenseNumber:         req.LicenseNumber,
+		LicenseClass:          req.LicenseClass,
+		LicenseExpiry:         expiry,
+		EmploymentType:        entity.EmploymentType(req.EmploymentType),
+		HomeDepot:             req.HomeDepot,
+		EmergencyContactName:  req.EmergencyContactName,
+		EmergencyContactPhone: req.EmergencyContactPhone,
+	}, nil
+}
+
+func toDriverResponse(d *driver.DriverOutput) dto.DriverResponse {
+	return dto.DriverResponse{
+		ID:                    d.ID.String(),
+		UserID:                d.UserID.String(),
+		FirstName:             d.FirstName,
+		LastName:              d.LastName,
+		PhoneNumber:           d.PhoneNumber,
+		Email:                 d.Email,
+		LicenseNumber:         d.LicenseNumber,
+		LicenseClass:          d.LicenseClass,
+		LicenseExpiry:         d.LicenseExpiry.Format(dto.DateLayout),
+		LicenseValid:          d.LicenseValid,
+		EmploymentType:        string(d.EmploymentType),
+		HomeDepot:             d.HomeDepot,
+		EmergencyContactName:  d.EmergencyContactName,
+		EmergencyContactPhone: d.EmergencyContactPhone,
+		Status:                string(d.Status),
+		CreatedAt:             d.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             d.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,65 @@
+package eta
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/usecase/eta"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles ETA and delay requests
+type Handler struct {
+	useCase *eta.ETAUseCase
+}
+
+// NewHandler creates a new ETA handler
+func NewHandler(useCase *eta.ETAUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Delays godoc
+// @Summary List trip delays
+// @Description List the stops of a trip whose predicted arrival passed their time window by more than the configured margin, newest first. Current ETAs are on the trip's stops.
+// @Tags trips
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Trip ID"
+// @Success 200 {object} httpresponse.Response{data=[]dto.StopDelayResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips/{id}/delays [get]
+func (h *Handler) Delays(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid trip ID"))
+	}
+
+	results, err := h.useCase.Delays(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.StopDelayResponse, len(results))
+	for i, d := range results {
+		data[i] = dto.StopDelayResponse{
+			ID:           d.ID.String(),
+			TripID:       d.TripID.String(),
+			StopID:       d.StopID.String(),
+			ShipmentID:   d.ShipmentID.String(),
+			VehicleID:    d.VehicleID.String(),
+			ETA:          d.ETA.Format(time.RFC3339),
+			WindowEnd:    d.WindowEnd.Format(time.RFC3339),
+			DelayMinutes: int(d.Delay / time.Minute),
+			DetectedAt:   d.DetectedAt.Format(time.RFC3339),
+		}
+	}
+
+	return httpresponse.Success(c, data, "Trip delays retrieved successfully")
+}
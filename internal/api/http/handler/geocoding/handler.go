@@ -0,0 +1,102 @@
+package geocoding
+
+import (
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/usecase/geocoding"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Handler handles geocoding requests
+type Handler struct {
+	useCase *geocoding.GeocodingUseCase
+}
+
+// NewHandler creates a new geocoding handler
+func NewHandler(useCase *geocoding.GeocodingUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Geocode godoc
+// @Summary Geocode address
+// @Description Resolve a Thai address to coordinates. The precision tells whether the pin is the building, the street or only the surrounding area.
+// @Tags geocoding
+// @Produce json
+// @Security Bearer
+// @Param address query string false "Street part: house number, moo, soi, road"
+// @Param subdistrict query string false "Subdistrict (tambon/khwaeng)"
+// @Param district query string false "District (amphoe/khet)"
+// @Param province query string false "Province; required without postcode"
+// @Param postcode query string false "Five-digit postcode"
+// @Success 200 {object} httpresponse.Response{data=dto.GeocodeResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/geocode [get]
+func (h *Handler) Geocode(c *fiber.Ctx) error {
+	var query dto.GeocodeQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Geocode(c.Context(), geocoding.GeocodeInput{
+		Address:     query.Address,
+		Subdistrict: query.Subdistrict,
+		District:    query.District,
+		Province:    query.Province,
+		Postcode:    query.Postcode,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toGeocodeResponse(result), "Address geocoded successfully")
+}
+
+// ReverseGeocode godoc
+// @Summary Reverse geocode
+// @Description Resolve coordinates to the nearest known address
+// @Tags geocoding
+// @Produce json
+// @Security Bearer
+// @Param lat query number true "Latitude"
+// @Param lng query number true "Longitude"
+// @Success 200 {object} httpresponse.Response{data=dto.GeocodeResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/geocode/reverse [get]
+func (h *Handler) ReverseGeocode(c *fiber.Ctx) error {
+	var query dto.ReverseGeocodeQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.ReverseGeocode(c.Context(), *query.Lat, *query.Lng)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toGeocodeResponse(result), "Coordinates reverse geocoded successfully")
+}
+
+func toGeocodeResponse(r *geocoding.GeocodeOutput) dto.GeocodeResponse {
+	return dto.GeocodeResponse{
+		Latitude:         r.Latitude,
+		Longitude:        r.Longitude,
+		FormattedAddress: r.FormattedAddress,
+		Precision:        string(r.Precision),
+		Approximate:      r.Approximate,
+		Provider:         r.Provider,
+	}
+}
@@ -0,0 +1,311 @@
+package geofence
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/geofence"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles geofence and geofence event requests
+type Handler struct {
+	useCase *geofence.GeofenceUseCase
+}
+
+// NewHandler creates a new geofence handler
+func NewHandler(useCase *geofence.GeofenceUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Create godoc
+// @Summary Create geofence
+// @Description Add a circle or polygon geofence to a location. Vehicles that stay inside for the dwell time are marked arrived at the location's stops; vehicles that move beyond the exit buffer are marked departed.
+// @Tags geofences
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Location ID"
+// @Param request body dto.GeofenceRequest true "Geofence details"
+// @Success 201 {object} httpresponse.Response{data=dto.GeofenceResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/locations/{id}/geofences [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	locationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid location ID"))
+	}
+
+	var req dto.GeofenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Create(c.Context(), locationID, toGeofenceInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toGeofenceResponse(result), "Geofence created successfully")
+}
+
+// ListByLocation godoc
+// @Summary List location geofences
+// @Description List the geofences of a location
+// @Tags geofences
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Location ID"
+// @Success 200 {object} httpresponse.Response{data=[]dto.GeofenceResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/locations/{id}/geofences [get]
+func (h *Handler) ListByLocation(c *fiber.Ctx) error {
+	locationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid location ID"))
+	}
+
+	results, err := h.useCase.ListByLocation(c.Context(), locationID)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.GeofenceResponse, len(results))
+	for i, r := range results {
+		data[i] = toGeofenceResponse(r)
+	}
+
+	return httpresponse.Success(c, data, "Geofences retrieved successfully")
+}
+
+// Get godoc
+// @Summary Get geofence
+// @Description Get a geofence by ID
+// @Tags geofences
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Geofence ID"
+// @Success 200 {object} httpresponse.Response{data=dto.GeofenceResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/geofences/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid geofence ID"))
+	}
+
+	result, err := h.useCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toGeofenceResponse(result), "Geofence retrieved successfully")
+}
+
+// Update godoc
+// @Summary Update geofence
+// @Description Update a geofence's boundary, dwell time and exit buffer
+// @Tags geofences
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Geofence ID"
+// @Param request body dto.GeofenceRequest true "Geofence details"
+// @Success 200 {object} httpresponse.Response{data=dto.GeofenceResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/geofences/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid geofence ID"))
+	}
+
+	var req dto.GeofenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Update(c.Context(), id, toGeofenceInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toGeofenceResponse(result), "Geofence updated successfully")
+}
+
+// Delete godoc
+// @Summary Delete geofence
+// @Description Remove a geofence
+// @Tags geofences
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Geofence ID"
+// @Success 200 {object} httpresponse.Response
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/geofences/{id} [delete]
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid geofence ID"))
+	}
+
+	if err := h.useCase.Delete(c.Context(), id); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, nil, "Geofence deleted successfully")
+}
+
+// ListEvents godoc
+// @Summary List geofence events
+// @Description List enter, dwell and exit events, newest first, with optional filters
+// @Tags geofences
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param vehicle_id query string false "Vehicle ID"
+// @Param geofence_id query string false "Geofence ID"
+// @Param location_id query string false "Location ID"
+// @Param trip_id query string false "Trip ID"
+// @Param from query string false "Events at or after (RFC 3339)"
+// @Param to query string false "Events before (RFC 3339)"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.GeofenceEventResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/geofences/events [get]
+func (h *Handler) ListEvents(c *fiber.Ctx) error {
+	var query dto.ListGeofenceEventsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := geofence.ListEventsInput{
+		VehicleID:  parseOptionalUUID(query.VehicleID),
+		GeofenceID: parseOptionalUUID(query.GeofenceID),
+		LocationID: parseOptionalUUID(query.LocationID),
+		TripID:     parseOptionalUUID(query.TripID),
+		From:       dto.ParseTimestamp(query.From),
+		To:         dto.ParseTimestamp(query.To),
+		Limit:      query.GetLimit(),
+		Offset:     query.Offset,
+	}
+
+	results, total, err := h.useCase.ListEvents(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.GeofenceEventResponse, len(results))
+	for i, r := range results {
+		data[i] = toEventResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+func toGeofenceInput(req dto.GeofenceRequest) geofence.GeofenceInput {
+	input := geofence.GeofenceInput{
+		Name:        req.Name,
+		Shape:       entity.GeofenceShape(req.Shape),
+		RadiusM:     req.RadiusM,
+		ExitBufferM: req.ExitBufferM,
+		Active:      req.Active == nil || *req.Active,
+	}
+	if req.Center != nil {
+		input.Center = &entity.Coordinate{Latitude: *req.Center.Latitude, Longitude: *req.Center.Longitude}
+	}
+	for _, p := range req.Polygon {
+		input.Polygon = append(input.Polygon, entity.Coordinate{Latitude: *p.Latitude, Longitude: *p.Longitude})
+	}
+	if req.DwellSeconds != nil {
+		dwell := time.Duration(*req.DwellSeconds) * time.Second
+		input.DwellTime = &dwell
+	}
+	return input
+}
+
+func toGeofenceResponse(g *geofence.GeofenceOutput) dto.GeofenceResponse {
+	resp := dto.GeofenceResponse{
+		ID:           g.ID.String(),
+		LocationID:   g.LocationID.String(),
+		Name:         g.Name,
+		Shape:        string(g.Shape),
+		RadiusM:      g.RadiusM,
+		DwellSeconds: int(g.DwellTime / time.Second),
+		ExitBufferM:  g.ExitBufferM,
+		Active:       g.Active,
+		CreatedAt:    g.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    g.UpdatedAt.Format(time.RFC3339),
+	}
+	if g.Center != nil {
+		resp.Center = &dto.CoordinateResponse{Latitude: g.Center.Latitude, Longitude: g.Center.Longitude}
+	}
+	for _, p := range g.Polygon {
+		resp.Polygon = append(resp.Polygon, dto.CoordinateResponse{Latitude: p.Latitude, Longitude: p.Longitude})
+	}
+	return resp
+}
+
+func toEventResponse(e *geofence.EventOutput) dto.GeofenceEventResponse {
+	return dto.GeofenceEventResponse{
+		ID:         e.ID.String(),
+		GeofenceID: e.GeofenceID.String(),
+		LocationID: e.LocationID.String(),
+		VehicleID:  e.VehicleID.String(),
+		TripID:     formatOptionalUUID(e.TripID),
+		StopID:     formatOptionalUUID(e.StopID),
+		Type:       string(e.Type),
+		OccurredAt: e.OccurredAt.Format(time.RFC3339),
+		Latitude:   e.Latitude,
+		Longitude:  e.Longitude,
+	}
+}
+
+func parseOptionalUUID(value string) *uuid.UUID {
+	if value == "" {
+		return nil
+	}
+	id := uuid.MustParse(value)
+	return &id
+}
+
+func formatOptionalUUID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
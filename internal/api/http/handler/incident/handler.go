@@ -0,0 +1,509 @@
+package incident
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/api/http/middleware"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/incident"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles incident requests
+type Handler struct {
+	useCase *incident.IncidentUseCase
+}
+
+// NewHandler creates a new incident handler
+func NewHandler(useCase *incident.IncidentUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// UploadURLs godoc
+// @Summary Get incident photo upload URLs
+// @Description Get presigned URLs to upload photos of an incident. Upload each photo with a PUT to its URL,
+// @Description then pass the object keys when reporting the incident or adding photos to it.
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.IncidentUploadURLsRequest true "Photo content types"
+// @Success 200 {object} httpresponse.Response{data=[]dto.PresignUploadResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/incidents/upload-urls [post]
+func (h *Handler) UploadURLs(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	var req dto.IncidentUploadURLsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	results, err := h.useCase.UploadURLs(c.Context(), userID, req.ContentTypes)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.PresignUploadResponse, len(results))
+	for i, r := range results {
+		data[i] = dto.PresignUploadResponse{UploadURL: r.UploadURL, ObjectKey: r.ObjectKey}
+	}
+
+	return httpresponse.Success(c, data, "Upload URLs generated successfully")
+}
+
+// Create godoc
+// @Summary Report incident
+// @Description Report damage, a shortage, an accident, a breakdown, a refused delivery or another exception on
+// @Description a trip, one of its stops or a shipment. The incident must be resolved within the SLA of its severity.
+// @Description Its owner, by default the dispatcher of the trip, is notified on their realtime channel.
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateIncidentRequest true "Incident"
+// @Success 201 {object} httpresponse.Response{data=dto.IncidentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/incidents [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	var req dto.CreateIncidentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Create(c.Context(), incident.IncidentInput{
+		Category:    entity.IncidentCategory(req.Category),
+		Severity:    entity.IncidentSeverity(req.Severity),
+		TripID:      parseOptionalID(req.TripID),
+		StopID:      parseOptionalID(req.StopID),
+		ShipmentID:  parseOptionalID(req.ShipmentID),
+		Description: req.Description,
+		PhotoKeys:   req.PhotoKeys,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		OccurredAt:  dto.ParseTimestamp(req.OccurredAt),
+		OwnerID:     parseOptionalID(req.OwnerID),
+		ReportedBy:  userID,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toIncidentResponse(result), "Incident reported successfully")
+}
+
+// List godoc
+// @Summary List incidents
+// @Description List incidents, most recent first. open keeps the incidents awaiting a resolution;
+// @Description overdue keeps those open past their SLA.
+// @Tags incidents
+// @Produce json
+// @Security Bearer
+// @Param trip_id query string false "Trip ID"
+// @Param shipment_id query string false "Shipment ID"
+// @Param owner_id query string false "Owner user ID"
+// @Param category query string false "Category" Enums(damage, shortage, accident, breakdown, refused_delivery, other)
+// @Param severity query string false "Severity" Enums(low, medium, high, critical)
+// @Param status query string false "Status" Enums(open, in_progress, resolved, closed, cancelled)
+// @Param open query bool false "Only open incidents"
+// @Param overdue query bool false "Only incidents open past their SLA"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} httpresponse.Response{data=[]dto.IncidentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/incidents [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	var query dto.ListIncidentsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := incident.ListIncidentsInput{
+		TripID:     parseOptionalID(query.TripID),
+		ShipmentID: parseOptionalID(query.ShipmentID),
+		OwnerID:    parseOptionalID(query.OwnerID),
+		Open:       query.Open,
+		Overdue:    query.Overdue,
+		Limit:      query.GetLimit(),
+		Offset:     query.Offset,
+	}
+	if query.Category != "" {
+		category := entity.IncidentCategory(query.Category)
+		input.Category = &category
+	}
+	if query.Severity != "" {
+		severity := entity.IncidentSeverity(query.Severity)
+		input.Severity = &severity
+	}
+	if query.Status != "" {
+		status := entity.IncidentStatus(query.Status)
+		input.Status = &status
+	}
+
+	results, total, err := h.useCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.IncidentResponse, len(results))
+	for i, r := range results {
+		data[i] = toIncidentResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// Get godoc
+// @Summary Get incident
+// @Description Get an incident by ID with links to download its photos
+// @Tags incidents
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Incident ID"
+// @Success 200 {object} httpresponse.Response{data=dto.IncidentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/incidents/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid incident ID"))
+	}
+
+	result, err := h.useCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toIncidentResponse(result), "Incident retrieved successfully")
+}
+
+// Update godoc
+// @Summary Update incident
+// @Description Replace the details or the owner of an open incident; what it is linked to cannot change.
+// @Description Changing the severity moves the SLA due time. A new owner is notified on their realtime channel.
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Incident ID"
+// @Param request body dto.UpdateIncidentRequest true "Incident"
+// @Success 200 {object} httpresponse.Response{data=dto.IncidentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/incidents/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid incident ID"))
+	}
+
+	var req dto.UpdateIncidentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Update(c.Context(), id, incident.UpdateIncidentInput{
+		Category:    entity.IncidentCategory(req.Category),
+		Severity:    entity.IncidentSeverity(req.Severity),
+		Description: req.Description,
+		OwnerID:     parseOptionalID(req.OwnerID),
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toIncidentResponse(result), "Incident updated successfully")
+}
+
+// AddPhotos godoc
+// @Summary Add incident photos
+// @Description Attach photos uploaded by the current user to an incident that is not closed or cancelled
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Incident ID"
+// @Param request body dto.AddIncidentPhotosRequest true "Photo keys"
+// @Success 200 {object} httpresponse.Response{data=dto.IncidentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/incidents/{id}/photos [post]
+func (h *Handler) AddPhotos(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid incident ID"))
+	}
+
+	var req dto.AddIncidentPhotosRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.AddPhotos(c.Context(), id, userID, req.PhotoKeys)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toIncidentResponse(result), "Incident photos added successfully")
+}
+
+// Start godoc
+// @Summary Start working on incident
+// @Description Mark an open incident as in progress, or reopen a resolved one whose resolution was not accepted
+// @Tags incidents
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Incident ID"
+// @Success 200 {object} httpresponse.Response{data=dto.IncidentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/incidents/{id}/start [post]
+func (h *Handler) Start(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid incident ID"))
+	}
+
+	result, err := h.useCase.Start(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toIncidentResponse(result), "Incident started successfully")
+}
+
+// Resolve godoc
+// @Summary Resolve incident
+// @Description Record how an open incident was resolved
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Incident ID"
+// @Param request body dto.ResolveIncidentRequest true "Resolution"
+// @Success 200 {object} httpresponse.Response{data=dto.IncidentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/incidents/{id}/resolve [post]
+func (h *Handler) Resolve(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid incident ID"))
+	}
+
+	var req dto.ResolveIncidentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Resolve(c.Context(), id, userID, req.Resolution)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toIncidentResponse(result), "Incident resolved successfully")
+}
+
+// Close godoc
+// @Summary Close incident
+// @Description Accept the resolution of a resolved incident
+// @Tags incidents
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Incident ID"
+// @Success 200 {object} httpresponse.Response{data=dto.IncidentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/incidents/{id}/close [post]
+func (h *Handler) Close(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid incident ID"))
+	}
+
+	result, err := h.useCase.Close(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toIncidentResponse(result), "Incident closed successfully")
+}
+
+// Cancel godoc
+// @Summary Cancel incident
+// @Description Withdraw an open incident reported in error
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Incident ID"
+// @Param request body dto.CancelIncidentRequest false "Reason"
+// @Success 200 {object} httpresponse.Response{data=dto.IncidentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/incidents/{id}/cancel [post]
+func (h *Handler) Cancel(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid incident ID"))
+	}
+
+	var req dto.CancelIncidentRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return httpresponse.Error(c, err)
+		}
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Cancel(c.Context(), id, req.Reason)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toIncidentResponse(result), "Incident cancelled successfully")
+}
+
+// CheckSLA godoc
+// @Summary Check incident SLAs
+// @Description Announce open incidents past their SLA now rather than at the next periodic check.
+// @Description Each breach is announced once to the incident's owner and on its trip and shipment channels.
+// @Tags incidents
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} httpresponse.Response{data=dto.IncidentSLACheckResponse}
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/incidents/check-sla [post]
+func (h *Handler) CheckSLA(c *fiber.Ctx) error {
+	breached, err := h.useCase.CheckSLA(c.Context())
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, dto.IncidentSLACheckResponse{Breached: breached}, "Incident SLAs checked successfully")
+}
+
+func parseOptionalID(value string) *uuid.UUID {
+	if value == "" {
+		return nil
+	}
+	id := uuid.MustParse(value)
+	return &id
+}
+
+func formatOptionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func toIncidentResponse(i *incident.IncidentOutput) dto.IncidentResponse {
+	photoKeys := i.PhotoKeys
+	if photoKeys == nil {
+		photoKeys = []string{}
+	}
+
+	return dto.IncidentResponse{
+		ID:             i.ID.String(),
+		DocumentNumber: i.Number,
+		Category:       string(i.Category),
+		Severity:       string(i.Severity),
+		Status:         string(i.Status),
+		TripID:         formatOptionalID(i.TripID),
+		StopID:         formatOptionalID(i.StopID),
+		ShipmentID:     formatOptionalID(i.ShipmentID),
+		Description:    i.Description,
+		PhotoKeys:      photoKeys,
+		PhotoURLs:      i.PhotoURLs,
+		Latitude:       i.Latitude,
+		Longitude:      i.Longitude,
+		LocationID:     formatOptionalID(i.LocationID),
+		ReportedBy:     i.ReportedBy.String(),
+		OccurredAt:     i.OccurredAt.Format(time.RFC3339),
+		OwnerID:        formatOptionalID(i.OwnerID),
+		DueAt:          i.DueAt.Format(time.RFC3339),
+		Overdue:        i.Overdue,
+		BreachedAt:     dto.FormatTimestamp(i.BreachedAt),
+		Resolution:     i.Resolution,
+		ResolvedBy:     formatOptionalID(i.ResolvedBy),
+		ResolvedAt:     dto.FormatTimestamp(i.ResolvedAt),
+		ClosedAt:       dto.FormatTimestamp(i.ClosedAt),
+		CancelReason:   i.CancelReason,
+		CreatedAt:      i.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      i.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,383 @@
+package invoicing
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/invoicing"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles invoice and credit note requests
+type Handler struct {
+	useCase *invoicing.InvoiceUseCase
+}
+
+// NewHandler creates a new invoicing handler
+func NewHandler(useCase *invoicing.InvoiceUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Create godoc
+// @Summary Create invoice
+// @Description Draft an invoice for every shipment of the customer delivered in the billing period that is not on a live invoice yet.
+// @Description Each shipment is priced from the customer's rate card; VAT is charged at 7% and, for customers that withhold tax, 1% is withheld.
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateInvoiceRequest true "Billing period"
+// @Success 201 {object} httpresponse.Response{data=dto.InvoiceResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/invoices [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req dto.CreateInvoiceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	periodFrom, err := time.Parse(dto.DateLayout, req.PeriodFrom)
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid period start date"))
+	}
+	periodTo, err := time.Parse(dto.DateLayout, req.PeriodTo)
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid period end date"))
+	}
+
+	result, err := h.useCase.CreateDraft(c.Context(), invoicing.CreateInvoiceInput{
+		OrganizationID: uuid.MustParse(req.OrganizationID),
+		PeriodFrom:     periodFrom,
+		PeriodTo:       periodTo,
+		Notes:          req.Notes,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toInvoiceResponse(result), "Invoice created successfully")
+}
+
+// List godoc
+// @Summary List invoices
+// @Description List invoices and credit notes by customer, kind or status, newest first
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param organization_id query string false "Organization ID"
+// @Param kind query string false "Kind" Enums(invoice, credit_note)
+// @Param status query string false "Status" Enums(draft, issued, paid, void)
+// @Param search query string false "Search by number"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.InvoiceResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/invoices [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	var query dto.ListInvoicesQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := invoicing.ListInvoicesInput{
+		Search: query.Search,
+		Limit:  query.GetLimit(),
+		Offset: query.Offset,
+	}
+	if query.OrganizationID != "" {
+		id := uuid.MustParse(query.OrganizationID)
+		input.OrganizationID = &id
+	}
+	if query.Kind != "" {
+		kind := entity.InvoiceKind(query.Kind)
+		input.Kind = &kind
+	}
+	if query.Status != "" {
+		status := entity.InvoiceStatus(query.Status)
+		input.Status = &status
+	}
+
+	results, total, err := h.useCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.InvoiceResponse, len(results))
+	for i, r := range results {
+		data[i] = toInvoiceResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// Get godoc
+// @Summary Get invoice
+// @Description Get an invoice or credit note with its lines
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} httpresponse.Response{data=dto.InvoiceResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/invoices/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid invoice ID"))
+	}
+
+	result, err := h.useCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toInvoiceResponse(result), "Invoice retrieved successfully")
+}
+
+// Issue godoc
+// @Summary Issue invoice
+// @Description Number a draft invoice or credit note in the customer's sequence and start its credit term
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Invoice ID"
+// @Success 200 {object} httpresponse.Response{data=dto.InvoiceResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/invoices/{id}/issue [post]
+func (h *Handler) Issue(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid invoice ID"))
+	}
+
+	result, err := h.useCase.Issue(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toInvoiceResponse(result), "Invoice issued successfully")
+}
+
+// Pay godoc
+// @Summary Record invoice payment
+// @Description Mark an issued invoice as paid; paid_at defaults to now
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Invoice ID"
+// @Param request body dto.PayInvoiceRequest false "Payment"
+// @Success 200 {object} httpresponse.Response{data=dto.InvoiceResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/invoices/{id}/pay [post]
+func (h *Handler) Pay(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid invoice ID"))
+	}
+
+	var req dto.PayInvoiceRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return httpresponse.Error(c, err)
+		}
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := invoicing.PaymentInput{Reference: req.Reference}
+	if paidAt := dto.ParseTimestamp(req.PaidAt); paidAt != nil {
+		input.PaidAt = *paidAt
+	}
+
+	result, err := h.useCase.MarkPaid(c.Context(), id, input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toInvoiceResponse(result), "Invoice paid successfully")
+}
+
+// Void godoc
+// @Summary Void invoice
+// @Description Cancel a draft or an unpaid invoice so its shipments can be billed again. Issued numbers stay used.
+// @Description An invoice with credit notes that are not void cannot be voided.
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Invoice ID"
+// @Param request body dto.VoidInvoiceRequest true "Reason"
+// @Success 200 {object} httpresponse.Response{data=dto.InvoiceResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/invoices/{id}/void [post]
+func (h *Handler) Void(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid invoice ID"))
+	}
+
+	var req dto.VoidInvoiceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Void(c.Context(), id, req.Reason)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toInvoiceResponse(result), "Invoice voided successfully")
+}
+
+// CreateCreditNote godoc
+// @Summary Create credit note
+// @Description Draft a credit note correcting an issued or paid invoice. Without lines the whole invoice is credited;
+// @Description the credit notes of an invoice may not credit more than its subtotal.
+// @Tags invoices
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Invoice ID"
+// @Param request body dto.CreateCreditNoteRequest true "Credit note"
+// @Success 201 {object} httpresponse.Response{data=dto.InvoiceResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/invoices/{id}/credit-notes [post]
+func (h *Handler) CreateCreditNote(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid invoice ID"))
+	}
+
+	var req dto.CreateCreditNoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := invoicing.CreditNoteInput{
+		Reason: req.Reason,
+		Lines:  make([]invoicing.CreditNoteLineInput, len(req.Lines)),
+		Notes:  req.Notes,
+	}
+	for i, l := range req.Lines {
+		input.Lines[i] = invoicing.CreditNoteLineInput{Description: l.Description, Amount: entity.Baht(l.Amount)}
+		if l.ShipmentID != "" {
+			shipmentID := uuid.MustParse(l.ShipmentID)
+			input.Lines[i].ShipmentID = &shipmentID
+		}
+	}
+
+	result, err := h.useCase.CreateCreditNote(c.Context(), id, input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toInvoiceResponse(result), "Credit note created successfully")
+}
+
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(dto.DateLayout)
+	return &s
+}
+
+func toInvoiceResponse(inv *invoicing.InvoiceOutput) dto.InvoiceResponse {
+	lines := make([]dto.InvoiceLineResponse, len(inv.Lines))
+	for i, l := range inv.Lines {
+		lines[i] = dto.InvoiceLineResponse{
+			Sequence:    l.Sequence,
+			ServiceDate: dto.FormatTimestamp(l.ServiceDate),
+			Description: l.Description,
+			Quantity:    l.Quantity,
+			UnitPrice:   l.UnitPrice.Baht(),
+			Amount:      l.Amount.Baht(),
+		}
+		if l.ShipmentID != nil {
+			id := l.ShipmentID.String()
+			lines[i].ShipmentID = &id
+		}
+	}
+
+	resp := dto.InvoiceResponse{
+		ID:               inv.ID.String(),
+		Number:           inv.Number,
+		Kind:             string(inv.Kind),
+		Status:           string(inv.Status),
+		OrganizationID:   inv.OrganizationID.String(),
+		PeriodFrom:       inv.PeriodFrom.Format(dto.DateLayout),
+		PeriodTo:         inv.PeriodTo.Format(dto.DateLayout),
+		Currency:         inv.Currency,
+		CreditTermDays:   inv.CreditTermDays,
+		IssueDate:        formatDate(inv.IssueDate),
+		DueDate:          formatDate(inv.DueDate),
+		Overdue:          inv.Overdue,
+		Lines:            lines,
+		Subtotal:         inv.Subtotal.Baht(),
+		VATRate:          inv.VATRate,
+		VATAmount:        inv.VATAmount.Baht(),
+		Total:            inv.Total.Baht(),
+		WithholdingRate:  inv.WithholdingRate,
+		WithholdingTax:   inv.WithholdingTax.Baht(),
+		AmountDue:        inv.AmountDue.Baht(),
+		Reason:           inv.Reason,
+		Notes:            inv.Notes,
+		PaidAt:           dto.FormatTimestamp(inv.PaidAt),
+		PaymentReference: inv.PaymentReference,
+		VoidedAt:         dto.FormatTimestamp(inv.VoidedAt),
+		VoidReason:       inv.VoidReason,
+		CreatedAt:        inv.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        inv.UpdatedAt.Format(time.RFC3339),
+	}
+	if inv.CreditedInvoiceID != nil {
+		id := inv.CreditedInvoiceID.String()
+		resp.CreditedInvoiceID = &id
+	}
+	return resp
+}
@@ -0,0 +1,232 @@
+package label
+
+import (
+	"fmt"
+	"strconv"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/label"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles label template and shipping label requests
+type Handler struct {
+	useCase *label.LabelUseCase
+}
+
+// NewHandler creates a new label handler
+func NewHandler(useCase *label.LabelUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// GetTemplate godoc
+// @Summary Get label template
+// @Description Get the shipping label template that applies to an organization, its own or the default
+// @Tags labels
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Success 200 {object} httpresponse.Response{data=dto.LabelTemplateResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/organizations/{id}/label-template [get]
+func (h *Handler) GetTemplate(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid organization ID"))
+	}
+
+	result, err := h.useCase.GetTemplate(c.Context(), orgID)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toLabelTemplateResponse(result), "Label template retrieved successfully")
+}
+
+// SetTemplate godoc
+// @Summary Set label template
+// @Description Set an organization's own shipping label template: the label stock (4x6 inch or A6), the printer resolution
+// @Description and Thai TrueType font for ZPL, and the GS1 company prefix and extension digit of the SSCC printed on each piece.
+// @Description Without a company prefix, labels carry no SSCC.
+// @Tags labels
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param request body dto.LabelTemplateRequest true "Label template"
+// @Success 200 {object} httpresponse.Response{data=dto.LabelTemplateResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/organizations/{id}/label-template [put]
+func (h *Handler) SetTemplate(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid organization ID"))
+	}
+
+	var req dto.LabelTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.SetTemplate(c.Context(), label.SetTemplateInput{
+		OrganizationID:   orgID,
+		Size:             entity.LabelSize(req.Size),
+		DPI:              req.DPI,
+		ZPLFont:          req.ZPLFont,
+		GS1CompanyPrefix: req.GS1CompanyPrefix,
+		SSCCExtension:    req.SSCCExtension,
+		Footer:           req.Footer,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toLabelTemplateResponse(result), "Label template updated successfully")
+}
+
+// DeleteTemplate godoc
+// @Summary Reset label template
+// @Description Remove an organization's own label template so the default template applies again
+// @Tags labels
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Success 200 {object} httpresponse.Response
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/organizations/{id}/label-template [delete]
+func (h *Handler) DeleteTemplate(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid organization ID"))
+	}
+
+	if err := h.useCase.DeleteTemplate(c.Context(), orgID); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, nil, "Label template reset successfully")
+}
+
+// Shipment godoc
+// @Summary Print shipment labels
+// @Description Print a label for every piece of a shipment (one per pallet) with the tracking barcode, the piece's SSCC,
+// @Description the consignee address, sort code and route, as ZPL for Zebra printers or as PDF. Reprints keep each piece's SSCC.
+// @Tags labels
+// @Produce application/x-zpl
+// @Produce application/pdf
+// @Security Bearer
+// @Param id path string true "Shipment ID"
+// @Param format query string false "Output format" Enums(zpl, pdf) default(zpl)
+// @Success 200 {file} file
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/shipments/{id}/labels [get]
+func (h *Handler) Shipment(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid shipment ID"))
+	}
+	format, err := parseFormat(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.ShipmentLabels(c.Context(), id, format)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return sendLabels(c, result)
+}
+
+// Trip godoc
+// @Summary Print trip labels
+// @Description Print the labels of every shipment on a trip in one batch, in pickup order, as ZPL for Zebra printers or as PDF.
+// @Description Cancelled shipments are left out.
+// @Tags labels
+// @Produce application/x-zpl
+// @Produce application/pdf
+// @Security Bearer
+// @Param id path string true "Trip ID"
+// @Param format query string false "Output format" Enums(zpl, pdf) default(zpl)
+// @Success 200 {file} file
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips/{id}/labels [get]
+func (h *Handler) Trip(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid trip ID"))
+	}
+	format, err := parseFormat(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.TripLabels(c.Context(), id, format)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return sendLabels(c, result)
+}
+
+func parseFormat(c *fiber.Ctx) (entity.LabelFormat, error) {
+	var query dto.LabelQuery
+	if err := c.QueryParser(&query); err != nil {
+		return "", err
+	}
+	if err := validator.Validate(query); err != nil {
+		return "", err
+	}
+	if query.Format == "" {
+		return entity.LabelFormatZPL, nil
+	}
+	return entity.LabelFormat(query.Format), nil
+}
+
+func sendLabels(c *fiber.Ctx, result *label.LabelOutput) error {
+	c.Set(fiber.HeaderContentType, result.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", result.Filename))
+	c.Set("X-Label-Count", strconv.Itoa(result.Count))
+	return c.Send(result.Content)
+}
+
+func toLabelTemplateResponse(o *label.TemplateOutput) dto.LabelTemplateResponse {
+	t := o.Template
+	response := dto.LabelTemplateResponse{
+		Size:             string(t.Size),
+		DPI:              t.DPI,
+		ZPLFont:          t.ZPLFont,
+		GS1CompanyPrefix: t.GS1CompanyPrefix,
+		SSCCExtension:    t.SSCCExtension,
+		Footer:           t.Footer,
+		Custom:           o.Custom,
+	}
+	if o.Custom {
+		response.UpdatedAt = dto.FormatTimestamp(&t.UpdatedAt)
+	}
+	return response
+}
@@ -0,0 +1,147 @@
+package loadplan
+
+import (
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/internal/usecase/loadplan"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Handler handles load planning requests
+type Handler struct {
+	useCase *loadplan.LoadPlanUseCase
+}
+
+// NewHandler creates a new load plan handler
+func NewHandler(useCase *loadplan.LoadPlanUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Plan godoc
+// @Summary Plan truck fill
+// @Description Pack handling units into a vehicle cargo space. Units stay upright and fill from the front wall; non-stackable units carry nothing on top.
+// @Description Returns placements, fill, center of gravity, axle loads when axle positions are given, leftovers with reasons, and top/side layout views as JSON and SVG.
+// @Description With format=svg the SVG drawing is returned directly.
+// @Tags load-plans
+// @Accept json
+// @Produce json,image/svg+xml
+// @Security Bearer
+// @Param format query string false "Response format" Enums(json, svg)
+// @Param request body dto.LoadPlanRequest true "Cargo space and handling units"
+// @Success 200 {object} httpresponse.Response{data=dto.LoadPlanResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/load-plans [post]
+func (h *Handler) Plan(c *fiber.Ctx) error {
+	var req dto.LoadPlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Plan(c.Context(), toPlanInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if c.Query("format") == "svg" {
+		c.Set(fiber.HeaderContentType, "image/svg+xml")
+		return c.SendString(result.SVG)
+	}
+
+	return httpresponse.Success(c, toLoadPlanResponse(result), "Load plan created successfully")
+}
+
+func toPlanInput(req dto.LoadPlanRequest) loadplan.PlanInput {
+	input := loadplan.PlanInput{
+		Space: service.CargoSpace{
+			LengthMM:    req.Space.LengthMM,
+			WidthMM:     req.Space.WidthMM,
+			HeightMM:    req.Space.HeightMM,
+			MaxWeightKg: req.Space.MaxWeightKg,
+			FrontAxleMM: req.Space.FrontAxleMM,
+			RearAxleMM:  req.Space.RearAxleMM,
+		},
+		Units: make([]service.HandlingUnit, len(req.Units)),
+	}
+	for i, u := range req.Units {
+		input.Units[i] = service.HandlingUnit{
+			ID:                u.ID,
+			Quantity:          u.Quantity,
+			LengthMM:          u.LengthMM,
+			WidthMM:           u.WidthMM,
+			HeightMM:          u.HeightMM,
+			WeightKg:          u.WeightKg,
+			Stackable:         u.Stackable,
+			OrientationLocked: u.OrientationLocked,
+		}
+	}
+	return input
+}
+
+func toLoadPlanResponse(p *service.LoadPlan) dto.LoadPlanResponse {
+	resp := dto.LoadPlanResponse{
+		Placements:    make([]dto.CargoPlacementResponse, len(p.Placements)),
+		Leftovers:     make([]dto.CargoLeftoverResponse, len(p.Leftovers)),
+		VolumeFillPct: p.VolumeFillPct,
+		WeightFillPct: p.WeightFillPct,
+		UsedLengthMM:  p.UsedLengthMM,
+		TotalWeightKg: p.TotalWeightKg,
+		CenterOfGravity: dto.CargoPointResponse{
+			XMM: p.CenterOfGravity.XMM,
+			YMM: p.CenterOfGravity.YMM,
+			ZMM: p.CenterOfGravity.ZMM,
+		},
+		LateralOffsetMM: p.LateralOffsetMM,
+		Warnings:        append([]string{}, p.Warnings...),
+		Layout:          make([]dto.LayoutViewResponse, len(p.Views)),
+		SVG:             p.SVG,
+	}
+	for i, pl := range p.Placements {
+		resp.Placements[i] = dto.CargoPlacementResponse{
+			UnitID:   pl.UnitID,
+			Sequence: pl.Sequence,
+			XMM:      pl.XMM,
+			YMM:      pl.YMM,
+			ZMM:      pl.ZMM,
+			LengthMM: pl.LengthMM,
+			WidthMM:  pl.WidthMM,
+			HeightMM: pl.HeightMM,
+			Rotated:  pl.Rotated,
+			WeightKg: pl.WeightKg,
+		}
+	}
+	for i, l := range p.Leftovers {
+		resp.Leftovers[i] = dto.CargoLeftoverResponse{UnitID: l.UnitID, Sequence: l.Sequence, Reason: l.Reason}
+	}
+	if p.Axles != nil {
+		resp.Axles = &dto.AxleLoadResponse{FrontKg: p.Axles.FrontKg, RearKg: p.Axles.RearKg}
+	}
+	for i, v := range p.Views {
+		view := dto.LayoutViewResponse{
+			Name:   v.Name,
+			Width:  v.Width,
+			Height: v.Height,
+			Rects:  make([]dto.LayoutRectResponse, len(v.Rects)),
+		}
+		for j, r := range v.Rects {
+			view.Rects[j] = dto.LayoutRectResponse{
+				UnitID:   r.UnitID,
+				Sequence: r.Sequence,
+				X:        r.X,
+				Y:        r.Y,
+				Width:    r.Width,
+				Height:   r.Height,
+				Color:    r.Color,
+			}
+		}
+		resp.Layout[i] = view
+	}
+	return resp
+}
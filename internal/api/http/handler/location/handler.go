@@ -0,0 +1,373 @@
+package location
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/location"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles address book and Thai address lookup requests
+type Handler struct {
+	useCase        *location.LocationUseCase
+	addressUseCase *location.AddressUseCase
+}
+
+// NewHandler creates a new location handler
+func NewHandler(useCase *location.LocationUseCase, addressUseCase *location.AddressUseCase) *Handler {
+	return &Handler{
+		useCase:        useCase,
+		addressUseCase: addressUseCase,
+	}
+}
+
+// Create godoc
+// @Summary Create location
+// @Description Save a new location in an organization's address book. The province must be a Thai province; a subdistrict and district listed in the address dataset must match the postcode, and unlisted ones are saved as entered with an address_unlisted warning. Locations saved without coordinates are geocoded and their pin precision recorded.
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param request body dto.LocationRequest true "Location details"
+// @Success 201 {object} httpresponse.Response{data=dto.LocationResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/organizations/{id}/locations [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid organization ID"))
+	}
+
+	var req dto.LocationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Create(c.Context(), orgID, toLocationInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toLocationResponse(result), "Location created successfully")
+}
+
+// List godoc
+// @Summary Search address book
+// @Description Search an organization's saved locations by name, code, road, area, postcode or contact
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param search query string false "Search text"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.LocationResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/organizations/{id}/locations [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid organization ID"))
+	}
+
+	var query dto.ListLocationsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := location.ListLocationsInput{
+		OrganizationID: orgID,
+		Search:         query.Search,
+		Limit:          query.GetLimit(),
+		Offset:         query.Offset,
+	}
+
+	results, total, err := h.useCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.LocationResponse, len(results))
+	for i, r := range results {
+		data[i] = toLocationResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// Get godoc
+// @Summary Get location
+// @Description Get a saved location by ID
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Location ID"
+// @Success 200 {object} httpresponse.Response{data=dto.LocationResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/locations/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid location ID"))
+	}
+
+	result, err := h.useCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toLocationResponse(result), "Location retrieved successfully")
+}
+
+// Update godoc
+// @Summary Update location
+// @Description Update a saved location
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Location ID"
+// @Param request body dto.LocationRequest true "Location details"
+// @Success 200 {object} httpresponse.Response{data=dto.LocationResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/locations/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid location ID"))
+	}
+
+	var req dto.LocationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Update(c.Context(), id, toLocationInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toLocationResponse(result), "Location updated successfully")
+}
+
+// Delete godoc
+// @Summary Delete location
+// @Description Remove a location from its address book
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Location ID"
+// @Success 200 {object} httpresponse.Response
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/locations/{id} [delete]
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid location ID"))
+	}
+
+	if err := h.useCase.Delete(c.Context(), id); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, nil, "Location deleted successfully")
+}
+
+// Provinces godoc
+// @Summary List provinces
+// @Description List Thai provinces for address autocomplete
+// @Tags addresses
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} httpresponse.Response{data=[]dto.AdminNameResponse}
+// @Router /api/v1/addresses/provinces [get]
+func (h *Handler) Provinces(c *fiber.Ctx) error {
+	return httpresponse.Success(c, toAdminNameResponses(h.addressUseCase.Provinces()), "Provinces retrieved successfully")
+}
+
+// Districts godoc
+// @Summary List districts
+// @Description List the districts (amphoe/khet) of a province
+// @Tags addresses
+// @Produce json
+// @Security Bearer
+// @Param province query string true "Province name in Thai or English"
+// @Success 200 {object} httpresponse.Response{data=[]dto.AdminNameResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Router /api/v1/addresses/districts [get]
+func (h *Handler) Districts(c *fiber.Ctx) error {
+	province := c.Query("province")
+	if province == "" {
+		return httpresponse.Error(c, apierror.NewBadRequestError("province is required"))
+	}
+
+	return httpresponse.Success(c, toAdminNameResponses(h.addressUseCase.Districts(province)), "Districts retrieved successfully")
+}
+
+// Subdistricts godoc
+// @Summary List subdistricts
+// @Description List the subdistricts (tambon/khwaeng) of a district with their postcodes
+// @Tags addresses
+// @Produce json
+// @Security Bearer
+// @Param province query string true "Province name in Thai or English"
+// @Param district query string true "District name in Thai or English"
+// @Success 200 {object} httpresponse.Response{data=[]dto.AdminAreaResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Router /api/v1/addresses/subdistricts [get]
+func (h *Handler) Subdistricts(c *fiber.Ctx) error {
+	province, district := c.Query("province"), c.Query("district")
+	if province == "" || district == "" {
+		return httpresponse.Error(c, apierror.NewBadRequestError("province and district are required"))
+	}
+
+	return httpresponse.Success(c, toAdminAreaResponses(h.addressUseCase.Subdistricts(province, district)), "Subdistricts retrieved successfully")
+}
+
+// ByPostcode godoc
+// @Summary Lookup postcode
+// @Description List the subdistricts served by a postcode
+// @Tags addresses
+// @Produce json
+// @Security Bearer
+// @Param postcode path string true "Five-digit postcode"
+// @Success 200 {object} httpresponse.Response{data=[]dto.AdminAreaResponse}
+// @Router /api/v1/addresses/postcodes/{postcode} [get]
+func (h *Handler) ByPostcode(c *fiber.Ctx) error {
+	return httpresponse.Success(c, toAdminAreaResponses(h.addressUseCase.ByPostcode(c.Params("postcode"))), "Areas retrieved successfully")
+}
+
+// Search godoc
+// @Summary Autocomplete address areas
+// @Description Search subdistricts, districts, provinces and postcodes by free text
+// @Tags addresses
+// @Produce json
+// @Security Bearer
+// @Param q query string true "Search text"
+// @Param limit query int false "Maximum results" default(20)
+// @Success 200 {object} httpresponse.Response{data=[]dto.AdminAreaResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Router /api/v1/addresses/search [get]
+func (h *Handler) Search(c *fiber.Ctx) error {
+	q := c.Query("q")
+	if q == "" {
+		return httpresponse.Error(c, apierror.NewBadRequestError("q is required"))
+	}
+
+	return httpresponse.Success(c, toAdminAreaResponses(h.addressUseCase.Search(q, c.QueryInt("limit", 20))), "Areas retrieved successfully")
+}
+
+func toLocationInput(req dto.LocationRequest) location.LocationInput {
+	hours := make([]entity.OpeningHours, len(req.OpeningHours))
+	for i, h := range req.OpeningHours {
+		hours[i] = entity.OpeningHours{Weekday: time.Weekday(h.Weekday), Open: h.Open, Close: h.Close}
+	}
+
+	return location.LocationInput{
+		Name:         req.Name,
+		Code:         req.Code,
+		HouseNumber:  req.HouseNumber,
+		Moo:          req.Moo,
+		Soi:          req.Soi,
+		Road:         req.Road,
+		Subdistrict:  req.Subdistrict,
+		District:     req.District,
+		Province:     req.Province,
+		Postcode:     req.Postcode,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		ContactName:  req.ContactName,
+		ContactPhone: req.ContactPhone,
+		OpeningHours: hours,
+		Notes:        req.Notes,
+	}
+}
+
+func toLocationResponse(l *location.LocationOutput) dto.LocationResponse {
+	hours := make([]dto.OpeningHoursResponse, len(l.OpeningHours))
+	for i, h := range l.OpeningHours {
+		hours[i] = dto.OpeningHoursResponse{Weekday: int(h.Weekday), Open: h.Open, Close: h.Close}
+	}
+
+	return dto.LocationResponse{
+		ID:               l.ID.String(),
+		OrganizationID:   l.OrganizationID.String(),
+		Name:             l.Name,
+		Code:             l.Code,
+		HouseNumber:      l.HouseNumber,
+		Moo:              l.Moo,
+		Soi:              l.Soi,
+		Road:             l.Road,
+		Subdistrict:      l.Subdistrict,
+		District:         l.District,
+		Province:         l.Province,
+		Postcode:         l.Postcode,
+		FormattedAddress: l.FormattedAddress,
+		Latitude:         l.Latitude,
+		Longitude:        l.Longitude,
+		GeoPrecision:     string(l.GeoPrecision),
+		ContactName:      l.ContactName,
+		ContactPhone:     l.ContactPhone,
+		OpeningHours:     hours,
+		Notes:            l.Notes,
+		AddressWarnings:  l.AddressWarnings,
+		CreatedAt:        l.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        l.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toAdminNameResponses(names []location.NameOutput) []dto.AdminNameResponse {
+	data := make([]dto.AdminNameResponse, len(names))
+	for i, n := range names {
+		data[i] = dto.AdminNameResponse{TH: n.TH, EN: n.EN}
+	}
+	return data
+}
+
+func toAdminAreaResponses(areas []location.AreaOutput) []dto.AdminAreaResponse {
+	data := make([]dto.AdminAreaResponse, len(areas))
+	for i, a := range areas {
+		data[i] = dto.AdminAreaResponse{
+			SubdistrictTH: a.SubdistrictTH,
+			SubdistrictEN: a.SubdistrictEN,
+			DistrictTH:    a.DistrictTH,
+			DistrictEN:    a.DistrictEN,
+			ProvinceTH:    a.ProvinceTH,
+			ProvinceEN:    a.ProvinceEN,
+			Postcode:      a.Postcode,
+		}
+	}
+	return data
+}
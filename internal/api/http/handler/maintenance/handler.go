@@ -0,0 +1,702 @@
+package maintenance
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/api/http/middleware"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/maintenance"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles maintenance plan, schedule and work order requests
+type Handler struct {
+	planUseCase        *maintenance.PlanUseCase
+	maintenanceUseCase *maintenance.MaintenanceUseCase
+	workOrderUseCase   *maintenance.WorkOrderUseCase
+}
+
+// NewHandler creates a new maintenance handler
+func NewHandler(
+	planUseCase *maintenance.PlanUseCase,
+	maintenanceUseCase *maintenance.MaintenanceUseCase,
+	workOrderUseCase *maintenance.WorkOrderUseCase,
+) *Handler {
+	return &Handler{
+		planUseCase:        planUseCase,
+		maintenanceUseCase: maintenanceUseCase,
+		workOrderUseCase:   workOrderUseCase,
+	}
+}
+
+// CreatePlan godoc
+// @Summary Create maintenance plan
+// @Description Add a preventive service for every vehicle of a type: every interval_km, every interval_days,
+// @Description or whichever comes first when both are set. Vehicles are counted from their odometer when the
+// @Description scheduler next runs, until a preventive work order for the plan is completed.
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.MaintenancePlanRequest true "Maintenance plan"
+// @Success 201 {object} httpresponse.Response{data=dto.MaintenancePlanResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/maintenance-plans [post]
+func (h *Handler) CreatePlan(c *fiber.Ctx) error {
+	var req dto.MaintenancePlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.planUseCase.Create(c.Context(), toPlanInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toPlanResponse(result), "Maintenance plan created successfully")
+}
+
+// ListPlans godoc
+// @Summary List maintenance plans
+// @Description List maintenance plans by vehicle type and name
+// @Tags maintenance
+// @Produce json
+// @Security Bearer
+// @Param vehicle_type query string false "Vehicle type" Enums(4w, 6w, 10w, 18w)
+// @Param active query bool false "Active"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.MaintenancePlanResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/maintenance-plans [get]
+func (h *Handler) ListPlans(c *fiber.Ctx) error {
+	var query dto.ListMaintenancePlansQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := maintenance.ListPlansInput{
+		Limit:  query.GetLimit(),
+		Offset: query.Offset,
+	}
+	if query.VehicleType != "" {
+		vehicleType := entity.VehicleType(query.VehicleType)
+		input.VehicleType = &vehicleType
+	}
+	if query.Active != "" {
+		active := query.Active == "true"
+		input.Active = &active
+	}
+
+	results, total, err := h.planUseCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.MaintenancePlanResponse, len(results))
+	for i, r := range results {
+		data[i] = toPlanResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// GetPlan godoc
+// @Summary Get maintenance plan
+// @Description Get a maintenance plan by ID
+// @Tags maintenance
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Maintenance plan ID"
+// @Success 200 {object} httpresponse.Response{data=dto.MaintenancePlanResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/maintenance-plans/{id} [get]
+func (h *Handler) GetPlan(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid maintenance plan ID"))
+	}
+
+	result, err := h.planUseCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toPlanResponse(result), "Maintenance plan retrieved successfully")
+}
+
+// UpdatePlan godoc
+// @Summary Update maintenance plan
+// @Description Replace a maintenance plan, e.g. to change its intervals or retire it with active false.
+// @Description New intervals count from each vehicle's last service.
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Maintenance plan ID"
+// @Param request body dto.MaintenancePlanRequest true "Maintenance plan"
+// @Success 200 {object} httpresponse.Response{data=dto.MaintenancePlanResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/maintenance-plans/{id} [put]
+func (h *Handler) UpdatePlan(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid maintenance plan ID"))
+	}
+
+	var req dto.MaintenancePlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.planUseCase.Update(c.Context(), id, toPlanInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toPlanResponse(result), "Maintenance plan updated successfully")
+}
+
+// VehicleSchedules godoc
+// @Summary Get vehicle maintenance
+// @Description Check a vehicle's maintenance schedules against its latest odometer reading and list them,
+// @Description most urgent first. An overdue vehicle cannot be assigned to trips.
+// @Tags maintenance
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Vehicle ID"
+// @Success 200 {object} httpresponse.Response{data=[]dto.MaintenanceScheduleResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicles/{id}/maintenance [get]
+func (h *Handler) VehicleSchedules(c *fiber.Ctx) error {
+	vehicleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid vehicle ID"))
+	}
+
+	results, err := h.maintenanceUseCase.VehicleSchedules(c.Context(), vehicleID)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.MaintenanceScheduleResponse, len(results))
+	for i, r := range results {
+		data[i] = toScheduleResponse(r)
+	}
+
+	return httpresponse.Success(c, data, "Maintenance schedules retrieved successfully")
+}
+
+// ListSchedules godoc
+// @Summary List maintenance schedules
+// @Description List when vehicles are next due for service as of the last check, overdue first, then due,
+// @Description then by due date
+// @Tags maintenance
+// @Produce json
+// @Security Bearer
+// @Param vehicle_id query string false "Vehicle ID"
+// @Param status query string false "Status" Enums(ok, due, overdue)
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.MaintenanceScheduleResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/maintenance/schedules [get]
+func (h *Handler) ListSchedules(c *fiber.Ctx) error {
+	var query dto.ListMaintenanceSchedulesQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := maintenance.ListSchedulesInput{
+		VehicleID: parseOptionalID(query.VehicleID),
+		Limit:     query.GetLimit(),
+		Offset:    query.Offset,
+	}
+	if query.Status != "" {
+		status := entity.MaintenanceStatus(query.Status)
+		input.Status = &status
+	}
+
+	results, total, err := h.maintenanceUseCase.ListSchedules(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.MaintenanceScheduleResponse, len(results))
+	for i, r := range results {
+		data[i] = toScheduleResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// CheckSchedules godoc
+// @Summary Check maintenance schedules
+// @Description Check every vehicle's maintenance schedules now rather than at the scheduler's next run,
+// @Description raising an alert for each service that became due or overdue
+// @Tags maintenance
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} httpresponse.Response{data=dto.MaintenanceCheckResponse}
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/maintenance/check [post]
+func (h *Handler) CheckSchedules(c *fiber.Ctx) error {
+	alerts, err := h.maintenanceUseCase.CheckSchedules(c.Context())
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, dto.MaintenanceCheckResponse{Alerts: alerts}, "Maintenance schedules checked successfully")
+}
+
+// CreateWorkOrder godoc
+// @Summary Create work order
+// @Description Open a work order for maintenance or repair work on a vehicle, with the workshop, parts and labor.
+// @Description A preventive work order services a maintenance plan for the vehicle's type.
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateWorkOrderRequest true "Work order"
+// @Success 201 {object} httpresponse.Response{data=dto.WorkOrderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/work-orders [post]
+func (h *Handler) CreateWorkOrder(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	var req dto.CreateWorkOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := maintenance.WorkOrderInput{
+		VehicleID:   uuid.MustParse(req.VehicleID),
+		PlanID:      parseOptionalID(req.PlanID),
+		Type:        entity.WorkOrderType(req.Type),
+		Workshop:    req.Workshop,
+		Description: req.Description,
+		OdometerKm:  req.OdometerKm,
+		Parts:       toPartInputs(req.Parts),
+		LaborCost:   entity.Baht(req.LaborCost),
+		Notes:       req.Notes,
+		CreatedBy:   userID,
+	}
+
+	result, err := h.workOrderUseCase.Create(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toWorkOrderResponse(result), "Work order created successfully")
+}
+
+// ListWorkOrders godoc
+// @Summary List work orders
+// @Description List work orders, newest first. search matches the number or workshop.
+// @Tags maintenance
+// @Produce json
+// @Security Bearer
+// @Param vehicle_id query string false "Vehicle ID"
+// @Param status query string false "Status" Enums(open, in_progress, completed, cancelled)
+// @Param type query string false "Type" Enums(preventive, corrective)
+// @Param search query string false "Search"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.WorkOrderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/work-orders [get]
+func (h *Handler) ListWorkOrders(c *fiber.Ctx) error {
+	var query dto.ListWorkOrdersQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := maintenance.ListWorkOrdersInput{
+		VehicleID: parseOptionalID(query.VehicleID),
+		Search:    query.Search,
+		Limit:     query.GetLimit(),
+		Offset:    query.Offset,
+	}
+	if query.Status != "" {
+		status := entity.WorkOrderStatus(query.Status)
+		input.Status = &status
+	}
+	if query.Type != "" {
+		workOrderType := entity.WorkOrderType(query.Type)
+		input.Type = &workOrderType
+	}
+
+	results, total, err := h.workOrderUseCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.WorkOrderResponse, len(results))
+	for i, r := range results {
+		data[i] = toWorkOrderResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// GetWorkOrder godoc
+// @Summary Get work order
+// @Description Get a work order with its parts by ID
+// @Tags maintenance
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Work order ID"
+// @Success 200 {object} httpresponse.Response{data=dto.WorkOrderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/work-orders/{id} [get]
+func (h *Handler) GetWorkOrder(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid work order ID"))
+	}
+
+	result, err := h.workOrderUseCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toWorkOrderResponse(result), "Work order retrieved successfully")
+}
+
+// UpdateWorkOrder godoc
+// @Summary Update work order
+// @Description Replace the details, parts and labor of a work order that is not yet completed or cancelled
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Work order ID"
+// @Param request body dto.UpdateWorkOrderRequest true "Work order details"
+// @Success 200 {object} httpresponse.Response{data=dto.WorkOrderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/work-orders/{id} [put]
+func (h *Handler) UpdateWorkOrder(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid work order ID"))
+	}
+
+	var req dto.UpdateWorkOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := maintenance.UpdateWorkOrderInput{
+		Workshop:    req.Workshop,
+		Description: req.Description,
+		OdometerKm:  req.OdometerKm,
+		Parts:       toPartInputs(req.Parts),
+		LaborCost:   entity.Baht(req.LaborCost),
+		Notes:       req.Notes,
+	}
+
+	result, err := h.workOrderUseCase.Update(c.Context(), id, input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toWorkOrderResponse(result), "Work order updated successfully")
+}
+
+// StartWorkOrder godoc
+// @Summary Start work order
+// @Description Record that the vehicle went off the road for the work; downtime runs from then until completion
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Work order ID"
+// @Param request body dto.StartWorkOrderRequest false "Start time"
+// @Success 200 {object} httpresponse.Response{data=dto.WorkOrderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/work-orders/{id}/start [post]
+func (h *Handler) StartWorkOrder(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid work order ID"))
+	}
+
+	var req dto.StartWorkOrderRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return httpresponse.Error(c, err)
+		}
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	startedAt := time.Now()
+	if t := dto.ParseTimestamp(req.StartedAt); t != nil {
+		startedAt = *t
+	}
+
+	result, err := h.workOrderUseCase.Start(c.Context(), id, startedAt)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toWorkOrderResponse(result), "Work order started successfully")
+}
+
+// CompleteWorkOrder godoc
+// @Summary Complete work order
+// @Description Close a work order when the vehicle goes back on the road. Completing a preventive work order
+// @Description restarts the vehicle's schedule for its plan from the odometer reading; the total cost is recorded
+// @Description as a maintenance or repair expense of the vehicle.
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Work order ID"
+// @Param request body dto.CompleteWorkOrderRequest false "Completion"
+// @Success 200 {object} httpresponse.Response{data=dto.WorkOrderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/work-orders/{id}/complete [post]
+func (h *Handler) CompleteWorkOrder(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid work order ID"))
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	var req dto.CompleteWorkOrderRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return httpresponse.Error(c, err)
+		}
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := maintenance.CompleteWorkOrderInput{
+		CompletedAt: time.Now(),
+		StartedAt:   dto.ParseTimestamp(req.StartedAt),
+		OdometerKm:  req.OdometerKm,
+		CompletedBy: userID,
+	}
+	if t := dto.ParseTimestamp(req.CompletedAt); t != nil {
+		input.CompletedAt = *t
+	}
+
+	result, err := h.workOrderUseCase.Complete(c.Context(), id, input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toWorkOrderResponse(result), "Work order completed successfully")
+}
+
+// CancelWorkOrder godoc
+// @Summary Cancel work order
+// @Description Drop a work order that was not carried out
+// @Tags maintenance
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Work order ID"
+// @Success 200 {object} httpresponse.Response{data=dto.WorkOrderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/work-orders/{id}/cancel [post]
+func (h *Handler) CancelWorkOrder(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid work order ID"))
+	}
+
+	result, err := h.workOrderUseCase.Cancel(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toWorkOrderResponse(result), "Work order cancelled successfully")
+}
+
+// parseOptionalID parses an ID already validated as a UUID, if given
+func parseOptionalID(value string) *uuid.UUID {
+	if value == "" {
+		return nil
+	}
+	id := uuid.MustParse(value)
+	return &id
+}
+
+func formatOptionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func toPlanInput(req dto.MaintenancePlanRequest) maintenance.PlanInput {
+	return maintenance.PlanInput{
+		Name:         req.Name,
+		VehicleType:  entity.VehicleType(req.VehicleType),
+		IntervalKm:   req.IntervalKm,
+		IntervalDays: req.IntervalDays,
+		Description:  req.Description,
+		Active:       req.Active == nil || *req.Active,
+	}
+}
+
+func toPartInputs(reqs []dto.WorkOrderPartRequest) []maintenance.PartInput {
+	parts := make([]maintenance.PartInput, len(reqs))
+	for i, p := range reqs {
+		parts[i] = maintenance.PartInput{
+			PartNumber: p.PartNumber,
+			Name:       p.Name,
+			Quantity:   p.Quantity,
+			UnitCost:   entity.Baht(p.UnitCost),
+		}
+	}
+	return parts
+}
+
+func toPlanResponse(p *maintenance.PlanOutput) dto.MaintenancePlanResponse {
+	return dto.MaintenancePlanResponse{
+		ID:           p.ID.String(),
+		Name:         p.Name,
+		VehicleType:  string(p.VehicleType),
+		IntervalKm:   p.IntervalKm,
+		IntervalDays: p.IntervalDays,
+		Description:  p.Description,
+		Active:       p.Active,
+		CreatedAt:    p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    p.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toScheduleResponse(s *maintenance.ScheduleOutput) dto.MaintenanceScheduleResponse {
+	return dto.MaintenanceScheduleResponse{
+		ID:              s.ID.String(),
+		VehicleID:       s.VehicleID.String(),
+		PlanID:          s.PlanID.String(),
+		PlanName:        s.PlanName,
+		LastServiceAt:   s.LastServiceAt.Format(time.RFC3339),
+		LastServiceKm:   s.LastServiceKm,
+		LastWorkOrderID: formatOptionalID(s.LastWorkOrderID),
+		CurrentKm:       s.CurrentKm,
+		NextDueAt:       dto.FormatTimestamp(s.NextDueAt),
+		NextDueKm:       s.NextDueKm,
+		RemainingKm:     s.RemainingKm,
+		Status:          string(s.Status),
+		CheckedAt:       s.CheckedAt.Format(time.RFC3339),
+	}
+}
+
+func toWorkOrderResponse(o *maintenance.WorkOrderOutput) dto.WorkOrderResponse {
+	parts := make([]dto.WorkOrderPartResponse, len(o.Parts))
+	for i, p := range o.Parts {
+		parts[i] = dto.WorkOrderPartResponse{
+			Sequence:   p.Sequence,
+			PartNumber: p.PartNumber,
+			Name:       p.Name,
+			Quantity:   p.Quantity,
+			UnitCost:   p.UnitCost.Baht(),
+			Amount:     p.Amount.Baht(),
+		}
+	}
+
+	return dto.WorkOrderResponse{
+		ID:            o.ID.String(),
+		Number:        o.Number,
+		VehicleID:     o.VehicleID.String(),
+		PlanID:        formatOptionalID(o.PlanID),
+		Type:          string(o.Type),
+		Status:        string(o.Status),
+		Workshop:      o.Workshop,
+		Description:   o.Description,
+		OdometerKm:    o.OdometerKm,
+		Parts:         parts,
+		PartsCost:     o.PartsCost.Baht(),
+		LaborCost:     o.LaborCost.Baht(),
+		TotalCost:     o.TotalCost.Baht(),
+		StartedAt:     dto.FormatTimestamp(o.StartedAt),
+		CompletedAt:   dto.FormatTimestamp(o.CompletedAt),
+		DowntimeHours: o.DowntimeHours,
+		Notes:         o.Notes,
+		CreatedBy:     formatOptionalID(o.CreatedBy),
+		CreatedAt:     o.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     o.UpdatedAt.Format(time.RFC3339),
+	}
+}
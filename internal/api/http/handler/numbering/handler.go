@@ -0,0 +1,130 @@
+package numbering
+
+import (
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/numbering"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles document number format requests
+type Handler struct {
+	useCase *numbering.NumberingUseCase
+}
+
+// NewHandler creates a new numbering handler
+func NewHandler(useCase *numbering.NumberingUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// List godoc
+// @Summary List number formats
+// @Description List the number format that applies to each document type of an organization, its own or the default, with an example number
+// @Tags numbering
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Success 200 {object} httpresponse.Response{data=[]dto.NumberingFormatResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/organizations/{id}/numbering-formats [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid organization ID"))
+	}
+
+	results, err := h.useCase.ListFormats(c.Context(), orgID)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.NumberingFormatResponse, len(results))
+	for i, r := range results {
+		data[i] = toNumberingFormatResponse(r)
+	}
+
+	return httpresponse.Success(c, data, "Number formats retrieved successfully")
+}
+
+// Set godoc
+// @Summary Set number format
+// @Description Set an organization's own number format for a document type. Formats combine literal text with the placeholders {YYYY} {YY} {MM} {DD} {YYMM}, their Buddhist-era forms {BBBB} {BB} {BBMM}, exactly one {seq:N} zero-padded to N digits and an optional trailing {mod11} or {mod10} check character. The sequence restarts every year, month or day according to the smallest date part used, which must be shown together with the larger ones. Numbers must begin with literal text that neither begins nor is begun by that of the default format or another organization's format. Shipment tracking numbers require a check character.
+// @Tags numbering
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param type path string true "Document type" Enums(shipment, trip, invoice, credit_note, proof_of_delivery)
+// @Param request body dto.NumberingFormatRequest true "Number format"
+// @Success 200 {object} httpresponse.Response{data=dto.NumberingFormatResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/organizations/{id}/numbering-formats/{type} [put]
+func (h *Handler) Set(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid organization ID"))
+	}
+
+	var req dto.NumberingFormatRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.SetFormat(c.Context(), orgID, entity.DocumentType(c.Params("type")), req.Format)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toNumberingFormatResponse(result), "Number format updated successfully")
+}
+
+// Delete godoc
+// @Summary Reset number format
+// @Description Remove an organization's own number format for a document type so the default format applies again
+// @Tags numbering
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param type path string true "Document type" Enums(shipment, trip, invoice, credit_note, proof_of_delivery)
+// @Success 200 {object} httpresponse.Response
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/organizations/{id}/numbering-formats/{type} [delete]
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid organization ID"))
+	}
+
+	if err := h.useCase.DeleteFormat(c.Context(), orgID, entity.DocumentType(c.Params("type"))); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, nil, "Number format reset successfully")
+}
+
+func toNumberingFormatResponse(f *numbering.FormatOutput) dto.NumberingFormatResponse {
+	return dto.NumberingFormatResponse{
+		DocumentType: string(f.DocumentType),
+		Format:       f.Format,
+		Custom:       f.Custom,
+		Example:      f.Example,
+		UpdatedAt:    dto.FormatTimestamp(f.UpdatedAt),
+	}
+}
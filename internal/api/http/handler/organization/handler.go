@@ -0,0 +1,189 @@
+package organization
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/usecase/organization"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles organization requests
+type Handler struct {
+	useCase *organization.OrganizationUseCase
+}
+
+// NewHandler creates a new organization handler
+func NewHandler(useCase *organization.OrganizationUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Create godoc
+// @Summary Create organization
+// @Description Create a new organization
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.OrganizationRequest true "Organization details"
+// @Success 201 {object} httpresponse.Response{data=dto.OrganizationResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/organizations [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req dto.OrganizationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Create(c.Context(), toOrganizationInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toOrganizationResponse(result), "Organization created successfully")
+}
+
+// List godoc
+// @Summary List organizations
+// @Description List organizations with optional search by name or tax ID
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param search query string false "Search by name or tax ID"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.OrganizationResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/organizations [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	var query dto.ListOrganizationsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := organization.ListOrganizationsInput{
+		Search: query.Search,
+		Limit:  query.GetLimit(),
+		Offset: query.Offset,
+	}
+
+	results, total, err := h.useCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.OrganizationResponse, len(results))
+	for i, r := range results {
+		data[i] = toOrganizationResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// Get godoc
+// @Summary Get organization
+// @Description Get an organization by ID
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Success 200 {object} httpresponse.Response{data=dto.OrganizationResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/organizations/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid organization ID"))
+	}
+
+	result, err := h.useCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toOrganizationResponse(result), "Organization retrieved successfully")
+}
+
+// Update godoc
+// @Summary Update organization
+// @Description Update an organization
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Organization ID"
+// @Param request body dto.OrganizationRequest true "Organization details"
+// @Success 200 {object} httpresponse.Response{data=dto.OrganizationResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/organizations/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid organization ID"))
+	}
+
+	var req dto.OrganizationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Update(c.Context(), id, toOrganizationInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toOrganizationResponse(result), "Organization updated successfully")
+}
+
+func toOrganizationInput(req dto.OrganizationRequest) organization.OrganizationInput {
+	return organization.OrganizationInput{
+		Name:           req.Name,
+		TaxID:          req.TaxID,
+		BranchCode:     req.BranchCode,
+		Phone:          req.Phone,
+		Email:          req.Email,
+		CreditTermDays: req.CreditTermDays,
+		WithholdsTax:   req.WithholdsTax == nil || *req.WithholdsTax,
+	}
+}
+
+func toOrganizationResponse(o *organization.OrganizationOutput) dto.OrganizationResponse {
+	return dto.OrganizationResponse{
+		ID:             o.ID.String(),
+		Name:           o.Name,
+		TaxID:          o.TaxID,
+		BranchCode:     o.BranchCode,
+		Phone:          o.Phone,
+		Email:          o.Email,
+		CreditTermDays: o.CreditTermDays,
+		WithholdsTax:   o.WithholdsTax,
+		CreatedAt:      o.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      o.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,172 @@
+package planning
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/internal/usecase/planning"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles route optimization requests
+type Handler struct {
+	useCase *planning.PlanningUseCase
+}
+
+// NewHandler creates a new planning handler
+func NewHandler(useCase *planning.PlanningUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Optimize godoc
+// @Summary Optimize routes
+// @Description Queue a vehicle routing job over depots, stops with demand and time windows, and a fleet with capacities.
+// @Description Without a matrix, travel is estimated from coordinates. Poll the returned job for proposed trips and unassigned stops with reasons.
+// @Tags planning
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.OptimizeRequest true "Routing problem"
+// @Success 202 {object} httpresponse.Response{data=dto.PlanningJobResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Failure 503 {object} httpresponse.Response "Too many optimization jobs queued"
+// @Router /api/v1/planning/optimize [post]
+func (h *Handler) Optimize(c *fiber.Ctx) error {
+	var req dto.OptimizeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Optimize(c.Context(), toOptimizeInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Accepted(c, toJobResponse(result), "Optimization job queued")
+}
+
+// GetJob godoc
+// @Summary Get optimization job
+// @Description Get the status of an optimization job and, once completed, its proposed plan. Jobs are kept for 24 hours.
+// @Tags planning
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Job ID"
+// @Success 200 {object} httpresponse.Response{data=dto.PlanningJobResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/planning/jobs/{id} [get]
+func (h *Handler) GetJob(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid job ID"))
+	}
+
+	result, err := h.useCase.GetJob(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toJobResponse(result), "Optimization job retrieved successfully")
+}
+
+func toOptimizeInput(req dto.OptimizeRequest) planning.OptimizeInput {
+	input := planning.OptimizeInput{
+		Depots:    make([]service.RoutingDepot, len(req.Depots)),
+		Stops:     make([]service.RoutingStop, len(req.Stops)),
+		Vehicles:  make([]service.RoutingVehicle, len(req.Vehicles)),
+		TimeLimit: time.Duration(req.TimeLimitSeconds) * time.Second,
+	}
+
+	for i, d := range req.Depots {
+		input.Depots[i] = service.RoutingDepot{ID: d.ID, Latitude: *d.Latitude, Longitude: *d.Longitude}
+	}
+	for i, s := range req.Stops {
+		input.Stops[i] = service.RoutingStop{
+			ID:          s.ID,
+			Latitude:    *s.Latitude,
+			Longitude:   *s.Longitude,
+			Demand:      entity.Load{WeightKg: s.WeightKg, VolumeM3: s.VolumeM3, Pallets: s.Pallets},
+			ServiceTime: time.Duration(s.ServiceMinutes) * time.Minute,
+			WindowStart: dto.ParseTimestamp(s.WindowStart),
+			WindowEnd:   dto.ParseTimestamp(s.WindowEnd),
+		}
+	}
+	for i, v := range req.Vehicles {
+		input.Vehicles[i] = service.RoutingVehicle{
+			ID:         v.ID,
+			DepotID:    v.DepotID,
+			Capacity:   entity.Load{WeightKg: v.MaxWeightKg, VolumeM3: v.MaxVolumeM3, Pallets: v.MaxPallets},
+			ShiftStart: *dto.ParseTimestamp(v.ShiftStart),
+			ShiftEnd:   dto.ParseTimestamp(v.ShiftEnd),
+		}
+	}
+	if req.Matrix != nil {
+		input.Matrix = &service.TravelMatrix{DistancesM: req.Matrix.DistancesM, DurationsS: req.Matrix.DurationsS}
+	}
+
+	return input
+}
+
+func toJobResponse(j *planning.JobOutput) dto.PlanningJobResponse {
+	resp := dto.PlanningJobResponse{
+		ID:               j.ID.String(),
+		Status:           string(j.Status),
+		TimeLimitSeconds: int(j.TimeLimit / time.Second),
+		Error:            j.Error,
+		CreatedAt:        j.CreatedAt.Format(time.RFC3339),
+		StartedAt:        dto.FormatTimestamp(j.StartedAt),
+		CompletedAt:      dto.FormatTimestamp(j.CompletedAt),
+	}
+	if j.Solution != nil {
+		resp.Solution = toSolutionResponse(j.Solution)
+	}
+	return resp
+}
+
+func toSolutionResponse(s *service.RoutingSolution) *dto.RoutingSolutionResponse {
+	resp := &dto.RoutingSolutionResponse{
+		Routes:         make([]dto.PlannedRouteResponse, len(s.Routes)),
+		Unassigned:     make([]dto.UnassignedStopResponse, len(s.Unassigned)),
+		TotalDistanceM: s.TotalDistanceM,
+		Iterations:     s.Iterations,
+	}
+	for i, r := range s.Routes {
+		route := dto.PlannedRouteResponse{
+			VehicleID: r.VehicleID,
+			Visits:    make([]dto.PlannedVisitResponse, len(r.Visits)),
+			DistanceM: r.DistanceM,
+			Start:     r.Start.Format(time.RFC3339),
+			End:       r.End.Format(time.RFC3339),
+			WeightKg:  r.Load.WeightKg,
+			VolumeM3:  r.Load.VolumeM3,
+			Pallets:   r.Load.Pallets,
+		}
+		for j, v := range r.Visits {
+			route.Visits[j] = dto.PlannedVisitResponse{
+				StopID:       v.StopID,
+				Arrival:      v.Arrival.Format(time.RFC3339),
+				ServiceStart: v.ServiceStart.Format(time.RFC3339),
+				Departure:    v.Departure.Format(time.RFC3339),
+			}
+		}
+		resp.Routes[i] = route
+	}
+	for i, u := range s.Unassigned {
+		resp.Unassigned[i] = dto.UnassignedStopResponse{StopID: u.StopID, Reason: u.Reason}
+	}
+	return resp
+}
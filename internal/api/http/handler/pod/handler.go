@@ -0,0 +1,397 @@
+package pod
+
+import (
+	"fmt"
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/api/http/middleware"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/pod"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles electronic proof-of-delivery and failed delivery requests
+type Handler struct {
+	useCase *pod.ProofOfDeliveryUseCase
+}
+
+// NewHandler creates a new proof-of-delivery handler
+func NewHandler(useCase *pod.ProofOfDeliveryUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// UploadURLs godoc
+// @Summary Get POD upload URLs
+// @Description Get presigned URLs for uploading the signature and delivery photos of a delivery stop.
+// @Description Only the trip's driver or co-driver may upload. PUT each file to its upload_url, then submit the object keys.
+// @Tags pod
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Trip ID"
+// @Param stopId path string true "Stop ID"
+// @Param request body dto.PODUploadURLsRequest true "Content types of the files"
+// @Success 200 {object} httpresponse.Response{data=dto.PODUploadURLsResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips/{id}/stops/{stopId}/pod/upload-urls [post]
+func (h *Handler) UploadURLs(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	tripID, stopID, err := parseStop(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	var req dto.PODUploadURLsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.UploadURLs(c.Context(), pod.UploadURLsInput{
+		UserID:               userID,
+		TripID:               tripID,
+		StopID:               stopID,
+		SignatureContentType: req.SignatureContentType,
+		PhotoContentTypes:    req.PhotoContentTypes,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	resp := dto.PODUploadURLsResponse{
+		Signature: dto.PresignUploadResponse{UploadURL: result.Signature.UploadURL, ObjectKey: result.Signature.ObjectKey},
+		Photos:    make([]dto.PresignUploadResponse, len(result.Photos)),
+	}
+	for i, p := range result.Photos {
+		resp.Photos[i] = dto.PresignUploadResponse{UploadURL: p.UploadURL, ObjectKey: p.ObjectKey}
+	}
+
+	return httpresponse.Success(c, resp, "Upload URLs generated successfully")
+}
+
+// Submit godoc
+// @Summary Submit proof of delivery
+// @Description Record the recipient, signature, photos and device position for a delivery stop and mark its shipment delivered.
+// @Description The files must have been uploaded, the trip must be in progress and the position must be near the delivery location.
+// @Tags pod
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Trip ID"
+// @Param stopId path string true "Stop ID"
+// @Param request body dto.SubmitPODRequest true "Proof of delivery"
+// @Success 201 {object} httpresponse.Response{data=dto.PODResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips/{id}/stops/{stopId}/pod [post]
+func (h *Handler) Submit(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	tripID, stopID, err := parseStop(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	var req dto.SubmitPODRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Submit(c.Context(), pod.SubmitInput{
+		UserID:        userID,
+		TripID:        tripID,
+		StopID:        stopID,
+		RecipientName: req.RecipientName,
+		SignatureKey:  req.SignatureKey,
+		PhotoKeys:     req.PhotoKeys,
+		Latitude:      *req.Latitude,
+		Longitude:     *req.Longitude,
+		AccuracyM:     req.AccuracyM,
+		CapturedAt:    *dto.ParseTimestamp(req.CapturedAt),
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toPODResponse(result), "Proof of delivery recorded successfully")
+}
+
+// Get godoc
+// @Summary Get proof of delivery
+// @Description Get the proof of delivery of a stop with short-lived download URLs for the signature and photos
+// @Tags pod
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Trip ID"
+// @Param stopId path string true "Stop ID"
+// @Success 200 {object} httpresponse.Response{data=dto.PODResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips/{id}/stops/{stopId}/pod [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	tripID, stopID, err := parseStop(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Get(c.Context(), tripID, stopID)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toPODResponse(result), "Proof of delivery retrieved successfully")
+}
+
+// Document godoc
+// @Summary Download proof of delivery PDF
+// @Description Download the proof of delivery of a stop as a printable PDF with the signature and photos
+// @Tags pod
+// @Produce application/pdf
+// @Security Bearer
+// @Param id path string true "Trip ID"
+// @Param stopId path string true "Stop ID"
+// @Success 200 {file} file
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips/{id}/stops/{stopId}/pod/pdf [get]
+func (h *Handler) Document(c *fiber.Ctx) error {
+	tripID, stopID, err := parseStop(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Document(c.Context(), tripID, stopID)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", result.Filename))
+	return c.Send(result.Content)
+}
+
+// AttemptUploadURL godoc
+// @Summary Get failed delivery upload URL
+// @Description Get a presigned URL for uploading the evidence photo of a failed delivery at a delivery stop.
+// @Description Only the trip's driver or co-driver may upload. PUT the file to upload_url, then record the attempt with the object key.
+// @Tags pod
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Trip ID"
+// @Param stopId path string true "Stop ID"
+// @Param request body dto.AttemptUploadURLRequest true "Content type of the photo"
+// @Success 200 {object} httpresponse.Response{data=dto.PresignUploadResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips/{id}/stops/{stopId}/failed-attempt/upload-url [post]
+func (h *Handler) AttemptUploadURL(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	tripID, stopID, err := parseStop(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	var req dto.AttemptUploadURLRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.AttemptUploadURL(c.Context(), pod.AttemptUploadURLInput{
+		UserID:      userID,
+		TripID:      tripID,
+		StopID:      stopID,
+		ContentType: req.ContentType,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, dto.PresignUploadResponse{UploadURL: result.UploadURL, ObjectKey: result.ObjectKey}, "Upload URL generated successfully")
+}
+
+// Fail godoc
+// @Summary Record failed delivery
+// @Description Record why the delivery at a stop could not be made, with an evidence photo and the device position.
+// @Description The shipment is marked failed and a follow-up leg is booked as a new shipment: another attempt, or a return
+// @Description to the sender once the configured number of attempts was made. Consignees can pick a new date for a reattempt
+// @Description on the tracking page. Unless the address was wrong, the position must be near the delivery location.
+// @Tags pod
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Trip ID"
+// @Param stopId path string true "Stop ID"
+// @Param request body dto.FailedAttemptRequest true "Failed delivery"
+// @Success 201 {object} httpresponse.Response{data=dto.FailedAttemptResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips/{id}/stops/{stopId}/failed-attempt [post]
+func (h *Handler) Fail(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	tripID, stopID, err := parseStop(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	var req dto.FailedAttemptRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Fail(c.Context(), pod.FailInput{
+		UserID:      userID,
+		TripID:      tripID,
+		StopID:      stopID,
+		Reason:      entity.FailureReason(req.Reason),
+		PhotoKey:    req.PhotoKey,
+		Latitude:    *req.Latitude,
+		Longitude:   *req.Longitude,
+		AccuracyM:   req.AccuracyM,
+		AttemptedAt: *dto.ParseTimestamp(req.AttemptedAt),
+		Notes:       req.Notes,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toFailedAttemptResponse(result), "Failed delivery recorded successfully")
+}
+
+// GetAttempt godoc
+// @Summary Get failed delivery
+// @Description Get the failed delivery recorded at a stop with a short-lived download URL for its photo and the follow-up leg
+// @Tags pod
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Trip ID"
+// @Param stopId path string true "Stop ID"
+// @Success 200 {object} httpresponse.Response{data=dto.FailedAttemptResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips/{id}/stops/{stopId}/failed-attempt [get]
+func (h *Handler) GetAttempt(c *fiber.Ctx) error {
+	tripID, stopID, err := parseStop(c)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.GetAttempt(c.Context(), tripID, stopID)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toFailedAttemptResponse(result), "Failed delivery retrieved successfully")
+}
+
+func parseStop(c *fiber.Ctx) (uuid.UUID, uuid.UUID, error) {
+	tripID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apierror.NewBadRequestError("Invalid trip ID")
+	}
+	stopID, err := uuid.Parse(c.Params("stopId"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, apierror.NewBadRequestError("Invalid stop ID")
+	}
+	return tripID, stopID, nil
+}
+
+func toPODResponse(o *pod.ProofOfDeliveryOutput) dto.PODResponse {
+	return dto.PODResponse{
+		ID:             o.ID.String(),
+		DocumentNumber: o.Number,
+		TripID:         o.TripID.String(),
+		StopID:         o.StopID.String(),
+		ShipmentID:     o.ShipmentID.String(),
+		DriverID:       o.DriverID.String(),
+		RecipientName:  o.RecipientName,
+		SignatureKey:   o.SignatureKey,
+		SignatureURL:   o.SignatureURL,
+		PhotoKeys:      o.PhotoKeys,
+		PhotoURLs:      o.PhotoURLs,
+		Latitude:       o.Latitude,
+		Longitude:      o.Longitude,
+		AccuracyM:      o.AccuracyM,
+		DistanceM:      o.DistanceM,
+		CapturedAt:     o.CapturedAt.Format(time.RFC3339),
+		Notes:          o.Notes,
+		CreatedAt:      o.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toFailedAttemptResponse(o *pod.DeliveryAttemptOutput) dto.FailedAttemptResponse {
+	return dto.FailedAttemptResponse{
+		ID:                o.ID.String(),
+		TripID:            o.TripID.String(),
+		StopID:            o.StopID.String(),
+		ShipmentID:        o.ShipmentID.String(),
+		DriverID:          o.DriverID.String(),
+		Attempt:           o.Attempt,
+		Reason:            string(o.Reason),
+		PhotoKey:          o.PhotoKey,
+		PhotoURL:          o.PhotoURL,
+		Latitude:          o.Latitude,
+		Longitude:         o.Longitude,
+		AccuracyM:         o.AccuracyM,
+		DistanceM:         o.DistanceM,
+		AttemptedAt:       o.AttemptedAt.Format(time.RFC3339),
+		Notes:             o.Notes,
+		LegID:             o.LegID.String(),
+		Leg:               string(o.Leg),
+		LegTrackingNumber: o.LegTrackingNumber,
+		CreatedAt:         o.CreatedAt.Format(time.RFC3339),
+	}
+}
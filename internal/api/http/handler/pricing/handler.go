@@ -0,0 +1,477 @@
+package pricing
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/pricing"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles rate card, diesel price and quoting requests
+type Handler struct {
+	rateCardUseCase *pricing.RateCardUseCase
+	pricingUseCase  *pricing.PricingUseCase
+}
+
+// NewHandler creates a new pricing handler
+func NewHandler(rateCardUseCase *pricing.RateCardUseCase, pricingUseCase *pricing.PricingUseCase) *Handler {
+	return &Handler{rateCardUseCase: rateCardUseCase, pricingUseCase: pricingUseCase}
+}
+
+// CreateRateCard godoc
+// @Summary Publish rate card version
+// @Description Publish a new version of a customer's rate card, or of the standard tariff when organization_id is omitted.
+// @Description Versions are immutable and numbered per name; of the cards in effect at the pricing date, the one that took effect last applies.
+// @Tags pricing
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateRateCardRequest true "Rate card"
+// @Success 201 {object} httpresponse.Response{data=dto.RateCardResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/rate-cards [post]
+func (h *Handler) CreateRateCard(c *fiber.Ctx) error {
+	var req dto.CreateRateCardRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.rateCardUseCase.Create(c.Context(), toRateCardInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toRateCardResponse(result), "Rate card created successfully")
+}
+
+// ListRateCards godoc
+// @Summary List rate cards
+// @Description List rate card versions by organization and effective date
+// @Tags pricing
+// @Produce json
+// @Security Bearer
+// @Param organization_id query string false "Organization ID"
+// @Param standard query bool false "Only the standard tariff"
+// @Param effective_at query string false "Effective at (RFC 3339)"
+// @Param search query string false "Search by name"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.RateCardResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/rate-cards [get]
+func (h *Handler) ListRateCards(c *fiber.Ctx) error {
+	var query dto.ListRateCardsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := pricing.ListRateCardsInput{
+		StandardOnly: query.Standard,
+		EffectiveAt:  dto.ParseTimestamp(query.EffectiveAt),
+		Search:       query.Search,
+		Limit:        query.GetLimit(),
+		Offset:       query.Offset,
+	}
+	if query.OrganizationID != "" {
+		id := uuid.MustParse(query.OrganizationID)
+		input.OrganizationID = &id
+	}
+
+	results, total, err := h.rateCardUseCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.RateCardResponse, len(results))
+	for i, r := range results {
+		data[i] = toRateCardResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// GetRateCard godoc
+// @Summary Get rate card
+// @Description Get a rate card version by ID
+// @Tags pricing
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Rate card ID"
+// @Success 200 {object} httpresponse.Response{data=dto.RateCardResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/rate-cards/{id} [get]
+func (h *Handler) GetRateCard(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid rate card ID"))
+	}
+
+	result, err := h.rateCardUseCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toRateCardResponse(result), "Rate card retrieved successfully")
+}
+
+// CreateDieselPrice godoc
+// @Summary Record diesel price
+// @Description Record the reference diesel price used for fuel surcharges from a date on
+// @Tags pricing
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateDieselPriceRequest true "Diesel price"
+// @Success 201 {object} httpresponse.Response{data=dto.DieselPriceResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/diesel-prices [post]
+func (h *Handler) CreateDieselPrice(c *fiber.Ctx) error {
+	var req dto.CreateDieselPriceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	effectiveDate, err := time.Parse(dto.DateLayout, req.EffectiveDate)
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid effective date"))
+	}
+
+	result, err := h.rateCardUseCase.CreateDieselPrice(c.Context(), pricing.DieselPriceInput{
+		EffectiveDate: effectiveDate,
+		PricePerLitre: entity.Baht(req.PricePerLitre),
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toDieselPriceResponse(result), "Diesel price created successfully")
+}
+
+// ListDieselPrices godoc
+// @Summary List diesel prices
+// @Description List the diesel price table, newest first
+// @Tags pricing
+// @Produce json
+// @Security Bearer
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.DieselPriceResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/diesel-prices [get]
+func (h *Handler) ListDieselPrices(c *fiber.Ctx) error {
+	var query dto.PaginationQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	results, total, err := h.rateCardUseCase.ListDieselPrices(c.Context(), query.GetLimit(), query.Offset)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.DieselPriceResponse, len(results))
+	for i, r := range results {
+		data[i] = toDieselPriceResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, query.GetLimit(), query.Offset)
+}
+
+// QuoteShipment godoc
+// @Summary Quote shipment
+// @Description Price a booked shipment against its customer's rate card effective at the pricing date (default: pickup window start), falling back to the standard tariff.
+// @Description Without distance_km the distance is estimated from the pickup and delivery coordinates. Each line carries the rule that produced it.
+// @Tags pricing
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Shipment ID"
+// @Param request body dto.QuoteShipmentRequest true "Quote options"
+// @Success 200 {object} httpresponse.Response{data=dto.QuoteResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/shipments/{id}/quote [post]
+func (h *Handler) QuoteShipment(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid shipment ID"))
+	}
+
+	var req dto.QuoteShipmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.pricingUseCase.PriceShipment(c.Context(), id, pricing.PriceShipmentInput{
+		VehicleType:  entity.VehicleType(req.VehicleType),
+		DistanceKm:   req.DistanceKm,
+		Accessorials: toAccessorialQuantities(req.Accessorials),
+		PricedAt:     dto.ParseTimestamp(req.PricedAt),
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toQuoteResponse(result), "Shipment priced successfully")
+}
+
+// Simulate godoc
+// @Summary Simulate quote
+// @Description Price a hypothetical shipment for sales. rate_card_id prices against a specific version, even one not yet effective.
+// @Tags pricing
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.SimulateQuoteRequest true "Simulated shipment"
+// @Success 200 {object} httpresponse.Response{data=dto.QuoteResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/pricing/simulate [post]
+func (h *Handler) Simulate(c *fiber.Ctx) error {
+	var req dto.SimulateQuoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := pricing.SimulateInput{
+		Origin:       toAddressInput(req.Origin),
+		Destination:  toAddressInput(req.Destination),
+		VehicleType:  entity.VehicleType(req.VehicleType),
+		DistanceKm:   req.DistanceKm,
+		Load:         entity.Load{WeightKg: req.WeightKg, VolumeM3: req.VolumeM3, Pallets: req.Pallets},
+		Accessorials: toAccessorialQuantities(req.Accessorials),
+		PricedAt:     dto.ParseTimestamp(req.PricedAt),
+	}
+	if req.RateCardID != "" {
+		id := uuid.MustParse(req.RateCardID)
+		input.RateCardID = &id
+	}
+	if req.OrganizationID != "" {
+		id := uuid.MustParse(req.OrganizationID)
+		input.OrganizationID = &id
+	}
+
+	result, err := h.pricingUseCase.Simulate(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toQuoteResponse(result), "Quote simulated successfully")
+}
+
+func toRateCardInput(req dto.CreateRateCardRequest) pricing.RateCardInput {
+	input := pricing.RateCardInput{
+		Name:             req.Name,
+		Currency:         req.Currency,
+		EffectiveFrom:    *dto.ParseTimestamp(req.EffectiveFrom),
+		EffectiveTo:      dto.ParseTimestamp(req.EffectiveTo),
+		VolumetricFactor: req.VolumetricFactor,
+		Zones:            make([]entity.RateZone, len(req.Zones)),
+		Lanes:            make([]entity.RateLane, len(req.Lanes)),
+		Accessorials:     make([]entity.AccessorialRate, len(req.Accessorials)),
+	}
+	if req.OrganizationID != "" {
+		id := uuid.MustParse(req.OrganizationID)
+		input.OrganizationID = &id
+	}
+	for i, z := range req.Zones {
+		input.Zones[i] = entity.RateZone{Code: z.Code, Provinces: z.Provinces, PostcodePrefixes: z.PostcodePrefixes}
+	}
+	for i, l := range req.Lanes {
+		lane := entity.RateLane{
+			OriginZone:      l.OriginZone,
+			DestinationZone: l.DestinationZone,
+			VehicleType:     entity.VehicleType(l.VehicleType),
+			BaseCharge:      entity.Baht(l.BaseCharge),
+			DistanceBands:   make([]entity.DistanceBand, len(l.DistanceBands)),
+			WeightBreaks:    make([]entity.WeightBreak, len(l.WeightBreaks)),
+			PerPallet:       entity.Baht(l.PerPallet),
+			MinCharge:       entity.Baht(l.MinCharge),
+		}
+		for j, b := range l.DistanceBands {
+			lane.DistanceBands[j] = entity.DistanceBand{UpToKm: b.UpToKm, Amount: entity.Baht(b.Amount), PerKm: entity.Baht(b.PerKm)}
+		}
+		for j, b := range l.WeightBreaks {
+			lane.WeightBreaks[j] = entity.WeightBreak{MinKg: b.MinKg, PerKg: entity.Baht(b.PerKg)}
+		}
+		input.Lanes[i] = lane
+	}
+	if req.Fuel != nil {
+		input.Fuel = &entity.FuelSurcharge{
+			BasePrice:   entity.Baht(req.Fuel.BasePrice),
+			Step:        entity.Baht(req.Fuel.Step),
+			StepPercent: req.Fuel.StepPercent,
+		}
+	}
+	for i, a := range req.Accessorials {
+		input.Accessorials[i] = entity.AccessorialRate{
+			Code:        a.Code,
+			Description: a.Description,
+			Unit:        entity.AccessorialUnit(a.Unit),
+			Amount:      entity.Baht(a.Amount),
+			FreeUnits:   a.FreeUnits,
+		}
+	}
+	return input
+}
+
+func toAccessorialQuantities(req []dto.AccessorialQuantityRequest) []entity.AccessorialQuantity {
+	quantities := make([]entity.AccessorialQuantity, len(req))
+	for i, a := range req {
+		quantities[i] = entity.AccessorialQuantity{Code: a.Code, Quantity: a.Quantity}
+	}
+	return quantities
+}
+
+func toAddressInput(req dto.PricingAddressRequest) pricing.AddressInput {
+	return pricing.AddressInput{
+		Province:  req.Province,
+		Postcode:  req.Postcode,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+	}
+}
+
+func toRateCardResponse(c *pricing.RateCardOutput) dto.RateCardResponse {
+	resp := dto.RateCardResponse{
+		ID:               c.ID.String(),
+		Name:             c.Name,
+		Version:          c.Version,
+		Currency:         c.Currency,
+		EffectiveFrom:    c.EffectiveFrom.Format(time.RFC3339),
+		EffectiveTo:      dto.FormatTimestamp(c.EffectiveTo),
+		VolumetricFactor: c.VolumetricFactor,
+		Zones:            make([]dto.RateZoneResponse, len(c.Zones)),
+		Lanes:            make([]dto.RateLaneResponse, len(c.Lanes)),
+		Accessorials:     make([]dto.AccessorialRateResponse, len(c.Accessorials)),
+		CreatedAt:        c.CreatedAt.Format(time.RFC3339),
+	}
+	if c.OrganizationID != nil {
+		id := c.OrganizationID.String()
+		resp.OrganizationID = &id
+	}
+	for i, z := range c.Zones {
+		resp.Zones[i] = dto.RateZoneResponse{Code: z.Code, Provinces: z.Provinces, PostcodePrefixes: z.PostcodePrefixes}
+	}
+	for i, l := range c.Lanes {
+		lane := dto.RateLaneResponse{
+			OriginZone:      l.OriginZone,
+			DestinationZone: l.DestinationZone,
+			VehicleType:     string(l.VehicleType),
+			BaseCharge:      l.BaseCharge.Baht(),
+			DistanceBands:   make([]dto.DistanceBandResponse, len(l.DistanceBands)),
+			WeightBreaks:    make([]dto.WeightBreakResponse, len(l.WeightBreaks)),
+			PerPallet:       l.PerPallet.Baht(),
+			MinCharge:       l.MinCharge.Baht(),
+		}
+		for j, b := range l.DistanceBands {
+			lane.DistanceBands[j] = dto.DistanceBandResponse{UpToKm: b.UpToKm, Amount: b.Amount.Baht(), PerKm: b.PerKm.Baht()}
+		}
+		for j, b := range l.WeightBreaks {
+			lane.WeightBreaks[j] = dto.WeightBreakResponse{MinKg: b.MinKg, PerKg: b.PerKg.Baht()}
+		}
+		resp.Lanes[i] = lane
+	}
+	if c.Fuel != nil {
+		resp.Fuel = &dto.FuelSurchargeResponse{BasePrice: c.Fuel.BasePrice.Baht(), Step: c.Fuel.Step.Baht(), StepPercent: c.Fuel.StepPercent}
+	}
+	for i, a := range c.Accessorials {
+		resp.Accessorials[i] = dto.AccessorialRateResponse{
+			Code:        a.Code,
+			Description: a.Description,
+			Unit:        string(a.Unit),
+			Amount:      a.Amount.Baht(),
+			FreeUnits:   a.FreeUnits,
+		}
+	}
+	return resp
+}
+
+func toDieselPriceResponse(p *pricing.DieselPriceOutput) dto.DieselPriceResponse {
+	return dto.DieselPriceResponse{
+		ID:            p.ID.String(),
+		EffectiveDate: p.EffectiveDate.Format(dto.DateLayout),
+		PricePerLitre: p.PricePerLitre.Baht(),
+		CreatedAt:     p.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toQuoteResponse(q *pricing.QuoteOutput) dto.QuoteResponse {
+	resp := dto.QuoteResponse{
+		RateCardID:        q.RateCardID.String(),
+		RateCardName:      q.RateCardName,
+		RateCardVersion:   q.RateCardVersion,
+		Currency:          q.Currency,
+		OriginZone:        q.OriginZone,
+		DestinationZone:   q.DestinationZone,
+		DistanceKm:        q.DistanceKm,
+		DistanceEstimated: q.DistanceEstimated,
+		ChargeableKg:      q.ChargeableKg,
+
+		PricedAt: q.PricedAt.Format(time.RFC3339),
+		Lines:    make([]dto.QuoteLineResponse, len(q.Lines)),
+		Total:    q.Total.Baht(),
+	}
+	if q.DieselPrice != nil {
+		price := q.DieselPrice.Baht()
+		resp.DieselPrice = &price
+	}
+	if q.ShipmentID != nil {
+		id := q.ShipmentID.String()
+		resp.ShipmentID = &id
+	}
+	for i, l := range q.Lines {
+		resp.Lines[i] = dto.QuoteLineResponse{
+			Code:      l.Code,
+			Quantity:  l.Quantity,
+			UnitPrice: l.UnitPrice.Baht(),
+			Amount:    l.Amount.Baht(),
+			Rule:      l.Rule,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,139 @@
+package publictracking
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/usecase/publictracking"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Handler handles the unauthenticated tracking page API
+type Handler struct {
+	useCase *publictracking.PublicTrackingUseCase
+}
+
+// NewHandler creates a new public tracking handler
+func NewHandler(useCase *publictracking.PublicTrackingUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Track godoc
+// @Summary Track a shipment
+// @Description Public tracking page for consignees, no account needed: status, expected delivery window, masked recipient
+// @Description and a timeline of milestones, newest first. Only district and province of places are shown.
+// @Description When the service requires a challenge, the delivery postcode or the last four digits of the recipient's phone must be given.
+// @Description Tracking numbers with a wrong check digit are rejected as mistyped. Requests are rate-limited per client IP.
+// @Tags tracking
+// @Produce json
+// @Param trackingNumber path string true "Tracking number"
+// @Param postcode query string false "Delivery postcode"
+// @Param phone_suffix query string false "Last four digits of the recipient's phone number"
+// @Success 200 {object} httpresponse.Response{data=dto.TrackingPageResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 429 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/track/{trackingNumber} [get]
+func (h *Handler) Track(c *fiber.Ctx) error {
+	var query dto.TrackShipmentQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Track(c.Context(), publictracking.TrackInput{
+		TrackingNumber: c.Params("trackingNumber"),
+		Postcode:       query.Postcode,
+		PhoneSuffix:    query.PhoneSuffix,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	events := make([]dto.TrackingEventResponse, len(result.Events))
+	for i, e := range result.Events {
+		events[i] = dto.TrackingEventResponse{
+			Code:       e.Code,
+			OccurredAt: e.OccurredAt.Format(time.RFC3339),
+			District:   e.District,
+			Province:   e.Province,
+		}
+	}
+
+	return httpresponse.Success(c, dto.TrackingPageResponse{
+		TrackingNumber: result.TrackingNumber,
+		Status:         result.Status,
+		Recipient:      result.Recipient,
+		District:       result.District,
+		Province:       result.Province,
+		ETAFrom:        dto.FormatTimestamp(result.ETAFrom),
+		ETATo:          dto.FormatTimestamp(result.ETATo),
+		DeliveredAt:    dto.FormatTimestamp(result.DeliveredAt),
+		Reschedulable:  result.Reschedulable,
+		Events:         events,
+	}, "Tracking page retrieved successfully")
+}
+
+// Reschedule godoc
+// @Summary Reschedule a failed delivery
+// @Description After a failed delivery, let the consignee pick when the next attempt is made: a whole date in Thai time, or a window.
+// @Description The delivery postcode or the last four digits of the recipient's phone are always required. The window must end at
+// @Description least two hours from now and start within 14 days. A reattempt already planned onto a trip, or a return to the sender,
+// @Description cannot be rescheduled. Requests are rate-limited per client IP.
+// @Tags tracking
+// @Accept json
+// @Produce json
+// @Param trackingNumber path string true "Tracking number"
+// @Param request body dto.RescheduleDeliveryRequest true "New delivery date or window"
+// @Success 200 {object} httpresponse.Response{data=dto.RescheduleDeliveryResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 429 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/track/{trackingNumber}/reschedule [post]
+func (h *Handler) Reschedule(c *fiber.Ctx) error {
+	var req dto.RescheduleDeliveryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := publictracking.RescheduleInput{
+		TrackingNumber: c.Params("trackingNumber"),
+		Postcode:       req.Postcode,
+		PhoneSuffix:    req.PhoneSuffix,
+		DeliverFrom:    dto.ParseTimestamp(req.DeliverFrom),
+		DeliverTo:      dto.ParseTimestamp(req.DeliverTo),
+	}
+	if req.Date != "" {
+		day, err := time.Parse(dto.DateLayout, req.Date)
+		if err != nil {
+			return httpresponse.Error(c, err)
+		}
+		input.Date = &day
+	}
+
+	result, err := h.useCase.Reschedule(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, dto.RescheduleDeliveryResponse{
+		TrackingNumber: result.TrackingNumber,
+		DeliverFrom:    result.DeliverFrom.Format(time.RFC3339),
+		DeliverTo:      result.DeliverTo.Format(time.RFC3339),
+	}, "Delivery rescheduled successfully")
+}
@@ -0,0 +1,310 @@
+package realtime
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/api/http/middleware"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/internal/usecase/realtime"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	// channelsKey passes the channels requested in the handshake to the WebSocket handler
+	channelsKey = "stream_channels"
+
+	// userKey passes the authenticated user to the WebSocket handler
+	userKey = "stream_user"
+
+	// maxCommandSize limits the size of a message a WebSocket client may send
+	maxCommandSize = 16 * 1024
+
+	// replyBuffer is how many command replies may wait for the writer
+	replyBuffer = 16
+
+	// Defaults used when the handler is created without timings
+	defaultHeartbeatInterval = 25 * time.Second
+	defaultWriteTimeout      = 10 * time.Second
+)
+
+// Handler handles WebSocket and server-sent event streams of realtime updates
+type Handler struct {
+	useCase           *realtime.RealtimeUseCase
+	heartbeatInterval time.Duration
+	writeTimeout      time.Duration
+	websocket         fiber.Handler
+}
+
+// NewHandler creates a new realtime handler that pings idle clients every heartbeatInterval
+// and gives up on a write after writeTimeout
+func NewHandler(useCase *realtime.RealtimeUseCase, heartbeatInterval, writeTimeout time.Duration) *Handler {
+	if heartbeatInterval <= 0 {
+		heartbeatInterval = defaultHeartbeatInterval
+	}
+	if writeTimeout <= 0 {
+		writeTimeout = defaultWriteTimeout
+	}
+	h := &Handler{
+		useCase:           useCase,
+		heartbeatInterval: heartbeatInterval,
+		writeTimeout:      writeTimeout,
+	}
+	h.websocket = websocket.New(h.serveWebSocket)
+	return h
+}
+
+// WebSocket godoc
+// @Summary Stream realtime updates over WebSocket
+// @Description Upgrade to a WebSocket that pushes events (dto.StreamEventResponse) on the subscribed channels.
+// @Description Channels are named trip:<id>, shipment:<id>, vehicle:<id>, organization:<id>, driver:<id>, carrier:<id>, user:<id> or dispatch:<organization id>; initial channels may be given in the query
+// @Description and more added or removed by sending {"action":"subscribe"|"unsubscribe","channels":[...]}, answered with a dto.StreamReply.
+// @Description Only channels the user may follow are accepted: their own user channel, their carrier's and its awarded trips for carrier users,
+// @Description their own driver channel and their trips for drivers, and everything but other users' and carriers' channels for staff; other users may follow nothing else.
+// @Description Browsers may pass the token in access_token instead of the Authorization header. The server pings every heartbeat interval
+// @Description and closes connections that stop answering; clients that fall too far behind are closed with code 1008 "slow consumer".
+// @Tags realtime
+// @Produce json
+// @Security Bearer
+// @Param channels query string false "Comma-separated channels"
+// @Param access_token query string false "Access token, when the Authorization header cannot be set"
+// @Success 101 {object} dto.StreamEventResponse
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 426 {object} httpresponse.Response
+// @Router /api/v1/stream/ws [get]
+func (h *Handler) WebSocket(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return httpresponse.Error(c, &apierror.APIError{
+			Code:       apierror.CodeUpgradeRequired,
+			Message:    "WebSocket upgrade required",
+			StatusCode: fiber.StatusUpgradeRequired,
+		})
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	var query dto.StreamQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+	channels := splitChannels(query.Channels)
+	if err := h.useCase.Authorize(c.Context(), userID, channels); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	c.Locals(userKey, userID)
+	c.Locals(channelsKey, channels)
+	return h.websocket(c)
+}
+
+// serveWebSocket runs one upgraded connection: this goroutine reads commands and a second one
+// is the only writer, so events, replies and pings never interleave
+func (h *Handler) serveWebSocket(conn *websocket.Conn) {
+	userID, _ := conn.Locals(userKey).(uuid.UUID)
+	channels, _ := conn.Locals(channelsKey).([]string)
+	sub, err := h.useCase.Connect(context.Background(), userID, channels)
+	if err != nil {
+		_ = conn.WriteJSON(errorReply(err))
+		return
+	}
+	defer sub.Close()
+
+	replies := make(chan dto.StreamReply, replyBuffer)
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		h.writeWebSocket(conn, sub, replies)
+	}()
+
+	readTimeout := 2 * h.heartbeatInterval
+	conn.SetReadLimit(maxCommandSize)
+	_ = conn.SetReadDeadline(time.Now().Add(readTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(readTimeout))
+	})
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			break
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(readTimeout))
+
+		select {
+		case replies <- h.handleCommand(userID, sub, message):
+		case <-sub.Done():
+		}
+	}
+
+	sub.Close()
+	<-written
+}
+
+// handleCommand applies one subscribe or unsubscribe command of the user
+func (h *Handler) handleCommand(userID uuid.UUID, sub service.Subscription, message []byte) dto.StreamReply {
+	var cmd dto.StreamCommand
+	if err := json.Unmarshal(message, &cmd); err != nil {
+		return dto.StreamReply{Type: "error", Error: "Invalid command"}
+	}
+	if err := validator.Validate(cmd); err != nil {
+		return errorReply(err)
+	}
+
+	if cmd.Action == "unsubscribe" {
+		h.useCase.Unsubscribe(sub, cmd.Channels)
+		return dto.StreamReply{Type: "unsubscribed", Channels: cmd.Channels}
+	}
+	if err := h.useCase.Subscribe(context.Background(), userID, sub, cmd.Channels); err != nil {
+		return errorReply(err)
+	}
+	return dto.StreamReply{Type: "subscribed", Channels: cmd.Channels}
+}
+
+// writeWebSocket sends events, replies and heartbeats until the subscription ends or a write fails,
+// then closes the connection so the reader stops as well
+func (h *Handler) writeWebSocket(conn *websocket.Conn, sub service.Subscription, replies <-chan dto.StreamReply) {
+	defer conn.Close()
+
+	ticker := time.NewTicker(h.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		select {
+		case <-sub.Done():
+			code, reason := websocket.CloseNormalClosure, ""
+			if sub.Dropped() {
+				code, reason = websocket.ClosePolicyViolation, "slow consumer"
+			}
+			_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(h.writeTimeout))
+			return
+		case message := <-sub.Messages():
+			_ = conn.SetWriteDeadline(time.Now().Add(h.writeTimeout))
+			err = conn.WriteMessage(websocket.TextMessage, message)
+		case reply := <-replies:
+			_ = conn.SetWriteDeadline(time.Now().Add(h.writeTimeout))
+			err = conn.WriteJSON(reply)
+		case <-ticker.C:
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(h.writeTimeout))
+		}
+		if err != nil {
+			sub.Close()
+			return
+		}
+	}
+}
+
+// SSE godoc
+// @Summary Stream realtime updates as server-sent events
+// @Description Fallback for clients without WebSocket support: an event stream of the given channels, each event (dto.StreamEventResponse)
+// @Description sent as one data line. A comment line is sent every heartbeat interval to keep proxies from closing the stream.
+// @Description Clients that fall too far behind receive a "dropped" event and the stream ends; EventSource reconnects by itself.
+// @Tags realtime
+// @Produce text/event-stream
+// @Security Bearer
+// @Param channels query string true "Comma-separated channels"
+// @Param access_token query string false "Access token, when the Authorization header cannot be set"
+// @Success 200 {object} dto.StreamEventResponse
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Router /api/v1/stream/sse [get]
+func (h *Handler) SSE(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	var query dto.StreamQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+	channels := splitChannels(query.Channels)
+	if len(channels) == 0 {
+		return httpresponse.Error(c, errs.ValidationErrors{"channels": {"required"}})
+	}
+
+	sub, err := h.useCase.Connect(c.Context(), userID, channels)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The server's write timeout covers the whole response, so each write sets its own deadline instead
+	netConn := c.Context().Conn()
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+
+		ticker := time.NewTicker(h.heartbeatInterval)
+		defer ticker.Stop()
+
+		_, _ = w.WriteString("retry: 3000\n\n")
+		for {
+			select {
+			case <-sub.Done():
+				if sub.Dropped() {
+					_, _ = w.WriteString("event: dropped\ndata: {\"reason\":\"slow consumer\"}\n\n")
+					_ = netConn.SetWriteDeadline(time.Now().Add(h.writeTimeout))
+					_ = w.Flush()
+				}
+				return
+			case message := <-sub.Messages():
+				_, _ = w.WriteString("data: ")
+				_, _ = w.Write(message)
+				_, _ = w.WriteString("\n\n")
+			case <-ticker.C:
+				_, _ = w.WriteString(": ping\n\n")
+			}
+
+			_ = netConn.SetWriteDeadline(time.Now().Add(h.writeTimeout))
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// splitChannels splits a comma-separated channel list, skipping blanks
+func splitChannels(value string) []string {
+	var channels []string
+	for _, c := range strings.Split(value, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			channels = append(channels, c)
+		}
+	}
+	return channels
+}
+
+// errorReply describes a failed command to a WebSocket client
+func errorReply(err error) dto.StreamReply {
+	var valErrs errs.ValidationErrors
+	if errors.As(err, &valErrs) {
+		return dto.StreamReply{Type: "error", Error: "Validation failed", Details: valErrs}
+	}
+	if errors.Is(err, errs.ErrForbidden) {
+		return dto.StreamReply{Type: "error", Error: "Access forbidden"}
+	}
+	return dto.StreamReply{Type: "error", Error: "Command failed"}
+}
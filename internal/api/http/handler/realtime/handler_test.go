@@ -0,0 +1,184 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/api/http/middleware"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+	realtimeSvc "tms-core-service/internal/infra/realtime"
+	"tms-core-service/internal/usecase/realtime"
+	"tms-core-service/pkg/jwt"
+
+	"github.com/fasthttp/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// allStaff, noCarrier and noDriver make every user back-office staff
+type allStaff struct{ repository.UserRepository }
+
+func (allStaff) FindByID(_ context.Context, id uuid.UUID) (*entity.User, error) {
+	return &entity.User{ID: id, Role: entity.UserRoleStaff}, nil
+}
+
+type noCarrier struct{ repository.CarrierRepository }
+
+func (noCarrier) FindByUserID(context.Context, uuid.UUID) (*entity.Carrier, error) {
+	return nil, errs.ErrNotFound
+}
+
+type noDriver struct{ repository.DriverRepository }
+
+func (noDriver) FindByUserID(context.Context, uuid.UUID) (*entity.Driver, error) {
+	return nil, errs.ErrNotFound
+}
+
+// stalledSubscription is a subscription the test drops by hand, as the hub drops one that overflows
+type stalledSubscription struct {
+	messages chan []byte
+	done     chan struct{}
+	dropped  atomic.Bool
+	once     sync.Once
+}
+
+func (s *stalledSubscription) Subscribe(...string) error { return nil }
+func (s *stalledSubscription) Unsubscribe(...string)     {}
+func (s *stalledSubscription) Messages() <-chan []byte   { return s.messages }
+func (s *stalledSubscription) Done() <-chan struct{}     { return s.done }
+func (s *stalledSubscription) Dropped() bool             { return s.dropped.Load() }
+func (s *stalledSubscription) Close()                    { s.once.Do(func() { close(s.done) }) }
+
+func (s *stalledSubscription) drop() {
+	s.dropped.Store(true)
+	s.Close()
+}
+
+// stalledSubscriber hands out one stalledSubscription
+type stalledSubscriber struct {
+	sub       *stalledSubscription
+	connected chan struct{}
+}
+
+func (s *stalledSubscriber) Connect() service.Subscription {
+	close(s.connected)
+	return s.sub
+}
+
+// serve starts the stream routes on a local port and returns the WebSocket URL and a token
+func serve(t *testing.T, subscriber service.EventSubscriber) (string, string) {
+	t.Helper()
+	jwtService := jwt.NewJWTService("test-secret", "test")
+	uc := realtime.NewRealtimeUseCase(subscriber, nil, allStaff{}, noCarrier{}, noDriver{}, nil)
+	h := NewHandler(uc, time.Minute, time.Second)
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/ws", middleware.StreamAuth(jwtService), h.WebSocket)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	go func() { _ = app.Listener(ln) }()
+	t.Cleanup(func() { _ = app.Shutdown() })
+
+	token, err := jwtService.GenerateToken(uuid.New(), "active", time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	return "ws://" + ln.Addr().String() + "/ws", token
+}
+
+func TestWebSocketKeepsServingWhenAConsumerStalls(t *testing.T) {
+	hub := realtimeSvc.NewHub(8, 10)
+	url, token := serve(t, hub)
+	channel := service.Channel(service.ChannelVehicle, uuid.New())
+
+	conns := make([]*websocket.Conn, 3)
+	for i := range conns {
+		conn, _, err := websocket.DefaultDialer.Dial(url+"?channels="+channel+"&access_token="+token, nil)
+		if err != nil {
+			t.Fatalf("dial: %v", err)
+		}
+		defer conn.Close()
+		conns[i] = conn
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for hub.Subscribers(channel) < len(conns) {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d of %d clients subscribed", hub.Subscribers(channel), len(conns))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// a subscription nobody reads stands in for a client whose connection has stalled
+	slow := hub.Connect()
+	if err := slow.Subscribe(channel); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		hub.Dispatch(channel, []byte(`{"type":"vehicle.position"}`))
+		for _, conn := range conns {
+			_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			if _, message, err := conn.ReadMessage(); err != nil || !strings.Contains(string(message), "vehicle.position") {
+				t.Fatalf("event %d: %q, %v", i, message, err)
+			}
+		}
+	}
+	if !slow.Dropped() {
+		t.Fatal("the stalled subscription was not dropped")
+	}
+}
+
+func TestWebSocketClosesDroppedClientWithPolicyViolation(t *testing.T) {
+	subscriber := &stalledSubscriber{
+		sub:       &stalledSubscription{messages: make(chan []byte), done: make(chan struct{})},
+		connected: make(chan struct{}),
+	}
+	url, token := serve(t, subscriber)
+
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?channels="+service.Channel(service.ChannelVehicle, uuid.New())+"&access_token="+token, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	select {
+	case <-subscriber.connected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handler never subscribed")
+	}
+
+	subscriber.sub.drop()
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _, err = conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) {
+		t.Fatalf("err = %v, want a close frame", err)
+	}
+	if closeErr.Code != websocket.ClosePolicyViolation || closeErr.Text != "slow consumer" {
+		t.Errorf("closed with %d %q, want 1008 slow consumer", closeErr.Code, closeErr.Text)
+	}
+}
+
+func TestWebSocketRejectsForbiddenChannel(t *testing.T) {
+	url, token := serve(t, realtimeSvc.NewHub(8, 10))
+
+	_, resp, err := websocket.DefaultDialer.Dial(url+"?channels="+service.Channel(service.ChannelUser, uuid.New())+"&access_token="+token, nil)
+	if err == nil {
+		t.Fatal("subscribed to another user's channel")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("response = %v, want 403", resp)
+	}
+}
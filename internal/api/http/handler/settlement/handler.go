@@ -0,0 +1,598 @@
+package settlement
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/api/http/middleware"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/settlement"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles driver pay rule and settlement requests
+type Handler struct {
+	payRuleUseCase    *settlement.PayRuleUseCase
+	settlementUseCase *settlement.SettlementUseCase
+}
+
+// NewHandler creates a new settlement handler
+func NewHandler(payRuleUseCase *settlement.PayRuleUseCase, settlementUseCase *settlement.SettlementUseCase) *Handler {
+	return &Handler{payRuleUseCase: payRuleUseCase, settlementUseCase: settlementUseCase}
+}
+
+// CreatePayRule godoc
+// @Summary Create driver pay rule
+// @Description Add a rule pricing drivers' trips from a date on: a base amount per trip, per km, per delivery drop,
+// @Description an allowance per night away and an overtime rate per hour beyond the standard hours of each day.
+// @Description A rule for a vehicle type wins over one for any vehicle type.
+// @Tags driver-settlements
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.DriverPayRuleRequest true "Pay rule"
+// @Success 201 {object} httpresponse.Response{data=dto.DriverPayRuleResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/driver-pay-rules [post]
+func (h *Handler) CreatePayRule(c *fiber.Ctx) error {
+	var req dto.DriverPayRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.payRuleUseCase.Create(c.Context(), toPayRuleInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toPayRuleResponse(result), "Pay rule created successfully")
+}
+
+// ListPayRules godoc
+// @Summary List driver pay rules
+// @Description List driver pay rules, latest effective date first
+// @Tags driver-settlements
+// @Produce json
+// @Security Bearer
+// @Param vehicle_type query string false "Vehicle type" Enums(4w, 6w, 10w, 18w)
+// @Param effective_at query string false "Effective at (RFC 3339)"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.DriverPayRuleResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/driver-pay-rules [get]
+func (h *Handler) ListPayRules(c *fiber.Ctx) error {
+	var query dto.ListDriverPayRulesQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := settlement.ListPayRulesInput{
+		EffectiveAt: dto.ParseTimestamp(query.EffectiveAt),
+		Limit:       query.GetLimit(),
+		Offset:      query.Offset,
+	}
+	if query.VehicleType != "" {
+		vehicleType := entity.VehicleType(query.VehicleType)
+		input.VehicleType = &vehicleType
+	}
+
+	results, total, err := h.payRuleUseCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.DriverPayRuleResponse, len(results))
+	for i, r := range results {
+		data[i] = toPayRuleResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// GetPayRule godoc
+// @Summary Get driver pay rule
+// @Description Get a driver pay rule by ID
+// @Tags driver-settlements
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Pay rule ID"
+// @Success 200 {object} httpresponse.Response{data=dto.DriverPayRuleResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/driver-pay-rules/{id} [get]
+func (h *Handler) GetPayRule(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid pay rule ID"))
+	}
+
+	result, err := h.payRuleUseCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toPayRuleResponse(result), "Pay rule retrieved successfully")
+}
+
+// UpdatePayRule godoc
+// @Summary Update driver pay rule
+// @Description Replace a driver pay rule, e.g. to end it with effective_to. Settlements already drafted keep their pay.
+// @Tags driver-settlements
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Pay rule ID"
+// @Param request body dto.DriverPayRuleRequest true "Pay rule"
+// @Success 200 {object} httpresponse.Response{data=dto.DriverPayRuleResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/driver-pay-rules/{id} [put]
+func (h *Handler) UpdatePayRule(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid pay rule ID"))
+	}
+
+	var req dto.DriverPayRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.payRuleUseCase.Update(c.Context(), id, toPayRuleInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toPayRuleResponse(result), "Pay rule updated successfully")
+}
+
+// Create godoc
+// @Summary Create driver settlement
+// @Description Draft a pay statement for every completed trip the driver drove or co-drove that was planned to start
+// @Description in the period and is not on another settlement of the driver. Each trip is paid by the pay rule
+// @Description effective when it started; distance is estimated between its stops.
+// @Tags driver-settlements
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateDriverSettlementRequest true "Driver and period"
+// @Success 201 {object} httpresponse.Response{data=dto.DriverSettlementResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/driver-settlements [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req dto.CreateDriverSettlementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	periodFrom, err := time.Parse(dto.DateLayout, req.PeriodFrom)
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid period start date"))
+	}
+	periodTo, err := time.Parse(dto.DateLayout, req.PeriodTo)
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid period end date"))
+	}
+
+	input := settlement.CreateSettlementInput{
+		DriverID:    uuid.MustParse(req.DriverID),
+		PeriodFrom:  periodFrom,
+		PeriodTo:    periodTo,
+		Adjustments: make([]settlement.AdjustmentInput, len(req.Adjustments)),
+		Notes:       req.Notes,
+	}
+	for i, a := range req.Adjustments {
+		input.Adjustments[i] = toAdjustmentInput(a)
+	}
+
+	result, err := h.settlementUseCase.CreateDraft(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toSettlementResponse(result), "Settlement created successfully")
+}
+
+// List godoc
+// @Summary List driver settlements
+// @Description List driver settlements by driver, status or period, newest first
+// @Tags driver-settlements
+// @Produce json
+// @Security Bearer
+// @Param driver_id query string false "Driver ID"
+// @Param status query string false "Status" Enums(draft, approved, void)
+// @Param period_from query string false "Settlements whose period ends on or after (YYYY-MM-DD)"
+// @Param period_to query string false "Settlements whose period starts on or before (YYYY-MM-DD)"
+// @Param search query string false "Search by number"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.DriverSettlementResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/driver-settlements [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	var query dto.ListDriverSettlementsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := settlement.ListSettlementsInput{
+		Search: query.Search,
+		Limit:  query.GetLimit(),
+		Offset: query.Offset,
+	}
+	if query.DriverID != "" {
+		id := uuid.MustParse(query.DriverID)
+		input.DriverID = &id
+	}
+	if query.Status != "" {
+		status := entity.SettlementStatus(query.Status)
+		input.Status = &status
+	}
+	if query.PeriodFrom != "" {
+		from, _ := time.Parse(dto.DateLayout, query.PeriodFrom)
+		input.From = &from
+	}
+	if query.PeriodTo != "" {
+		to, _ := time.Parse(dto.DateLayout, query.PeriodTo)
+		input.To = &to
+	}
+
+	results, total, err := h.settlementUseCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.DriverSettlementResponse, len(results))
+	for i, r := range results {
+		data[i] = toSettlementResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// Get godoc
+// @Summary Get driver settlement
+// @Description Get a driver settlement with its trip lines and adjustments
+// @Tags driver-settlements
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Settlement ID"
+// @Success 200 {object} httpresponse.Response{data=dto.DriverSettlementResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/driver-settlements/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid settlement ID"))
+	}
+
+	result, err := h.settlementUseCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toSettlementResponse(result), "Settlement retrieved successfully")
+}
+
+// AddAdjustment godoc
+// @Summary Add settlement adjustment
+// @Description Take an advance or deduction off a draft settlement
+// @Tags driver-settlements
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Settlement ID"
+// @Param request body dto.SettlementAdjustmentRequest true "Adjustment"
+// @Success 200 {object} httpresponse.Response{data=dto.DriverSettlementResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/driver-settlements/{id}/adjustments [post]
+func (h *Handler) AddAdjustment(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid settlement ID"))
+	}
+
+	var req dto.SettlementAdjustmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.settlementUseCase.AddAdjustment(c.Context(), id, toAdjustmentInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toSettlementResponse(result), "Adjustment added successfully")
+}
+
+// RemoveAdjustment godoc
+// @Summary Remove settlement adjustment
+// @Description Drop an advance or deduction from a draft settlement
+// @Tags driver-settlements
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Settlement ID"
+// @Param adjustmentId path string true "Adjustment ID"
+// @Success 200 {object} httpresponse.Response{data=dto.DriverSettlementResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/driver-settlements/{id}/adjustments/{adjustmentId} [delete]
+func (h *Handler) RemoveAdjustment(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid settlement ID"))
+	}
+	adjustmentID, err := uuid.Parse(c.Params("adjustmentId"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid adjustment ID"))
+	}
+
+	result, err := h.settlementUseCase.RemoveAdjustment(c.Context(), id, adjustmentID)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toSettlementResponse(result), "Adjustment removed successfully")
+}
+
+// Approve godoc
+// @Summary Approve driver settlement
+// @Description Number a draft settlement and release it to payroll
+// @Tags driver-settlements
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Settlement ID"
+// @Success 200 {object} httpresponse.Response{data=dto.DriverSettlementResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/driver-settlements/{id}/approve [post]
+func (h *Handler) Approve(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid settlement ID"))
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	result, err := h.settlementUseCase.Approve(c.Context(), id, userID)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toSettlementResponse(result), "Settlement approved successfully")
+}
+
+// Void godoc
+// @Summary Void driver settlement
+// @Description Cancel a draft or approved settlement so its trips can be settled again. Approved numbers stay used.
+// @Tags driver-settlements
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Settlement ID"
+// @Param request body dto.VoidDriverSettlementRequest true "Reason"
+// @Success 200 {object} httpresponse.Response{data=dto.DriverSettlementResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/driver-settlements/{id}/void [post]
+func (h *Handler) Void(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid settlement ID"))
+	}
+
+	var req dto.VoidDriverSettlementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.settlementUseCase.Void(c.Context(), id, req.Reason)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toSettlementResponse(result), "Settlement voided successfully")
+}
+
+// Export godoc
+// @Summary Export driver payroll
+// @Description Download the approved settlements whose period overlaps the pay period as CSV for payroll,
+// @Description one row per settlement. The X-Settlement-Count header holds the number of rows.
+// @Tags driver-settlements
+// @Produce text/csv
+// @Security Bearer
+// @Param period_from query string true "First day of the pay period (YYYY-MM-DD)"
+// @Param period_to query string true "Last day of the pay period (YYYY-MM-DD)"
+// @Success 200 {file} file
+// @Failure 400 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/driver-settlements/export [get]
+func (h *Handler) Export(c *fiber.Ctx) error {
+	var query dto.ExportDriverSettlementsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	periodFrom, _ := time.Parse(dto.DateLayout, query.PeriodFrom)
+	periodTo, _ := time.Parse(dto.DateLayout, query.PeriodTo)
+
+	result, err := h.settlementUseCase.Export(c.Context(), settlement.ExportInput{PeriodFrom: periodFrom, PeriodTo: periodTo})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", result.Filename))
+	c.Set("X-Settlement-Count", strconv.Itoa(result.Count))
+	return c.Send(result.Content)
+}
+
+func toPayRuleInput(req dto.DriverPayRuleRequest) settlement.PayRuleInput {
+	return settlement.PayRuleInput{
+		Name:               req.Name,
+		VehicleType:        entity.VehicleType(req.VehicleType),
+		BasePerTrip:        entity.Baht(req.BasePerTrip),
+		PerKm:              entity.Baht(req.PerKm),
+		PerDrop:            entity.Baht(req.PerDrop),
+		OvernightAllowance: entity.Baht(req.OvernightAllowance),
+		OvertimeRate:       entity.Baht(req.OvertimeRate),
+		StandardHours:      req.StandardHours,
+		EffectiveFrom:      *dto.ParseTimestamp(req.EffectiveFrom),
+		EffectiveTo:        dto.ParseTimestamp(req.EffectiveTo),
+	}
+}
+
+func toAdjustmentInput(req dto.SettlementAdjustmentRequest) settlement.AdjustmentInput {
+	return settlement.AdjustmentInput{
+		Kind:        entity.AdjustmentKind(req.Kind),
+		Description: req.Description,
+		Amount:      entity.Baht(req.Amount),
+	}
+}
+
+func toPayRuleResponse(r *settlement.PayRuleOutput) dto.DriverPayRuleResponse {
+	return dto.DriverPayRuleResponse{
+		ID:                 r.ID.String(),
+		Name:               r.Name,
+		VehicleType:        string(r.VehicleType),
+		BasePerTrip:        r.BasePerTrip.Baht(),
+		PerKm:              r.PerKm.Baht(),
+		PerDrop:            r.PerDrop.Baht(),
+		OvernightAllowance: r.OvernightAllowance.Baht(),
+		OvertimeRate:       r.OvertimeRate.Baht(),
+		StandardHours:      r.StandardHours,
+		EffectiveFrom:      r.EffectiveFrom.Format(time.RFC3339),
+		EffectiveTo:        dto.FormatTimestamp(r.EffectiveTo),
+		CreatedAt:          r.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          r.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toSettlementResponse(s *settlement.SettlementOutput) dto.DriverSettlementResponse {
+	lines := make([]dto.SettlementLineResponse, len(s.Lines))
+	for i, l := range s.Lines {
+		lines[i] = dto.SettlementLineResponse{
+			Sequence:      l.Sequence,
+			TripID:        l.TripID.String(),
+			TripNumber:    l.TripNumber,
+			CoDriver:      l.CoDriver,
+			VehicleType:   string(l.VehicleType),
+			PayRuleID:     l.PayRuleID.String(),
+			StartedAt:     l.StartedAt.Format(time.RFC3339),
+			EndedAt:       l.EndedAt.Format(time.RFC3339),
+			DistanceKm:    l.DistanceKm,
+			Drops:         l.Drops,
+			Overnights:    l.Overnights,
+			OvertimeHours: l.OvertimeHours,
+			BasePay:       l.BasePay.Baht(),
+			DistancePay:   l.DistancePay.Baht(),
+			DropPay:       l.DropPay.Baht(),
+			OvernightPay:  l.OvernightPay.Baht(),
+			OvertimePay:   l.OvertimePay.Baht(),
+			Amount:        l.Amount.Baht(),
+		}
+	}
+
+	adjustments := make([]dto.SettlementAdjustmentResponse, len(s.Adjustments))
+	for i, a := range s.Adjustments {
+		adjustments[i] = dto.SettlementAdjustmentResponse{
+			ID:          a.ID.String(),
+			Kind:        string(a.Kind),
+			Description: a.Description,
+			Amount:      a.Amount.Baht(),
+			CreatedAt:   a.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	resp := dto.DriverSettlementResponse{
+		ID:          s.ID.String(),
+		Number:      s.Number,
+		DriverID:    s.DriverID.String(),
+		Status:      string(s.Status),
+		PeriodFrom:  s.PeriodFrom.Format(dto.DateLayout),
+		PeriodTo:    s.PeriodTo.Format(dto.DateLayout),
+		Lines:       lines,
+		Adjustments: adjustments,
+		GrossPay:    s.GrossPay.Baht(),
+		Advances:    s.Advances.Baht(),
+		Deductions:  s.Deductions.Baht(),
+		NetPay:      s.NetPay.Baht(),
+		Notes:       s.Notes,
+		ApprovedAt:  dto.FormatTimestamp(s.ApprovedAt),
+		VoidedAt:    dto.FormatTimestamp(s.VoidedAt),
+		VoidReason:  s.VoidReason,
+		CreatedAt:   s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   s.UpdatedAt.Format(time.RFC3339),
+	}
+	if s.ApprovedBy != nil {
+		id := s.ApprovedBy.String()
+		resp.ApprovedBy = &id
+	}
+	return resp
+}
@@ -0,0 +1,315 @@
+package shipment
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/shipment"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles shipment requests
+type Handler struct {
+	useCase *shipment.ShipmentUseCase
+}
+
+// NewHandler creates a new shipment handler
+func NewHandler(useCase *shipment.ShipmentUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Create godoc
+// @Summary Create shipment
+// @Description Book a shipment between two locations in the organization's address book
+// @Tags shipments
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateShipmentRequest true "Shipment details"
+// @Success 201 {object} httpresponse.Response{data=dto.ShipmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/shipments [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req dto.CreateShipmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Create(c.Context(), uuid.MustParse(req.OrganizationID), toShipmentInput(req.ShipmentRequest))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toShipmentResponse(result), "Shipment created successfully")
+}
+
+// List godoc
+// @Summary List shipments
+// @Description List shipments with optional organization and status filters and search
+// @Tags shipments
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param organization_id query string false "Organization ID"
+// @Param status query string false "Shipment status" Enums(pending, planned, in_transit, delivered, failed, cancelled)
+// @Param search query string false "Search by tracking number, reference or notes"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.ShipmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/shipments [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	var query dto.ListShipmentsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := shipment.ListShipmentsInput{
+		Search: query.Search,
+		Limit:  query.GetLimit(),
+		Offset: query.Offset,
+	}
+	if query.OrganizationID != "" {
+		orgID := uuid.MustParse(query.OrganizationID)
+		input.OrganizationID = &orgID
+	}
+	if query.Status != "" {
+		status := entity.ShipmentStatus(query.Status)
+		input.Status = &status
+	}
+
+	results, total, err := h.useCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.ShipmentResponse, len(results))
+	for i, r := range results {
+		data[i] = toShipmentResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// Get godoc
+// @Summary Get shipment
+// @Description Get a shipment by ID
+// @Tags shipments
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Shipment ID"
+// @Success 200 {object} httpresponse.Response{data=dto.ShipmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/shipments/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid shipment ID"))
+	}
+
+	result, err := h.useCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toShipmentResponse(result), "Shipment retrieved successfully")
+}
+
+// Update godoc
+// @Summary Update shipment
+// @Description Update a shipment that has not been planned on a trip yet
+// @Tags shipments
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Shipment ID"
+// @Param request body dto.ShipmentRequest true "Shipment details"
+// @Success 200 {object} httpresponse.Response{data=dto.ShipmentResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/shipments/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid shipment ID"))
+	}
+
+	var req dto.ShipmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Update(c.Context(), id, toShipmentInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toShipmentResponse(result), "Shipment updated successfully")
+}
+
+// Delete godoc
+// @Summary Delete shipment
+// @Description Delete a shipment that has not been planned on a trip yet
+// @Tags shipments
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Shipment ID"
+// @Success 200 {object} httpresponse.Response
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/shipments/{id} [delete]
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid shipment ID"))
+	}
+
+	if err := h.useCase.Delete(c.Context(), id); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, nil, "Shipment deleted successfully")
+}
+
+// Timeline godoc
+// @Summary Get shipment timeline
+// @Description Get the events in the life of a shipment, newest first: its status changes, the arrivals and
+// @Description departures of its trip stops, detected delays, a failed delivery and its open incidents
+// @Tags shipments
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Shipment ID"
+// @Success 200 {object} httpresponse.Response{data=[]dto.ShipmentTimelineEventResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/shipments/{id}/timeline [get]
+func (h *Handler) Timeline(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid shipment ID"))
+	}
+
+	results, err := h.useCase.Timeline(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.ShipmentTimelineEventResponse, len(results))
+	for i, r := range results {
+		data[i] = toTimelineEventResponse(r)
+	}
+
+	return httpresponse.Success(c, data, "Shipment timeline retrieved successfully")
+}
+
+func toShipmentInput(req dto.ShipmentRequest) shipment.ShipmentInput {
+	return shipment.ShipmentInput{
+		Reference:          req.Reference,
+		PickupLocationID:   uuid.MustParse(req.PickupLocationID),
+		DeliveryLocationID: uuid.MustParse(req.DeliveryLocationID),
+		WeightKg:           req.WeightKg,
+		VolumeM3:           req.VolumeM3,
+		Pallets:            req.Pallets,
+		PickupFrom:         dto.ParseTimestamp(req.PickupFrom),
+		PickupTo:           dto.ParseTimestamp(req.PickupTo),
+		DeliverFrom:        dto.ParseTimestamp(req.DeliverFrom),
+		DeliverTo:          dto.ParseTimestamp(req.DeliverTo),
+		Notes:              req.Notes,
+	}
+}
+
+func toShipmentResponse(s *shipment.ShipmentOutput) dto.ShipmentResponse {
+	var parentID *string
+	if s.ParentID != nil {
+		id := s.ParentID.String()
+		parentID = &id
+	}
+
+	return dto.ShipmentResponse{
+		ID:                 s.ID.String(),
+		OrganizationID:     s.OrganizationID.String(),
+		TrackingNumber:     s.TrackingNumber,
+		Reference:          s.Reference,
+		PickupLocationID:   s.PickupLocationID.String(),
+		DeliveryLocationID: s.DeliveryLocationID.String(),
+		WeightKg:           s.WeightKg,
+		VolumeM3:           s.VolumeM3,
+		Pallets:            s.Pallets,
+		PickupFrom:         dto.FormatTimestamp(s.PickupFrom),
+		PickupTo:           dto.FormatTimestamp(s.PickupTo),
+		DeliverFrom:        dto.FormatTimestamp(s.DeliverFrom),
+		DeliverTo:          dto.FormatTimestamp(s.DeliverTo),
+		Status:             string(s.Status),
+		Notes:              s.Notes,
+		ParentID:           parentID,
+		Leg:                string(s.Leg),
+		Attempt:            s.Attempt,
+		CreatedAt:          s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          s.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toTimelineEventResponse(e shipment.TimelineEventOutput) dto.ShipmentTimelineEventResponse {
+	resp := dto.ShipmentTimelineEventResponse{
+		Type:          e.Type,
+		OccurredAt:    e.OccurredAt.Format(time.RFC3339),
+		Status:        string(e.Status),
+		StopType:      string(e.StopType),
+		ETA:           dto.FormatTimestamp(e.ETA),
+		FailureReason: string(e.FailureReason),
+	}
+	if e.TripID != nil {
+		s := e.TripID.String()
+		resp.TripID = &s
+	}
+	if e.StopID != nil {
+		s := e.StopID.String()
+		resp.StopID = &s
+	}
+	if e.LegID != nil {
+		s := e.LegID.String()
+		resp.LegID = &s
+	}
+	if i := e.Incident; i != nil {
+		resp.Incident = &dto.ShipmentTimelineIncidentResponse{
+			ID:             i.ID.String(),
+			DocumentNumber: i.Number,
+			Category:       string(i.Category),
+			Severity:       string(i.Severity),
+			Status:         string(i.Status),
+			DueAt:          i.DueAt.Format(time.RFC3339),
+			Overdue:        i.Overdue,
+		}
+	}
+	return resp
+}
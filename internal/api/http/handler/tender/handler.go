@@ -0,0 +1,450 @@
+package tender
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/api/http/middleware"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/tender"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles tender requests from planners and from carrier users
+type Handler struct {
+	useCase *tender.TenderUseCase
+}
+
+// NewHandler creates a new tender handler
+func NewHandler(useCase *tender.TenderUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Create godoc
+// @Summary Create tender
+// @Description Offer a pending shipment or a planned trip to carriers. Carriers are offered the work one at a time in the given order;
+// @Description each has response_window_minutes (default 120) to answer before the tender rolls over to the next carrier.
+// @Tags tenders
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.CreateTenderRequest true "Tender"
+// @Success 201 {object} httpresponse.Response{data=dto.TenderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/tenders [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req dto.CreateTenderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := tender.TenderInput{
+		CarrierIDs:     make([]uuid.UUID, len(req.CarrierIDs)),
+		ResponseWindow: time.Duration(req.ResponseWindowMinutes) * time.Minute,
+		VehicleType:    entity.VehicleType(req.VehicleType),
+		Notes:          req.Notes,
+	}
+	if req.ShipmentID != "" {
+		id := uuid.MustParse(req.ShipmentID)
+		input.ShipmentID = &id
+	}
+	if req.TripID != "" {
+		id := uuid.MustParse(req.TripID)
+		input.TripID = &id
+	}
+	for i, id := range req.CarrierIDs {
+		input.CarrierIDs[i] = uuid.MustParse(id)
+	}
+
+	result, err := h.useCase.Create(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toTenderResponse(result), "Tender created successfully")
+}
+
+// List godoc
+// @Summary List tenders
+// @Description List tenders by status, shipment, trip or carrier, newest first
+// @Tags tenders
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param status query string false "Status" Enums(open, accepted, exhausted, cancelled)
+// @Param shipment_id query string false "Shipment ID"
+// @Param trip_id query string false "Trip ID"
+// @Param carrier_id query string false "Offered to carrier"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.TenderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/tenders [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	var query dto.ListTendersQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := tender.ListTendersInput{
+		Status:     toTenderStatus(query.Status),
+		ShipmentID: parseOptionalUUID(query.ShipmentID),
+		TripID:     parseOptionalUUID(query.TripID),
+		CarrierID:  parseOptionalUUID(query.CarrierID),
+		Limit:      query.GetLimit(),
+		Offset:     query.Offset,
+	}
+
+	results, total, err := h.useCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.TenderResponse, len(results))
+	for i, r := range results {
+		data[i] = toTenderResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// Get godoc
+// @Summary Get tender
+// @Description Get a tender with its offers and state change history
+// @Tags tenders
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Tender ID"
+// @Success 200 {object} httpresponse.Response{data=dto.TenderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/tenders/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid tender ID"))
+	}
+
+	result, err := h.useCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toTenderResponse(result), "Tender retrieved successfully")
+}
+
+// Cancel godoc
+// @Summary Cancel tender
+// @Description Withdraw an open tender and every pending offer
+// @Tags tenders
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Tender ID"
+// @Param request body dto.CancelTenderRequest false "Reason"
+// @Success 200 {object} httpresponse.Response{data=dto.TenderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/tenders/{id}/cancel [post]
+func (h *Handler) Cancel(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid tender ID"))
+	}
+
+	var req dto.CancelTenderRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return httpresponse.Error(c, err)
+		}
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Cancel(c.Context(), id, req.Reason)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toTenderResponse(result), "Tender cancelled successfully")
+}
+
+// CarrierList godoc
+// @Summary List my tenders
+// @Description List the tenders offered to the carrier the current user acts for. Only the carrier's own offer is shown.
+// @Tags carrier-portal
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param status query string false "Tender status" Enums(open, accepted, exhausted, cancelled)
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.CarrierTenderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carrier/tenders [get]
+func (h *Handler) CarrierList(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	var query dto.ListCarrierTendersQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := tender.ListCarrierTendersInput{
+		Status: toTenderStatus(query.Status),
+		Limit:  query.GetLimit(),
+		Offset: query.Offset,
+	}
+
+	results, total, err := h.useCase.ListForCarrier(c.Context(), userID, input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.CarrierTenderResponse, len(results))
+	for i, r := range results {
+		data[i] = toCarrierTenderResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// CarrierGet godoc
+// @Summary Get my tender
+// @Description Get a tender offered to the carrier the current user acts for
+// @Tags carrier-portal
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Tender ID"
+// @Success 200 {object} httpresponse.Response{data=dto.CarrierTenderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carrier/tenders/{id} [get]
+func (h *Handler) CarrierGet(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid tender ID"))
+	}
+
+	result, err := h.useCase.GetForCarrier(c.Context(), userID, id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toCarrierTenderResponse(result), "Tender retrieved successfully")
+}
+
+// Accept godoc
+// @Summary Accept tender
+// @Description Accept the offer currently held by the current user's carrier. Answers after the deadline are refused.
+// @Tags carrier-portal
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Tender ID"
+// @Success 200 {object} httpresponse.Response{data=dto.CarrierTenderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carrier/tenders/{id}/accept [post]
+func (h *Handler) Accept(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid tender ID"))
+	}
+
+	result, err := h.useCase.Accept(c.Context(), userID, id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toCarrierTenderResponse(result), "Tender accepted successfully")
+}
+
+// Reject godoc
+// @Summary Reject tender
+// @Description Decline the offer currently held by the current user's carrier; the tender rolls over to the next carrier
+// @Tags carrier-portal
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Tender ID"
+// @Param request body dto.RejectTenderRequest false "Reason"
+// @Success 200 {object} httpresponse.Response{data=dto.CarrierTenderResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 403 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/carrier/tenders/{id}/reject [post]
+func (h *Handler) Reject(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid tender ID"))
+	}
+
+	var req dto.RejectTenderRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return httpresponse.Error(c, err)
+		}
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Reject(c.Context(), userID, id, req.Reason)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toCarrierTenderResponse(result), "Tender rejected successfully")
+}
+
+func toTenderStatus(value string) *entity.TenderStatus {
+	if value == "" {
+		return nil
+	}
+	status := entity.TenderStatus(value)
+	return &status
+}
+
+func parseOptionalUUID(value string) *uuid.UUID {
+	if value == "" {
+		return nil
+	}
+	id := uuid.MustParse(value)
+	return &id
+}
+
+func formatOptionalUUID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func toSummaryResponse(s entity.TenderSummary) dto.TenderSummaryResponse {
+	return dto.TenderSummaryResponse{
+		VehicleType:         string(s.VehicleType),
+		OriginProvince:      s.OriginProvince,
+		DestinationProvince: s.DestinationProvince,
+		PickupAt:            dto.FormatTimestamp(s.PickupAt),
+		DeliverBy:           dto.FormatTimestamp(s.DeliverBy),
+		WeightKg:            s.Load.WeightKg,
+		VolumeM3:            s.Load.VolumeM3,
+		Pallets:             s.Load.Pallets,
+	}
+}
+
+func toOfferResponse(o tender.OfferOutput) dto.TenderOfferResponse {
+	return dto.TenderOfferResponse{
+		ID:          o.ID.String(),
+		CarrierID:   o.CarrierID.String(),
+		Rank:        o.Rank,
+		Status:      string(o.Status),
+		OfferedAt:   dto.FormatTimestamp(o.OfferedAt),
+		Deadline:    dto.FormatTimestamp(o.Deadline),
+		RespondedAt: dto.FormatTimestamp(o.RespondedAt),
+		Reason:      o.Reason,
+	}
+}
+
+func toTenderResponse(t *tender.TenderOutput) dto.TenderResponse {
+	resp := dto.TenderResponse{
+		ID:                    t.ID.String(),
+		ShipmentID:            formatOptionalUUID(t.ShipmentID),
+		TripID:                formatOptionalUUID(t.TripID),
+		Status:                string(t.Status),
+		ResponseWindowMinutes: int(t.ResponseWindow / time.Minute),
+		Summary:               toSummaryResponse(t.Summary),
+		Notes:                 t.Notes,
+		AwardedCarrierID:      formatOptionalUUID(t.AwardedCarrierID),
+		Offers:                make([]dto.TenderOfferResponse, len(t.Offers)),
+		Events:                make([]dto.TenderEventResponse, len(t.Events)),
+		CreatedAt:             t.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:             t.UpdatedAt.Format(time.RFC3339),
+	}
+	for i, o := range t.Offers {
+		resp.Offers[i] = toOfferResponse(o)
+	}
+	for i, e := range t.Events {
+		resp.Events[i] = dto.TenderEventResponse{
+			Type:       string(e.Type),
+			CarrierID:  formatOptionalUUID(e.CarrierID),
+			Note:       e.Note,
+			OccurredAt: e.OccurredAt.Format(time.RFC3339),
+		}
+	}
+	return resp
+}
+
+func toCarrierTenderResponse(t *tender.CarrierTenderOutput) dto.CarrierTenderResponse {
+	return dto.CarrierTenderResponse{
+		ID:         t.ID.String(),
+		ShipmentID: formatOptionalUUID(t.ShipmentID),
+		TripID:     formatOptionalUUID(t.TripID),
+		Status:     string(t.Status),
+		Summary:    toSummaryResponse(t.Summary),
+		Notes:      t.Notes,
+		Offer:      toOfferResponse(t.Offer),
+		Awarded:    t.Awarded,
+	}
+}
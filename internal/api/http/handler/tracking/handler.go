@@ -0,0 +1,205 @@
+package tracking
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/usecase/tracking"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles GPS ingestion and vehicle location requests
+type Handler struct {
+	useCase *tracking.TrackingUseCase
+}
+
+// NewHandler creates a new tracking handler
+func NewHandler(useCase *tracking.TrackingUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Ingest godoc
+// @Summary Ingest GPS positions
+// @Description Accept a batch of up to 1000 GPS fixes from telematics devices, in any order.
+// @Description Live positions are updated immediately unless a newer fix is known; history is written in the background.
+// @Description Fixes for unknown vehicles or devices, timestamped in the future or out of range are reported in rejected by batch index.
+// @Description Geofence, ETA and realtime updates follow in the background.
+// @Tags tracking
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.IngestPositionsRequest true "GPS fixes"
+// @Success 202 {object} httpresponse.Response{data=dto.IngestPositionsResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/tracking/positions [post]
+func (h *Handler) Ingest(c *fiber.Ctx) error {
+	var req dto.IngestPositionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	inputs := make([]tracking.PositionInput, len(req.Positions))
+	for i, p := range req.Positions {
+		inputs[i] = tracking.PositionInput{
+			DeviceID:   p.DeviceID,
+			RecordedAt: *dto.ParseTimestamp(p.Timestamp),
+			Latitude:   *p.Latitude,
+			Longitude:  *p.Longitude,
+			SpeedKmh:   p.SpeedKmh,
+			Heading:    p.Heading,
+			OdometerKm: p.OdometerKm,
+		}
+		if p.VehicleID != "" {
+			id := uuid.MustParse(p.VehicleID)
+			inputs[i].VehicleID = &id
+		}
+	}
+
+	result, err := h.useCase.Ingest(c.Context(), inputs)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	resp := dto.IngestPositionsResponse{
+		Accepted:   result.Accepted,
+		Duplicates: result.Duplicates,
+		Rejected:   make([]dto.RejectedPositionResponse, len(result.Rejected)),
+	}
+	for i, r := range result.Rejected {
+		resp.Rejected[i] = dto.RejectedPositionResponse{Index: r.Index, Reason: r.Reason}
+	}
+
+	return httpresponse.Accepted(c, resp, "Positions accepted")
+}
+
+// Fleet godoc
+// @Summary Live fleet positions
+// @Description Get the latest known position of every vehicle, or of the vehicles given by repeating vehicle_ids
+// @Tags tracking
+// @Produce json
+// @Security Bearer
+// @Param vehicle_ids query []string false "Vehicle IDs" collectionFormat(multi)
+// @Success 200 {object} httpresponse.Response{data=[]dto.FleetPositionResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/tracking/fleet [get]
+func (h *Handler) Fleet(c *fiber.Ctx) error {
+	var query dto.FleetPositionsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	ids := make([]uuid.UUID, len(query.VehicleIDs))
+	for i, id := range query.VehicleIDs {
+		ids[i] = uuid.MustParse(id)
+	}
+
+	results, err := h.useCase.FleetPositions(c.Context(), ids)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.FleetPositionResponse, len(results))
+	for i, r := range results {
+		data[i] = dto.FleetPositionResponse{
+			VehicleID:     r.VehicleID.String(),
+			PlateNumber:   r.PlateNumber,
+			PlateProvince: r.PlateProvince,
+			Position:      toPositionResponse(r.Position),
+		}
+	}
+
+	return httpresponse.Success(c, data, "Fleet positions retrieved successfully")
+}
+
+// Track godoc
+// @Summary Vehicle track
+// @Description Get a vehicle's track between two times (at most 7 days), simplified with the Douglas-Peucker algorithm.
+// @Description tolerance_m (default 10) is the largest distance a dropped fix may lie from the returned path; 0 returns every fix.
+// @Description A period with more than 100000 fixes is truncated: truncated is set and next_from gives the from of the next page.
+// @Tags tracking
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Vehicle ID"
+// @Param from query string true "Start time (RFC 3339)"
+// @Param to query string true "End time (RFC 3339)"
+// @Param tolerance_m query number false "Simplification tolerance in meters"
+// @Success 200 {object} httpresponse.Response{data=dto.TrackResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicles/{id}/track [get]
+func (h *Handler) Track(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid vehicle ID"))
+	}
+
+	var query dto.TrackQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := tracking.TrackInput{
+		VehicleID:  id,
+		From:       *dto.ParseTimestamp(query.From),
+		To:         *dto.ParseTimestamp(query.To),
+		ToleranceM: tracking.DefaultToleranceM,
+	}
+	if query.ToleranceM != nil {
+		input.ToleranceM = *query.ToleranceM
+	}
+
+	result, err := h.useCase.Track(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	resp := dto.TrackResponse{
+		VehicleID: result.VehicleID.String(),
+		From:      result.From.Format(time.RFC3339),
+		To:        result.To.Format(time.RFC3339),
+		RawPoints: result.RawPoints,
+		DistanceM: result.DistanceM,
+		Truncated: result.Truncated,
+		Points:    make([]dto.PositionResponse, len(result.Points)),
+	}
+	if result.NextFrom != nil {
+		next := result.NextFrom.Format(time.RFC3339Nano)
+		resp.NextFrom = &next
+	}
+	for i, p := range result.Points {
+		resp.Points[i] = toPositionResponse(p)
+	}
+
+	return httpresponse.Success(c, resp, "Track retrieved successfully")
+}
+
+func toPositionResponse(p tracking.PositionOutput) dto.PositionResponse {
+	return dto.PositionResponse{
+		Timestamp:  p.RecordedAt.Format(time.RFC3339),
+		Latitude:   p.Latitude,
+		Longitude:  p.Longitude,
+		SpeedKmh:   p.SpeedKmh,
+		Heading:    p.Heading,
+		OdometerKm: p.OdometerKm,
+	}
+}
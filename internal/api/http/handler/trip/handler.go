@@ -0,0 +1,324 @@
+package trip
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/api/http/middleware"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/trip"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles trip planning and dispatch requests
+type Handler struct {
+	useCase *trip.TripUseCase
+}
+
+// NewHandler creates a new trip handler
+func NewHandler(useCase *trip.TripUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Create godoc
+// @Summary Plan trip
+// @Description Group shipments into a trip with ordered pickup/delivery stops, a vehicle and driver(s).
+// @Description The vehicle must have capacity for the peak load, drivers must be available with a license valid until the planned end, and neither may be booked on an overlapping trip.
+// @Tags trips
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.TripRequest true "Trip plan"
+// @Success 201 {object} httpresponse.Response{data=dto.TripResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req dto.TripRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Create(c.Context(), toTripInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toTripResponse(result), "Trip created successfully")
+}
+
+// List godoc
+// @Summary List trips
+// @Description List trips by status, vehicle, driver and planned time window
+// @Tags trips
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param status query string false "Trip status" Enums(planned, dispatched, in_progress, completed, cancelled)
+// @Param vehicle_id query string false "Vehicle ID"
+// @Param driver_id query string false "Driver or co-driver ID"
+// @Param from query string false "Trips ending at or after (RFC 3339)"
+// @Param to query string false "Trips starting before (RFC 3339)"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.TripResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	var query dto.ListTripsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := trip.ListTripsInput{
+		From:   dto.ParseTimestamp(query.From),
+		To:     dto.ParseTimestamp(query.To),
+		Limit:  query.GetLimit(),
+		Offset: query.Offset,
+	}
+	if query.Status != "" {
+		status := entity.TripStatus(query.Status)
+		input.Status = &status
+	}
+	if query.VehicleID != "" {
+		vehicleID := uuid.MustParse(query.VehicleID)
+		input.VehicleID = &vehicleID
+	}
+	if query.DriverID != "" {
+		driverID := uuid.MustParse(query.DriverID)
+		input.DriverID = &driverID
+	}
+
+	results, total, err := h.useCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.TripResponse, len(results))
+	for i, r := range results {
+		data[i] = toTripResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// Get godoc
+// @Summary Get trip
+// @Description Get a trip with its stops by ID
+// @Tags trips
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Trip ID"
+// @Success 200 {object} httpresponse.Response{data=dto.TripResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid trip ID"))
+	}
+
+	result, err := h.useCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toTripResponse(result), "Trip retrieved successfully")
+}
+
+// Update godoc
+// @Summary Re-plan trip
+// @Description Replace the assignment and stops of a trip that has not been dispatched yet
+// @Tags trips
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Trip ID"
+// @Param request body dto.TripRequest true "Trip plan"
+// @Success 200 {object} httpresponse.Response{data=dto.TripResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid trip ID"))
+	}
+
+	var req dto.TripRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Update(c.Context(), id, toTripInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toTripResponse(result), "Trip updated successfully")
+}
+
+// UpdateStatus godoc
+// @Summary Update trip status
+// @Description Move a trip through planned → dispatched → in_progress → completed, or cancel it. Cancelling returns its shipments to pending.
+// @Description A trip cannot be dispatched while a blocking compliance document of its vehicle or drivers has expired.
+// @Description The user dispatching a trip becomes its dispatcher, who is notified of the trip's incidents.
+// @Tags trips
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Trip ID"
+// @Param request body dto.UpdateTripStatusRequest true "New status"
+// @Success 200 {object} httpresponse.Response{data=dto.TripResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/trips/{id}/status [patch]
+func (h *Handler) UpdateStatus(c *fiber.Ctx) error {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid trip ID"))
+	}
+
+	var req dto.UpdateTripStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.UpdateStatus(c.Context(), id, entity.TripStatus(req.Status), userID)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toTripResponse(result), "Trip status updated successfully")
+}
+
+func toTripInput(req dto.TripRequest) trip.TripInput {
+	stops := make([]trip.StopInput, len(req.Stops))
+	for i, s := range req.Stops {
+		stops[i] = trip.StopInput{
+			Type:           entity.StopType(s.Type),
+			ShipmentID:     uuid.MustParse(s.ShipmentID),
+			PlannedArrival: dto.ParseTimestamp(s.PlannedArrival),
+		}
+		if s.ServiceMinutes != nil {
+			serviceTime := time.Duration(*s.ServiceMinutes) * time.Minute
+			stops[i].ServiceTime = &serviceTime
+		}
+	}
+
+	input := trip.TripInput{
+		VehicleID:    uuid.MustParse(req.VehicleID),
+		DriverID:     uuid.MustParse(req.DriverID),
+		PlannedStart: *dto.ParseTimestamp(req.PlannedStart),
+		PlannedEnd:   *dto.ParseTimestamp(req.PlannedEnd),
+		Notes:        req.Notes,
+		Stops:        stops,
+	}
+	if req.CoDriverID != "" {
+		coDriverID := uuid.MustParse(req.CoDriverID)
+		input.CoDriverID = &coDriverID
+	}
+	return input
+}
+
+func toTripResponse(t *trip.TripOutput) dto.TripResponse {
+	stops := make([]dto.TripStopResponse, len(t.Stops))
+	for i, s := range t.Stops {
+		stops[i] = dto.TripStopResponse{
+			ID:             s.ID.String(),
+			Sequence:       s.Sequence,
+			Type:           string(s.Type),
+			ShipmentID:     s.ShipmentID.String(),
+			LocationID:     s.LocationID.String(),
+			PlannedArrival: dto.FormatTimestamp(s.PlannedArrival),
+			Status:         string(s.Status),
+			ArrivedAt:      dto.FormatTimestamp(s.ArrivedAt),
+			DepartedAt:     dto.FormatTimestamp(s.DepartedAt),
+			ETA:            dto.FormatTimestamp(s.ETA),
+			DelayedAt:      dto.FormatTimestamp(s.DelayedAt),
+		}
+		if s.ServiceTime != nil {
+			minutes := int(*s.ServiceTime / time.Minute)
+			stops[i].ServiceMinutes = &minutes
+		}
+	}
+
+	var coDriverID *string
+	if t.CoDriverID != nil {
+		id := t.CoDriverID.String()
+		coDriverID = &id
+	}
+
+	var dispatchedBy *string
+	if t.DispatchedBy != nil {
+		id := t.DispatchedBy.String()
+		dispatchedBy = &id
+	}
+
+	var carrierID, tenderID *string
+	if t.CarrierID != nil {
+		id := t.CarrierID.String()
+		carrierID = &id
+	}
+	if t.TenderID != nil {
+		id := t.TenderID.String()
+		tenderID = &id
+	}
+
+	return dto.TripResponse{
+		ID:           t.ID.String(),
+		TripNumber:   t.Number,
+		VehicleID:    t.VehicleID.String(),
+		DriverID:     t.DriverID.String(),
+		CoDriverID:   coDriverID,
+		Status:       string(t.Status),
+		PlannedStart: t.PlannedStart.Format(time.RFC3339),
+		PlannedEnd:   t.PlannedEnd.Format(time.RFC3339),
+		Notes:        t.Notes,
+		DispatchedBy: dispatchedBy,
+		CarrierID:    carrierID,
+		TenderID:     tenderID,
+		Stops:        stops,
+		CreatedAt:    t.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    t.UpdatedAt.Format(time.RFC3339),
+	}
+}
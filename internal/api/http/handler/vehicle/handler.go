@@ -0,0 +1,275 @@
+package vehicle
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/vehicle"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles fleet vehicle requests
+type Handler struct {
+	useCase *vehicle.VehicleUseCase
+}
+
+// NewHandler creates a new vehicle handler
+func NewHandler(useCase *vehicle.VehicleUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// Create godoc
+// @Summary Register vehicle
+// @Description Register a vehicle with its payload capacity
+// @Tags vehicles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body dto.VehicleRequest true "Vehicle details"
+// @Success 201 {object} httpresponse.Response{data=dto.VehicleResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicles [post]
+func (h *Handler) Create(c *fiber.Ctx) error {
+	var req dto.VehicleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Create(c.Context(), toVehicleInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toVehicleResponse(result), "Vehicle created successfully")
+}
+
+// List godoc
+// @Summary List vehicles
+// @Description List vehicles with optional status and type filters and search
+// @Tags vehicles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param status query string false "Vehicle status" Enums(active, maintenance, inactive)
+// @Param type query string false "Vehicle type" Enums(4w, 6w, 10w, 18w)
+// @Param search query string false "Search by plate, brand, model or depot"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.VehicleResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicles [get]
+func (h *Handler) List(c *fiber.Ctx) error {
+	var query dto.ListVehiclesQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := vehicle.ListVehiclesInput{
+		Search: query.Search,
+		Limit:  query.GetLimit(),
+		Offset: query.Offset,
+	}
+	if query.Status != "" {
+		status := entity.VehicleStatus(query.Status)
+		input.Status = &status
+	}
+	if query.Type != "" {
+		vehicleType := entity.VehicleType(query.Type)
+		input.Type = &vehicleType
+	}
+
+	results, total, err := h.useCase.List(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.VehicleResponse, len(results))
+	for i, r := range results {
+		data[i] = toVehicleResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// Get godoc
+// @Summary Get vehicle
+// @Description Get a vehicle by ID
+// @Tags vehicles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Vehicle ID"
+// @Success 200 {object} httpresponse.Response{data=dto.VehicleResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicles/{id} [get]
+func (h *Handler) Get(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid vehicle ID"))
+	}
+
+	result, err := h.useCase.Get(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toVehicleResponse(result), "Vehicle retrieved successfully")
+}
+
+// Update godoc
+// @Summary Update vehicle
+// @Description Update a vehicle's details and capacity
+// @Tags vehicles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Vehicle ID"
+// @Param request body dto.VehicleRequest true "Vehicle details"
+// @Success 200 {object} httpresponse.Response{data=dto.VehicleResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 409 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicles/{id} [put]
+func (h *Handler) Update(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid vehicle ID"))
+	}
+
+	var req dto.VehicleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.Update(c.Context(), id, toVehicleInput(req))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toVehicleResponse(result), "Vehicle updated successfully")
+}
+
+// UpdateStatus godoc
+// @Summary Update vehicle status
+// @Description Change a vehicle's operational status. Only active vehicles can be assigned to trips.
+// @Tags vehicles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Vehicle ID"
+// @Param request body dto.UpdateVehicleStatusRequest true "New status"
+// @Success 200 {object} httpresponse.Response{data=dto.VehicleResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicles/{id}/status [patch]
+func (h *Handler) UpdateStatus(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid vehicle ID"))
+	}
+
+	var req dto.UpdateVehicleStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.UpdateStatus(c.Context(), id, entity.VehicleStatus(req.Status))
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toVehicleResponse(result), "Vehicle status updated successfully")
+}
+
+// Delete godoc
+// @Summary Delete vehicle
+// @Description Remove a vehicle from the fleet
+// @Tags vehicles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Vehicle ID"
+// @Success 200 {object} httpresponse.Response
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicles/{id} [delete]
+func (h *Handler) Delete(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid vehicle ID"))
+	}
+
+	if err := h.useCase.Delete(c.Context(), id); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, nil, "Vehicle deleted successfully")
+}
+
+func toVehicleInput(req dto.VehicleRequest) vehicle.VehicleInput {
+	return vehicle.VehicleInput{
+		PlateNumber:   req.PlateNumber,
+		PlateProvince: req.PlateProvince,
+		Type:          entity.VehicleType(req.Type),
+		Brand:         req.Brand,
+		Model:         req.Model,
+		Year:          req.Year,
+		MaxWeightKg:   req.MaxWeightKg,
+		MaxVolumeM3:   req.MaxVolumeM3,
+		MaxPallets:    req.MaxPallets,
+		HomeDepot:     req.HomeDepot,
+		GPSDeviceID:   req.GPSDeviceID,
+		FuelBaseline:  req.FuelBaseline,
+	}
+}
+
+func toVehicleResponse(v *vehicle.VehicleOutput) dto.VehicleResponse {
+	return dto.VehicleResponse{
+		ID:            v.ID.String(),
+		PlateNumber:   v.PlateNumber,
+		PlateProvince: v.PlateProvince,
+		Type:          string(v.Type),
+		Brand:         v.Brand,
+		Model:         v.Model,
+		Year:          v.Year,
+		MaxWeightKg:   v.MaxWeightKg,
+		MaxVolumeM3:   v.MaxVolumeM3,
+		MaxPallets:    v.MaxPallets,
+		HomeDepot:     v.HomeDepot,
+		GPSDeviceID:   v.GPSDeviceID,
+		FuelBaseline:  v.FuelBaseline,
+		Status:        string(v.Status),
+		CreatedAt:     v.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     v.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,553 @@
+package vehiclecost
+
+import (
+	"time"
+
+	"tms-core-service/internal/api/http/dto"
+	"tms-core-service/internal/api/http/middleware"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/usecase/vehiclecost"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+	"tms-core-service/internal/util/validator"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Handler handles fuel log, vehicle expense and cost report requests
+type Handler struct {
+	useCase *vehiclecost.VehicleCostUseCase
+}
+
+// NewHandler creates a new vehicle cost handler
+func NewHandler(useCase *vehiclecost.VehicleCostUseCase) *Handler {
+	return &Handler{useCase: useCase}
+}
+
+// ReceiptUploadURL godoc
+// @Summary Get receipt upload URL
+// @Description Get a presigned URL to upload a receipt photo for a vehicle's fill-up or expense.
+// @Description Pass the returned object_key as receipt_key when recording it.
+// @Tags vehicle-costs
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Vehicle ID"
+// @Param request body dto.ReceiptUploadURLRequest true "Content type"
+// @Success 200 {object} httpresponse.Response{data=dto.PresignUploadResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicles/{id}/receipts/upload-url [post]
+func (h *Handler) ReceiptUploadURL(c *fiber.Ctx) error {
+	vehicleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid vehicle ID"))
+	}
+
+	var req dto.ReceiptUploadURLRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	result, err := h.useCase.ReceiptUploadURL(c.Context(), vehicleID, req.ContentType)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, dto.PresignUploadResponse{
+		UploadURL: result.UploadURL,
+		ObjectKey: result.ObjectKey,
+	}, "Upload URL generated successfully")
+}
+
+// RecordFuel godoc
+// @Summary Record fuel fill-up
+// @Description Record a vehicle's fill-up. A full tank is rated in km per litre against the previous full tank
+// @Description and flagged when it is off the vehicle's baseline beyond the tolerance, e.g. fuel going missing.
+// @Tags vehicle-costs
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Vehicle ID"
+// @Param request body dto.FuelLogRequest true "Fill-up"
+// @Success 201 {object} httpresponse.Response{data=dto.FuelLogResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicles/{id}/fuel-logs [post]
+func (h *Handler) RecordFuel(c *fiber.Ctx) error {
+	vehicleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid vehicle ID"))
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	var req dto.FuelLogRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	filledAt, err := time.Parse(time.RFC3339, req.FilledAt)
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid fill-up time"))
+	}
+
+	input := vehiclecost.FuelLogInput{
+		VehicleID:     vehicleID,
+		DriverID:      parseOptionalID(req.DriverID),
+		FilledAt:      filledAt,
+		Liters:        req.Liters,
+		PricePerLiter: entity.Baht(req.PricePerLiter),
+		Amount:        entity.Baht(req.Amount),
+		OdometerKm:    req.OdometerKm,
+		Station:       req.Station,
+		FullTank:      req.FullTank == nil || *req.FullTank,
+		ReceiptKey:    req.ReceiptKey,
+		Notes:         req.Notes,
+		CreatedBy:     userID,
+	}
+
+	result, err := h.useCase.RecordFuel(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toFuelLogResponse(result), "Fuel log recorded successfully")
+}
+
+// ListFuel godoc
+// @Summary List fuel fill-ups
+// @Description List a vehicle's fill-ups, latest first
+// @Tags vehicle-costs
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Vehicle ID"
+// @Param from query string false "Filled at or after (RFC 3339)"
+// @Param to query string false "Filled before (RFC 3339)"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.FuelLogResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicles/{id}/fuel-logs [get]
+func (h *Handler) ListFuel(c *fiber.Ctx) error {
+	vehicleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid vehicle ID"))
+	}
+
+	var query dto.ListFuelLogsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := vehiclecost.ListFuelLogsInput{
+		VehicleID: vehicleID,
+		From:      dto.ParseTimestamp(query.From),
+		To:        dto.ParseTimestamp(query.To),
+		Limit:     query.GetLimit(),
+		Offset:    query.Offset,
+	}
+
+	results, total, err := h.useCase.ListFuel(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.FuelLogResponse, len(results))
+	for i, r := range results {
+		data[i] = toFuelLogResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// GetFuel godoc
+// @Summary Get fuel fill-up
+// @Description Get a fill-up by ID
+// @Tags vehicle-costs
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Fuel log ID"
+// @Success 200 {object} httpresponse.Response{data=dto.FuelLogResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/fuel-logs/{id} [get]
+func (h *Handler) GetFuel(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid fuel log ID"))
+	}
+
+	result, err := h.useCase.GetFuel(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toFuelLogResponse(result), "Fuel log retrieved successfully")
+}
+
+// DeleteFuel godoc
+// @Summary Delete fuel fill-up
+// @Description Delete a fill-up recorded in error; the vehicle's next full tank is rated again
+// @Tags vehicle-costs
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Fuel log ID"
+// @Success 200 {object} httpresponse.Response
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/fuel-logs/{id} [delete]
+func (h *Handler) DeleteFuel(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid fuel log ID"))
+	}
+
+	if err := h.useCase.DeleteFuel(c.Context(), id); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, nil, "Fuel log deleted successfully")
+}
+
+// RecordExpense godoc
+// @Summary Record vehicle expense
+// @Description Record a running cost of a vehicle other than fuel, such as a toll, repair or fine
+// @Tags vehicle-costs
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Vehicle ID"
+// @Param request body dto.VehicleExpenseRequest true "Expense"
+// @Success 201 {object} httpresponse.Response{data=dto.VehicleExpenseResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 401 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicles/{id}/expenses [post]
+func (h *Handler) RecordExpense(c *fiber.Ctx) error {
+	vehicleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid vehicle ID"))
+	}
+
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		return httpresponse.Error(c, fiber.ErrUnauthorized)
+	}
+
+	var req dto.VehicleExpenseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(req); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	incurredAt, err := time.Parse(time.RFC3339, req.IncurredAt)
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid expense time"))
+	}
+
+	input := vehiclecost.ExpenseInput{
+		VehicleID:   vehicleID,
+		DriverID:    parseOptionalID(req.DriverID),
+		TripID:      parseOptionalID(req.TripID),
+		Type:        entity.ExpenseType(req.Type),
+		IncurredAt:  incurredAt,
+		Amount:      entity.Baht(req.Amount),
+		Description: req.Description,
+		Reference:   req.Reference,
+		ReceiptKey:  req.ReceiptKey,
+		CreatedBy:   userID,
+	}
+
+	result, err := h.useCase.RecordExpense(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Created(c, toExpenseResponse(result), "Expense recorded successfully")
+}
+
+// ListExpenses godoc
+// @Summary List vehicle expenses
+// @Description List a vehicle's expenses other than fuel, latest first
+// @Tags vehicle-costs
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Vehicle ID"
+// @Param type query string false "Expense type" Enums(toll, parking, repair, maintenance, tyre, fine, other)
+// @Param from query string false "Incurred at or after (RFC 3339)"
+// @Param to query string false "Incurred before (RFC 3339)"
+// @Param limit query int false "Page size" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} httpresponse.PaginatedResponse{data=[]dto.VehicleExpenseResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicles/{id}/expenses [get]
+func (h *Handler) ListExpenses(c *fiber.Ctx) error {
+	vehicleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid vehicle ID"))
+	}
+
+	var query dto.ListVehicleExpensesQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	input := vehiclecost.ListExpensesInput{
+		VehicleID: vehicleID,
+		From:      dto.ParseTimestamp(query.From),
+		To:        dto.ParseTimestamp(query.To),
+		Limit:     query.GetLimit(),
+		Offset:    query.Offset,
+	}
+	if query.Type != "" {
+		expenseType := entity.ExpenseType(query.Type)
+		input.Type = &expenseType
+	}
+
+	results, total, err := h.useCase.ListExpenses(c.Context(), input)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	data := make([]dto.VehicleExpenseResponse, len(results))
+	for i, r := range results {
+		data[i] = toExpenseResponse(r)
+	}
+
+	return httpresponse.Paginated(c, data, total, input.Limit, input.Offset)
+}
+
+// GetExpense godoc
+// @Summary Get vehicle expense
+// @Description Get a vehicle expense by ID
+// @Tags vehicle-costs
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Expense ID"
+// @Success 200 {object} httpresponse.Response{data=dto.VehicleExpenseResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicle-expenses/{id} [get]
+func (h *Handler) GetExpense(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid expense ID"))
+	}
+
+	result, err := h.useCase.GetExpense(c.Context(), id)
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toExpenseResponse(result), "Expense retrieved successfully")
+}
+
+// DeleteExpense godoc
+// @Summary Delete vehicle expense
+// @Description Delete a vehicle expense recorded in error
+// @Tags vehicle-costs
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Expense ID"
+// @Success 200 {object} httpresponse.Response
+// @Failure 400 {object} httpresponse.Response
+// @Failure 404 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/vehicle-expenses/{id} [delete]
+func (h *Handler) DeleteExpense(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid expense ID"))
+	}
+
+	if err := h.useCase.DeleteExpense(c.Context(), id); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, nil, "Expense deleted successfully")
+}
+
+// Report godoc
+// @Summary Vehicle cost report
+// @Description Total each vehicle's fuel and other running costs over the period's days, by plate number.
+// @Description Efficiency and cost per km are over the distance rated by the period's full-tank fill-ups.
+// @Tags vehicle-costs
+// @Produce json
+// @Security Bearer
+// @Param vehicle_id query string false "Vehicle ID"
+// @Param period_from query string true "First day (YYYY-MM-DD)"
+// @Param period_to query string true "Last day (YYYY-MM-DD)"
+// @Success 200 {object} httpresponse.Response{data=dto.VehicleCostReportResponse}
+// @Failure 400 {object} httpresponse.Response
+// @Failure 422 {object} httpresponse.Response
+// @Failure 500 {object} httpresponse.Response
+// @Router /api/v1/reports/vehicle-costs [get]
+func (h *Handler) Report(c *fiber.Ctx) error {
+	var query dto.VehicleCostReportQuery
+	if err := c.QueryParser(&query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	if err := validator.Validate(query); err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	periodFrom, err := time.Parse(dto.DateLayout, query.PeriodFrom)
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid period start date"))
+	}
+	periodTo, err := time.Parse(dto.DateLayout, query.PeriodTo)
+	if err != nil {
+		return httpresponse.Error(c, apierror.NewBadRequestError("Invalid period end date"))
+	}
+
+	result, err := h.useCase.Report(c.Context(), vehiclecost.ReportInput{
+		VehicleID:  parseOptionalID(query.VehicleID),
+		PeriodFrom: periodFrom,
+		PeriodTo:   periodTo,
+	})
+	if err != nil {
+		return httpresponse.Error(c, err)
+	}
+
+	return httpresponse.Success(c, toReportResponse(result), "Cost report generated successfully")
+}
+
+// parseOptionalID parses an ID already validated as a UUID, if given
+func parseOptionalID(value string) *uuid.UUID {
+	if value == "" {
+		return nil
+	}
+	id := uuid.MustParse(value)
+	return &id
+}
+
+func formatOptionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func toFuelLogResponse(l *vehiclecost.FuelLogOutput) dto.FuelLogResponse {
+	return dto.FuelLogResponse{
+		ID:                 l.ID.String(),
+		VehicleID:          l.VehicleID.String(),
+		DriverID:           formatOptionalID(l.DriverID),
+		FilledAt:           l.FilledAt.Format(time.RFC3339),
+		Liters:             l.Liters,
+		PricePerLiter:      l.PricePerLiter.Baht(),
+		Amount:             l.Amount.Baht(),
+		OdometerKm:         l.OdometerKm,
+		Station:            l.Station,
+		FullTank:           l.FullTank,
+		ReceiptKey:         l.ReceiptKey,
+		ReceiptURL:         l.ReceiptURL,
+		Notes:              l.Notes,
+		DistanceKm:         l.DistanceKm,
+		KmPerLiter:         l.KmPerLiter,
+		BaselineKmPerLiter: l.BaselineKmPerLiter,
+		Deviation:          l.Deviation,
+		Flagged:            l.Flagged,
+		CreatedBy:          formatOptionalID(l.CreatedBy),
+		CreatedAt:          l.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:          l.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toExpenseResponse(e *vehiclecost.ExpenseOutput) dto.VehicleExpenseResponse {
+	return dto.VehicleExpenseResponse{
+		ID:          e.ID.String(),
+		VehicleID:   e.VehicleID.String(),
+		DriverID:    formatOptionalID(e.DriverID),
+		TripID:      formatOptionalID(e.TripID),
+		Type:        string(e.Type),
+		IncurredAt:  e.IncurredAt.Format(time.RFC3339),
+		Amount:      e.Amount.Baht(),
+		Description: e.Description,
+		Reference:   e.Reference,
+		ReceiptKey:  e.ReceiptKey,
+		ReceiptURL:  e.ReceiptURL,
+		CreatedBy:   formatOptionalID(e.CreatedBy),
+		CreatedAt:   e.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   e.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toReportResponse(r *vehiclecost.CostReportOutput) dto.VehicleCostReportResponse {
+	vehicles := make([]dto.VehicleCostResponse, len(r.Vehicles))
+	for i, v := range r.Vehicles {
+		expenses := make([]dto.ExpenseTotalResponse, len(v.Expenses))
+		for j, e := range v.Expenses {
+			expenses[j] = dto.ExpenseTotalResponse{Type: string(e.Type), Count: e.Count, Amount: e.Amount.Baht()}
+		}
+		var costPerKm *float64
+		if v.CostPerKm != nil {
+			baht := v.CostPerKm.Baht()
+			costPerKm = &baht
+		}
+		vehicles[i] = dto.VehicleCostResponse{
+			VehicleID:          v.VehicleID.String(),
+			PlateNumber:        v.PlateNumber,
+			VehicleType:        string(v.VehicleType),
+			Fills:              v.Fills,
+			Liters:             v.Liters,
+			FuelCost:           v.FuelCost.Baht(),
+			DistanceKm:         v.DistanceKm,
+			KmPerLiter:         v.KmPerLiter,
+			BaselineKmPerLiter: v.BaselineKmPerLiter,
+			FlaggedFills:       v.FlaggedFills,
+			Expenses:           expenses,
+			ExpenseCost:        v.ExpenseCost.Baht(),
+			TotalCost:          v.TotalCost.Baht(),
+			CostPerKm:          costPerKm,
+		}
+	}
+
+	return dto.VehicleCostReportResponse{
+		PeriodFrom:  r.PeriodFrom.Format(dto.DateLayout),
+		PeriodTo:    r.PeriodTo.Format(dto.DateLayout),
+		Vehicles:    vehicles,
+		FuelCost:    r.FuelCost.Baht(),
+		ExpenseCost: r.ExpenseCost.Baht(),
+		TotalCost:   r.TotalCost.Baht(),
+	}
+}
@@ -93,3 +93,18 @@ func GetUserStatus(c *fiber.Ctx) (string, bool) {
 	statusStr, ok := status.(string)
 	return statusStr, ok
 }
+
+// StreamAuth creates a JWT authentication middleware for streaming endpoints.
+// Browsers cannot set headers on WebSocket or EventSource requests, so the token may also be passed
+// in the access_token query parameter.
+func StreamAuth(jwtService *jwt.JWTService) fiber.Handler {
+	auth := JWTAuth(jwtService)
+	return func(c *fiber.Ctx) error {
+		if c.Get(authorizationHeader) == "" {
+			if token := c.Query("access_token"); token != "" {
+				c.Request().Header.Set(authorizationHeader, bearerPrefix+token)
+			}
+		}
+		return auth(c)
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"tms-core-service/internal/domain/cache"
+	"tms-core-service/internal/util/apierror"
+	"tms-core-service/internal/util/httpresponse"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// rateLimitKeyPrefix namespaces rate limit counters in the cache
+	rateLimitKeyPrefix = "ratelimit:"
+
+	// Defaults used when a limit is not configured
+	defaultRateLimit  = 30
+	defaultRateWindow = time.Minute
+)
+
+// RateLimit creates a middleware that allows each client IP at most max requests per window
+// on the routes it guards; name separates the counters of different routes.
+// Counters live in the shared cache and are incremented atomically, so the limit holds across
+// instances and concurrent requests. The client IP is taken from the proxy header only when the
+// request comes from a trusted proxy (see server.proxy_header). When the cache is unavailable
+// requests are let through rather than failed.
+func RateLimit(store cache.CacheRepository, name string, max int, window time.Duration) fiber.Handler {
+	if max <= 0 {
+		max = defaultRateLimit
+	}
+	if window <= 0 {
+		window = defaultRateWindow
+	}
+	limit := strconv.Itoa(max)
+
+	return func(c *fiber.Ctx) error {
+		count, ttl, err := store.Increment(c.UserContext(), rateLimitKeyPrefix+name+":"+c.IP(), window)
+		if err != nil {
+			log.Printf("[ERROR] rate limit %s: %v", name, err)
+			return c.Next()
+		}
+
+		reset := strconv.Itoa(int((ttl + time.Second - 1) / time.Second))
+		c.Set("X-RateLimit-Limit", limit)
+		c.Set("X-RateLimit-Reset", reset)
+		if count > int64(max) {
+			c.Set("X-RateLimit-Remaining", "0")
+			c.Set(fiber.HeaderRetryAfter, reset)
+			return httpresponse.Error(c, &apierror.APIError{
+				Code:       apierror.CodeTooManyRequests,
+				Message:    "Too many requests, please try again later",
+				StatusCode: http.StatusTooManyRequests,
+			})
+		}
+		c.Set("X-RateLimit-Remaining", strconv.FormatInt(int64(max)-count, 10))
+		return c.Next()
+	}
+}
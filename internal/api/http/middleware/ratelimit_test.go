@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/cache"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// counterStore counts in memory; only Increment is used by the rate limiter
+type counterStore struct {
+	cache.CacheRepository
+	mu     sync.Mutex
+	counts map[string]int64
+	down   bool
+}
+
+func (s *counterStore) Increment(_ context.Context, key string, exp time.Duration) (int64, time.Duration, error) {
+	if s.down {
+		return 0, 0, errors.New("connection refused")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.counts[key]++
+	return s.counts[key], exp, nil
+}
+
+func newLimitedApp(store cache.CacheRepository, trustedProxies ...string) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ProxyHeader:             "X-Real-IP",
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          trustedProxies,
+		EnableIPValidation:      true,
+	})
+	app.Get("/track", RateLimit(store, "track", 3, time.Minute), func(c *fiber.Ctx) error {
+		return c.SendString(c.IP())
+	})
+	return app
+}
+
+func get(t *testing.T, app *fiber.App, clientIP string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/track", nil)
+	if clientIP != "" {
+		req.Header.Set("X-Real-IP", clientIP)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	return resp
+}
+
+func TestRateLimitBlocksAfterMax(t *testing.T) {
+	app := newLimitedApp(&counterStore{counts: make(map[string]int64)})
+
+	for i := 1; i <= 3; i++ {
+		if resp := get(t, app, ""); resp.StatusCode != http.StatusOK {
+			t.Fatalf("request %d: status %d", i, resp.StatusCode)
+		}
+	}
+	resp := get(t, app, "")
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("4th request: status %d, want 429", resp.StatusCode)
+	}
+	if resp.Header.Get(fiber.HeaderRetryAfter) != "60" || resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		t.Errorf("headers = %v, want Retry-After 60 and nothing remaining", resp.Header)
+	}
+}
+
+func TestRateLimitIgnoresProxyHeaderFromUntrustedClients(t *testing.T) {
+	// requests made with app.Test come from 0.0.0.0
+	untrusted := newLimitedApp(&counterStore{counts: make(map[string]int64)}, "10.0.0.1")
+	for i := 0; i < 3; i++ {
+		get(t, untrusted, "203.0.113.10")
+	}
+	if resp := get(t, untrusted, "203.0.113.99"); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("spoofed client IP got status %d, want 429", resp.StatusCode)
+	}
+
+	trusted := newLimitedApp(&counterStore{counts: make(map[string]int64)}, "0.0.0.0")
+	for i := 0; i < 3; i++ {
+		get(t, trusted, "203.0.113.10")
+	}
+	if resp := get(t, trusted, "203.0.113.99"); resp.StatusCode != http.StatusOK {
+		t.Errorf("another client behind the trusted proxy got status %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestRateLimitLetsRequestsThroughWhenCacheIsDown(t *testing.T) {
+	app := newLimitedApp(&counterStore{down: true})
+	for i := 0; i < 5; i++ {
+		if resp := get(t, app, ""); resp.StatusCode != http.StatusOK {
+			t.Fatalf("request %d: status %d", i+1, resp.StatusCode)
+		}
+	}
+}
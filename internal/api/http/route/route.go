@@ -2,7 +2,35 @@ package route
 
 import (
 	"tms-core-service/internal/api/http/handler/auth"
+	"tms-core-service/internal/api/http/handler/carrier"
+	"tms-core-service/internal/api/http/handler/compliance"
+	"tms-core-service/internal/api/http/handler/dock"
+	"tms-core-service/internal/api/http/handler/document"
+	"tms-core-service/internal/api/http/handler/driver"
+	"tms-core-service/internal/api/http/handler/eta"
+	"tms-core-service/internal/api/http/handler/geocoding"
+	"tms-core-service/internal/api/http/handler/geofence"
 	"tms-core-service/internal/api/http/handler/healthcheck"
+	"tms-core-service/internal/api/http/handler/incident"
+	"tms-core-service/internal/api/http/handler/invoicing"
+	"tms-core-service/internal/api/http/handler/label"
+	"tms-core-service/internal/api/http/handler/loadplan"
+	"tms-core-service/internal/api/http/handler/location"
+	"tms-core-service/internal/api/http/handler/maintenance"
+	"tms-core-service/internal/api/http/handler/numbering"
+	"tms-core-service/internal/api/http/handler/organization"
+	"tms-core-service/internal/api/http/handler/planning"
+	"tms-core-service/internal/api/http/handler/pod"
+	"tms-core-service/internal/api/http/handler/pricing"
+	"tms-core-service/internal/api/http/handler/publictracking"
+	"tms-core-service/internal/api/http/handler/realtime"
+	"tms-core-service/internal/api/http/handler/settlement"
+	"tms-core-service/internal/api/http/handler/shipment"
+	"tms-core-service/internal/api/http/handler/tender"
+	"tms-core-service/internal/api/http/handler/tracking"
+	"tms-core-service/internal/api/http/handler/trip"
+	"tms-core-service/internal/api/http/handler/vehicle"
+	"tms-core-service/internal/api/http/handler/vehiclecost"
 	"tms-core-service/internal/api/http/middleware"
 	"tms-core-service/pkg/jwt"
 
@@ -12,9 +40,38 @@ import (
 
 // Dependencies holds all handler dependencies
 type Dependencies struct {
-	HealthCheckHandler *healthcheck.Handler
-	AuthHandler        *auth.Handler
-	JWTService         *jwt.JWTService
+	HealthCheckHandler  *healthcheck.Handler
+	AuthHandler         *auth.Handler
+	DriverHandler       *driver.Handler
+	OrganizationHandler *organization.Handler
+	LocationHandler     *location.Handler
+	NumberingHandler    *numbering.Handler
+	GeocodingHandler    *geocoding.Handler
+	VehicleHandler      *vehicle.Handler
+	ShipmentHandler     *shipment.Handler
+	TripHandler         *trip.Handler
+	PlanningHandler     *planning.Handler
+	LoadPlanHandler     *loadplan.Handler
+	PricingHandler      *pricing.Handler
+	CarrierHandler      *carrier.Handler
+	TenderHandler       *tender.Handler
+	InvoiceHandler      *invoicing.Handler
+	DocumentHandler     *document.Handler
+	LabelHandler        *label.Handler
+	SettlementHandler   *settlement.Handler
+	VehicleCostHandler  *vehiclecost.Handler
+	MaintenanceHandler  *maintenance.Handler
+	ComplianceHandler   *compliance.Handler
+	DockHandler         *dock.Handler
+	TrackingHandler     *tracking.Handler
+	PODHandler          *pod.Handler
+	IncidentHandler     *incident.Handler
+	GeofenceHandler     *geofence.Handler
+	ETAHandler          *eta.Handler
+	RealtimeHandler     *realtime.Handler
+	PublicTrackHandler  *publictracking.Handler
+	PublicRateLimiter   fiber.Handler
+	JWTService          *jwt.JWTService
 }
 
 // SetupRoutes configures all application routes
@@ -43,9 +100,277 @@ func SetupRoutes(app *fiber.App, deps *Dependencies) {
 	authGroup.Get("/line/callback", deps.AuthHandler.LineCallback)
 	authGroup.Post("/refresh", deps.AuthHandler.RefreshToken)
 
+	// Public tracking page for consignees (no auth required, rate-limited per IP)
+	v1.Get("/track/:trackingNumber", deps.PublicRateLimiter, deps.PublicTrackHandler.Track)
+	v1.Post("/track/:trackingNumber/reschedule", deps.PublicRateLimiter, deps.PublicTrackHandler.Reschedule)
+
+	// Realtime streams; the token may be passed in the query since browsers cannot set headers on them
+	stream := v1.Group("/stream", middleware.StreamAuth(deps.JWTService))
+	stream.Get("/ws", deps.RealtimeHandler.WebSocket)
+	stream.Get("/sse", deps.RealtimeHandler.SSE)
+
 	// Protected routes (JWT required)
 	protected := v1.Group("", middleware.JWTAuth(deps.JWTService))
 	protected.Get("/auth/me", deps.AuthHandler.GetProfile)
 	protected.Put("/auth/profile", deps.AuthHandler.UpdateProfile)
 	protected.Post("/auth/avatar/upload-url", deps.AuthHandler.GetAvatarUploadURL)
+
+	// Driver management
+	drivers := protected.Group("/drivers")
+	drivers.Post("/", deps.DriverHandler.Create)
+	drivers.Post("/invite", deps.DriverHandler.Invite)
+	drivers.Get("/", deps.DriverHandler.List)
+	drivers.Get("/:id", deps.DriverHandler.Get)
+	drivers.Put("/:id", deps.DriverHandler.Update)
+	drivers.Patch("/:id/status", deps.DriverHandler.UpdateStatus)
+	drivers.Delete("/:id", deps.DriverHandler.Delete)
+
+	// Organizations, their address books and number formats
+	organizations := protected.Group("/organizations")
+	organizations.Post("/", deps.OrganizationHandler.Create)
+	organizations.Get("/", deps.OrganizationHandler.List)
+	organizations.Get("/:id", deps.OrganizationHandler.Get)
+	organizations.Put("/:id", deps.OrganizationHandler.Update)
+	organizations.Post("/:id/locations", deps.LocationHandler.Create)
+	organizations.Get("/:id/locations", deps.LocationHandler.List)
+	organizations.Get("/:id/numbering-formats", deps.NumberingHandler.List)
+	organizations.Put("/:id/numbering-formats/:type", deps.NumberingHandler.Set)
+	organizations.Delete("/:id/numbering-formats/:type", deps.NumberingHandler.Delete)
+	organizations.Get("/:id/label-template", deps.LabelHandler.GetTemplate)
+	organizations.Put("/:id/label-template", deps.LabelHandler.SetTemplate)
+	organizations.Delete("/:id/label-template", deps.LabelHandler.DeleteTemplate)
+
+	locations := protected.Group("/locations")
+	locations.Get("/:id", deps.LocationHandler.Get)
+	locations.Put("/:id", deps.LocationHandler.Update)
+	locations.Delete("/:id", deps.LocationHandler.Delete)
+	locations.Post("/:id/geofences", deps.GeofenceHandler.Create)
+	locations.Get("/:id/geofences", deps.GeofenceHandler.ListByLocation)
+
+	// Site docks and their appointments
+	docks := protected.Group("/docks")
+	docks.Post("/", deps.DockHandler.Create)
+	docks.Get("/", deps.DockHandler.List)
+	docks.Get("/:id", deps.DockHandler.Get)
+	docks.Put("/:id", deps.DockHandler.Update)
+	docks.Get("/:id/slots", deps.DockHandler.Slots)
+
+	dockAppointments := protected.Group("/dock-appointments")
+	dockAppointments.Post("/", deps.DockHandler.Book)
+	dockAppointments.Get("/", deps.DockHandler.ListAppointments)
+	dockAppointments.Get("/turnaround", deps.DockHandler.Turnaround)
+	dockAppointments.Get("/:id", deps.DockHandler.GetAppointment)
+	dockAppointments.Put("/:id", deps.DockHandler.Reschedule)
+	dockAppointments.Post("/:id/check-in", deps.DockHandler.CheckIn)
+	dockAppointments.Post("/:id/check-out", deps.DockHandler.CheckOut)
+	dockAppointments.Post("/:id/cancel", deps.DockHandler.Cancel)
+	dockAppointments.Post("/:id/no-show", deps.DockHandler.NoShow)
+
+	// Geofences and the arrival and departure events they detect
+	geofences := protected.Group("/geofences")
+	geofences.Get("/events", deps.GeofenceHandler.ListEvents)
+	geofences.Get("/:id", deps.GeofenceHandler.Get)
+	geofences.Put("/:id", deps.GeofenceHandler.Update)
+	geofences.Delete("/:id", deps.GeofenceHandler.Delete)
+
+	// Thai address autocomplete
+	addresses := protected.Group("/addresses")
+	addresses.Get("/provinces", deps.LocationHandler.Provinces)
+	addresses.Get("/districts", deps.LocationHandler.Districts)
+	addresses.Get("/subdistricts", deps.LocationHandler.Subdistricts)
+	addresses.Get("/postcodes/:postcode", deps.LocationHandler.ByPostcode)
+	addresses.Get("/search", deps.LocationHandler.Search)
+
+	// Geocoding
+	geocode := protected.Group("/geocode")
+	geocode.Get("/", deps.GeocodingHandler.Geocode)
+	geocode.Get("/reverse", deps.GeocodingHandler.ReverseGeocode)
+
+	// Fleet vehicles
+	vehicles := protected.Group("/vehicles")
+	vehicles.Post("/", deps.VehicleHandler.Create)
+	vehicles.Get("/", deps.VehicleHandler.List)
+	vehicles.Get("/:id", deps.VehicleHandler.Get)
+	vehicles.Put("/:id", deps.VehicleHandler.Update)
+	vehicles.Patch("/:id/status", deps.VehicleHandler.UpdateStatus)
+	vehicles.Delete("/:id", deps.VehicleHandler.Delete)
+	vehicles.Get("/:id/track", deps.TrackingHandler.Track)
+
+	// Fuel and running costs
+	vehicles.Post("/:id/receipts/upload-url", deps.VehicleCostHandler.ReceiptUploadURL)
+	vehicles.Post("/:id/fuel-logs", deps.VehicleCostHandler.RecordFuel)
+	vehicles.Get("/:id/fuel-logs", deps.VehicleCostHandler.ListFuel)
+	vehicles.Post("/:id/expenses", deps.VehicleCostHandler.RecordExpense)
+	vehicles.Get("/:id/expenses", deps.VehicleCostHandler.ListExpenses)
+
+	fuelLogs := protected.Group("/fuel-logs")
+	fuelLogs.Get("/:id", deps.VehicleCostHandler.GetFuel)
+	fuelLogs.Delete("/:id", deps.VehicleCostHandler.DeleteFuel)
+
+	vehicleExpenses := protected.Group("/vehicle-expenses")
+	vehicleExpenses.Get("/:id", deps.VehicleCostHandler.GetExpense)
+	vehicleExpenses.Delete("/:id", deps.VehicleCostHandler.DeleteExpense)
+
+	protected.Get("/reports/vehicle-costs", deps.VehicleCostHandler.Report)
+
+	// Preventive maintenance and work orders
+	vehicles.Get("/:id/maintenance", deps.MaintenanceHandler.VehicleSchedules)
+
+	maintenancePlans := protected.Group("/maintenance-plans")
+	maintenancePlans.Post("/", deps.MaintenanceHandler.CreatePlan)
+	maintenancePlans.Get("/", deps.MaintenanceHandler.ListPlans)
+	maintenancePlans.Get("/:id", deps.MaintenanceHandler.GetPlan)
+	maintenancePlans.Put("/:id", deps.MaintenanceHandler.UpdatePlan)
+
+	protected.Get("/maintenance/schedules", deps.MaintenanceHandler.ListSchedules)
+	protected.Post("/maintenance/check", deps.MaintenanceHandler.CheckSchedules)
+
+	workOrders := protected.Group("/work-orders")
+	workOrders.Post("/", deps.MaintenanceHandler.CreateWorkOrder)
+	workOrders.Get("/", deps.MaintenanceHandler.ListWorkOrders)
+	workOrders.Get("/:id", deps.MaintenanceHandler.GetWorkOrder)
+	workOrders.Put("/:id", deps.MaintenanceHandler.UpdateWorkOrder)
+	workOrders.Post("/:id/start", deps.MaintenanceHandler.StartWorkOrder)
+	workOrders.Post("/:id/complete", deps.MaintenanceHandler.CompleteWorkOrder)
+	workOrders.Post("/:id/cancel", deps.MaintenanceHandler.CancelWorkOrder)
+
+	// Vehicle, driver and carrier compliance documents
+	complianceDocuments := protected.Group("/compliance-documents")
+	complianceDocuments.Post("/upload-url", deps.ComplianceHandler.UploadURL)
+	complianceDocuments.Post("/", deps.ComplianceHandler.Create)
+	complianceDocuments.Get("/", deps.ComplianceHandler.List)
+	complianceDocuments.Get("/:id", deps.ComplianceHandler.Get)
+	complianceDocuments.Put("/:id", deps.ComplianceHandler.Update)
+	complianceDocuments.Delete("/:id", deps.ComplianceHandler.Delete)
+
+	protected.Post("/compliance/reminders", deps.ComplianceHandler.SendReminders)
+
+	// GPS ingestion and live positions
+	tracking := protected.Group("/tracking")
+	tracking.Post("/positions", deps.TrackingHandler.Ingest)
+	tracking.Get("/fleet", deps.TrackingHandler.Fleet)
+
+	// Shipments
+	shipments := protected.Group("/shipments")
+	shipments.Post("/", deps.ShipmentHandler.Create)
+	shipments.Get("/", deps.ShipmentHandler.List)
+	shipments.Get("/:id", deps.ShipmentHandler.Get)
+	shipments.Put("/:id", deps.ShipmentHandler.Update)
+	shipments.Delete("/:id", deps.ShipmentHandler.Delete)
+	shipments.Post("/:id/quote", deps.PricingHandler.QuoteShipment)
+	shipments.Get("/:id/waybill", deps.DocumentHandler.Waybill)
+	shipments.Get("/:id/delivery-note", deps.DocumentHandler.DeliveryNote)
+	shipments.Get("/:id/labels", deps.LabelHandler.Shipment)
+	shipments.Get("/:id/timeline", deps.ShipmentHandler.Timeline)
+
+	// Trip planning and dispatch
+	trips := protected.Group("/trips")
+	trips.Post("/", deps.TripHandler.Create)
+	trips.Get("/", deps.TripHandler.List)
+	trips.Get("/:id", deps.TripHandler.Get)
+	trips.Put("/:id", deps.TripHandler.Update)
+	trips.Patch("/:id/status", deps.TripHandler.UpdateStatus)
+	trips.Get("/:id/delays", deps.ETAHandler.Delays)
+	trips.Get("/:id/labels", deps.LabelHandler.Trip)
+
+	// Electronic proof of delivery
+	trips.Post("/:id/stops/:stopId/pod/upload-urls", deps.PODHandler.UploadURLs)
+	trips.Post("/:id/stops/:stopId/pod", deps.PODHandler.Submit)
+	trips.Get("/:id/stops/:stopId/pod", deps.PODHandler.Get)
+	trips.Get("/:id/stops/:stopId/pod/pdf", deps.PODHandler.Document)
+	trips.Post("/:id/stops/:stopId/failed-attempt/upload-url", deps.PODHandler.AttemptUploadURL)
+	trips.Post("/:id/stops/:stopId/failed-attempt", deps.PODHandler.Fail)
+	trips.Get("/:id/stops/:stopId/failed-attempt", deps.PODHandler.GetAttempt)
+
+	// Exceptions and incidents on trips and shipments
+	incidents := protected.Group("/incidents")
+	incidents.Post("/upload-urls", deps.IncidentHandler.UploadURLs)
+	incidents.Post("/check-sla", deps.IncidentHandler.CheckSLA)
+	incidents.Post("/", deps.IncidentHandler.Create)
+	incidents.Get("/", deps.IncidentHandler.List)
+	incidents.Get("/:id", deps.IncidentHandler.Get)
+	incidents.Put("/:id", deps.IncidentHandler.Update)
+	incidents.Post("/:id/photos", deps.IncidentHandler.AddPhotos)
+	incidents.Post("/:id/start", deps.IncidentHandler.Start)
+	incidents.Post("/:id/resolve", deps.IncidentHandler.Resolve)
+	incidents.Post("/:id/close", deps.IncidentHandler.Close)
+	incidents.Post("/:id/cancel", deps.IncidentHandler.Cancel)
+
+	// Route optimization
+	planningGroup := protected.Group("/planning")
+	planningGroup.Post("/optimize", deps.PlanningHandler.Optimize)
+	planningGroup.Get("/jobs/:id", deps.PlanningHandler.GetJob)
+
+	// Load planning
+	protected.Post("/load-plans", deps.LoadPlanHandler.Plan)
+
+	// Rate cards and pricing
+	rateCards := protected.Group("/rate-cards")
+	rateCards.Post("/", deps.PricingHandler.CreateRateCard)
+	rateCards.Get("/", deps.PricingHandler.ListRateCards)
+	rateCards.Get("/:id", deps.PricingHandler.GetRateCard)
+
+	dieselPrices := protected.Group("/diesel-prices")
+	dieselPrices.Post("/", deps.PricingHandler.CreateDieselPrice)
+	dieselPrices.Get("/", deps.PricingHandler.ListDieselPrices)
+
+	protected.Post("/pricing/simulate", deps.PricingHandler.Simulate)
+
+	// Carriers (subcontractors)
+	carriers := protected.Group("/carriers")
+	carriers.Post("/", deps.CarrierHandler.Create)
+	carriers.Get("/", deps.CarrierHandler.List)
+	carriers.Get("/:id", deps.CarrierHandler.Get)
+	carriers.Put("/:id", deps.CarrierHandler.Update)
+	carriers.Delete("/:id", deps.CarrierHandler.Delete)
+	carriers.Get("/:id/users", deps.CarrierHandler.ListUsers)
+	carriers.Post("/:id/users", deps.CarrierHandler.AddUser)
+	carriers.Delete("/:id/users/:userId", deps.CarrierHandler.RemoveUser)
+
+	// Load tendering
+	tenders := protected.Group("/tenders")
+	tenders.Post("/", deps.TenderHandler.Create)
+	tenders.Get("/", deps.TenderHandler.List)
+	tenders.Get("/:id", deps.TenderHandler.Get)
+	tenders.Post("/:id/cancel", deps.TenderHandler.Cancel)
+
+	// Invoicing
+	invoices := protected.Group("/invoices")
+	invoices.Post("/", deps.InvoiceHandler.Create)
+	invoices.Get("/", deps.InvoiceHandler.List)
+	invoices.Get("/:id", deps.InvoiceHandler.Get)
+	invoices.Post("/:id/issue", deps.InvoiceHandler.Issue)
+	invoices.Post("/:id/pay", deps.InvoiceHandler.Pay)
+	invoices.Post("/:id/void", deps.InvoiceHandler.Void)
+	invoices.Post("/:id/credit-notes", deps.InvoiceHandler.CreateCreditNote)
+	invoices.Get("/:id/pdf", deps.DocumentHandler.Invoice)
+
+	// Driver pay
+	payRules := protected.Group("/driver-pay-rules")
+	payRules.Post("/", deps.SettlementHandler.CreatePayRule)
+	payRules.Get("/", deps.SettlementHandler.ListPayRules)
+	payRules.Get("/:id", deps.SettlementHandler.GetPayRule)
+	payRules.Put("/:id", deps.SettlementHandler.UpdatePayRule)
+
+	settlements := protected.Group("/driver-settlements")
+	settlements.Post("/", deps.SettlementHandler.Create)
+	settlements.Get("/", deps.SettlementHandler.List)
+	settlements.Get("/export", deps.SettlementHandler.Export)
+	settlements.Get("/:id", deps.SettlementHandler.Get)
+	settlements.Post("/:id/approve", deps.SettlementHandler.Approve)
+	settlements.Post("/:id/void", deps.SettlementHandler.Void)
+	settlements.Post("/:id/adjustments", deps.SettlementHandler.AddAdjustment)
+	settlements.Delete("/:id/adjustments/:adjustmentId", deps.SettlementHandler.RemoveAdjustment)
+
+	// Carrier-facing API: the user must act for a carrier
+	carrierPortal := protected.Group("/carrier")
+	carrierPortal.Get("/tenders", deps.TenderHandler.CarrierList)
+	carrierPortal.Get("/tenders/:id", deps.TenderHandler.CarrierGet)
+	carrierPortal.Post("/tenders/:id/accept", deps.TenderHandler.Accept)
+	carrierPortal.Post("/tenders/:id/reject", deps.TenderHandler.Reject)
+	carrierPortal.Get("/docks", deps.DockHandler.CarrierListDocks)
+	carrierPortal.Get("/docks/:id/slots", deps.DockHandler.CarrierSlots)
+	carrierPortal.Post("/dock-appointments", deps.DockHandler.CarrierBook)
+	carrierPortal.Get("/dock-appointments", deps.DockHandler.CarrierListAppointments)
+	carrierPortal.Post("/dock-appointments/:id/cancel", deps.DockHandler.CarrierCancel)
 }
@@ -10,14 +10,31 @@ import (
 
 // AppConfig represents the entire application configuration
 type AppConfig struct {
-	Server    ServerConfig    `mapstructure:"server"`
-	Database  DatabaseConfig  `mapstructure:"database"`
-	Redis     RedisConfig     `mapstructure:"redis"`
-	JWT       JWTConfig       `mapstructure:"jwt"`
-	Migration MigrationConfig `mapstructure:"migration"`
-	Google    GoogleConfig    `mapstructure:"google"`
-	Line      LineConfig      `mapstructure:"line"`
-	S3        S3Config        `mapstructure:"s3"`
+	Server         ServerConfig         `mapstructure:"server"`
+	Database       DatabaseConfig       `mapstructure:"database"`
+	Redis          RedisConfig          `mapstructure:"redis"`
+	JWT            JWTConfig            `mapstructure:"jwt"`
+	Migration      MigrationConfig      `mapstructure:"migration"`
+	Google         GoogleConfig         `mapstructure:"google"`
+	Line           LineConfig           `mapstructure:"line"`
+	S3             S3Config             `mapstructure:"s3"`
+	Geocoding      GeocodingConfig      `mapstructure:"geocoding"`
+	Tendering      TenderingConfig      `mapstructure:"tendering"`
+	Delivery       DeliveryConfig       `mapstructure:"delivery"`
+	Tracking       TrackingConfig       `mapstructure:"tracking"`
+	ETA            ETAConfig            `mapstructure:"eta"`
+	Realtime       RealtimeConfig       `mapstructure:"realtime"`
+	PublicTracking PublicTrackingConfig `mapstructure:"public_tracking"`
+	Numbering      NumberingConfig      `mapstructure:"numbering"`
+	Invoicing      InvoicingConfig      `mapstructure:"invoicing"`
+	Documents      DocumentsConfig      `mapstructure:"documents"`
+	Labels         LabelsConfig         `mapstructure:"labels"`
+	Fuel           FuelConfig           `mapstructure:"fuel"`
+	Maintenance    MaintenanceConfig    `mapstructure:"maintenance"`
+	Compliance     ComplianceConfig     `mapstructure:"compliance"`
+	Incidents      IncidentsConfig      `mapstructure:"incidents"`
+	Notifications  NotificationsConfig  `mapstructure:"notifications"`
+	Planning       PlanningConfig       `mapstructure:"planning"`
 }
 
 // ServerConfig contains HTTP server settings
@@ -26,6 +43,10 @@ type ServerConfig struct {
 	Mode        string        `mapstructure:"mode"` // debug, release, test
 	Timeout     TimeoutConfig `mapstructure:"timeout"`
 	FrontendURL string        `mapstructure:"frontend_url"`
+	// ProxyHeader names the header carrying the client IP set by the reverse proxy, e.g. X-Real-IP.
+	// It is only read on requests from TrustedProxies; others are identified by their remote address.
+	ProxyHeader    string   `mapstructure:"proxy_header"`
+	TrustedProxies []string `mapstructure:"trusted_proxies"` // IPs or CIDR ranges
 }
 
 // TimeoutConfig contains server timeout settings
@@ -100,6 +121,132 @@ type S3Config struct {
 	PresignExpiry time.Duration `mapstructure:"presign_expiry"`
 }
 
+// GeocodingConfig contains geocoding provider settings
+type GeocodingConfig struct {
+	Provider string        `mapstructure:"provider"` // offline, http
+	BaseURL  string        `mapstructure:"base_url"`
+	APIKey   string        `mapstructure:"api_key"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+}
+
+// TenderingConfig contains load tendering settings
+type TenderingConfig struct {
+	SweepInterval time.Duration `mapstructure:"sweep_interval"` // how often expired offers are rolled over
+}
+
+// DeliveryConfig contains proof-of-delivery and failed-delivery settings
+type DeliveryConfig struct {
+	MaxDistanceM float64 `mapstructure:"max_distance_m"` // how far from the delivery location a POD may be captured
+	MaxAttempts  int     `mapstructure:"max_attempts"`   // delivery attempts before the goods are returned to the sender
+}
+
+// TrackingConfig contains GPS history writer settings
+type TrackingConfig struct {
+	BatchSize     int           `mapstructure:"batch_size"`     // positions per database write
+	FlushInterval time.Duration `mapstructure:"flush_interval"` // longest time a position waits to be written
+}
+
+// ETAConfig contains arrival prediction settings
+type ETAConfig struct {
+	DefaultServiceTime time.Duration `mapstructure:"default_service_time"` // time at a stop without planned service minutes
+	DelayMargin        time.Duration `mapstructure:"delay_margin"`         // how far past its window an ETA may be before the stop is delayed
+	MinInterval        time.Duration `mapstructure:"min_interval"`         // shortest time between recomputations per vehicle; 0 for every fix
+}
+
+// RealtimeConfig contains WebSocket and SSE streaming settings
+type RealtimeConfig struct {
+	ClientBuffer      int           `mapstructure:"client_buffer"`      // messages queued per connection before it is dropped as a slow consumer
+	MaxChannels       int           `mapstructure:"max_channels"`       // channels one connection may subscribe to
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"` // how often idle connections are pinged
+	WriteTimeout      time.Duration `mapstructure:"write_timeout"`      // longest time a single write may block
+}
+
+// PublicTrackingConfig contains settings of the unauthenticated tracking page API
+type PublicTrackingConfig struct {
+	RequireChallenge bool          `mapstructure:"require_challenge"` // consignees must give the delivery postcode or phone digits
+	RateLimit        int           `mapstructure:"rate_limit"`        // requests per client IP per rate window
+	RateWindow       time.Duration `mapstructure:"rate_window"`
+	CacheTTL         time.Duration `mapstructure:"cache_ttl"` // how long a page is cached; status changes invalidate it earlier
+}
+
+// NumberingConfig contains document number settings
+type NumberingConfig struct {
+	Formats map[string]string `mapstructure:"formats"` // default format per document type, for organizations without their own
+}
+
+// InvoicingConfig contains billing settings
+type InvoicingConfig struct {
+	VATRate         float64 `mapstructure:"vat_rate"`         // e.g. 0.07
+	WithholdingRate float64 `mapstructure:"withholding_rate"` // withheld on transport services by customers that withhold tax, e.g. 0.01
+	CreditTermDays  int     `mapstructure:"credit_term_days"` // for customers without their own term
+}
+
+// DocumentsConfig contains printable document settings
+type DocumentsConfig struct {
+	FontRegular string        `mapstructure:"font_regular"` // TrueType font with Thai glyphs; the built-in Sarabun when empty
+	FontBold    string        `mapstructure:"font_bold"`
+	Logo        string        `mapstructure:"logo"`         // JPEG or PNG printed in document headers
+	TrackingURL string        `mapstructure:"tracking_url"` // public tracking page the tracking number is appended to, encoded on waybills
+	Company     CompanyConfig `mapstructure:"company"`
+}
+
+// CompanyConfig is the carrier printed as the seller on invoices, waybills and delivery notes
+type CompanyConfig struct {
+	Name       string `mapstructure:"name"`
+	TaxID      string `mapstructure:"tax_id"`
+	BranchCode string `mapstructure:"branch_code"` // "00000" for the head office
+	Address    string `mapstructure:"address"`
+	Phone      string `mapstructure:"phone"`
+}
+
+// LabelsConfig contains the default shipping label template, used by organizations without their own
+type LabelsConfig struct {
+	Size             string `mapstructure:"size"`               // 4x6 or a6
+	DPI              int    `mapstructure:"dpi"`                // 203 or 300
+	ZPLFont          string `mapstructure:"zpl_font"`           // printer TrueType font with Thai glyphs, e.g. E:SARABUN.TTF
+	GS1CompanyPrefix string `mapstructure:"gs1_company_prefix"` // the carrier's prefix for SSCCs; no SSCCs when empty
+	SSCCExtension    int    `mapstructure:"sscc_extension"`
+	Footer           string `mapstructure:"footer"`
+}
+
+// FuelConfig contains fuel efficiency settings
+type FuelConfig struct {
+	BaselineKmPerLiter map[string]float64 `mapstructure:"baseline_km_per_liter"` // per vehicle type, for vehicles without their own
+	DeviationTolerance float64            `mapstructure:"deviation_tolerance"`   // fraction off the baseline before a fill-up is flagged, e.g. 0.15
+}
+
+// MaintenanceConfig contains preventive maintenance settings
+type MaintenanceConfig struct {
+	CheckInterval time.Duration `mapstructure:"check_interval"` // how often schedules are checked against the latest odometers
+	WarnKm        float64       `mapstructure:"warn_km"`        // distance before the due distance at which a service becomes due
+	WarnDays      int           `mapstructure:"warn_days"`      // days before the due date at which a service becomes due
+}
+
+// ComplianceConfig contains compliance document settings
+type ComplianceConfig struct {
+	ReminderInterval time.Duration `mapstructure:"reminder_interval"` // how often documents are checked for reminders
+	ReminderDays     []int         `mapstructure:"reminder_days"`     // days before expiry at which reminders are sent
+	BlockingTypes    []string      `mapstructure:"blocking_types"`    // document types that block dispatch and tenders once expired
+}
+
+// IncidentsConfig contains incident management settings
+type IncidentsConfig struct {
+	CheckInterval time.Duration            `mapstructure:"check_interval"` // how often open incidents are checked against their SLA
+	ResolveWithin map[string]time.Duration `mapstructure:"resolve_within"` // SLA per severity, from when the incident occurred
+}
+
+// NotificationsConfig contains notification outbox settings
+type NotificationsConfig struct {
+	DispatchInterval time.Duration `mapstructure:"dispatch_interval"` // how often the outbox is checked for notifications to deliver
+}
+
+// PlanningConfig contains route optimization settings
+type PlanningConfig struct {
+	Workers   int `mapstructure:"workers"`    // optimization jobs solved at the same time
+	QueueSize int `mapstructure:"queue_size"` // jobs waiting for a worker before new ones are rejected
+}
+
 // LoadConfig loads configuration from the specified file
 func LoadConfig(configPath string) (*AppConfig, error) {
 	viper.SetConfigFile(configPath)
@@ -143,6 +290,9 @@ func LoadConfig(configPath string) (*AppConfig, error) {
 	_ = viper.BindEnv("s3.access_key", "S3_ACCESS_KEY")
 	_ = viper.BindEnv("s3.secret_key", "S3_SECRET_KEY")
 	_ = viper.BindEnv("s3.presign_expiry", "S3_PRESIGN_EXPIRY")
+	_ = viper.BindEnv("geocoding.provider", "GEOCODING_PROVIDER")
+	_ = viper.BindEnv("geocoding.base_url", "GEOCODING_BASE_URL")
+	_ = viper.BindEnv("geocoding.api_key", "GEOCODING_API_KEY")
 
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
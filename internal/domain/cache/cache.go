@@ -21,4 +21,12 @@ type CacheRepository interface {
 
 	// SetNX sets a value only if it doesn't exist (atomic)
 	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+
+	// Increment adds one to a counter and returns the new count and the time left until it expires.
+	// A new counter expires after expiration (atomic).
+	Increment(ctx context.Context, key string, expiration time.Duration) (int64, time.Duration, error)
+
+	// DeleteIfEquals removes a value only if it still equals the given one (atomic), e.g. to release
+	// a lock taken with SetNX without releasing one taken by someone else after it expired
+	DeleteIfEquals(ctx context.Context, key, value string) (bool, error)
 }
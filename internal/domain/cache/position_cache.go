@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// PositionCache holds the latest known position of each vehicle for fast map reads
+type PositionCache interface {
+	// SetLatest stores each position that is newer than the one cached for its vehicle,
+	// so late or repeated fixes never move a vehicle back. It returns how many were stored.
+	SetLatest(ctx context.Context, positions []entity.VehiclePosition) (int, error)
+
+	// GetLatest returns the latest positions of the given vehicles; vehicles without one are omitted
+	GetLatest(ctx context.Context, vehicleIDs []uuid.UUID) ([]entity.VehiclePosition, error)
+
+	// ListLatest returns the latest position of every vehicle that has reported
+	ListLatest(ctx context.Context) ([]entity.VehiclePosition, error)
+}
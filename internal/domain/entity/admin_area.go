@@ -0,0 +1,21 @@
+package entity
+
+// AdminArea represents a Thai subdistrict (tambon/khwaeng) with its parent district, province and postcode
+type AdminArea struct {
+	SubdistrictTH string
+	SubdistrictEN string
+	DistrictTH    string
+	DistrictEN    string
+	ProvinceTH    string
+	ProvinceEN    string
+	Postcode      string
+	Latitude      float64
+	Longitude     float64
+	Unlisted      bool // the dataset does not list the district or subdistrict; they are kept as given, without a centroid
+}
+
+// AdminName represents a Thai administrative name with its English transliteration
+type AdminName struct {
+	TH string
+	EN string
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+
+	"github.com/google/uuid"
+)
+
+// CarrierStatus represents whether a carrier may receive new tenders
+type CarrierStatus string
+
+const (
+	CarrierStatusActive    CarrierStatus = "active"
+	CarrierStatusSuspended CarrierStatus = "suspended"
+)
+
+// Carrier represents a subcontracted transport company (Pure Domain Entity)
+type Carrier struct {
+	ID              uuid.UUID
+	Name            string
+	TaxID           string
+	BranchCode      string
+	ContactName     string
+	ContactPhone    string
+	ContactEmail    string
+	FleetTypes      []VehicleType
+	ServiceAreas    []string // provinces served; empty means nationwide
+	InsuranceExpiry *time.Time
+	Status          CarrierStatus
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       *time.Time
+}
+
+// IsInsured reports whether the carrier's cargo insurance covers the given time.
+// Insurance is valid through the end of its expiry date; a carrier without a recorded policy is not insured.
+func (c *Carrier) IsInsured(at time.Time) bool {
+	if c.InsuranceExpiry == nil {
+		return false
+	}
+	y, m, d := c.InsuranceExpiry.Date()
+	return at.Before(time.Date(y, m, d, 0, 0, 0, 0, c.InsuranceExpiry.Location()).AddDate(0, 0, 1))
+}
+
+// Operates reports whether the carrier runs the vehicle type. An empty type matches any fleet.
+func (c *Carrier) Operates(vehicleType VehicleType) bool {
+	if vehicleType == "" {
+		return true
+	}
+	for _, t := range c.FleetTypes {
+		if t == vehicleType {
+			return true
+		}
+	}
+	return false
+}
+
+// Serves reports whether the carrier covers the province
+func (c *Carrier) Serves(province string) bool {
+	if len(c.ServiceAreas) == 0 {
+		return true
+	}
+	for _, p := range c.ServiceAreas {
+		if strings.EqualFold(p, province) {
+			return true
+		}
+	}
+	return false
+}
+
+// EnsureTenderable returns errs.ErrCarrierIneligible when the carrier cannot be offered work
+// with the given vehicle type between the given provinces at the given time
+func (c *Carrier) EnsureTenderable(at time.Time, vehicleType VehicleType, provinces ...string) error {
+	switch {
+	case c.Status != CarrierStatusActive:
+		return fmt.Errorf("%w: %s is %s", errs.ErrCarrierIneligible, c.Name, c.Status)
+	case !c.IsInsured(at):
+		return fmt.Errorf("%w: %s has no valid insurance", errs.ErrCarrierIneligible, c.Name)
+	case !c.Operates(vehicleType):
+		return fmt.Errorf("%w: %s does not operate %s", errs.ErrCarrierIneligible, c.Name, vehicleType)
+	}
+	for _, p := range provinces {
+		if !c.Serves(p) {
+			return fmt.Errorf("%w: %s does not serve %s", errs.ErrCarrierIneligible, c.Name, p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+
+	"github.com/google/uuid"
+)
+
+// ComplianceOwnerType represents the kind of record a compliance document belongs to
+type ComplianceOwnerType string
+
+const (
+	ComplianceOwnerVehicle ComplianceOwnerType = "vehicle"
+	ComplianceOwnerDriver  ComplianceOwnerType = "driver"
+	ComplianceOwnerCarrier ComplianceOwnerType = "carrier"
+)
+
+// ComplianceDocumentType represents the kind of permit, policy or certificate a document is
+type ComplianceDocumentType string
+
+const (
+	ComplianceVehicleRegistration ComplianceDocumentType = "vehicle_registration"
+	ComplianceCompulsoryInsurance ComplianceDocumentType = "compulsory_insurance" // พ.ร.บ.
+	ComplianceVehicleInsurance    ComplianceDocumentType = "vehicle_insurance"
+	ComplianceVehicleTax          ComplianceDocumentType = "vehicle_tax"
+	ComplianceGPSCertification    ComplianceDocumentType = "gps_certification"
+	ComplianceDriverLicense       ComplianceDocumentType = "driver_license"
+	ComplianceCarrierLicense      ComplianceDocumentType = "carrier_license"
+	ComplianceOther               ComplianceDocumentType = "other" // never supersedes another document
+)
+
+// complianceOwners lists the owner types each document type may be attached to
+var complianceOwners = map[ComplianceDocumentType][]ComplianceOwnerType{
+	ComplianceVehicleRegistration: {ComplianceOwnerVehicle},
+	ComplianceCompulsoryInsurance: {ComplianceOwnerVehicle},
+	ComplianceVehicleInsurance:    {ComplianceOwnerVehicle},
+	ComplianceVehicleTax:          {ComplianceOwnerVehicle},
+	ComplianceGPSCertification:    {ComplianceOwnerVehicle},
+	ComplianceDriverLicense:       {ComplianceOwnerDriver},
+	ComplianceCarrierLicense:      {ComplianceOwnerCarrier},
+	ComplianceOther:               {ComplianceOwnerVehicle, ComplianceOwnerDriver, ComplianceOwnerCarrier},
+}
+
+// AppliesTo reports whether documents of the type may be attached to the owner type
+func (t ComplianceDocumentType) AppliesTo(owner ComplianceOwnerType) bool {
+	return slices.Contains(complianceOwners[t], owner)
+}
+
+// ComplianceStatus represents where a document stands against its expiry date
+type ComplianceStatus string
+
+const (
+	ComplianceStatusValid    ComplianceStatus = "valid"
+	ComplianceStatusExpiring ComplianceStatus = "expiring" // within the earliest reminder before expiry
+	ComplianceStatusExpired  ComplianceStatus = "expired"
+)
+
+// ComplianceOwner identifies the vehicle, driver or carrier a document belongs to
+type ComplianceOwner struct {
+	Type ComplianceOwnerType
+	ID   uuid.UUID
+}
+
+// ComplianceDocument is a permit, policy or certificate of a vehicle, driver or carrier that expires.
+// It is valid through the end of its expiry date, a Thai calendar day. Only the document of a type expiring
+// last counts for an owner, so renewing a document supersedes the earlier ones.
+type ComplianceDocument struct {
+	ID           uuid.UUID
+	OwnerType    ComplianceOwnerType
+	OwnerID      uuid.UUID
+	Type         ComplianceDocumentType
+	Number       string
+	IssuedOn     *time.Time
+	ExpiresOn    time.Time
+	FileKey      string
+	Notes        string
+	RemindedDays *int // days-before-expiry stage of the last reminder; zero once the expiry was announced
+	RemindedAt   *time.Time
+	CreatedBy    *uuid.UUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Owner returns the vehicle, driver or carrier the document belongs to
+func (d *ComplianceDocument) Owner() ComplianceOwner {
+	return ComplianceOwner{Type: d.OwnerType, ID: d.OwnerID}
+}
+
+// DaysLeft returns the number of days from the given calendar day until the document expires;
+// zero on the expiry date and negative once expired
+func (d *ComplianceDocument) DaysLeft(today time.Time) int {
+	y, m, day := d.ExpiresOn.Date()
+	expires := time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+	y, m, day = today.Date()
+	return int(expires.Sub(time.Date(y, m, day, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+}
+
+// Expired reports whether the document has expired by the given calendar day
+func (d *ComplianceDocument) Expired(today time.Time) bool {
+	return d.DaysLeft(today) < 0
+}
+
+// Status returns where the document stands on the given calendar day; it is expiring within warnDays of expiry
+func (d *ComplianceDocument) Status(today time.Time, warnDays int) ComplianceStatus {
+	switch left := d.DaysLeft(today); {
+	case left < 0:
+		return ComplianceStatusExpired
+	case left <= warnDays:
+		return ComplianceStatusExpiring
+	default:
+		return ComplianceStatusValid
+	}
+}
+
+// ReminderDue works out the reminder stage the document is at on the given calendar day: the smallest of the
+// thresholds, in days before expiry, it is within, or zero once expired. It reports whether the stage has not
+// been reminded of yet; a document that skipped stages, e.g. one recorded close to expiry, is reminded once.
+func (d *ComplianceDocument) ReminderDue(today time.Time, thresholds []int) (int, bool) {
+	left := d.DaysLeft(today)
+	stage := -1
+	if left < 0 {
+		stage = 0
+	} else {
+		for _, t := range thresholds {
+			if t >= left && (stage < 0 || t < stage) {
+				stage = t
+			}
+		}
+	}
+	if stage < 0 {
+		return 0, false
+	}
+	return stage, d.RemindedDays == nil || stage < *d.RemindedDays
+}
+
+// Reminded records that the reminder of a stage was sent
+func (d *ComplianceDocument) Reminded(stage int, at time.Time) {
+	d.RemindedDays = &stage
+	d.RemindedAt = &at
+}
+
+// Reschedule sets a new expiry date; the reminders start over when it changes
+func (d *ComplianceDocument) Reschedule(expiresOn time.Time) {
+	if !expiresOn.Equal(d.ExpiresOn) {
+		d.RemindedDays = nil
+		d.RemindedAt = nil
+	}
+	d.ExpiresOn = expiresOn
+}
+
+// ComplianceExpiredError returns errs.ErrComplianceExpired naming the first of the expired documents, or nil
+func ComplianceExpiredError(docs []*ComplianceDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	d := docs[0]
+	return fmt.Errorf("%w: %s of %s %s expired on %s", errs.ErrComplianceExpired,
+		d.Type, d.OwnerType, d.OwnerID, d.ExpiresOn.Format("2006-01-02"))
+}
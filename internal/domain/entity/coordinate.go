@@ -0,0 +1,7 @@
+package entity
+
+// Coordinate is a WGS84 position in decimal degrees
+type Coordinate struct {
+	Latitude  float64
+	Longitude float64
+}
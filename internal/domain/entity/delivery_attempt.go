@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FailureReason represents why a delivery could not be made
+type FailureReason string
+
+const (
+	FailureNobodyHome   FailureReason = "nobody_home"
+	FailureWrongAddress FailureReason = "wrong_address"
+	FailureRefused      FailureReason = "refused"
+	FailureClosed       FailureReason = "closed"
+)
+
+// RequiresPresence reports whether the driver must have been at the delivery location to give the reason.
+// A wrong address is found away from the pinned location.
+func (r FailureReason) RequiresPresence() bool {
+	return r != FailureWrongAddress
+}
+
+// DeliveryAttempt records a failed delivery at a delivery stop and the leg booked to follow it up
+// (Pure Domain Entity). The evidence photo is a file in object storage referenced by key.
+type DeliveryAttempt struct {
+	ID          uuid.UUID
+	TripID      uuid.UUID
+	StopID      uuid.UUID
+	ShipmentID  uuid.UUID
+	DriverID    uuid.UUID
+	Attempt     int // the shipment's attempt number
+	Reason      FailureReason
+	PhotoKey    string
+	Latitude    float64
+	Longitude   float64
+	AccuracyM   *float64 // reported by the device
+	DistanceM   *float64 // from the delivery location; nil when the location has no coordinates
+	AttemptedAt time.Time
+	Notes       string
+	LegID       uuid.UUID // the reattempt or return leg
+	Leg         ShipmentLeg
+	CreatedAt   time.Time
+}
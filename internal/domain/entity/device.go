@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// MaxClockSkew is how far in the future a time reported by a driver's or staff member's device may be
+const MaxClockSkew = 5 * time.Minute
+
+// InFuture reports whether a time reported by a device lies further ahead of now than clocks may drift
+func InFuture(t, now time.Time) bool {
+	return t.After(now.Add(MaxClockSkew))
+}
@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInFuture(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	for at, want := range map[time.Time]bool{
+		now.Add(-time.Hour):                 false,
+		now.Add(MaxClockSkew):               false,
+		now.Add(MaxClockSkew + time.Second): true,
+	} {
+		if got := InFuture(at, now); got != want {
+			t.Errorf("InFuture(now%+v) = %v, want %v", at.Sub(now), got, want)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+
+	"github.com/google/uuid"
+)
+
+// DockDirection represents whether trucks at a dock are unloaded, loaded or both
+type DockDirection string
+
+const (
+	DockInbound  DockDirection = "inbound"  // unloading
+	DockOutbound DockDirection = "outbound" // loading
+	DockBoth     DockDirection = "both"     // docks only; an appointment is either inbound or outbound
+)
+
+// Dock is a loading bay at a site, bookable in slots during its operating hours (Pure Domain Entity)
+type Dock struct {
+	ID             uuid.UUID
+	LocationID     uuid.UUID // the site
+	Name           string
+	Code           string
+	Direction      DockDirection
+	Capacity       int            // trucks served at the same time
+	SlotMinutes    int            // appointments start on and last whole slots counted from the opening time
+	OperatingHours []OpeningHours // local time; a weekday without hours is closed
+	VehicleTypes   []VehicleType  // vehicle types the dock can take; empty means any
+	Active         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Handles reports whether the dock serves appointments of the direction
+func (d *Dock) Handles(direction DockDirection) bool {
+	return d.Direction == DockBoth || d.Direction == direction
+}
+
+// Accepts reports whether the dock can take the vehicle type. An empty type matches any dock.
+func (d *Dock) Accepts(vehicleType VehicleType) bool {
+	return vehicleType == "" || len(d.VehicleTypes) == 0 || slices.Contains(d.VehicleTypes, vehicleType)
+}
+
+// DockSlot is one bookable period of a dock
+type DockSlot struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Slots returns the dock's slots on a calendar day, read in the given zone
+func (d *Dock) Slots(day time.Time, zone *time.Location) []DockSlot {
+	y, m, dd := day.Date()
+	midnight := time.Date(y, m, dd, 0, 0, 0, 0, zone)
+	slot := time.Duration(d.SlotMinutes) * time.Minute
+
+	var slots []DockSlot
+	for _, h := range d.OperatingHours {
+		if h.Weekday != midnight.Weekday() {
+			continue
+		}
+		opens, closes, ok := h.period(midnight)
+		if !ok {
+			continue
+		}
+		for start := opens; !start.Add(slot).After(closes); start = start.Add(slot) {
+			slots = append(slots, DockSlot{Start: start, End: start.Add(slot)})
+		}
+	}
+	slices.SortFunc(slots, func(a, b DockSlot) int { return a.Start.Compare(b.Start) })
+	return slots
+}
+
+// FitsSchedule reports whether a booking from start to end covers whole slots within one period of operating hours
+func (d *Dock) FitsSchedule(start, end time.Time, zone *time.Location) bool {
+	if !end.After(start) || d.SlotMinutes <= 0 {
+		return false
+	}
+	local := start.In(zone)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, zone)
+	slot := time.Duration(d.SlotMinutes) * time.Minute
+
+	for _, h := range d.OperatingHours {
+		if h.Weekday != midnight.Weekday() {
+			continue
+		}
+		opens, closes, ok := h.period(midnight)
+		if !ok || start.Before(opens) || end.After(closes) {
+			continue
+		}
+		if start.Sub(opens)%slot == 0 && end.Sub(start)%slot == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValid reports whether the hours are readable times, closing after opening on the same day
+func (h OpeningHours) IsValid() bool {
+	_, _, ok := h.period(time.Time{})
+	return ok
+}
+
+// period returns the opening and closing times on the day starting at midnight; closed when they are unreadable
+func (h OpeningHours) period(midnight time.Time) (time.Time, time.Time, bool) {
+	opens, err := time.Parse("15:04", h.Open)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	closes, err := time.Parse("15:04", h.Close)
+	if err != nil || !closes.After(opens) {
+		return time.Time{}, time.Time{}, false
+	}
+	at := func(t time.Time) time.Time {
+		return midnight.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+	}
+	return at(opens), at(closes), true
+}
+
+// DockAppointmentStatus represents the lifecycle status of a dock appointment
+type DockAppointmentStatus string
+
+const (
+	DockAppointmentBooked    DockAppointmentStatus = "booked"
+	DockAppointmentCheckedIn DockAppointmentStatus = "checked_in" // the truck is at the site
+	DockAppointmentCompleted DockAppointmentStatus = "completed"  // the truck checked out
+	DockAppointmentCancelled DockAppointmentStatus = "cancelled"
+	DockAppointmentNoShow    DockAppointmentStatus = "no_show"
+)
+
+// DockAppointment books a dock for a truck to be loaded or unloaded (Pure Domain Entity)
+type DockAppointment struct {
+	ID           uuid.UUID
+	DockID       uuid.UUID
+	LocationID   uuid.UUID
+	Direction    DockDirection
+	TripID       *uuid.UUID
+	CarrierID    *uuid.UUID // set when booked by a carrier
+	VehicleType  VehicleType
+	PlateNumber  string
+	DriverName   string
+	Reference    string
+	StartAt      time.Time
+	EndAt        time.Time
+	Status       DockAppointmentStatus
+	CheckedInAt  *time.Time
+	CheckedOutAt *time.Time
+	CancelReason string
+	Notes        string
+	CreatedBy    *uuid.UUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Occupies reports whether the appointment still holds its slots
+func (a *DockAppointment) Occupies() bool {
+	return a.Status == DockAppointmentBooked || a.Status == DockAppointmentCheckedIn
+}
+
+// Overlaps reports whether the appointment's booking overlaps the period [start, end)
+func (a *DockAppointment) Overlaps(start, end time.Time) bool {
+	return a.StartAt.Before(end) && start.Before(a.EndAt)
+}
+
+// IsEditable reports whether the appointment may still be moved
+func (a *DockAppointment) IsEditable() bool {
+	return a.Status == DockAppointmentBooked
+}
+
+// CheckIn records the truck's arrival at the site
+func (a *DockAppointment) CheckIn(at time.Time) error {
+	if a.Status != DockAppointmentBooked {
+		return errs.ErrInvalidStatusTransition
+	}
+	a.Status = DockAppointmentCheckedIn
+	a.CheckedInAt = &at
+	return nil
+}
+
+// CheckOut records the truck leaving the site
+func (a *DockAppointment) CheckOut(at time.Time) error {
+	if a.Status != DockAppointmentCheckedIn {
+		return errs.ErrInvalidStatusTransition
+	}
+	if at.Before(*a.CheckedInAt) {
+		at = *a.CheckedInAt
+	}
+	a.Status = DockAppointmentCompleted
+	a.CheckedOutAt = &at
+	return nil
+}
+
+// Cancel releases the appointment's slots
+func (a *DockAppointment) Cancel(reason string) error {
+	if a.Status != DockAppointmentBooked {
+		return errs.ErrInvalidStatusTransition
+	}
+	a.Status = DockAppointmentCancelled
+	a.CancelReason = reason
+	return nil
+}
+
+// MarkNoShow releases the slots of an appointment the truck never came to
+func (a *DockAppointment) MarkNoShow() error {
+	if a.Status != DockAppointmentBooked {
+		return errs.ErrInvalidStatusTransition
+	}
+	a.Status = DockAppointmentNoShow
+	return nil
+}
+
+// Turnaround returns how long the truck spent at the site, once it has checked out
+func (a *DockAppointment) Turnaround() *time.Duration {
+	if a.CheckedInAt == nil || a.CheckedOutAt == nil {
+		return nil
+	}
+	d := a.CheckedOutAt.Sub(*a.CheckedInAt)
+	return &d
+}
+
+// PeakOccupancy returns the most appointments holding the dock at the same time during [start, end)
+func PeakOccupancy(appointments []*DockAppointment, start, end time.Time) int {
+	peak := 0
+	// Occupancy only rises where an appointment or the period starts
+	points := []time.Time{start}
+	for _, a := range appointments {
+		if a.StartAt.After(start) && a.StartAt.Before(end) {
+			points = append(points, a.StartAt)
+		}
+	}
+	for _, p := range points {
+		n := 0
+		for _, a := range appointments {
+			if a.Occupies() && !p.Before(a.StartAt) && p.Before(a.EndAt) {
+				n++
+			}
+		}
+		peak = max(peak, n)
+	}
+	return peak
+}
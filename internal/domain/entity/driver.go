@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+// DriverStatus represents the working status of a driver
+type DriverStatus string
+
+const (
+	DriverStatusAvailable DriverStatus = "available"
+	DriverStatusOnDuty    DriverStatus = "on_duty"
+	DriverStatusOffDuty   DriverStatus = "off_duty"
+	DriverStatusOnLeave   DriverStatus = "on_leave"
+)
+
+// EmploymentType represents how a driver is employed
+type EmploymentType string
+
+const (
+	EmploymentTypeEmployee   EmploymentType = "employee"
+	EmploymentTypeContractor EmploymentType = "contractor"
+)
+
+// Driver represents a driver profile linked 1:1 to a user account (Pure Domain Entity)
+type Driver struct {
+	ID                    uuid.UUID
+	UserID                uuid.UUID
+	LicenseNumber         string
+	LicenseClass          string
+	LicenseExpiry         time.Time
+	EmploymentType        EmploymentType
+	HomeDepot             string
+	EmergencyContactName  string
+	EmergencyContactPhone string
+	Status                DriverStatus
+	User                  *User
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	DeletedAt             *time.Time
+}
+
+// IsLicenseValid reports whether the driver's license is still valid at the given time.
+// A license is valid through the end of its expiry date in Thailand.
+func (d *Driver) IsLicenseValid(at time.Time) bool {
+	return at.Before(timeutil.StartOfDay(d.LicenseExpiry).AddDate(0, 0, 1))
+}
+
+// EnsureAssignable returns a domain error when the driver cannot be assigned to work starting at the given time
+func (d *Driver) EnsureAssignable(at time.Time) error {
+	if !d.IsLicenseValid(at) {
+		return errs.ErrDriverLicenseExpired
+	}
+	if d.Status == DriverStatusOnLeave || d.Status == DriverStatusOffDuty {
+		return errs.ErrDriverUnavailable
+	}
+	return nil
+}
@@ -0,0 +1,306 @@
+package entity
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// nightStart and nightEnd bound the night on a trip away overnight; the driver rests, paid by the overnight allowance
+	nightStart = 22 * time.Hour
+	nightEnd   = 6 * time.Hour
+
+	// restBreak is the rest due in a working day after restAfter of work (Labour Protection Act, section 27)
+	restAfter = 5 * time.Hour
+	restBreak = time.Hour
+)
+
+// DriverPayRule sets what a driver earns for a trip from a date on, optionally for one vehicle type
+type DriverPayRule struct {
+	ID                 uuid.UUID
+	Name               string
+	VehicleType        VehicleType // empty matches any vehicle type
+	BasePerTrip        Money
+	PerKm              Money
+	PerDrop            Money   // per delivery stop
+	OvernightAllowance Money   // per night away
+	OvertimeRate       Money   // per hour worked beyond the standard hours
+	StandardHours      float64 // hours of work a day, across all trips, covered by the trip pay
+	EffectiveFrom      time.Time
+	EffectiveTo        *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// IsEffective reports whether the rule applies at the given time
+func (r *DriverPayRule) IsEffective(at time.Time) bool {
+	return !at.Before(r.EffectiveFrom) && (r.EffectiveTo == nil || at.Before(*r.EffectiveTo))
+}
+
+// SettlementStatus represents the lifecycle status of a driver settlement
+type SettlementStatus string
+
+const (
+	SettlementStatusDraft    SettlementStatus = "draft"    // being checked; has no number yet
+	SettlementStatusApproved SettlementStatus = "approved" // numbered and ready for payroll
+	SettlementStatusVoid     SettlementStatus = "void"
+)
+
+// AdjustmentKind distinguishes what an adjustment takes off a driver's pay
+type AdjustmentKind string
+
+const (
+	AdjustmentAdvance   AdjustmentKind = "advance"   // cash paid to the driver ahead of the settlement
+	AdjustmentDeduction AdjustmentKind = "deduction" // e.g. damage, fines or a shortfall
+)
+
+// DriverSettlement states a driver's pay for the completed trips of a period, less advances and deductions.
+// Trip pay is worked out when the settlement is drafted; NetPay = GrossPay - Advances - Deductions.
+type DriverSettlement struct {
+	ID          uuid.UUID
+	Number      string // issued in sequence when the settlement is approved
+	DriverID    uuid.UUID
+	Status      SettlementStatus
+	PeriodFrom  time.Time // first day of the period
+	PeriodTo    time.Time // last day of the period
+	Lines       []SettlementLine
+	Adjustments []SettlementAdjustment
+	GrossPay    Money
+	Advances    Money
+	Deductions  Money
+	NetPay      Money
+	Notes       string
+	ApprovedAt  *time.Time
+	ApprovedBy  *uuid.UUID
+	VoidedAt    *time.Time
+	VoidReason  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// SettlementLine is the pay for one trip, broken down by the components of its pay rule
+type SettlementLine struct {
+	ID            uuid.UUID
+	SettlementID  uuid.UUID
+	Sequence      int
+	TripID        uuid.UUID
+	TripNumber    string
+	CoDriver      bool
+	VehicleType   VehicleType
+	PayRuleID     uuid.UUID
+	StartedAt     time.Time
+	EndedAt       time.Time
+	DistanceKm    float64
+	Drops         int
+	Overnights    int
+	OvertimeHours float64
+	BasePay       Money
+	DistancePay   Money
+	DropPay       Money
+	OvernightPay  Money
+	OvertimePay   Money
+	Amount        Money
+}
+
+// SettlementAdjustment is an advance or deduction taken off the pay of a settlement
+type SettlementAdjustment struct {
+	ID           uuid.UUID
+	SettlementID uuid.UUID
+	Kind         AdjustmentKind
+	Description  string
+	Amount       Money // positive; it reduces the net pay
+	CreatedAt    time.Time
+}
+
+// AddTrip pays a trip by the rule. The line carries the trip's start and end, distance and drops;
+// nights away are the calendar days the trip spans in loc. Overtime depends on the driver's other trips
+// of the day, so it is left to PayOvertime once all trips are added.
+func (s *DriverSettlement) AddTrip(line SettlementLine, rule *DriverPayRule, loc *time.Location) {
+	y1, m1, d1 := line.StartedAt.In(loc).Date()
+	y2, m2, d2 := line.EndedAt.In(loc).Date()
+	days := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC).Sub(time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC))
+	line.Overnights = max(int(days.Hours()/24), 0)
+
+	line.SettlementID = s.ID
+	line.Sequence = len(s.Lines) + 1
+	line.PayRuleID = rule.ID
+	line.BasePay = rule.BasePerTrip
+	line.DistancePay = rule.PerKm.Mul(line.DistanceKm)
+	line.DropPay = rule.PerDrop.Mul(float64(line.Drops))
+	line.OvernightPay = rule.OvernightAllowance.Mul(float64(line.Overnights))
+	line.OvertimeHours = 0
+	line.OvertimePay = 0
+	line.total()
+	s.Lines = append(s.Lines, line)
+	s.calculate()
+}
+
+// PayOvertime works out the overtime on the settlement's trips, whose pay rules are given by ID.
+// Hours are counted per calendar day in loc across all the trips, once where trips overlap; the nights
+// of trips away overnight and the rest break due each day do not count. The hours worked beyond the
+// standard hours of a day are paid at the overtime rate of the trip they were worked on.
+func (s *DriverSettlement) PayOvertime(rules map[uuid.UUID]*DriverPayRule, loc *time.Location) {
+	type piece struct {
+		line     int
+		from, to time.Time
+	}
+	days := make(map[time.Time][]piece)
+	for i, l := range s.Lines {
+		for _, p := range workPeriods(l.StartedAt, l.EndedAt, l.Overnights > 0, loc) {
+			days[p.day] = append(days[p.day], piece{line: i, from: p.from, to: p.to})
+		}
+	}
+
+	overtime := make([]time.Duration, len(s.Lines))
+	for _, pieces := range days {
+		sort.Slice(pieces, func(i, j int) bool { return pieces[i].from.Before(pieces[j].from) })
+		var worked time.Duration
+		var counted time.Time // hours before this were counted on an earlier trip
+		for _, p := range pieces {
+			from := p.from
+			if from.Before(counted) {
+				from = counted
+			}
+			if !p.to.After(from) {
+				continue
+			}
+			d := p.to.Sub(from)
+			if rule := rules[s.Lines[p.line].PayRuleID]; rule != nil && rule.StandardHours > 0 {
+				threshold := rule.standardTime()
+				overtime[p.line] += max(worked+d-threshold, 0) - max(worked-threshold, 0)
+			}
+			worked += d
+			counted = p.to
+		}
+	}
+
+	for i := range s.Lines {
+		l := &s.Lines[i]
+		l.OvertimeHours = math.Round(overtime[i].Hours()*100) / 100
+		l.OvertimePay = 0
+		if rule := rules[l.PayRuleID]; rule != nil {
+			l.OvertimePay = rule.OvertimeRate.Mul(l.OvertimeHours)
+		}
+		l.total()
+	}
+	s.calculate()
+}
+
+// standardTime is how long after starting work in a day overtime begins: the standard hours plus the
+// rest break due within them
+func (r *DriverPayRule) standardTime() time.Duration {
+	standard := time.Duration(r.StandardHours * float64(time.Hour))
+	if standard > restAfter {
+		standard += restBreak
+	}
+	return standard
+}
+
+// workPeriod is the part of a trip worked on one calendar day, given as a date at midnight UTC
+type workPeriod struct {
+	day      time.Time
+	from, to time.Time
+}
+
+// workPeriods splits the time on a trip by calendar day in loc; on a trip away overnight, the nights are rest
+func workPeriods(start, end time.Time, away bool, loc *time.Location) []workPeriod {
+	var periods []workPeriod
+	for cursor := start; cursor.Before(end); {
+		y, m, d := cursor.In(loc).Date()
+		midnight := time.Date(y, m, d, 0, 0, 0, 0, loc)
+		next := midnight.AddDate(0, 0, 1)
+
+		from, to := cursor, end
+		if next.Before(to) {
+			to = next
+		}
+		if away {
+			if morning := midnight.Add(nightEnd); from.Before(morning) {
+				from = morning
+			}
+			if evening := midnight.Add(nightStart); to.After(evening) {
+				to = evening
+			}
+		}
+		if to.After(from) {
+			periods = append(periods, workPeriod{day: time.Date(y, m, d, 0, 0, 0, 0, time.UTC), from: from, to: to})
+		}
+		cursor = next
+	}
+	return periods
+}
+
+// AddAdjustment takes an advance or deduction off a draft settlement
+func (s *DriverSettlement) AddAdjustment(adjustment SettlementAdjustment) error {
+	if s.Status != SettlementStatusDraft {
+		return errs.ErrResourceLocked
+	}
+	adjustment.SettlementID = s.ID
+	s.Adjustments = append(s.Adjustments, adjustment)
+	s.calculate()
+	return nil
+}
+
+// RemoveAdjustment drops an adjustment from a draft settlement
+func (s *DriverSettlement) RemoveAdjustment(id uuid.UUID) error {
+	if s.Status != SettlementStatusDraft {
+		return errs.ErrResourceLocked
+	}
+	for i, a := range s.Adjustments {
+		if a.ID == id {
+			s.Adjustments = append(s.Adjustments[:i], s.Adjustments[i+1:]...)
+			s.calculate()
+			return nil
+		}
+	}
+	return errs.ErrNotFound
+}
+
+// Approve numbers a draft settlement and releases it to payroll
+func (s *DriverSettlement) Approve(number string, by uuid.UUID, at time.Time) error {
+	if s.Status != SettlementStatusDraft {
+		return errs.ErrInvalidStatusTransition
+	}
+	s.Number = number
+	s.Status = SettlementStatusApproved
+	s.ApprovedAt = &at
+	s.ApprovedBy = &by
+	return nil
+}
+
+// Void cancels a settlement so its trips can be settled again. An approved settlement keeps its number.
+func (s *DriverSettlement) Void(reason string, at time.Time) error {
+	if s.Status == SettlementStatusVoid {
+		return errs.ErrInvalidStatusTransition
+	}
+	s.Status = SettlementStatusVoid
+	s.VoidedAt = &at
+	s.VoidReason = reason
+	return nil
+}
+
+// total sums the pay components of the line
+func (l *SettlementLine) total() {
+	l.Amount = l.BasePay + l.DistancePay + l.DropPay + l.OvernightPay + l.OvertimePay
+}
+
+// calculate derives the gross pay, adjustments and net pay from the lines and adjustments
+func (s *DriverSettlement) calculate() {
+	s.GrossPay, s.Advances, s.Deductions = 0, 0, 0
+	for _, l := range s.Lines {
+		s.GrossPay += l.Amount
+	}
+	for _, a := range s.Adjustments {
+		if a.Kind == AdjustmentAdvance {
+			s.Advances += a.Amount
+		} else {
+			s.Deductions += a.Amount
+		}
+	}
+	s.NetPay = s.GrossPay - s.Advances - s.Deductions
+}
@@ -0,0 +1,146 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+
+	"github.com/google/uuid"
+)
+
+var bangkok = time.FixedZone("ICT", 7*60*60)
+
+func at(day, hour, minute int) time.Time {
+	return time.Date(2026, 10, day, hour, minute, 0, 0, bangkok)
+}
+
+func testPayRule() *DriverPayRule {
+	return &DriverPayRule{
+		ID:                 uuid.New(),
+		BasePerTrip:        40000,
+		PerKm:              150,
+		PerDrop:            5000,
+		OvernightAllowance: 30000,
+		OvertimeRate:       7500,
+		StandardHours:      8,
+	}
+}
+
+func settle(rule *DriverPayRule, trips ...[2]time.Time) *DriverSettlement {
+	s := &DriverSettlement{Status: SettlementStatusDraft}
+	for _, t := range trips {
+		s.AddTrip(SettlementLine{StartedAt: t[0], EndedAt: t[1]}, rule, bangkok)
+	}
+	s.PayOvertime(map[uuid.UUID]*DriverPayRule{rule.ID: rule}, bangkok)
+	return s
+}
+
+func TestDriverSettlementAddTrip(t *testing.T) {
+	rule := testPayRule()
+	s := &DriverSettlement{Status: SettlementStatusDraft}
+	s.AddTrip(SettlementLine{StartedAt: at(20, 8, 0), EndedAt: at(20, 15, 0), DistanceKm: 212.4, Drops: 3}, rule, bangkok)
+
+	l := s.Lines[0]
+	if l.Sequence != 1 || l.PayRuleID != rule.ID {
+		t.Errorf("sequence %d, rule %s; want 1 and the rule's ID", l.Sequence, l.PayRuleID)
+	}
+	// 400 + 212.4 km × 1.50 + 3 drops × 50
+	if l.BasePay != 40000 || l.DistancePay != 31860 || l.DropPay != 15000 || l.Overnights != 0 || l.Amount != 86860 {
+		t.Errorf("line = %+v, want 400 + 318.60 + 150 = 868.60 and no nights away", l)
+	}
+	if s.GrossPay != 86860 || s.NetPay != 86860 {
+		t.Errorf("gross %s, net %s; want 868.60", s.GrossPay, s.NetPay)
+	}
+}
+
+func TestDriverSettlementOvertimeAcrossTrips(t *testing.T) {
+	// Two trips of 5 hours on the same day: 10 hours worked, 8 standard plus the hour's rest break
+	s := settle(testPayRule(),
+		[2]time.Time{at(20, 6, 0), at(20, 11, 0)},
+		[2]time.Time{at(20, 13, 0), at(20, 18, 0)},
+	)
+	if s.Lines[0].OvertimeHours != 0 {
+		t.Errorf("first trip overtime = %vh, want none", s.Lines[0].OvertimeHours)
+	}
+	if l := s.Lines[1]; l.OvertimeHours != 1 || l.OvertimePay != 7500 || l.Amount != 47500 {
+		t.Errorf("second trip overtime = %vh paid %s (amount %s), want 1h paid 75 on top of 400", l.OvertimeHours, l.OvertimePay, l.Amount)
+	}
+	if s.GrossPay != 87500 {
+		t.Errorf("gross = %s, want 875.00", s.GrossPay)
+	}
+}
+
+func TestDriverSettlementOvertimeCountsOverlapOnce(t *testing.T) {
+	// 07:00-19:00 with a trip inside another: 12 hours worked, not 16
+	s := settle(testPayRule(),
+		[2]time.Time{at(20, 7, 0), at(20, 19, 0)},
+		[2]time.Time{at(20, 9, 0), at(20, 13, 0)},
+	)
+	if got := s.Lines[0].OvertimeHours + s.Lines[1].OvertimeHours; got != 3 {
+		t.Errorf("overtime = %vh, want 3h", got)
+	}
+	if s.Lines[1].OvertimeHours != 0 {
+		t.Errorf("the overlapping trip has %vh overtime, want none", s.Lines[1].OvertimeHours)
+	}
+}
+
+func TestDriverSettlementOvertimeExcludesNightsAway(t *testing.T) {
+	// Away from 08:00 on the 20th to 18:00 on the 21st: the night from 22:00 to 06:00 is rest.
+	// The 20th has 14 hours of work and the 21st 12, each with 9 hours before overtime.
+	s := settle(testPayRule(), [2]time.Time{at(20, 8, 0), at(21, 18, 0)})
+	l := s.Lines[0]
+	if l.Overnights != 1 || l.OvernightPay != 30000 {
+		t.Errorf("overnights = %d paid %s, want 1 paid 300", l.Overnights, l.OvernightPay)
+	}
+	if l.OvertimeHours != 8 || l.OvertimePay != 60000 {
+		t.Errorf("overtime = %vh paid %s, want 5h + 3h = 8h paid 600", l.OvertimeHours, l.OvertimePay)
+	}
+}
+
+func TestDriverSettlementOvertimePastMidnight(t *testing.T) {
+	// A trip running past midnight is away overnight, so only 16:00-22:00 on the 20th is work
+	s := settle(testPayRule(),
+		[2]time.Time{at(20, 16, 0), at(21, 4, 0)},
+		[2]time.Time{at(21, 8, 0), at(21, 12, 0)},
+	)
+	l := s.Lines[0]
+	if l.Overnights != 1 {
+		t.Fatalf("overnights = %d, want 1", l.Overnights)
+	}
+	if l.OvertimeHours != 0 || s.Lines[1].OvertimeHours != 0 {
+		t.Errorf("overtime = %vh, want none", l.OvertimeHours)
+	}
+}
+
+func TestDriverSettlementAdjustments(t *testing.T) {
+	s := settle(testPayRule(), [2]time.Time{at(20, 8, 0), at(20, 12, 0)})
+	if err := s.AddAdjustment(SettlementAdjustment{ID: uuid.New(), Kind: AdjustmentAdvance, Amount: Baht(150.004)}); err != nil {
+		t.Fatalf("AddAdjustment: %v", err)
+	}
+	deduction := SettlementAdjustment{ID: uuid.New(), Kind: AdjustmentDeduction, Amount: Baht(49.5)}
+	if err := s.AddAdjustment(deduction); err != nil {
+		t.Fatalf("AddAdjustment: %v", err)
+	}
+	if s.Advances != 15000 || s.Deductions != 4950 || s.NetPay != 20050 {
+		t.Errorf("advances %s, deductions %s, net %s; want 150, 49.50 and 200.50", s.Advances, s.Deductions, s.NetPay)
+	}
+
+	if err := s.RemoveAdjustment(deduction.ID); err != nil || s.NetPay != 25000 {
+		t.Errorf("RemoveAdjustment: err = %v, net %s; want 250", err, s.NetPay)
+	}
+	if err := s.RemoveAdjustment(uuid.New()); !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("removing an unknown adjustment: err = %v, want ErrNotFound", err)
+	}
+
+	if err := s.Approve("STL2610-0001", uuid.New(), at(31, 17, 0)); err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if err := s.AddAdjustment(deduction); !errors.Is(err, errs.ErrResourceLocked) {
+		t.Errorf("adjusting an approved settlement: err = %v, want ErrResourceLocked", err)
+	}
+	if err := s.Approve("STL2610-0002", uuid.New(), at(31, 17, 0)); !errors.Is(err, errs.ErrInvalidStatusTransition) {
+		t.Errorf("approving twice: err = %v, want ErrInvalidStatusTransition", err)
+	}
+}
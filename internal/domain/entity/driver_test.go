@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+)
+
+func TestIsLicenseValid(t *testing.T) {
+	// a DATE column comes back as midnight UTC
+	d := &Driver{LicenseExpiry: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)}
+	for _, tc := range []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2026, 10, 18, 23, 59, 0, 0, bangkok), true},
+		{time.Date(2026, 10, 19, 0, 0, 0, 0, bangkok), false},
+		{time.Date(2026, 10, 18, 16, 59, 0, 0, time.UTC), true},
+		{time.Date(2026, 10, 18, 17, 0, 0, 0, time.UTC), false},
+		{time.Date(2026, 10, 19, 6, 59, 0, 0, bangkok), false},
+	} {
+		if got := d.IsLicenseValid(tc.at); got != tc.want {
+			t.Errorf("IsLicenseValid(%s) = %v, want %v", tc.at, got, tc.want)
+		}
+	}
+}
+
+func TestEnsureAssignable(t *testing.T) {
+	at := time.Date(2026, 10, 18, 8, 0, 0, 0, bangkok)
+	valid := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		driver Driver
+		want   error
+	}{
+		{Driver{LicenseExpiry: valid, Status: DriverStatusAvailable}, nil},
+		{Driver{LicenseExpiry: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), Status: DriverStatusAvailable}, errs.ErrDriverLicenseExpired},
+		{Driver{LicenseExpiry: valid, Status: DriverStatusOnLeave}, errs.ErrDriverUnavailable},
+		{Driver{LicenseExpiry: valid, Status: DriverStatusOffDuty}, errs.ErrDriverUnavailable},
+	} {
+		if err := tc.driver.EnsureAssignable(at); !errors.Is(err, tc.want) {
+			t.Errorf("EnsureAssignable(%s, %s) = %v, want %v", tc.driver.LicenseExpiry.Format(time.DateOnly), tc.driver.Status, err, tc.want)
+		}
+	}
+}
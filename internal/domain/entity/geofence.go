@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GeofenceShape represents how a geofence boundary is described
+type GeofenceShape string
+
+const (
+	GeofenceShapeCircle  GeofenceShape = "circle"
+	GeofenceShapePolygon GeofenceShape = "polygon"
+)
+
+// metersPerDegree is the length of a degree of latitude, rounded down so that bounds err on the large side
+const metersPerDegree = 110000.0
+
+// GeofenceEventType represents what a vehicle did at a geofence
+type GeofenceEventType string
+
+const (
+	GeofenceEventEnter GeofenceEventType = "enter"
+	GeofenceEventDwell GeofenceEventType = "dwell" // stayed inside for the dwell time; counts as arrival
+	GeofenceEventExit  GeofenceEventType = "exit"
+)
+
+// Geofence represents an area around a location used to detect arrivals and departures (Pure Domain Entity)
+type Geofence struct {
+	ID          uuid.UUID
+	LocationID  uuid.UUID
+	Name        string
+	Shape       GeofenceShape
+	Center      Coordinate   // circle only
+	RadiusM     float64      // circle only
+	Polygon     []Coordinate // polygon only; the ring is closed implicitly
+	DwellTime   time.Duration
+	ExitBufferM float64 // how far outside the boundary a vehicle must be before it has left
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Bounds returns a latitude/longitude box around the geofence and its exit buffer.
+// A point outside the box is outside the geofence and beyond the exit buffer.
+func (g *Geofence) Bounds() (min, max Coordinate) {
+	margin := g.ExitBufferM
+	switch g.Shape {
+	case GeofenceShapeCircle:
+		min, max = g.Center, g.Center
+		margin += g.RadiusM
+	case GeofenceShapePolygon:
+		if len(g.Polygon) == 0 {
+			return Coordinate{}, Coordinate{}
+		}
+		min, max = g.Polygon[0], g.Polygon[0]
+		for _, c := range g.Polygon[1:] {
+			min.Latitude, max.Latitude = math.Min(min.Latitude, c.Latitude), math.Max(max.Latitude, c.Latitude)
+			min.Longitude, max.Longitude = math.Min(min.Longitude, c.Longitude), math.Max(max.Longitude, c.Longitude)
+		}
+	}
+
+	dLat := margin / metersPerDegree
+	min.Latitude, max.Latitude = min.Latitude-dLat, max.Latitude+dLat
+	// a degree of longitude is shortest on the side nearest a pole
+	cos := math.Cos(math.Max(math.Abs(min.Latitude), math.Abs(max.Latitude)) * math.Pi / 180)
+	if cos < 0.01 {
+		return Coordinate{Latitude: min.Latitude, Longitude: -180}, Coordinate{Latitude: max.Latitude, Longitude: 180}
+	}
+	dLng := dLat / cos
+	min.Longitude, max.Longitude = min.Longitude-dLng, max.Longitude+dLng
+	return min, max
+}
+
+// GeofenceEvent represents a vehicle entering, dwelling in or leaving a geofence
+type GeofenceEvent struct {
+	ID         uuid.UUID
+	GeofenceID uuid.UUID
+	LocationID uuid.UUID
+	VehicleID  uuid.UUID
+	TripID     *uuid.UUID // trip whose stop was advanced by the event, if any
+	StopID     *uuid.UUID
+	Type       GeofenceEventType
+	OccurredAt time.Time
+	Latitude   float64
+	Longitude  float64
+	CreatedAt  time.Time
+}
+
+// GeofencePresence records a vehicle being inside one geofence
+type GeofencePresence struct {
+	EnteredAt time.Time
+	Dwelling  bool
+}
+
+// GeofenceState tracks which geofences a vehicle is inside between position fixes
+type GeofenceState struct {
+	LastAt   time.Time
+	Presence map[uuid.UUID]*GeofencePresence
+}
+
+// NewGeofenceState creates the state of a vehicle that is outside every geofence
+func NewGeofenceState() *GeofenceState {
+	return &GeofenceState{Presence: make(map[uuid.UUID]*GeofencePresence)}
+}
+
+// Observe advances the vehicle's presence in a geofence with a fix taken at the given time and returns
+// the events it causes. inside reports whether the fix lies within the boundary and clear whether it lies
+// beyond the exit buffer; fixes in between keep the current presence so a vehicle on the edge does not flap.
+func (s *GeofenceState) Observe(g *Geofence, at time.Time, inside, clear bool) []GeofenceEventType {
+	p, ok := s.Presence[g.ID]
+	switch {
+	case !ok:
+		if !inside {
+			return nil
+		}
+		p = &GeofencePresence{EnteredAt: at}
+		s.Presence[g.ID] = p
+		if g.DwellTime <= 0 {
+			p.Dwelling = true
+			return []GeofenceEventType{GeofenceEventEnter, GeofenceEventDwell}
+		}
+		return []GeofenceEventType{GeofenceEventEnter}
+	case clear:
+		delete(s.Presence, g.ID)
+		return []GeofenceEventType{GeofenceEventExit}
+	case !p.Dwelling && at.Sub(p.EnteredAt) >= g.DwellTime:
+		p.Dwelling = true
+		return []GeofenceEventType{GeofenceEventDwell}
+	}
+	return nil
+}
+
+// Forget drops presence in geofences that are not in the given set, e.g. after they were deleted
+func (s *GeofenceState) Forget(keep map[uuid.UUID]bool) {
+	for id := range s.Presence {
+		if !keep[id] {
+			delete(s.Presence, id)
+		}
+	}
+}
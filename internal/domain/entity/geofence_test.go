@@ -0,0 +1,25 @@
+package entity
+
+import "testing"
+
+func TestGeofenceBounds(t *testing.T) {
+	circle := &Geofence{Shape: GeofenceShapeCircle, Center: Coordinate{Latitude: 13.7, Longitude: 100.5}, RadiusM: 500, ExitBufferM: 50}
+	min, max := circle.Bounds()
+	// 550 m is about 0.0049° of latitude and 0.0051° of longitude at 13.7°N
+	if min.Latitude > 13.6950 || max.Latitude < 13.7050 || min.Longitude > 100.4949 || max.Longitude < 100.5051 {
+		t.Errorf("circle bounds %v - %v do not cover the radius and exit buffer", min, max)
+	}
+	if min.Latitude < 13.69 || max.Longitude > 100.51 {
+		t.Errorf("circle bounds %v - %v are far too wide", min, max)
+	}
+
+	polygon := &Geofence{Shape: GeofenceShapePolygon, Polygon: []Coordinate{
+		{Latitude: 13.70, Longitude: 100.50},
+		{Latitude: 13.72, Longitude: 100.50},
+		{Latitude: 13.71, Longitude: 100.53},
+	}}
+	min, max = polygon.Bounds()
+	if min != (Coordinate{Latitude: 13.70, Longitude: 100.50}) || max != (Coordinate{Latitude: 13.72, Longitude: 100.53}) {
+		t.Errorf("polygon bounds without buffer = %v - %v, want the vertices' extent", min, max)
+	}
+}
@@ -0,0 +1,148 @@
+package entity
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+
+	"github.com/google/uuid"
+)
+
+// IncidentCategory represents what went wrong
+type IncidentCategory string
+
+const (
+	IncidentDamage          IncidentCategory = "damage"
+	IncidentShortage        IncidentCategory = "shortage"
+	IncidentAccident        IncidentCategory = "accident"
+	IncidentBreakdown       IncidentCategory = "breakdown"
+	IncidentRefusedDelivery IncidentCategory = "refused_delivery"
+	IncidentOther           IncidentCategory = "other"
+)
+
+// IncidentSeverity represents how urgently an incident must be resolved
+type IncidentSeverity string
+
+const (
+	IncidentSeverityLow      IncidentSeverity = "low"
+	IncidentSeverityMedium   IncidentSeverity = "medium"
+	IncidentSeverityHigh     IncidentSeverity = "high"
+	IncidentSeverityCritical IncidentSeverity = "critical"
+)
+
+// IncidentStatus represents the progress of an incident towards its resolution
+type IncidentStatus string
+
+const (
+	IncidentStatusOpen       IncidentStatus = "open"
+	IncidentStatusInProgress IncidentStatus = "in_progress" // the owner is working on it
+	IncidentStatusResolved   IncidentStatus = "resolved"
+	IncidentStatusClosed     IncidentStatus = "closed"    // the resolution was accepted
+	IncidentStatusCancelled  IncidentStatus = "cancelled" // reported in error
+)
+
+// incidentTransitions lists the statuses each incident status may move to
+var incidentTransitions = map[IncidentStatus][]IncidentStatus{
+	IncidentStatusOpen:       {IncidentStatusInProgress, IncidentStatusResolved, IncidentStatusCancelled},
+	IncidentStatusInProgress: {IncidentStatusResolved, IncidentStatusCancelled},
+	IncidentStatusResolved:   {IncidentStatusClosed, IncidentStatusInProgress},
+}
+
+// Incident records something that went wrong on a trip, at one of its stops or with a shipment (Pure Domain Entity).
+// Photos are files in object storage referenced by key.
+type Incident struct {
+	ID           uuid.UUID
+	Number       string // document number, e.g. INC-2610-00017
+	Category     IncidentCategory
+	Severity     IncidentSeverity
+	Status       IncidentStatus
+	TripID       *uuid.UUID
+	StopID       *uuid.UUID // a stop of the trip
+	ShipmentID   *uuid.UUID
+	Description  string
+	PhotoKeys    []string
+	Latitude     *float64
+	Longitude    *float64
+	LocationID   *uuid.UUID // the stop's location, when reported at a stop
+	ReportedBy   uuid.UUID
+	OccurredAt   time.Time
+	OwnerID      *uuid.UUID // the user responsible for resolving it
+	DueAt        time.Time  // when it must be resolved by under its SLA
+	BreachedAt   *time.Time // when it was found unresolved past DueAt
+	Resolution   string
+	ResolvedBy   *uuid.UUID
+	ResolvedAt   *time.Time
+	ClosedAt     *time.Time
+	CancelReason string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// IsOpen reports whether the incident still awaits a resolution
+func (i *Incident) IsOpen() bool {
+	return i.Status == IncidentStatusOpen || i.Status == IncidentStatusInProgress
+}
+
+// Overdue reports whether the incident is open past its SLA
+func (i *Incident) Overdue(now time.Time) bool {
+	return i.IsOpen() && now.After(i.DueAt)
+}
+
+// CanTransitionTo reports whether the incident may move to the given status
+func (i *Incident) CanTransitionTo(next IncidentStatus) bool {
+	for _, s := range incidentTransitions[i.Status] {
+		if s == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Start marks the incident as being worked on
+func (i *Incident) Start() error {
+	if !i.CanTransitionTo(IncidentStatusInProgress) {
+		return errs.ErrInvalidStatusTransition
+	}
+	i.Status = IncidentStatusInProgress
+	i.Resolution = ""
+	i.ResolvedBy = nil
+	i.ResolvedAt = nil
+	return nil
+}
+
+// Resolve records how the incident was resolved
+func (i *Incident) Resolve(resolution string, by uuid.UUID, at time.Time) error {
+	if !i.CanTransitionTo(IncidentStatusResolved) {
+		return errs.ErrInvalidStatusTransition
+	}
+	i.Status = IncidentStatusResolved
+	i.Resolution = resolution
+	i.ResolvedBy = &by
+	i.ResolvedAt = &at
+	return nil
+}
+
+// Close accepts the resolution
+func (i *Incident) Close(at time.Time) error {
+	if !i.CanTransitionTo(IncidentStatusClosed) {
+		return errs.ErrInvalidStatusTransition
+	}
+	i.Status = IncidentStatusClosed
+	i.ClosedAt = &at
+	return nil
+}
+
+// Cancel withdraws an incident reported in error
+func (i *Incident) Cancel(reason string) error {
+	if !i.CanTransitionTo(IncidentStatusCancelled) {
+		return errs.ErrInvalidStatusTransition
+	}
+	i.Status = IncidentStatusCancelled
+	i.CancelReason = reason
+	return nil
+}
+
+// Breached records that the incident was found unresolved past its SLA
+func (i *Incident) Breached(at time.Time) {
+	i.BreachedAt = &at
+}
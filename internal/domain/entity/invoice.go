@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceKind distinguishes invoices from the credit notes that correct them
+type InvoiceKind string
+
+const (
+	InvoiceKindInvoice    InvoiceKind = "invoice"
+	InvoiceKindCreditNote InvoiceKind = "credit_note"
+)
+
+// InvoiceStatus represents the lifecycle status of an invoice or credit note
+type InvoiceStatus string
+
+const (
+	InvoiceStatusDraft  InvoiceStatus = "draft"  // being prepared; has no number yet
+	InvoiceStatusIssued InvoiceStatus = "issued" // numbered and sent to the customer
+	InvoiceStatusPaid   InvoiceStatus = "paid"
+	InvoiceStatusVoid   InvoiceStatus = "void"
+)
+
+// Invoice bills a customer for delivered shipments of a billing period, or, as a credit note,
+// reduces an issued invoice. Amounts are in satang of the invoice currency.
+// VAT is charged on the subtotal; withholding tax is deducted by the customer from the subtotal,
+// so the customer pays AmountDue = Total - WithholdingTax.
+type Invoice struct {
+	ID                uuid.UUID
+	Number            string // issued in sequence when the invoice is issued
+	Kind              InvoiceKind
+	Status            InvoiceStatus
+	OrganizationID    uuid.UUID
+	CreditedInvoiceID *uuid.UUID // the invoice a credit note corrects
+	PeriodFrom        time.Time  // first day of the billing period
+	PeriodTo          time.Time  // last day of the billing period
+	Currency          string
+	CreditTermDays    int
+	IssueDate         *time.Time
+	DueDate           *time.Time
+	Lines             []InvoiceLine
+	Subtotal          Money
+	VATRate           float64 // e.g. 0.07
+	VATAmount         Money
+	Total             Money
+	WithholdingRate   float64 // e.g. 0.01; zero when the customer does not withhold
+	WithholdingTax    Money
+	AmountDue         Money
+	Reason            string // why a credit note was raised
+	Notes             string
+	PaidAt            *time.Time
+	PaymentReference  string
+	VoidedAt          *time.Time
+	VoidReason        string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// InvoiceLine is one charge on an invoice, usually the transport of one shipment
+type InvoiceLine struct {
+	ID          uuid.UUID
+	InvoiceID   uuid.UUID
+	Sequence    int
+	ShipmentID  *uuid.UUID
+	ServiceDate *time.Time // when the shipment was delivered
+	Description string
+	Quantity    float64
+	UnitPrice   Money
+	Amount      Money
+}
+
+// BillableShipment is a delivered shipment that is not on a live invoice yet
+type BillableShipment struct {
+	Shipment    *Shipment
+	DeliveredAt time.Time
+}
+
+// IsCreditNote reports whether the invoice is a credit note
+func (inv *Invoice) IsCreditNote() bool {
+	return inv.Kind == InvoiceKindCreditNote
+}
+
+// IsCreditable reports whether credit notes may be raised against the invoice
+func (inv *Invoice) IsCreditable() bool {
+	return inv.Kind == InvoiceKindInvoice && (inv.Status == InvoiceStatusIssued || inv.Status == InvoiceStatusPaid)
+}
+
+// IsOverdue reports whether an issued invoice is unpaid after its due date
+func (inv *Invoice) IsOverdue(now time.Time) bool {
+	return inv.Kind == InvoiceKindInvoice && inv.Status == InvoiceStatusIssued &&
+		inv.DueDate != nil && now.After(inv.DueDate.AddDate(0, 0, 1))
+}
+
+// AddLine appends a line, pricing it at quantity times unit price, and updates the totals
+func (inv *Invoice) AddLine(line InvoiceLine) {
+	line.InvoiceID = inv.ID
+	line.Sequence = len(inv.Lines) + 1
+	line.Amount = line.UnitPrice.Mul(line.Quantity)
+	inv.Lines = append(inv.Lines, line)
+	inv.calculate()
+}
+
+// Issue numbers a draft and starts its credit term on the issue date
+func (inv *Invoice) Issue(number string, issueDate time.Time) error {
+	if inv.Status != InvoiceStatusDraft {
+		return errs.ErrInvalidStatusTransition
+	}
+	due := issueDate.AddDate(0, 0, inv.CreditTermDays)
+	inv.Number = number
+	inv.Status = InvoiceStatusIssued
+	inv.IssueDate = &issueDate
+	inv.DueDate = &due
+	return nil
+}
+
+// MarkPaid records the customer's payment of an issued invoice
+func (inv *Invoice) MarkPaid(at time.Time, reference string) error {
+	if inv.Kind != InvoiceKindInvoice || inv.Status != InvoiceStatusIssued {
+		return errs.ErrInvalidStatusTransition
+	}
+	inv.Status = InvoiceStatusPaid
+	inv.PaidAt = &at
+	inv.PaymentReference = reference
+	return nil
+}
+
+// Void cancels a draft or an unpaid invoice. Its number stays used so the sequence has no gaps,
+// and its shipments may be billed again. Paid invoices are corrected with credit notes instead.
+func (inv *Invoice) Void(reason string, at time.Time) error {
+	if inv.Status != InvoiceStatusDraft && inv.Status != InvoiceStatusIssued {
+		return errs.ErrInvalidStatusTransition
+	}
+	inv.Status = InvoiceStatusVoid
+	inv.VoidedAt = &at
+	inv.VoidReason = reason
+	return nil
+}
+
+// calculate derives the subtotal, taxes and amount due from the lines
+func (inv *Invoice) calculate() {
+	var subtotal Money
+	for _, l := range inv.Lines {
+		subtotal += l.Amount
+	}
+	inv.Subtotal = subtotal
+	inv.VATAmount = subtotal.Mul(inv.VATRate)
+	inv.Total = subtotal + inv.VATAmount
+	inv.WithholdingTax = subtotal.Mul(inv.WithholdingRate)
+	inv.AmountDue = inv.Total - inv.WithholdingTax
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "testing"
+
+func TestInvoiceTotals(t *testing.T) {
+	inv := &Invoice{VATRate: 0.07, WithholdingRate: 0.01}
+	inv.AddLine(InvoiceLine{Quantity: 1, UnitPrice: Baht(0.1)})
+	inv.AddLine(InvoiceLine{Quantity: 1, UnitPrice: Baht(0.2)})
+	inv.AddLine(InvoiceLine{Quantity: 2.5, UnitPrice: Baht(648.05)})
+
+	// 0.10 + 0.20 + 1620.125 rounded to 1620.13
+	for name, tc := range map[string]struct{ got, want Money }{
+		"line amount":     {inv.Lines[2].Amount, 162013},
+		"subtotal":        {inv.Subtotal, 162043},
+		"VAT":             {inv.VATAmount, 11343}, // 113.4301
+		"total":           {inv.Total, 173386},
+		"withholding tax": {inv.WithholdingTax, 1620}, // 16.2043
+		"amount due":      {inv.AmountDue, 171766},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("%s = %s, want %s", name, tc.got, tc.want)
+		}
+	}
+	if inv.Lines[2].Sequence != 3 {
+		t.Errorf("sequence = %d, want 3", inv.Lines[2].Sequence)
+	}
+}
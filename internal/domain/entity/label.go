@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LabelSize is the stock a shipping label is printed on
+type LabelSize string
+
+const (
+	LabelSize4x6 LabelSize = "4x6" // 4 × 6 inch thermal label
+	LabelSizeA6  LabelSize = "a6"  // 105 × 148 mm
+)
+
+// LabelFormat is the output a shipping label is rendered to
+type LabelFormat string
+
+const (
+	LabelFormatZPL LabelFormat = "zpl" // Zebra printer language, sent to the printer as is
+	LabelFormatPDF LabelFormat = "pdf"
+)
+
+// LabelTemplate represents how an organization's shipping labels are printed (Pure Domain Entity).
+// Organizations without one use the configured default.
+type LabelTemplate struct {
+	OrganizationID   uuid.UUID
+	Size             LabelSize
+	DPI              int    // printer resolution for ZPL, 203 or 300
+	ZPLFont          string // printer path of a TrueType font with Thai glyphs, e.g. E:SARABUN.TTF; empty uses the built-in font, which has none
+	GS1CompanyPrefix string // 7 to 10 digits; labels carry no SSCC without one
+	SSCCExtension    int    // extension digit of the SSCCs, 0 to 9
+	Footer           string // e.g. handling instructions
+	UpdatedAt        time.Time
+}
+
+// ShipmentPiece is one labelled handling unit of a shipment with its Serial Shipping Container Code.
+// The SSCC is assigned when the piece is first labelled so reprinted labels carry the same code.
+type ShipmentPiece struct {
+	ShipmentID uuid.UUID
+	Piece      int    // 1-based
+	SSCC       string // 18 digits; empty when the template has no GS1 company prefix
+	CreatedAt  time.Time
+}
+
+// Pieces returns how many labels a shipment needs: one per pallet, or one for loose freight
+func (s *Shipment) Pieces() int {
+	return max(s.Pallets, 1)
+}
+
+// SSCC builds a Serial Shipping Container Code from the template's extension digit and company prefix
+// and a serial reference, followed by the GS1 check digit. It reports false when the serial reference
+// does not fit in the digits the company prefix leaves.
+func (t *LabelTemplate) SSCC(serial int64) (string, bool) {
+	digits := 16 - len(t.GS1CompanyPrefix)
+	limit := int64(1)
+	for i := 0; i < digits; i++ {
+		limit *= 10
+	}
+	if serial < 0 || serial >= limit {
+		return "", false
+	}
+	body := fmt.Sprintf("%d%s%0*d", t.SSCCExtension, t.GS1CompanyPrefix, digits, serial)
+	return body + string(gs1CheckDigit(body)), true
+}
+
+// gs1CheckDigit returns the GS1 modulo 10 check digit of a digit string, weighting digits 3, 1, 3, ... from the right
+func gs1CheckDigit(digits string) byte {
+	sum, weight := 0, 3
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		weight = 4 - weight
+	}
+	return byte('0' + (10-sum%10)%10)
+}
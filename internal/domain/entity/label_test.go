@@ -0,0 +1,50 @@
+package entity
+
+import "testing"
+
+func TestLabelTemplateSSCC(t *testing.T) {
+	template := &LabelTemplate{GS1CompanyPrefix: "0614141", SSCCExtension: 1}
+
+	// The GS1 General Specifications example SSCC
+	sscc, ok := template.SSCC(123456789)
+	if !ok || sscc != "106141411234567897" {
+		t.Errorf("SSCC(123456789) = %q, %v; want 106141411234567897", sscc, ok)
+	}
+
+	sscc, ok = template.SSCC(0)
+	if !ok || len(sscc) != 18 || sscc[:17] != "10614141000000000" {
+		t.Errorf("SSCC(0) = %q, %v; want the serial reference padded to 9 digits", sscc, ok)
+	}
+
+	// A 7-digit company prefix leaves 9 digits for the serial reference
+	if _, ok := template.SSCC(999999999); !ok {
+		t.Error("the largest serial reference does not fit")
+	}
+	if _, ok := template.SSCC(1000000000); ok {
+		t.Error("a 10-digit serial reference fits a 7-digit company prefix")
+	}
+	if _, ok := template.SSCC(-1); ok {
+		t.Error("a negative serial reference fits")
+	}
+}
+
+func TestGS1CheckDigit(t *testing.T) {
+	for digits, want := range map[string]byte{
+		"10614141123456789": '7',
+		"00000000000000000": '0',
+		"629104150021":      '3', // EAN-13 6291041500213
+		"03600029145":       '2', // UPC-A 036000291452
+	} {
+		if got := gs1CheckDigit(digits); got != want {
+			t.Errorf("gs1CheckDigit(%s) = %c, want %c", digits, got, want)
+		}
+	}
+}
+
+func TestShipmentPieces(t *testing.T) {
+	for pallets, want := range map[int]int{0: 1, 1: 1, 6: 6} {
+		if got := (&Shipment{Pallets: pallets}).Pieces(); got != want {
+			t.Errorf("%d pallets: %d pieces, want %d", pallets, got, want)
+		}
+	}
+}
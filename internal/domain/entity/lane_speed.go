@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LaneSpeed is the observed average speed between two provinces for trips leaving at one hour of the day
+type LaneSpeed struct {
+	OriginProvince      string
+	DestinationProvince string
+	Hour                int // 0-23, Thai local time
+	Samples             int
+	SpeedKmh            float64
+	UpdatedAt           time.Time
+}
+
+// StopDelay records that a stop's predicted arrival passed its time window
+type StopDelay struct {
+	ID         uuid.UUID
+	TripID     uuid.UUID
+	StopID     uuid.UUID
+	ShipmentID uuid.UUID
+	VehicleID  uuid.UUID
+	ETA        time.Time
+	WindowEnd  time.Time
+	DetectedAt time.Time
+}
+
+// Delay returns how late the stop is predicted to be
+func (d *StopDelay) Delay() time.Duration {
+	return d.ETA.Sub(d.WindowEnd)
+}
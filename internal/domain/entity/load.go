@@ -0,0 +1,50 @@
+package entity
+
+import "fmt"
+
+// Load represents the size of cargo in the dimensions vehicle capacity is measured in
+type Load struct {
+	WeightKg float64
+	VolumeM3 float64
+	Pallets  int
+}
+
+// Add returns the sum of two loads
+func (l Load) Add(o Load) Load {
+	return Load{WeightKg: l.WeightKg + o.WeightKg, VolumeM3: l.VolumeM3 + o.VolumeM3, Pallets: l.Pallets + o.Pallets}
+}
+
+// Sub returns the difference of two loads
+func (l Load) Sub(o Load) Load {
+	return Load{WeightKg: l.WeightKg - o.WeightKg, VolumeM3: l.VolumeM3 - o.VolumeM3, Pallets: l.Pallets - o.Pallets}
+}
+
+// Max returns the per-dimension maximum of two loads
+func (l Load) Max(o Load) Load {
+	if o.WeightKg > l.WeightKg {
+		l.WeightKg = o.WeightKg
+	}
+	if o.VolumeM3 > l.VolumeM3 {
+		l.VolumeM3 = o.VolumeM3
+	}
+	if o.Pallets > l.Pallets {
+		l.Pallets = o.Pallets
+	}
+	return l
+}
+
+// Exceeds returns a description of each dimension in which the load is over capacity.
+// A zero capacity dimension is treated as unlimited.
+func (l Load) Exceeds(capacity Load) []string {
+	var over []string
+	if capacity.WeightKg > 0 && l.WeightKg > capacity.WeightKg {
+		over = append(over, fmt.Sprintf("weight %.0f kg > %.0f kg", l.WeightKg, capacity.WeightKg))
+	}
+	if capacity.VolumeM3 > 0 && l.VolumeM3 > capacity.VolumeM3 {
+		over = append(over, fmt.Sprintf("volume %.2f m3 > %.2f m3", l.VolumeM3, capacity.VolumeM3))
+	}
+	if capacity.Pallets > 0 && l.Pallets > capacity.Pallets {
+		over = append(over, fmt.Sprintf("pallets %d > %d", l.Pallets, capacity.Pallets))
+	}
+	return over
+}
@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GeoPrecision describes how exactly a coordinate pins an address
+type GeoPrecision string
+
+const (
+	GeoPrecisionManual      GeoPrecision = "manual"      // entered or confirmed by a user
+	GeoPrecisionRooftop     GeoPrecision = "rooftop"     // the building itself
+	GeoPrecisionStreet      GeoPrecision = "street"      // interpolated along the road or the road's center
+	GeoPrecisionSubdistrict GeoPrecision = "subdistrict" // centroid of the subdistrict (tambon/khwaeng)
+	GeoPrecisionPostcode    GeoPrecision = "postcode"    // centroid of the postcode area
+	GeoPrecisionApproximate GeoPrecision = "approximate" // anything coarser
+)
+
+// IsApproximate reports whether the pin only locates the surrounding area rather than the address
+func (p GeoPrecision) IsApproximate() bool {
+	switch p {
+	case GeoPrecisionManual, GeoPrecisionRooftop, GeoPrecisionStreet:
+		return false
+	}
+	return true
+}
+
+// OpeningHours represents the receiving hours of a location on one weekday
+type OpeningHours struct {
+	Weekday time.Weekday
+	Open    string // HH:MM, local time
+	Close   string // HH:MM, local time
+}
+
+// Location represents a saved address in an organization's address book (Pure Domain Entity)
+type Location struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	Code           string
+	HouseNumber    string
+	Moo            string
+	Soi            string
+	Road           string
+	Subdistrict    string
+	District       string
+	Province       string
+	Postcode       string
+	Latitude       *float64
+	Longitude      *float64
+	GeoPrecision   GeoPrecision
+	ContactName    string
+	ContactPhone   string
+	OpeningHours   []OpeningHours
+	Notes          string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time
+}
+
+// HasCoordinates reports whether the location has a latitude/longitude pin
+func (l *Location) HasCoordinates() bool {
+	return l.Latitude != nil && l.Longitude != nil
+}
+
+// StreetAddress returns the part of the address below subdistrict level (house number, moo, soi, road)
+func (l *Location) StreetAddress() string {
+	var parts []string
+	if l.HouseNumber != "" {
+		parts = append(parts, l.HouseNumber)
+	}
+	if l.Moo != "" {
+		parts = append(parts, "หมู่ "+l.Moo)
+	}
+	if l.Soi != "" {
+		parts = append(parts, "ซอย"+l.Soi)
+	}
+	if l.Road != "" {
+		parts = append(parts, "ถนน"+l.Road)
+	}
+	return strings.Join(parts, " ")
+}
+
+// FormattedAddress returns the address in conventional Thai order.
+// Bangkok uses แขวง/เขต while other provinces use ตำบล/อำเภอ/จังหวัด.
+func (l *Location) FormattedAddress() string {
+	var parts []string
+	if street := l.StreetAddress(); street != "" {
+		parts = append(parts, street)
+	}
+	if l.Province == "กรุงเทพมหานคร" {
+		parts = append(parts, "แขวง"+l.Subdistrict, "เขต"+l.District, l.Province)
+	} else {
+		parts = append(parts, "ตำบล"+l.Subdistrict, "อำเภอ"+l.District, "จังหวัด"+l.Province)
+	}
+	parts = append(parts, l.Postcode)
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,214 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+
+	"github.com/google/uuid"
+)
+
+// MaintenancePlan schedules a preventive service for every vehicle of a type: every IntervalKm, every
+// IntervalDays, or whichever comes first when both are set.
+type MaintenancePlan struct {
+	ID           uuid.UUID
+	Name         string
+	VehicleType  VehicleType
+	IntervalKm   float64 // zero when the service is not due by distance
+	IntervalDays int     // zero when the service is not due by time
+	Description  string
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// MaintenanceStatus represents how close a vehicle is to a planned service
+type MaintenanceStatus string
+
+const (
+	MaintenanceStatusOK      MaintenanceStatus = "ok"
+	MaintenanceStatusDue     MaintenanceStatus = "due"     // within the warning distance or days
+	MaintenanceStatusOverdue MaintenanceStatus = "overdue" // past the due date or distance; blocks assignment
+)
+
+// maintenanceSeverity orders the statuses so that only a worsening raises an alert
+var maintenanceSeverity = map[MaintenanceStatus]int{
+	MaintenanceStatusOK:      0,
+	MaintenanceStatusDue:     1,
+	MaintenanceStatusOverdue: 2,
+}
+
+// MaintenanceSchedule tracks when a vehicle is next due for the service of a plan, counted from its last
+// service. A vehicle with no service recorded is counted from when the plan started to apply to it.
+type MaintenanceSchedule struct {
+	ID              uuid.UUID
+	VehicleID       uuid.UUID
+	PlanID          uuid.UUID
+	LastServiceAt   time.Time
+	LastServiceKm   float64
+	LastWorkOrderID *uuid.UUID
+	CurrentKm       float64 // latest odometer reading when checked
+	NextDueAt       *time.Time
+	NextDueKm       *float64
+	Status          MaintenanceStatus
+	CheckedAt       time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Serviced restarts the schedule from a completed service
+func (s *MaintenanceSchedule) Serviced(at time.Time, odometerKm float64, workOrderID uuid.UUID) {
+	s.LastServiceAt = at
+	s.LastServiceKm = odometerKm
+	s.LastWorkOrderID = &workOrderID
+	s.Status = MaintenanceStatusOK
+}
+
+// Evaluate works out the next due date and distance and the status at the given time and odometer. A service
+// within warnKm or warnDays is due. It reports whether the status got worse, which calls for an alert.
+func (s *MaintenanceSchedule) Evaluate(plan *MaintenancePlan, currentKm float64, at time.Time, warnKm float64, warnDays int) bool {
+	s.CurrentKm = max(currentKm, s.CurrentKm)
+	s.CheckedAt = at
+	s.NextDueAt, s.NextDueKm = nil, nil
+	if plan.IntervalDays > 0 {
+		due := s.LastServiceAt.AddDate(0, 0, plan.IntervalDays)
+		s.NextDueAt = &due
+	}
+	if plan.IntervalKm > 0 {
+		due := math.Round((s.LastServiceKm+plan.IntervalKm)*10) / 10
+		s.NextDueKm = &due
+	}
+
+	status := MaintenanceStatusOK
+	switch {
+	case s.NextDueAt != nil && !at.Before(*s.NextDueAt),
+		s.NextDueKm != nil && s.CurrentKm >= *s.NextDueKm:
+		status = MaintenanceStatusOverdue
+	case s.NextDueAt != nil && !at.Before(s.NextDueAt.AddDate(0, 0, -warnDays)),
+		s.NextDueKm != nil && s.CurrentKm >= *s.NextDueKm-warnKm:
+		status = MaintenanceStatusDue
+	}
+
+	worse := maintenanceSeverity[status] > maintenanceSeverity[s.Status]
+	s.Status = status
+	return worse
+}
+
+// WorkOrderType distinguishes planned services from repairs
+type WorkOrderType string
+
+const (
+	WorkOrderPreventive WorkOrderType = "preventive" // the service of a maintenance plan
+	WorkOrderCorrective WorkOrderType = "corrective" // a repair
+)
+
+// WorkOrderStatus represents the lifecycle status of a work order
+type WorkOrderStatus string
+
+const (
+	WorkOrderStatusOpen       WorkOrderStatus = "open"
+	WorkOrderStatusInProgress WorkOrderStatus = "in_progress" // the vehicle is off the road
+	WorkOrderStatusCompleted  WorkOrderStatus = "completed"
+	WorkOrderStatusCancelled  WorkOrderStatus = "cancelled"
+)
+
+// WorkOrder records maintenance or repair work on a vehicle: the workshop, parts, cost and how long the
+// vehicle was off the road. TotalCost = PartsCost + LaborCost.
+type WorkOrder struct {
+	ID            uuid.UUID
+	Number        string
+	VehicleID     uuid.UUID
+	PlanID        *uuid.UUID // the plan a preventive work order services
+	Type          WorkOrderType
+	Status        WorkOrderStatus
+	Workshop      string
+	Description   string
+	OdometerKm    float64
+	Parts         []WorkOrderPart
+	PartsCost     Money
+	LaborCost     Money
+	TotalCost     Money
+	StartedAt     *time.Time
+	CompletedAt   *time.Time
+	DowntimeHours float64
+	Notes         string
+	CreatedBy     *uuid.UUID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// WorkOrderPart is a part fitted under a work order
+type WorkOrderPart struct {
+	ID          uuid.UUID
+	WorkOrderID uuid.UUID
+	Sequence    int
+	PartNumber  string
+	Name        string
+	Quantity    float64
+	UnitCost    Money
+	Amount      Money
+}
+
+// SetCosts replaces the parts and labor of a work order that is not yet closed
+func (w *WorkOrder) SetCosts(parts []WorkOrderPart, laborCost Money) error {
+	if w.closed() {
+		return errs.ErrResourceLocked
+	}
+	w.PartsCost = 0
+	for i := range parts {
+		parts[i].WorkOrderID = w.ID
+		parts[i].Sequence = i + 1
+		parts[i].Amount = parts[i].UnitCost.Mul(parts[i].Quantity)
+		w.PartsCost += parts[i].Amount
+	}
+	w.Parts = parts
+	w.LaborCost = laborCost
+	w.TotalCost = w.PartsCost + w.LaborCost
+	return nil
+}
+
+// Start takes the vehicle off the road for the work
+func (w *WorkOrder) Start(at time.Time) error {
+	if w.Status != WorkOrderStatusOpen {
+		return errs.ErrInvalidStatusTransition
+	}
+	w.Status = WorkOrderStatusInProgress
+	w.StartedAt = &at
+	return nil
+}
+
+// Complete closes the work order at the odometer reading when the vehicle went back on the road.
+// A work order that was never started is taken to have started at startedAt, or to have had no downtime.
+func (w *WorkOrder) Complete(at time.Time, odometerKm float64, startedAt *time.Time) error {
+	if w.closed() {
+		return errs.ErrInvalidStatusTransition
+	}
+	if w.StartedAt == nil {
+		if startedAt == nil {
+			startedAt = &at
+		}
+		w.StartedAt = startedAt
+	}
+	if at.Before(*w.StartedAt) {
+		return errs.ValidationErrors{"completed_at": {"before_started_at"}}
+	}
+	w.Status = WorkOrderStatusCompleted
+	w.CompletedAt = &at
+	w.OdometerKm = odometerKm
+	w.DowntimeHours = math.Round(at.Sub(*w.StartedAt).Hours()*100) / 100
+	return nil
+}
+
+// Cancel drops a work order that was not carried out
+func (w *WorkOrder) Cancel() error {
+	if w.closed() {
+		return errs.ErrInvalidStatusTransition
+	}
+	w.Status = WorkOrderStatusCancelled
+	return nil
+}
+
+func (w *WorkOrder) closed() bool {
+	return w.Status == WorkOrderStatusCompleted || w.Status == WorkOrderStatusCancelled
+}
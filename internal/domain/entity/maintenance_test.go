@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"tms-core-service/internal/domain/errs"
+)
+
+func TestWorkOrderSetCosts(t *testing.T) {
+	w := &WorkOrder{Status: WorkOrderStatusOpen}
+	parts := []WorkOrderPart{
+		{Name: "Oil filter", Quantity: 1, UnitCost: Baht(0.1)},
+		{Name: "Oil filter", Quantity: 1, UnitCost: Baht(0.2)},
+		{Name: "Engine oil", Quantity: 6.5, UnitCost: Baht(182.75)},
+	}
+	if err := w.SetCosts(parts, Baht(850)); err != nil {
+		t.Fatalf("SetCosts: %v", err)
+	}
+
+	// 0.10 + 0.20 + 1187.875 rounded to 1187.88
+	for name, tc := range map[string]struct{ got, want Money }{
+		"part amount": {w.Parts[2].Amount, 118788},
+		"parts cost":  {w.PartsCost, 118818},
+		"labor cost":  {w.LaborCost, 85000},
+		"total cost":  {w.TotalCost, 203818},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("%s = %s, want %s", name, tc.got, tc.want)
+		}
+	}
+	if w.Parts[2].Sequence != 3 {
+		t.Errorf("sequence = %d, want 3", w.Parts[2].Sequence)
+	}
+
+	w.Status = WorkOrderStatusCompleted
+	if err := w.SetCosts(nil, 0); !errors.Is(err, errs.ErrResourceLocked) {
+		t.Errorf("costing a completed work order: err = %v, want ErrResourceLocked", err)
+	}
+}
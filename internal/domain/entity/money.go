@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+)
+
+// Money is an amount in satang, the hundredth of a baht. Amounts are whole satang so that sums
+// are exact; only multiplying by a quantity or a percentage rounds, half away from zero.
+type Money int64
+
+// Baht converts an amount in baht to Money, rounded to the satang
+func Baht(v float64) Money {
+	return Money(math.Round(v * 100))
+}
+
+// Baht returns the amount in baht
+func (m Money) Baht() float64 {
+	return float64(m) / 100
+}
+
+// Mul returns the amount times a quantity, rounded to the satang
+func (m Money) Mul(quantity float64) Money {
+	return Money(math.Round(float64(m) * quantity))
+}
+
+// Percent returns p percent of the amount, rounded to the satang
+func (m Money) Percent(p float64) Money {
+	return Money(math.Round(float64(m) * p / 100))
+}
+
+// String formats the amount in baht with two decimals, e.g. 1234.50
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign, m = "-", -m
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, m/100, m%100)
+}
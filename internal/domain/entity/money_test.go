@@ -0,0 +1,59 @@
+package entity
+
+import "testing"
+
+func TestBaht(t *testing.T) {
+	for _, tc := range []struct {
+		baht float64
+		want Money
+	}{
+		{0, 0},
+		{1, 100},
+		{0.1 + 0.2, 30},
+		{1234.565, 123457},
+		{-12.345, -1235},
+		{29.94, 2994},
+	} {
+		if got := Baht(tc.baht); got != tc.want {
+			t.Errorf("Baht(%v) = %d, want %d", tc.baht, got, tc.want)
+		}
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	// 0.1 + 0.2 drifts in float baht but not in satang
+	var sum Money
+	for i := 0; i < 1000; i++ {
+		sum += Baht(0.1)
+	}
+	if sum != Baht(100) {
+		t.Errorf("1000 × 0.10 = %s, want 100.00", sum)
+	}
+
+	if got := Baht(12.5).Mul(3.3); got != 4125 {
+		t.Errorf("12.50 × 3.3 = %s, want 41.25", got)
+	}
+	if got := Baht(0.85).Mul(1234.5); got != 104933 {
+		t.Errorf("0.85 × 1234.5 = %s, want 1049.33", got)
+	}
+	if got := Baht(4567.89).Percent(7); got != 31975 {
+		t.Errorf("7%% of 4567.89 = %s, want 319.75", got)
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	for _, tc := range []struct {
+		m    Money
+		want string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{123450, "1234.50"},
+		{-7, "-0.07"},
+		{-123456, "-1234.56"},
+	} {
+		if got := tc.m.String(); got != tc.want {
+			t.Errorf("Money(%d).String() = %q, want %q", tc.m, got, tc.want)
+		}
+	}
+}
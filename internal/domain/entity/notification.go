@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationStatus represents the delivery status of a notification
+type NotificationStatus string
+
+const (
+	NotificationStatusPending   NotificationStatus = "pending" // in the outbox, waiting to be delivered
+	NotificationStatusDelivered NotificationStatus = "delivered"
+	NotificationStatusFailed    NotificationStatus = "failed" // given up after MaxDeliveryAttempts
+)
+
+const (
+	// MaxDeliveryAttempts bounds how often delivering a notification is tried
+	MaxDeliveryAttempts = 20
+
+	// maxRetryDelay caps the wait between delivery attempts, which doubles from a second
+	maxRetryDelay = 5 * time.Minute
+)
+
+// Notification is a message to users, such as an expiry reminder or a new incident (Pure Domain Entity).
+// It is recorded in the outbox together with the change it announces and delivered on its realtime
+// channels afterwards, so it is neither lost when delivery fails nor sent for a change that was rolled back.
+type Notification struct {
+	ID            uuid.UUID
+	Type          string   // e.g. compliance.document_expiring
+	Channels      []string // realtime channels it is delivered on
+	Data          map[string]interface{}
+	OccurredAt    time.Time
+	Status        NotificationStatus
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Delivered marks the notification delivered
+func (n *Notification) Delivered(at time.Time) {
+	n.Status = NotificationStatusDelivered
+	n.Attempts++
+	n.LastError = ""
+	n.DeliveredAt = &at
+}
+
+// DeliveryFailed records a failed attempt and schedules the next one, or gives up after MaxDeliveryAttempts
+func (n *Notification) DeliveryFailed(err error, at time.Time) {
+	n.Attempts++
+	n.LastError = err.Error()
+	if n.Attempts >= MaxDeliveryAttempts {
+		n.Status = NotificationStatusFailed
+		return
+	}
+	n.NextAttemptAt = at.Add(min(time.Second<<(n.Attempts-1), maxRetryDelay))
+}
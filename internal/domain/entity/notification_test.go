@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNotificationDeliveryFailed(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	n := &Notification{Status: NotificationStatusPending, NextAttemptAt: now}
+
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second} {
+		n.DeliveryFailed(errors.New("redis down"), now)
+		if got := n.NextAttemptAt.Sub(now); got != want {
+			t.Errorf("after attempt %d: retry in %s, want %s", attempt+1, got, want)
+		}
+	}
+	for n.Attempts < MaxDeliveryAttempts-1 {
+		n.DeliveryFailed(errors.New("redis down"), now)
+		if n.NextAttemptAt.Sub(now) > maxRetryDelay {
+			t.Fatalf("after attempt %d: retry in %s, beyond the cap", n.Attempts, n.NextAttemptAt.Sub(now))
+		}
+	}
+	if n.Status != NotificationStatusPending {
+		t.Fatalf("status = %s before the last attempt, want pending", n.Status)
+	}
+
+	n.DeliveryFailed(errors.New("redis down"), now)
+	if n.Status != NotificationStatusFailed || n.LastError != "redis down" {
+		t.Errorf("status = %s, last error %q; want failed with the error", n.Status, n.LastError)
+	}
+}
+
+func TestNotificationDelivered(t *testing.T) {
+	now := time.Now()
+	n := &Notification{Status: NotificationStatusPending, Attempts: 2, LastError: "redis down"}
+	n.Delivered(now)
+	if n.Status != NotificationStatusDelivered || n.DeliveredAt == nil || !n.DeliveredAt.Equal(now) || n.LastError != "" {
+		t.Errorf("notification = %+v, want delivered now with the error cleared", n)
+	}
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DocumentType identifies a kind of numbered document
+type DocumentType string
+
+const (
+	DocumentShipment        DocumentType = "shipment" // tracking numbers
+	DocumentTrip            DocumentType = "trip"
+	DocumentInvoice         DocumentType = "invoice"
+	DocumentCreditNote      DocumentType = "credit_note"
+	DocumentProofOfDelivery DocumentType = "proof_of_delivery"
+
+	// DocumentDriverSettlement numbers the company's own driver pay statements, never an organization's
+	DocumentDriverSettlement DocumentType = "driver_settlement"
+
+	// DocumentWorkOrder numbers the company's own vehicle maintenance work orders
+	DocumentWorkOrder DocumentType = "work_order"
+
+	// DocumentIncident numbers the company's own incident reports
+	DocumentIncident DocumentType = "incident"
+
+	// DocumentSSCC counts the serial references of shipping label SSCCs. It has a sequence but no format.
+	DocumentSSCC DocumentType = "sscc"
+)
+
+// DocumentTypes lists every document type organizations may number in their own format
+var DocumentTypes = []DocumentType{DocumentShipment, DocumentTrip, DocumentInvoice, DocumentCreditNote, DocumentProofOfDelivery}
+
+// InternalDocumentTypes lists the numbered document types that always use the default format
+var InternalDocumentTypes = []DocumentType{DocumentDriverSettlement, DocumentWorkOrder, DocumentIncident}
+
+// NumberingFormat represents an organization's own number format for one document type (Pure Domain Entity).
+// Organizations without one use the default format of the document type.
+type NumberingFormat struct {
+	OrganizationID uuid.UUID
+	DocumentType   DocumentType
+	Format         string // e.g. TMS-{YYMM}-{seq:6}{mod11}
+	UpdatedAt      time.Time
+}
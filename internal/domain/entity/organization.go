@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization represents a company using the system, such as a shipper or customer (Pure Domain Entity)
+type Organization struct {
+	ID         uuid.UUID
+	Name       string
+	TaxID      string
+	BranchCode string
+	Phone      string
+	Email      string
+	// Billing terms
+	CreditTermDays *int // days from invoice to due date; nil uses the default term
+	WithholdsTax   bool // the customer withholds tax on the transport services it pays for
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time
+}
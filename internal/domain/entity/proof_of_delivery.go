@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProofOfDelivery records the hand-over of a shipment at a delivery stop (Pure Domain Entity).
+// The signature and photos are files in object storage referenced by key.
+type ProofOfDelivery struct {
+	ID            uuid.UUID
+	Number        string // document number, e.g. POD-2603-000128
+	TripID        uuid.UUID
+	StopID        uuid.UUID
+	ShipmentID    uuid.UUID
+	DriverID      uuid.UUID
+	RecipientName string
+	SignatureKey  string
+	PhotoKeys     []string
+	Latitude      float64
+	Longitude     float64
+	AccuracyM     *float64 // reported by the device
+	DistanceM     *float64 // from the delivery location; nil when the location has no coordinates
+	CapturedAt    time.Time
+	Notes         string
+	CreatedAt     time.Time
+}
+
+// FileKeys returns the storage keys of the signature followed by the photos
+func (p *ProofOfDelivery) FileKeys() []string {
+	return append([]string{p.SignatureKey}, p.PhotoKeys...)
+}
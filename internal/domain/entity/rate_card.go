@@ -0,0 +1,385 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+
+	"github.com/google/uuid"
+)
+
+// AnyZone matches every zone in a lane
+const AnyZone = "*"
+
+// AccessorialUnit is the unit an accessorial is charged in
+type AccessorialUnit string
+
+const (
+	AccessorialPerShipment AccessorialUnit = "per_shipment"
+	AccessorialPerDrop     AccessorialUnit = "per_drop"
+	AccessorialPerHour     AccessorialUnit = "per_hour"
+)
+
+// RateZone groups provinces and postcode prefixes into a pricing zone
+type RateZone struct {
+	Code             string
+	Provinces        []string
+	PostcodePrefixes []string
+}
+
+// Matches reports whether an address falls into the zone
+func (z RateZone) Matches(province, postcode string) bool {
+	for _, p := range z.PostcodePrefixes {
+		if postcode != "" && strings.HasPrefix(postcode, p) {
+			return true
+		}
+	}
+	for _, p := range z.Provinces {
+		if strings.EqualFold(strings.TrimSpace(p), strings.TrimSpace(province)) {
+			return true
+		}
+	}
+	return false
+}
+
+// DistanceBand prices the lane distance: a flat amount plus a per-km rate, up to a distance.
+// A zero UpToKm means no upper bound.
+type DistanceBand struct {
+	UpToKm float64
+	Amount Money
+	PerKm  Money
+}
+
+// WeightBreak is a per-kg rate applied from a minimum chargeable weight
+type WeightBreak struct {
+	MinKg float64
+	PerKg Money
+}
+
+// RateLane prices shipments between two zones, optionally for one vehicle type
+type RateLane struct {
+	OriginZone      string
+	DestinationZone string
+	VehicleType     VehicleType // empty matches any vehicle type
+	BaseCharge      Money
+	DistanceBands   []DistanceBand
+	WeightBreaks    []WeightBreak
+	PerPallet       Money
+	MinCharge       Money
+}
+
+// Label describes the lane in quote rules
+func (l RateLane) Label() string {
+	label := l.OriginZone + " → " + l.DestinationZone
+	if l.VehicleType != "" {
+		label += " (" + string(l.VehicleType) + ")"
+	}
+	return label
+}
+
+// specificity ranks lanes so exact zones and vehicle types win over wildcards
+func (l RateLane) specificity() int {
+	s := 0
+	if l.OriginZone != AnyZone {
+		s += 2
+	}
+	if l.DestinationZone != AnyZone {
+		s += 2
+	}
+	if l.VehicleType != "" {
+		s++
+	}
+	return s
+}
+
+func (l RateLane) matches(origin, destination string, vehicleType VehicleType) bool {
+	return (l.OriginZone == AnyZone || l.OriginZone == origin) &&
+		(l.DestinationZone == AnyZone || l.DestinationZone == destination) &&
+		(l.VehicleType == "" || l.VehicleType == vehicleType)
+}
+
+// FuelSurcharge indexes a surcharge on the linehaul to the diesel price:
+// every full Step (THB/litre) above BasePrice adds StepPercent.
+type FuelSurcharge struct {
+	BasePrice   Money
+	Step        Money
+	StepPercent float64
+}
+
+// AccessorialRate is the price of an additional service. The first FreeUnits are not charged.
+type AccessorialRate struct {
+	Code        string
+	Description string
+	Unit        AccessorialUnit
+	Amount      Money
+	FreeUnits   float64
+}
+
+// RateCard is one version of a customer's tariff, or of the standard tariff when OrganizationID is nil.
+// Versions are immutable; a change is published as a new version with its own effective period.
+type RateCard struct {
+	ID               uuid.UUID
+	OrganizationID   *uuid.UUID
+	Name             string
+	Version          int
+	Currency         string
+	EffectiveFrom    time.Time
+	EffectiveTo      *time.Time
+	VolumetricFactor float64 // kg charged per m3; zero disables volumetric weight
+	Zones            []RateZone
+	Lanes            []RateLane
+	Fuel             *FuelSurcharge
+	Accessorials     []AccessorialRate
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// IsEffective reports whether the card applies at the given time
+func (c *RateCard) IsEffective(at time.Time) bool {
+	return !at.Before(c.EffectiveFrom) && (c.EffectiveTo == nil || at.Before(*c.EffectiveTo))
+}
+
+// Zone returns the code of the first zone containing the address, or AnyZone
+func (c *RateCard) Zone(province, postcode string) string {
+	for _, z := range c.Zones {
+		if z.Matches(province, postcode) {
+			return z.Code
+		}
+	}
+	return AnyZone
+}
+
+// Accessorial returns the card's rate for an accessorial code
+func (c *RateCard) Accessorial(code string) (*AccessorialRate, bool) {
+	for i := range c.Accessorials {
+		if c.Accessorials[i].Code == code {
+			return &c.Accessorials[i], true
+		}
+	}
+	return nil, false
+}
+
+// PricingRequest describes what to price
+type PricingRequest struct {
+	OriginProvince      string
+	OriginPostcode      string
+	DestinationProvince string
+	DestinationPostcode string
+	VehicleType         VehicleType
+	DistanceKm          float64
+	Load                Load
+	Accessorials        []AccessorialQuantity
+}
+
+// AccessorialQuantity is a requested additional service
+type AccessorialQuantity struct {
+	Code     string
+	Quantity float64
+}
+
+// DieselPrice is the reference diesel price from a date on
+type DieselPrice struct {
+	ID            uuid.UUID
+	EffectiveDate time.Time
+	PricePerLitre Money
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// QuoteLine is one charge on a quote with the rule that produced it
+type QuoteLine struct {
+	Code      string
+	Quantity  float64
+	UnitPrice Money
+	Amount    Money
+	Rule      string
+}
+
+// Quote is a line-itemized price
+type Quote struct {
+	RateCardID      uuid.UUID
+	RateCardName    string
+	RateCardVersion int
+	Currency        string
+	OriginZone      string
+	DestinationZone string
+	ChargeableKg    float64
+	Lines           []QuoteLine
+	Total           Money
+}
+
+// Quote line codes
+const (
+	LineBase          = "base"
+	LineDistance      = "distance"
+	LineWeight        = "weight"
+	LinePallet        = "pallet"
+	LineMinimum       = "minimum_charge"
+	LineFuelSurcharge = "fuel_surcharge"
+	LineAccessorial   = "accessorial"
+)
+
+// Price quotes a request against the card. diesel may be nil when the card has no fuel surcharge.
+// It returns errs.ErrNoApplicableRate when no lane or distance band covers the request and
+// errs.ValidationErrors for accessorials the card does not price.
+func (c *RateCard) Price(req PricingRequest, diesel *DieselPrice) (*Quote, error) {
+	origin := c.Zone(req.OriginProvince, req.OriginPostcode)
+	destination := c.Zone(req.DestinationProvince, req.DestinationPostcode)
+
+	lane, ok := c.lane(origin, destination, req.VehicleType)
+	if !ok {
+		return nil, fmt.Errorf("%w: no lane for %s → %s", errs.ErrNoApplicableRate, origin, destination)
+	}
+
+	quote := &Quote{
+		RateCardID:      c.ID,
+		RateCardName:    c.Name,
+		RateCardVersion: c.Version,
+		Currency:        c.Currency,
+		OriginZone:      origin,
+		DestinationZone: destination,
+		ChargeableKg:    c.chargeableWeight(req.Load),
+	}
+	lanePrefix := "lane " + lane.Label()
+
+	if lane.BaseCharge > 0 {
+		quote.add(QuoteLine{Code: LineBase, Quantity: 1, UnitPrice: lane.BaseCharge, Rule: lanePrefix + ": base charge"})
+	}
+
+	if len(lane.DistanceBands) > 0 {
+		band, ok := distanceBand(lane.DistanceBands, req.DistanceKm)
+		if !ok {
+			return nil, fmt.Errorf("%w: %.1f km is beyond the last distance band", errs.ErrNoApplicableRate, req.DistanceKm)
+		}
+		bound := "no limit"
+		if band.UpToKm > 0 {
+			bound = fmt.Sprintf("≤ %g km", band.UpToKm)
+		}
+		if band.Amount > 0 {
+			quote.add(QuoteLine{Code: LineDistance, Quantity: 1, UnitPrice: band.Amount,
+				Rule: fmt.Sprintf("%s: distance band %s, flat", lanePrefix, bound)})
+		}
+		if band.PerKm > 0 {
+			quote.add(QuoteLine{Code: LineDistance, Quantity: req.DistanceKm, UnitPrice: band.PerKm,
+				Rule: fmt.Sprintf("%s: distance band %s, per km", lanePrefix, bound)})
+		}
+	}
+
+	if brk, ok := weightBreak(lane.WeightBreaks, quote.ChargeableKg); ok {
+		rule := fmt.Sprintf("%s: weight break from %g kg", lanePrefix, brk.MinKg)
+		if quote.ChargeableKg > req.Load.WeightKg {
+			rule += fmt.Sprintf(", volumetric %g kg/m3", c.VolumetricFactor)
+		}
+		quote.add(QuoteLine{Code: LineWeight, Quantity: quote.ChargeableKg, UnitPrice: brk.PerKg, Rule: rule})
+	}
+
+	if lane.PerPallet > 0 && req.Load.Pallets > 0 {
+		quote.add(QuoteLine{Code: LinePallet, Quantity: float64(req.Load.Pallets), UnitPrice: lane.PerPallet,
+			Rule: lanePrefix + ": per pallet"})
+	}
+
+	if linehaul := quote.Total; lane.MinCharge > 0 && linehaul < lane.MinCharge {
+		quote.add(QuoteLine{Code: LineMinimum, Quantity: 1, UnitPrice: lane.MinCharge - linehaul,
+			Rule: fmt.Sprintf("%s: minimum charge %s", lanePrefix, lane.MinCharge)})
+	}
+
+	if c.Fuel != nil && diesel != nil {
+		linehaul := quote.Total
+		if percent, steps := c.Fuel.percent(diesel.PricePerLitre); percent > 0 {
+			quote.add(QuoteLine{Code: LineFuelSurcharge, Quantity: 1, UnitPrice: linehaul.Percent(percent),
+				Rule: fmt.Sprintf("diesel %s/l from %s vs base %s/l: %d step(s) of %g%% = %g%% of linehaul %s",
+					diesel.PricePerLitre, diesel.EffectiveDate.Format("2006-01-02"), c.Fuel.BasePrice, steps, c.Fuel.StepPercent, percent, linehaul)})
+		}
+	}
+
+	validationErrs := make(errs.ValidationErrors)
+	for i, a := range req.Accessorials {
+		rate, ok := c.Accessorial(a.Code)
+		if !ok {
+			validationErrs[fmt.Sprintf("accessorials[%d].code", i)] = []string{"not_in_rate_card"}
+			continue
+		}
+		charged := a.Quantity
+		if rate.Unit == AccessorialPerShipment {
+			charged = 1
+		}
+		charged = math.Max(0, charged-rate.FreeUnits)
+		if charged == 0 {
+			continue
+		}
+		rule := fmt.Sprintf("accessorial %s %s", rate.Code, rate.Unit)
+		if rate.FreeUnits > 0 {
+			rule += fmt.Sprintf(", first %g free", rate.FreeUnits)
+		}
+		quote.add(QuoteLine{Code: LineAccessorial + ":" + rate.Code, Quantity: charged, UnitPrice: rate.Amount, Rule: rule})
+	}
+	if len(validationErrs) > 0 {
+		return nil, validationErrs
+	}
+
+	return quote, nil
+}
+
+// lane picks the most specific lane matching the zones and vehicle type
+func (c *RateCard) lane(origin, destination string, vehicleType VehicleType) (*RateLane, bool) {
+	var best *RateLane
+	for i := range c.Lanes {
+		l := &c.Lanes[i]
+		if l.matches(origin, destination, vehicleType) && (best == nil || l.specificity() > best.specificity()) {
+			best = l
+		}
+	}
+	return best, best != nil
+}
+
+func (c *RateCard) chargeableWeight(load Load) float64 {
+	return math.Max(load.WeightKg, load.VolumeM3*c.VolumetricFactor)
+}
+
+// percent returns the surcharge percentage and the number of full steps above the base price
+func (f *FuelSurcharge) percent(price Money) (float64, int) {
+	if f.Step <= 0 || price <= f.BasePrice {
+		return 0, 0
+	}
+	steps := int((price - f.BasePrice) / f.Step)
+	return float64(steps) * f.StepPercent, steps
+}
+
+func distanceBand(bands []DistanceBand, km float64) (DistanceBand, bool) {
+	sorted := append([]DistanceBand(nil), bands...)
+	sort.Slice(sorted, func(i, j int) bool {
+		// Unbounded bands go last
+		if sorted[i].UpToKm == 0 || sorted[j].UpToKm == 0 {
+			return sorted[j].UpToKm == 0 && sorted[i].UpToKm != 0
+		}
+		return sorted[i].UpToKm < sorted[j].UpToKm
+	})
+	for _, b := range sorted {
+		if b.UpToKm == 0 || km <= b.UpToKm {
+			return b, true
+		}
+	}
+	return DistanceBand{}, false
+}
+
+// weightBreak returns the break with the highest minimum not above the weight
+func weightBreak(breaks []WeightBreak, kg float64) (WeightBreak, bool) {
+	var best WeightBreak
+	found := false
+	for _, b := range breaks {
+		if b.MinKg <= kg && (!found || b.MinKg > best.MinKg) {
+			best, found = b, true
+		}
+	}
+	return best, found && kg > 0
+}
+
+func (q *Quote) add(line QuoteLine) {
+	line.Amount = line.UnitPrice.Mul(line.Quantity)
+	q.Lines = append(q.Lines, line)
+	q.Total += line.Amount
+}
@@ -0,0 +1,202 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+)
+
+func testRateCard() *RateCard {
+	return &RateCard{
+		Name:             "standard",
+		Version:          3,
+		Currency:         "THB",
+		VolumetricFactor: 250,
+		Zones: []RateZone{
+			{Code: "BKK", Provinces: []string{"กรุงเทพมหานคร", "Bangkok"}, PostcodePrefixes: []string{"10"}},
+			{Code: "NORTH", Provinces: []string{"เชียงใหม่", "Chiang Mai"}},
+		},
+		Lanes: []RateLane{
+			{
+				OriginZone:      "BKK",
+				DestinationZone: "NORTH",
+				BaseCharge:      Baht(500),
+				DistanceBands: []DistanceBand{
+					{UpToKm: 0, PerKm: Baht(9)},
+					{UpToKm: 300, Amount: Baht(1000), PerKm: Baht(12.5)},
+				},
+				WeightBreaks: []WeightBreak{{MinKg: 0, PerKg: Baht(1.2)}, {MinKg: 1000, PerKg: Baht(0.85)}},
+				PerPallet:    Baht(150),
+				MinCharge:    Baht(2500),
+			},
+			{OriginZone: AnyZone, DestinationZone: AnyZone, MinCharge: Baht(3000)},
+			{OriginZone: "BKK", DestinationZone: "NORTH", VehicleType: VehicleType("6w"), BaseCharge: Baht(800)},
+		},
+		Fuel: &FuelSurcharge{BasePrice: Baht(30), Step: Baht(1), StepPercent: 1.5},
+		Accessorials: []AccessorialRate{
+			{Code: "WAIT", Unit: AccessorialPerHour, Amount: Baht(300), FreeUnits: 1},
+			{Code: "TAILLIFT", Unit: AccessorialPerShipment, Amount: Baht(450)},
+		},
+	}
+}
+
+func TestRateCardPrice(t *testing.T) {
+	card := testRateCard()
+	diesel := &DieselPrice{EffectiveDate: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), PricePerLitre: Baht(32.99)}
+
+	quote, err := card.Price(PricingRequest{
+		OriginProvince:      "Bangkok",
+		DestinationProvince: "Chiang Mai",
+		DistanceKm:          687.4,
+		Load:                Load{WeightKg: 800, VolumeM3: 4, Pallets: 2},
+		Accessorials:        []AccessorialQuantity{{Code: "WAIT", Quantity: 2.5}, {Code: "TAILLIFT", Quantity: 3}},
+	}, diesel)
+	if err != nil {
+		t.Fatalf("Price: %v", err)
+	}
+
+	if quote.OriginZone != "BKK" || quote.DestinationZone != "NORTH" {
+		t.Errorf("zones = %s → %s, want BKK → NORTH", quote.OriginZone, quote.DestinationZone)
+	}
+	// 4 m3 × 250 kg/m3 = 1000 kg beats the 800 kg actual weight
+	if quote.ChargeableKg != 1000 {
+		t.Errorf("chargeable = %v kg, want 1000", quote.ChargeableKg)
+	}
+
+	want := []struct {
+		code   string
+		amount Money
+	}{
+		{LineBase, Baht(500)},
+		{LineDistance, Baht(6186.60)}, // 687.4 km × 9.00, unbounded band
+		{LineWeight, Baht(850)},       // 1000 kg × 0.85
+		{LinePallet, Baht(300)},
+		{LineFuelSurcharge, Baht(235.10)}, // 2 full steps × 1.5% = 3% of 7836.60
+		{LineAccessorial + ":WAIT", Baht(450)},
+		{LineAccessorial + ":TAILLIFT", Baht(450)},
+	}
+	if len(quote.Lines) != len(want) {
+		t.Fatalf("%d lines, want %d: %+v", len(quote.Lines), len(want), quote.Lines)
+	}
+	var total Money
+	for i, w := range want {
+		l := quote.Lines[i]
+		if l.Code != w.code || l.Amount != w.amount {
+			t.Errorf("line %d = %s %s, want %s %s", i, l.Code, l.Amount, w.code, w.amount)
+		}
+		total += l.Amount
+	}
+	if quote.Total != total {
+		t.Errorf("total = %s, want the sum of the lines %s", quote.Total, total)
+	}
+}
+
+func TestRateCardPriceMinimumCharge(t *testing.T) {
+	card := testRateCard()
+	card.Fuel = nil
+
+	quote, err := card.Price(PricingRequest{
+		OriginPostcode:      "10110",
+		DestinationProvince: "เชียงใหม่",
+		DistanceKm:          100,
+		Load:                Load{WeightKg: 10},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Price: %v", err)
+	}
+
+	// base 500 + band ≤ 300 km (1000 flat + 100 × 12.50) + 10 kg × 1.20 = 2762.00, above the 2500 minimum
+	if quote.Total != Baht(2762) {
+		t.Errorf("total = %s, want 2762.00", quote.Total)
+	}
+
+	quote, err = card.Price(PricingRequest{OriginProvince: "Phuket", DestinationProvince: "Chiang Mai"}, nil)
+	if err != nil {
+		t.Fatalf("Price: %v", err)
+	}
+	if len(quote.Lines) != 1 || quote.Lines[0].Code != LineMinimum || quote.Total != Baht(3000) {
+		t.Errorf("wildcard lane quote = %+v, want only the 3000.00 minimum charge", quote.Lines)
+	}
+}
+
+func TestRateCardPriceMostSpecificLane(t *testing.T) {
+	card := testRateCard()
+	card.Fuel = nil
+
+	quote, err := card.Price(PricingRequest{
+		OriginProvince:      "Bangkok",
+		DestinationProvince: "Chiang Mai",
+		VehicleType:         VehicleType("6w"),
+	}, nil)
+	if err != nil {
+		t.Fatalf("Price: %v", err)
+	}
+	if quote.Total != Baht(800) {
+		t.Errorf("total = %s, want the 6w lane base 800.00", quote.Total)
+	}
+}
+
+func TestRateCardPriceErrors(t *testing.T) {
+	card := testRateCard()
+	card.Lanes = card.Lanes[:1]
+	card.Lanes[0].DistanceBands = card.Lanes[0].DistanceBands[1:]
+
+	_, err := card.Price(PricingRequest{OriginProvince: "Bangkok", DestinationProvince: "Phuket"}, nil)
+	if !errors.Is(err, errs.ErrNoApplicableRate) {
+		t.Errorf("unknown lane: err = %v, want ErrNoApplicableRate", err)
+	}
+
+	_, err = card.Price(PricingRequest{OriginProvince: "Bangkok", DestinationProvince: "Chiang Mai", DistanceKm: 301}, nil)
+	if !errors.Is(err, errs.ErrNoApplicableRate) {
+		t.Errorf("beyond last band: err = %v, want ErrNoApplicableRate", err)
+	}
+
+	_, err = card.Price(PricingRequest{
+		OriginProvince:      "Bangkok",
+		DestinationProvince: "Chiang Mai",
+		Accessorials:        []AccessorialQuantity{{Code: "FERRY", Quantity: 1}},
+	}, nil)
+	var validationErrs errs.ValidationErrors
+	if !errors.As(err, &validationErrs) || validationErrs["accessorials[0].code"] == nil {
+		t.Errorf("unknown accessorial: err = %v, want a validation error on accessorials[0].code", err)
+	}
+}
+
+func TestFuelSurchargePercent(t *testing.T) {
+	fuel := &FuelSurcharge{BasePrice: Baht(30), Step: Baht(0.5), StepPercent: 1}
+	for _, tc := range []struct {
+		price Money
+		want  float64
+		steps int
+	}{
+		{Baht(29), 0, 0},
+		{Baht(30), 0, 0},
+		{Baht(30.49), 0, 0},
+		{Baht(30.5), 1, 1},
+		{Baht(32.99), 5, 5},
+	} {
+		percent, steps := fuel.percent(tc.price)
+		if percent != tc.want || steps != tc.steps {
+			t.Errorf("percent(%s) = %v%%, %d steps; want %v%%, %d", tc.price, percent, steps, tc.want, tc.steps)
+		}
+	}
+}
+
+func TestRateCardIsEffective(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 6, 0)
+	card := &RateCard{EffectiveFrom: from, EffectiveTo: &to}
+
+	for at, want := range map[time.Time]bool{
+		from.Add(-time.Second): false,
+		from:                   true,
+		to.Add(-time.Second):   true,
+		to:                     false,
+	} {
+		if got := card.IsEffective(at); got != want {
+			t.Errorf("IsEffective(%s) = %v, want %v", at, got, want)
+		}
+	}
+}
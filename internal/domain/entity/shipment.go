@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShipmentStatus represents the lifecycle status of a shipment
+type ShipmentStatus string
+
+const (
+	ShipmentStatusPending   ShipmentStatus = "pending"    // waiting to be planned on a trip
+	ShipmentStatusPlanned   ShipmentStatus = "planned"    // assigned to a trip
+	ShipmentStatusInTransit ShipmentStatus = "in_transit" // picked up
+	ShipmentStatusDelivered ShipmentStatus = "delivered"
+	ShipmentStatusFailed    ShipmentStatus = "failed" // delivery failed; a reattempt or return leg carries on
+	ShipmentStatusCancelled ShipmentStatus = "cancelled"
+)
+
+// ShipmentLeg represents why a shipment was booked
+type ShipmentLeg string
+
+const (
+	ShipmentLegOriginal  ShipmentLeg = "original"
+	ShipmentLegReattempt ShipmentLeg = "reattempt" // another attempt at a failed delivery
+	ShipmentLegReturn    ShipmentLeg = "return"    // takes undeliverable goods back to the pickup location
+)
+
+// Shipment represents a consignment to move from a pickup location to a delivery location (Pure Domain Entity)
+type Shipment struct {
+	ID                 uuid.UUID
+	OrganizationID     uuid.UUID
+	TrackingNumber     string // public identifier given to consignees
+	Reference          string
+	PickupLocationID   uuid.UUID
+	DeliveryLocationID uuid.UUID
+	WeightKg           float64
+	VolumeM3           float64
+	Pallets            int
+	PickupFrom         *time.Time
+	PickupTo           *time.Time
+	DeliverFrom        *time.Time
+	DeliverTo          *time.Time
+	Status             ShipmentStatus
+	Notes              string
+	ParentID           *uuid.UUID // the shipment whose failed delivery this leg follows up
+	Leg                ShipmentLeg
+	Attempt            int // which attempt at delivering to the delivery location this is, from 1
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	DeletedAt          *time.Time
+}
+
+// ShipmentStatusChange records a shipment moving to a status
+type ShipmentStatusChange struct {
+	ID         uuid.UUID
+	ShipmentID uuid.UUID
+	Status     ShipmentStatus
+	ChangedAt  time.Time
+}
+
+// Window returns the time window of the shipment's pickup or delivery
+func (s *Shipment) Window(t StopType) (from, to *time.Time) {
+	if t == StopTypePickup {
+		return s.PickupFrom, s.PickupTo
+	}
+	return s.DeliverFrom, s.DeliverTo
+}
+
+// Load returns the shipment's size for capacity checks
+func (s *Shipment) Load() Load {
+	return Load{WeightKg: s.WeightKg, VolumeM3: s.VolumeM3, Pallets: s.Pallets}
+}
+
+// FollowUpLeg returns the leg to book after the shipment's delivery failed: another attempt while fewer than
+// maxAttempts were made, otherwise a return of the goods from the delivery location to the pickup location.
+// A failed return is attempted again. The leg has no time windows until one is agreed.
+func (s *Shipment) FollowUpLeg(maxAttempts int) *Shipment {
+	parentID := s.ID
+	leg := &Shipment{
+		OrganizationID:     s.OrganizationID,
+		Reference:          s.Reference,
+		PickupLocationID:   s.PickupLocationID,
+		DeliveryLocationID: s.DeliveryLocationID,
+		WeightKg:           s.WeightKg,
+		VolumeM3:           s.VolumeM3,
+		Pallets:            s.Pallets,
+		Status:             ShipmentStatusPending,
+		Notes:              s.Notes,
+		ParentID:           &parentID,
+		Leg:                ShipmentLegReattempt,
+		Attempt:            s.Attempt + 1,
+	}
+	switch {
+	case s.Leg == ShipmentLegReturn:
+		leg.Leg = ShipmentLegReturn
+	case s.Attempt >= maxAttempts:
+		leg.Leg = ShipmentLegReturn
+		leg.PickupLocationID, leg.DeliveryLocationID = s.DeliveryLocationID, s.PickupLocationID
+		leg.Attempt = 1
+	}
+	return leg
+}
@@ -0,0 +1,232 @@
+package entity
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+
+	"github.com/google/uuid"
+)
+
+// TenderStatus represents the lifecycle status of a tender
+type TenderStatus string
+
+const (
+	TenderStatusOpen      TenderStatus = "open"      // an offer is waiting for a carrier's answer
+	TenderStatusAccepted  TenderStatus = "accepted"  // a carrier took the work
+	TenderStatusExhausted TenderStatus = "exhausted" // every carrier rejected or let the offer expire
+	TenderStatusCancelled TenderStatus = "cancelled"
+)
+
+// TenderOfferStatus represents the state of one carrier's offer within a tender
+type TenderOfferStatus string
+
+const (
+	TenderOfferQueued    TenderOfferStatus = "queued" // waiting for higher-ranked carriers
+	TenderOfferOffered   TenderOfferStatus = "offered"
+	TenderOfferAccepted  TenderOfferStatus = "accepted"
+	TenderOfferRejected  TenderOfferStatus = "rejected"
+	TenderOfferExpired   TenderOfferStatus = "expired"
+	TenderOfferCancelled TenderOfferStatus = "cancelled"
+)
+
+// TenderEventType represents a recorded tender state change
+type TenderEventType string
+
+const (
+	TenderEventOffered   TenderEventType = "offered"
+	TenderEventAccepted  TenderEventType = "accepted"
+	TenderEventRejected  TenderEventType = "rejected"
+	TenderEventExpired   TenderEventType = "expired"
+	TenderEventExhausted TenderEventType = "exhausted"
+	TenderEventCancelled TenderEventType = "cancelled"
+)
+
+// TenderSummary is a snapshot of the offered work taken when the tender is created,
+// so carriers see what they are accepting without access to the shipment or trip
+type TenderSummary struct {
+	VehicleType         VehicleType
+	OriginProvince      string
+	DestinationProvince string
+	PickupAt            *time.Time
+	DeliverBy           *time.Time
+	Load                Load
+}
+
+// Tender offers a shipment or a trip to carriers one at a time in rank order (a waterfall).
+// Each offer is open for ResponseWindow; a rejection or timeout rolls the tender over to the next carrier.
+type Tender struct {
+	ID               uuid.UUID
+	ShipmentID       *uuid.UUID
+	TripID           *uuid.UUID
+	Status           TenderStatus
+	ResponseWindow   time.Duration
+	Summary          TenderSummary
+	Notes            string
+	AwardedCarrierID *uuid.UUID
+	Offers           []TenderOffer // ordered by rank
+	Events           []TenderEvent // in order of occurrence
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// TenderOffer is the offer of a tender to one carrier
+type TenderOffer struct {
+	ID          uuid.UUID
+	TenderID    uuid.UUID
+	CarrierID   uuid.UUID
+	Rank        int
+	Status      TenderOfferStatus
+	OfferedAt   *time.Time
+	Deadline    *time.Time
+	RespondedAt *time.Time
+	Reason      string
+}
+
+// TenderEvent records a tender state change. Events without an ID have not been persisted yet.
+type TenderEvent struct {
+	ID         uuid.UUID
+	TenderID   uuid.UUID
+	Sequence   int
+	CarrierID  *uuid.UUID
+	Type       TenderEventType
+	Note       string
+	OccurredAt time.Time
+}
+
+// IsOpen reports whether the tender is still waiting for a carrier
+func (t *Tender) IsOpen() bool {
+	return t.Status == TenderStatusOpen
+}
+
+// CurrentOffer returns the offer waiting for an answer, if any
+func (t *Tender) CurrentOffer() *TenderOffer {
+	for i := range t.Offers {
+		if t.Offers[i].Status == TenderOfferOffered {
+			return &t.Offers[i]
+		}
+	}
+	return nil
+}
+
+// OfferTo returns the offer made to the carrier, if any
+func (t *Tender) OfferTo(carrierID uuid.UUID) *TenderOffer {
+	for i := range t.Offers {
+		if t.Offers[i].CarrierID == carrierID {
+			return &t.Offers[i]
+		}
+	}
+	return nil
+}
+
+// Start opens the tender by offering it to the first-ranked carrier
+func (t *Tender) Start(now time.Time) {
+	t.Status = TenderStatusOpen
+	t.advance(now)
+}
+
+// Accept awards the tender to the carrier holding the current offer.
+// It returns errs.ErrInvalidStatusTransition when the carrier has no open offer
+// and errs.ErrOfferExpired when the answer arrives after the deadline.
+func (t *Tender) Accept(carrierID uuid.UUID, now time.Time) error {
+	offer, err := t.openOfferFor(carrierID, now)
+	if err != nil {
+		return err
+	}
+
+	offer.Status = TenderOfferAccepted
+	offer.RespondedAt = &now
+	t.Status = TenderStatusAccepted
+	t.AwardedCarrierID = &carrierID
+	t.record(TenderEventAccepted, &carrierID, "", now)
+	return nil
+}
+
+// Reject records the carrier's refusal and rolls the tender over to the next carrier
+func (t *Tender) Reject(carrierID uuid.UUID, reason string, now time.Time) error {
+	offer, err := t.openOfferFor(carrierID, now)
+	if err != nil {
+		return err
+	}
+
+	offer.Status = TenderOfferRejected
+	offer.RespondedAt = &now
+	offer.Reason = reason
+	t.record(TenderEventRejected, &carrierID, reason, now)
+	t.advance(now)
+	return nil
+}
+
+// ExpireOverdue rolls the tender over when the current offer's deadline has passed.
+// It reports whether anything changed.
+func (t *Tender) ExpireOverdue(now time.Time) bool {
+	if !t.IsOpen() {
+		return false
+	}
+	offer := t.CurrentOffer()
+	if offer == nil || offer.Deadline == nil || now.Before(*offer.Deadline) {
+		return false
+	}
+
+	offer.Status = TenderOfferExpired
+	t.record(TenderEventExpired, &offer.CarrierID, "", now)
+	t.advance(now)
+	return true
+}
+
+// Cancel withdraws an open tender and every offer still pending
+func (t *Tender) Cancel(reason string, now time.Time) error {
+	if !t.IsOpen() {
+		return errs.ErrInvalidStatusTransition
+	}
+
+	for i := range t.Offers {
+		if o := &t.Offers[i]; o.Status == TenderOfferQueued || o.Status == TenderOfferOffered {
+			o.Status = TenderOfferCancelled
+		}
+	}
+	t.Status = TenderStatusCancelled
+	t.record(TenderEventCancelled, nil, reason, now)
+	return nil
+}
+
+func (t *Tender) openOfferFor(carrierID uuid.UUID, now time.Time) (*TenderOffer, error) {
+	offer := t.CurrentOffer()
+	if !t.IsOpen() || offer == nil || offer.CarrierID != carrierID {
+		return nil, errs.ErrInvalidStatusTransition
+	}
+	if offer.Deadline != nil && !now.Before(*offer.Deadline) {
+		return nil, errs.ErrOfferExpired
+	}
+	return offer, nil
+}
+
+// advance offers the tender to the next queued carrier, or marks it exhausted when none is left
+func (t *Tender) advance(now time.Time) {
+	for i := range t.Offers {
+		o := &t.Offers[i]
+		if o.Status != TenderOfferQueued {
+			continue
+		}
+		deadline := now.Add(t.ResponseWindow)
+		o.Status = TenderOfferOffered
+		o.OfferedAt = &now
+		o.Deadline = &deadline
+		t.record(TenderEventOffered, &o.CarrierID, "", now)
+		return
+	}
+
+	t.Status = TenderStatusExhausted
+	t.record(TenderEventExhausted, nil, "", now)
+}
+
+func (t *Tender) record(eventType TenderEventType, carrierID *uuid.UUID, note string, at time.Time) {
+	t.Events = append(t.Events, TenderEvent{
+		TenderID:   t.ID,
+		Sequence:   len(t.Events) + 1,
+		CarrierID:  carrierID,
+		Type:       eventType,
+		Note:       note,
+		OccurredAt: at,
+	})
+}
@@ -0,0 +1,122 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+
+	"github.com/google/uuid"
+)
+
+func testTender(carriers ...uuid.UUID) *Tender {
+	t := &Tender{ResponseWindow: time.Hour}
+	for i, id := range carriers {
+		t.Offers = append(t.Offers, TenderOffer{CarrierID: id, Rank: i + 1, Status: TenderOfferQueued})
+	}
+	return t
+}
+
+func TestTenderWaterfall(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	tender := testTender(first, second)
+	tender.Start(now)
+
+	if offer := tender.CurrentOffer(); offer == nil || offer.CarrierID != first {
+		t.Fatalf("current offer = %+v, want the first carrier", offer)
+	}
+	if err := tender.Accept(second, now); !errors.Is(err, errs.ErrInvalidStatusTransition) {
+		t.Errorf("accept out of turn: err = %v, want ErrInvalidStatusTransition", err)
+	}
+
+	if err := tender.Reject(first, "no truck", now.Add(time.Minute)); err != nil {
+		t.Fatalf("Reject: %v", err)
+	}
+	offer := tender.CurrentOffer()
+	if offer == nil || offer.CarrierID != second {
+		t.Fatalf("current offer = %+v, want the second carrier", offer)
+	}
+	if want := now.Add(time.Minute + time.Hour); !offer.Deadline.Equal(want) {
+		t.Errorf("deadline = %s, want %s", offer.Deadline, want)
+	}
+
+	if err := tender.Accept(second, now.Add(30*time.Minute)); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	if tender.Status != TenderStatusAccepted || tender.AwardedCarrierID == nil || *tender.AwardedCarrierID != second {
+		t.Errorf("status = %s, awarded = %v; want accepted by the second carrier", tender.Status, tender.AwardedCarrierID)
+	}
+
+	var types []TenderEventType
+	for i, e := range tender.Events {
+		if e.Sequence != i+1 {
+			t.Errorf("event %d has sequence %d", i, e.Sequence)
+		}
+		types = append(types, e.Type)
+	}
+	want := []TenderEventType{TenderEventOffered, TenderEventRejected, TenderEventOffered, TenderEventAccepted}
+	if len(types) != len(want) {
+		t.Fatalf("events = %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Errorf("events = %v, want %v", types, want)
+			break
+		}
+	}
+}
+
+func TestTenderExpireOverdue(t *testing.T) {
+	carrier := uuid.New()
+	now := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	tender := testTender(carrier)
+	tender.Start(now)
+
+	if tender.ExpireOverdue(now.Add(59 * time.Minute)) {
+		t.Error("expired before the deadline")
+	}
+	if err := tender.Accept(carrier, now.Add(time.Hour)); !errors.Is(err, errs.ErrOfferExpired) {
+		t.Errorf("accept at the deadline: err = %v, want ErrOfferExpired", err)
+	}
+	if !tender.ExpireOverdue(now.Add(time.Hour)) {
+		t.Fatal("not expired at the deadline")
+	}
+	if tender.Status != TenderStatusExhausted {
+		t.Errorf("status = %s, want exhausted once the last carrier expired", tender.Status)
+	}
+	if tender.ExpireOverdue(now.Add(2 * time.Hour)) {
+		t.Error("an exhausted tender changed again")
+	}
+}
+
+func TestTenderCancel(t *testing.T) {
+	tender := testTender(uuid.New(), uuid.New())
+	now := time.Now()
+	tender.Start(now)
+
+	if err := tender.Cancel("planned in house", now); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	for _, o := range tender.Offers {
+		if o.Status != TenderOfferCancelled {
+			t.Errorf("offer to rank %d is %s, want cancelled", o.Rank, o.Status)
+		}
+	}
+	if err := tender.Cancel("again", now); !errors.Is(err, errs.ErrInvalidStatusTransition) {
+		t.Errorf("second cancel: err = %v, want ErrInvalidStatusTransition", err)
+	}
+}
+
+func TestTripAwardedToCarrierIsNotEditable(t *testing.T) {
+	trip := &Trip{Status: TripStatusPlanned}
+	if !trip.IsEditable() {
+		t.Fatal("a planned trip is not editable")
+	}
+	carrier := uuid.New()
+	trip.CarrierID = &carrier
+	if trip.IsEditable() {
+		t.Error("a trip awarded to a carrier is still editable")
+	}
+}
@@ -0,0 +1,211 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TripStatus represents the lifecycle status of a trip
+type TripStatus string
+
+const (
+	TripStatusPlanned    TripStatus = "planned"
+	TripStatusDispatched TripStatus = "dispatched"
+	TripStatusInProgress TripStatus = "in_progress"
+	TripStatusCompleted  TripStatus = "completed"
+	TripStatusCancelled  TripStatus = "cancelled"
+)
+
+// tripTransitions lists the statuses each trip status may move to
+var tripTransitions = map[TripStatus][]TripStatus{
+	TripStatusPlanned:    {TripStatusDispatched, TripStatusCancelled},
+	TripStatusDispatched: {TripStatusInProgress, TripStatusPlanned, TripStatusCancelled},
+	TripStatusInProgress: {TripStatusCompleted},
+}
+
+// StopType represents what happens at a trip stop
+type StopType string
+
+const (
+	StopTypePickup   StopType = "pickup"
+	StopTypeDelivery StopType = "delivery"
+)
+
+// StopStatus represents the progress of a vehicle through a trip stop
+type StopStatus string
+
+const (
+	StopStatusPending  StopStatus = "pending"
+	StopStatusArrived  StopStatus = "arrived"
+	StopStatusDeparted StopStatus = "departed"
+)
+
+// Trip represents a vehicle run with an ordered list of stops (Pure Domain Entity)
+type Trip struct {
+	ID           uuid.UUID
+	Number       string // human-friendly trip number, e.g. TRP-2603-00042
+	VehicleID    uuid.UUID
+	DriverID     uuid.UUID
+	CoDriverID   *uuid.UUID
+	Status       TripStatus
+	PlannedStart time.Time
+	PlannedEnd   time.Time
+	Notes        string
+	DispatchedBy *uuid.UUID // the dispatcher responsible for the trip, set when it is dispatched
+	CarrierID    *uuid.UUID // the carrier the trip was awarded to through a tender
+	TenderID     *uuid.UUID // the tender that awarded it
+	Stops        []TripStop
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// TripStop represents one pickup or delivery of a shipment on a trip
+type TripStop struct {
+	ID             uuid.UUID
+	TripID         uuid.UUID
+	Sequence       int
+	Type           StopType
+	ShipmentID     uuid.UUID
+	LocationID     uuid.UUID
+	PlannedArrival *time.Time
+	ServiceTime    *time.Duration // planned time at the stop; a default applies when nil
+	Status         StopStatus
+	ArrivedAt      *time.Time
+	DepartedAt     *time.Time
+	ETA            *time.Time // predicted arrival while the stop is pending
+	DelayedAt      *time.Time // when the ETA was found to miss the stop's time window; cleared on recovery
+}
+
+// IsActive reports whether the trip still occupies its vehicle and drivers
+func (t *Trip) IsActive() bool {
+	return t.Status != TripStatusCompleted && t.Status != TripStatusCancelled
+}
+
+// IsEditable reports whether the trip's assignment and stops may still be changed.
+// A trip awarded to a carrier is run as tendered.
+func (t *Trip) IsEditable() bool {
+	return t.Status == TripStatusPlanned && t.CarrierID == nil
+}
+
+// CanTransitionTo reports whether the trip may move to the given status
+func (t *Trip) CanTransitionTo(next TripStatus) bool {
+	for _, s := range tripTransitions[t.Status] {
+		if s == next {
+			return true
+		}
+	}
+	return false
+}
+
+// DriverIDs returns the IDs of the driver and co-driver, if any
+func (t *Trip) DriverIDs() []uuid.UUID {
+	ids := []uuid.UUID{t.DriverID}
+	if t.CoDriverID != nil {
+		ids = append(ids, *t.CoDriverID)
+	}
+	return ids
+}
+
+// ShipmentIDs returns the distinct shipments served by the trip in stop order
+func (t *Trip) ShipmentIDs() []uuid.UUID {
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, s := range t.Stops {
+		if !seen[s.ShipmentID] {
+			seen[s.ShipmentID] = true
+			ids = append(ids, s.ShipmentID)
+		}
+	}
+	return ids
+}
+
+// PeakLoad returns the largest load carried between any two stops.
+// Shipments without a pickup stop are loaded before departure; shipments are unloaded at their delivery stop.
+func (t *Trip) PeakLoad(shipments map[uuid.UUID]*Shipment) Load {
+	var onboard Load
+	pickedUp := make(map[uuid.UUID]bool)
+	for _, s := range t.Stops {
+		if s.Type == StopTypePickup {
+			pickedUp[s.ShipmentID] = true
+		}
+	}
+	for id, sh := range shipments {
+		if !pickedUp[id] {
+			onboard = onboard.Add(sh.Load())
+		}
+	}
+
+	peak := onboard
+	for _, s := range t.Stops {
+		sh, ok := shipments[s.ShipmentID]
+		if !ok {
+			continue
+		}
+		switch s.Type {
+		case StopTypePickup:
+			onboard = onboard.Add(sh.Load())
+		case StopTypeDelivery:
+			onboard = onboard.Sub(sh.Load())
+		}
+		peak = peak.Max(onboard)
+	}
+	return peak
+}
+
+// Stop returns the stop with the given ID
+func (t *Trip) Stop(id uuid.UUID) (*TripStop, bool) {
+	for i := range t.Stops {
+		if t.Stops[i].ID == id {
+			return &t.Stops[i], true
+		}
+	}
+	return nil, false
+}
+
+// IsDrivenBy reports whether the driver is the trip's driver or co-driver
+func (t *Trip) IsDrivenBy(driverID uuid.UUID) bool {
+	for _, id := range t.DriverIDs() {
+		if id == driverID {
+			return true
+		}
+	}
+	return false
+}
+
+// ArriveAt marks the vehicle as arrived at the next pending stop at the location, together with the
+// pending stops that directly follow it at the same location, and returns the stops it changed
+func (t *Trip) ArriveAt(locationID uuid.UUID, at time.Time) []*TripStop {
+	var arrived []*TripStop
+	for i := range t.Stops {
+		s := &t.Stops[i]
+		if s.Status != StopStatusPending {
+			continue
+		}
+		if s.LocationID != locationID {
+			if len(arrived) > 0 {
+				break
+			}
+			continue
+		}
+		s.Status = StopStatusArrived
+		s.ArrivedAt = &at
+		arrived = append(arrived, s)
+	}
+	return arrived
+}
+
+// DepartFrom marks the vehicle as departed from the stops it has arrived at at the location
+// and returns the stops it changed
+func (t *Trip) DepartFrom(locationID uuid.UUID, at time.Time) []*TripStop {
+	var departed []*TripStop
+	for i := range t.Stops {
+		s := &t.Stops[i]
+		if s.Status == StopStatusArrived && s.LocationID == locationID {
+			s.Status = StopStatusDeparted
+			s.DepartedAt = &at
+			departed = append(departed, s)
+		}
+	}
+	return departed
+}
@@ -6,6 +6,16 @@ import (
 	"github.com/google/uuid"
 )
 
+// UserRole is what a user may do beyond their own account
+type UserRole string
+
+const (
+	// UserRoleUser is every self-registered account; what it sees follows from its carrier or driver profile
+	UserRoleUser UserRole = "user"
+	// UserRoleStaff is back-office staff of the operator. It is granted in the database, never through the API.
+	UserRoleStaff UserRole = "staff"
+)
+
 // User represents a user in the system (Pure Domain Entity)
 type User struct {
 	ID           uuid.UUID
@@ -17,7 +27,13 @@ type User struct {
 	AvatarURL    string
 	GoogleID     *string
 	LineID       *string
+	Role         UserRole
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 	DeletedAt    *time.Time
 }
+
+// IsStaff reports whether the user works in the back office
+func (u *User) IsStaff() bool {
+	return u.Role == UserRoleStaff
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VehicleType represents the class of a vehicle by axle configuration
+type VehicleType string
+
+const (
+	VehicleType4W  VehicleType = "4w"  // pickup / 4-wheel light truck
+	VehicleType6W  VehicleType = "6w"  // 6-wheel medium truck
+	VehicleType10W VehicleType = "10w" // 10-wheel heavy truck
+	VehicleType18W VehicleType = "18w" // tractor with semi-trailer
+)
+
+// VehicleStatus represents the operational status of a vehicle
+type VehicleStatus string
+
+const (
+	VehicleStatusActive      VehicleStatus = "active"
+	VehicleStatusMaintenance VehicleStatus = "maintenance"
+	VehicleStatusInactive    VehicleStatus = "inactive"
+)
+
+// Vehicle represents a fleet vehicle (Pure Domain Entity)
+type Vehicle struct {
+	ID            uuid.UUID
+	PlateNumber   string
+	PlateProvince string
+	Type          VehicleType
+	Brand         string
+	Model         string
+	Year          int
+	MaxWeightKg   float64
+	MaxVolumeM3   float64
+	MaxPallets    int
+	HomeDepot     string
+	GPSDeviceID   *string // telematics unit reporting positions for the vehicle
+	FuelBaseline  float64 // expected km per litre; zero uses the default for the vehicle type
+	Status        VehicleStatus
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     *time.Time
+}
+
+// Capacity returns the vehicle's payload limits
+func (v *Vehicle) Capacity() Load {
+	return Load{WeightKg: v.MaxWeightKg, VolumeM3: v.MaxVolumeM3, Pallets: v.MaxPallets}
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FuelLog records a fuel fill-up of a vehicle. Efficiency is worked out on full-tank fill-ups only:
+// the distance since the previous full tank over the litres bought since then, this fill-up included.
+type FuelLog struct {
+	ID            uuid.UUID
+	VehicleID     uuid.UUID
+	DriverID      *uuid.UUID
+	FilledAt      time.Time
+	Liters        float64
+	PricePerLiter Money
+	Amount        Money // paid
+	OdometerKm    float64
+	Station       string
+	FullTank      bool
+	ReceiptKey    string // object key of the receipt photo
+	Notes         string
+
+	DistanceKm         float64  // since the previous full tank; zero when not rated
+	EfficiencyLiters   float64  // litres bought since the previous full tank
+	KmPerLiter         *float64 // nil when the fill-up cannot be rated
+	BaselineKmPerLiter float64  // what the vehicle is expected to do when rated
+	Deviation          *float64 // fraction off the baseline, negative when worse
+	Flagged            bool     // deviates from the baseline beyond the tolerance
+
+	CreatedBy *uuid.UUID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Rate sets the fill-up's efficiency from the distance driven on the litres, and flags it when it is off
+// the baseline by more than the tolerance either way: far worse may mean fuel going missing, far better
+// usually a wrong odometer reading or a fill-up not recorded. A zero baseline rates without flagging.
+func (f *FuelLog) Rate(distanceKm, liters, baseline, tolerance float64) {
+	f.ClearRating()
+	if distanceKm <= 0 || liters <= 0 {
+		return
+	}
+	kmPerLiter := math.Round(distanceKm/liters*100) / 100
+	f.DistanceKm = distanceKm
+	f.EfficiencyLiters = liters
+	f.KmPerLiter = &kmPerLiter
+	if baseline <= 0 {
+		return
+	}
+	deviation := math.Round((kmPerLiter-baseline)/baseline*1000) / 1000
+	f.BaselineKmPerLiter = baseline
+	f.Deviation = &deviation
+	f.Flagged = math.Abs(deviation) > tolerance
+}
+
+// ClearRating removes the fill-up's efficiency, e.g. when it is not a full tank or has no earlier full tank
+func (f *FuelLog) ClearRating() {
+	f.DistanceKm = 0
+	f.EfficiencyLiters = 0
+	f.KmPerLiter = nil
+	f.BaselineKmPerLiter = 0
+	f.Deviation = nil
+	f.Flagged = false
+}
+
+// ExpenseType represents what a vehicle expense was for
+type ExpenseType string
+
+const (
+	ExpenseToll        ExpenseType = "toll"
+	ExpenseParking     ExpenseType = "parking"
+	ExpenseRepair      ExpenseType = "repair"
+	ExpenseMaintenance ExpenseType = "maintenance"
+	ExpenseTyre        ExpenseType = "tyre"
+	ExpenseFine        ExpenseType = "fine"
+	ExpenseOther       ExpenseType = "other"
+)
+
+// VehicleExpense records a running cost of a vehicle other than fuel
+type VehicleExpense struct {
+	ID          uuid.UUID
+	VehicleID   uuid.UUID
+	DriverID    *uuid.UUID
+	TripID      *uuid.UUID
+	Type        ExpenseType
+	IncurredAt  time.Time
+	Amount      Money // paid
+	Description string
+	Reference   string // receipt, invoice or ticket number
+	ReceiptKey  string // object key of the receipt photo
+	CreatedBy   *uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
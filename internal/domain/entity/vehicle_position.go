@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VehiclePosition is a GPS fix reported for a vehicle (Pure Domain Entity).
+// A vehicle has at most one position per RecordedAt; repeats are duplicates.
+type VehiclePosition struct {
+	VehicleID  uuid.UUID
+	DeviceID   string
+	RecordedAt time.Time // when the device took the fix
+	Latitude   float64
+	Longitude  float64
+	SpeedKmh   *float64
+	Heading    *float64 // degrees clockwise from north
+	OdometerKm *float64
+	ReceivedAt time.Time // when the server accepted the fix
+}
+
+// IsValid reports whether the fix can be stored: a timestamp, coordinates on the globe and,
+// when given, a speed, heading and odometer reading in range
+func (p *VehiclePosition) IsValid() bool {
+	if p.RecordedAt.IsZero() || !finite(p.Latitude) || !finite(p.Longitude) ||
+		math.Abs(p.Latitude) > 90 || math.Abs(p.Longitude) > 180 {
+		return false
+	}
+	if p.SpeedKmh != nil && (!finite(*p.SpeedKmh) || *p.SpeedKmh < 0) {
+		return false
+	}
+	if p.Heading != nil && (!finite(*p.Heading) || *p.Heading < 0 || *p.Heading >= 360) {
+		return false
+	}
+	if p.OdometerKm != nil && (!finite(*p.OdometerKm) || *p.OdometerKm < 0) {
+		return false
+	}
+	return true
+}
+
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestVehiclePositionIsValid(t *testing.T) {
+	value := func(v float64) *float64 { return &v }
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		p    VehiclePosition
+		want bool
+	}{
+		"valid":             {VehiclePosition{RecordedAt: at, Latitude: 13.75, Longitude: 100.5, SpeedKmh: value(62), Heading: value(359.9)}, true},
+		"no timestamp":      {VehiclePosition{Latitude: 13.75, Longitude: 100.5}, false},
+		"latitude":          {VehiclePosition{RecordedAt: at, Latitude: 91, Longitude: 100.5}, false},
+		"longitude":         {VehiclePosition{RecordedAt: at, Latitude: 13.75, Longitude: -180.5}, false},
+		"not a number":      {VehiclePosition{RecordedAt: at, Latitude: math.NaN(), Longitude: 100.5}, false},
+		"negative speed":    {VehiclePosition{RecordedAt: at, Latitude: 13.75, Longitude: 100.5, SpeedKmh: value(-1)}, false},
+		"full circle":       {VehiclePosition{RecordedAt: at, Latitude: 13.75, Longitude: 100.5, Heading: value(360)}, false},
+		"infinite odometer": {VehiclePosition{RecordedAt: at, Latitude: 13.75, Longitude: 100.5, OdometerKm: value(math.Inf(1))}, false},
+	} {
+		if got := tc.p.IsValid(); got != tc.want {
+			t.Errorf("%s: IsValid() = %v, want %v", name, got, tc.want)
+		}
+	}
+}
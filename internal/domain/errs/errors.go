@@ -30,6 +30,57 @@ var (
 
 	// ErrTokenInvalid indicates the JWT token is invalid
 	ErrTokenInvalid = errors.New("token invalid")
+
+	// ErrDriverLicenseExpired indicates the driver's license has expired
+	ErrDriverLicenseExpired = errors.New("driver license expired")
+
+	// ErrDriverUnavailable indicates the driver cannot take new work in the current status
+	ErrDriverUnavailable = errors.New("driver unavailable")
+
+	// ErrVehicleUnavailable indicates the vehicle cannot take new work in the current status
+	ErrVehicleUnavailable = errors.New("vehicle unavailable")
+
+	// ErrMaintenanceOverdue indicates the vehicle is past due for preventive maintenance
+	ErrMaintenanceOverdue = errors.New("maintenance overdue")
+
+	// ErrComplianceExpired indicates a vehicle, driver or carrier document required for the work has expired
+	ErrComplianceExpired = errors.New("compliance document expired")
+
+	// ErrCapacityExceeded indicates the planned load exceeds the vehicle's capacity
+	ErrCapacityExceeded = errors.New("capacity exceeded")
+
+	// ErrScheduleConflict indicates a vehicle or driver is already booked for an overlapping period
+	ErrScheduleConflict = errors.New("schedule conflict")
+
+	// ErrSlotUnavailable indicates the dock is fully booked for part of the requested period
+	ErrSlotUnavailable = errors.New("dock slot unavailable")
+
+	// ErrShipmentUnavailable indicates the shipment cannot be planned in its current status
+	ErrShipmentUnavailable = errors.New("shipment unavailable")
+
+	// ErrInvalidStatusTransition indicates the requested status change is not allowed
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+	// ErrResourceLocked indicates the resource can no longer be modified in its current status
+	ErrResourceLocked = errors.New("resource locked")
+
+	// ErrNoApplicableRate indicates no rate card, lane or band prices the request
+	ErrNoApplicableRate = errors.New("no applicable rate")
+
+	// ErrCarrierIneligible indicates the carrier cannot be offered the work
+	ErrCarrierIneligible = errors.New("carrier ineligible")
+
+	// ErrOfferExpired indicates the answer to a tender offer arrived after its deadline
+	ErrOfferExpired = errors.New("offer expired")
+
+	// ErrOutOfRange indicates a device position is too far from where the event should have happened
+	ErrOutOfRange = errors.New("position out of range")
+
+	// ErrChallengeFailed indicates the postcode or phone digits given to view a tracking page do not match
+	ErrChallengeFailed = errors.New("tracking challenge failed")
+
+	// ErrServiceBusy indicates the service has no capacity left for the request; it may be retried later
+	ErrServiceBusy = errors.New("service busy")
 )
 
 // ValidationError represents field-specific validation errors
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// CarrierFilter holds optional criteria for listing carriers
+type CarrierFilter struct {
+	Status      *entity.CarrierStatus
+	VehicleType *entity.VehicleType
+	Province    string // carriers serving the province, including nationwide ones
+	Search      string // name or tax ID
+}
+
+// CarrierRepository defines the interface for carrier data operations
+type CarrierRepository interface {
+	// FindByID retrieves a carrier by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Carrier, error)
+
+	// FindByIDs retrieves the carriers with the given IDs; missing IDs are skipped
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Carrier, error)
+
+	// FindByUserID retrieves the carrier a user account belongs to
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Carrier, error)
+
+	// Create creates a new carrier
+	Create(ctx context.Context, carrier *entity.Carrier) error
+
+	// Update updates an existing carrier
+	Update(ctx context.Context, carrier *entity.Carrier) error
+
+	// Delete soft deletes a carrier
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves carriers matching the filter with pagination
+	List(ctx context.Context, filter CarrierFilter, limit, offset int) ([]*entity.Carrier, int64, error)
+
+	// AddUser links a user account to the carrier. A user belongs to at most one carrier.
+	AddUser(ctx context.Context, carrierID, userID uuid.UUID) error
+
+	// RemoveUser unlinks a user account from the carrier
+	RemoveUser(ctx context.Context, carrierID, userID uuid.UUID) error
+
+	// ListUserIDs retrieves the user accounts linked to the carrier
+	ListUserIDs(ctx context.Context, carrierID uuid.UUID) ([]uuid.UUID, error)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ComplianceDocumentFilter holds optional criteria for listing compliance documents.
+// ExpiringBefore keeps the documents that count for their owner and expire on or before the given day.
+type ComplianceDocumentFilter struct {
+	OwnerType      *entity.ComplianceOwnerType
+	OwnerID        *uuid.UUID
+	Type           *entity.ComplianceDocumentType
+	ExpiringBefore *time.Time
+}
+
+// ComplianceDocumentRepository defines the interface for compliance document data operations
+type ComplianceDocumentRepository interface {
+	// FindByID retrieves a document by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.ComplianceDocument, error)
+
+	// FindByIDForUpdate retrieves a document and locks it until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.ComplianceDocument, error)
+
+	// Create creates a new document
+	Create(ctx context.Context, doc *entity.ComplianceDocument) error
+
+	// Update updates an existing document
+	Update(ctx context.Context, doc *entity.ComplianceDocument) error
+
+	// Delete deletes a document
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves documents matching the filter with pagination, soonest expiry first
+	List(ctx context.Context, filter ComplianceDocumentFilter, limit, offset int) ([]*entity.ComplianceDocument, int64, error)
+
+	// ListUnreminded retrieves the documents that count for their owner, expire on or before the given day
+	// and whose expiry has not been announced yet, soonest expiry first
+	ListUnreminded(ctx context.Context, before time.Time, limit, offset int) ([]*entity.ComplianceDocument, error)
+
+	// FindExpired retrieves the documents of the given types that count for the owners and expired before the given day
+	FindExpired(ctx context.Context, owners []entity.ComplianceOwner, types []entity.ComplianceDocumentType, today time.Time) ([]*entity.ComplianceDocument, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryAttemptRepository defines the interface for failed delivery attempt data operations
+type DeliveryAttemptRepository interface {
+	// FindByStopID retrieves the failed attempt recorded for a trip stop
+	FindByStopID(ctx context.Context, stopID uuid.UUID) (*entity.DeliveryAttempt, error)
+
+	// FindByShipmentID retrieves the failed attempt at delivering a shipment
+	FindByShipmentID(ctx context.Context, shipmentID uuid.UUID) (*entity.DeliveryAttempt, error)
+
+	// Create records a failed attempt; a second one for the same stop or shipment is a conflict
+	Create(ctx context.Context, attempt *entity.DeliveryAttempt) error
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// DockFilter holds optional criteria for listing docks
+type DockFilter struct {
+	LocationID *uuid.UUID
+	Direction  *entity.DockDirection // docks handling the direction, both-way docks included
+	Active     *bool
+}
+
+// DockRepository defines the interface for dock data operations
+type DockRepository interface {
+	// FindByID retrieves a dock by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Dock, error)
+
+	// FindByIDForUpdate retrieves a dock and locks it until the surrounding transaction ends,
+	// which serializes the bookings of the dock
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Dock, error)
+
+	// Create creates a new dock
+	Create(ctx context.Context, dock *entity.Dock) error
+
+	// Update updates an existing dock
+	Update(ctx context.Context, dock *entity.Dock) error
+
+	// List retrieves docks matching the filter with pagination, by site and name
+	List(ctx context.Context, filter DockFilter, limit, offset int) ([]*entity.Dock, int64, error)
+}
+
+// DockAppointmentFilter holds optional criteria for listing dock appointments.
+// From and To bound the booked start, [From, To).
+type DockAppointmentFilter struct {
+	LocationID *uuid.UUID
+	DockID     *uuid.UUID
+	TripID     *uuid.UUID
+	CarrierID  *uuid.UUID
+	Status     *entity.DockAppointmentStatus
+	From       *time.Time
+	To         *time.Time
+}
+
+// DockTurnaround summarizes the appointments of a dock. Wait is from the booked start to check-in, negative
+// when early; turnaround is from check-in to check-out. Both are averaged over the completed appointments.
+type DockTurnaround struct {
+	DockID               uuid.UUID
+	Appointments         int
+	Completed            int
+	NoShows              int
+	Cancelled            int
+	AvgWaitMinutes       float64
+	AvgTurnaroundMinutes float64
+	MaxTurnaroundMinutes float64
+}
+
+// DockAppointmentRepository defines the interface for dock appointment data operations
+type DockAppointmentRepository interface {
+	// FindByID retrieves an appointment by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.DockAppointment, error)
+
+	// FindByIDForUpdate retrieves an appointment and locks it until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.DockAppointment, error)
+
+	// Create creates a new appointment
+	Create(ctx context.Context, appointment *entity.DockAppointment) error
+
+	// Update updates an existing appointment
+	Update(ctx context.Context, appointment *entity.DockAppointment) error
+
+	// List retrieves appointments matching the filter with pagination, by booked start
+	List(ctx context.Context, filter DockAppointmentFilter, limit, offset int) ([]*entity.DockAppointment, int64, error)
+
+	// FindOccupying retrieves the appointments holding the dock at any time during [start, end), leaving out excludeID
+	FindOccupying(ctx context.Context, dockID uuid.UUID, start, end time.Time, excludeID *uuid.UUID) ([]*entity.DockAppointment, error)
+
+	// FindOccupyingByTrip retrieves the trip's appointments holding any dock during [start, end), leaving out excludeID
+	FindOccupyingByTrip(ctx context.Context, tripID uuid.UUID, start, end time.Time, excludeID *uuid.UUID) ([]*entity.DockAppointment, error)
+
+	// Turnaround summarizes the appointments matching the filter per dock
+	Turnaround(ctx context.Context, filter DockAppointmentFilter) ([]DockTurnaround, error)
+}
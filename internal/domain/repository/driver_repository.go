@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// DriverFilter holds optional criteria for listing drivers
+type DriverFilter struct {
+	Status *entity.DriverStatus
+	Search string
+}
+
+// DriverRepository defines the interface for driver data operations
+type DriverRepository interface {
+	// FindByID retrieves a driver by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Driver, error)
+
+	// FindByIDForUpdate retrieves a driver and locks its row until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Driver, error)
+
+	// FindByUserID retrieves a driver by the linked user ID
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Driver, error)
+
+	// Create creates a new driver
+	Create(ctx context.Context, driver *entity.Driver) error
+
+	// Update updates an existing driver
+	Update(ctx context.Context, driver *entity.Driver) error
+
+	// Delete soft deletes a driver
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves drivers matching the filter with pagination
+	List(ctx context.Context, filter DriverFilter, limit, offset int) ([]*entity.Driver, int64, error)
+}
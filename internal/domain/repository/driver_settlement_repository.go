@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// DriverPayRuleFilter holds optional criteria for listing driver pay rules
+type DriverPayRuleFilter struct {
+	VehicleType *entity.VehicleType
+	EffectiveAt *time.Time
+}
+
+// DriverPayRuleRepository defines the interface for driver pay rule data operations
+type DriverPayRuleRepository interface {
+	// FindByID retrieves a pay rule by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.DriverPayRule, error)
+
+	// FindEffective retrieves the rule effective at the given time for a vehicle type, preferring a rule
+	// for that vehicle type over one for any vehicle type, then the latest effective date
+	FindEffective(ctx context.Context, vehicleType entity.VehicleType, at time.Time) (*entity.DriverPayRule, error)
+
+	// Create creates a new pay rule
+	Create(ctx context.Context, rule *entity.DriverPayRule) error
+
+	// Update updates an existing pay rule
+	Update(ctx context.Context, rule *entity.DriverPayRule) error
+
+	// List retrieves pay rules matching the filter, latest effective date first, with pagination
+	List(ctx context.Context, filter DriverPayRuleFilter, limit, offset int) ([]*entity.DriverPayRule, int64, error)
+}
+
+// DriverSettlementFilter holds optional criteria for listing driver settlements
+type DriverSettlementFilter struct {
+	DriverID *uuid.UUID
+	Status   *entity.SettlementStatus
+	From     *time.Time // settlements whose period ends on or after
+	To       *time.Time // settlements whose period starts on or before
+	Search   string     // matches the number
+}
+
+// DriverSettlementRepository defines the interface for driver settlement data operations.
+// Settlements are loaded with their lines and adjustments; lines do not change after creation.
+type DriverSettlementRepository interface {
+	// FindByID retrieves a settlement with its lines and adjustments by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.DriverSettlement, error)
+
+	// FindByIDForUpdate retrieves a settlement and locks its row until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.DriverSettlement, error)
+
+	// Create creates a new settlement with its lines and adjustments
+	Create(ctx context.Context, settlement *entity.DriverSettlement) error
+
+	// Update saves the settlement's status, number and totals and replaces its adjustments
+	Update(ctx context.Context, settlement *entity.DriverSettlement) error
+
+	// List retrieves settlements matching the filter with pagination, newest first
+	List(ctx context.Context, filter DriverSettlementFilter, limit, offset int) ([]*entity.DriverSettlement, int64, error)
+
+	// ListSettleableTrips retrieves the completed trips a driver drove or co-drove that were planned to
+	// start in [from, to) and are not on a settlement of that driver other than a void one, by planned start
+	ListSettleableTrips(ctx context.Context, driverID uuid.UUID, from, to time.Time) ([]*entity.Trip, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// LaneSpeedRepository defines the interface for historical lane speed data operations
+type LaneSpeedRepository interface {
+	// FindByLane retrieves the hourly speeds observed between two provinces; hours without samples are omitted
+	FindByLane(ctx context.Context, originProvince, destinationProvince string) ([]*entity.LaneSpeed, error)
+
+	// RecordSample folds one observed leg speed into the lane's average for the hour
+	RecordSample(ctx context.Context, originProvince, destinationProvince string, hour int, speedKmh float64) error
+}
+
+// StopDelayRepository defines the interface for stop delay data operations
+type StopDelayRepository interface {
+	// Create records a detected delay
+	Create(ctx context.Context, delay *entity.StopDelay) error
+
+	// ListByTrip retrieves the delays detected on a trip, newest first
+	ListByTrip(ctx context.Context, tripID uuid.UUID) ([]*entity.StopDelay, error)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// GeofenceEventFilter holds optional criteria for listing geofence events
+type GeofenceEventFilter struct {
+	VehicleID  *uuid.UUID
+	GeofenceID *uuid.UUID
+	LocationID *uuid.UUID
+	TripID     *uuid.UUID
+	From       *time.Time // events at or after
+	To         *time.Time // events before
+}
+
+// GeofenceRepository defines the interface for geofence and geofence event data operations
+type GeofenceRepository interface {
+	// FindByID retrieves a geofence by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Geofence, error)
+
+	// Create creates a new geofence
+	Create(ctx context.Context, geofence *entity.Geofence) error
+
+	// Update updates an existing geofence
+	Update(ctx context.Context, geofence *entity.Geofence) error
+
+	// Delete soft deletes a geofence
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// ListByLocation retrieves the geofences of a location
+	ListByLocation(ctx context.Context, locationID uuid.UUID) ([]*entity.Geofence, error)
+
+	// ListActive retrieves every active geofence
+	ListActive(ctx context.Context) ([]*entity.Geofence, error)
+
+	// CreateEvents records geofence events
+	CreateEvents(ctx context.Context, events []*entity.GeofenceEvent) error
+
+	// ListEvents retrieves geofence events matching the filter, newest first, with pagination
+	ListEvents(ctx context.Context, filter GeofenceEventFilter, limit, offset int) ([]*entity.GeofenceEvent, int64, error)
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// IncidentFilter holds optional criteria for listing incidents.
+// Open keeps the incidents awaiting a resolution; OverdueAt keeps those open past their SLA at the given time.
+type IncidentFilter struct {
+	TripID     *uuid.UUID
+	ShipmentID *uuid.UUID
+	OwnerID    *uuid.UUID
+	Category   *entity.IncidentCategory
+	Severity   *entity.IncidentSeverity
+	Status     *entity.IncidentStatus
+	Open       bool
+	OverdueAt  *time.Time
+}
+
+// IncidentRepository defines the interface for incident data operations
+type IncidentRepository interface {
+	// FindByID retrieves an incident by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Incident, error)
+
+	// FindByIDForUpdate retrieves an incident and locks it until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Incident, error)
+
+	// Create creates a new incident
+	Create(ctx context.Context, incident *entity.Incident) error
+
+	// Update updates an existing incident
+	Update(ctx context.Context, incident *entity.Incident) error
+
+	// List retrieves incidents matching the filter with pagination, most recent first
+	List(ctx context.Context, filter IncidentFilter, limit, offset int) ([]*entity.Incident, int64, error)
+
+	// ListUnbreached retrieves the open incidents due before the given time whose breach has not been announced yet,
+	// soonest due first
+	ListUnbreached(ctx context.Context, before time.Time, limit int) ([]*entity.Incident, error)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceFilter holds optional criteria for listing invoices and credit notes
+type InvoiceFilter struct {
+	OrganizationID *uuid.UUID
+	Kind           *entity.InvoiceKind
+	Status         *entity.InvoiceStatus
+	Search         string // matches the number
+}
+
+// InvoiceRepository defines the interface for invoice and credit note data operations.
+// Invoices are loaded and created together with their lines; lines do not change afterwards.
+type InvoiceRepository interface {
+	// FindByID retrieves an invoice with its lines by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Invoice, error)
+
+	// FindByIDForUpdate retrieves an invoice like FindByID and locks it until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Invoice, error)
+
+	// Create creates a new invoice with its lines
+	Create(ctx context.Context, invoice *entity.Invoice) error
+
+	// Update saves the invoice's status, number, dates and payment without touching its lines
+	Update(ctx context.Context, invoice *entity.Invoice) error
+
+	// List retrieves invoices matching the filter with pagination, newest first
+	List(ctx context.Context, filter InvoiceFilter, limit, offset int) ([]*entity.Invoice, int64, error)
+
+	// ListCreditNotes retrieves the credit notes raised against an invoice that are not void
+	ListCreditNotes(ctx context.Context, invoiceID uuid.UUID) ([]*entity.Invoice, error)
+
+	// ListBillableShipments retrieves an organization's shipments delivered in [from, to) that are not
+	// on an invoice other than a void one, in order of delivery
+	ListBillableShipments(ctx context.Context, organizationID uuid.UUID, from, to time.Time) ([]*entity.BillableShipment, error)
+}
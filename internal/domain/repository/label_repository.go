@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// LabelRepository defines the interface for label templates and labelled shipment pieces
+type LabelRepository interface {
+	// FindTemplate retrieves an organization's own label template
+	FindTemplate(ctx context.Context, organizationID uuid.UUID) (*entity.LabelTemplate, error)
+
+	// SaveTemplate creates or replaces an organization's label template
+	SaveTemplate(ctx context.Context, template *entity.LabelTemplate) error
+
+	// DeleteTemplate removes an organization's label template so the default applies again
+	DeleteTemplate(ctx context.Context, organizationID uuid.UUID) error
+
+	// FindPieces retrieves the labelled pieces of the given shipments ordered by shipment and piece
+	FindPieces(ctx context.Context, shipmentIDs []uuid.UUID) ([]*entity.ShipmentPiece, error)
+
+	// CreatePieces records newly labelled pieces; a piece labelled concurrently is a conflict
+	CreatePieces(ctx context.Context, pieces []*entity.ShipmentPiece) error
+}
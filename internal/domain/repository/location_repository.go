@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// LocationRepository defines the interface for location (address book) data operations
+type LocationRepository interface {
+	// FindByID retrieves a location by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Location, error)
+
+	// FindByIDs retrieves the locations with the given IDs; missing IDs are skipped
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Location, error)
+
+	// Create creates a new location
+	Create(ctx context.Context, location *entity.Location) error
+
+	// Update updates an existing location
+	Update(ctx context.Context, location *entity.Location) error
+
+	// Delete soft deletes a location
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// ListByOrganization retrieves an organization's saved locations matching the search with pagination
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID, search string, limit, offset int) ([]*entity.Location, int64, error)
+}
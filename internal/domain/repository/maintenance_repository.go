@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// MaintenancePlanFilter holds optional criteria for listing maintenance plans
+type MaintenancePlanFilter struct {
+	VehicleType *entity.VehicleType
+	Active      *bool
+}
+
+// MaintenancePlanRepository defines the interface for maintenance plan data operations
+type MaintenancePlanRepository interface {
+	// FindByID retrieves a plan by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.MaintenancePlan, error)
+
+	// Create creates a new plan
+	Create(ctx context.Context, plan *entity.MaintenancePlan) error
+
+	// Update updates an existing plan
+	Update(ctx context.Context, plan *entity.MaintenancePlan) error
+
+	// List retrieves plans matching the filter with pagination, by vehicle type and name
+	List(ctx context.Context, filter MaintenancePlanFilter, limit, offset int) ([]*entity.MaintenancePlan, int64, error)
+}
+
+// MaintenanceScheduleFilter holds optional criteria for listing maintenance schedules
+type MaintenanceScheduleFilter struct {
+	VehicleID *uuid.UUID
+	Status    *entity.MaintenanceStatus
+}
+
+// MaintenanceScheduleRepository defines the interface for maintenance schedule data operations
+type MaintenanceScheduleRepository interface {
+	// FindByVehicles retrieves the schedules of the given vehicles
+	FindByVehicles(ctx context.Context, vehicleIDs []uuid.UUID) ([]*entity.MaintenanceSchedule, error)
+
+	// FindForUpdate retrieves a vehicle's schedule for a plan and locks it until the surrounding transaction ends
+	FindForUpdate(ctx context.Context, vehicleID, planID uuid.UUID) (*entity.MaintenanceSchedule, error)
+
+	// Save creates or updates a schedule; a vehicle has one schedule per plan
+	Save(ctx context.Context, schedule *entity.MaintenanceSchedule) error
+
+	// Delete deletes schedules, e.g. of plans that no longer apply to the vehicle
+	Delete(ctx context.Context, ids []uuid.UUID) error
+
+	// List retrieves schedules of active plans matching the filter with pagination, most urgent first
+	List(ctx context.Context, filter MaintenanceScheduleFilter, limit, offset int) ([]*entity.MaintenanceSchedule, int64, error)
+
+	// HasOverdue reports whether the vehicle is past due for the service of an active plan at the given time
+	HasOverdue(ctx context.Context, vehicleID uuid.UUID, at time.Time) (bool, error)
+
+	// LatestOdometers retrieves the highest odometer reading known for each vehicle from its latest GPS fix,
+	// fill-ups and completed work orders; vehicles without a reading are left out
+	LatestOdometers(ctx context.Context, vehicleIDs []uuid.UUID) (map[uuid.UUID]float64, error)
+}
+
+// WorkOrderFilter holds optional criteria for listing work orders
+type WorkOrderFilter struct {
+	VehicleID *uuid.UUID
+	Status    *entity.WorkOrderStatus
+	Type      *entity.WorkOrderType
+	Search    string
+}
+
+// WorkOrderRepository defines the interface for work order data operations
+type WorkOrderRepository interface {
+	// FindByID retrieves a work order with its parts by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.WorkOrder, error)
+
+	// FindByIDForUpdate retrieves a work order and locks it until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.WorkOrder, error)
+
+	// Create creates a new work order with its parts
+	Create(ctx context.Context, order *entity.WorkOrder) error
+
+	// Update updates an existing work order and replaces its parts
+	Update(ctx context.Context, order *entity.WorkOrder) error
+
+	// List retrieves work orders matching the filter with pagination, newest first
+	List(ctx context.Context, filter WorkOrderFilter, limit, offset int) ([]*entity.WorkOrder, int64, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+)
+
+// NotificationRepository defines the interface for the notification outbox
+type NotificationRepository interface {
+	// Create records notifications as pending, due for delivery at once
+	Create(ctx context.Context, notifications ...*entity.Notification) error
+
+	// ListDueForUpdate retrieves up to limit pending notifications due for delivery at the given time, oldest first,
+	// and locks them until the surrounding transaction ends. Notifications locked by another dispatcher are skipped.
+	ListDueForUpdate(ctx context.Context, at time.Time, limit int) ([]*entity.Notification, error)
+
+	// Update updates the delivery status of a notification
+	Update(ctx context.Context, notification *entity.Notification) error
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// NumberingRepository defines the interface for number formats and the sequences behind them.
+// Sequences of the default formats are kept under the nil organization ID.
+type NumberingRepository interface {
+	// FindFormat retrieves an organization's own format for a document type
+	FindFormat(ctx context.Context, organizationID uuid.UUID, documentType entity.DocumentType) (*entity.NumberingFormat, error)
+
+	// ListFormats retrieves an organization's own formats
+	ListFormats(ctx context.Context, organizationID uuid.UUID) ([]*entity.NumberingFormat, error)
+
+	// ListFormatsByType retrieves every organization's own format for a document type
+	ListFormatsByType(ctx context.Context, documentType entity.DocumentType) ([]*entity.NumberingFormat, error)
+
+	// SaveFormat creates or replaces an organization's format for a document type
+	SaveFormat(ctx context.Context, format *entity.NumberingFormat) error
+
+	// DeleteFormat removes an organization's format so the default applies again
+	DeleteFormat(ctx context.Context, organizationID uuid.UUID, documentType entity.DocumentType) error
+
+	// NextValue increments the sequence of an organization, document type and period and returns the new value,
+	// starting at 1. The sequence row stays locked until the surrounding transaction ends.
+	NextValue(ctx context.Context, organizationID uuid.UUID, documentType entity.DocumentType, period string) (int64, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationRepository defines the interface for organization data operations
+type OrganizationRepository interface {
+	// FindByID retrieves an organization by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error)
+
+	// FindByIDForUpdate retrieves an organization like FindByID and locks it until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Organization, error)
+
+	// Create creates a new organization
+	Create(ctx context.Context, org *entity.Organization) error
+
+	// Update updates an existing organization
+	Update(ctx context.Context, org *entity.Organization) error
+
+	// List retrieves organizations whose name or tax ID match the search with pagination
+	List(ctx context.Context, search string, limit, offset int) ([]*entity.Organization, int64, error)
+}
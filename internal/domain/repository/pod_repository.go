@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ProofOfDeliveryRepository defines the interface for proof-of-delivery data operations
+type ProofOfDeliveryRepository interface {
+	// FindByStopID retrieves the proof of delivery recorded for a trip stop
+	FindByStopID(ctx context.Context, stopID uuid.UUID) (*entity.ProofOfDelivery, error)
+
+	// Create records a proof of delivery; a second one for the same stop is a conflict
+	Create(ctx context.Context, pod *entity.ProofOfDelivery) error
+}
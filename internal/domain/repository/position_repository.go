@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// PositionRepository defines the interface for GPS position history
+type PositionRepository interface {
+	// CreateBatch stores positions, skipping any already stored for the same vehicle and time,
+	// and returns how many were new. It returns errs.ErrBadRequest when the database refuses a
+	// position, e.g. of a vehicle that no longer exists; nothing of the batch is stored then.
+	CreateBatch(ctx context.Context, positions []entity.VehiclePosition) (int64, error)
+
+	// ListTrack retrieves a vehicle's positions recorded in [from, to] in time order, at most limit of them
+	ListTrack(ctx context.Context, vehicleID uuid.UUID, from, to time.Time, limit int) ([]entity.VehiclePosition, error)
+}
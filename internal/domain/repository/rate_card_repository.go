@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// RateCardFilter holds optional criteria for listing rate cards
+type RateCardFilter struct {
+	OrganizationID *uuid.UUID
+	StandardOnly   bool // only cards without an organization
+	EffectiveAt    *time.Time
+	Search         string
+}
+
+// RateCardRepository defines the interface for rate card data operations
+type RateCardRepository interface {
+	// FindByID retrieves a rate card version by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.RateCard, error)
+
+	// FindEffective retrieves the card that most recently took effect at the given time for an organization,
+	// or for the standard tariff when organizationID is nil
+	FindEffective(ctx context.Context, organizationID *uuid.UUID, at time.Time) (*entity.RateCard, error)
+
+	// LatestVersion returns the highest version of a named card, or 0 if none exists
+	LatestVersion(ctx context.Context, organizationID *uuid.UUID, name string) (int, error)
+
+	// Create creates a new rate card version
+	Create(ctx context.Context, card *entity.RateCard) error
+
+	// List retrieves rate cards matching the filter with pagination
+	List(ctx context.Context, filter RateCardFilter, limit, offset int) ([]*entity.RateCard, int64, error)
+}
+
+// DieselPriceRepository defines the interface for reference diesel price data operations
+type DieselPriceRepository interface {
+	// FindEffective retrieves the latest price effective on or before the given time
+	FindEffective(ctx context.Context, at time.Time) (*entity.DieselPrice, error)
+
+	// Create creates a new diesel price
+	Create(ctx context.Context, price *entity.DieselPrice) error
+
+	// List retrieves diesel prices, newest first, with pagination
+	List(ctx context.Context, limit, offset int) ([]*entity.DieselPrice, int64, error)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ShipmentFilter holds optional criteria for listing shipments
+type ShipmentFilter struct {
+	OrganizationID *uuid.UUID
+	Status         *entity.ShipmentStatus
+	Search         string
+}
+
+// ShipmentRepository defines the interface for shipment data operations
+type ShipmentRepository interface {
+	// FindByID retrieves a shipment by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Shipment, error)
+
+	// FindByIDForUpdate retrieves a shipment by ID and locks it until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Shipment, error)
+
+	// FindByTrackingNumber retrieves a shipment by its public tracking number
+	FindByTrackingNumber(ctx context.Context, trackingNumber string) (*entity.Shipment, error)
+
+	// FindByIDs retrieves the shipments with the given IDs; missing IDs are skipped
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Shipment, error)
+
+	// FindByIDsForUpdate retrieves the shipments like FindByIDs and locks them, in ID order,
+	// until the surrounding transaction ends
+	FindByIDsForUpdate(ctx context.Context, ids []uuid.UUID) ([]*entity.Shipment, error)
+
+	// Create creates a new shipment
+	Create(ctx context.Context, shipment *entity.Shipment) error
+
+	// Update updates an existing shipment
+	Update(ctx context.Context, shipment *entity.Shipment) error
+
+	// UpdateDeliveryWindow sets the delivery window of a pending shipment only, so that it cannot change
+	// once the shipment is planned. It returns errs.ErrNotFound when no pending shipment has the ID.
+	UpdateDeliveryWindow(ctx context.Context, id uuid.UUID, from, to time.Time) error
+
+	// UpdateStatus sets the status of the given shipments and records the change in the status history
+	// of those whose status differed
+	UpdateStatus(ctx context.Context, ids []uuid.UUID, status entity.ShipmentStatus) error
+
+	// ListStatusHistory retrieves the status changes of a shipment, oldest first
+	ListStatusHistory(ctx context.Context, shipmentID uuid.UUID) ([]*entity.ShipmentStatusChange, error)
+
+	// Delete soft deletes a shipment
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves shipments matching the filter with pagination
+	List(ctx context.Context, filter ShipmentFilter, limit, offset int) ([]*entity.Shipment, int64, error)
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// TenderFilter holds optional criteria for listing tenders
+type TenderFilter struct {
+	Status     *entity.TenderStatus
+	ShipmentID *uuid.UUID
+	TripID     *uuid.UUID
+	CarrierID  *uuid.UUID // tenders offered to the carrier; queued offers are not visible yet
+}
+
+// TenderRepository defines the interface for tender data operations.
+// Tenders are loaded and saved together with their offers and events.
+type TenderRepository interface {
+	// FindByID retrieves a tender with its offers and events by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Tender, error)
+
+	// FindByIDForUpdate retrieves a tender like FindByID and locks it until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Tender, error)
+
+	// Create creates a new tender with its offers and events.
+	// It returns errs.ErrConflict when the shipment or trip already has an open or accepted tender.
+	Create(ctx context.Context, tender *entity.Tender) error
+
+	// Update saves the tender's status and offers and appends its unsaved events
+	Update(ctx context.Context, tender *entity.Tender) error
+
+	// List retrieves tenders matching the filter with pagination, newest first
+	List(ctx context.Context, filter TenderFilter, limit, offset int) ([]*entity.Tender, int64, error)
+
+	// ListOverdueIDs retrieves open tenders whose current offer's deadline is before the given time
+	ListOverdueIDs(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error)
+}
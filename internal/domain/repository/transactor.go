@@ -0,0 +1,9 @@
+package repository
+
+import "context"
+
+// Transactor defines the interface for running operations inside a single database transaction
+type Transactor interface {
+	// WithTransaction executes fn within a transaction carried by the context passed to fn
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
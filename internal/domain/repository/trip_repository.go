@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// TripFilter holds optional criteria for listing trips
+type TripFilter struct {
+	Status    *entity.TripStatus
+	VehicleID *uuid.UUID
+	DriverID  *uuid.UUID // matches driver or co-driver
+	From      *time.Time // trips ending at or after
+	To        *time.Time // trips starting before
+}
+
+// TripRepository defines the interface for trip data operations.
+// Trips are loaded and saved together with their stops.
+type TripRepository interface {
+	// FindByID retrieves a trip with its stops by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Trip, error)
+
+	// FindByIDForUpdate retrieves a trip like FindByID and locks it until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Trip, error)
+
+	// Create creates a new trip and its stops
+	Create(ctx context.Context, trip *entity.Trip) error
+
+	// Update updates an existing trip and replaces its stops
+	Update(ctx context.Context, trip *entity.Trip) error
+
+	// UpdateStatus sets the status of a trip without touching its stops
+	UpdateStatus(ctx context.Context, id uuid.UUID, status entity.TripStatus) error
+
+	// UpdateDispatcher sets the dispatcher responsible for a trip
+	UpdateDispatcher(ctx context.Context, id, userID uuid.UUID) error
+
+	// UpdateCarrier records the carrier a trip was awarded to and the tender that awarded it
+	UpdateCarrier(ctx context.Context, id, carrierID, tenderID uuid.UUID) error
+
+	// UpdateStopProgress saves the status and arrival and departure times of the given stops
+	UpdateStopProgress(ctx context.Context, stops []*entity.TripStop) error
+
+	// UpdateStopETAs saves the predicted arrival and delay flag of the given stops
+	UpdateStopETAs(ctx context.Context, stops []*entity.TripStop) error
+
+	// FindActiveByVehicle retrieves the dispatched or in-progress trips of a vehicle ordered by planned start
+	FindActiveByVehicle(ctx context.Context, vehicleID uuid.UUID) ([]*entity.Trip, error)
+
+	// FindByShipment retrieves the latest trip that is not cancelled and carries the shipment
+	FindByShipment(ctx context.Context, shipmentID uuid.UUID) (*entity.Trip, error)
+
+	// List retrieves trips matching the filter with pagination
+	List(ctx context.Context, filter TripFilter, limit, offset int) ([]*entity.Trip, int64, error)
+
+	// FindOverlapping retrieves active trips in [start, end) that use the vehicle or any of the drivers,
+	// excluding the trip with excludeID when given
+	FindOverlapping(ctx context.Context, vehicleID uuid.UUID, driverIDs []uuid.UUID, start, end time.Time, excludeID *uuid.UUID) ([]*entity.Trip, error)
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// VehicleCostFilter holds optional criteria for listing and summarizing fuel logs and vehicle expenses.
+// From and To bound when the fill-up or expense happened, [From, To).
+type VehicleCostFilter struct {
+	VehicleID *uuid.UUID
+	From      *time.Time
+	To        *time.Time
+}
+
+// FuelSummary totals a vehicle's fill-ups. Efficiency is the rated distance over the rated litres.
+type FuelSummary struct {
+	VehicleID        uuid.UUID
+	Fills            int
+	Liters           float64
+	Amount           entity.Money
+	DistanceKm       float64 // rated distance
+	EfficiencyLiters float64 // rated litres
+	Flagged          int
+}
+
+// ExpenseSummary totals a vehicle's expenses of one type
+type ExpenseSummary struct {
+	VehicleID uuid.UUID
+	Type      entity.ExpenseType
+	Count     int
+	Amount    entity.Money
+}
+
+// FuelLogRepository defines the interface for fuel log data operations
+type FuelLogRepository interface {
+	// FindByID retrieves a fuel log by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.FuelLog, error)
+
+	// FindPrevious retrieves the vehicle's latest fill-up before the given time, only full tanks if fullTank is set
+	FindPrevious(ctx context.Context, vehicleID uuid.UUID, before time.Time, fullTank bool) (*entity.FuelLog, error)
+
+	// FindNext retrieves the vehicle's earliest fill-up after the given time, only full tanks if fullTank is set
+	FindNext(ctx context.Context, vehicleID uuid.UUID, after time.Time, fullTank bool) (*entity.FuelLog, error)
+
+	// SumLiters totals the litres of the vehicle's fill-ups strictly between the given times
+	SumLiters(ctx context.Context, vehicleID uuid.UUID, after, before time.Time) (float64, error)
+
+	// Create creates a new fuel log
+	Create(ctx context.Context, log *entity.FuelLog) error
+
+	// Update updates an existing fuel log
+	Update(ctx context.Context, log *entity.FuelLog) error
+
+	// Delete deletes a fuel log
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves fuel logs matching the filter with pagination, latest first
+	List(ctx context.Context, filter VehicleCostFilter, limit, offset int) ([]*entity.FuelLog, int64, error)
+
+	// Summarize totals the fuel logs matching the filter per vehicle
+	Summarize(ctx context.Context, filter VehicleCostFilter) ([]FuelSummary, error)
+}
+
+// VehicleExpenseRepository defines the interface for vehicle expense data operations
+type VehicleExpenseRepository interface {
+	// FindByID retrieves an expense by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.VehicleExpense, error)
+
+	// Create creates a new expense
+	Create(ctx context.Context, expense *entity.VehicleExpense) error
+
+	// Delete deletes an expense
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves expenses matching the filter and type with pagination, latest first
+	List(ctx context.Context, filter VehicleCostFilter, expenseType *entity.ExpenseType, limit, offset int) ([]*entity.VehicleExpense, int64, error)
+
+	// Summarize totals the expenses matching the filter per vehicle and type
+	Summarize(ctx context.Context, filter VehicleCostFilter) ([]ExpenseSummary, error)
+}
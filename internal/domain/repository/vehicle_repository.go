@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// VehicleFilter holds optional criteria for listing vehicles
+type VehicleFilter struct {
+	Status *entity.VehicleStatus
+	Type   *entity.VehicleType
+	Search string
+}
+
+// VehicleRepository defines the interface for vehicle data operations
+type VehicleRepository interface {
+	// FindByID retrieves a vehicle by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Vehicle, error)
+
+	// FindByIDForUpdate retrieves a vehicle and locks it until the surrounding transaction ends
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Vehicle, error)
+
+	// FindByIDs retrieves the vehicles with the given IDs; unknown IDs are skipped
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Vehicle, error)
+
+	// FindByGPSDeviceIDs retrieves the vehicles fitted with the given GPS devices; unknown devices are skipped
+	FindByGPSDeviceIDs(ctx context.Context, deviceIDs []string) ([]*entity.Vehicle, error)
+
+	// Create creates a new vehicle
+	Create(ctx context.Context, vehicle *entity.Vehicle) error
+
+	// Update updates an existing vehicle
+	Update(ctx context.Context, vehicle *entity.Vehicle) error
+
+	// Delete soft deletes a vehicle
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// List retrieves vehicles matching the filter with pagination
+	List(ctx context.Context, filter VehicleFilter, limit, offset int) ([]*entity.Vehicle, int64, error)
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// CarrierForUser resolves the carrier a user acts for, for the use cases behind the carrier API;
+// users who are not linked to a carrier are refused with errs.ErrForbidden
+func CarrierForUser(ctx context.Context, carrierRepo repository.CarrierRepository, userID uuid.UUID) (*entity.Carrier, error) {
+	carrier, err := carrierRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrForbidden
+		}
+		return nil, fmt.Errorf("carrier repository: find by user id: %w", err)
+	}
+	return carrier, nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+)
+
+// Party is a seller, customer, shipper or consignee printed on a document
+type Party struct {
+	Name       string
+	TaxID      string
+	BranchCode string // "00000" or empty for the head office
+	Address    string
+	Contact    string
+	Phone      string
+}
+
+// InvoiceDocument is the content of a printable invoice/tax invoice or credit note
+type InvoiceDocument struct {
+	Invoice  *entity.Invoice
+	Seller   Party
+	Customer Party
+	// The invoice a credit note corrects; a credit note shows its original and corrected value
+	CreditedNumber    string
+	CreditedIssueDate *time.Time
+	CreditedSubtotal  entity.Money
+}
+
+// ShipmentDocument is the content of a printable waybill or delivery note.
+// Trip and delivery details are empty until the shipment is planned or delivered.
+type ShipmentDocument struct {
+	TrackingNumber string
+	Reference      string
+	TrackingURL    string // public tracking page, encoded in a QR code
+	BookedAt       time.Time
+	Seller         Party
+	Customer       Party
+	Shipper        Party
+	Consignee      Party
+	PickupFrom     *time.Time
+	PickupTo       *time.Time
+	DeliverFrom    *time.Time
+	DeliverTo      *time.Time
+	WeightKg       float64
+	VolumeM3       float64
+	Pallets        int
+	Notes          string
+	TripNumber     string
+	VehiclePlate   string
+	DriverName     string
+	PODNumber      string
+	DeliveredAt    *time.Time
+	ReceivedBy     string
+}
+
+// DeliveryDocument is the content of a printable proof of delivery.
+// Signature and photos are the uploaded JPEG or PNG files.
+type DeliveryDocument struct {
+	DocumentNumber    string
+	ShipmentReference string
+	TripNumber        string
+	StopSequence      int
+	LocationName      string
+	Address           string
+	DriverName        string
+	VehiclePlate      string
+	RecipientName     string
+	CapturedAt        time.Time
+	Latitude          float64
+	Longitude         float64
+	AccuracyM         *float64
+	DistanceM         *float64
+	Notes             string
+	Signature         []byte
+	Photos            [][]byte
+}
+
+// DocumentRenderer defines the interface for producing printable documents.
+// Rendering is deterministic: the same content always yields the same bytes.
+type DocumentRenderer interface {
+	// RenderProofOfDelivery renders a proof of delivery as a PDF
+	RenderProofOfDelivery(doc DeliveryDocument) ([]byte, error)
+	// RenderInvoice renders an invoice/tax invoice or a credit note as a PDF
+	RenderInvoice(doc InvoiceDocument) ([]byte, error)
+	// RenderWaybill renders the consignment note that travels with a shipment as a PDF
+	RenderWaybill(doc ShipmentDocument) ([]byte, error)
+	// RenderDeliveryNote renders the note the consignee signs on receipt as a PDF
+	RenderDeliveryNote(doc ShipmentDocument) ([]byte, error)
+}
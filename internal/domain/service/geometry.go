@@ -0,0 +1,16 @@
+package service
+
+import "tms-core-service/internal/domain/entity"
+
+// Geometry defines the interface for calculations on WGS84 coordinates
+type Geometry interface {
+	// Distance returns the great-circle distance between two coordinates in meters
+	Distance(fromLat, fromLng, toLat, toLng float64) float64
+	// Simplify returns the indices of the path points to keep so that no dropped point
+	// lies further than toleranceM meters from the simplified path
+	Simplify(path []entity.Coordinate, toleranceM float64) []int
+	// PolygonContains reports whether the point lies inside the polygon
+	PolygonContains(polygon []entity.Coordinate, point entity.Coordinate) bool
+	// DistanceToPolygon returns the distance in meters from the point to the polygon's nearest edge
+	DistanceToPolygon(polygon []entity.Coordinate, point entity.Coordinate) float64
+}
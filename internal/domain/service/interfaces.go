@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"tms-core-service/internal/domain/entity"
+
 	"github.com/google/uuid"
 )
 
@@ -31,4 +33,55 @@ type StorageService interface {
 	GenerateUploadURL(ctx context.Context, key string, contentType string) (string, error)
 	// GenerateDownloadURL creates a presigned URL for downloading a file
 	GenerateDownloadURL(ctx context.Context, key string) (string, error)
+	// ObjectExists reports whether a file has been uploaded under the key
+	ObjectExists(ctx context.Context, key string) (bool, error)
+	// GetObject downloads a file; a missing key is reported as errs.ErrNotFound
+	GetObject(ctx context.Context, key string) ([]byte, error)
+	// PutObject uploads a file generated by the server
+	PutObject(ctx context.Context, key string, contentType string, data []byte) error
+}
+
+// AddressDirectory defines the interface for Thai administrative-area lookups
+type AddressDirectory interface {
+	// Resolve validates a subdistrict/district/province/postcode combination and returns the canonical area.
+	// An invalid combination is reported as errs.ValidationErrors keyed by the offending field. A district or
+	// subdistrict of a known province that the dataset does not list is returned as given, marked Unlisted.
+	Resolve(subdistrict, district, province, postcode string) (*entity.AdminArea, error)
+	// Search returns areas matching a free-text query for autocomplete
+	Search(query string, limit int) []entity.AdminArea
+	// Provinces lists all provinces
+	Provinces() []entity.AdminName
+	// Districts lists the districts of a province
+	Districts(province string) []entity.AdminName
+	// Subdistricts lists the subdistricts of a district
+	Subdistricts(province, district string) []entity.AdminArea
+	// ByPostcode lists the areas served by a postcode
+	ByPostcode(postcode string) []entity.AdminArea
+}
+
+// GeocodeQuery represents a structured address to geocode
+type GeocodeQuery struct {
+	Address     string // street part: house number, moo, soi, road
+	Subdistrict string
+	District    string
+	Province    string
+	Postcode    string
+}
+
+// GeocodeResult represents a geocoded position and how precisely it pins the address
+type GeocodeResult struct {
+	Latitude         float64
+	Longitude        float64
+	FormattedAddress string
+	Precision        entity.GeoPrecision
+	Provider         string
+}
+
+// Geocoder defines the interface for forward and reverse geocoding.
+// Both methods return errs.ErrNotFound when the provider has no match.
+type Geocoder interface {
+	// Geocode resolves an address to coordinates
+	Geocode(ctx context.Context, query GeocodeQuery) (*GeocodeResult, error)
+	// ReverseGeocode resolves coordinates to the nearest known address
+	ReverseGeocode(ctx context.Context, lat, lng float64) (*GeocodeResult, error)
 }
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+)
+
+// ShippingLabel is the content of the label on one piece of a shipment
+type ShippingLabel struct {
+	Template       *entity.LabelTemplate
+	Carrier        string
+	TrackingNumber string
+	Reference      string
+	SSCC           string // empty when the template has no GS1 company prefix
+	Piece          int
+	Pieces         int
+	Shipper        Party
+	Consignee      Party
+	SortCode       string // where the label is sorted at the hub, e.g. the delivery postcode
+	Route          string // trip and delivery stop once the shipment is planned, e.g. TRP-2610-00042/3
+	WeightKg       float64
+	DeliverBy      *time.Time
+}
+
+// LabelRenderer defines the interface for printing shipping labels.
+// Each label is printed with its own template; rendering is deterministic.
+type LabelRenderer interface {
+	// RenderZPL renders labels as one ZPL print job for Zebra printers
+	RenderZPL(labels []ShippingLabel) ([]byte, error)
+	// RenderPDF renders labels as a PDF with one label per page
+	RenderPDF(labels []ShippingLabel) ([]byte, error)
+}
@@ -0,0 +1,100 @@
+package service
+
+// CargoSpace is the loadable space of a vehicle body in millimeters
+type CargoSpace struct {
+	LengthMM    int
+	WidthMM     int
+	HeightMM    int
+	MaxWeightKg float64 // zero means unlimited
+	// FrontAxleMM and RearAxleMM are axle positions measured from the front wall towards the doors;
+	// the front axle is negative when it sits under the cab. Axle loads need both.
+	FrontAxleMM *int
+	RearAxleMM  *int
+}
+
+// HandlingUnit is a type of pallet, crate or carton to be loaded, repeated Quantity times
+type HandlingUnit struct {
+	ID                string
+	Quantity          int
+	LengthMM          int
+	WidthMM           int
+	HeightMM          int
+	WeightKg          float64
+	Stackable         bool
+	OrientationLocked bool
+}
+
+// CargoPlacement is one unit's position in the cargo space, with its dimensions as loaded
+type CargoPlacement struct {
+	UnitID   string
+	Sequence int // 1-based index within the unit's quantity
+	XMM      int
+	YMM      int
+	ZMM      int
+	LengthMM int
+	WidthMM  int
+	HeightMM int
+	Rotated  bool
+	WeightKg float64
+}
+
+// CargoLeftover is a unit that could not be loaded, with the reason
+type CargoLeftover struct {
+	UnitID   string
+	Sequence int
+	Reason   string
+}
+
+// CargoPoint is a position in the cargo space in millimeters
+type CargoPoint struct {
+	XMM float64
+	YMM float64
+	ZMM float64
+}
+
+// AxleLoad is the cargo's share of the load on each axle
+type AxleLoad struct {
+	FrontKg float64
+	RearKg  float64
+}
+
+// LayoutRect is a unit drawn on a layout view
+type LayoutRect struct {
+	UnitID   string
+	Sequence int
+	X        int
+	Y        int
+	Width    int
+	Height   int
+	Color    string
+}
+
+// LayoutView is a 2D projection of the cargo space for rendering
+type LayoutView struct {
+	Name   string
+	Width  int
+	Height int
+	Rects  []LayoutRect
+}
+
+// LoadPlan is a packing plan for a cargo space
+type LoadPlan struct {
+	Placements      []CargoPlacement
+	Leftovers       []CargoLeftover
+	VolumeFillPct   float64
+	WeightFillPct   float64
+	UsedLengthMM    int
+	TotalWeightKg   float64
+	CenterOfGravity CargoPoint
+	LateralOffsetMM float64
+	Axles           *AxleLoad
+	Warnings        []string
+	Views           []LayoutView
+	SVG             string
+}
+
+// LoadPlanner defines the interface for packing handling units into a cargo space
+type LoadPlanner interface {
+	// Plan places as many units as fit and reports the rest as leftovers
+	Plan(space CargoSpace, units []HandlingUnit) (*LoadPlan, error)
+}
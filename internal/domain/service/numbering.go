@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// NumberGenerator defines the interface for issuing human-friendly document numbers.
+// Call Next inside the transaction that saves the document: the sequence stays locked until it commits
+// and a rollback hands the number back, so numbers are issued without gaps, as tax invoices require.
+type NumberGenerator interface {
+	// Next issues the next number of a document type in the organization's format, or the default format
+	Next(ctx context.Context, organizationID uuid.UUID, documentType entity.DocumentType, at time.Time) (string, error)
+
+	// Plausible reports whether a number could have been issued. It is false only when the number has
+	// the shape of a format with a check character and the check character is wrong, i.e. it was mistyped.
+	Plausible(ctx context.Context, documentType entity.DocumentType, number string) (bool, error)
+
+	// DefaultFormat returns the format used by organizations without their own
+	DefaultFormat(documentType entity.DocumentType) string
+
+	// Preview renders an example number of a format without issuing it; it fails when the format is invalid
+	Preview(format string, at time.Time) (string, error)
+
+	// Prefix returns the text every number of a format begins with; it fails when the format is invalid
+	Prefix(format string) (string, error)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// Realtime channel kinds; a channel is named "<kind>:<id>"
+const (
+	ChannelTrip         = "trip"
+	ChannelShipment     = "shipment"
+	ChannelVehicle      = "vehicle"
+	ChannelOrganization = "organization"
+	ChannelDriver       = "driver"
+	ChannelCarrier      = "carrier"
+	ChannelUser         = "user"
+	ChannelDispatch     = "dispatch" // an organization's dispatch queue, followed by its dispatchers
+)
+
+// Channel returns the name of the realtime channel of one trip, shipment, vehicle, organization, driver, carrier
+// or user, or of an organization's dispatch queue
+func Channel(kind string, id uuid.UUID) string {
+	return kind + ":" + id.String()
+}
+
+// RealtimeEvent is a change pushed to the clients subscribed to any of its channels
+type RealtimeEvent struct {
+	Channels   []string
+	Type       string // e.g. vehicle.position, trip.status_changed
+	Data       map[string]interface{}
+	OccurredAt time.Time
+}
+
+// EventPublisher defines the interface for broadcasting changes to the clients connected to every instance.
+// Delivery is best effort: clients that are not connected when an event is published never see it.
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...RealtimeEvent) error
+}
+
+// Subscription is one client's feed of the events published on the channels it subscribed to
+type Subscription interface {
+	// Subscribe adds channels to the feed
+	Subscribe(channels ...string) error
+	// Unsubscribe removes channels from the feed
+	Unsubscribe(channels ...string)
+	// Messages delivers encoded events, ready to send to the client
+	Messages() <-chan []byte
+	// Done is closed when the subscription ends
+	Done() <-chan struct{}
+	// Dropped reports whether the subscription ended because the client fell behind
+	Dropped() bool
+	// Close ends the subscription
+	Close()
+}
+
+// EventSubscriber defines the interface for receiving realtime events on this instance
+type EventSubscriber interface {
+	Connect() Subscription
+}
+
+// ShipmentStatusChanged builds the event announcing a shipment's new status on its own and its organization's channels
+func ShipmentStatusChanged(shipment *entity.Shipment, status entity.ShipmentStatus, at time.Time) RealtimeEvent {
+	return RealtimeEvent{
+		Channels: []string{
+			Channel(ChannelShipment, shipment.ID),
+			Channel(ChannelOrganization, shipment.OrganizationID),
+		},
+		Type: "shipment.status_changed",
+		Data: map[string]interface{}{
+			"shipment_id": shipment.ID,
+			"reference":   shipment.Reference,
+			"status":      status,
+		},
+		OccurredAt: at,
+	}
+}
+
+// NewNotification records an event in the notification outbox, to be delivered once the change it announces is committed
+func NewNotification(event RealtimeEvent) *entity.Notification {
+	return &entity.Notification{
+		Type:       event.Type,
+		Channels:   event.Channels,
+		Data:       event.Data,
+		OccurredAt: event.OccurredAt,
+	}
+}
+
+// NotificationEvent returns the event delivering a notification from the outbox
+func NotificationEvent(n *entity.Notification) RealtimeEvent {
+	return RealtimeEvent{
+		Channels:   n.Channels,
+		Type:       n.Type,
+		Data:       n.Data,
+		OccurredAt: n.OccurredAt,
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+)
+
+// RoutingDepot is a point where vehicles start and end their routes
+type RoutingDepot struct {
+	ID        string
+	Latitude  float64
+	Longitude float64
+}
+
+// RoutingStop is a drop to be visited once
+type RoutingStop struct {
+	ID          string
+	Latitude    float64
+	Longitude   float64
+	Demand      entity.Load
+	ServiceTime time.Duration
+	WindowStart *time.Time
+	WindowEnd   *time.Time
+}
+
+// RoutingVehicle is a fleet vehicle available for the plan
+type RoutingVehicle struct {
+	ID         string
+	DepotID    string
+	Capacity   entity.Load // zero dimensions are unlimited
+	ShiftStart time.Time
+	ShiftEnd   *time.Time
+}
+
+// TravelMatrix holds travel distances (meters) and durations (seconds) between all nodes,
+// indexed depots first and then stops, in problem order
+type TravelMatrix struct {
+	DistancesM [][]float64
+	DurationsS [][]float64
+}
+
+// RoutingProblem is a vehicle routing instance.
+// Without a matrix, travel is estimated from straight-line distances.
+type RoutingProblem struct {
+	Depots    []RoutingDepot
+	Stops     []RoutingStop
+	Vehicles  []RoutingVehicle
+	Matrix    *TravelMatrix
+	TimeLimit time.Duration
+}
+
+// PlannedVisit is a scheduled stop on a proposed route
+type PlannedVisit struct {
+	StopID       string
+	Arrival      time.Time
+	ServiceStart time.Time
+	Departure    time.Time
+}
+
+// PlannedRoute is the proposed trip of one vehicle
+type PlannedRoute struct {
+	VehicleID string
+	Visits    []PlannedVisit
+	DistanceM float64
+	Start     time.Time
+	End       time.Time
+	Load      entity.Load
+}
+
+// UnassignedStop is a stop left out of the plan, with the reason
+type UnassignedStop struct {
+	StopID string
+	Reason string
+}
+
+// RoutingSolution is the proposed plan for a routing problem
+type RoutingSolution struct {
+	Routes         []PlannedRoute
+	Unassigned     []UnassignedStop
+	TotalDistanceM float64
+	Iterations     int
+}
+
+// RouteOptimizer defines the interface for vehicle routing solvers
+type RouteOptimizer interface {
+	// Optimize returns the best plan found within the problem's time limit
+	Optimize(ctx context.Context, problem RoutingProblem) (*RoutingSolution, error)
+}
+
+// TravelEstimator defines the interface for point-to-point road travel estimates
+type TravelEstimator interface {
+	// Estimate returns the road distance in meters and the driving time between two coordinates
+	Estimate(ctx context.Context, fromLat, fromLng, toLat, toLng float64) (float64, time.Duration, error)
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+)
+
+// PositionHistoryWriter defines the interface for persisting GPS positions in the background
+type PositionHistoryWriter interface {
+	// Enqueue hands positions over for writing. It blocks while the queue is full until ctx is done.
+	Enqueue(ctx context.Context, positions []entity.VehiclePosition) error
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"tms-core-service/internal/domain/errs"
+
+	"github.com/google/uuid"
+)
+
+// imageExtensions maps the image content types drivers and staff may upload to file extensions
+var imageExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+}
+
+// ImageUploadURL issues a presigned URL for uploading an image straight to storage under a new object key
+// starting with prefix. An unsupported content type is reported as a validation error on field.
+func ImageUploadURL(ctx context.Context, storage StorageService, prefix, contentType, field string) (url, key string, err error) {
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return "", "", errs.ValidationErrors{field: {"unsupported"}}
+	}
+	key = fmt.Sprintf("%s%s.%s", prefix, uuid.New(), ext)
+
+	url, err = storage.GenerateUploadURL(ctx, key, contentType)
+	if err != nil {
+		return "", "", fmt.Errorf("storage service: generate upload url: %w", err)
+	}
+	return url, key, nil
+}
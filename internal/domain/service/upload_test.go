@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"tms-core-service/internal/domain/errs"
+)
+
+type fakeStorage struct {
+	StorageService
+	keys []string
+}
+
+func (s *fakeStorage) GenerateUploadURL(_ context.Context, key, _ string) (string, error) {
+	s.keys = append(s.keys, key)
+	return "https://storage.example/" + key, nil
+}
+
+func TestImageUploadURL(t *testing.T) {
+	storage := &fakeStorage{}
+
+	url, key, err := ImageUploadURL(context.Background(), storage, "pod/trip/stop/photo-", "image/png", "content_type")
+	if err != nil {
+		t.Fatalf("ImageUploadURL: %v", err)
+	}
+	if !strings.HasPrefix(key, "pod/trip/stop/photo-") || !strings.HasSuffix(key, ".png") {
+		t.Errorf("key = %q, want the prefix and a .png extension", key)
+	}
+	if url != "https://storage.example/"+key {
+		t.Errorf("url = %q, not issued for key %q", url, key)
+	}
+
+	_, _, err = ImageUploadURL(context.Background(), storage, "pod/", "application/pdf", "photo_content_types")
+	var verrs errs.ValidationErrors
+	if !errors.As(err, &verrs) || verrs["photo_content_types"] == nil {
+		t.Errorf("err = %v, want a validation error on photo_content_types", err)
+	}
+	if len(storage.keys) != 1 {
+		t.Errorf("%d upload URLs issued, want none for the unsupported type", len(storage.keys)-1)
+	}
+}
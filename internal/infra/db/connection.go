@@ -20,6 +20,9 @@ func NewConnection(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	// Open database connection
 	db, err := gorm.Open(postgres.Open(cfg.GetDSN()), &gorm.Config{
 		Logger: gormLogger,
+		// Map driver errors such as unique violations to gorm.ErrDuplicatedKey so repositories can
+		// report errs.ErrConflict
+		TranslateError: true,
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
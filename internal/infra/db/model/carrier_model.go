@@ -0,0 +1,112 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Carrier is the database model for carriers
+type Carrier struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name            string    `gorm:"not null"`
+	TaxID           string    `gorm:"index"`
+	BranchCode      string    `gorm:"not null;default:'00000'"`
+	ContactName     string
+	ContactPhone    string
+	ContactEmail    string
+	FleetTypes      string     `gorm:"type:jsonb;not null;default:'[]'"`
+	ServiceAreas    string     `gorm:"type:jsonb;not null;default:'[]'"`
+	InsuranceExpiry *time.Time `gorm:"type:date"`
+	Status          string     `gorm:"not null;index"`
+	CreatedAt       time.Time  `gorm:"not null;default:now()"`
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName specifies the table name for Carrier
+func (Carrier) TableName() string {
+	return "carriers"
+}
+
+// CarrierUser is the database model linking user accounts to carriers
+type CarrierUser struct {
+	CarrierID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `gorm:"not null;default:now()"`
+}
+
+// TableName specifies the table name for CarrierUser
+func (CarrierUser) TableName() string {
+	return "carrier_users"
+}
+
+// ToEntity converts database model to domain entity
+func (m *Carrier) ToEntity() *entity.Carrier {
+	var deletedAt *time.Time
+	if m.DeletedAt.Valid {
+		deletedAt = &m.DeletedAt.Time
+	}
+
+	var fleetTypes []entity.VehicleType
+	_ = json.Unmarshal([]byte(m.FleetTypes), &fleetTypes)
+	var serviceAreas []string
+	_ = json.Unmarshal([]byte(m.ServiceAreas), &serviceAreas)
+
+	return &entity.Carrier{
+		ID:              m.ID,
+		Name:            m.Name,
+		TaxID:           m.TaxID,
+		BranchCode:      m.BranchCode,
+		ContactName:     m.ContactName,
+		ContactPhone:    m.ContactPhone,
+		ContactEmail:    m.ContactEmail,
+		FleetTypes:      fleetTypes,
+		ServiceAreas:    serviceAreas,
+		InsuranceExpiry: m.InsuranceExpiry,
+		Status:          entity.CarrierStatus(m.Status),
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+		DeletedAt:       deletedAt,
+	}
+}
+
+// CarrierFromEntity creates a database model from a domain entity
+func CarrierFromEntity(e *entity.Carrier) *Carrier {
+	var deletedAt gorm.DeletedAt
+	if e.DeletedAt != nil {
+		deletedAt = gorm.DeletedAt{Time: *e.DeletedAt, Valid: true}
+	}
+
+	fleetTypes := e.FleetTypes
+	if fleetTypes == nil {
+		fleetTypes = []entity.VehicleType{}
+	}
+	fleetJSON, _ := json.Marshal(fleetTypes)
+	serviceAreas := e.ServiceAreas
+	if serviceAreas == nil {
+		serviceAreas = []string{}
+	}
+	areasJSON, _ := json.Marshal(serviceAreas)
+
+	return &Carrier{
+		ID:              e.ID,
+		Name:            e.Name,
+		TaxID:           e.TaxID,
+		BranchCode:      e.BranchCode,
+		ContactName:     e.ContactName,
+		ContactPhone:    e.ContactPhone,
+		ContactEmail:    e.ContactEmail,
+		FleetTypes:      string(fleetJSON),
+		ServiceAreas:    string(areasJSON),
+		InsuranceExpiry: e.InsuranceExpiry,
+		Status:          string(e.Status),
+		CreatedAt:       e.CreatedAt,
+		UpdatedAt:       e.UpdatedAt,
+		DeletedAt:       deletedAt,
+	}
+}
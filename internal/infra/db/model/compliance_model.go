@@ -0,0 +1,72 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ComplianceDocument is the database model for compliance documents
+type ComplianceDocument struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	OwnerType    string    `gorm:"not null;index:idx_compliance_documents_owner"`
+	OwnerID      uuid.UUID `gorm:"type:uuid;not null;index:idx_compliance_documents_owner"`
+	Type         string    `gorm:"not null"`
+	Number       string
+	IssuedOn     *time.Time `gorm:"type:date"`
+	ExpiresOn    time.Time  `gorm:"type:date;not null;index"`
+	FileKey      string
+	Notes        string
+	RemindedDays *int
+	RemindedAt   *time.Time
+	CreatedBy    *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt    time.Time  `gorm:"not null;default:now()"`
+	UpdatedAt    time.Time
+}
+
+// TableName specifies the table name for ComplianceDocument
+func (ComplianceDocument) TableName() string {
+	return "compliance_documents"
+}
+
+// ToEntity converts database model to domain entity
+func (m *ComplianceDocument) ToEntity() *entity.ComplianceDocument {
+	return &entity.ComplianceDocument{
+		ID:           m.ID,
+		OwnerType:    entity.ComplianceOwnerType(m.OwnerType),
+		OwnerID:      m.OwnerID,
+		Type:         entity.ComplianceDocumentType(m.Type),
+		Number:       m.Number,
+		IssuedOn:     m.IssuedOn,
+		ExpiresOn:    m.ExpiresOn,
+		FileKey:      m.FileKey,
+		Notes:        m.Notes,
+		RemindedDays: m.RemindedDays,
+		RemindedAt:   m.RemindedAt,
+		CreatedBy:    m.CreatedBy,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+// ComplianceDocumentFromEntity creates a database model from a domain entity
+func ComplianceDocumentFromEntity(e *entity.ComplianceDocument) *ComplianceDocument {
+	return &ComplianceDocument{
+		ID:           e.ID,
+		OwnerType:    string(e.OwnerType),
+		OwnerID:      e.OwnerID,
+		Type:         string(e.Type),
+		Number:       e.Number,
+		IssuedOn:     e.IssuedOn,
+		ExpiresOn:    e.ExpiresOn,
+		FileKey:      e.FileKey,
+		Notes:        e.Notes,
+		RemindedDays: e.RemindedDays,
+		RemindedAt:   e.RemindedAt,
+		CreatedBy:    e.CreatedBy,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryAttempt is the database model for failed delivery attempts
+type DeliveryAttempt struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TripID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	StopID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	ShipmentID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	DriverID    uuid.UUID `gorm:"type:uuid;not null"`
+	Attempt     int       `gorm:"not null"`
+	Reason      string    `gorm:"not null"`
+	PhotoKey    string    `gorm:"not null"`
+	Latitude    float64   `gorm:"not null"`
+	Longitude   float64   `gorm:"not null"`
+	AccuracyM   *float64
+	DistanceM   *float64
+	AttemptedAt time.Time `gorm:"not null"`
+	Notes       string
+	LegID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	Leg         string    `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"not null;default:now()"`
+}
+
+// TableName specifies the table name for DeliveryAttempt
+func (DeliveryAttempt) TableName() string {
+	return "delivery_attempts"
+}
+
+// ToEntity converts database model to domain entity
+func (m *DeliveryAttempt) ToEntity() *entity.DeliveryAttempt {
+	return &entity.DeliveryAttempt{
+		ID:          m.ID,
+		TripID:      m.TripID,
+		StopID:      m.StopID,
+		ShipmentID:  m.ShipmentID,
+		DriverID:    m.DriverID,
+		Attempt:     m.Attempt,
+		Reason:      entity.FailureReason(m.Reason),
+		PhotoKey:    m.PhotoKey,
+		Latitude:    m.Latitude,
+		Longitude:   m.Longitude,
+		AccuracyM:   m.AccuracyM,
+		DistanceM:   m.DistanceM,
+		AttemptedAt: m.AttemptedAt,
+		Notes:       m.Notes,
+		LegID:       m.LegID,
+		Leg:         entity.ShipmentLeg(m.Leg),
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+// DeliveryAttemptFromEntity creates a database model from a domain entity
+func DeliveryAttemptFromEntity(e *entity.DeliveryAttempt) *DeliveryAttempt {
+	return &DeliveryAttempt{
+		ID:          e.ID,
+		TripID:      e.TripID,
+		StopID:      e.StopID,
+		ShipmentID:  e.ShipmentID,
+		DriverID:    e.DriverID,
+		Attempt:     e.Attempt,
+		Reason:      string(e.Reason),
+		PhotoKey:    e.PhotoKey,
+		Latitude:    e.Latitude,
+		Longitude:   e.Longitude,
+		AccuracyM:   e.AccuracyM,
+		DistanceM:   e.DistanceM,
+		AttemptedAt: e.AttemptedAt,
+		Notes:       e.Notes,
+		LegID:       e.LegID,
+		Leg:         string(e.Leg),
+		CreatedAt:   e.CreatedAt,
+	}
+}
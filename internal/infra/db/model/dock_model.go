@@ -0,0 +1,168 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// Dock is the database model for docks
+type Dock struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	LocationID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name           string    `gorm:"not null"`
+	Code           string
+	Direction      string    `gorm:"not null"`
+	Capacity       int       `gorm:"not null"`
+	SlotMinutes    int       `gorm:"not null"`
+	OperatingHours string    `gorm:"type:jsonb;not null;default:'[]'"`
+	VehicleTypes   string    `gorm:"type:jsonb;not null;default:'[]'"`
+	Active         bool      `gorm:"not null"`
+	CreatedAt      time.Time `gorm:"not null;default:now()"`
+	UpdatedAt      time.Time
+}
+
+// TableName specifies the table name for Dock
+func (Dock) TableName() string {
+	return "docks"
+}
+
+// ToEntity converts database model to domain entity
+func (m *Dock) ToEntity() *entity.Dock {
+	var hours []openingHours
+	_ = json.Unmarshal([]byte(m.OperatingHours), &hours)
+	entityHours := make([]entity.OpeningHours, len(hours))
+	for i, h := range hours {
+		entityHours[i] = entity.OpeningHours{Weekday: time.Weekday(h.Weekday), Open: h.Open, Close: h.Close}
+	}
+	var vehicleTypes []entity.VehicleType
+	_ = json.Unmarshal([]byte(m.VehicleTypes), &vehicleTypes)
+
+	return &entity.Dock{
+		ID:             m.ID,
+		LocationID:     m.LocationID,
+		Name:           m.Name,
+		Code:           m.Code,
+		Direction:      entity.DockDirection(m.Direction),
+		Capacity:       m.Capacity,
+		SlotMinutes:    m.SlotMinutes,
+		OperatingHours: entityHours,
+		VehicleTypes:   vehicleTypes,
+		Active:         m.Active,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// DockFromEntity creates a database model from a domain entity
+func DockFromEntity(e *entity.Dock) *Dock {
+	hours := make([]openingHours, len(e.OperatingHours))
+	for i, h := range e.OperatingHours {
+		hours[i] = openingHours{Weekday: int(h.Weekday), Open: h.Open, Close: h.Close}
+	}
+	hoursJSON, _ := json.Marshal(hours)
+	vehicleTypes := e.VehicleTypes
+	if vehicleTypes == nil {
+		vehicleTypes = []entity.VehicleType{}
+	}
+	typesJSON, _ := json.Marshal(vehicleTypes)
+
+	return &Dock{
+		ID:             e.ID,
+		LocationID:     e.LocationID,
+		Name:           e.Name,
+		Code:           e.Code,
+		Direction:      string(e.Direction),
+		Capacity:       e.Capacity,
+		SlotMinutes:    e.SlotMinutes,
+		OperatingHours: string(hoursJSON),
+		VehicleTypes:   string(typesJSON),
+		Active:         e.Active,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+// DockAppointment is the database model for dock appointments
+type DockAppointment struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	DockID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	LocationID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Direction    string     `gorm:"not null"`
+	TripID       *uuid.UUID `gorm:"type:uuid;index"`
+	CarrierID    *uuid.UUID `gorm:"type:uuid;index"`
+	VehicleType  string
+	PlateNumber  string
+	DriverName   string
+	Reference    string
+	StartAt      time.Time `gorm:"not null"`
+	EndAt        time.Time `gorm:"not null"`
+	Status       string    `gorm:"not null"`
+	CheckedInAt  *time.Time
+	CheckedOutAt *time.Time
+	CancelReason string
+	Notes        string
+	CreatedBy    *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt    time.Time  `gorm:"not null;default:now()"`
+	UpdatedAt    time.Time
+}
+
+// TableName specifies the table name for DockAppointment
+func (DockAppointment) TableName() string {
+	return "dock_appointments"
+}
+
+// ToEntity converts database model to domain entity
+func (m *DockAppointment) ToEntity() *entity.DockAppointment {
+	return &entity.DockAppointment{
+		ID:           m.ID,
+		DockID:       m.DockID,
+		LocationID:   m.LocationID,
+		Direction:    entity.DockDirection(m.Direction),
+		TripID:       m.TripID,
+		CarrierID:    m.CarrierID,
+		VehicleType:  entity.VehicleType(m.VehicleType),
+		PlateNumber:  m.PlateNumber,
+		DriverName:   m.DriverName,
+		Reference:    m.Reference,
+		StartAt:      m.StartAt,
+		EndAt:        m.EndAt,
+		Status:       entity.DockAppointmentStatus(m.Status),
+		CheckedInAt:  m.CheckedInAt,
+		CheckedOutAt: m.CheckedOutAt,
+		CancelReason: m.CancelReason,
+		Notes:        m.Notes,
+		CreatedBy:    m.CreatedBy,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+// DockAppointmentFromEntity creates a database model from a domain entity
+func DockAppointmentFromEntity(e *entity.DockAppointment) *DockAppointment {
+	return &DockAppointment{
+		ID:           e.ID,
+		DockID:       e.DockID,
+		LocationID:   e.LocationID,
+		Direction:    string(e.Direction),
+		TripID:       e.TripID,
+		CarrierID:    e.CarrierID,
+		VehicleType:  string(e.VehicleType),
+		PlateNumber:  e.PlateNumber,
+		DriverName:   e.DriverName,
+		Reference:    e.Reference,
+		StartAt:      e.StartAt,
+		EndAt:        e.EndAt,
+		Status:       string(e.Status),
+		CheckedInAt:  e.CheckedInAt,
+		CheckedOutAt: e.CheckedOutAt,
+		CancelReason: e.CancelReason,
+		Notes:        e.Notes,
+		CreatedBy:    e.CreatedBy,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Driver is the database model for drivers
+type Driver struct {
+	ID                    uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID                uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	LicenseNumber         string    `gorm:"not null"`
+	LicenseClass          string    `gorm:"not null"`
+	LicenseExpiry         time.Time `gorm:"type:date;not null"`
+	EmploymentType        string    `gorm:"not null"`
+	HomeDepot             string
+	EmergencyContactName  string
+	EmergencyContactPhone string
+	Status                string    `gorm:"not null;index"`
+	User                  *User     `gorm:"foreignKey:UserID"`
+	CreatedAt             time.Time `gorm:"not null;default:now()"`
+	UpdatedAt             time.Time
+	DeletedAt             gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName specifies the table name for Driver
+func (Driver) TableName() string {
+	return "drivers"
+}
+
+// ToEntity converts database model to domain entity
+func (m *Driver) ToEntity() *entity.Driver {
+	var deletedAt *time.Time
+	if m.DeletedAt.Valid {
+		deletedAt = &m.DeletedAt.Time
+	}
+
+	var user *entity.User
+	if m.User != nil {
+		user = m.User.ToEntity()
+	}
+
+	return &entity.Driver{
+		ID:                    m.ID,
+		UserID:                m.UserID,
+		LicenseNumber:         m.LicenseNumber,
+		LicenseClass:          m.LicenseClass,
+		LicenseExpiry:         m.LicenseExpiry,
+		EmploymentType:        entity.EmploymentType(m.EmploymentType),
+		HomeDepot:             m.HomeDepot,
+		EmergencyContactName:  m.EmergencyContactName,
+		EmergencyContactPhone: m.EmergencyContactPhone,
+		Status:                entity.DriverStatus(m.Status),
+		User:                  user,
+		CreatedAt:             m.CreatedAt,
+		UpdatedAt:             m.UpdatedAt,
+		DeletedAt:             deletedAt,
+	}
+}
+
+// DriverFromEntity creates a database model from a domain entity.
+// The linked user is not copied so that saving a driver never writes the users table.
+func DriverFromEntity(e *entity.Driver) *Driver {
+	var deletedAt gorm.DeletedAt
+	if e.DeletedAt != nil {
+		deletedAt = gorm.DeletedAt{Time: *e.DeletedAt, Valid: true}
+	}
+
+	return &Driver{
+		ID:                    e.ID,
+		UserID:                e.UserID,
+		LicenseNumber:         e.LicenseNumber,
+		LicenseClass:          e.LicenseClass,
+		LicenseExpiry:         e.LicenseExpiry,
+		EmploymentType:        string(e.EmploymentType),
+		HomeDepot:             e.HomeDepot,
+		EmergencyContactName:  e.EmergencyContactName,
+		EmergencyContactPhone: e.EmergencyContactPhone,
+		Status:                string(e.Status),
+		CreatedAt:             e.CreatedAt,
+		UpdatedAt:             e.UpdatedAt,
+		DeletedAt:             deletedAt,
+	}
+}
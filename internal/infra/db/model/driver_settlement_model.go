@@ -0,0 +1,276 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// DriverPayRule is the database model for driver pay rules
+type DriverPayRule struct {
+	ID                 uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name               string    `gorm:"not null"`
+	VehicleType        string    // empty matches any vehicle type
+	BasePerTrip        float64   `gorm:"type:numeric(12,2);not null"`
+	PerKm              float64   `gorm:"type:numeric(12,2);not null"`
+	PerDrop            float64   `gorm:"type:numeric(12,2);not null"`
+	OvernightAllowance float64   `gorm:"type:numeric(12,2);not null"`
+	OvertimeRate       float64   `gorm:"type:numeric(12,2);not null"`
+	StandardHours      float64   `gorm:"type:numeric(5,2);not null"`
+	EffectiveFrom      time.Time `gorm:"not null"`
+	EffectiveTo        *time.Time
+	CreatedAt          time.Time `gorm:"not null;default:now()"`
+	UpdatedAt          time.Time
+}
+
+// TableName specifies the table name for DriverPayRule
+func (DriverPayRule) TableName() string {
+	return "driver_pay_rules"
+}
+
+// ToEntity converts database model to domain entity
+func (m *DriverPayRule) ToEntity() *entity.DriverPayRule {
+	return &entity.DriverPayRule{
+		ID:                 m.ID,
+		Name:               m.Name,
+		VehicleType:        entity.VehicleType(m.VehicleType),
+		BasePerTrip:        entity.Baht(m.BasePerTrip),
+		PerKm:              entity.Baht(m.PerKm),
+		PerDrop:            entity.Baht(m.PerDrop),
+		OvernightAllowance: entity.Baht(m.OvernightAllowance),
+		OvertimeRate:       entity.Baht(m.OvertimeRate),
+		StandardHours:      m.StandardHours,
+		EffectiveFrom:      m.EffectiveFrom,
+		EffectiveTo:        m.EffectiveTo,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+}
+
+// DriverPayRuleFromEntity creates a database model from a domain entity
+func DriverPayRuleFromEntity(e *entity.DriverPayRule) *DriverPayRule {
+	return &DriverPayRule{
+		ID:                 e.ID,
+		Name:               e.Name,
+		VehicleType:        string(e.VehicleType),
+		BasePerTrip:        e.BasePerTrip.Baht(),
+		PerKm:              e.PerKm.Baht(),
+		PerDrop:            e.PerDrop.Baht(),
+		OvernightAllowance: e.OvernightAllowance.Baht(),
+		OvertimeRate:       e.OvertimeRate.Baht(),
+		StandardHours:      e.StandardHours,
+		EffectiveFrom:      e.EffectiveFrom,
+		EffectiveTo:        e.EffectiveTo,
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
+	}
+}
+
+// DriverSettlement is the database model for driver settlements
+type DriverSettlement struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Number      *string   `gorm:"uniqueIndex"` // NULL while draft
+	DriverID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Status      string    `gorm:"not null;default:'draft'"`
+	PeriodFrom  time.Time `gorm:"type:date;not null"`
+	PeriodTo    time.Time `gorm:"type:date;not null"`
+	GrossPay    float64   `gorm:"type:numeric(14,2);not null"`
+	Advances    float64   `gorm:"type:numeric(14,2);not null"`
+	Deductions  float64   `gorm:"type:numeric(14,2);not null"`
+	NetPay      float64   `gorm:"type:numeric(14,2);not null"`
+	Notes       string
+	ApprovedAt  *time.Time
+	ApprovedBy  *uuid.UUID `gorm:"type:uuid"`
+	VoidedAt    *time.Time
+	VoidReason  string
+	Lines       []DriverSettlementLine       `gorm:"foreignKey:SettlementID"`
+	Adjustments []DriverSettlementAdjustment `gorm:"foreignKey:SettlementID"`
+	CreatedAt   time.Time                    `gorm:"not null;default:now()"`
+	UpdatedAt   time.Time
+}
+
+// TableName specifies the table name for DriverSettlement
+func (DriverSettlement) TableName() string {
+	return "driver_settlements"
+}
+
+// DriverSettlementLine is the database model for the trip lines of a driver settlement
+type DriverSettlementLine struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	SettlementID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Sequence      int       `gorm:"not null"`
+	TripID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	TripNumber    string    `gorm:"not null"`
+	CoDriver      bool      `gorm:"not null"`
+	VehicleType   string
+	PayRuleID     uuid.UUID `gorm:"type:uuid;not null"`
+	StartedAt     time.Time `gorm:"not null"`
+	EndedAt       time.Time `gorm:"not null"`
+	DistanceKm    float64   `gorm:"type:numeric(10,1);not null"`
+	Drops         int       `gorm:"not null"`
+	Overnights    int       `gorm:"not null"`
+	OvertimeHours float64   `gorm:"type:numeric(6,2);not null"`
+	BasePay       float64   `gorm:"type:numeric(12,2);not null"`
+	DistancePay   float64   `gorm:"type:numeric(12,2);not null"`
+	DropPay       float64   `gorm:"type:numeric(12,2);not null"`
+	OvernightPay  float64   `gorm:"type:numeric(12,2);not null"`
+	OvertimePay   float64   `gorm:"type:numeric(12,2);not null"`
+	Amount        float64   `gorm:"type:numeric(12,2);not null"`
+}
+
+// TableName specifies the table name for DriverSettlementLine
+func (DriverSettlementLine) TableName() string {
+	return "driver_settlement_lines"
+}
+
+// DriverSettlementAdjustment is the database model for the advances and deductions of a driver settlement
+type DriverSettlementAdjustment struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	SettlementID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Kind         string    `gorm:"not null"`
+	Description  string    `gorm:"not null"`
+	Amount       float64   `gorm:"type:numeric(12,2);not null"`
+	CreatedAt    time.Time `gorm:"not null;default:now()"`
+}
+
+// TableName specifies the table name for DriverSettlementAdjustment
+func (DriverSettlementAdjustment) TableName() string {
+	return "driver_settlement_adjustments"
+}
+
+// ToEntity converts database model to domain entity
+func (m *DriverSettlement) ToEntity() *entity.DriverSettlement {
+	lines := make([]entity.SettlementLine, len(m.Lines))
+	for i, l := range m.Lines {
+		lines[i] = entity.SettlementLine{
+			ID:            l.ID,
+			SettlementID:  l.SettlementID,
+			Sequence:      l.Sequence,
+			TripID:        l.TripID,
+			TripNumber:    l.TripNumber,
+			CoDriver:      l.CoDriver,
+			VehicleType:   entity.VehicleType(l.VehicleType),
+			PayRuleID:     l.PayRuleID,
+			StartedAt:     l.StartedAt,
+			EndedAt:       l.EndedAt,
+			DistanceKm:    l.DistanceKm,
+			Drops:         l.Drops,
+			Overnights:    l.Overnights,
+			OvertimeHours: l.OvertimeHours,
+			BasePay:       entity.Baht(l.BasePay),
+			DistancePay:   entity.Baht(l.DistancePay),
+			DropPay:       entity.Baht(l.DropPay),
+			OvernightPay:  entity.Baht(l.OvernightPay),
+			OvertimePay:   entity.Baht(l.OvertimePay),
+			Amount:        entity.Baht(l.Amount),
+		}
+	}
+
+	adjustments := make([]entity.SettlementAdjustment, len(m.Adjustments))
+	for i, a := range m.Adjustments {
+		adjustments[i] = entity.SettlementAdjustment{
+			ID:           a.ID,
+			SettlementID: a.SettlementID,
+			Kind:         entity.AdjustmentKind(a.Kind),
+			Description:  a.Description,
+			Amount:       entity.Baht(a.Amount),
+			CreatedAt:    a.CreatedAt,
+		}
+	}
+
+	var number string
+	if m.Number != nil {
+		number = *m.Number
+	}
+
+	return &entity.DriverSettlement{
+		ID:          m.ID,
+		Number:      number,
+		DriverID:    m.DriverID,
+		Status:      entity.SettlementStatus(m.Status),
+		PeriodFrom:  m.PeriodFrom,
+		PeriodTo:    m.PeriodTo,
+		Lines:       lines,
+		Adjustments: adjustments,
+		GrossPay:    entity.Baht(m.GrossPay),
+		Advances:    entity.Baht(m.Advances),
+		Deductions:  entity.Baht(m.Deductions),
+		NetPay:      entity.Baht(m.NetPay),
+		Notes:       m.Notes,
+		ApprovedAt:  m.ApprovedAt,
+		ApprovedBy:  m.ApprovedBy,
+		VoidedAt:    m.VoidedAt,
+		VoidReason:  m.VoidReason,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// DriverSettlementFromEntity creates a database model from a domain entity.
+// Lines and adjustments are not copied; the repository writes them separately.
+func DriverSettlementFromEntity(e *entity.DriverSettlement) *DriverSettlement {
+	var number *string
+	if e.Number != "" {
+		number = &e.Number
+	}
+
+	return &DriverSettlement{
+		ID:         e.ID,
+		Number:     number,
+		DriverID:   e.DriverID,
+		Status:     string(e.Status),
+		PeriodFrom: e.PeriodFrom,
+		PeriodTo:   e.PeriodTo,
+		GrossPay:   e.GrossPay.Baht(),
+		Advances:   e.Advances.Baht(),
+		Deductions: e.Deductions.Baht(),
+		NetPay:     e.NetPay.Baht(),
+		Notes:      e.Notes,
+		ApprovedAt: e.ApprovedAt,
+		ApprovedBy: e.ApprovedBy,
+		VoidedAt:   e.VoidedAt,
+		VoidReason: e.VoidReason,
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  e.UpdatedAt,
+	}
+}
+
+// DriverSettlementLineFromEntity creates a database model from a settlement line
+func DriverSettlementLineFromEntity(settlementID uuid.UUID, e entity.SettlementLine) *DriverSettlementLine {
+	return &DriverSettlementLine{
+		ID:            e.ID,
+		SettlementID:  settlementID,
+		Sequence:      e.Sequence,
+		TripID:        e.TripID,
+		TripNumber:    e.TripNumber,
+		CoDriver:      e.CoDriver,
+		VehicleType:   string(e.VehicleType),
+		PayRuleID:     e.PayRuleID,
+		StartedAt:     e.StartedAt,
+		EndedAt:       e.EndedAt,
+		DistanceKm:    e.DistanceKm,
+		Drops:         e.Drops,
+		Overnights:    e.Overnights,
+		OvertimeHours: e.OvertimeHours,
+		BasePay:       e.BasePay.Baht(),
+		DistancePay:   e.DistancePay.Baht(),
+		DropPay:       e.DropPay.Baht(),
+		OvernightPay:  e.OvernightPay.Baht(),
+		OvertimePay:   e.OvertimePay.Baht(),
+		Amount:        e.Amount.Baht(),
+	}
+}
+
+// DriverSettlementAdjustmentFromEntity creates a database model from a settlement adjustment
+func DriverSettlementAdjustmentFromEntity(settlementID uuid.UUID, e entity.SettlementAdjustment) *DriverSettlementAdjustment {
+	return &DriverSettlementAdjustment{
+		ID:           e.ID,
+		SettlementID: settlementID,
+		Kind:         string(e.Kind),
+		Description:  e.Description,
+		Amount:       e.Amount.Baht(),
+		CreatedAt:    e.CreatedAt,
+	}
+}
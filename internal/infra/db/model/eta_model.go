@@ -0,0 +1,81 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// LaneSpeed is the database model for historical lane speeds
+type LaneSpeed struct {
+	OriginProvince      string    `gorm:"primaryKey"`
+	DestinationProvince string    `gorm:"primaryKey"`
+	Hour                int       `gorm:"primaryKey"`
+	Samples             int       `gorm:"not null"`
+	SpeedKmh            float64   `gorm:"not null"`
+	UpdatedAt           time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for LaneSpeed
+func (LaneSpeed) TableName() string {
+	return "lane_speeds"
+}
+
+// ToEntity converts database model to domain entity
+func (m *LaneSpeed) ToEntity() *entity.LaneSpeed {
+	return &entity.LaneSpeed{
+		OriginProvince:      m.OriginProvince,
+		DestinationProvince: m.DestinationProvince,
+		Hour:                m.Hour,
+		Samples:             m.Samples,
+		SpeedKmh:            m.SpeedKmh,
+		UpdatedAt:           m.UpdatedAt,
+	}
+}
+
+// StopDelay is the database model for stop delays
+type StopDelay struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TripID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	StopID     uuid.UUID `gorm:"type:uuid;not null"`
+	ShipmentID uuid.UUID `gorm:"type:uuid;not null"`
+	VehicleID  uuid.UUID `gorm:"type:uuid;not null"`
+	ETA        time.Time `gorm:"column:eta;not null"`
+	WindowEnd  time.Time `gorm:"not null"`
+	DetectedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for StopDelay
+func (StopDelay) TableName() string {
+	return "stop_delays"
+}
+
+// ToEntity converts database model to domain entity
+func (m *StopDelay) ToEntity() *entity.StopDelay {
+	return &entity.StopDelay{
+		ID:         m.ID,
+		TripID:     m.TripID,
+		StopID:     m.StopID,
+		ShipmentID: m.ShipmentID,
+		VehicleID:  m.VehicleID,
+		ETA:        m.ETA,
+		WindowEnd:  m.WindowEnd,
+		DetectedAt: m.DetectedAt,
+	}
+}
+
+// StopDelayFromEntity creates a database model from a domain entity
+func StopDelayFromEntity(e *entity.StopDelay) *StopDelay {
+	return &StopDelay{
+		ID:         e.ID,
+		TripID:     e.TripID,
+		StopID:     e.StopID,
+		ShipmentID: e.ShipmentID,
+		VehicleID:  e.VehicleID,
+		ETA:        e.ETA,
+		WindowEnd:  e.WindowEnd,
+		DetectedAt: e.DetectedAt,
+	}
+}
@@ -0,0 +1,151 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// coordinate is the JSON representation of entity.Coordinate
+type coordinate struct {
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lng"`
+}
+
+// Geofence is the database model for geofences
+type Geofence struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	LocationID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name         string    `gorm:"not null"`
+	Shape        string    `gorm:"not null"`
+	CenterLat    *float64
+	CenterLng    *float64
+	RadiusM      *float64
+	Polygon      string    `gorm:"type:jsonb;not null;default:'[]'"`
+	DwellSeconds int       `gorm:"not null"`
+	ExitBufferM  float64   `gorm:"not null"`
+	Active       bool      `gorm:"not null;default:true"`
+	CreatedAt    time.Time `gorm:"not null;default:now()"`
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName specifies the table name for Geofence
+func (Geofence) TableName() string {
+	return "geofences"
+}
+
+// ToEntity converts database model to domain entity
+func (m *Geofence) ToEntity() *entity.Geofence {
+	var points []coordinate
+	_ = json.Unmarshal([]byte(m.Polygon), &points)
+	var polygon []entity.Coordinate
+	for _, p := range points {
+		polygon = append(polygon, entity.Coordinate{Latitude: p.Latitude, Longitude: p.Longitude})
+	}
+
+	g := &entity.Geofence{
+		ID:          m.ID,
+		LocationID:  m.LocationID,
+		Name:        m.Name,
+		Shape:       entity.GeofenceShape(m.Shape),
+		Polygon:     polygon,
+		DwellTime:   time.Duration(m.DwellSeconds) * time.Second,
+		ExitBufferM: m.ExitBufferM,
+		Active:      m.Active,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+	if m.CenterLat != nil && m.CenterLng != nil {
+		g.Center = entity.Coordinate{Latitude: *m.CenterLat, Longitude: *m.CenterLng}
+	}
+	if m.RadiusM != nil {
+		g.RadiusM = *m.RadiusM
+	}
+	return g
+}
+
+// GeofenceFromEntity creates a database model from a domain entity
+func GeofenceFromEntity(e *entity.Geofence) *Geofence {
+	points := make([]coordinate, len(e.Polygon))
+	for i, p := range e.Polygon {
+		points[i] = coordinate{Latitude: p.Latitude, Longitude: p.Longitude}
+	}
+	polygonJSON, _ := json.Marshal(points)
+
+	m := &Geofence{
+		ID:           e.ID,
+		LocationID:   e.LocationID,
+		Name:         e.Name,
+		Shape:        string(e.Shape),
+		Polygon:      string(polygonJSON),
+		DwellSeconds: int(e.DwellTime / time.Second),
+		ExitBufferM:  e.ExitBufferM,
+		Active:       e.Active,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+	if e.Shape == entity.GeofenceShapeCircle {
+		lat, lng, radius := e.Center.Latitude, e.Center.Longitude, e.RadiusM
+		m.CenterLat, m.CenterLng, m.RadiusM = &lat, &lng, &radius
+	}
+	return m
+}
+
+// GeofenceEvent is the database model for geofence events
+type GeofenceEvent struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	GeofenceID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	LocationID uuid.UUID  `gorm:"type:uuid;not null"`
+	VehicleID  uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TripID     *uuid.UUID `gorm:"type:uuid;index"`
+	StopID     *uuid.UUID `gorm:"type:uuid"`
+	Type       string     `gorm:"not null"`
+	OccurredAt time.Time  `gorm:"not null"`
+	Latitude   float64    `gorm:"not null"`
+	Longitude  float64    `gorm:"not null"`
+	CreatedAt  time.Time  `gorm:"not null;default:now()"`
+}
+
+// TableName specifies the table name for GeofenceEvent
+func (GeofenceEvent) TableName() string {
+	return "geofence_events"
+}
+
+// ToEntity converts database model to domain entity
+func (m *GeofenceEvent) ToEntity() *entity.GeofenceEvent {
+	return &entity.GeofenceEvent{
+		ID:         m.ID,
+		GeofenceID: m.GeofenceID,
+		LocationID: m.LocationID,
+		VehicleID:  m.VehicleID,
+		TripID:     m.TripID,
+		StopID:     m.StopID,
+		Type:       entity.GeofenceEventType(m.Type),
+		OccurredAt: m.OccurredAt,
+		Latitude:   m.Latitude,
+		Longitude:  m.Longitude,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+// GeofenceEventFromEntity creates a database model from a domain entity
+func GeofenceEventFromEntity(e *entity.GeofenceEvent) *GeofenceEvent {
+	return &GeofenceEvent{
+		ID:         e.ID,
+		GeofenceID: e.GeofenceID,
+		LocationID: e.LocationID,
+		VehicleID:  e.VehicleID,
+		TripID:     e.TripID,
+		StopID:     e.StopID,
+		Type:       string(e.Type),
+		OccurredAt: e.OccurredAt,
+		Latitude:   e.Latitude,
+		Longitude:  e.Longitude,
+		CreatedAt:  e.CreatedAt,
+	}
+}
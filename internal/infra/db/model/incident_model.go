@@ -0,0 +1,115 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// Incident is the database model for incidents
+type Incident struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	DocumentNumber string     `gorm:"not null;uniqueIndex"`
+	Category       string     `gorm:"not null"`
+	Severity       string     `gorm:"not null"`
+	Status         string     `gorm:"not null;index"`
+	TripID         *uuid.UUID `gorm:"type:uuid;index"`
+	StopID         *uuid.UUID `gorm:"type:uuid"`
+	ShipmentID     *uuid.UUID `gorm:"type:uuid;index"`
+	Description    string     `gorm:"not null"`
+	PhotoKeys      string     `gorm:"type:jsonb;not null;default:'[]'"`
+	Latitude       *float64
+	Longitude      *float64
+	LocationID     *uuid.UUID `gorm:"type:uuid"`
+	ReportedBy     uuid.UUID  `gorm:"type:uuid;not null"`
+	OccurredAt     time.Time  `gorm:"not null"`
+	OwnerID        *uuid.UUID `gorm:"type:uuid;index"`
+	DueAt          time.Time  `gorm:"not null"`
+	BreachedAt     *time.Time
+	Resolution     string
+	ResolvedBy     *uuid.UUID `gorm:"type:uuid"`
+	ResolvedAt     *time.Time
+	ClosedAt       *time.Time
+	CancelReason   string
+	CreatedAt      time.Time `gorm:"not null;default:now()"`
+	UpdatedAt      time.Time
+}
+
+// TableName specifies the table name for Incident
+func (Incident) TableName() string {
+	return "incidents"
+}
+
+// ToEntity converts database model to domain entity
+func (m *Incident) ToEntity() *entity.Incident {
+	var photoKeys []string
+	_ = json.Unmarshal([]byte(m.PhotoKeys), &photoKeys)
+
+	return &entity.Incident{
+		ID:           m.ID,
+		Number:       m.DocumentNumber,
+		Category:     entity.IncidentCategory(m.Category),
+		Severity:     entity.IncidentSeverity(m.Severity),
+		Status:       entity.IncidentStatus(m.Status),
+		TripID:       m.TripID,
+		StopID:       m.StopID,
+		ShipmentID:   m.ShipmentID,
+		Description:  m.Description,
+		PhotoKeys:    photoKeys,
+		Latitude:     m.Latitude,
+		Longitude:    m.Longitude,
+		LocationID:   m.LocationID,
+		ReportedBy:   m.ReportedBy,
+		OccurredAt:   m.OccurredAt,
+		OwnerID:      m.OwnerID,
+		DueAt:        m.DueAt,
+		BreachedAt:   m.BreachedAt,
+		Resolution:   m.Resolution,
+		ResolvedBy:   m.ResolvedBy,
+		ResolvedAt:   m.ResolvedAt,
+		ClosedAt:     m.ClosedAt,
+		CancelReason: m.CancelReason,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+// IncidentFromEntity creates a database model from a domain entity
+func IncidentFromEntity(e *entity.Incident) *Incident {
+	photoKeys := e.PhotoKeys
+	if photoKeys == nil {
+		photoKeys = []string{}
+	}
+	photoJSON, _ := json.Marshal(photoKeys)
+
+	return &Incident{
+		ID:             e.ID,
+		DocumentNumber: e.Number,
+		Category:       string(e.Category),
+		Severity:       string(e.Severity),
+		Status:         string(e.Status),
+		TripID:         e.TripID,
+		StopID:         e.StopID,
+		ShipmentID:     e.ShipmentID,
+		Description:    e.Description,
+		PhotoKeys:      string(photoJSON),
+		Latitude:       e.Latitude,
+		Longitude:      e.Longitude,
+		LocationID:     e.LocationID,
+		ReportedBy:     e.ReportedBy,
+		OccurredAt:     e.OccurredAt,
+		OwnerID:        e.OwnerID,
+		DueAt:          e.DueAt,
+		BreachedAt:     e.BreachedAt,
+		Resolution:     e.Resolution,
+		ResolvedBy:     e.ResolvedBy,
+		ResolvedAt:     e.ResolvedAt,
+		ClosedAt:       e.ClosedAt,
+		CancelReason:   e.CancelReason,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
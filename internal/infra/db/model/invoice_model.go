@@ -0,0 +1,172 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// Invoice is the database model for invoices and credit notes
+type Invoice struct {
+	ID                uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Number            *string    `gorm:"uniqueIndex"` // NULL while draft
+	Kind              string     `gorm:"not null"`
+	Status            string     `gorm:"not null;default:'draft'"`
+	OrganizationID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	CreditedInvoiceID *uuid.UUID `gorm:"type:uuid;index"`
+	PeriodFrom        time.Time  `gorm:"type:date;not null"`
+	PeriodTo          time.Time  `gorm:"type:date;not null"`
+	Currency          string     `gorm:"not null"`
+	CreditTermDays    int        `gorm:"not null;default:0"`
+	IssueDate         *time.Time `gorm:"type:date"`
+	DueDate           *time.Time `gorm:"type:date"`
+	Subtotal          float64    `gorm:"type:numeric(14,2);not null"`
+	VATRate           float64    `gorm:"column:vat_rate;type:numeric(5,4);not null"`
+	VATAmount         float64    `gorm:"column:vat_amount;type:numeric(14,2);not null"`
+	Total             float64    `gorm:"type:numeric(14,2);not null"`
+	WithholdingRate   float64    `gorm:"type:numeric(5,4);not null"`
+	WithholdingTax    float64    `gorm:"type:numeric(14,2);not null"`
+	AmountDue         float64    `gorm:"type:numeric(14,2);not null"`
+	Reason            string
+	Notes             string
+	PaidAt            *time.Time
+	PaymentReference  string
+	VoidedAt          *time.Time
+	VoidReason        string
+	Lines             []InvoiceLine `gorm:"foreignKey:InvoiceID"`
+	CreatedAt         time.Time     `gorm:"not null;default:now()"`
+	UpdatedAt         time.Time
+}
+
+// TableName specifies the table name for Invoice
+func (Invoice) TableName() string {
+	return "invoices"
+}
+
+// InvoiceLine is the database model for invoice lines
+type InvoiceLine struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	InvoiceID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Sequence    int        `gorm:"not null"`
+	ShipmentID  *uuid.UUID `gorm:"type:uuid;index"`
+	ServiceDate *time.Time
+	Description string  `gorm:"not null"`
+	Quantity    float64 `gorm:"type:numeric(14,3);not null"`
+	UnitPrice   float64 `gorm:"type:numeric(14,2);not null"`
+	Amount      float64 `gorm:"type:numeric(14,2);not null"`
+}
+
+// TableName specifies the table name for InvoiceLine
+func (InvoiceLine) TableName() string {
+	return "invoice_lines"
+}
+
+// ToEntity converts database model to domain entity
+func (m *Invoice) ToEntity() *entity.Invoice {
+	lines := make([]entity.InvoiceLine, len(m.Lines))
+	for i, l := range m.Lines {
+		lines[i] = entity.InvoiceLine{
+			ID:          l.ID,
+			InvoiceID:   l.InvoiceID,
+			Sequence:    l.Sequence,
+			ShipmentID:  l.ShipmentID,
+			ServiceDate: l.ServiceDate,
+			Description: l.Description,
+			Quantity:    l.Quantity,
+			UnitPrice:   entity.Baht(l.UnitPrice),
+			Amount:      entity.Baht(l.Amount),
+		}
+	}
+
+	var number string
+	if m.Number != nil {
+		number = *m.Number
+	}
+
+	return &entity.Invoice{
+		ID:                m.ID,
+		Number:            number,
+		Kind:              entity.InvoiceKind(m.Kind),
+		Status:            entity.InvoiceStatus(m.Status),
+		OrganizationID:    m.OrganizationID,
+		CreditedInvoiceID: m.CreditedInvoiceID,
+		PeriodFrom:        m.PeriodFrom,
+		PeriodTo:          m.PeriodTo,
+		Currency:          m.Currency,
+		CreditTermDays:    m.CreditTermDays,
+		IssueDate:         m.IssueDate,
+		DueDate:           m.DueDate,
+		Lines:             lines,
+		Subtotal:          entity.Baht(m.Subtotal),
+		VATRate:           m.VATRate,
+		VATAmount:         entity.Baht(m.VATAmount),
+		Total:             entity.Baht(m.Total),
+		WithholdingRate:   m.WithholdingRate,
+		WithholdingTax:    entity.Baht(m.WithholdingTax),
+		AmountDue:         entity.Baht(m.AmountDue),
+		Reason:            m.Reason,
+		Notes:             m.Notes,
+		PaidAt:            m.PaidAt,
+		PaymentReference:  m.PaymentReference,
+		VoidedAt:          m.VoidedAt,
+		VoidReason:        m.VoidReason,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+	}
+}
+
+// InvoiceFromEntity creates a database model from a domain entity.
+// Lines are not copied; the repository writes them separately.
+func InvoiceFromEntity(e *entity.Invoice) *Invoice {
+	var number *string
+	if e.Number != "" {
+		number = &e.Number
+	}
+
+	return &Invoice{
+		ID:                e.ID,
+		Number:            number,
+		Kind:              string(e.Kind),
+		Status:            string(e.Status),
+		OrganizationID:    e.OrganizationID,
+		CreditedInvoiceID: e.CreditedInvoiceID,
+		PeriodFrom:        e.PeriodFrom,
+		PeriodTo:          e.PeriodTo,
+		Currency:          e.Currency,
+		CreditTermDays:    e.CreditTermDays,
+		IssueDate:         e.IssueDate,
+		DueDate:           e.DueDate,
+		Subtotal:          e.Subtotal.Baht(),
+		VATRate:           e.VATRate,
+		VATAmount:         e.VATAmount.Baht(),
+		Total:             e.Total.Baht(),
+		WithholdingRate:   e.WithholdingRate,
+		WithholdingTax:    e.WithholdingTax.Baht(),
+		AmountDue:         e.AmountDue.Baht(),
+		Reason:            e.Reason,
+		Notes:             e.Notes,
+		PaidAt:            e.PaidAt,
+		PaymentReference:  e.PaymentReference,
+		VoidedAt:          e.VoidedAt,
+		VoidReason:        e.VoidReason,
+		CreatedAt:         e.CreatedAt,
+		UpdatedAt:         e.UpdatedAt,
+	}
+}
+
+// InvoiceLineFromEntity creates a database model from an invoice line
+func InvoiceLineFromEntity(invoiceID uuid.UUID, e entity.InvoiceLine) *InvoiceLine {
+	return &InvoiceLine{
+		ID:          e.ID,
+		InvoiceID:   invoiceID,
+		Sequence:    e.Sequence,
+		ShipmentID:  e.ShipmentID,
+		ServiceDate: e.ServiceDate,
+		Description: e.Description,
+		Quantity:    e.Quantity,
+		UnitPrice:   e.UnitPrice.Baht(),
+		Amount:      e.Amount.Baht(),
+	}
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// LabelTemplate is the database model for organizations' label templates
+type LabelTemplate struct {
+	OrganizationID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Size             string    `gorm:"not null"`
+	DPI              int       `gorm:"column:dpi;not null"`
+	ZPLFont          string    `gorm:"column:zpl_font;not null"`
+	GS1CompanyPrefix string    `gorm:"column:gs1_company_prefix;not null"`
+	SSCCExtension    int       `gorm:"column:sscc_extension;not null"`
+	Footer           string    `gorm:"not null"`
+	UpdatedAt        time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for LabelTemplate
+func (LabelTemplate) TableName() string {
+	return "label_templates"
+}
+
+// ToEntity converts database model to domain entity
+func (m *LabelTemplate) ToEntity() *entity.LabelTemplate {
+	return &entity.LabelTemplate{
+		OrganizationID:   m.OrganizationID,
+		Size:             entity.LabelSize(m.Size),
+		DPI:              m.DPI,
+		ZPLFont:          m.ZPLFont,
+		GS1CompanyPrefix: m.GS1CompanyPrefix,
+		SSCCExtension:    m.SSCCExtension,
+		Footer:           m.Footer,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+// LabelTemplateFromEntity creates a database model from a domain entity
+func LabelTemplateFromEntity(e *entity.LabelTemplate) *LabelTemplate {
+	return &LabelTemplate{
+		OrganizationID:   e.OrganizationID,
+		Size:             string(e.Size),
+		DPI:              e.DPI,
+		ZPLFont:          e.ZPLFont,
+		GS1CompanyPrefix: e.GS1CompanyPrefix,
+		SSCCExtension:    e.SSCCExtension,
+		Footer:           e.Footer,
+		UpdatedAt:        e.UpdatedAt,
+	}
+}
+
+// ShipmentPiece is the database model for labelled shipment pieces
+type ShipmentPiece struct {
+	ShipmentID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Piece      int       `gorm:"primaryKey"`
+	SSCC       string    `gorm:"column:sscc;not null"`
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for ShipmentPiece
+func (ShipmentPiece) TableName() string {
+	return "shipment_pieces"
+}
+
+// ToEntity converts database model to domain entity
+func (m *ShipmentPiece) ToEntity() *entity.ShipmentPiece {
+	return &entity.ShipmentPiece{
+		ShipmentID: m.ShipmentID,
+		Piece:      m.Piece,
+		SSCC:       m.SSCC,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+// ShipmentPieceFromEntity creates a database model from a domain entity
+func ShipmentPieceFromEntity(e *entity.ShipmentPiece) *ShipmentPiece {
+	return &ShipmentPiece{
+		ShipmentID: e.ShipmentID,
+		Piece:      e.Piece,
+		SSCC:       e.SSCC,
+		CreatedAt:  e.CreatedAt,
+	}
+}
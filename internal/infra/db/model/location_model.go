@@ -0,0 +1,128 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// openingHours is the JSON representation of entity.OpeningHours
+type openingHours struct {
+	Weekday int    `json:"weekday"`
+	Open    string `json:"open"`
+	Close   string `json:"close"`
+}
+
+// Location is the database model for locations
+type Location struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	OrganizationID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name           string    `gorm:"not null"`
+	Code           string
+	HouseNumber    string
+	Moo            string
+	Soi            string
+	Road           string
+	Subdistrict    string `gorm:"not null"`
+	District       string `gorm:"not null"`
+	Province       string `gorm:"not null"`
+	Postcode       string `gorm:"not null;index"`
+	Latitude       *float64
+	Longitude      *float64
+	GeoPrecision   string
+	ContactName    string
+	ContactPhone   string
+	OpeningHours   string `gorm:"type:jsonb;not null;default:'[]'"`
+	Notes          string
+	CreatedAt      time.Time `gorm:"not null;default:now()"`
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName specifies the table name for Location
+func (Location) TableName() string {
+	return "locations"
+}
+
+// ToEntity converts database model to domain entity
+func (m *Location) ToEntity() *entity.Location {
+	var deletedAt *time.Time
+	if m.DeletedAt.Valid {
+		deletedAt = &m.DeletedAt.Time
+	}
+
+	var hours []openingHours
+	_ = json.Unmarshal([]byte(m.OpeningHours), &hours)
+	entityHours := make([]entity.OpeningHours, len(hours))
+	for i, h := range hours {
+		entityHours[i] = entity.OpeningHours{Weekday: time.Weekday(h.Weekday), Open: h.Open, Close: h.Close}
+	}
+
+	return &entity.Location{
+		ID:             m.ID,
+		OrganizationID: m.OrganizationID,
+		Name:           m.Name,
+		Code:           m.Code,
+		HouseNumber:    m.HouseNumber,
+		Moo:            m.Moo,
+		Soi:            m.Soi,
+		Road:           m.Road,
+		Subdistrict:    m.Subdistrict,
+		District:       m.District,
+		Province:       m.Province,
+		Postcode:       m.Postcode,
+		Latitude:       m.Latitude,
+		Longitude:      m.Longitude,
+		GeoPrecision:   entity.GeoPrecision(m.GeoPrecision),
+		ContactName:    m.ContactName,
+		ContactPhone:   m.ContactPhone,
+		OpeningHours:   entityHours,
+		Notes:          m.Notes,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+		DeletedAt:      deletedAt,
+	}
+}
+
+// LocationFromEntity creates a database model from a domain entity
+func LocationFromEntity(e *entity.Location) *Location {
+	var deletedAt gorm.DeletedAt
+	if e.DeletedAt != nil {
+		deletedAt = gorm.DeletedAt{Time: *e.DeletedAt, Valid: true}
+	}
+
+	hours := make([]openingHours, len(e.OpeningHours))
+	for i, h := range e.OpeningHours {
+		hours[i] = openingHours{Weekday: int(h.Weekday), Open: h.Open, Close: h.Close}
+	}
+	hoursJSON, _ := json.Marshal(hours)
+
+	return &Location{
+		ID:             e.ID,
+		OrganizationID: e.OrganizationID,
+		Name:           e.Name,
+		Code:           e.Code,
+		HouseNumber:    e.HouseNumber,
+		Moo:            e.Moo,
+		Soi:            e.Soi,
+		Road:           e.Road,
+		Subdistrict:    e.Subdistrict,
+		District:       e.District,
+		Province:       e.Province,
+		Postcode:       e.Postcode,
+		Latitude:       e.Latitude,
+		Longitude:      e.Longitude,
+		GeoPrecision:   string(e.GeoPrecision),
+		ContactName:    e.ContactName,
+		ContactPhone:   e.ContactPhone,
+		OpeningHours:   string(hoursJSON),
+		Notes:          e.Notes,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+		DeletedAt:      deletedAt,
+	}
+}
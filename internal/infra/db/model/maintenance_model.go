@@ -0,0 +1,243 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// MaintenancePlan is the database model for maintenance plans
+type MaintenancePlan struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name         string    `gorm:"not null"`
+	VehicleType  string    `gorm:"not null;index"`
+	IntervalKm   float64   `gorm:"type:numeric(10,1);not null"`
+	IntervalDays int       `gorm:"not null"`
+	Description  string
+	Active       bool      `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"not null;default:now()"`
+	UpdatedAt    time.Time
+}
+
+// TableName specifies the table name for MaintenancePlan
+func (MaintenancePlan) TableName() string {
+	return "maintenance_plans"
+}
+
+// ToEntity converts database model to domain entity
+func (m *MaintenancePlan) ToEntity() *entity.MaintenancePlan {
+	return &entity.MaintenancePlan{
+		ID:           m.ID,
+		Name:         m.Name,
+		VehicleType:  entity.VehicleType(m.VehicleType),
+		IntervalKm:   m.IntervalKm,
+		IntervalDays: m.IntervalDays,
+		Description:  m.Description,
+		Active:       m.Active,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+// MaintenancePlanFromEntity creates a database model from a domain entity
+func MaintenancePlanFromEntity(e *entity.MaintenancePlan) *MaintenancePlan {
+	return &MaintenancePlan{
+		ID:           e.ID,
+		Name:         e.Name,
+		VehicleType:  string(e.VehicleType),
+		IntervalKm:   e.IntervalKm,
+		IntervalDays: e.IntervalDays,
+		Description:  e.Description,
+		Active:       e.Active,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
+
+// MaintenanceSchedule is the database model for maintenance schedules
+type MaintenanceSchedule struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	VehicleID       uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_maintenance_schedules_vehicle_plan"`
+	PlanID          uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_maintenance_schedules_vehicle_plan"`
+	LastServiceAt   time.Time  `gorm:"not null"`
+	LastServiceKm   float64    `gorm:"type:numeric(10,1);not null"`
+	LastWorkOrderID *uuid.UUID `gorm:"type:uuid"`
+	CurrentKm       float64    `gorm:"type:numeric(10,1);not null"`
+	NextDueAt       *time.Time
+	NextDueKm       *float64  `gorm:"type:numeric(10,1)"`
+	Status          string    `gorm:"not null"`
+	CheckedAt       time.Time `gorm:"not null"`
+	CreatedAt       time.Time `gorm:"not null;default:now()"`
+	UpdatedAt       time.Time
+}
+
+// TableName specifies the table name for MaintenanceSchedule
+func (MaintenanceSchedule) TableName() string {
+	return "maintenance_schedules"
+}
+
+// ToEntity converts database model to domain entity
+func (m *MaintenanceSchedule) ToEntity() *entity.MaintenanceSchedule {
+	return &entity.MaintenanceSchedule{
+		ID:              m.ID,
+		VehicleID:       m.VehicleID,
+		PlanID:          m.PlanID,
+		LastServiceAt:   m.LastServiceAt,
+		LastServiceKm:   m.LastServiceKm,
+		LastWorkOrderID: m.LastWorkOrderID,
+		CurrentKm:       m.CurrentKm,
+		NextDueAt:       m.NextDueAt,
+		NextDueKm:       m.NextDueKm,
+		Status:          entity.MaintenanceStatus(m.Status),
+		CheckedAt:       m.CheckedAt,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// MaintenanceScheduleFromEntity creates a database model from a domain entity
+func MaintenanceScheduleFromEntity(e *entity.MaintenanceSchedule) *MaintenanceSchedule {
+	return &MaintenanceSchedule{
+		ID:              e.ID,
+		VehicleID:       e.VehicleID,
+		PlanID:          e.PlanID,
+		LastServiceAt:   e.LastServiceAt,
+		LastServiceKm:   e.LastServiceKm,
+		LastWorkOrderID: e.LastWorkOrderID,
+		CurrentKm:       e.CurrentKm,
+		NextDueAt:       e.NextDueAt,
+		NextDueKm:       e.NextDueKm,
+		Status:          string(e.Status),
+		CheckedAt:       e.CheckedAt,
+		CreatedAt:       e.CreatedAt,
+		UpdatedAt:       e.UpdatedAt,
+	}
+}
+
+// WorkOrder is the database model for work orders
+type WorkOrder struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Number        string     `gorm:"not null;uniqueIndex"`
+	VehicleID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	PlanID        *uuid.UUID `gorm:"type:uuid"`
+	Type          string     `gorm:"not null"`
+	Status        string     `gorm:"not null"`
+	Workshop      string
+	Description   string
+	OdometerKm    float64 `gorm:"type:numeric(10,1);not null"`
+	PartsCost     float64 `gorm:"type:numeric(12,2);not null"`
+	LaborCost     float64 `gorm:"type:numeric(12,2);not null"`
+	TotalCost     float64 `gorm:"type:numeric(12,2);not null"`
+	StartedAt     *time.Time
+	CompletedAt   *time.Time
+	DowntimeHours float64 `gorm:"type:numeric(8,2);not null"`
+	Notes         string
+	CreatedBy     *uuid.UUID      `gorm:"type:uuid"`
+	Parts         []WorkOrderPart `gorm:"foreignKey:WorkOrderID"`
+	CreatedAt     time.Time       `gorm:"not null;default:now()"`
+	UpdatedAt     time.Time
+}
+
+// TableName specifies the table name for WorkOrder
+func (WorkOrder) TableName() string {
+	return "work_orders"
+}
+
+// WorkOrderPart is the database model for the parts of a work order
+type WorkOrderPart struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	WorkOrderID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Sequence    int       `gorm:"not null"`
+	PartNumber  string
+	Name        string  `gorm:"not null"`
+	Quantity    float64 `gorm:"type:numeric(10,2);not null"`
+	UnitCost    float64 `gorm:"type:numeric(12,2);not null"`
+	Amount      float64 `gorm:"type:numeric(12,2);not null"`
+}
+
+// TableName specifies the table name for WorkOrderPart
+func (WorkOrderPart) TableName() string {
+	return "work_order_parts"
+}
+
+// ToEntity converts database model to domain entity
+func (m *WorkOrder) ToEntity() *entity.WorkOrder {
+	parts := make([]entity.WorkOrderPart, len(m.Parts))
+	for i, p := range m.Parts {
+		parts[i] = entity.WorkOrderPart{
+			ID:          p.ID,
+			WorkOrderID: p.WorkOrderID,
+			Sequence:    p.Sequence,
+			PartNumber:  p.PartNumber,
+			Name:        p.Name,
+			Quantity:    p.Quantity,
+			UnitCost:    entity.Baht(p.UnitCost),
+			Amount:      entity.Baht(p.Amount),
+		}
+	}
+
+	return &entity.WorkOrder{
+		ID:            m.ID,
+		Number:        m.Number,
+		VehicleID:     m.VehicleID,
+		PlanID:        m.PlanID,
+		Type:          entity.WorkOrderType(m.Type),
+		Status:        entity.WorkOrderStatus(m.Status),
+		Workshop:      m.Workshop,
+		Description:   m.Description,
+		OdometerKm:    m.OdometerKm,
+		Parts:         parts,
+		PartsCost:     entity.Baht(m.PartsCost),
+		LaborCost:     entity.Baht(m.LaborCost),
+		TotalCost:     entity.Baht(m.TotalCost),
+		StartedAt:     m.StartedAt,
+		CompletedAt:   m.CompletedAt,
+		DowntimeHours: m.DowntimeHours,
+		Notes:         m.Notes,
+		CreatedBy:     m.CreatedBy,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+// WorkOrderFromEntity creates a database model from a domain entity.
+// Parts are not copied; the repository writes them separately.
+func WorkOrderFromEntity(e *entity.WorkOrder) *WorkOrder {
+	return &WorkOrder{
+		ID:            e.ID,
+		Number:        e.Number,
+		VehicleID:     e.VehicleID,
+		PlanID:        e.PlanID,
+		Type:          string(e.Type),
+		Status:        string(e.Status),
+		Workshop:      e.Workshop,
+		Description:   e.Description,
+		OdometerKm:    e.OdometerKm,
+		PartsCost:     e.PartsCost.Baht(),
+		LaborCost:     e.LaborCost.Baht(),
+		TotalCost:     e.TotalCost.Baht(),
+		StartedAt:     e.StartedAt,
+		CompletedAt:   e.CompletedAt,
+		DowntimeHours: e.DowntimeHours,
+		Notes:         e.Notes,
+		CreatedBy:     e.CreatedBy,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
+
+// WorkOrderPartFromEntity creates a database model from a work order part
+func WorkOrderPartFromEntity(workOrderID uuid.UUID, e entity.WorkOrderPart) *WorkOrderPart {
+	return &WorkOrderPart{
+		ID:          e.ID,
+		WorkOrderID: workOrderID,
+		Sequence:    e.Sequence,
+		PartNumber:  e.PartNumber,
+		Name:        e.Name,
+		Quantity:    e.Quantity,
+		UnitCost:    e.UnitCost.Baht(),
+		Amount:      e.Amount.Baht(),
+	}
+}
@@ -0,0 +1,89 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// Notification is the database model for the notification outbox
+type Notification struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Type          string    `gorm:"not null"`
+	Channels      string    `gorm:"type:jsonb;not null;default:'[]'"`
+	Data          string    `gorm:"type:jsonb;not null;default:'{}'"`
+	OccurredAt    time.Time `gorm:"not null"`
+	Status        string    `gorm:"not null;default:'pending'"`
+	Attempts      int       `gorm:"not null;default:0"`
+	LastError     string
+	NextAttemptAt time.Time `gorm:"not null;default:now()"`
+	DeliveredAt   *time.Time
+	CreatedAt     time.Time `gorm:"not null;default:now()"`
+	UpdatedAt     time.Time
+}
+
+// TableName specifies the table name for Notification
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// ToEntity converts database model to domain entity
+func (m *Notification) ToEntity() *entity.Notification {
+	var channels []string
+	_ = json.Unmarshal([]byte(m.Channels), &channels)
+	var data map[string]interface{}
+	_ = json.Unmarshal([]byte(m.Data), &data)
+
+	return &entity.Notification{
+		ID:            m.ID,
+		Type:          m.Type,
+		Channels:      channels,
+		Data:          data,
+		OccurredAt:    m.OccurredAt,
+		Status:        entity.NotificationStatus(m.Status),
+		Attempts:      m.Attempts,
+		LastError:     m.LastError,
+		NextAttemptAt: m.NextAttemptAt,
+		DeliveredAt:   m.DeliveredAt,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+// NotificationFromEntity creates a database model from a domain entity
+func NotificationFromEntity(e *entity.Notification) (*Notification, error) {
+	channels := e.Channels
+	if channels == nil {
+		channels = []string{}
+	}
+	channelJSON, err := json.Marshal(channels)
+	if err != nil {
+		return nil, err
+	}
+	data := e.Data
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Notification{
+		ID:            e.ID,
+		Type:          e.Type,
+		Channels:      string(channelJSON),
+		Data:          string(dataJSON),
+		OccurredAt:    e.OccurredAt,
+		Status:        string(e.Status),
+		Attempts:      e.Attempts,
+		LastError:     e.LastError,
+		NextAttemptAt: e.NextAttemptAt,
+		DeliveredAt:   e.DeliveredAt,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// NumberingFormat is the database model for organizations' number formats
+type NumberingFormat struct {
+	OrganizationID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	DocumentType   string    `gorm:"primaryKey"`
+	Format         string    `gorm:"not null"`
+	UpdatedAt      time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for NumberingFormat
+func (NumberingFormat) TableName() string {
+	return "numbering_formats"
+}
+
+// ToEntity converts database model to domain entity
+func (m *NumberingFormat) ToEntity() *entity.NumberingFormat {
+	return &entity.NumberingFormat{
+		OrganizationID: m.OrganizationID,
+		DocumentType:   entity.DocumentType(m.DocumentType),
+		Format:         m.Format,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+// NumberingFormatFromEntity creates a database model from a domain entity
+func NumberingFormatFromEntity(e *entity.NumberingFormat) *NumberingFormat {
+	return &NumberingFormat{
+		OrganizationID: e.OrganizationID,
+		DocumentType:   string(e.DocumentType),
+		Format:         e.Format,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+// NumberSequence is the database model for number sequences
+type NumberSequence struct {
+	OrganizationID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	DocumentType   string    `gorm:"primaryKey"`
+	Period         string    `gorm:"primaryKey"`
+	LastValue      int64     `gorm:"not null"`
+	UpdatedAt      time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for NumberSequence
+func (NumberSequence) TableName() string {
+	return "number_sequences"
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Organization is the database model for organizations
+type Organization struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name           string    `gorm:"not null"`
+	TaxID          string    `gorm:"index"`
+	BranchCode     string    `gorm:"not null"`
+	Phone          string
+	Email          string
+	CreditTermDays *int
+	WithholdsTax   bool      `gorm:"not null;default:true"`
+	CreatedAt      time.Time `gorm:"not null;default:now()"`
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName specifies the table name for Organization
+func (Organization) TableName() string {
+	return "organizations"
+}
+
+// ToEntity converts database model to domain entity
+func (m *Organization) ToEntity() *entity.Organization {
+	var deletedAt *time.Time
+	if m.DeletedAt.Valid {
+		deletedAt = &m.DeletedAt.Time
+	}
+
+	return &entity.Organization{
+		ID:             m.ID,
+		Name:           m.Name,
+		TaxID:          m.TaxID,
+		BranchCode:     m.BranchCode,
+		Phone:          m.Phone,
+		Email:          m.Email,
+		CreditTermDays: m.CreditTermDays,
+		WithholdsTax:   m.WithholdsTax,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+		DeletedAt:      deletedAt,
+	}
+}
+
+// OrganizationFromEntity creates a database model from a domain entity
+func OrganizationFromEntity(e *entity.Organization) *Organization {
+	var deletedAt gorm.DeletedAt
+	if e.DeletedAt != nil {
+		deletedAt = gorm.DeletedAt{Time: *e.DeletedAt, Valid: true}
+	}
+
+	return &Organization{
+		ID:             e.ID,
+		Name:           e.Name,
+		TaxID:          e.TaxID,
+		BranchCode:     e.BranchCode,
+		Phone:          e.Phone,
+		Email:          e.Email,
+		CreditTermDays: e.CreditTermDays,
+		WithholdsTax:   e.WithholdsTax,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+		DeletedAt:      deletedAt,
+	}
+}
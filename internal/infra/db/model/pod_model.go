@@ -0,0 +1,88 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ProofOfDelivery is the database model for proofs of delivery
+type ProofOfDelivery struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	DocumentNumber string    `gorm:"not null;uniqueIndex"`
+	TripID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	StopID         uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	ShipmentID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	DriverID       uuid.UUID `gorm:"type:uuid;not null"`
+	RecipientName  string    `gorm:"not null"`
+	SignatureKey   string    `gorm:"not null"`
+	PhotoKeys      string    `gorm:"type:jsonb;not null;default:'[]'"`
+	Latitude       float64   `gorm:"not null"`
+	Longitude      float64   `gorm:"not null"`
+	AccuracyM      *float64
+	DistanceM      *float64
+	CapturedAt     time.Time `gorm:"not null"`
+	Notes          string
+	CreatedAt      time.Time `gorm:"not null;default:now()"`
+}
+
+// TableName specifies the table name for ProofOfDelivery
+func (ProofOfDelivery) TableName() string {
+	return "proof_of_deliveries"
+}
+
+// ToEntity converts database model to domain entity
+func (m *ProofOfDelivery) ToEntity() *entity.ProofOfDelivery {
+	var photoKeys []string
+	_ = json.Unmarshal([]byte(m.PhotoKeys), &photoKeys)
+
+	return &entity.ProofOfDelivery{
+		ID:            m.ID,
+		Number:        m.DocumentNumber,
+		TripID:        m.TripID,
+		StopID:        m.StopID,
+		ShipmentID:    m.ShipmentID,
+		DriverID:      m.DriverID,
+		RecipientName: m.RecipientName,
+		SignatureKey:  m.SignatureKey,
+		PhotoKeys:     photoKeys,
+		Latitude:      m.Latitude,
+		Longitude:     m.Longitude,
+		AccuracyM:     m.AccuracyM,
+		DistanceM:     m.DistanceM,
+		CapturedAt:    m.CapturedAt,
+		Notes:         m.Notes,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+// ProofOfDeliveryFromEntity creates a database model from a domain entity
+func ProofOfDeliveryFromEntity(e *entity.ProofOfDelivery) *ProofOfDelivery {
+	photoKeys := e.PhotoKeys
+	if photoKeys == nil {
+		photoKeys = []string{}
+	}
+	photoJSON, _ := json.Marshal(photoKeys)
+
+	return &ProofOfDelivery{
+		ID:             e.ID,
+		DocumentNumber: e.Number,
+		TripID:         e.TripID,
+		StopID:         e.StopID,
+		ShipmentID:     e.ShipmentID,
+		DriverID:       e.DriverID,
+		RecipientName:  e.RecipientName,
+		SignatureKey:   e.SignatureKey,
+		PhotoKeys:      string(photoJSON),
+		Latitude:       e.Latitude,
+		Longitude:      e.Longitude,
+		AccuracyM:      e.AccuracyM,
+		DistanceM:      e.DistanceM,
+		CapturedAt:     e.CapturedAt,
+		Notes:          e.Notes,
+		CreatedAt:      e.CreatedAt,
+	}
+}
@@ -0,0 +1,235 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// rateCardRules is the JSON representation of a rate card's pricing rules; amounts are kept in baht
+type rateCardRules struct {
+	Zones        []rateZone        `json:"zones"`
+	Lanes        []rateLane        `json:"lanes"`
+	Fuel         *fuelSurcharge    `json:"fuel,omitempty"`
+	Accessorials []accessorialRate `json:"accessorials"`
+}
+
+type rateZone struct {
+	Code             string   `json:"code"`
+	Provinces        []string `json:"provinces"`
+	PostcodePrefixes []string `json:"postcode_prefixes"`
+}
+
+type rateLane struct {
+	OriginZone      string         `json:"origin_zone"`
+	DestinationZone string         `json:"destination_zone"`
+	VehicleType     string         `json:"vehicle_type,omitempty"`
+	BaseCharge      float64        `json:"base_charge"`
+	DistanceBands   []distanceBand `json:"distance_bands"`
+	WeightBreaks    []weightBreak  `json:"weight_breaks"`
+	PerPallet       float64        `json:"per_pallet"`
+	MinCharge       float64        `json:"min_charge"`
+}
+
+type distanceBand struct {
+	UpToKm float64 `json:"up_to_km"`
+	Amount float64 `json:"amount"`
+	PerKm  float64 `json:"per_km"`
+}
+
+type weightBreak struct {
+	MinKg float64 `json:"min_kg"`
+	PerKg float64 `json:"per_kg"`
+}
+
+type fuelSurcharge struct {
+	BasePrice   float64 `json:"base_price"`
+	Step        float64 `json:"step"`
+	StepPercent float64 `json:"step_percent"`
+}
+
+type accessorialRate struct {
+	Code        string  `json:"code"`
+	Description string  `json:"description"`
+	Unit        string  `json:"unit"`
+	Amount      float64 `json:"amount"`
+	FreeUnits   float64 `json:"free_units"`
+}
+
+// RateCard is the database model for rate cards
+type RateCard struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	OrganizationID   *uuid.UUID `gorm:"type:uuid;index"`
+	Name             string     `gorm:"not null"`
+	Version          int        `gorm:"not null"`
+	Currency         string     `gorm:"not null"`
+	EffectiveFrom    time.Time  `gorm:"not null"`
+	EffectiveTo      *time.Time
+	VolumetricFactor float64
+	Rules            string    `gorm:"type:jsonb;not null"`
+	CreatedAt        time.Time `gorm:"not null;default:now()"`
+	UpdatedAt        time.Time
+}
+
+// TableName specifies the table name for RateCard
+func (RateCard) TableName() string {
+	return "rate_cards"
+}
+
+// ToEntity converts database model to domain entity
+func (m *RateCard) ToEntity() *entity.RateCard {
+	var rules rateCardRules
+	_ = json.Unmarshal([]byte(m.Rules), &rules)
+
+	card := &entity.RateCard{
+		ID:               m.ID,
+		OrganizationID:   m.OrganizationID,
+		Name:             m.Name,
+		Version:          m.Version,
+		Currency:         m.Currency,
+		EffectiveFrom:    m.EffectiveFrom,
+		EffectiveTo:      m.EffectiveTo,
+		VolumetricFactor: m.VolumetricFactor,
+		Zones:            make([]entity.RateZone, len(rules.Zones)),
+		Lanes:            make([]entity.RateLane, len(rules.Lanes)),
+		Accessorials:     make([]entity.AccessorialRate, len(rules.Accessorials)),
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+	for i, z := range rules.Zones {
+		card.Zones[i] = entity.RateZone{Code: z.Code, Provinces: z.Provinces, PostcodePrefixes: z.PostcodePrefixes}
+	}
+	for i, l := range rules.Lanes {
+		lane := entity.RateLane{
+			OriginZone:      l.OriginZone,
+			DestinationZone: l.DestinationZone,
+			VehicleType:     entity.VehicleType(l.VehicleType),
+			BaseCharge:      entity.Baht(l.BaseCharge),
+			DistanceBands:   make([]entity.DistanceBand, len(l.DistanceBands)),
+			WeightBreaks:    make([]entity.WeightBreak, len(l.WeightBreaks)),
+			PerPallet:       entity.Baht(l.PerPallet),
+			MinCharge:       entity.Baht(l.MinCharge),
+		}
+		for j, b := range l.DistanceBands {
+			lane.DistanceBands[j] = entity.DistanceBand{UpToKm: b.UpToKm, Amount: entity.Baht(b.Amount), PerKm: entity.Baht(b.PerKm)}
+		}
+		for j, b := range l.WeightBreaks {
+			lane.WeightBreaks[j] = entity.WeightBreak{MinKg: b.MinKg, PerKg: entity.Baht(b.PerKg)}
+		}
+		card.Lanes[i] = lane
+	}
+	if rules.Fuel != nil {
+		card.Fuel = &entity.FuelSurcharge{
+			BasePrice:   entity.Baht(rules.Fuel.BasePrice),
+			Step:        entity.Baht(rules.Fuel.Step),
+			StepPercent: rules.Fuel.StepPercent,
+		}
+	}
+	for i, a := range rules.Accessorials {
+		card.Accessorials[i] = entity.AccessorialRate{
+			Code:        a.Code,
+			Description: a.Description,
+			Unit:        entity.AccessorialUnit(a.Unit),
+			Amount:      entity.Baht(a.Amount),
+			FreeUnits:   a.FreeUnits,
+		}
+	}
+	return card
+}
+
+// RateCardFromEntity creates a database model from a domain entity
+func RateCardFromEntity(e *entity.RateCard) *RateCard {
+	rules := rateCardRules{
+		Zones:        make([]rateZone, len(e.Zones)),
+		Lanes:        make([]rateLane, len(e.Lanes)),
+		Accessorials: make([]accessorialRate, len(e.Accessorials)),
+	}
+	for i, z := range e.Zones {
+		rules.Zones[i] = rateZone{Code: z.Code, Provinces: z.Provinces, PostcodePrefixes: z.PostcodePrefixes}
+	}
+	for i, l := range e.Lanes {
+		lane := rateLane{
+			OriginZone:      l.OriginZone,
+			DestinationZone: l.DestinationZone,
+			VehicleType:     string(l.VehicleType),
+			BaseCharge:      l.BaseCharge.Baht(),
+			DistanceBands:   make([]distanceBand, len(l.DistanceBands)),
+			WeightBreaks:    make([]weightBreak, len(l.WeightBreaks)),
+			PerPallet:       l.PerPallet.Baht(),
+			MinCharge:       l.MinCharge.Baht(),
+		}
+		for j, b := range l.DistanceBands {
+			lane.DistanceBands[j] = distanceBand{UpToKm: b.UpToKm, Amount: b.Amount.Baht(), PerKm: b.PerKm.Baht()}
+		}
+		for j, b := range l.WeightBreaks {
+			lane.WeightBreaks[j] = weightBreak{MinKg: b.MinKg, PerKg: b.PerKg.Baht()}
+		}
+		rules.Lanes[i] = lane
+	}
+	if e.Fuel != nil {
+		rules.Fuel = &fuelSurcharge{BasePrice: e.Fuel.BasePrice.Baht(), Step: e.Fuel.Step.Baht(), StepPercent: e.Fuel.StepPercent}
+	}
+	for i, a := range e.Accessorials {
+		rules.Accessorials[i] = accessorialRate{
+			Code:        a.Code,
+			Description: a.Description,
+			Unit:        string(a.Unit),
+			Amount:      a.Amount.Baht(),
+			FreeUnits:   a.FreeUnits,
+		}
+	}
+	rulesJSON, _ := json.Marshal(rules)
+
+	return &RateCard{
+		ID:               e.ID,
+		OrganizationID:   e.OrganizationID,
+		Name:             e.Name,
+		Version:          e.Version,
+		Currency:         e.Currency,
+		EffectiveFrom:    e.EffectiveFrom,
+		EffectiveTo:      e.EffectiveTo,
+		VolumetricFactor: e.VolumetricFactor,
+		Rules:            string(rulesJSON),
+		CreatedAt:        e.CreatedAt,
+		UpdatedAt:        e.UpdatedAt,
+	}
+}
+
+// DieselPrice is the database model for reference diesel prices
+type DieselPrice struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	EffectiveDate time.Time `gorm:"type:date;not null"`
+	PricePerLitre float64   `gorm:"not null"`
+	CreatedAt     time.Time `gorm:"not null;default:now()"`
+	UpdatedAt     time.Time
+}
+
+// TableName specifies the table name for DieselPrice
+func (DieselPrice) TableName() string {
+	return "diesel_prices"
+}
+
+// ToEntity converts database model to domain entity
+func (m *DieselPrice) ToEntity() *entity.DieselPrice {
+	return &entity.DieselPrice{
+		ID:            m.ID,
+		EffectiveDate: m.EffectiveDate,
+		PricePerLitre: entity.Baht(m.PricePerLitre),
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+// DieselPriceFromEntity creates a database model from a domain entity
+func DieselPriceFromEntity(e *entity.DieselPrice) *DieselPrice {
+	return &DieselPrice{
+		ID:            e.ID,
+		EffectiveDate: e.EffectiveDate,
+		PricePerLitre: e.PricePerLitre.Baht(),
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
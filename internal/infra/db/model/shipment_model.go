@@ -0,0 +1,127 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Shipment is the database model for shipments
+type Shipment struct {
+	ID                 uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	OrganizationID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	TrackingNumber     string    `gorm:"not null;uniqueIndex"`
+	Reference          string
+	PickupLocationID   uuid.UUID `gorm:"type:uuid;not null"`
+	DeliveryLocationID uuid.UUID `gorm:"type:uuid;not null"`
+	WeightKg           float64
+	VolumeM3           float64
+	Pallets            int
+	PickupFrom         *time.Time
+	PickupTo           *time.Time
+	DeliverFrom        *time.Time
+	DeliverTo          *time.Time
+	Status             string `gorm:"not null;index"`
+	Notes              string
+	ParentID           *uuid.UUID `gorm:"type:uuid;uniqueIndex"`
+	Leg                string     `gorm:"not null;default:'original'"`
+	Attempt            int        `gorm:"not null;default:1"`
+	CreatedAt          time.Time  `gorm:"not null;default:now()"`
+	UpdatedAt          time.Time
+	DeletedAt          gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName specifies the table name for Shipment
+func (Shipment) TableName() string {
+	return "shipments"
+}
+
+// ToEntity converts database model to domain entity
+func (m *Shipment) ToEntity() *entity.Shipment {
+	var deletedAt *time.Time
+	if m.DeletedAt.Valid {
+		deletedAt = &m.DeletedAt.Time
+	}
+
+	return &entity.Shipment{
+		ID:                 m.ID,
+		OrganizationID:     m.OrganizationID,
+		TrackingNumber:     m.TrackingNumber,
+		Reference:          m.Reference,
+		PickupLocationID:   m.PickupLocationID,
+		DeliveryLocationID: m.DeliveryLocationID,
+		WeightKg:           m.WeightKg,
+		VolumeM3:           m.VolumeM3,
+		Pallets:            m.Pallets,
+		PickupFrom:         m.PickupFrom,
+		PickupTo:           m.PickupTo,
+		DeliverFrom:        m.DeliverFrom,
+		DeliverTo:          m.DeliverTo,
+		Status:             entity.ShipmentStatus(m.Status),
+		Notes:              m.Notes,
+		ParentID:           m.ParentID,
+		Leg:                entity.ShipmentLeg(m.Leg),
+		Attempt:            m.Attempt,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+		DeletedAt:          deletedAt,
+	}
+}
+
+// ShipmentFromEntity creates a database model from a domain entity
+func ShipmentFromEntity(e *entity.Shipment) *Shipment {
+	var deletedAt gorm.DeletedAt
+	if e.DeletedAt != nil {
+		deletedAt = gorm.DeletedAt{Time: *e.DeletedAt, Valid: true}
+	}
+
+	return &Shipment{
+		ID:                 e.ID,
+		OrganizationID:     e.OrganizationID,
+		TrackingNumber:     e.TrackingNumber,
+		Reference:          e.Reference,
+		PickupLocationID:   e.PickupLocationID,
+		DeliveryLocationID: e.DeliveryLocationID,
+		WeightKg:           e.WeightKg,
+		VolumeM3:           e.VolumeM3,
+		Pallets:            e.Pallets,
+		PickupFrom:         e.PickupFrom,
+		PickupTo:           e.PickupTo,
+		DeliverFrom:        e.DeliverFrom,
+		DeliverTo:          e.DeliverTo,
+		Status:             string(e.Status),
+		Notes:              e.Notes,
+		ParentID:           e.ParentID,
+		Leg:                string(e.Leg),
+		Attempt:            e.Attempt,
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
+		DeletedAt:          deletedAt,
+	}
+}
+
+// ShipmentStatusChange is the database model for shipment status history
+type ShipmentStatusChange struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ShipmentID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Status     string    `gorm:"not null"`
+	ChangedAt  time.Time `gorm:"not null;default:now()"`
+}
+
+// TableName specifies the table name for ShipmentStatusChange
+func (ShipmentStatusChange) TableName() string {
+	return "shipment_status_history"
+}
+
+// ToEntity converts database model to domain entity
+func (m *ShipmentStatusChange) ToEntity() *entity.ShipmentStatusChange {
+	return &entity.ShipmentStatusChange{
+		ID:         m.ID,
+		ShipmentID: m.ShipmentID,
+		Status:     entity.ShipmentStatus(m.Status),
+		ChangedAt:  m.ChangedAt,
+	}
+}
@@ -0,0 +1,176 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// Tender is the database model for tenders
+type Tender struct {
+	ID                    uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ShipmentID            *uuid.UUID `gorm:"type:uuid;index"`
+	TripID                *uuid.UUID `gorm:"type:uuid;index"`
+	Status                string     `gorm:"not null;index"`
+	ResponseWindowSeconds int        `gorm:"not null"`
+	VehicleType           string
+	OriginProvince        string
+	DestinationProvince   string
+	PickupAt              *time.Time
+	DeliverBy             *time.Time
+	WeightKg              float64 `gorm:"not null;default:0"`
+	VolumeM3              float64 `gorm:"not null;default:0"`
+	Pallets               int     `gorm:"not null;default:0"`
+	Notes                 string
+	AwardedCarrierID      *uuid.UUID    `gorm:"type:uuid;index"`
+	Offers                []TenderOffer `gorm:"foreignKey:TenderID"`
+	Events                []TenderEvent `gorm:"foreignKey:TenderID"`
+	CreatedAt             time.Time     `gorm:"not null;default:now()"`
+	UpdatedAt             time.Time
+}
+
+// TableName specifies the table name for Tender
+func (Tender) TableName() string {
+	return "tenders"
+}
+
+// TenderOffer is the database model for tender offers
+type TenderOffer struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TenderID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	CarrierID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	Rank        int       `gorm:"not null"`
+	Status      string    `gorm:"not null"`
+	OfferedAt   *time.Time
+	Deadline    *time.Time
+	RespondedAt *time.Time
+	Reason      string
+}
+
+// TableName specifies the table name for TenderOffer
+func (TenderOffer) TableName() string {
+	return "tender_offers"
+}
+
+// TenderEvent is the database model for the tender state change log
+type TenderEvent struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TenderID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Sequence   int        `gorm:"not null"`
+	CarrierID  *uuid.UUID `gorm:"type:uuid"`
+	Type       string     `gorm:"not null"`
+	Note       string
+	OccurredAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for TenderEvent
+func (TenderEvent) TableName() string {
+	return "tender_events"
+}
+
+// ToEntity converts database model to domain entity
+func (m *Tender) ToEntity() *entity.Tender {
+	offers := make([]entity.TenderOffer, len(m.Offers))
+	for i, o := range m.Offers {
+		offers[i] = entity.TenderOffer{
+			ID:          o.ID,
+			TenderID:    o.TenderID,
+			CarrierID:   o.CarrierID,
+			Rank:        o.Rank,
+			Status:      entity.TenderOfferStatus(o.Status),
+			OfferedAt:   o.OfferedAt,
+			Deadline:    o.Deadline,
+			RespondedAt: o.RespondedAt,
+			Reason:      o.Reason,
+		}
+	}
+
+	events := make([]entity.TenderEvent, len(m.Events))
+	for i, e := range m.Events {
+		events[i] = entity.TenderEvent{
+			ID:         e.ID,
+			TenderID:   e.TenderID,
+			Sequence:   e.Sequence,
+			CarrierID:  e.CarrierID,
+			Type:       entity.TenderEventType(e.Type),
+			Note:       e.Note,
+			OccurredAt: e.OccurredAt,
+		}
+	}
+
+	return &entity.Tender{
+		ID:             m.ID,
+		ShipmentID:     m.ShipmentID,
+		TripID:         m.TripID,
+		Status:         entity.TenderStatus(m.Status),
+		ResponseWindow: time.Duration(m.ResponseWindowSeconds) * time.Second,
+		Summary: entity.TenderSummary{
+			VehicleType:         entity.VehicleType(m.VehicleType),
+			OriginProvince:      m.OriginProvince,
+			DestinationProvince: m.DestinationProvince,
+			PickupAt:            m.PickupAt,
+			DeliverBy:           m.DeliverBy,
+			Load:                entity.Load{WeightKg: m.WeightKg, VolumeM3: m.VolumeM3, Pallets: m.Pallets},
+		},
+		Notes:            m.Notes,
+		AwardedCarrierID: m.AwardedCarrierID,
+		Offers:           offers,
+		Events:           events,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+// TenderFromEntity creates a database model from a domain entity.
+// Offers and events are not copied; the repository writes them separately.
+func TenderFromEntity(e *entity.Tender) *Tender {
+	return &Tender{
+		ID:                    e.ID,
+		ShipmentID:            e.ShipmentID,
+		TripID:                e.TripID,
+		Status:                string(e.Status),
+		ResponseWindowSeconds: int(e.ResponseWindow / time.Second),
+		VehicleType:           string(e.Summary.VehicleType),
+		OriginProvince:        e.Summary.OriginProvince,
+		DestinationProvince:   e.Summary.DestinationProvince,
+		PickupAt:              e.Summary.PickupAt,
+		DeliverBy:             e.Summary.DeliverBy,
+		WeightKg:              e.Summary.Load.WeightKg,
+		VolumeM3:              e.Summary.Load.VolumeM3,
+		Pallets:               e.Summary.Load.Pallets,
+		Notes:                 e.Notes,
+		AwardedCarrierID:      e.AwardedCarrierID,
+		CreatedAt:             e.CreatedAt,
+		UpdatedAt:             e.UpdatedAt,
+	}
+}
+
+// TenderOfferFromEntity creates a database model from a tender offer
+func TenderOfferFromEntity(tenderID uuid.UUID, e entity.TenderOffer) *TenderOffer {
+	return &TenderOffer{
+		ID:          e.ID,
+		TenderID:    tenderID,
+		CarrierID:   e.CarrierID,
+		Rank:        e.Rank,
+		Status:      string(e.Status),
+		OfferedAt:   e.OfferedAt,
+		Deadline:    e.Deadline,
+		RespondedAt: e.RespondedAt,
+		Reason:      e.Reason,
+	}
+}
+
+// TenderEventFromEntity creates a database model from a tender event
+func TenderEventFromEntity(tenderID uuid.UUID, e entity.TenderEvent) *TenderEvent {
+	return &TenderEvent{
+		ID:         e.ID,
+		TenderID:   tenderID,
+		Sequence:   e.Sequence,
+		CarrierID:  e.CarrierID,
+		Type:       string(e.Type),
+		Note:       e.Note,
+		OccurredAt: e.OccurredAt,
+	}
+}
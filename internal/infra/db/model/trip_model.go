@@ -0,0 +1,156 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// Trip is the database model for trips
+type Trip struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TripNumber   string     `gorm:"not null;uniqueIndex"`
+	VehicleID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	DriverID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	CoDriverID   *uuid.UUID `gorm:"type:uuid;index"`
+	Status       string     `gorm:"not null;index"`
+	PlannedStart time.Time  `gorm:"not null"`
+	PlannedEnd   time.Time  `gorm:"not null"`
+	Notes        string
+	DispatchedBy *uuid.UUID `gorm:"type:uuid;index"`
+	CarrierID    *uuid.UUID `gorm:"type:uuid;index"`
+	TenderID     *uuid.UUID `gorm:"type:uuid"`
+	Stops        []TripStop `gorm:"foreignKey:TripID"`
+	CreatedAt    time.Time  `gorm:"not null;default:now()"`
+	UpdatedAt    time.Time
+}
+
+// TableName specifies the table name for Trip
+func (Trip) TableName() string {
+	return "trips"
+}
+
+// TripStop is the database model for trip stops
+type TripStop struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TripID         uuid.UUID `gorm:"type:uuid;not null;index"`
+	Sequence       int       `gorm:"not null"`
+	Type           string    `gorm:"not null"`
+	ShipmentID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	LocationID     uuid.UUID `gorm:"type:uuid;not null"`
+	PlannedArrival *time.Time
+	ServiceMinutes *int
+	Status         string `gorm:"not null;default:'pending'"`
+	ArrivedAt      *time.Time
+	DepartedAt     *time.Time
+	ETA            *time.Time `gorm:"column:eta"`
+	DelayedAt      *time.Time
+}
+
+// TableName specifies the table name for TripStop
+func (TripStop) TableName() string {
+	return "trip_stops"
+}
+
+// ToEntity converts database model to domain entity
+func (m *Trip) ToEntity() *entity.Trip {
+	stops := make([]entity.TripStop, len(m.Stops))
+	for i, s := range m.Stops {
+		stops[i] = entity.TripStop{
+			ID:             s.ID,
+			TripID:         s.TripID,
+			Sequence:       s.Sequence,
+			Type:           entity.StopType(s.Type),
+			ShipmentID:     s.ShipmentID,
+			LocationID:     s.LocationID,
+			PlannedArrival: s.PlannedArrival,
+			ServiceTime:    durationFromMinutes(s.ServiceMinutes),
+			Status:         entity.StopStatus(s.Status),
+			ArrivedAt:      s.ArrivedAt,
+			DepartedAt:     s.DepartedAt,
+			ETA:            s.ETA,
+			DelayedAt:      s.DelayedAt,
+		}
+	}
+
+	return &entity.Trip{
+		ID:           m.ID,
+		Number:       m.TripNumber,
+		VehicleID:    m.VehicleID,
+		DriverID:     m.DriverID,
+		CoDriverID:   m.CoDriverID,
+		Status:       entity.TripStatus(m.Status),
+		PlannedStart: m.PlannedStart,
+		PlannedEnd:   m.PlannedEnd,
+		Notes:        m.Notes,
+		DispatchedBy: m.DispatchedBy,
+		CarrierID:    m.CarrierID,
+		TenderID:     m.TenderID,
+		Stops:        stops,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+// TripFromEntity creates a database model from a domain entity.
+// Stops are not copied; the repository writes them separately so they can be replaced as a set.
+func TripFromEntity(e *entity.Trip) *Trip {
+	return &Trip{
+		ID:           e.ID,
+		TripNumber:   e.Number,
+		VehicleID:    e.VehicleID,
+		DriverID:     e.DriverID,
+		CoDriverID:   e.CoDriverID,
+		Status:       string(e.Status),
+		PlannedStart: e.PlannedStart,
+		PlannedEnd:   e.PlannedEnd,
+		Notes:        e.Notes,
+		DispatchedBy: e.DispatchedBy,
+		CarrierID:    e.CarrierID,
+		TenderID:     e.TenderID,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
+
+// TripStopFromEntity creates a database model from a domain entity
+func TripStopFromEntity(tripID uuid.UUID, e entity.TripStop) *TripStop {
+	status := e.Status
+	if status == "" {
+		status = entity.StopStatusPending
+	}
+
+	return &TripStop{
+		ID:             e.ID,
+		TripID:         tripID,
+		Sequence:       e.Sequence,
+		Type:           string(e.Type),
+		ShipmentID:     e.ShipmentID,
+		LocationID:     e.LocationID,
+		PlannedArrival: e.PlannedArrival,
+		ServiceMinutes: minutesFromDuration(e.ServiceTime),
+		Status:         string(status),
+		ArrivedAt:      e.ArrivedAt,
+		DepartedAt:     e.DepartedAt,
+		ETA:            e.ETA,
+		DelayedAt:      e.DelayedAt,
+	}
+}
+
+func durationFromMinutes(minutes *int) *time.Duration {
+	if minutes == nil {
+		return nil
+	}
+	d := time.Duration(*minutes) * time.Minute
+	return &d
+}
+
+func minutesFromDuration(d *time.Duration) *int {
+	if d == nil {
+		return nil
+	}
+	minutes := int(*d / time.Minute)
+	return &minutes
+}
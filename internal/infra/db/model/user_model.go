@@ -20,6 +20,7 @@ type User struct {
 	AvatarURL    string
 	GoogleID     *string   `gorm:"uniqueIndex"`
 	LineID       *string   `gorm:"uniqueIndex"`
+	Role         string    `gorm:"not null;default:user"`
 	CreatedAt    time.Time `gorm:"not null;default:now()"`
 	UpdatedAt    time.Time
 	DeletedAt    gorm.DeletedAt `gorm:"index"`
@@ -47,6 +48,7 @@ func (m *User) ToEntity() *entity.User {
 		AvatarURL:    m.AvatarURL,
 		GoogleID:     m.GoogleID,
 		LineID:       m.LineID,
+		Role:         entity.UserRole(m.Role),
 		CreatedAt:    m.CreatedAt,
 		UpdatedAt:    m.UpdatedAt,
 		DeletedAt:    deletedAt,
@@ -70,6 +72,7 @@ func FromEntity(e *entity.User) *User {
 		AvatarURL:    e.AvatarURL,
 		GoogleID:     e.GoogleID,
 		LineID:       e.LineID,
+		Role:         string(e.Role),
 		CreatedAt:    e.CreatedAt,
 		UpdatedAt:    e.UpdatedAt,
 		DeletedAt:    deletedAt,
@@ -0,0 +1,153 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// FuelLog is the database model for fuel logs
+type FuelLog struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	VehicleID          uuid.UUID  `gorm:"type:uuid;not null;index"`
+	DriverID           *uuid.UUID `gorm:"type:uuid"`
+	FilledAt           time.Time  `gorm:"not null"`
+	Liters             float64    `gorm:"type:numeric(10,2);not null"`
+	PricePerLiter      float64    `gorm:"type:numeric(10,2);not null"`
+	Amount             float64    `gorm:"type:numeric(12,2);not null"`
+	OdometerKm         float64    `gorm:"type:numeric(10,1);not null"`
+	Station            string
+	FullTank           bool `gorm:"not null"`
+	ReceiptKey         string
+	Notes              string
+	DistanceKm         float64    `gorm:"type:numeric(10,1);not null"`
+	EfficiencyLiters   float64    `gorm:"type:numeric(10,2);not null"`
+	KmPerLiter         *float64   `gorm:"type:numeric(6,2)"`
+	BaselineKmPerLiter float64    `gorm:"type:numeric(6,2);not null"`
+	Deviation          *float64   `gorm:"type:numeric(6,3)"`
+	Flagged            bool       `gorm:"not null"`
+	CreatedBy          *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt          time.Time  `gorm:"not null;default:now()"`
+	UpdatedAt          time.Time
+}
+
+// TableName specifies the table name for FuelLog
+func (FuelLog) TableName() string {
+	return "fuel_logs"
+}
+
+// ToEntity converts database model to domain entity
+func (m *FuelLog) ToEntity() *entity.FuelLog {
+	return &entity.FuelLog{
+		ID:                 m.ID,
+		VehicleID:          m.VehicleID,
+		DriverID:           m.DriverID,
+		FilledAt:           m.FilledAt,
+		Liters:             m.Liters,
+		PricePerLiter:      entity.Baht(m.PricePerLiter),
+		Amount:             entity.Baht(m.Amount),
+		OdometerKm:         m.OdometerKm,
+		Station:            m.Station,
+		FullTank:           m.FullTank,
+		ReceiptKey:         m.ReceiptKey,
+		Notes:              m.Notes,
+		DistanceKm:         m.DistanceKm,
+		EfficiencyLiters:   m.EfficiencyLiters,
+		KmPerLiter:         m.KmPerLiter,
+		BaselineKmPerLiter: m.BaselineKmPerLiter,
+		Deviation:          m.Deviation,
+		Flagged:            m.Flagged,
+		CreatedBy:          m.CreatedBy,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+}
+
+// FuelLogFromEntity creates a database model from a domain entity
+func FuelLogFromEntity(e *entity.FuelLog) *FuelLog {
+	return &FuelLog{
+		ID:                 e.ID,
+		VehicleID:          e.VehicleID,
+		DriverID:           e.DriverID,
+		FilledAt:           e.FilledAt,
+		Liters:             e.Liters,
+		PricePerLiter:      e.PricePerLiter.Baht(),
+		Amount:             e.Amount.Baht(),
+		OdometerKm:         e.OdometerKm,
+		Station:            e.Station,
+		FullTank:           e.FullTank,
+		ReceiptKey:         e.ReceiptKey,
+		Notes:              e.Notes,
+		DistanceKm:         e.DistanceKm,
+		EfficiencyLiters:   e.EfficiencyLiters,
+		KmPerLiter:         e.KmPerLiter,
+		BaselineKmPerLiter: e.BaselineKmPerLiter,
+		Deviation:          e.Deviation,
+		Flagged:            e.Flagged,
+		CreatedBy:          e.CreatedBy,
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
+	}
+}
+
+// VehicleExpense is the database model for vehicle expenses
+type VehicleExpense struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	VehicleID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	DriverID    *uuid.UUID `gorm:"type:uuid"`
+	TripID      *uuid.UUID `gorm:"type:uuid"`
+	Type        string     `gorm:"not null"`
+	IncurredAt  time.Time  `gorm:"not null"`
+	Amount      float64    `gorm:"type:numeric(12,2);not null"`
+	Description string
+	Reference   string
+	ReceiptKey  string
+	CreatedBy   *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt   time.Time  `gorm:"not null;default:now()"`
+	UpdatedAt   time.Time
+}
+
+// TableName specifies the table name for VehicleExpense
+func (VehicleExpense) TableName() string {
+	return "vehicle_expenses"
+}
+
+// ToEntity converts database model to domain entity
+func (m *VehicleExpense) ToEntity() *entity.VehicleExpense {
+	return &entity.VehicleExpense{
+		ID:          m.ID,
+		VehicleID:   m.VehicleID,
+		DriverID:    m.DriverID,
+		TripID:      m.TripID,
+		Type:        entity.ExpenseType(m.Type),
+		IncurredAt:  m.IncurredAt,
+		Amount:      entity.Baht(m.Amount),
+		Description: m.Description,
+		Reference:   m.Reference,
+		ReceiptKey:  m.ReceiptKey,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// VehicleExpenseFromEntity creates a database model from a domain entity
+func VehicleExpenseFromEntity(e *entity.VehicleExpense) *VehicleExpense {
+	return &VehicleExpense{
+		ID:          e.ID,
+		VehicleID:   e.VehicleID,
+		DriverID:    e.DriverID,
+		TripID:      e.TripID,
+		Type:        string(e.Type),
+		IncurredAt:  e.IncurredAt,
+		Amount:      e.Amount.Baht(),
+		Description: e.Description,
+		Reference:   e.Reference,
+		ReceiptKey:  e.ReceiptKey,
+		CreatedBy:   e.CreatedBy,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
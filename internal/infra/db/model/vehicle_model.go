@@ -0,0 +1,92 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Vehicle is the database model for vehicles
+type Vehicle struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	PlateNumber   string    `gorm:"not null"`
+	PlateProvince string    `gorm:"not null"`
+	Type          string    `gorm:"not null"`
+	Brand         string
+	Model         string
+	Year          int
+	MaxWeightKg   float64
+	MaxVolumeM3   float64
+	MaxPallets    int
+	HomeDepot     string
+	GPSDeviceID   *string   `gorm:"column:gps_device_id"`
+	FuelBaseline  float64   `gorm:"column:fuel_baseline_km_per_liter;not null;default:0"`
+	Status        string    `gorm:"not null;index"`
+	CreatedAt     time.Time `gorm:"not null;default:now()"`
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName specifies the table name for Vehicle
+func (Vehicle) TableName() string {
+	return "vehicles"
+}
+
+// ToEntity converts database model to domain entity
+func (m *Vehicle) ToEntity() *entity.Vehicle {
+	var deletedAt *time.Time
+	if m.DeletedAt.Valid {
+		deletedAt = &m.DeletedAt.Time
+	}
+
+	return &entity.Vehicle{
+		ID:            m.ID,
+		PlateNumber:   m.PlateNumber,
+		PlateProvince: m.PlateProvince,
+		Type:          entity.VehicleType(m.Type),
+		Brand:         m.Brand,
+		Model:         m.Model,
+		Year:          m.Year,
+		MaxWeightKg:   m.MaxWeightKg,
+		MaxVolumeM3:   m.MaxVolumeM3,
+		MaxPallets:    m.MaxPallets,
+		HomeDepot:     m.HomeDepot,
+		GPSDeviceID:   m.GPSDeviceID,
+		FuelBaseline:  m.FuelBaseline,
+		Status:        entity.VehicleStatus(m.Status),
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+		DeletedAt:     deletedAt,
+	}
+}
+
+// VehicleFromEntity creates a database model from a domain entity
+func VehicleFromEntity(e *entity.Vehicle) *Vehicle {
+	var deletedAt gorm.DeletedAt
+	if e.DeletedAt != nil {
+		deletedAt = gorm.DeletedAt{Time: *e.DeletedAt, Valid: true}
+	}
+
+	return &Vehicle{
+		ID:            e.ID,
+		PlateNumber:   e.PlateNumber,
+		PlateProvince: e.PlateProvince,
+		Type:          string(e.Type),
+		Brand:         e.Brand,
+		Model:         e.Model,
+		Year:          e.Year,
+		MaxWeightKg:   e.MaxWeightKg,
+		MaxVolumeM3:   e.MaxVolumeM3,
+		MaxPallets:    e.MaxPallets,
+		HomeDepot:     e.HomeDepot,
+		GPSDeviceID:   e.GPSDeviceID,
+		FuelBaseline:  e.FuelBaseline,
+		Status:        string(e.Status),
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+		DeletedAt:     deletedAt,
+	}
+}
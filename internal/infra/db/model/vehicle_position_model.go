@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// VehiclePosition is the database model for GPS position history
+type VehiclePosition struct {
+	VehicleID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	RecordedAt time.Time `gorm:"primaryKey"`
+	DeviceID   string
+	Latitude   float64 `gorm:"not null"`
+	Longitude  float64 `gorm:"not null"`
+	SpeedKmh   *float64
+	Heading    *float64
+	OdometerKm *float64
+	ReceivedAt time.Time `gorm:"not null;default:now()"`
+}
+
+// TableName specifies the table name for VehiclePosition
+func (VehiclePosition) TableName() string {
+	return "vehicle_positions"
+}
+
+// ToEntity converts database model to domain entity
+func (m *VehiclePosition) ToEntity() entity.VehiclePosition {
+	return entity.VehiclePosition{
+		VehicleID:  m.VehicleID,
+		DeviceID:   m.DeviceID,
+		RecordedAt: m.RecordedAt,
+		Latitude:   m.Latitude,
+		Longitude:  m.Longitude,
+		SpeedKmh:   m.SpeedKmh,
+		Heading:    m.Heading,
+		OdometerKm: m.OdometerKm,
+		ReceivedAt: m.ReceivedAt,
+	}
+}
+
+// VehiclePositionFromEntity creates a database model from a domain entity
+func VehiclePositionFromEntity(e entity.VehiclePosition) VehiclePosition {
+	return VehiclePosition{
+		VehicleID:  e.VehicleID,
+		DeviceID:   e.DeviceID,
+		RecordedAt: e.RecordedAt,
+		Latitude:   e.Latitude,
+		Longitude:  e.Longitude,
+		SpeedKmh:   e.SpeedKmh,
+		Heading:    e.Heading,
+		OdometerKm: e.OdometerKm,
+		ReceivedAt: e.ReceivedAt,
+	}
+}
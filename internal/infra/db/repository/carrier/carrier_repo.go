@@ -0,0 +1,194 @@
+package carrier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type carrierRepo struct {
+	db *gorm.DB
+}
+
+// NewCarrierRepository creates a new carrier repository
+func NewCarrierRepository(db *gorm.DB) repository.CarrierRepository {
+	return &carrierRepo{db: db}
+}
+
+// FindByID retrieves a carrier by ID
+func (r *carrierRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Carrier, error) {
+	var carrier model.Carrier
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).First(&carrier, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return carrier.ToEntity(), nil
+}
+
+// FindByIDs retrieves the carriers with the given IDs; missing IDs are skipped
+func (r *carrierRepo) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Carrier, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var dbCarriers []*model.Carrier
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Where("id IN ?", ids).Find(&dbCarriers).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.Carrier, len(dbCarriers))
+	for i, c := range dbCarriers {
+		entities[i] = c.ToEntity()
+	}
+	return entities, nil
+}
+
+// FindByUserID retrieves the carrier a user account belongs to
+func (r *carrierRepo) FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Carrier, error) {
+	var carrier model.Carrier
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Joins("JOIN carrier_users ON carrier_users.carrier_id = carriers.id").
+		Where("carrier_users.user_id = ?", userID).
+		First(&carrier).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return carrier.ToEntity(), nil
+}
+
+// Create creates a new carrier
+func (r *carrierRepo) Create(ctx context.Context, carrier *entity.Carrier) error {
+	dbModel := model.CarrierFromEntity(carrier)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	carrier.ID = dbModel.ID
+	carrier.CreatedAt = dbModel.CreatedAt
+	carrier.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing carrier
+func (r *carrierRepo) Update(ctx context.Context, carrier *entity.Carrier) error {
+	dbModel := model.CarrierFromEntity(carrier)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	carrier.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Delete soft deletes a carrier
+func (r *carrierRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Delete(&model.Carrier{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// List retrieves carriers matching the filter with pagination
+func (r *carrierRepo) List(ctx context.Context, filter repository.CarrierFilter, limit, offset int) ([]*entity.Carrier, int64, error) {
+	var dbCarriers []*model.Carrier
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.Carrier{})
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+	if filter.VehicleType != nil {
+		types, _ := json.Marshal([]string{string(*filter.VehicleType)})
+		query = query.Where("fleet_types @> ?::jsonb", string(types))
+	}
+	if filter.Province != "" {
+		areas, _ := json.Marshal([]string{filter.Province})
+		query = query.Where("service_areas = '[]'::jsonb OR service_areas @> ?::jsonb", string(areas))
+	}
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where("name ILIKE ? OR tax_id ILIKE ?", like, like)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Order("name ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbCarriers).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.Carrier, len(dbCarriers))
+	for i, c := range dbCarriers {
+		entities[i] = c.ToEntity()
+	}
+
+	return entities, total, nil
+}
+
+// AddUser links a user account to the carrier
+func (r *carrierRepo) AddUser(ctx context.Context, carrierID, userID uuid.UUID) error {
+	link := &model.CarrierUser{CarrierID: carrierID, UserID: userID}
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(link).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveUser unlinks a user account from the carrier
+func (r *carrierRepo) RemoveUser(ctx context.Context, carrierID, userID uuid.UUID) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).
+		Delete(&model.CarrierUser{}, "carrier_id = ? AND user_id = ?", carrierID, userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// ListUserIDs retrieves the user accounts linked to the carrier
+func (r *carrierRepo) ListUserIDs(ctx context.Context, carrierID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Model(&model.CarrierUser{}).
+		Where("carrier_id = ?", carrierID).
+		Order("created_at ASC").
+		Pluck("user_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
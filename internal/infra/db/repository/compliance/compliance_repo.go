@@ -0,0 +1,181 @@
+package compliance
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// currentDocument keeps the documents that count for their owner: of each type but other, the one expiring last
+const currentDocument = `(compliance_documents.type = ? OR NOT EXISTS (
+	SELECT 1 FROM compliance_documents newer
+	WHERE newer.owner_type = compliance_documents.owner_type
+		AND newer.owner_id = compliance_documents.owner_id
+		AND newer.type = compliance_documents.type
+		AND (newer.expires_on > compliance_documents.expires_on
+			OR (newer.expires_on = compliance_documents.expires_on AND newer.id > compliance_documents.id))
+))`
+
+type complianceRepo struct {
+	db *gorm.DB
+}
+
+// NewComplianceDocumentRepository creates a new compliance document repository
+func NewComplianceDocumentRepository(db *gorm.DB) repository.ComplianceDocumentRepository {
+	return &complianceRepo{db: db}
+}
+
+// FindByID retrieves a document by ID
+func (r *complianceRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.ComplianceDocument, error) {
+	return r.find(db.FromContext(ctx, r.db).WithContext(ctx), id)
+}
+
+// FindByIDForUpdate retrieves a document and locks it until the surrounding transaction ends
+func (r *complianceRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.ComplianceDocument, error) {
+	return r.find(db.FromContext(ctx, r.db).WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *complianceRepo) find(tx *gorm.DB, id uuid.UUID) (*entity.ComplianceDocument, error) {
+	var doc model.ComplianceDocument
+	if err := tx.First(&doc, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return doc.ToEntity(), nil
+}
+
+// Create creates a new document
+func (r *complianceRepo) Create(ctx context.Context, doc *entity.ComplianceDocument) error {
+	dbModel := model.ComplianceDocumentFromEntity(doc)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		return err
+	}
+	doc.ID = dbModel.ID
+	doc.CreatedAt = dbModel.CreatedAt
+	doc.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing document
+func (r *complianceRepo) Update(ctx context.Context, doc *entity.ComplianceDocument) error {
+	dbModel := model.ComplianceDocumentFromEntity(doc)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	doc.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Delete deletes a document
+func (r *complianceRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Delete(&model.ComplianceDocument{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// List retrieves documents matching the filter with pagination, soonest expiry first
+func (r *complianceRepo) List(ctx context.Context, filter repository.ComplianceDocumentFilter, limit, offset int) ([]*entity.ComplianceDocument, int64, error) {
+	var dbDocs []*model.ComplianceDocument
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.ComplianceDocument{})
+	if filter.OwnerType != nil {
+		query = query.Where("owner_type = ?", string(*filter.OwnerType))
+	}
+	if filter.OwnerID != nil {
+		query = query.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.Type != nil {
+		query = query.Where("type = ?", string(*filter.Type))
+	}
+	if filter.ExpiringBefore != nil {
+		query = query.Where("expires_on <= ?", *filter.ExpiringBefore).
+			Where(currentDocument, string(entity.ComplianceOther))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Order("expires_on ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbDocs).Error; err != nil {
+		return nil, 0, err
+	}
+	return toEntities(dbDocs), total, nil
+}
+
+// ListUnreminded retrieves the documents that count for their owner, expire on or before the given day
+// and whose expiry has not been announced yet, soonest expiry first
+func (r *complianceRepo) ListUnreminded(ctx context.Context, before time.Time, limit, offset int) ([]*entity.ComplianceDocument, error) {
+	var dbDocs []*model.ComplianceDocument
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("expires_on <= ?", before).
+		Where("reminded_days IS DISTINCT FROM 0").
+		Where(currentDocument, string(entity.ComplianceOther)).
+		Order("expires_on ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbDocs).Error; err != nil {
+		return nil, err
+	}
+	return toEntities(dbDocs), nil
+}
+
+// FindExpired retrieves the documents of the given types that count for the owners and expired before the given day
+func (r *complianceRepo) FindExpired(ctx context.Context, owners []entity.ComplianceOwner, types []entity.ComplianceDocumentType, today time.Time) ([]*entity.ComplianceDocument, error) {
+	if len(owners) == 0 || len(types) == 0 {
+		return nil, nil
+	}
+	pairs := make([][]interface{}, len(owners))
+	for i, o := range owners {
+		pairs[i] = []interface{}{string(o.Type), o.ID}
+	}
+	typeNames := make([]string, len(types))
+	for i, t := range types {
+		typeNames[i] = string(t)
+	}
+
+	var dbDocs []*model.ComplianceDocument
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("(owner_type, owner_id) IN ?", pairs).
+		Where("type IN ?", typeNames).
+		Where("expires_on < ?", today).
+		Where(currentDocument, string(entity.ComplianceOther)).
+		Order("expires_on ASC, id ASC").
+		Find(&dbDocs).Error; err != nil {
+		return nil, err
+	}
+	return toEntities(dbDocs), nil
+}
+
+func toEntities(dbDocs []*model.ComplianceDocument) []*entity.ComplianceDocument {
+	entities := make([]*entity.ComplianceDocument, len(dbDocs))
+	for i, d := range dbDocs {
+		entities[i] = d.ToEntity()
+	}
+	return entities
+}
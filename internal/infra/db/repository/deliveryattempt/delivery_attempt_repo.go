@@ -0,0 +1,59 @@
+package deliveryattempt
+
+import (
+	"context"
+	"errors"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type deliveryAttemptRepo struct {
+	db *gorm.DB
+}
+
+// NewDeliveryAttemptRepository creates a new delivery attempt repository
+func NewDeliveryAttemptRepository(db *gorm.DB) repository.DeliveryAttemptRepository {
+	return &deliveryAttemptRepo{db: db}
+}
+
+// FindByStopID retrieves the failed attempt recorded for a trip stop
+func (r *deliveryAttemptRepo) FindByStopID(ctx context.Context, stopID uuid.UUID) (*entity.DeliveryAttempt, error) {
+	return r.findBy(ctx, "stop_id = ?", stopID)
+}
+
+// FindByShipmentID retrieves the failed attempt at delivering a shipment
+func (r *deliveryAttemptRepo) FindByShipmentID(ctx context.Context, shipmentID uuid.UUID) (*entity.DeliveryAttempt, error) {
+	return r.findBy(ctx, "shipment_id = ?", shipmentID)
+}
+
+func (r *deliveryAttemptRepo) findBy(ctx context.Context, query string, id uuid.UUID) (*entity.DeliveryAttempt, error) {
+	var attempt model.DeliveryAttempt
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).First(&attempt, query, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return attempt.ToEntity(), nil
+}
+
+// Create records a failed attempt
+func (r *deliveryAttemptRepo) Create(ctx context.Context, attempt *entity.DeliveryAttempt) error {
+	dbModel := model.DeliveryAttemptFromEntity(attempt)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	attempt.ID = dbModel.ID
+	attempt.CreatedAt = dbModel.CreatedAt
+	return nil
+}
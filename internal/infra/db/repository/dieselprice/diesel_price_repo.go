@@ -0,0 +1,77 @@
+package dieselprice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"gorm.io/gorm"
+)
+
+type dieselPriceRepo struct {
+	db *gorm.DB
+}
+
+// NewDieselPriceRepository creates a new diesel price repository
+func NewDieselPriceRepository(db *gorm.DB) repository.DieselPriceRepository {
+	return &dieselPriceRepo{db: db}
+}
+
+// FindEffective retrieves the latest price effective on or before the given time
+func (r *dieselPriceRepo) FindEffective(ctx context.Context, at time.Time) (*entity.DieselPrice, error) {
+	var price model.DieselPrice
+	err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("effective_date <= ?", at).
+		Order("effective_date DESC").
+		First(&price).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return price.ToEntity(), nil
+}
+
+// Create creates a new diesel price
+func (r *dieselPriceRepo) Create(ctx context.Context, price *entity.DieselPrice) error {
+	dbModel := model.DieselPriceFromEntity(price)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	price.ID = dbModel.ID
+	price.CreatedAt = dbModel.CreatedAt
+	price.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// List retrieves diesel prices, newest first, with pagination
+func (r *dieselPriceRepo) List(ctx context.Context, limit, offset int) ([]*entity.DieselPrice, int64, error) {
+	var dbPrices []*model.DieselPrice
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.DieselPrice{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("effective_date DESC").Limit(limit).Offset(offset).Find(&dbPrices).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.DieselPrice, len(dbPrices))
+	for i, p := range dbPrices {
+		entities[i] = p.ToEntity()
+	}
+
+	return entities, total, nil
+}
@@ -0,0 +1,180 @@
+package dock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// occupyingStatuses are the statuses of appointments that hold their slots
+var occupyingStatuses = []string{string(entity.DockAppointmentBooked), string(entity.DockAppointmentCheckedIn)}
+
+type appointmentRepo struct {
+	db *gorm.DB
+}
+
+// NewDockAppointmentRepository creates a new dock appointment repository
+func NewDockAppointmentRepository(db *gorm.DB) repository.DockAppointmentRepository {
+	return &appointmentRepo{db: db}
+}
+
+// FindByID retrieves an appointment by ID
+func (r *appointmentRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.DockAppointment, error) {
+	return r.find(db.FromContext(ctx, r.db).WithContext(ctx), id)
+}
+
+// FindByIDForUpdate retrieves an appointment and locks it until the surrounding transaction ends
+func (r *appointmentRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.DockAppointment, error) {
+	return r.find(db.FromContext(ctx, r.db).WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *appointmentRepo) find(tx *gorm.DB, id uuid.UUID) (*entity.DockAppointment, error) {
+	var appointment model.DockAppointment
+	if err := tx.First(&appointment, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return appointment.ToEntity(), nil
+}
+
+// Create creates a new appointment
+func (r *appointmentRepo) Create(ctx context.Context, appointment *entity.DockAppointment) error {
+	dbModel := model.DockAppointmentFromEntity(appointment)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		return err
+	}
+	appointment.ID = dbModel.ID
+	appointment.CreatedAt = dbModel.CreatedAt
+	appointment.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing appointment
+func (r *appointmentRepo) Update(ctx context.Context, appointment *entity.DockAppointment) error {
+	dbModel := model.DockAppointmentFromEntity(appointment)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	appointment.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// List retrieves appointments matching the filter with pagination, by booked start
+func (r *appointmentRepo) List(ctx context.Context, filter repository.DockAppointmentFilter, limit, offset int) ([]*entity.DockAppointment, int64, error) {
+	var dbAppointments []*model.DockAppointment
+	var total int64
+
+	query := applyFilter(db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.DockAppointment{}), filter)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Order("start_at ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbAppointments).Error; err != nil {
+		return nil, 0, err
+	}
+	return toEntities(dbAppointments), total, nil
+}
+
+// FindOccupying retrieves the appointments holding the dock at any time during [start, end), leaving out excludeID
+func (r *appointmentRepo) FindOccupying(ctx context.Context, dockID uuid.UUID, start, end time.Time, excludeID *uuid.UUID) ([]*entity.DockAppointment, error) {
+	return r.findOccupying(db.FromContext(ctx, r.db).WithContext(ctx).Where("dock_id = ?", dockID), start, end, excludeID)
+}
+
+// FindOccupyingByTrip retrieves the trip's appointments holding any dock during [start, end), leaving out excludeID
+func (r *appointmentRepo) FindOccupyingByTrip(ctx context.Context, tripID uuid.UUID, start, end time.Time, excludeID *uuid.UUID) ([]*entity.DockAppointment, error) {
+	return r.findOccupying(db.FromContext(ctx, r.db).WithContext(ctx).Where("trip_id = ?", tripID), start, end, excludeID)
+}
+
+func (r *appointmentRepo) findOccupying(query *gorm.DB, start, end time.Time, excludeID *uuid.UUID) ([]*entity.DockAppointment, error) {
+	query = query.
+		Where("status IN ?", occupyingStatuses).
+		Where("start_at < ? AND end_at > ?", end, start)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+
+	var dbAppointments []*model.DockAppointment
+	if err := query.Order("start_at ASC, id ASC").Find(&dbAppointments).Error; err != nil {
+		return nil, err
+	}
+	return toEntities(dbAppointments), nil
+}
+
+// Turnaround summarizes the appointments matching the filter per dock
+func (r *appointmentRepo) Turnaround(ctx context.Context, filter repository.DockAppointmentFilter) ([]repository.DockTurnaround, error) {
+	var summaries []repository.DockTurnaround
+	if err := applyFilter(db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.DockAppointment{}), filter).
+		Select(`dock_id,
+			COUNT(*) AS appointments,
+			COUNT(*) FILTER (WHERE status = ?) AS completed,
+			COUNT(*) FILTER (WHERE status = ?) AS no_shows,
+			COUNT(*) FILTER (WHERE status = ?) AS cancelled,
+			COALESCE(AVG(EXTRACT(EPOCH FROM checked_in_at - start_at) / 60) FILTER (WHERE status = ?), 0) AS avg_wait_minutes,
+			COALESCE(AVG(EXTRACT(EPOCH FROM checked_out_at - checked_in_at) / 60) FILTER (WHERE status = ?), 0) AS avg_turnaround_minutes,
+			COALESCE(MAX(EXTRACT(EPOCH FROM checked_out_at - checked_in_at) / 60) FILTER (WHERE status = ?), 0) AS max_turnaround_minutes`,
+			string(entity.DockAppointmentCompleted),
+			string(entity.DockAppointmentNoShow),
+			string(entity.DockAppointmentCancelled),
+			string(entity.DockAppointmentCompleted),
+			string(entity.DockAppointmentCompleted),
+			string(entity.DockAppointmentCompleted)).
+		Group("dock_id").
+		Order("dock_id").
+		Scan(&summaries).Error; err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func applyFilter(query *gorm.DB, filter repository.DockAppointmentFilter) *gorm.DB {
+	if filter.LocationID != nil {
+		query = query.Where("location_id = ?", *filter.LocationID)
+	}
+	if filter.DockID != nil {
+		query = query.Where("dock_id = ?", *filter.DockID)
+	}
+	if filter.TripID != nil {
+		query = query.Where("trip_id = ?", *filter.TripID)
+	}
+	if filter.CarrierID != nil {
+		query = query.Where("carrier_id = ?", *filter.CarrierID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+	if filter.From != nil {
+		query = query.Where("start_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("start_at < ?", *filter.To)
+	}
+	return query
+}
+
+func toEntities(dbAppointments []*model.DockAppointment) []*entity.DockAppointment {
+	entities := make([]*entity.DockAppointment, len(dbAppointments))
+	for i, a := range dbAppointments {
+		entities[i] = a.ToEntity()
+	}
+	return entities
+}
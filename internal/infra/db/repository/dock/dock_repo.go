@@ -0,0 +1,108 @@
+package dock
+
+import (
+	"context"
+	"errors"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type dockRepo struct {
+	db *gorm.DB
+}
+
+// NewDockRepository creates a new dock repository
+func NewDockRepository(db *gorm.DB) repository.DockRepository {
+	return &dockRepo{db: db}
+}
+
+// FindByID retrieves a dock by ID
+func (r *dockRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Dock, error) {
+	return r.find(db.FromContext(ctx, r.db).WithContext(ctx), id)
+}
+
+// FindByIDForUpdate retrieves a dock and locks it until the surrounding transaction ends,
+// which serializes the bookings of the dock
+func (r *dockRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Dock, error) {
+	return r.find(db.FromContext(ctx, r.db).WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *dockRepo) find(tx *gorm.DB, id uuid.UUID) (*entity.Dock, error) {
+	var dock model.Dock
+	if err := tx.First(&dock, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return dock.ToEntity(), nil
+}
+
+// Create creates a new dock
+func (r *dockRepo) Create(ctx context.Context, dock *entity.Dock) error {
+	dbModel := model.DockFromEntity(dock)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		return err
+	}
+	dock.ID = dbModel.ID
+	dock.CreatedAt = dbModel.CreatedAt
+	dock.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing dock
+func (r *dockRepo) Update(ctx context.Context, dock *entity.Dock) error {
+	dbModel := model.DockFromEntity(dock)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	dock.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// List retrieves docks matching the filter with pagination, by site and name
+func (r *dockRepo) List(ctx context.Context, filter repository.DockFilter, limit, offset int) ([]*entity.Dock, int64, error) {
+	var dbDocks []*model.Dock
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.Dock{})
+	if filter.LocationID != nil {
+		query = query.Where("location_id = ?", *filter.LocationID)
+	}
+	if filter.Direction != nil {
+		query = query.Where("direction IN ?", []string{string(*filter.Direction), string(entity.DockBoth)})
+	}
+	if filter.Active != nil {
+		query = query.Where("active = ?", *filter.Active)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Order("location_id ASC, name ASC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbDocks).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.Dock, len(dbDocks))
+	for i, d := range dbDocks {
+		entities[i] = d.ToEntity()
+	}
+	return entities, total, nil
+}
@@ -0,0 +1,144 @@
+package driver
+
+import (
+	"context"
+	"errors"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type driverRepo struct {
+	db *gorm.DB
+}
+
+// NewDriverRepository creates a new driver repository
+func NewDriverRepository(db *gorm.DB) repository.DriverRepository {
+	return &driverRepo{db: db}
+}
+
+// FindByID retrieves a driver by ID
+func (r *driverRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Driver, error) {
+	var driver model.Driver
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Preload("User").First(&driver, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return driver.ToEntity(), nil
+}
+
+// FindByIDForUpdate retrieves a driver and locks its row until the surrounding transaction ends.
+// The user is not loaded.
+func (r *driverRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Driver, error) {
+	var driver model.Driver
+	err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&driver, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return driver.ToEntity(), nil
+}
+
+// FindByUserID retrieves a driver by the linked user ID
+func (r *driverRepo) FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Driver, error) {
+	var driver model.Driver
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Preload("User").Where("user_id = ?", userID).First(&driver).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return driver.ToEntity(), nil
+}
+
+// Create creates a new driver
+func (r *driverRepo) Create(ctx context.Context, driver *entity.Driver) error {
+	dbModel := model.DriverFromEntity(driver)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	driver.ID = dbModel.ID
+	driver.CreatedAt = dbModel.CreatedAt
+	driver.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing driver
+func (r *driverRepo) Update(ctx context.Context, driver *entity.Driver) error {
+	dbModel := model.DriverFromEntity(driver)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	driver.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Delete soft deletes a driver
+func (r *driverRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Delete(&model.Driver{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// List retrieves drivers matching the filter with pagination
+func (r *driverRepo) List(ctx context.Context, filter repository.DriverFilter, limit, offset int) ([]*entity.Driver, int64, error) {
+	var dbDrivers []*model.Driver
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.Driver{})
+	if filter.Status != nil {
+		query = query.Where("drivers.status = ?", string(*filter.Status))
+	}
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.
+			Joins("JOIN users ON users.id = drivers.user_id").
+			Where("users.first_name ILIKE ? OR users.last_name ILIKE ? OR users.phone_number ILIKE ? OR drivers.license_number ILIKE ?",
+				like, like, like, like)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Preload("User").
+		Order("drivers.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbDrivers).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.Driver, len(dbDrivers))
+	for i, d := range dbDrivers {
+		entities[i] = d.ToEntity()
+	}
+
+	return entities, total, nil
+}
@@ -0,0 +1,114 @@
+package expense
+
+import (
+	"context"
+	"errors"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type expenseRepo struct {
+	db *gorm.DB
+}
+
+// NewVehicleExpenseRepository creates a new vehicle expense repository
+func NewVehicleExpenseRepository(db *gorm.DB) repository.VehicleExpenseRepository {
+	return &expenseRepo{db: db}
+}
+
+// FindByID retrieves an expense by ID
+func (r *expenseRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.VehicleExpense, error) {
+	var expense model.VehicleExpense
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).First(&expense, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return expense.ToEntity(), nil
+}
+
+// Create creates a new expense
+func (r *expenseRepo) Create(ctx context.Context, expense *entity.VehicleExpense) error {
+	dbModel := model.VehicleExpenseFromEntity(expense)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		return err
+	}
+	expense.ID = dbModel.ID
+	expense.CreatedAt = dbModel.CreatedAt
+	expense.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Delete deletes an expense
+func (r *expenseRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Delete(&model.VehicleExpense{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// List retrieves expenses matching the filter and type with pagination, latest first
+func (r *expenseRepo) List(ctx context.Context, filter repository.VehicleCostFilter, expenseType *entity.ExpenseType, limit, offset int) ([]*entity.VehicleExpense, int64, error) {
+	var dbExpenses []*model.VehicleExpense
+	var total int64
+
+	query := applyFilter(db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.VehicleExpense{}), filter)
+	if expenseType != nil {
+		query = query.Where("type = ?", string(*expenseType))
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Order("incurred_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbExpenses).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.VehicleExpense, len(dbExpenses))
+	for i, e := range dbExpenses {
+		entities[i] = e.ToEntity()
+	}
+	return entities, total, nil
+}
+
+// Summarize totals the expenses matching the filter per vehicle and type
+func (r *expenseRepo) Summarize(ctx context.Context, filter repository.VehicleCostFilter) ([]repository.ExpenseSummary, error) {
+	var summaries []repository.ExpenseSummary
+	if err := applyFilter(db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.VehicleExpense{}), filter).
+		Select("vehicle_id, type, COUNT(*) AS count, COALESCE(ROUND(SUM(amount) * 100), 0)::bigint AS amount").
+		Group("vehicle_id, type").
+		Order("vehicle_id, type").
+		Scan(&summaries).Error; err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func applyFilter(query *gorm.DB, filter repository.VehicleCostFilter) *gorm.DB {
+	if filter.VehicleID != nil {
+		query = query.Where("vehicle_id = ?", *filter.VehicleID)
+	}
+	if filter.From != nil {
+		query = query.Where("incurred_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("incurred_at < ?", *filter.To)
+	}
+	return query
+}
@@ -0,0 +1,175 @@
+package fuellog
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type fuelLogRepo struct {
+	db *gorm.DB
+}
+
+// NewFuelLogRepository creates a new fuel log repository
+func NewFuelLogRepository(db *gorm.DB) repository.FuelLogRepository {
+	return &fuelLogRepo{db: db}
+}
+
+// FindByID retrieves a fuel log by ID
+func (r *fuelLogRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.FuelLog, error) {
+	var log model.FuelLog
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).First(&log, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return log.ToEntity(), nil
+}
+
+// FindPrevious retrieves the vehicle's latest fill-up before the given time
+func (r *fuelLogRepo) FindPrevious(ctx context.Context, vehicleID uuid.UUID, before time.Time, fullTank bool) (*entity.FuelLog, error) {
+	query := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("vehicle_id = ? AND filled_at < ?", vehicleID, before).
+		Order("filled_at DESC, id DESC")
+	return r.first(query, fullTank)
+}
+
+// FindNext retrieves the vehicle's earliest fill-up after the given time
+func (r *fuelLogRepo) FindNext(ctx context.Context, vehicleID uuid.UUID, after time.Time, fullTank bool) (*entity.FuelLog, error) {
+	query := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("vehicle_id = ? AND filled_at > ?", vehicleID, after).
+		Order("filled_at ASC, id ASC")
+	return r.first(query, fullTank)
+}
+
+// SumLiters totals the litres of the vehicle's fill-ups strictly between the given times
+func (r *fuelLogRepo) SumLiters(ctx context.Context, vehicleID uuid.UUID, after, before time.Time) (float64, error) {
+	var liters float64
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Model(&model.FuelLog{}).
+		Where("vehicle_id = ? AND filled_at > ? AND filled_at < ?", vehicleID, after, before).
+		Select("COALESCE(SUM(liters), 0)").
+		Scan(&liters).Error; err != nil {
+		return 0, err
+	}
+	return liters, nil
+}
+
+// Create creates a new fuel log
+func (r *fuelLogRepo) Create(ctx context.Context, log *entity.FuelLog) error {
+	dbModel := model.FuelLogFromEntity(log)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		return err
+	}
+	log.ID = dbModel.ID
+	log.CreatedAt = dbModel.CreatedAt
+	log.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing fuel log
+func (r *fuelLogRepo) Update(ctx context.Context, log *entity.FuelLog) error {
+	dbModel := model.FuelLogFromEntity(log)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	log.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Delete deletes a fuel log
+func (r *fuelLogRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Delete(&model.FuelLog{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// List retrieves fuel logs matching the filter with pagination, latest first
+func (r *fuelLogRepo) List(ctx context.Context, filter repository.VehicleCostFilter, limit, offset int) ([]*entity.FuelLog, int64, error) {
+	var dbLogs []*model.FuelLog
+	var total int64
+
+	query := applyFilter(db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.FuelLog{}), filter)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Order("filled_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbLogs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.FuelLog, len(dbLogs))
+	for i, l := range dbLogs {
+		entities[i] = l.ToEntity()
+	}
+	return entities, total, nil
+}
+
+// Summarize totals the fuel logs matching the filter per vehicle
+func (r *fuelLogRepo) Summarize(ctx context.Context, filter repository.VehicleCostFilter) ([]repository.FuelSummary, error) {
+	var summaries []repository.FuelSummary
+	if err := applyFilter(db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.FuelLog{}), filter).
+		Select(`vehicle_id,
+			COUNT(*) AS fills,
+			COALESCE(SUM(liters), 0) AS liters,
+			COALESCE(ROUND(SUM(amount) * 100), 0)::bigint AS amount,
+			COALESCE(SUM(distance_km) FILTER (WHERE km_per_liter IS NOT NULL), 0) AS distance_km,
+			COALESCE(SUM(efficiency_liters) FILTER (WHERE km_per_liter IS NOT NULL), 0) AS efficiency_liters,
+			COUNT(*) FILTER (WHERE flagged) AS flagged`).
+		Group("vehicle_id").
+		Order("vehicle_id").
+		Scan(&summaries).Error; err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func (r *fuelLogRepo) first(query *gorm.DB, fullTank bool) (*entity.FuelLog, error) {
+	if fullTank {
+		query = query.Where("full_tank")
+	}
+	var log model.FuelLog
+	if err := query.First(&log).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return log.ToEntity(), nil
+}
+
+func applyFilter(query *gorm.DB, filter repository.VehicleCostFilter) *gorm.DB {
+	if filter.VehicleID != nil {
+		query = query.Where("vehicle_id = ?", *filter.VehicleID)
+	}
+	if filter.From != nil {
+		query = query.Where("filled_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("filled_at < ?", *filter.To)
+	}
+	return query
+}
@@ -0,0 +1,170 @@
+package geofence
+
+import (
+	"context"
+	"errors"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type geofenceRepo struct {
+	db *gorm.DB
+}
+
+// NewGeofenceRepository creates a new geofence repository
+func NewGeofenceRepository(db *gorm.DB) repository.GeofenceRepository {
+	return &geofenceRepo{db: db}
+}
+
+// FindByID retrieves a geofence by ID
+func (r *geofenceRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Geofence, error) {
+	var geofence model.Geofence
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).First(&geofence, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return geofence.ToEntity(), nil
+}
+
+// Create creates a new geofence
+func (r *geofenceRepo) Create(ctx context.Context, geofence *entity.Geofence) error {
+	dbModel := model.GeofenceFromEntity(geofence)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		return err
+	}
+	geofence.ID = dbModel.ID
+	geofence.CreatedAt = dbModel.CreatedAt
+	geofence.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing geofence
+func (r *geofenceRepo) Update(ctx context.Context, geofence *entity.Geofence) error {
+	dbModel := model.GeofenceFromEntity(geofence)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	geofence.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Delete soft deletes a geofence
+func (r *geofenceRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Delete(&model.Geofence{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// ListByLocation retrieves the geofences of a location
+func (r *geofenceRepo) ListByLocation(ctx context.Context, locationID uuid.UUID) ([]*entity.Geofence, error) {
+	var dbGeofences []*model.Geofence
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("location_id = ?", locationID).
+		Order("created_at ASC").
+		Find(&dbGeofences).Error; err != nil {
+		return nil, err
+	}
+	return toGeofenceEntities(dbGeofences), nil
+}
+
+// ListActive retrieves every active geofence
+func (r *geofenceRepo) ListActive(ctx context.Context) ([]*entity.Geofence, error) {
+	var dbGeofences []*model.Geofence
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("active = ?", true).
+		Find(&dbGeofences).Error; err != nil {
+		return nil, err
+	}
+	return toGeofenceEntities(dbGeofences), nil
+}
+
+// CreateEvents records geofence events
+func (r *geofenceRepo) CreateEvents(ctx context.Context, events []*entity.GeofenceEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	dbEvents := make([]*model.GeofenceEvent, len(events))
+	for i, e := range events {
+		dbEvents[i] = model.GeofenceEventFromEntity(e)
+	}
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(&dbEvents).Error; err != nil {
+		return err
+	}
+
+	for i := range events {
+		events[i].ID = dbEvents[i].ID
+		events[i].CreatedAt = dbEvents[i].CreatedAt
+	}
+	return nil
+}
+
+// ListEvents retrieves geofence events matching the filter, newest first, with pagination
+func (r *geofenceRepo) ListEvents(ctx context.Context, filter repository.GeofenceEventFilter, limit, offset int) ([]*entity.GeofenceEvent, int64, error) {
+	var dbEvents []*model.GeofenceEvent
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.GeofenceEvent{})
+	if filter.VehicleID != nil {
+		query = query.Where("vehicle_id = ?", *filter.VehicleID)
+	}
+	if filter.GeofenceID != nil {
+		query = query.Where("geofence_id = ?", *filter.GeofenceID)
+	}
+	if filter.LocationID != nil {
+		query = query.Where("location_id = ?", *filter.LocationID)
+	}
+	if filter.TripID != nil {
+		query = query.Where("trip_id = ?", *filter.TripID)
+	}
+	if filter.From != nil {
+		query = query.Where("occurred_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("occurred_at < ?", *filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Order("occurred_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbEvents).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.GeofenceEvent, len(dbEvents))
+	for i, e := range dbEvents {
+		entities[i] = e.ToEntity()
+	}
+	return entities, total, nil
+}
+
+func toGeofenceEntities(dbGeofences []*model.Geofence) []*entity.Geofence {
+	entities := make([]*entity.Geofence, len(dbGeofences))
+	for i, g := range dbGeofences {
+		entities[i] = g.ToEntity()
+	}
+	return entities
+}
@@ -0,0 +1,145 @@
+package incident
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// openStatuses are the statuses of incidents awaiting a resolution
+var openStatuses = []string{string(entity.IncidentStatusOpen), string(entity.IncidentStatusInProgress)}
+
+type incidentRepo struct {
+	db *gorm.DB
+}
+
+// NewIncidentRepository creates a new incident repository
+func NewIncidentRepository(db *gorm.DB) repository.IncidentRepository {
+	return &incidentRepo{db: db}
+}
+
+// FindByID retrieves an incident by ID
+func (r *incidentRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Incident, error) {
+	return r.find(db.FromContext(ctx, r.db).WithContext(ctx), id)
+}
+
+// FindByIDForUpdate retrieves an incident and locks it until the surrounding transaction ends
+func (r *incidentRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Incident, error) {
+	return r.find(db.FromContext(ctx, r.db).WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *incidentRepo) find(tx *gorm.DB, id uuid.UUID) (*entity.Incident, error) {
+	var incident model.Incident
+	if err := tx.First(&incident, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return incident.ToEntity(), nil
+}
+
+// Create creates a new incident
+func (r *incidentRepo) Create(ctx context.Context, incident *entity.Incident) error {
+	dbModel := model.IncidentFromEntity(incident)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		return err
+	}
+	incident.ID = dbModel.ID
+	incident.CreatedAt = dbModel.CreatedAt
+	incident.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing incident
+func (r *incidentRepo) Update(ctx context.Context, incident *entity.Incident) error {
+	dbModel := model.IncidentFromEntity(incident)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	incident.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// List retrieves incidents matching the filter with pagination, most recent first
+func (r *incidentRepo) List(ctx context.Context, filter repository.IncidentFilter, limit, offset int) ([]*entity.Incident, int64, error) {
+	var dbIncidents []*model.Incident
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.Incident{})
+	if filter.TripID != nil {
+		query = query.Where("trip_id = ?", *filter.TripID)
+	}
+	if filter.ShipmentID != nil {
+		query = query.Where("shipment_id = ?", *filter.ShipmentID)
+	}
+	if filter.OwnerID != nil {
+		query = query.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.Category != nil {
+		query = query.Where("category = ?", string(*filter.Category))
+	}
+	if filter.Severity != nil {
+		query = query.Where("severity = ?", string(*filter.Severity))
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+	if filter.Open || filter.OverdueAt != nil {
+		query = query.Where("status IN ?", openStatuses)
+	}
+	if filter.OverdueAt != nil {
+		query = query.Where("due_at < ?", *filter.OverdueAt)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Order("occurred_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbIncidents).Error; err != nil {
+		return nil, 0, err
+	}
+	return toEntities(dbIncidents), total, nil
+}
+
+// ListUnbreached retrieves the open incidents due before the given time whose breach has not been announced yet,
+// soonest due first
+func (r *incidentRepo) ListUnbreached(ctx context.Context, before time.Time, limit int) ([]*entity.Incident, error) {
+	var dbIncidents []*model.Incident
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("status IN ?", openStatuses).
+		Where("due_at < ?", before).
+		Where("breached_at IS NULL").
+		Order("due_at ASC, id ASC").
+		Limit(limit).
+		Find(&dbIncidents).Error; err != nil {
+		return nil, err
+	}
+	return toEntities(dbIncidents), nil
+}
+
+func toEntities(dbIncidents []*model.Incident) []*entity.Incident {
+	entities := make([]*entity.Incident, len(dbIncidents))
+	for i, m := range dbIncidents {
+		entities[i] = m.ToEntity()
+	}
+	return entities
+}
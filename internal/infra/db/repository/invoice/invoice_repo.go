@@ -0,0 +1,196 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type invoiceRepo struct {
+	db *gorm.DB
+}
+
+// NewInvoiceRepository creates a new invoice repository
+func NewInvoiceRepository(db *gorm.DB) repository.InvoiceRepository {
+	return &invoiceRepo{db: db}
+}
+
+// FindByID retrieves an invoice with its lines by ID
+func (r *invoiceRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Invoice, error) {
+	return r.find(ctx, db.FromContext(ctx, r.db).WithContext(ctx), id)
+}
+
+// FindByIDForUpdate retrieves an invoice and locks its row until the surrounding transaction ends
+func (r *invoiceRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Invoice, error) {
+	return r.find(ctx, db.FromContext(ctx, r.db).WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+// Create creates a new invoice with its lines
+func (r *invoiceRepo) Create(ctx context.Context, invoice *entity.Invoice) error {
+	dbModel := model.InvoiceFromEntity(invoice)
+	tx := db.FromContext(ctx, r.db).WithContext(ctx)
+	if err := tx.Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	invoice.ID = dbModel.ID
+	invoice.CreatedAt = dbModel.CreatedAt
+	invoice.UpdatedAt = dbModel.UpdatedAt
+
+	lines := make([]*model.InvoiceLine, len(invoice.Lines))
+	for i, l := range invoice.Lines {
+		lines[i] = model.InvoiceLineFromEntity(invoice.ID, l)
+	}
+	if len(lines) > 0 {
+		if err := tx.Create(&lines).Error; err != nil {
+			return err
+		}
+	}
+	for i := range invoice.Lines {
+		invoice.Lines[i].ID = lines[i].ID
+		invoice.Lines[i].InvoiceID = invoice.ID
+	}
+	return nil
+}
+
+// Update saves the invoice's status, number, dates and payment without touching its lines
+func (r *invoiceRepo) Update(ctx context.Context, invoice *entity.Invoice) error {
+	dbModel := model.InvoiceFromEntity(invoice)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Omit("Lines").Save(dbModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	invoice.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// List retrieves invoices matching the filter with pagination, newest first
+func (r *invoiceRepo) List(ctx context.Context, filter repository.InvoiceFilter, limit, offset int) ([]*entity.Invoice, int64, error) {
+	var dbInvoices []*model.Invoice
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.Invoice{})
+	if filter.OrganizationID != nil {
+		query = query.Where("organization_id = ?", *filter.OrganizationID)
+	}
+	if filter.Kind != nil {
+		query = query.Where("kind = ?", string(*filter.Kind))
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+	if filter.Search != "" {
+		query = query.Where("number ILIKE ?", "%"+filter.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Preload("Lines", orderLines).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbInvoices).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return toEntities(dbInvoices), total, nil
+}
+
+// ListCreditNotes retrieves the credit notes raised against an invoice that are not void
+func (r *invoiceRepo) ListCreditNotes(ctx context.Context, invoiceID uuid.UUID) ([]*entity.Invoice, error) {
+	var dbInvoices []*model.Invoice
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Preload("Lines", orderLines).
+		Where("credited_invoice_id = ? AND kind = ? AND status <> ?",
+			invoiceID, string(entity.InvoiceKindCreditNote), string(entity.InvoiceStatusVoid)).
+		Order("created_at ASC").
+		Find(&dbInvoices).Error; err != nil {
+		return nil, err
+	}
+	return toEntities(dbInvoices), nil
+}
+
+// ListBillableShipments retrieves an organization's shipments delivered in [from, to) that are not on a live invoice.
+// A shipment counts as delivered by its status, at the time its status last changed to delivered.
+func (r *invoiceRepo) ListBillableShipments(ctx context.Context, organizationID uuid.UUID, from, to time.Time) ([]*entity.BillableShipment, error) {
+	var rows []struct {
+		model.Shipment
+		DeliveredAt time.Time
+	}
+	delivered := string(entity.ShipmentStatusDelivered)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Model(&model.Shipment{}).
+		Select("shipments.*, delivery.changed_at AS delivered_at").
+		Joins(`JOIN LATERAL (
+			SELECT max(changed_at) AS changed_at FROM shipment_status_history
+			WHERE shipment_status_history.shipment_id = shipments.id AND shipment_status_history.status = ?
+		) AS delivery ON true`, delivered).
+		Where("shipments.organization_id = ? AND shipments.status = ?", organizationID, delivered).
+		Where("delivery.changed_at >= ? AND delivery.changed_at < ?", from, to).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM invoice_lines
+			JOIN invoices ON invoices.id = invoice_lines.invoice_id
+			WHERE invoice_lines.shipment_id = shipments.id AND invoices.kind = ? AND invoices.status <> ?
+		)`, string(entity.InvoiceKindInvoice), string(entity.InvoiceStatusVoid)).
+		Order("delivery.changed_at ASC, shipments.id ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	billable := make([]*entity.BillableShipment, len(rows))
+	for i := range rows {
+		billable[i] = &entity.BillableShipment{Shipment: rows[i].Shipment.ToEntity(), DeliveredAt: rows[i].DeliveredAt}
+	}
+	return billable, nil
+}
+
+func (r *invoiceRepo) find(ctx context.Context, query *gorm.DB, id uuid.UUID) (*entity.Invoice, error) {
+	var invoice model.Invoice
+	if err := query.First(&invoice, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// Lines are loaded separately so the row lock, if any, applies to the invoice alone
+	if err := orderLines(db.FromContext(ctx, r.db).WithContext(ctx)).
+		Where("invoice_id = ?", id).
+		Find(&invoice.Lines).Error; err != nil {
+		return nil, err
+	}
+	return invoice.ToEntity(), nil
+}
+
+func toEntities(invoices []*model.Invoice) []*entity.Invoice {
+	entities := make([]*entity.Invoice, len(invoices))
+	for i, inv := range invoices {
+		entities[i] = inv.ToEntity()
+	}
+	return entities
+}
+
+func orderLines(db *gorm.DB) *gorm.DB {
+	return db.Order("invoice_lines.sequence ASC")
+}
@@ -0,0 +1,106 @@
+package label
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type labelRepo struct {
+	db *gorm.DB
+}
+
+// NewLabelRepository creates a new label repository
+func NewLabelRepository(db *gorm.DB) repository.LabelRepository {
+	return &labelRepo{db: db}
+}
+
+// FindTemplate retrieves an organization's own label template
+func (r *labelRepo) FindTemplate(ctx context.Context, organizationID uuid.UUID) (*entity.LabelTemplate, error) {
+	var template model.LabelTemplate
+	err := db.FromContext(ctx, r.db).WithContext(ctx).
+		First(&template, "organization_id = ?", organizationID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return template.ToEntity(), nil
+}
+
+// SaveTemplate creates or replaces an organization's label template
+func (r *labelRepo) SaveTemplate(ctx context.Context, template *entity.LabelTemplate) error {
+	template.UpdatedAt = time.Now()
+	return db.FromContext(ctx, r.db).WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "organization_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"size", "dpi", "zpl_font", "gs1_company_prefix", "sscc_extension", "footer", "updated_at"}),
+		}).
+		Create(model.LabelTemplateFromEntity(template)).Error
+}
+
+// DeleteTemplate removes an organization's label template so the default applies again
+func (r *labelRepo) DeleteTemplate(ctx context.Context, organizationID uuid.UUID) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).
+		Delete(&model.LabelTemplate{}, "organization_id = ?", organizationID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// FindPieces retrieves the labelled pieces of the given shipments ordered by shipment and piece
+func (r *labelRepo) FindPieces(ctx context.Context, shipmentIDs []uuid.UUID) ([]*entity.ShipmentPiece, error) {
+	if len(shipmentIDs) == 0 {
+		return nil, nil
+	}
+
+	var rows []*model.ShipmentPiece
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("shipment_id IN ?", shipmentIDs).
+		Order("shipment_id ASC, piece ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.ShipmentPiece, len(rows))
+	for i, p := range rows {
+		entities[i] = p.ToEntity()
+	}
+	return entities, nil
+}
+
+// CreatePieces records newly labelled pieces
+func (r *labelRepo) CreatePieces(ctx context.Context, pieces []*entity.ShipmentPiece) error {
+	if len(pieces) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	rows := make([]*model.ShipmentPiece, len(pieces))
+	for i, p := range pieces {
+		p.CreatedAt = now
+		rows[i] = model.ShipmentPieceFromEntity(p)
+	}
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(&rows).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package lanespeed
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// sampleWindow caps the weight of past samples so lane speeds follow changes in traffic
+const sampleWindow = 100
+
+type laneSpeedRepo struct {
+	db *gorm.DB
+}
+
+// NewLaneSpeedRepository creates a new lane speed repository
+func NewLaneSpeedRepository(db *gorm.DB) repository.LaneSpeedRepository {
+	return &laneSpeedRepo{db: db}
+}
+
+// FindByLane retrieves the hourly speeds observed between two provinces
+func (r *laneSpeedRepo) FindByLane(ctx context.Context, originProvince, destinationProvince string) ([]*entity.LaneSpeed, error) {
+	var rows []*model.LaneSpeed
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("origin_province = ? AND destination_province = ?", originProvince, destinationProvince).
+		Order("hour ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.LaneSpeed, len(rows))
+	for i, s := range rows {
+		entities[i] = s.ToEntity()
+	}
+	return entities, nil
+}
+
+// RecordSample folds one observed leg speed into the lane's moving average for the hour
+func (r *laneSpeedRepo) RecordSample(ctx context.Context, originProvince, destinationProvince string, hour int, speedKmh float64) error {
+	row := model.LaneSpeed{
+		OriginProvince:      originProvince,
+		DestinationProvince: destinationProvince,
+		Hour:                hour,
+		Samples:             1,
+		SpeedKmh:            speedKmh,
+		UpdatedAt:           time.Now(),
+	}
+
+	return db.FromContext(ctx, r.db).WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "origin_province"}, {Name: "destination_province"}, {Name: "hour"}},
+			DoUpdates: clause.Set{
+				{Column: clause.Column{Name: "speed_kmh"}, Value: gorm.Expr(
+					"(lane_speeds.speed_kmh * LEAST(lane_speeds.samples, ?) + excluded.speed_kmh) / (LEAST(lane_speeds.samples, ?) + 1)",
+					sampleWindow, sampleWindow,
+				)},
+				{Column: clause.Column{Name: "samples"}, Value: gorm.Expr("lane_speeds.samples + 1")},
+				{Column: clause.Column{Name: "updated_at"}, Value: gorm.Expr("excluded.updated_at")},
+			},
+		}).
+		Create(&row).Error
+}
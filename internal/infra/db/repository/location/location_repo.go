@@ -0,0 +1,134 @@
+package location
+
+import (
+	"context"
+	"errors"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type locationRepo struct {
+	db *gorm.DB
+}
+
+// NewLocationRepository creates a new location repository
+func NewLocationRepository(db *gorm.DB) repository.LocationRepository {
+	return &locationRepo{db: db}
+}
+
+// FindByID retrieves a location by ID
+func (r *locationRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Location, error) {
+	var location model.Location
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).First(&location, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return location.ToEntity(), nil
+}
+
+// FindByIDs retrieves the locations with the given IDs; missing IDs are skipped
+func (r *locationRepo) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Location, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var dbLocations []*model.Location
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Where("id IN ?", ids).Find(&dbLocations).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.Location, len(dbLocations))
+	for i, l := range dbLocations {
+		entities[i] = l.ToEntity()
+	}
+	return entities, nil
+}
+
+// Create creates a new location
+func (r *locationRepo) Create(ctx context.Context, location *entity.Location) error {
+	dbModel := model.LocationFromEntity(location)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	location.ID = dbModel.ID
+	location.CreatedAt = dbModel.CreatedAt
+	location.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing location
+func (r *locationRepo) Update(ctx context.Context, location *entity.Location) error {
+	dbModel := model.LocationFromEntity(location)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	location.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Delete soft deletes a location
+func (r *locationRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Delete(&model.Location{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// ListByOrganization retrieves an organization's saved locations matching the search with pagination
+func (r *locationRepo) ListByOrganization(ctx context.Context, organizationID uuid.UUID, search string, limit, offset int) ([]*entity.Location, int64, error) {
+	var dbLocations []*model.Location
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).
+		Model(&model.Location{}).
+		Where("organization_id = ?", organizationID)
+	if search != "" {
+		like := "%" + search + "%"
+		query = query.Where(
+			"name ILIKE ? OR code ILIKE ? OR road ILIKE ? OR subdistrict ILIKE ? OR district ILIKE ? OR province ILIKE ? OR postcode ILIKE ? OR contact_name ILIKE ?",
+			like, like, like, like, like, like, like, like,
+		)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Order("name ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbLocations).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.Location, len(dbLocations))
+	for i, l := range dbLocations {
+		entities[i] = l.ToEntity()
+	}
+
+	return entities, total, nil
+}
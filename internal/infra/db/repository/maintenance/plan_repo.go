@@ -0,0 +1,90 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type planRepo struct {
+	db *gorm.DB
+}
+
+// NewMaintenancePlanRepository creates a new maintenance plan repository
+func NewMaintenancePlanRepository(db *gorm.DB) repository.MaintenancePlanRepository {
+	return &planRepo{db: db}
+}
+
+// FindByID retrieves a plan by ID
+func (r *planRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.MaintenancePlan, error) {
+	var plan model.MaintenancePlan
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).First(&plan, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return plan.ToEntity(), nil
+}
+
+// Create creates a new plan
+func (r *planRepo) Create(ctx context.Context, plan *entity.MaintenancePlan) error {
+	dbModel := model.MaintenancePlanFromEntity(plan)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		return err
+	}
+	plan.ID = dbModel.ID
+	plan.CreatedAt = dbModel.CreatedAt
+	plan.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing plan
+func (r *planRepo) Update(ctx context.Context, plan *entity.MaintenancePlan) error {
+	dbModel := model.MaintenancePlanFromEntity(plan)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	plan.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// List retrieves plans matching the filter with pagination, by vehicle type and name
+func (r *planRepo) List(ctx context.Context, filter repository.MaintenancePlanFilter, limit, offset int) ([]*entity.MaintenancePlan, int64, error) {
+	var dbPlans []*model.MaintenancePlan
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.MaintenancePlan{})
+	if filter.VehicleType != nil {
+		query = query.Where("vehicle_type = ?", string(*filter.VehicleType))
+	}
+	if filter.Active != nil {
+		query = query.Where("active = ?", *filter.Active)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("vehicle_type ASC, name ASC, id ASC").Limit(limit).Offset(offset).Find(&dbPlans).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.MaintenancePlan, len(dbPlans))
+	for i, p := range dbPlans {
+		entities[i] = p.ToEntity()
+	}
+	return entities, total, nil
+}
@@ -0,0 +1,177 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type scheduleRepo struct {
+	db *gorm.DB
+}
+
+// NewMaintenanceScheduleRepository creates a new maintenance schedule repository
+func NewMaintenanceScheduleRepository(db *gorm.DB) repository.MaintenanceScheduleRepository {
+	return &scheduleRepo{db: db}
+}
+
+// FindByVehicles retrieves the schedules of the given vehicles
+func (r *scheduleRepo) FindByVehicles(ctx context.Context, vehicleIDs []uuid.UUID) ([]*entity.MaintenanceSchedule, error) {
+	if len(vehicleIDs) == 0 {
+		return nil, nil
+	}
+	var dbSchedules []*model.MaintenanceSchedule
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("vehicle_id IN ?", vehicleIDs).
+		Order("vehicle_id ASC, plan_id ASC").
+		Find(&dbSchedules).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.MaintenanceSchedule, len(dbSchedules))
+	for i, s := range dbSchedules {
+		entities[i] = s.ToEntity()
+	}
+	return entities, nil
+}
+
+// FindForUpdate retrieves a vehicle's schedule for a plan and locks it until the surrounding transaction ends
+func (r *scheduleRepo) FindForUpdate(ctx context.Context, vehicleID, planID uuid.UUID) (*entity.MaintenanceSchedule, error) {
+	var schedule model.MaintenanceSchedule
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&schedule, "vehicle_id = ? AND plan_id = ?", vehicleID, planID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return schedule.ToEntity(), nil
+}
+
+// Save creates or updates a schedule; a vehicle has one schedule per plan
+func (r *scheduleRepo) Save(ctx context.Context, schedule *entity.MaintenanceSchedule) error {
+	dbModel := model.MaintenanceScheduleFromEntity(schedule)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "vehicle_id"}, {Name: "plan_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"last_service_at", "last_service_km", "last_work_order_id", "current_km",
+				"next_due_at", "next_due_km", "status", "checked_at", "updated_at",
+			}),
+		}).
+		Create(dbModel).Error; err != nil {
+		return err
+	}
+	schedule.ID = dbModel.ID
+	schedule.UpdatedAt = dbModel.UpdatedAt
+	if schedule.CreatedAt.IsZero() {
+		schedule.CreatedAt = dbModel.CreatedAt
+	}
+	return nil
+}
+
+// Delete deletes schedules, e.g. of plans that no longer apply to the vehicle
+func (r *scheduleRepo) Delete(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return db.FromContext(ctx, r.db).WithContext(ctx).Delete(&model.MaintenanceSchedule{}, "id IN ?", ids).Error
+}
+
+// List retrieves schedules of active plans matching the filter with pagination, most urgent first
+func (r *scheduleRepo) List(ctx context.Context, filter repository.MaintenanceScheduleFilter, limit, offset int) ([]*entity.MaintenanceSchedule, int64, error) {
+	var dbSchedules []*model.MaintenanceSchedule
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).
+		Model(&model.MaintenanceSchedule{}).
+		Joins("JOIN maintenance_plans ON maintenance_plans.id = maintenance_schedules.plan_id AND maintenance_plans.active")
+	if filter.VehicleID != nil {
+		query = query.Where("maintenance_schedules.vehicle_id = ?", *filter.VehicleID)
+	}
+	if filter.Status != nil {
+		query = query.Where("maintenance_schedules.status = ?", string(*filter.Status))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Select("maintenance_schedules.*").
+		Order(clause.Expr{SQL: "CASE maintenance_schedules.status WHEN ? THEN 0 WHEN ? THEN 1 ELSE 2 END",
+			Vars: []interface{}{string(entity.MaintenanceStatusOverdue), string(entity.MaintenanceStatusDue)}}).
+		Order("maintenance_schedules.next_due_at ASC NULLS LAST, maintenance_schedules.id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbSchedules).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.MaintenanceSchedule, len(dbSchedules))
+	for i, s := range dbSchedules {
+		entities[i] = s.ToEntity()
+	}
+	return entities, total, nil
+}
+
+// HasOverdue reports whether the vehicle is past due for the service of an active plan at the given time.
+// A schedule past its due date counts even before the scheduler has marked it overdue.
+func (r *scheduleRepo) HasOverdue(ctx context.Context, vehicleID uuid.UUID, at time.Time) (bool, error) {
+	var overdue bool
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Raw(`SELECT EXISTS (
+			SELECT 1 FROM maintenance_schedules
+			JOIN maintenance_plans ON maintenance_plans.id = maintenance_schedules.plan_id AND maintenance_plans.active
+			WHERE maintenance_schedules.vehicle_id = ?
+				AND (maintenance_schedules.status = ? OR maintenance_schedules.next_due_at <= ?)
+		)`, vehicleID, string(entity.MaintenanceStatusOverdue), at).
+		Scan(&overdue).Error; err != nil {
+		return false, err
+	}
+	return overdue, nil
+}
+
+// LatestOdometers retrieves the highest odometer reading known for each vehicle from its latest GPS fix,
+// fill-ups and completed work orders; vehicles without a reading are left out
+func (r *scheduleRepo) LatestOdometers(ctx context.Context, vehicleIDs []uuid.UUID) (map[uuid.UUID]float64, error) {
+	odometers := make(map[uuid.UUID]float64, len(vehicleIDs))
+	if len(vehicleIDs) == 0 {
+		return odometers, nil
+	}
+
+	var rows []struct {
+		VehicleID  uuid.UUID
+		OdometerKm *float64
+	}
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Raw(`SELECT vehicles.id AS vehicle_id, GREATEST(
+			(SELECT odometer_km FROM vehicle_positions
+				WHERE vehicle_positions.vehicle_id = vehicles.id AND odometer_km IS NOT NULL
+				ORDER BY recorded_at DESC LIMIT 1),
+			(SELECT MAX(odometer_km) FROM fuel_logs WHERE fuel_logs.vehicle_id = vehicles.id),
+			(SELECT MAX(odometer_km) FROM work_orders WHERE work_orders.vehicle_id = vehicles.id AND work_orders.status = ?)
+		) AS odometer_km
+		FROM vehicles WHERE vehicles.id IN ?`, string(entity.WorkOrderStatusCompleted), vehicleIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if row.OdometerKm != nil {
+			odometers[row.VehicleID] = *row.OdometerKm
+		}
+	}
+	return odometers, nil
+}
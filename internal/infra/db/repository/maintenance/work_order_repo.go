@@ -0,0 +1,153 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type workOrderRepo struct {
+	db *gorm.DB
+}
+
+// NewWorkOrderRepository creates a new work order repository
+func NewWorkOrderRepository(db *gorm.DB) repository.WorkOrderRepository {
+	return &workOrderRepo{db: db}
+}
+
+// FindByID retrieves a work order with its parts by ID
+func (r *workOrderRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.WorkOrder, error) {
+	return r.find(ctx, db.FromContext(ctx, r.db).WithContext(ctx), id)
+}
+
+// FindByIDForUpdate retrieves a work order and locks its row until the surrounding transaction ends
+func (r *workOrderRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.WorkOrder, error) {
+	return r.find(ctx, db.FromContext(ctx, r.db).WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+// Create creates a new work order with its parts
+func (r *workOrderRepo) Create(ctx context.Context, order *entity.WorkOrder) error {
+	dbModel := model.WorkOrderFromEntity(order)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	order.ID = dbModel.ID
+	order.CreatedAt = dbModel.CreatedAt
+	order.UpdatedAt = dbModel.UpdatedAt
+
+	return r.insertParts(ctx, order)
+}
+
+// Update updates an existing work order and replaces its parts
+func (r *workOrderRepo) Update(ctx context.Context, order *entity.WorkOrder) error {
+	dbModel := model.WorkOrderFromEntity(order)
+	tx := db.FromContext(ctx, r.db).WithContext(ctx)
+	result := tx.Omit("Parts").Save(dbModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	order.UpdatedAt = dbModel.UpdatedAt
+
+	if err := tx.Where("work_order_id = ?", order.ID).Delete(&model.WorkOrderPart{}).Error; err != nil {
+		return err
+	}
+	return r.insertParts(ctx, order)
+}
+
+// List retrieves work orders matching the filter with pagination, newest first
+func (r *workOrderRepo) List(ctx context.Context, filter repository.WorkOrderFilter, limit, offset int) ([]*entity.WorkOrder, int64, error) {
+	var dbOrders []*model.WorkOrder
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.WorkOrder{})
+	if filter.VehicleID != nil {
+		query = query.Where("vehicle_id = ?", *filter.VehicleID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+	if filter.Type != nil {
+		query = query.Where("type = ?", string(*filter.Type))
+	}
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Where("number ILIKE ? OR workshop ILIKE ?", search, search)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Preload("Parts", orderParts).
+		Order("created_at DESC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbOrders).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.WorkOrder, len(dbOrders))
+	for i, o := range dbOrders {
+		entities[i] = o.ToEntity()
+	}
+	return entities, total, nil
+}
+
+func (r *workOrderRepo) find(ctx context.Context, query *gorm.DB, id uuid.UUID) (*entity.WorkOrder, error) {
+	var order model.WorkOrder
+	if err := query.First(&order, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// Parts are loaded separately so the row lock, if any, applies to the work order alone
+	if err := orderParts(db.FromContext(ctx, r.db).WithContext(ctx)).
+		Where("work_order_id = ?", id).
+		Find(&order.Parts).Error; err != nil {
+		return nil, err
+	}
+	return order.ToEntity(), nil
+}
+
+func (r *workOrderRepo) insertParts(ctx context.Context, order *entity.WorkOrder) error {
+	if len(order.Parts) == 0 {
+		return nil
+	}
+	parts := make([]*model.WorkOrderPart, len(order.Parts))
+	for i, p := range order.Parts {
+		parts[i] = model.WorkOrderPartFromEntity(order.ID, p)
+	}
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(&parts).Error; err != nil {
+		return err
+	}
+	for i := range order.Parts {
+		order.Parts[i].ID = parts[i].ID
+		order.Parts[i].WorkOrderID = order.ID
+	}
+	return nil
+}
+
+func orderParts(db *gorm.DB) *gorm.DB {
+	return db.Order("work_order_parts.sequence ASC")
+}
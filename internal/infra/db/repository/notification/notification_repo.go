@@ -0,0 +1,94 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type notificationRepo struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *gorm.DB) repository.NotificationRepository {
+	return &notificationRepo{db: db}
+}
+
+// Create records notifications as pending, due for delivery at once
+func (r *notificationRepo) Create(ctx context.Context, notifications ...*entity.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	rows := make([]*model.Notification, len(notifications))
+	for i, n := range notifications {
+		n.Status = entity.NotificationStatusPending
+		n.NextAttemptAt = now
+		row, err := model.NotificationFromEntity(n)
+		if err != nil {
+			return fmt.Errorf("encode notification %s: %w", n.Type, err)
+		}
+		rows[i] = row
+	}
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(&rows).Error; err != nil {
+		return err
+	}
+	for i, n := range notifications {
+		n.ID = rows[i].ID
+		n.CreatedAt = rows[i].CreatedAt
+		n.UpdatedAt = rows[i].UpdatedAt
+	}
+	return nil
+}
+
+// ListDueForUpdate retrieves pending notifications due for delivery and locks them, skipping those another
+// dispatcher holds
+func (r *notificationRepo) ListDueForUpdate(ctx context.Context, at time.Time, limit int) ([]*entity.Notification, error) {
+	var rows []*model.Notification
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND next_attempt_at <= ?", string(entity.NotificationStatusPending), at).
+		Order("next_attempt_at ASC, created_at ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	notifications := make([]*entity.Notification, len(rows))
+	for i, row := range rows {
+		notifications[i] = row.ToEntity()
+	}
+	return notifications, nil
+}
+
+// Update updates the delivery status of a notification
+func (r *notificationRepo) Update(ctx context.Context, notification *entity.Notification) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).
+		Model(&model.Notification{}).
+		Where("id = ?", notification.ID).
+		Updates(map[string]interface{}{
+			"status":          string(notification.Status),
+			"attempts":        notification.Attempts,
+			"last_error":      notification.LastError,
+			"next_attempt_at": notification.NextAttemptAt,
+			"delivered_at":    notification.DeliveredAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package numbering
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type numberingRepo struct {
+	db *gorm.DB
+}
+
+// NewNumberingRepository creates a new numbering repository
+func NewNumberingRepository(db *gorm.DB) repository.NumberingRepository {
+	return &numberingRepo{db: db}
+}
+
+// FindFormat retrieves an organization's own format for a document type
+func (r *numberingRepo) FindFormat(ctx context.Context, organizationID uuid.UUID, documentType entity.DocumentType) (*entity.NumberingFormat, error) {
+	var format model.NumberingFormat
+	err := db.FromContext(ctx, r.db).WithContext(ctx).
+		First(&format, "organization_id = ? AND document_type = ?", organizationID, string(documentType)).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return format.ToEntity(), nil
+}
+
+// ListFormats retrieves an organization's own formats
+func (r *numberingRepo) ListFormats(ctx context.Context, organizationID uuid.UUID) ([]*entity.NumberingFormat, error) {
+	return r.list(ctx, "organization_id = ?", organizationID)
+}
+
+// ListFormatsByType retrieves every organization's own format for a document type
+func (r *numberingRepo) ListFormatsByType(ctx context.Context, documentType entity.DocumentType) ([]*entity.NumberingFormat, error) {
+	return r.list(ctx, "document_type = ?", string(documentType))
+}
+
+// SaveFormat creates or replaces an organization's format for a document type
+func (r *numberingRepo) SaveFormat(ctx context.Context, format *entity.NumberingFormat) error {
+	format.UpdatedAt = time.Now()
+	return db.FromContext(ctx, r.db).WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "organization_id"}, {Name: "document_type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"format", "updated_at"}),
+		}).
+		Create(model.NumberingFormatFromEntity(format)).Error
+}
+
+// DeleteFormat removes an organization's format so the default applies again
+func (r *numberingRepo) DeleteFormat(ctx context.Context, organizationID uuid.UUID, documentType entity.DocumentType) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).
+		Delete(&model.NumberingFormat{}, "organization_id = ? AND document_type = ?", organizationID, string(documentType))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// NextValue increments the sequence of an organization, document type and period and returns the new value.
+// The upsert keeps the sequence row locked until the surrounding transaction ends, so concurrent callers
+// queue behind each other and a rolled back number is issued again.
+func (r *numberingRepo) NextValue(ctx context.Context, organizationID uuid.UUID, documentType entity.DocumentType, period string) (int64, error) {
+	var value int64
+	err := db.FromContext(ctx, r.db).WithContext(ctx).Raw(`
+		INSERT INTO number_sequences (organization_id, document_type, period, last_value, updated_at)
+		VALUES (?, ?, ?, 1, now())
+		ON CONFLICT (organization_id, document_type, period)
+		DO UPDATE SET last_value = number_sequences.last_value + 1, updated_at = excluded.updated_at
+		RETURNING last_value`,
+		organizationID, string(documentType), period,
+	).Scan(&value).Error
+	if err != nil {
+		return 0, err
+	}
+	return value, nil
+}
+
+func (r *numberingRepo) list(ctx context.Context, query string, arg interface{}) ([]*entity.NumberingFormat, error) {
+	var rows []*model.NumberingFormat
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where(query, arg).
+		Order("organization_id ASC, document_type ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.NumberingFormat, len(rows))
+	for i, f := range rows {
+		entities[i] = f.ToEntity()
+	}
+	return entities, nil
+}
@@ -0,0 +1,115 @@
+package organization
+
+import (
+	"context"
+	"errors"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type organizationRepo struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(db *gorm.DB) repository.OrganizationRepository {
+	return &organizationRepo{db: db}
+}
+
+// FindByID retrieves an organization by ID
+func (r *organizationRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	var org model.Organization
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).First(&org, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return org.ToEntity(), nil
+}
+
+// FindByIDForUpdate retrieves an organization and locks its row until the surrounding transaction ends
+func (r *organizationRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Organization, error) {
+	var org model.Organization
+	err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&org, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return org.ToEntity(), nil
+}
+
+// Create creates a new organization
+func (r *organizationRepo) Create(ctx context.Context, org *entity.Organization) error {
+	dbModel := model.OrganizationFromEntity(org)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	org.ID = dbModel.ID
+	org.CreatedAt = dbModel.CreatedAt
+	org.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing organization
+func (r *organizationRepo) Update(ctx context.Context, org *entity.Organization) error {
+	dbModel := model.OrganizationFromEntity(org)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	org.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// List retrieves organizations whose name or tax ID match the search with pagination
+func (r *organizationRepo) List(ctx context.Context, search string, limit, offset int) ([]*entity.Organization, int64, error) {
+	var dbOrgs []*model.Organization
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.Organization{})
+	if search != "" {
+		like := "%" + search + "%"
+		query = query.Where("name ILIKE ? OR tax_id ILIKE ?", like, like)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Order("name ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbOrgs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.Organization, len(dbOrgs))
+	for i, o := range dbOrgs {
+		entities[i] = o.ToEntity()
+	}
+
+	return entities, total, nil
+}
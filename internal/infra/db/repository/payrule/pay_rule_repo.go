@@ -0,0 +1,119 @@
+package payrule
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type payRuleRepo struct {
+	db *gorm.DB
+}
+
+// NewDriverPayRuleRepository creates a new driver pay rule repository
+func NewDriverPayRuleRepository(db *gorm.DB) repository.DriverPayRuleRepository {
+	return &payRuleRepo{db: db}
+}
+
+// FindByID retrieves a pay rule by ID
+func (r *payRuleRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.DriverPayRule, error) {
+	var rule model.DriverPayRule
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).First(&rule, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return rule.ToEntity(), nil
+}
+
+// FindEffective retrieves the rule effective at the given time for a vehicle type, preferring a rule
+// for that vehicle type over one for any vehicle type, then the latest effective date
+func (r *payRuleRepo) FindEffective(ctx context.Context, vehicleType entity.VehicleType, at time.Time) (*entity.DriverPayRule, error) {
+	var rule model.DriverPayRule
+	err := effectiveAt(db.FromContext(ctx, r.db).WithContext(ctx), at).
+		Where("vehicle_type = ? OR vehicle_type = ''", string(vehicleType)).
+		Order("vehicle_type DESC, effective_from DESC").
+		First(&rule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return rule.ToEntity(), nil
+}
+
+// Create creates a new pay rule
+func (r *payRuleRepo) Create(ctx context.Context, rule *entity.DriverPayRule) error {
+	dbModel := model.DriverPayRuleFromEntity(rule)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	rule.ID = dbModel.ID
+	rule.CreatedAt = dbModel.CreatedAt
+	rule.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing pay rule
+func (r *payRuleRepo) Update(ctx context.Context, rule *entity.DriverPayRule) error {
+	dbModel := model.DriverPayRuleFromEntity(rule)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	rule.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// List retrieves pay rules matching the filter, latest effective date first, with pagination
+func (r *payRuleRepo) List(ctx context.Context, filter repository.DriverPayRuleFilter, limit, offset int) ([]*entity.DriverPayRule, int64, error) {
+	var dbRules []*model.DriverPayRule
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.DriverPayRule{})
+	if filter.VehicleType != nil {
+		query = query.Where("vehicle_type = ?", string(*filter.VehicleType))
+	}
+	if filter.EffectiveAt != nil {
+		query = effectiveAt(query, *filter.EffectiveAt)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("effective_from DESC, name ASC").Limit(limit).Offset(offset).Find(&dbRules).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.DriverPayRule, len(dbRules))
+	for i, rule := range dbRules {
+		entities[i] = rule.ToEntity()
+	}
+
+	return entities, total, nil
+}
+
+func effectiveAt(query *gorm.DB, at time.Time) *gorm.DB {
+	return query.Where("effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", at, at)
+}
@@ -0,0 +1,50 @@
+package pod
+
+import (
+	"context"
+	"errors"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type podRepo struct {
+	db *gorm.DB
+}
+
+// NewProofOfDeliveryRepository creates a new proof-of-delivery repository
+func NewProofOfDeliveryRepository(db *gorm.DB) repository.ProofOfDeliveryRepository {
+	return &podRepo{db: db}
+}
+
+// FindByStopID retrieves the proof of delivery recorded for a trip stop
+func (r *podRepo) FindByStopID(ctx context.Context, stopID uuid.UUID) (*entity.ProofOfDelivery, error) {
+	var pod model.ProofOfDelivery
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).First(&pod, "stop_id = ?", stopID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return pod.ToEntity(), nil
+}
+
+// Create records a proof of delivery
+func (r *podRepo) Create(ctx context.Context, pod *entity.ProofOfDelivery) error {
+	dbModel := model.ProofOfDeliveryFromEntity(pod)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	pod.ID = dbModel.ID
+	pod.CreatedAt = dbModel.CreatedAt
+	return nil
+}
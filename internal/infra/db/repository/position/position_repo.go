@@ -0,0 +1,70 @@
+package position
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// insertChunkSize keeps each INSERT well below PostgreSQL's bind parameter limit
+const insertChunkSize = 1000
+
+type positionRepo struct {
+	db *gorm.DB
+}
+
+// NewPositionRepository creates a new GPS position repository
+func NewPositionRepository(db *gorm.DB) repository.PositionRepository {
+	return &positionRepo{db: db}
+}
+
+// CreateBatch stores positions, skipping duplicates of stored ones
+func (r *positionRepo) CreateBatch(ctx context.Context, positions []entity.VehiclePosition) (int64, error) {
+	if len(positions) == 0 {
+		return 0, nil
+	}
+
+	rows := make([]model.VehiclePosition, len(positions))
+	for i, p := range positions {
+		rows[i] = model.VehiclePositionFromEntity(p)
+	}
+
+	result := db.FromContext(ctx, r.db).WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(rows, insertChunkSize)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrForeignKeyViolated) {
+			return 0, errs.ErrBadRequest
+		}
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// ListTrack retrieves a vehicle's positions recorded in [from, to] in time order
+func (r *positionRepo) ListTrack(ctx context.Context, vehicleID uuid.UUID, from, to time.Time, limit int) ([]entity.VehiclePosition, error) {
+	var rows []model.VehiclePosition
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("vehicle_id = ? AND recorded_at BETWEEN ? AND ?", vehicleID, from, to).
+		Order("recorded_at ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	positions := make([]entity.VehiclePosition, len(rows))
+	for i := range rows {
+		positions[i] = rows[i].ToEntity()
+	}
+	return positions, nil
+}
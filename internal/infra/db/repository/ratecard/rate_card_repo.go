@@ -0,0 +1,125 @@
+package ratecard
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type rateCardRepo struct {
+	db *gorm.DB
+}
+
+// NewRateCardRepository creates a new rate card repository
+func NewRateCardRepository(db *gorm.DB) repository.RateCardRepository {
+	return &rateCardRepo{db: db}
+}
+
+// FindByID retrieves a rate card version by ID
+func (r *rateCardRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.RateCard, error) {
+	var card model.RateCard
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).First(&card, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return card.ToEntity(), nil
+}
+
+// FindEffective retrieves the card that most recently took effect at the given time for an organization.
+// Versions are numbered per card name, so they only break ties between cards taking effect at the same time.
+func (r *rateCardRepo) FindEffective(ctx context.Context, organizationID *uuid.UUID, at time.Time) (*entity.RateCard, error) {
+	var card model.RateCard
+	query := whereOrganization(db.FromContext(ctx, r.db).WithContext(ctx), organizationID)
+	err := effectiveAt(query, at).
+		Order("effective_from DESC, version DESC, created_at DESC").
+		First(&card).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return card.ToEntity(), nil
+}
+
+// LatestVersion returns the highest version of a named card, or 0 if none exists
+func (r *rateCardRepo) LatestVersion(ctx context.Context, organizationID *uuid.UUID, name string) (int, error) {
+	var version int
+	query := whereOrganization(db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.RateCard{}), organizationID)
+	if err := query.Where("name = ?", name).Select("COALESCE(MAX(version), 0)").Scan(&version).Error; err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// Create creates a new rate card version
+func (r *rateCardRepo) Create(ctx context.Context, card *entity.RateCard) error {
+	dbModel := model.RateCardFromEntity(card)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	card.ID = dbModel.ID
+	card.CreatedAt = dbModel.CreatedAt
+	card.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// List retrieves rate cards matching the filter with pagination
+func (r *rateCardRepo) List(ctx context.Context, filter repository.RateCardFilter, limit, offset int) ([]*entity.RateCard, int64, error) {
+	var dbCards []*model.RateCard
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.RateCard{})
+	switch {
+	case filter.StandardOnly:
+		query = query.Where("organization_id IS NULL")
+	case filter.OrganizationID != nil:
+		query = query.Where("organization_id = ?", *filter.OrganizationID)
+	}
+	if filter.EffectiveAt != nil {
+		query = effectiveAt(query, *filter.EffectiveAt)
+	}
+	if filter.Search != "" {
+		query = query.Where("name ILIKE ?", "%"+filter.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("name ASC, version DESC").Limit(limit).Offset(offset).Find(&dbCards).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.RateCard, len(dbCards))
+	for i, c := range dbCards {
+		entities[i] = c.ToEntity()
+	}
+
+	return entities, total, nil
+}
+
+func whereOrganization(query *gorm.DB, organizationID *uuid.UUID) *gorm.DB {
+	if organizationID == nil {
+		return query.Where("organization_id IS NULL")
+	}
+	return query.Where("organization_id = ?", *organizationID)
+}
+
+func effectiveAt(query *gorm.DB, at time.Time) *gorm.DB {
+	return query.Where("effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", at, at)
+}
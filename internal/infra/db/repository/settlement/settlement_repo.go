@@ -0,0 +1,206 @@
+package settlement
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type settlementRepo struct {
+	db *gorm.DB
+}
+
+// NewDriverSettlementRepository creates a new driver settlement repository
+func NewDriverSettlementRepository(db *gorm.DB) repository.DriverSettlementRepository {
+	return &settlementRepo{db: db}
+}
+
+// FindByID retrieves a settlement with its lines and adjustments by ID
+func (r *settlementRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.DriverSettlement, error) {
+	return r.find(ctx, db.FromContext(ctx, r.db).WithContext(ctx), id)
+}
+
+// FindByIDForUpdate retrieves a settlement and locks its row until the surrounding transaction ends
+func (r *settlementRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.DriverSettlement, error) {
+	return r.find(ctx, db.FromContext(ctx, r.db).WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+// Create creates a new settlement with its lines and adjustments
+func (r *settlementRepo) Create(ctx context.Context, settlement *entity.DriverSettlement) error {
+	dbModel := model.DriverSettlementFromEntity(settlement)
+	tx := db.FromContext(ctx, r.db).WithContext(ctx)
+	if err := tx.Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	settlement.ID = dbModel.ID
+	settlement.CreatedAt = dbModel.CreatedAt
+	settlement.UpdatedAt = dbModel.UpdatedAt
+
+	lines := make([]*model.DriverSettlementLine, len(settlement.Lines))
+	for i, l := range settlement.Lines {
+		lines[i] = model.DriverSettlementLineFromEntity(settlement.ID, l)
+	}
+	if len(lines) > 0 {
+		if err := tx.Create(&lines).Error; err != nil {
+			return err
+		}
+	}
+	for i := range settlement.Lines {
+		settlement.Lines[i].ID = lines[i].ID
+		settlement.Lines[i].SettlementID = settlement.ID
+	}
+
+	return r.insertAdjustments(ctx, settlement)
+}
+
+// Update saves the settlement's status, number and totals and replaces its adjustments
+func (r *settlementRepo) Update(ctx context.Context, settlement *entity.DriverSettlement) error {
+	dbModel := model.DriverSettlementFromEntity(settlement)
+	tx := db.FromContext(ctx, r.db).WithContext(ctx)
+	result := tx.Omit("Lines", "Adjustments").Save(dbModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	settlement.UpdatedAt = dbModel.UpdatedAt
+
+	if err := tx.Where("settlement_id = ?", settlement.ID).Delete(&model.DriverSettlementAdjustment{}).Error; err != nil {
+		return err
+	}
+	return r.insertAdjustments(ctx, settlement)
+}
+
+// List retrieves settlements matching the filter with pagination, newest first
+func (r *settlementRepo) List(ctx context.Context, filter repository.DriverSettlementFilter, limit, offset int) ([]*entity.DriverSettlement, int64, error) {
+	var dbSettlements []*model.DriverSettlement
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.DriverSettlement{})
+	if filter.DriverID != nil {
+		query = query.Where("driver_id = ?", *filter.DriverID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+	if filter.From != nil {
+		query = query.Where("period_to >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("period_from <= ?", *filter.To)
+	}
+	if filter.Search != "" {
+		query = query.Where("number ILIKE ?", "%"+filter.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Preload("Lines", orderLines).
+		Preload("Adjustments", orderAdjustments).
+		Order("created_at DESC, id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbSettlements).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.DriverSettlement, len(dbSettlements))
+	for i, s := range dbSettlements {
+		entities[i] = s.ToEntity()
+	}
+	return entities, total, nil
+}
+
+// ListSettleableTrips retrieves the completed trips a driver drove or co-drove that were planned to start
+// in [from, to) and are not on a live settlement of that driver, by planned start
+func (r *settlementRepo) ListSettleableTrips(ctx context.Context, driverID uuid.UUID, from, to time.Time) ([]*entity.Trip, error) {
+	var dbTrips []*model.Trip
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Preload("Stops", func(db *gorm.DB) *gorm.DB {
+			return db.Order("trip_stops.sequence ASC")
+		}).
+		Where("status = ? AND (driver_id = ? OR co_driver_id = ?)", string(entity.TripStatusCompleted), driverID, driverID).
+		Where("planned_start >= ? AND planned_start < ?", from, to).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM driver_settlement_lines
+			JOIN driver_settlements ON driver_settlements.id = driver_settlement_lines.settlement_id
+			WHERE driver_settlement_lines.trip_id = trips.id AND driver_settlements.driver_id = ? AND driver_settlements.status <> ?
+		)`, driverID, string(entity.SettlementStatusVoid)).
+		Order("planned_start ASC, id ASC").
+		Find(&dbTrips).Error; err != nil {
+		return nil, err
+	}
+
+	trips := make([]*entity.Trip, len(dbTrips))
+	for i, t := range dbTrips {
+		trips[i] = t.ToEntity()
+	}
+	return trips, nil
+}
+
+func (r *settlementRepo) find(ctx context.Context, query *gorm.DB, id uuid.UUID) (*entity.DriverSettlement, error) {
+	var settlement model.DriverSettlement
+	if err := query.First(&settlement, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// Lines and adjustments are loaded separately so the row lock, if any, applies to the settlement alone
+	tx := db.FromContext(ctx, r.db).WithContext(ctx)
+	if err := orderLines(tx).Where("settlement_id = ?", id).Find(&settlement.Lines).Error; err != nil {
+		return nil, err
+	}
+	if err := orderAdjustments(tx).Where("settlement_id = ?", id).Find(&settlement.Adjustments).Error; err != nil {
+		return nil, err
+	}
+	return settlement.ToEntity(), nil
+}
+
+func (r *settlementRepo) insertAdjustments(ctx context.Context, settlement *entity.DriverSettlement) error {
+	if len(settlement.Adjustments) == 0 {
+		return nil
+	}
+	adjustments := make([]*model.DriverSettlementAdjustment, len(settlement.Adjustments))
+	for i, a := range settlement.Adjustments {
+		adjustments[i] = model.DriverSettlementAdjustmentFromEntity(settlement.ID, a)
+	}
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(&adjustments).Error; err != nil {
+		return err
+	}
+	for i := range settlement.Adjustments {
+		settlement.Adjustments[i].ID = adjustments[i].ID
+		settlement.Adjustments[i].SettlementID = settlement.ID
+		settlement.Adjustments[i].CreatedAt = adjustments[i].CreatedAt
+	}
+	return nil
+}
+
+func orderLines(db *gorm.DB) *gorm.DB {
+	return db.Order("driver_settlement_lines.sequence ASC")
+}
+
+func orderAdjustments(db *gorm.DB) *gorm.DB {
+	return db.Order("driver_settlement_adjustments.created_at ASC, driver_settlement_adjustments.id ASC")
+}
@@ -0,0 +1,212 @@
+package shipment
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type shipmentRepo struct {
+	db *gorm.DB
+}
+
+// NewShipmentRepository creates a new shipment repository
+func NewShipmentRepository(db *gorm.DB) repository.ShipmentRepository {
+	return &shipmentRepo{db: db}
+}
+
+// FindByID retrieves a shipment by ID
+func (r *shipmentRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Shipment, error) {
+	return r.find(db.FromContext(ctx, r.db).WithContext(ctx), id)
+}
+
+// FindByIDForUpdate retrieves a shipment by ID and locks it until the surrounding transaction ends
+func (r *shipmentRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Shipment, error) {
+	return r.find(db.FromContext(ctx, r.db).WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *shipmentRepo) find(tx *gorm.DB, id uuid.UUID) (*entity.Shipment, error) {
+	var shipment model.Shipment
+	if err := tx.First(&shipment, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return shipment.ToEntity(), nil
+}
+
+// FindByTrackingNumber retrieves a shipment by its public tracking number
+func (r *shipmentRepo) FindByTrackingNumber(ctx context.Context, trackingNumber string) (*entity.Shipment, error) {
+	var shipment model.Shipment
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).First(&shipment, "tracking_number = ?", trackingNumber).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return shipment.ToEntity(), nil
+}
+
+// FindByIDs retrieves the shipments with the given IDs; missing IDs are skipped
+func (r *shipmentRepo) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Shipment, error) {
+	return r.findByIDs(db.FromContext(ctx, r.db).WithContext(ctx), ids)
+}
+
+// FindByIDsForUpdate retrieves the shipments with the given IDs and locks them until the surrounding transaction ends.
+// Rows are locked in ID order so that concurrent callers cannot deadlock on each other.
+func (r *shipmentRepo) FindByIDsForUpdate(ctx context.Context, ids []uuid.UUID) ([]*entity.Shipment, error) {
+	return r.findByIDs(db.FromContext(ctx, r.db).WithContext(ctx).Order("id").Clauses(clause.Locking{Strength: "UPDATE"}), ids)
+}
+
+func (r *shipmentRepo) findByIDs(tx *gorm.DB, ids []uuid.UUID) ([]*entity.Shipment, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var dbShipments []*model.Shipment
+	if err := tx.Where("id IN ?", ids).Find(&dbShipments).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.Shipment, len(dbShipments))
+	for i, s := range dbShipments {
+		entities[i] = s.ToEntity()
+	}
+	return entities, nil
+}
+
+// Create creates a new shipment
+func (r *shipmentRepo) Create(ctx context.Context, shipment *entity.Shipment) error {
+	dbModel := model.ShipmentFromEntity(shipment)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	shipment.ID = dbModel.ID
+	shipment.CreatedAt = dbModel.CreatedAt
+	shipment.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing shipment
+func (r *shipmentRepo) Update(ctx context.Context, shipment *entity.Shipment) error {
+	dbModel := model.ShipmentFromEntity(shipment)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	shipment.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// UpdateDeliveryWindow sets the delivery window of a pending shipment; the status is checked by the update
+// itself, so a shipment planned meanwhile is left alone
+func (r *shipmentRepo) UpdateDeliveryWindow(ctx context.Context, id uuid.UUID, from, to time.Time) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).
+		Model(&model.Shipment{}).
+		Where("id = ? AND status = ?", id, string(entity.ShipmentStatusPending)).
+		Updates(map[string]interface{}{"deliver_from": from, "deliver_to": to})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateStatus sets the status of the given shipments and records the change in the status history
+// of those whose status differed; both happen in one statement
+func (r *shipmentRepo) UpdateStatus(ctx context.Context, ids []uuid.UUID, status entity.ShipmentStatus) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return db.FromContext(ctx, r.db).WithContext(ctx).Exec(`
+		WITH changed AS (
+			UPDATE shipments SET status = ?, updated_at = now()
+			WHERE id IN ? AND status <> ? AND deleted_at IS NULL
+			RETURNING id
+		)
+		INSERT INTO shipment_status_history (shipment_id, status, changed_at)
+		SELECT id, ?, now() FROM changed`,
+		string(status), ids, string(status), string(status),
+	).Error
+}
+
+// ListStatusHistory retrieves the status changes of a shipment, oldest first
+func (r *shipmentRepo) ListStatusHistory(ctx context.Context, shipmentID uuid.UUID) ([]*entity.ShipmentStatusChange, error) {
+	var changes []*model.ShipmentStatusChange
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("shipment_id = ?", shipmentID).
+		Order("changed_at ASC").
+		Find(&changes).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.ShipmentStatusChange, len(changes))
+	for i, c := range changes {
+		entities[i] = c.ToEntity()
+	}
+	return entities, nil
+}
+
+// Delete soft deletes a shipment
+func (r *shipmentRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Delete(&model.Shipment{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// List retrieves shipments matching the filter with pagination
+func (r *shipmentRepo) List(ctx context.Context, filter repository.ShipmentFilter, limit, offset int) ([]*entity.Shipment, int64, error) {
+	var dbShipments []*model.Shipment
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.Shipment{})
+	if filter.OrganizationID != nil {
+		query = query.Where("organization_id = ?", *filter.OrganizationID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where("tracking_number ILIKE ? OR reference ILIKE ? OR notes ILIKE ?", like, like, like)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&dbShipments).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.Shipment, len(dbShipments))
+	for i, s := range dbShipments {
+		entities[i] = s.ToEntity()
+	}
+
+	return entities, total, nil
+}
@@ -0,0 +1,49 @@
+package stopdelay
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type stopDelayRepo struct {
+	db *gorm.DB
+}
+
+// NewStopDelayRepository creates a new stop delay repository
+func NewStopDelayRepository(db *gorm.DB) repository.StopDelayRepository {
+	return &stopDelayRepo{db: db}
+}
+
+// Create records a detected delay
+func (r *stopDelayRepo) Create(ctx context.Context, delay *entity.StopDelay) error {
+	dbModel := model.StopDelayFromEntity(delay)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		return err
+	}
+	delay.ID = dbModel.ID
+	return nil
+}
+
+// ListByTrip retrieves the delays detected on a trip, newest first
+func (r *stopDelayRepo) ListByTrip(ctx context.Context, tripID uuid.UUID) ([]*entity.StopDelay, error) {
+	var rows []*model.StopDelay
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("trip_id = ?", tripID).
+		Order("detected_at DESC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.StopDelay, len(rows))
+	for i, d := range rows {
+		entities[i] = d.ToEntity()
+	}
+	return entities, nil
+}
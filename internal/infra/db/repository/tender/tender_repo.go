@@ -0,0 +1,203 @@
+package tender
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type tenderRepo struct {
+	db *gorm.DB
+}
+
+// NewTenderRepository creates a new tender repository
+func NewTenderRepository(db *gorm.DB) repository.TenderRepository {
+	return &tenderRepo{db: db}
+}
+
+// FindByID retrieves a tender with its offers and events by ID
+func (r *tenderRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Tender, error) {
+	return r.find(ctx, db.FromContext(ctx, r.db).WithContext(ctx), id)
+}
+
+// FindByIDForUpdate retrieves a tender and locks its row until the surrounding transaction ends
+func (r *tenderRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Tender, error) {
+	return r.find(ctx, db.FromContext(ctx, r.db).WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+// Create creates a new tender with its offers and events
+func (r *tenderRepo) Create(ctx context.Context, tender *entity.Tender) error {
+	dbModel := model.TenderFromEntity(tender)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	tender.ID = dbModel.ID
+	tender.CreatedAt = dbModel.CreatedAt
+	tender.UpdatedAt = dbModel.UpdatedAt
+
+	offers := make([]*model.TenderOffer, len(tender.Offers))
+	for i, o := range tender.Offers {
+		offers[i] = model.TenderOfferFromEntity(tender.ID, o)
+	}
+	if len(offers) > 0 {
+		if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(&offers).Error; err != nil {
+			return err
+		}
+	}
+	for i := range tender.Offers {
+		tender.Offers[i].ID = offers[i].ID
+		tender.Offers[i].TenderID = tender.ID
+	}
+
+	return r.appendEvents(ctx, tender)
+}
+
+// Update saves the tender's status and offers and appends its unsaved events
+func (r *tenderRepo) Update(ctx context.Context, tender *entity.Tender) error {
+	dbModel := model.TenderFromEntity(tender)
+	tx := db.FromContext(ctx, r.db).WithContext(ctx)
+
+	result := tx.Omit("Offers", "Events").Save(dbModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	tender.UpdatedAt = dbModel.UpdatedAt
+
+	for _, o := range tender.Offers {
+		if err := tx.Save(model.TenderOfferFromEntity(tender.ID, o)).Error; err != nil {
+			return err
+		}
+	}
+
+	return r.appendEvents(ctx, tender)
+}
+
+// List retrieves tenders matching the filter with pagination, newest first
+func (r *tenderRepo) List(ctx context.Context, filter repository.TenderFilter, limit, offset int) ([]*entity.Tender, int64, error) {
+	var dbTenders []*model.Tender
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.Tender{})
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+	if filter.ShipmentID != nil {
+		query = query.Where("shipment_id = ?", *filter.ShipmentID)
+	}
+	if filter.TripID != nil {
+		query = query.Where("trip_id = ?", *filter.TripID)
+	}
+	if filter.CarrierID != nil {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM tender_offers o WHERE o.tender_id = tenders.id AND o.carrier_id = ? AND o.status <> ?)",
+			*filter.CarrierID, string(entity.TenderOfferQueued),
+		)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Preload("Offers", orderOffers).
+		Preload("Events", orderEvents).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbTenders).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.Tender, len(dbTenders))
+	for i, t := range dbTenders {
+		entities[i] = t.ToEntity()
+	}
+
+	return entities, total, nil
+}
+
+// ListOverdueIDs retrieves open tenders whose current offer's deadline is before the given time
+func (r *tenderRepo) ListOverdueIDs(ctx context.Context, before time.Time, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Model(&model.TenderOffer{}).
+		Joins("JOIN tenders ON tenders.id = tender_offers.tender_id").
+		Where("tenders.status = ?", string(entity.TenderStatusOpen)).
+		Where("tender_offers.status = ? AND tender_offers.deadline < ?", string(entity.TenderOfferOffered), before).
+		Order("tender_offers.deadline ASC").
+		Limit(limit).
+		Pluck("tender_offers.tender_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *tenderRepo) find(ctx context.Context, query *gorm.DB, id uuid.UUID) (*entity.Tender, error) {
+	var tender model.Tender
+	if err := query.First(&tender, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// Children are loaded separately so the row lock, if any, applies to the tender alone
+	tx := db.FromContext(ctx, r.db).WithContext(ctx)
+	if err := orderOffers(tx).Where("tender_id = ?", id).Find(&tender.Offers).Error; err != nil {
+		return nil, err
+	}
+	if err := orderEvents(tx).Where("tender_id = ?", id).Find(&tender.Events).Error; err != nil {
+		return nil, err
+	}
+	return tender.ToEntity(), nil
+}
+
+func (r *tenderRepo) appendEvents(ctx context.Context, tender *entity.Tender) error {
+	var pending []*model.TenderEvent
+	var indexes []int
+	for i, e := range tender.Events {
+		if e.ID == uuid.Nil {
+			pending = append(pending, model.TenderEventFromEntity(tender.ID, e))
+			indexes = append(indexes, i)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(&pending).Error; err != nil {
+		return err
+	}
+	for j, i := range indexes {
+		tender.Events[i].ID = pending[j].ID
+		tender.Events[i].TenderID = tender.ID
+	}
+	return nil
+}
+
+func orderOffers(db *gorm.DB) *gorm.DB {
+	return db.Order("tender_offers.rank ASC")
+}
+
+func orderEvents(db *gorm.DB) *gorm.DB {
+	return db.Order("tender_events.sequence ASC")
+}
@@ -0,0 +1,294 @@
+package trip
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// inactiveStatuses are trip statuses that no longer hold a vehicle or driver
+var inactiveStatuses = []string{string(entity.TripStatusCompleted), string(entity.TripStatusCancelled)}
+
+type tripRepo struct {
+	db *gorm.DB
+}
+
+// NewTripRepository creates a new trip repository
+func NewTripRepository(db *gorm.DB) repository.TripRepository {
+	return &tripRepo{db: db}
+}
+
+// FindByID retrieves a trip with its stops by ID
+func (r *tripRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Trip, error) {
+	return r.find(db.FromContext(ctx, r.db).WithContext(ctx), id)
+}
+
+// FindByIDForUpdate retrieves a trip with its stops and locks the trip row until the surrounding transaction ends
+func (r *tripRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Trip, error) {
+	return r.find(db.FromContext(ctx, r.db).WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *tripRepo) find(tx *gorm.DB, id uuid.UUID) (*entity.Trip, error) {
+	var trip model.Trip
+	if err := tx.
+		Preload("Stops", orderStops).
+		First(&trip, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return trip.ToEntity(), nil
+}
+
+// Create creates a new trip and its stops
+func (r *tripRepo) Create(ctx context.Context, trip *entity.Trip) error {
+	dbModel := model.TripFromEntity(trip)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		return err
+	}
+	trip.ID = dbModel.ID
+	trip.CreatedAt = dbModel.CreatedAt
+	trip.UpdatedAt = dbModel.UpdatedAt
+
+	return r.insertStops(ctx, trip)
+}
+
+// Update updates an existing trip and replaces its stops
+func (r *tripRepo) Update(ctx context.Context, trip *entity.Trip) error {
+	dbModel := model.TripFromEntity(trip)
+	tx := db.FromContext(ctx, r.db).WithContext(ctx)
+
+	result := tx.Omit("Stops").Save(dbModel)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	trip.UpdatedAt = dbModel.UpdatedAt
+
+	if err := tx.Where("trip_id = ?", trip.ID).Delete(&model.TripStop{}).Error; err != nil {
+		return err
+	}
+	return r.insertStops(ctx, trip)
+}
+
+// UpdateStatus sets the status of a trip without touching its stops
+func (r *tripRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.TripStatus) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).
+		Model(&model.Trip{}).
+		Where("id = ?", id).
+		Update("status", string(status))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateDispatcher sets the dispatcher responsible for a trip
+func (r *tripRepo) UpdateDispatcher(ctx context.Context, id, userID uuid.UUID) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).
+		Model(&model.Trip{}).
+		Where("id = ?", id).
+		Update("dispatched_by", userID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateCarrier records the carrier a trip was awarded to and the tender that awarded it
+func (r *tripRepo) UpdateCarrier(ctx context.Context, id, carrierID, tenderID uuid.UUID) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).
+		Model(&model.Trip{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"carrier_id": carrierID, "tender_id": tenderID})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// UpdateStopProgress saves the status and arrival and departure times of the given stops
+func (r *tripRepo) UpdateStopProgress(ctx context.Context, stops []*entity.TripStop) error {
+	tx := db.FromContext(ctx, r.db).WithContext(ctx)
+	for _, s := range stops {
+		result := tx.Model(&model.TripStop{}).
+			Where("id = ?", s.ID).
+			Updates(map[string]interface{}{
+				"status":      string(s.Status),
+				"arrived_at":  s.ArrivedAt,
+				"departed_at": s.DepartedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errs.ErrNotFound
+		}
+	}
+	return nil
+}
+
+// UpdateStopETAs saves the predicted arrival and delay flag of the given stops
+func (r *tripRepo) UpdateStopETAs(ctx context.Context, stops []*entity.TripStop) error {
+	tx := db.FromContext(ctx, r.db).WithContext(ctx)
+	for _, s := range stops {
+		if err := tx.Model(&model.TripStop{}).
+			Where("id = ?", s.ID).
+			Updates(map[string]interface{}{
+				"eta":        s.ETA,
+				"delayed_at": s.DelayedAt,
+			}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindActiveByVehicle retrieves the dispatched or in-progress trips of a vehicle ordered by planned start
+func (r *tripRepo) FindActiveByVehicle(ctx context.Context, vehicleID uuid.UUID) ([]*entity.Trip, error) {
+	var dbTrips []*model.Trip
+
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Preload("Stops", orderStops).
+		Where("vehicle_id = ?", vehicleID).
+		Where("status IN ?", []string{string(entity.TripStatusDispatched), string(entity.TripStatusInProgress)}).
+		Order("planned_start ASC").
+		Find(&dbTrips).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.Trip, len(dbTrips))
+	for i, t := range dbTrips {
+		entities[i] = t.ToEntity()
+	}
+	return entities, nil
+}
+
+// FindByShipment retrieves the latest trip that is not cancelled and carries the shipment
+func (r *tripRepo) FindByShipment(ctx context.Context, shipmentID uuid.UUID) (*entity.Trip, error) {
+	var trip model.Trip
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Preload("Stops", orderStops).
+		Where("id IN (?)", db.FromContext(ctx, r.db).Model(&model.TripStop{}).Select("trip_id").Where("shipment_id = ?", shipmentID)).
+		Where("status <> ?", string(entity.TripStatusCancelled)).
+		Order("planned_start DESC").
+		First(&trip).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return trip.ToEntity(), nil
+}
+
+// List retrieves trips matching the filter with pagination
+func (r *tripRepo) List(ctx context.Context, filter repository.TripFilter, limit, offset int) ([]*entity.Trip, int64, error) {
+	var dbTrips []*model.Trip
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.Trip{})
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+	if filter.VehicleID != nil {
+		query = query.Where("vehicle_id = ?", *filter.VehicleID)
+	}
+	if filter.DriverID != nil {
+		query = query.Where("driver_id = ? OR co_driver_id = ?", *filter.DriverID, *filter.DriverID)
+	}
+	if filter.From != nil {
+		query = query.Where("planned_end >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("planned_start < ?", *filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.
+		Preload("Stops", orderStops).
+		Order("planned_start ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&dbTrips).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.Trip, len(dbTrips))
+	for i, t := range dbTrips {
+		entities[i] = t.ToEntity()
+	}
+
+	return entities, total, nil
+}
+
+// FindOverlapping retrieves active trips in [start, end) that use the vehicle or any of the drivers
+func (r *tripRepo) FindOverlapping(ctx context.Context, vehicleID uuid.UUID, driverIDs []uuid.UUID, start, end time.Time, excludeID *uuid.UUID) ([]*entity.Trip, error) {
+	var dbTrips []*model.Trip
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).
+		Where("status NOT IN ?", inactiveStatuses).
+		Where("planned_start < ? AND planned_end > ?", end, start).
+		Where("vehicle_id = ? OR driver_id IN ? OR co_driver_id IN ?", vehicleID, driverIDs, driverIDs)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+
+	if err := query.Find(&dbTrips).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.Trip, len(dbTrips))
+	for i, t := range dbTrips {
+		entities[i] = t.ToEntity()
+	}
+	return entities, nil
+}
+
+func (r *tripRepo) insertStops(ctx context.Context, trip *entity.Trip) error {
+	if len(trip.Stops) == 0 {
+		return nil
+	}
+
+	stops := make([]*model.TripStop, len(trip.Stops))
+	for i, s := range trip.Stops {
+		stops[i] = model.TripStopFromEntity(trip.ID, s)
+	}
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(&stops).Error; err != nil {
+		return err
+	}
+
+	for i := range trip.Stops {
+		trip.Stops[i].ID = stops[i].ID
+		trip.Stops[i].TripID = trip.ID
+	}
+	return nil
+}
+
+func orderStops(db *gorm.DB) *gorm.DB {
+	return db.Order("trip_stops.sequence ASC")
+}
@@ -92,15 +92,17 @@ func (r *userRepo) Create(ctx context.Context, user *entity.User) error {
 		}
 		return err
 	}
-	// Update entity with ID if generated by DB
+	// Update entity with ID and role if generated by DB
 	user.ID = dbModel.ID
+	user.Role = entity.UserRole(dbModel.Role)
 	return nil
 }
 
 // Update updates an existing user
 func (r *userRepo) Update(ctx context.Context, user *entity.User) error {
 	dbModel := model.FromEntity(user)
-	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	// roles are granted in the database only
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Omit("Role").Save(dbModel)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -0,0 +1,165 @@
+package vehicle
+
+import (
+	"context"
+	"errors"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/infra/db"
+	"tms-core-service/internal/infra/db/model"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type vehicleRepo struct {
+	db *gorm.DB
+}
+
+// NewVehicleRepository creates a new vehicle repository
+func NewVehicleRepository(db *gorm.DB) repository.VehicleRepository {
+	return &vehicleRepo{db: db}
+}
+
+// FindByID retrieves a vehicle by ID
+func (r *vehicleRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Vehicle, error) {
+	var vehicle model.Vehicle
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).First(&vehicle, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return vehicle.ToEntity(), nil
+}
+
+// FindByIDForUpdate retrieves a vehicle and locks its row until the surrounding transaction ends
+func (r *vehicleRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Vehicle, error) {
+	var vehicle model.Vehicle
+	err := db.FromContext(ctx, r.db).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&vehicle, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+	return vehicle.ToEntity(), nil
+}
+
+// FindByIDs retrieves the vehicles with the given IDs
+func (r *vehicleRepo) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Vehicle, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var dbVehicles []*model.Vehicle
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Where("id IN ?", ids).Find(&dbVehicles).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.Vehicle, len(dbVehicles))
+	for i, v := range dbVehicles {
+		entities[i] = v.ToEntity()
+	}
+	return entities, nil
+}
+
+// FindByGPSDeviceIDs retrieves the vehicles fitted with the given GPS devices
+func (r *vehicleRepo) FindByGPSDeviceIDs(ctx context.Context, deviceIDs []string) ([]*entity.Vehicle, error) {
+	if len(deviceIDs) == 0 {
+		return nil, nil
+	}
+
+	var dbVehicles []*model.Vehicle
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Where("gps_device_id IN ?", deviceIDs).Find(&dbVehicles).Error; err != nil {
+		return nil, err
+	}
+
+	entities := make([]*entity.Vehicle, len(dbVehicles))
+	for i, v := range dbVehicles {
+		entities[i] = v.ToEntity()
+	}
+	return entities, nil
+}
+
+// Create creates a new vehicle
+func (r *vehicleRepo) Create(ctx context.Context, vehicle *entity.Vehicle) error {
+	dbModel := model.VehicleFromEntity(vehicle)
+	if err := db.FromContext(ctx, r.db).WithContext(ctx).Create(dbModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return err
+	}
+	vehicle.ID = dbModel.ID
+	vehicle.CreatedAt = dbModel.CreatedAt
+	vehicle.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Update updates an existing vehicle
+func (r *vehicleRepo) Update(ctx context.Context, vehicle *entity.Vehicle) error {
+	dbModel := model.VehicleFromEntity(vehicle)
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Save(dbModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return errs.ErrConflict
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	vehicle.UpdatedAt = dbModel.UpdatedAt
+	return nil
+}
+
+// Delete soft deletes a vehicle
+func (r *vehicleRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := db.FromContext(ctx, r.db).WithContext(ctx).Delete(&model.Vehicle{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// List retrieves vehicles matching the filter with pagination
+func (r *vehicleRepo) List(ctx context.Context, filter repository.VehicleFilter, limit, offset int) ([]*entity.Vehicle, int64, error) {
+	var dbVehicles []*model.Vehicle
+	var total int64
+
+	query := db.FromContext(ctx, r.db).WithContext(ctx).Model(&model.Vehicle{})
+	if filter.Status != nil {
+		query = query.Where("status = ?", string(*filter.Status))
+	}
+	if filter.Type != nil {
+		query = query.Where("type = ?", string(*filter.Type))
+	}
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where("plate_number ILIKE ? OR brand ILIKE ? OR model ILIKE ? OR home_depot ILIKE ?", like, like, like, like)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Order("plate_number ASC").Limit(limit).Offset(offset).Find(&dbVehicles).Error; err != nil {
+		return nil, 0, err
+	}
+
+	entities := make([]*entity.Vehicle, len(dbVehicles))
+	for i, v := range dbVehicles {
+		entities[i] = v.ToEntity()
+	}
+
+	return entities, total, nil
+}
@@ -0,0 +1,166 @@
+package realtime
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/service"
+)
+
+// Defaults used when the hub is created without limits
+const (
+	DefaultClientBuffer = 256
+	DefaultMaxChannels  = 100
+)
+
+// Hub fans events out to the subscriptions of the clients connected to this instance.
+// Dispatch never blocks: a client whose buffer is full is a slow consumer and is dropped,
+// so one stalled connection cannot hold up the others or the Redis subscription feeding them.
+type Hub struct {
+	mu           sync.RWMutex
+	channels     map[string]map[*subscription]struct{}
+	clientBuffer int
+	maxChannels  int
+}
+
+// NewHub creates a hub that buffers up to clientBuffer events per client
+// and lets each client subscribe to up to maxChannels channels
+func NewHub(clientBuffer, maxChannels int) *Hub {
+	if clientBuffer <= 0 {
+		clientBuffer = DefaultClientBuffer
+	}
+	if maxChannels <= 0 {
+		maxChannels = DefaultMaxChannels
+	}
+	return &Hub{
+		channels:     make(map[string]map[*subscription]struct{}),
+		clientBuffer: clientBuffer,
+		maxChannels:  maxChannels,
+	}
+}
+
+// Connect creates a subscription with no channels
+func (h *Hub) Connect() service.Subscription {
+	return &subscription{
+		hub:      h,
+		channels: make(map[string]struct{}),
+		messages: make(chan []byte, h.clientBuffer),
+		done:     make(chan struct{}),
+	}
+}
+
+// Dispatch delivers an encoded event to every subscription of the channel
+func (h *Hub) Dispatch(channel string, payload []byte) {
+	var slow []*subscription
+
+	h.mu.RLock()
+	for s := range h.channels[channel] {
+		select {
+		case s.messages <- payload:
+		default:
+			slow = append(slow, s)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, s := range slow {
+		s.dropped.Store(true)
+		s.Close()
+	}
+}
+
+// Subscribers returns the number of subscriptions of a channel
+func (h *Hub) Subscribers(channel string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.channels[channel])
+}
+
+type subscription struct {
+	hub      *Hub
+	channels map[string]struct{} // guarded by hub.mu
+	messages chan []byte
+	done     chan struct{}
+	once     sync.Once
+	dropped  atomic.Bool
+}
+
+func (s *subscription) Subscribe(channels ...string) error {
+	h := s.hub
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+
+	added := 0
+	for _, c := range channels {
+		if _, ok := s.channels[c]; !ok {
+			added++
+		}
+	}
+	if len(s.channels)+added > h.maxChannels {
+		return errs.ValidationErrors{"channels": {"too_many"}}
+	}
+
+	for _, c := range channels {
+		s.channels[c] = struct{}{}
+		subs, ok := h.channels[c]
+		if !ok {
+			subs = make(map[*subscription]struct{})
+			h.channels[c] = subs
+		}
+		subs[s] = struct{}{}
+	}
+	return nil
+}
+
+func (s *subscription) Unsubscribe(channels ...string) {
+	h := s.hub
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, c := range channels {
+		s.remove(c)
+	}
+}
+
+func (s *subscription) Messages() <-chan []byte {
+	return s.messages
+}
+
+func (s *subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *subscription) Dropped() bool {
+	return s.dropped.Load()
+}
+
+// Close ends the subscription. The message channel is left open so a concurrent Dispatch
+// never sends on a closed channel; readers stop on Done.
+func (s *subscription) Close() {
+	s.once.Do(func() {
+		h := s.hub
+		h.mu.Lock()
+		for c := range s.channels {
+			s.remove(c)
+		}
+		h.mu.Unlock()
+		close(s.done)
+	})
+}
+
+// remove drops one channel; the caller holds hub.mu
+func (s *subscription) remove(channel string) {
+	delete(s.channels, channel)
+	if subs, ok := s.hub.channels[channel]; ok {
+		delete(subs, s)
+		if len(subs) == 0 {
+			delete(s.hub.channels, channel)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package realtime
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/service"
+)
+
+func TestDispatchDropsOnlyTheSlowConsumer(t *testing.T) {
+	const buffer = 4
+	hub := NewHub(buffer, 10)
+	channel := "trip:3f0c5a56-7f5e-4a52-9a4b-6b1f1d1c0a01"
+
+	readers := make([]service.Subscription, 20)
+	for i := range readers {
+		readers[i] = hub.Connect()
+		if err := readers[i].Subscribe(channel); err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+	}
+	blocked := hub.Connect()
+	if err := blocked.Subscribe(channel); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	for n := 0; n < 50; n++ {
+		payload := []byte(fmt.Sprintf("event %d", n))
+		hub.Dispatch(channel, payload)
+
+		for i, r := range readers {
+			select {
+			case got := <-r.Messages():
+				if string(got) != string(payload) {
+					t.Fatalf("reader %d got %q, want %q", i, got, payload)
+				}
+			default:
+				t.Fatalf("reader %d missed %q", i, payload)
+			}
+		}
+
+		if n < buffer {
+			select {
+			case <-blocked.Done():
+				t.Fatalf("blocked reader dropped after %d event(s) with a buffer of %d", n+1, buffer)
+			default:
+			}
+		}
+	}
+
+	select {
+	case <-blocked.Done():
+	default:
+		t.Fatal("blocked reader still subscribed")
+	}
+	if !blocked.Dropped() {
+		t.Error("blocked reader not marked as dropped")
+	}
+	for _, r := range readers {
+		if r.Dropped() {
+			t.Fatal("a reader keeping up was dropped")
+		}
+	}
+	if n := hub.Subscribers(channel); n != len(readers) {
+		t.Errorf("%d subscribers left, want %d", n, len(readers))
+	}
+}
+
+func TestSubscriptionLimitsAndClose(t *testing.T) {
+	hub := NewHub(1, 2)
+	sub := hub.Connect()
+
+	if err := sub.Subscribe("trip:a", "trip:b"); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	// re-subscribing to a channel does not count twice
+	if err := sub.Subscribe("trip:a"); err != nil {
+		t.Fatalf("Subscribe again: %v", err)
+	}
+	var validationErrs errs.ValidationErrors
+	if err := sub.Subscribe("trip:c"); !errors.As(err, &validationErrs) {
+		t.Fatalf("third channel: err = %v, want too_many", err)
+	}
+
+	sub.Unsubscribe("trip:a")
+	if hub.Subscribers("trip:a") != 0 || hub.Subscribers("trip:b") != 1 {
+		t.Errorf("subscribers after unsubscribe: a=%d b=%d", hub.Subscribers("trip:a"), hub.Subscribers("trip:b"))
+	}
+
+	sub.Close()
+	sub.Close()
+	if hub.Subscribers("trip:b") != 0 {
+		t.Error("closed subscription still receives")
+	}
+	if sub.Dropped() {
+		t.Error("a closed subscription is not a dropped one")
+	}
+	// dispatching to a closed subscription must not panic
+	hub.Dispatch("trip:b", []byte("late"))
+}
@@ -11,6 +11,26 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// deleteIfEqualsScript deletes KEYS[1] when it holds ARGV[1]
+var deleteIfEqualsScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// incrementScript counts KEYS[1] up, starting a new counter with a lifetime of ARGV[1] ms,
+// and returns the count and the counter's remaining lifetime
+var incrementScript = redis.NewScript(`
+local n = redis.call("INCR", KEYS[1])
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {n, ttl}
+`)
+
 type cacheRepo struct {
 	client *redis.Client
 }
@@ -81,3 +101,21 @@ func (r *cacheRepo) SetNX(ctx context.Context, key string, value interface{}, ex
 
 	return r.client.SetNX(ctx, key, data, expiration).Result()
 }
+
+// Increment adds one to a counter and returns the new count and the time left until it expires (atomic)
+func (r *cacheRepo) Increment(ctx context.Context, key string, expiration time.Duration) (int64, time.Duration, error) {
+	result, err := incrementScript.Run(ctx, r.client, []string{key}, expiration.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+	return result[0], time.Duration(result[1]) * time.Millisecond, nil
+}
+
+// DeleteIfEquals removes a value only if it still equals the given one (atomic)
+func (r *cacheRepo) DeleteIfEquals(ctx context.Context, key, value string) (bool, error) {
+	n, err := deleteIfEqualsScript.Run(ctx, r.client, []string{key}, value).Int()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
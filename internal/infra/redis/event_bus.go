@@ -0,0 +1,85 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/service"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// eventChannelPrefix namespaces realtime channels in Redis pub/sub
+	eventChannelPrefix = "realtime:"
+
+	// eventReceiveBuffer is how many messages the subscription may hold while the hub catches up
+	eventReceiveBuffer = 1000
+)
+
+// eventMessage is the encoding of a realtime event sent to clients
+type eventMessage struct {
+	Channel    string                 `json:"channel"`
+	Type       string                 `json:"type"`
+	Data       map[string]interface{} `json:"data"`
+	OccurredAt string                 `json:"occurred_at"`
+}
+
+// EventBus relays realtime events between service instances over Redis pub/sub
+type EventBus struct {
+	client *redis.Client
+}
+
+// NewEventBus creates a new Redis-backed event bus
+func NewEventBus(client *redis.Client) *EventBus {
+	return &EventBus{client: client}
+}
+
+// Publish sends each event to its channels on every instance
+func (b *EventBus) Publish(ctx context.Context, events ...service.RealtimeEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	pipe := b.client.Pipeline()
+	for _, e := range events {
+		for _, channel := range e.Channels {
+			payload, err := json.Marshal(eventMessage{
+				Channel:    channel,
+				Type:       e.Type,
+				Data:       e.Data,
+				OccurredAt: e.OccurredAt.UTC().Format(time.RFC3339),
+			})
+			if err != nil {
+				return err
+			}
+			pipe.Publish(ctx, eventChannelPrefix+channel, payload)
+		}
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Run subscribes to every realtime channel and hands each message to dispatch until ctx is done.
+// The Redis client reconnects by itself; events published while it is disconnected are lost.
+func (b *EventBus) Run(ctx context.Context, dispatch func(channel string, payload []byte)) {
+	pubsub := b.client.PSubscribe(ctx, eventChannelPrefix+"*")
+	defer pubsub.Close()
+
+	messages := pubsub.Channel(redis.WithChannelSize(eventReceiveBuffer))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				log.Printf("[ERROR] event bus: subscription closed")
+				return
+			}
+			dispatch(strings.TrimPrefix(msg.Channel, eventChannelPrefix), []byte(msg.Payload))
+		}
+	}
+}
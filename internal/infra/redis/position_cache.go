@@ -0,0 +1,137 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"tms-core-service/internal/domain/cache"
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// positionsKey hashes vehicle ID -> latest position JSON
+	positionsKey = "fleet:positions"
+	// positionTimesKey hashes vehicle ID -> recorded_at of the latest position in Unix milliseconds
+	positionTimesKey = "fleet:positions:ts"
+)
+
+// setLatestScript stores each position only when it is newer than the cached one.
+// ARGV holds (vehicle ID, recorded_at ms, JSON) triples.
+var setLatestScript = redis.NewScript(`
+local stored = 0
+for i = 1, #ARGV, 3 do
+  local id, ts = ARGV[i], tonumber(ARGV[i + 1])
+  local current = tonumber(redis.call('HGET', KEYS[2], id))
+  if current == nil or ts > current then
+    redis.call('HSET', KEYS[1], id, ARGV[i + 2])
+    redis.call('HSET', KEYS[2], id, ts)
+    stored = stored + 1
+  end
+end
+return stored
+`)
+
+// cachedPosition is the JSON form of a position in Redis
+type cachedPosition struct {
+	VehicleID  uuid.UUID `json:"vehicle_id"`
+	DeviceID   string    `json:"device_id,omitempty"`
+	RecordedAt time.Time `json:"recorded_at"`
+	Latitude   float64   `json:"lat"`
+	Longitude  float64   `json:"lng"`
+	SpeedKmh   *float64  `json:"speed_kmh,omitempty"`
+	Heading    *float64  `json:"heading,omitempty"`
+	OdometerKm *float64  `json:"odometer_km,omitempty"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+type positionCache struct {
+	client *redis.Client
+}
+
+// NewPositionCache creates a latest-position cache backed by Redis hashes
+func NewPositionCache(client *redis.Client) cache.PositionCache {
+	return &positionCache{client: client}
+}
+
+// SetLatest stores positions that are newer than the cached ones
+func (c *positionCache) SetLatest(ctx context.Context, positions []entity.VehiclePosition) (int, error) {
+	if len(positions) == 0 {
+		return 0, nil
+	}
+
+	args := make([]interface{}, 0, 3*len(positions))
+	for _, p := range positions {
+		data, err := json.Marshal(cachedPosition(p))
+		if err != nil {
+			return 0, err
+		}
+		args = append(args, p.VehicleID.String(), p.RecordedAt.UnixMilli(), string(data))
+	}
+
+	stored, err := setLatestScript.Run(ctx, c.client, []string{positionsKey, positionTimesKey}, args...).Int()
+	if err != nil {
+		return 0, fmt.Errorf("set latest positions: %w", err)
+	}
+	return stored, nil
+}
+
+// GetLatest returns the latest positions of the given vehicles
+func (c *positionCache) GetLatest(ctx context.Context, vehicleIDs []uuid.UUID) ([]entity.VehiclePosition, error) {
+	if len(vehicleIDs) == 0 {
+		return nil, nil
+	}
+
+	fields := make([]string, len(vehicleIDs))
+	for i, id := range vehicleIDs {
+		fields[i] = id.String()
+	}
+	values, err := c.client.HMGet(ctx, positionsKey, fields...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var positions []entity.VehiclePosition
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue // no position for this vehicle
+		}
+		p, err := decodePosition(s)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, p)
+	}
+	return positions, nil
+}
+
+// ListLatest returns the latest position of every vehicle
+func (c *positionCache) ListLatest(ctx context.Context) ([]entity.VehiclePosition, error) {
+	values, err := c.client.HGetAll(ctx, positionsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]entity.VehiclePosition, 0, len(values))
+	for _, s := range values {
+		p, err := decodePosition(s)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, p)
+	}
+	return positions, nil
+}
+
+func decodePosition(s string) (entity.VehiclePosition, error) {
+	var p cachedPosition
+	if err := json.Unmarshal([]byte(s), &p); err != nil {
+		return entity.VehiclePosition{}, fmt.Errorf("decode cached position: %w", err)
+	}
+	return entity.VehiclePosition(p), nil
+}
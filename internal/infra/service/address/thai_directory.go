@@ -0,0 +1,99 @@
+package address
+
+import (
+	"errors"
+	"strings"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/thaiaddress"
+)
+
+type thaiAddressDirectory struct {
+	directory *thaiaddress.Directory
+}
+
+// NewThaiAddressDirectory creates an address directory backed by the embedded Thai administrative dataset
+func NewThaiAddressDirectory() service.AddressDirectory {
+	return &thaiAddressDirectory{directory: thaiaddress.Default()}
+}
+
+func (d *thaiAddressDirectory) Resolve(subdistrict, district, province, postcode string) (*entity.AdminArea, error) {
+	area, err := d.directory.Match(subdistrict, district, province, postcode)
+	if err != nil {
+		switch {
+		case errors.Is(err, thaiaddress.ErrUnknownProvince),
+			errors.Is(err, thaiaddress.ErrUnknownDistrict),
+			errors.Is(err, thaiaddress.ErrUnknownSubdistrict):
+			// The dataset does not list every district and subdistrict, so only the province must be known
+			p, ok := d.directory.Province(province)
+			if !ok {
+				return nil, errs.ValidationErrors{"province": {"unknown_province"}}
+			}
+			return &entity.AdminArea{
+				SubdistrictTH: strings.TrimSpace(subdistrict),
+				DistrictTH:    strings.TrimSpace(district),
+				ProvinceTH:    p.TH,
+				ProvinceEN:    p.EN,
+				Postcode:      strings.TrimSpace(postcode),
+				Unlisted:      true,
+			}, nil
+		case errors.Is(err, thaiaddress.ErrPostcodeMismatch):
+			return nil, errs.ValidationErrors{"postcode": {"postcode_mismatch"}}
+		}
+		return nil, err
+	}
+	result := toAdminArea(*area)
+	return &result, nil
+}
+
+func (d *thaiAddressDirectory) Search(query string, limit int) []entity.AdminArea {
+	return toAdminAreas(d.directory.Search(query, limit))
+}
+
+func (d *thaiAddressDirectory) Provinces() []entity.AdminName {
+	return toAdminNames(d.directory.Provinces())
+}
+
+func (d *thaiAddressDirectory) Districts(province string) []entity.AdminName {
+	return toAdminNames(d.directory.Districts(province))
+}
+
+func (d *thaiAddressDirectory) Subdistricts(province, district string) []entity.AdminArea {
+	return toAdminAreas(d.directory.Subdistricts(province, district))
+}
+
+func (d *thaiAddressDirectory) ByPostcode(postcode string) []entity.AdminArea {
+	return toAdminAreas(d.directory.ByPostcode(postcode))
+}
+
+func toAdminArea(a thaiaddress.Area) entity.AdminArea {
+	return entity.AdminArea{
+		SubdistrictTH: a.SubdistrictTH,
+		SubdistrictEN: a.SubdistrictEN,
+		DistrictTH:    a.DistrictTH,
+		DistrictEN:    a.DistrictEN,
+		ProvinceTH:    a.ProvinceTH,
+		ProvinceEN:    a.ProvinceEN,
+		Postcode:      a.Postcode,
+		Latitude:      a.Latitude,
+		Longitude:     a.Longitude,
+	}
+}
+
+func toAdminAreas(areas []thaiaddress.Area) []entity.AdminArea {
+	result := make([]entity.AdminArea, len(areas))
+	for i, a := range areas {
+		result[i] = toAdminArea(a)
+	}
+	return result
+}
+
+func toAdminNames(names []thaiaddress.Name) []entity.AdminName {
+	result := make([]entity.AdminName, len(names))
+	for i, n := range names {
+		result[i] = entity.AdminName{TH: n.TH, EN: n.EN}
+	}
+	return result
+}
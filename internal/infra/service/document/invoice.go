@@ -0,0 +1,174 @@
+package document
+
+import (
+	"fmt"
+	"strconv"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/pdf"
+)
+
+// Invoice table columns: sequence, service date, description and amount
+const (
+	colNoWidth     = 28.0
+	colDateWidth   = 78.0
+	colAmountWidth = 90.0
+	tableRowPad    = 5.0
+)
+
+func (r *pdfRenderer) RenderInvoice(d service.InvoiceDocument) ([]byte, error) {
+	inv := d.Invoice
+	titleTH, titleEN := "ใบแจ้งหนี้ / ใบกำกับภาษี", "Invoice / Tax Invoice"
+	if inv.IsCreditNote() {
+		titleTH, titleEN = "ใบลดหนี้ / ใบกำกับภาษี", "Credit Note / Tax Invoice"
+	}
+	reference := inv.Number
+	if reference == "" {
+		reference = "DRAFT"
+	}
+
+	s := r.newSheet(titleEN + " " + reference)
+	width := s.contentWidth()
+	page := s.addPage()
+	y, err := s.header(page, d.Seller, titleTH, titleEN)
+	if err != nil {
+		return nil, err
+	}
+
+	// Customer on the left, document details on the right
+	metaX := margin + 310
+	customerBottom := s.partyBox(page, margin, y, 300, s.label("ลูกค้า", "Customer"), d.Customer, true)
+
+	number := inv.Number
+	if number == "" {
+		number = s.label("ฉบับร่าง", "Draft")
+	}
+	meta := [][2]string{{s.label("เลขที่", "No."), number}}
+	if inv.IssueDate != nil {
+		meta = append(meta, [2]string{s.label("วันที่", "Date"), s.formatDate(*inv.IssueDate)})
+	}
+	if inv.IsCreditNote() {
+		meta = append(meta, [2]string{s.label("อ้างถึง", "Invoice"), d.CreditedNumber})
+		if d.CreditedIssueDate != nil {
+			meta = append(meta, [2]string{s.label("ลงวันที่", "Dated"), s.formatDate(*d.CreditedIssueDate)})
+		}
+	} else {
+		if inv.DueDate != nil {
+			meta = append(meta, [2]string{s.label("ครบกำหนด", "Due"), s.formatDate(*inv.DueDate)})
+		}
+		meta = append(meta,
+			[2]string{s.label("เครดิต", "Terms"), s.label(strconv.Itoa(inv.CreditTermDays)+" วัน", strconv.Itoa(inv.CreditTermDays)+" days")},
+			[2]string{s.label("รอบบิล", "Period"), s.formatDate(inv.PeriodFrom) + " - " + s.formatDate(inv.PeriodTo)},
+		)
+	}
+	if inv.Status == entity.InvoiceStatusVoid {
+		meta = append(meta, [2]string{s.label("สถานะ", "Status"), s.label("ยกเลิก", "Void")})
+	}
+	metaBottom := s.fields(page, metaX+8, y+16, 75, 205-16, meta)
+	metaBottom = max(metaBottom+2, customerBottom)
+	page.Rect(metaX, y, 205, metaBottom-y, 0.5)
+	y = metaBottom + 14
+
+	if inv.IsCreditNote() && inv.Reason != "" {
+		y = s.fields(page, margin, y, 90, width, [][2]string{{s.label("เหตุผล", "Reason"), inv.Reason}}) + 4
+	}
+
+	// Lines, continuing on new pages with the column headings repeated
+	y = s.invoiceTableHeader(page, y)
+	descW := width - colNoWidth - colDateWidth - colAmountWidth
+	for _, line := range inv.Lines {
+		desc := s.doc.Wrap(s.regular, 9, descW-2*tableRowPad, line.Description)
+		rowH := float64(len(desc))*12 + 2*tableRowPad
+		if y+rowH > s.bottom() {
+			page = s.addPage()
+			y = s.invoiceTableHeader(page, margin)
+		}
+		ty := y + tableRowPad + 8
+		page.TextRight(margin+colNoWidth-tableRowPad, ty, s.regular, 9, strconv.Itoa(line.Sequence))
+		if line.ServiceDate != nil {
+			page.Text(margin+colNoWidth+tableRowPad, ty, s.regular, 9, s.formatDate(*line.ServiceDate))
+		}
+		for i, l := range desc {
+			page.Text(margin+colNoWidth+colDateWidth+tableRowPad, ty+float64(i)*12, s.regular, 9, l)
+		}
+		page.TextRight(margin+width-tableRowPad, ty, s.regular, 9, formatMoney(line.Amount))
+		y += rowH
+		page.Line(margin, y, margin+width, y, 0.25)
+	}
+
+	// Totals, amount in words, barcode and signatures stay together on the last page
+	const closingHeight = 250.0
+	if y+closingHeight > s.bottom() {
+		page = s.addPage()
+		y = margin
+	}
+	y += 10
+
+	var totals [][2]string
+	if inv.IsCreditNote() {
+		totals = append(totals,
+			[2]string{s.label("มูลค่าตามใบกำกับเดิม", "Original value"), formatMoney(d.CreditedSubtotal)},
+			[2]string{s.label("มูลค่าที่ถูกต้อง", "Correct value"), formatMoney(d.CreditedSubtotal - inv.Subtotal)},
+			[2]string{s.label("ผลต่าง", "Difference"), formatMoney(inv.Subtotal)},
+		)
+	} else {
+		totals = append(totals, [2]string{s.label("รวมเป็นเงิน", "Subtotal"), formatMoney(inv.Subtotal)})
+	}
+	totals = append(totals,
+		[2]string{s.label("ภาษีมูลค่าเพิ่ม", "VAT") + " " + formatRate(inv.VATRate), formatMoney(inv.VATAmount)},
+		[2]string{s.label("จำนวนเงินรวมทั้งสิ้น", "Total"), formatMoney(inv.Total)},
+	)
+	if inv.WithholdingRate > 0 {
+		totals = append(totals,
+			[2]string{s.label("หักภาษี ณ ที่จ่าย", "Withholding tax") + " " + formatRate(inv.WithholdingRate), formatMoney(-inv.WithholdingTax)},
+			[2]string{s.label("ยอดชำระสุทธิ", "Amount due"), formatMoney(inv.AmountDue)},
+		)
+	}
+
+	totalsX := margin + width - 250
+	ty := y + 4
+	for i, row := range totals {
+		font := s.regular
+		if i == len(totals)-1 {
+			font = s.bold
+		}
+		page.Text(totalsX, ty, font, 9, row[0])
+		page.TextRight(margin+width-tableRowPad, ty, font, 9, row[1])
+		ty += 15
+	}
+	page.TextRight(margin+width-tableRowPad, ty, s.regular, smallSize, s.label("สกุลเงิน", "Currency")+" "+inv.Currency)
+
+	if s.thai {
+		page.Text(margin, y+4, s.bold, 9, "("+bahtText(inv.Total)+")")
+	}
+	if inv.Notes != "" {
+		s.fields(page, margin, y+22, 50, totalsX-margin-10, [][2]string{{s.label("หมายเหตุ", "Notes"), inv.Notes}})
+	}
+	y = ty + 20
+
+	if inv.Number != "" {
+		if err := s.code128(page, margin, y, 180, 36, inv.Number); err != nil {
+			return nil, fmt.Errorf("barcode: %w", err)
+		}
+	}
+	signW := 150.0
+	s.signature(page, margin+width-2*signW-10, y, signW, s.label("ผู้รับเงิน", "Received by"))
+	s.signature(page, margin+width-signW, y, signW, s.label("ผู้มีอำนาจลงนาม", "Authorized signature"))
+
+	s.footers(reference)
+	return s.doc.Bytes()
+}
+
+// invoiceTableHeader draws the shaded column headings of the invoice lines and returns the y below them
+func (s *sheet) invoiceTableHeader(page *pdf.Page, y float64) float64 {
+	width := s.contentWidth()
+	const h = 20.0
+	page.FillRect(margin, y, width, h, 0.9)
+	ty := y + 13
+	page.Text(margin+tableRowPad, ty, s.bold, 9, "#")
+	page.Text(margin+colNoWidth+tableRowPad, ty, s.bold, 9, s.label("วันที่", "Date"))
+	page.Text(margin+colNoWidth+colDateWidth+tableRowPad, ty, s.bold, 9, s.label("รายการ", "Description"))
+	page.TextRight(margin+width-tableRowPad, ty, s.bold, 9, s.label("จำนวนเงิน", "Amount"))
+	return y + h
+}
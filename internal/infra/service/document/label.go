@@ -0,0 +1,217 @@
+package document
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/barcode"
+	"tms-core-service/pkg/pdf"
+	"tms-core-service/pkg/timeutil"
+)
+
+// Labels are laid out in millimetres from the top left corner, once for both ZPL and PDF
+const (
+	labelMargin      = 3.0
+	labelLineSpacing = 1.2 // line pitch as a multiple of the text height
+	maxModuleWidth   = 0.5 // widest barcode bar unit; wider bars only make short codes harder to scan
+	mmToPt           = 72 / 25.4
+)
+
+type textAlign int
+
+const (
+	alignLeft textAlign = iota
+	alignCenter
+	alignRight
+)
+
+// labelCanvas is a label being drawn in ZPL or PDF
+type labelCanvas interface {
+	// text draws s with its top at y in a column w wide, wrapping to at most lines lines of the given height
+	text(x, y, w, size float64, bold bool, align textAlign, lines int, s string)
+	hline(x, y, w float64)
+	box(x, y, w, h float64)
+	// barcode draws a Code 128 symbol, or a GS1-128 symbol of element strings, centred in w
+	barcode(x, y, w, h float64, data string, gs1 bool) error
+}
+
+type labelRenderer struct {
+	fonts *pdfRenderer
+}
+
+// NewLabelRenderer creates a shipping label renderer for ZPL printers and PDF. fontPath and boldFontPath
+// are TrueType fonts with Thai glyphs for PDF labels; ZPL labels use the printer font named in the template.
+func NewLabelRenderer(fontPath, boldFontPath string) (service.LabelRenderer, error) {
+	fonts, err := loadFonts(fontPath, boldFontPath)
+	if err != nil {
+		return nil, err
+	}
+	return &labelRenderer{fonts: fonts}, nil
+}
+
+func (r *labelRenderer) RenderPDF(labels []service.ShippingLabel) ([]byte, error) {
+	s := r.fonts.newSheet("Shipping labels")
+	for _, l := range labels {
+		w, h := labelDimensions(l.Template.Size)
+		c := &pdfLabelCanvas{s: s, page: s.doc.AddPage(w*mmToPt, h*mmToPt)}
+		if err := drawLabel(c, l, s.thai); err != nil {
+			return nil, fmt.Errorf("label %s piece %d: %w", l.TrackingNumber, l.Piece, err)
+		}
+	}
+	return s.doc.Bytes()
+}
+
+// labelDimensions returns the width and height of a label in millimetres
+func labelDimensions(size entity.LabelSize) (float64, float64) {
+	if size == entity.LabelSizeA6 {
+		return 105, 148
+	}
+	return 101.6, 152.4
+}
+
+// drawLabel lays out one label: carrier and piece count, shipper, consignee, sort code and route,
+// the tracking barcode, the SSCC and the template footer. thai reports whether the canvas can print Thai.
+func drawLabel(c labelCanvas, l service.ShippingLabel, thai bool) error {
+	w, h := labelDimensions(l.Template.Size)
+	m := labelMargin
+	cw := w - 2*m
+	label := func(th, en string) string {
+		if !thai {
+			return en
+		}
+		return th + " " + en
+	}
+
+	c.text(m, m, cw*0.7, 4, true, alignLeft, 1, l.Carrier)
+	c.text(m+cw*0.7, m, cw*0.3, 4, true, alignRight, 1, fmt.Sprintf("%d/%d", l.Piece, l.Pieces))
+	c.hline(m, 9, cw)
+
+	c.text(m, 10.5, cw, 2.5, false, alignLeft, 1, label("ผู้ส่ง", "FROM"))
+	c.text(m, 13.5, cw, 3, true, alignLeft, 1, l.Shipper.Name)
+	c.text(m, 17.5, cw, 2.5, false, alignLeft, 2, l.Shipper.Address)
+	c.text(m, 23.5, cw, 2.5, false, alignLeft, 1, l.Shipper.Phone)
+	c.hline(m, 28, cw)
+
+	c.text(m, 29.5, cw, 2.5, false, alignLeft, 1, label("ผู้รับ", "TO"))
+	c.text(m, 33, cw, 5, true, alignLeft, 2, l.Consignee.Name)
+	c.text(m, 45.5, cw, 3.5, false, alignLeft, 3, l.Consignee.Address)
+	c.text(m, 58.5, cw, 3.5, true, alignLeft, 1, strings.TrimSpace(l.Consignee.Contact+" "+l.Consignee.Phone))
+	c.hline(m, 64, cw)
+
+	// Sort code boxed on the left, routing and handling details on the right
+	half := cw / 2
+	c.box(m, 65.5, half-1, 23)
+	c.text(m+1.5, 67, half-4, 2.5, false, alignLeft, 1, label("คัดแยก", "SORT"))
+	c.text(m+1.5, 71, half-4, 12, true, alignCenter, 1, l.SortCode)
+
+	rx, rw := m+half+1.5, half-1.5
+	details := [][2]string{
+		{label("เส้นทาง", "Route"), l.Route},
+		{label("น้ำหนัก", "Weight"), strconv.FormatFloat(l.WeightKg, 'f', -1, 64) + " kg"},
+		{label("ส่งภายใน", "Deliver by"), shortDate(l.DeliverBy)},
+		{label("อ้างอิง", "Ref."), l.Reference},
+	}
+	y := 66.5
+	for _, d := range details {
+		if d[1] == "" {
+			continue
+		}
+		c.text(rx, y, rw, 2.5, false, alignLeft, 1, d[0])
+		c.text(rx, y+2.8, rw, 3, true, alignLeft, 1, d[1])
+		y += 6
+	}
+	c.hline(m, 90, cw)
+
+	if err := c.barcode(m, 92, cw, 16, l.TrackingNumber, false); err != nil {
+		return fmt.Errorf("tracking barcode: %w", err)
+	}
+	c.text(m, 109, cw, 3.5, true, alignCenter, 1, l.TrackingNumber)
+
+	if l.SSCC != "" {
+		if err := c.barcode(m, 116, cw, 16, "00"+l.SSCC, true); err != nil {
+			return fmt.Errorf("sscc barcode: %w", err)
+		}
+		c.text(m, 133, cw, 3, false, alignCenter, 1, "(00) "+l.SSCC)
+	}
+
+	c.text(m, h-m-4, cw, 2.5, false, alignLeft, 1, l.Template.Footer)
+	return nil
+}
+
+// shortDate formats a date as day/month/Buddhist-era year in Thai local time, e.g. 18/10/2569
+func shortDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	local := t.In(timeutil.Thailand)
+	return fmt.Sprintf("%02d/%02d/%d", local.Day(), local.Month(), local.Year()+buddhistEraOffset)
+}
+
+// barcodeModules encodes a Code 128 or GS1-128 symbol
+func barcodeModules(data string, gs1 bool) ([]bool, error) {
+	if gs1 {
+		return barcode.GS1128(data)
+	}
+	return barcode.Code128(data)
+}
+
+type pdfLabelCanvas struct {
+	s    *sheet
+	page *pdf.Page
+}
+
+func (c *pdfLabelCanvas) text(x, y, w, size float64, bold bool, align textAlign, lines int, s string) {
+	font := c.s.regular
+	if bold {
+		font = c.s.bold
+	}
+	pt := size * mmToPt
+	wrapped := c.s.doc.Wrap(font, pt, w*mmToPt, s)
+	if len(wrapped) > lines {
+		wrapped = wrapped[:lines]
+	}
+	for i, line := range wrapped {
+		// Text sits on its baseline, about four fifths of the text height below the top
+		baseline := (y+float64(i)*size*labelLineSpacing)*mmToPt + pt*0.8
+		switch align {
+		case alignCenter:
+			c.page.TextCenter((x+w/2)*mmToPt, baseline, font, pt, line)
+		case alignRight:
+			c.page.TextRight((x+w)*mmToPt, baseline, font, pt, line)
+		default:
+			c.page.Text(x*mmToPt, baseline, font, pt, line)
+		}
+	}
+}
+
+func (c *pdfLabelCanvas) hline(x, y, w float64) {
+	c.page.Line(x*mmToPt, y*mmToPt, (x+w)*mmToPt, y*mmToPt, 0.75)
+}
+
+func (c *pdfLabelCanvas) box(x, y, w, h float64) {
+	c.page.Rect(x*mmToPt, y*mmToPt, w*mmToPt, h*mmToPt, 0.75)
+}
+
+func (c *pdfLabelCanvas) barcode(x, y, w, h float64, data string, gs1 bool) error {
+	modules, err := barcodeModules(data, gs1)
+	if err != nil {
+		return err
+	}
+	unit := min(w/float64(len(modules)), maxModuleWidth)
+	left := x + (w-unit*float64(len(modules)))/2
+	for start := 0; start < len(modules); {
+		end := start
+		for end < len(modules) && modules[end] == modules[start] {
+			end++
+		}
+		if modules[start] {
+			c.page.FillRect((left+float64(start)*unit)*mmToPt, y*mmToPt, float64(end-start)*unit*mmToPt, h*mmToPt, 0)
+		}
+		start = end
+	}
+	return nil
+}
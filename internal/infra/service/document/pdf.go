@@ -0,0 +1,437 @@
+package document
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/barcode"
+	"tms-core-service/pkg/pdf"
+)
+
+const (
+	margin      = 40.0
+	bodySize    = 10.0
+	smallSize   = 8.0
+	labelWidth  = 130.0
+	lineHeight  = 16.0
+	photoGap    = 10.0
+	photoHeight = 220.0
+	footerSpace = 40.0 // kept free at the bottom of every page for the footer
+)
+
+// Default Thai fonts, used when no font files are configured. The Sarabun TTFs (SIL Open Font License)
+// are built into the binary from the fonts directory; see fonts/README.md.
+const (
+	defaultRegularFont = "fonts/Sarabun-Regular.ttf"
+	defaultBoldFont    = "fonts/Sarabun-Bold.ttf"
+)
+
+//go:embed fonts
+var defaultFonts embed.FS
+
+type pdfRenderer struct {
+	regular *pdf.TrueTypeFont
+	bold    *pdf.TrueTypeFont
+	logo    []byte
+}
+
+// NewPDFRenderer creates a document renderer producing A4 PDFs with times in Thai local time and
+// Buddhist-era dates. fontPath and boldFontPath are TrueType fonts with Thai glyphs (e.g. Sarabun);
+// the bold font defaults to the regular one. Without font files the built-in Sarabun is used; a build
+// without it falls back to the standard Helvetica, which cannot show Thai, so labels are printed in
+// English only. logoPath is an optional JPEG or PNG
+// printed in the header of invoices, waybills and delivery notes.
+func NewPDFRenderer(fontPath, boldFontPath, logoPath string) (service.DocumentRenderer, error) {
+	r, err := loadFonts(fontPath, boldFontPath)
+	if err != nil {
+		return nil, err
+	}
+	if logoPath != "" {
+		if r.logo, err = os.ReadFile(logoPath); err != nil {
+			return nil, fmt.Errorf("read logo: %w", err)
+		}
+		if _, err := pdf.NewImage(r.logo); err != nil {
+			return nil, fmt.Errorf("logo %s: %w", logoPath, err)
+		}
+	}
+	return r, nil
+}
+
+// loadFonts loads the regular and bold fonts; the bold font defaults to the regular one, and both
+// default to the built-in fonts
+func loadFonts(fontPath, boldFontPath string) (*pdfRenderer, error) {
+	if fontPath == "" && boldFontPath == "" {
+		return loadDefaultFonts()
+	}
+	r := &pdfRenderer{}
+	var err error
+	if fontPath != "" {
+		if r.regular, err = loadFont(fontPath); err != nil {
+			return nil, err
+		}
+		r.bold = r.regular
+	}
+	if boldFontPath != "" {
+		if r.regular == nil {
+			return nil, fmt.Errorf("bold font %s given without a regular font", boldFontPath)
+		}
+		if r.bold, err = loadFont(boldFontPath); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// loadDefaultFonts loads the built-in fonts; without them the renderer uses Helvetica and says so
+func loadDefaultFonts() (*pdfRenderer, error) {
+	regular, err := loadDefaultFont(defaultRegularFont)
+	if err != nil {
+		return nil, err
+	}
+	if regular == nil {
+		log.Printf("[WARN] documents: the build has no built-in Thai font and none is configured; printing English labels only (run make fonts)")
+		return &pdfRenderer{}, nil
+	}
+	bold, err := loadDefaultFont(defaultBoldFont)
+	if err != nil {
+		return nil, err
+	}
+	if bold == nil {
+		bold = regular
+	}
+	return &pdfRenderer{regular: regular, bold: bold}, nil
+}
+
+// loadDefaultFont parses a built-in font, or returns nil when the build does not include it
+func loadDefaultFont(name string) (*pdf.TrueTypeFont, error) {
+	data, err := defaultFonts.ReadFile(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read font: %w", err)
+	}
+	font, err := pdf.ParseTrueType(data)
+	if err != nil {
+		return nil, fmt.Errorf("font %s: %w", name, err)
+	}
+	return font, nil
+}
+
+func loadFont(path string) (*pdf.TrueTypeFont, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read font: %w", err)
+	}
+	font, err := pdf.ParseTrueType(data)
+	if err != nil {
+		return nil, fmt.Errorf("font %s: %w", path, err)
+	}
+	return font, nil
+}
+
+// sheet is one document being laid out with the renderer's fonts
+type sheet struct {
+	doc     *pdf.Document
+	pages   []*pdf.Page
+	regular pdf.Font
+	bold    pdf.Font
+	thai    bool // the fonts have Thai glyphs
+	logo    []byte
+}
+
+func (r *pdfRenderer) newSheet(title string) *sheet {
+	s := &sheet{doc: pdf.New(), regular: pdf.Helvetica, bold: pdf.HelveticaBold, logo: r.logo}
+	s.doc.SetTitle(title)
+	if r.regular != nil {
+		s.regular = s.doc.AddFont(r.regular)
+		s.bold = s.regular
+		if r.bold != r.regular {
+			s.bold = s.doc.AddFont(r.bold)
+		}
+		s.thai = true
+	}
+	return s
+}
+
+func (s *sheet) addPage() *pdf.Page {
+	page := s.doc.AddPage(pdf.A4Width, pdf.A4Height)
+	s.pages = append(s.pages, page)
+	return page
+}
+
+// label returns a bilingual "ไทย / English" label, or the English part when Thai cannot be shown
+func (s *sheet) label(th, en string) string {
+	if !s.thai {
+		return en
+	}
+	return th + " / " + en
+}
+
+// contentWidth is the width between the page margins
+func (s *sheet) contentWidth() float64 {
+	return pdf.A4Width - 2*margin
+}
+
+// bottom is the lowest y content may reach above the footer
+func (s *sheet) bottom() float64 {
+	return pdf.A4Height - margin - footerSpace
+}
+
+// header draws the logo, the seller's details and the document title, and returns the y below them
+func (s *sheet) header(page *pdf.Page, seller service.Party, titleTH, titleEN string) (float64, error) {
+	x, y := margin, margin
+	bottom := y
+	if s.logo != nil {
+		img, err := pdf.NewImage(s.logo)
+		if err != nil {
+			return 0, fmt.Errorf("logo: %w", err)
+		}
+		w, h := img.Fit(110, 50)
+		page.Image(img, x, y, w, h)
+		x += w + 12
+		bottom = y + h
+	}
+
+	ty := y + 12
+	page.Text(x, ty, s.bold, 12, seller.Name)
+	for _, line := range s.doc.Wrap(s.regular, smallSize, 260, seller.Address) {
+		ty += 11
+		page.Text(x, ty, s.regular, smallSize, line)
+	}
+	if seller.TaxID != "" {
+		ty += 11
+		page.Text(x, ty, s.regular, smallSize, s.taxLine(seller))
+	}
+	if seller.Phone != "" {
+		ty += 11
+		page.Text(x, ty, s.regular, smallSize, s.label("โทร", "Tel.")+" "+seller.Phone)
+	}
+	bottom = max(bottom, ty+4)
+
+	right := margin + s.contentWidth()
+	if s.thai {
+		page.TextRight(right, y+14, s.bold, 16, titleTH)
+		page.TextRight(right, y+30, s.regular, 11, titleEN)
+	} else {
+		page.TextRight(right, y+14, s.bold, 16, titleEN)
+	}
+	bottom = max(bottom, y+36)
+
+	bottom += 8
+	page.Line(margin, bottom, right, bottom, 1)
+	return bottom + 16, nil
+}
+
+// taxLine formats a taxpayer ID with its head office or branch, as required on tax invoices
+func (s *sheet) taxLine(p service.Party) string {
+	office := s.label("สำนักงานใหญ่", "Head office")
+	if p.BranchCode != "" && p.BranchCode != "00000" {
+		office = s.label("สาขา", "Branch") + " " + p.BranchCode
+	}
+	return s.label("เลขประจำตัวผู้เสียภาษี", "Tax ID") + " " + p.TaxID + "  " + office
+}
+
+// partyBox draws a framed block with a heading and a party's name, address and contact, and returns its bottom
+func (s *sheet) partyBox(page *pdf.Page, x, y, w float64, heading string, p service.Party, withTax bool) float64 {
+	const pad = 8.0
+	ty := y + pad + 8
+	page.Text(x+pad, ty, s.bold, smallSize, heading)
+	ty += 14
+	for _, line := range s.doc.Wrap(s.bold, bodySize, w-2*pad, p.Name) {
+		page.Text(x+pad, ty, s.bold, bodySize, line)
+		ty += 13
+	}
+	for _, line := range s.doc.Wrap(s.regular, 9, w-2*pad, p.Address) {
+		page.Text(x+pad, ty, s.regular, 9, line)
+		ty += 12
+	}
+	if withTax && p.TaxID != "" {
+		for _, line := range s.doc.Wrap(s.regular, 9, w-2*pad, s.taxLine(p)) {
+			page.Text(x+pad, ty, s.regular, 9, line)
+			ty += 12
+		}
+	}
+	contact := strings.TrimSpace(p.Contact + " " + p.Phone)
+	if contact != "" {
+		page.Text(x+pad, ty, s.regular, 9, s.label("ติดต่อ", "Contact")+" "+contact)
+		ty += 12
+	}
+	h := ty - y + pad - 6
+	page.Rect(x, y, w, h, 0.5)
+	return y + h
+}
+
+// fields draws label/value rows and returns the y below them; rows without a value are skipped
+func (s *sheet) fields(page *pdf.Page, x, y, labelW, w float64, rows [][2]string) float64 {
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		page.Text(x, y, s.bold, 9, row[0])
+		for _, line := range s.doc.Wrap(s.regular, 9, w-labelW, row[1]) {
+			page.Text(x+labelW, y, s.regular, 9, line)
+			y += 13
+		}
+	}
+	return y
+}
+
+// signature draws a signing line with its caption and a date line
+func (s *sheet) signature(page *pdf.Page, x, y, w float64, caption string) {
+	page.Line(x+10, y+30, x+w-10, y+30, 0.5)
+	page.TextCenter(x+w/2, y+42, s.regular, smallSize, caption)
+	page.TextCenter(x+w/2, y+56, s.regular, smallSize, s.label("วันที่", "Date")+" ........../........../..........")
+}
+
+// footers numbers every page and repeats the document reference
+func (s *sheet) footers(reference string) {
+	for i, page := range s.pages {
+		y := pdf.A4Height - margin + 10
+		page.Line(margin, y-12, margin+s.contentWidth(), y-12, 0.5)
+		page.Text(margin, y, s.regular, smallSize, reference)
+		page.TextRight(margin+s.contentWidth(), y, s.regular, smallSize,
+			fmt.Sprintf("%s %d/%d", s.label("หน้า", "Page"), i+1, len(s.pages)))
+	}
+}
+
+// code128 draws a Code 128 barcode filling w×h at (x, y) with the text beneath it
+func (s *sheet) code128(page *pdf.Page, x, y, w, h float64, text string) error {
+	modules, err := barcode.Code128(text)
+	if err != nil {
+		return err
+	}
+	unit := w / float64(len(modules))
+	for start := 0; start < len(modules); {
+		end := start
+		for end < len(modules) && modules[end] == modules[start] {
+			end++
+		}
+		if modules[start] {
+			page.FillRect(x+float64(start)*unit, y, float64(end-start)*unit, h, 0)
+		}
+		start = end
+	}
+	page.TextCenter(x+w/2, y+h+11, s.regular, 9, text)
+	return nil
+}
+
+// qrCode draws a QR code filling a size×size square at (x, y)
+func (s *sheet) qrCode(page *pdf.Page, x, y, size float64, data string) error {
+	code, err := barcode.QRCode([]byte(data))
+	if err != nil {
+		return err
+	}
+	unit := size / float64(code.Size())
+	for row := 0; row < code.Size(); row++ {
+		for col := 0; col < code.Size(); {
+			end := col
+			for end < code.Size() && code.Dark(end, row) {
+				end++
+			}
+			if end > col {
+				page.FillRect(x+float64(col)*unit, y+float64(row)*unit, float64(end-col)*unit, unit, 0)
+				col = end
+			} else {
+				col++
+			}
+		}
+	}
+	return nil
+}
+
+func (r *pdfRenderer) RenderProofOfDelivery(d service.DeliveryDocument) ([]byte, error) {
+	s := r.newSheet("Proof of Delivery " + d.DocumentNumber)
+	page := s.addPage()
+	doc := s.doc
+	width := s.contentWidth()
+
+	y := margin + 20
+	if s.thai {
+		page.Text(margin, y, s.bold, 18, "ใบรับรองการส่งมอบ / Proof of Delivery")
+	} else {
+		page.Text(margin, y, s.bold, 18, "Proof of Delivery")
+	}
+	page.TextRight(margin+width, y, s.regular, bodySize, s.formatDateTime(d.CapturedAt))
+	y += 10
+	page.Line(margin, y, margin+width, y, 1)
+	y += 22
+
+	position := fmt.Sprintf("%.6f, %.6f", d.Latitude, d.Longitude)
+	if d.AccuracyM != nil {
+		position += fmt.Sprintf(" (±%.0f m)", *d.AccuracyM)
+	}
+	distance := "Location has no coordinates"
+	if d.DistanceM != nil {
+		distance = fmt.Sprintf("%.0f m", *d.DistanceM)
+	}
+
+	rows := [][2]string{
+		{"Document no.", d.DocumentNumber},
+		{"Shipment", d.ShipmentReference},
+		{"Trip", fmt.Sprintf("%s, stop %d", d.TripNumber, d.StopSequence)},
+		{"Delivered to", d.LocationName},
+		{"Address", d.Address},
+		{"Driver", d.DriverName},
+		{"Vehicle", d.VehiclePlate},
+		{"Received by", d.RecipientName},
+		{"Device position", position},
+		{"Distance from location", distance},
+		{"Notes", d.Notes},
+	}
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		page.Text(margin, y, s.bold, bodySize, row[0])
+		for _, line := range doc.Wrap(s.regular, bodySize, width-labelWidth, row[1]) {
+			page.Text(margin+labelWidth, y, s.regular, bodySize, line)
+			y += lineHeight
+		}
+	}
+
+	y += 10
+	page.Text(margin, y, s.bold, 12, "Signature")
+	y += 8
+	sig, err := pdf.NewImage(d.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+	boxW, boxH := 240.0, 100.0
+	page.Rect(margin, y, boxW, boxH, 0.5)
+	w, h := sig.Fit(boxW-10, boxH-10)
+	page.Image(sig, margin+(boxW-w)/2, y+(boxH-h)/2, w, h)
+	y += boxH + 24
+
+	if len(d.Photos) > 0 {
+		page.Text(margin, y, s.bold, 12, "Photos")
+		y += 8
+	}
+
+	// Photos are laid out two per row, continuing on new pages as needed
+	colW := (width - photoGap) / 2
+	for i, data := range d.Photos {
+		img, err := pdf.NewImage(data)
+		if err != nil {
+			return nil, fmt.Errorf("photo %d: %w", i+1, err)
+		}
+		if i%2 == 0 && i > 0 {
+			y += photoHeight + photoGap
+		}
+		if y+photoHeight > page.Height()-margin {
+			page = s.addPage()
+			y = margin
+		}
+		x := margin + float64(i%2)*(colW+photoGap)
+		w, h := img.Fit(colW, photoHeight)
+		page.Image(img, x+(colW-w)/2, y, w, h)
+	}
+
+	return doc.Bytes()
+}
@@ -0,0 +1,288 @@
+package document
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/pdf"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// Golden files are rendered with the standard fonts and with a test font covering Thai, so they do not
+// change with the built-in font but still cover the bilingual layout a Thai font prints
+var goldenRenderer = &pdfRenderer{}
+
+// testThaiFont builds a TrueType font with blank glyphs for printable ASCII and the Thai block. Its
+// advances follow Sarabun's shape closely enough for wrapping: Thai vowel and tone marks take no width.
+func testThaiFont(t *testing.T) *pdf.TrueTypeFont {
+	t.Helper()
+	type group struct{ first, last rune }
+	groups := []group{{0x20, 0x7e}, {0x0e01, 0x0e5b}}
+	advances := []uint16{500} // .notdef
+	for _, g := range groups {
+		for r := g.first; r <= g.last; r++ {
+			switch {
+			case r == ' ':
+				advances = append(advances, 250)
+			case r == 0x0e31, r >= 0x0e34 && r <= 0x0e3a, r >= 0x0e47 && r <= 0x0e4e:
+				advances = append(advances, 0)
+			case r >= 0x0e01:
+				advances = append(advances, 520)
+			default:
+				advances = append(advances, 550)
+			}
+		}
+	}
+	numGlyphs := uint16(len(advances))
+
+	be := func(values ...any) []byte {
+		var b bytes.Buffer
+		for _, v := range values {
+			if err := binary.Write(&b, binary.BigEndian, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return b.Bytes()
+	}
+
+	cmap := be(uint16(0), uint16(1), uint16(3), uint16(10), uint32(12),
+		uint16(12), uint16(0), uint32(16+12*len(groups)), uint32(0), uint32(len(groups)))
+	glyph := uint32(1)
+	for _, g := range groups {
+		cmap = append(cmap, be(uint32(g.first), uint32(g.last), glyph)...)
+		glyph += uint32(g.last - g.first + 1)
+	}
+	var hmtx []byte
+	for _, a := range advances {
+		hmtx = append(hmtx, be(a, int16(0))...)
+	}
+	name := "TMSTestThai"
+
+	tables := map[string][]byte{
+		"cmap": cmap,
+		"glyf": {},
+		"head": be(uint32(0x00010000), uint32(0x00010000), uint32(0), uint32(0x5f0f3cf5), uint16(0x000b), uint16(1000),
+			int64(0), int64(0), int16(0), int16(-250), int16(1000), int16(900), uint16(0), uint16(8), int16(2), int16(0), int16(0)),
+		"hhea": be(uint32(0x00010000), int16(900), int16(-250), int16(0), uint16(550), int16(0), int16(0), int16(550),
+			int16(1), int16(0), int16(0), [4]int16{}, int16(0), numGlyphs),
+		"hmtx": hmtx,
+		"loca": make([]byte, 2*(int(numGlyphs)+1)), // every glyph is empty
+		"maxp": be(uint32(0x00010000), numGlyphs, [6]uint16{}, uint16(1), [6]uint16{}),
+		"name": append(be(uint16(0), uint16(1), uint16(18), uint16(1), uint16(0), uint16(0), uint16(6), uint16(len(name)), uint16(0)), name...),
+		"post": be(uint32(0x00030000), int32(0), int16(-100), int16(50), [5]uint32{}),
+	}
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	data := be(uint32(0x00010000), uint16(len(tags)), uint16(128), uint16(3), uint16(16*len(tags)-128))
+	offset := len(data) + 16*len(tags)
+	var body []byte
+	for _, tag := range tags {
+		table := tables[tag]
+		var sum uint32
+		padded := append(table, make([]byte, (4-len(table)%4)%4)...)
+		for i := 0; i < len(padded); i += 4 {
+			sum += binary.BigEndian.Uint32(padded[i:])
+		}
+		data = append(data, tag...)
+		data = append(data, be(sum, uint32(offset+len(body)), uint32(len(table)))...)
+		body = append(body, padded...)
+	}
+	font, err := pdf.ParseTrueType(append(data, body...))
+	if err != nil {
+		t.Fatalf("test font: %v", err)
+	}
+	return font
+}
+
+var (
+	seller = service.Party{
+		Name:       "Siam Freight Co., Ltd.",
+		TaxID:      "0105561234567",
+		BranchCode: "00000",
+		Address:    "99 Rama IV Road, Khlong Toei, Bangkok 10110",
+		Phone:      "02-123-4567",
+	}
+	customer = service.Party{
+		Name:       "Northern Retail Co., Ltd.",
+		TaxID:      "0505559876543",
+		BranchCode: "00002",
+		Address:    "12 Huay Kaew Road, Suthep, Mueang Chiang Mai, Chiang Mai 50200",
+		Contact:    "Somchai Jaidee",
+		Phone:      "053-222-333",
+	}
+	consignee = service.Party{
+		Name:    "Northern Retail - Nimman branch",
+		Address: "45 Nimmanhaemin Road, Suthep, Mueang Chiang Mai, Chiang Mai 50200",
+		Contact: "Malee Srisuk",
+		Phone:   "081-234-5678",
+	}
+)
+
+func ict(month time.Month, day, hour, minute int) *time.Time {
+	t := time.Date(2026, month, day, hour, minute, 0, 0, timeutil.Thailand)
+	return &t
+}
+
+func testInvoice() service.InvoiceDocument {
+	inv := &entity.Invoice{
+		ID:              uuid.MustParse("6f1c2d3e-4b5a-4c6d-8e7f-001122334455"),
+		Number:          "INV2610-000123",
+		Kind:            entity.InvoiceKindInvoice,
+		Status:          entity.InvoiceStatusIssued,
+		Currency:        "THB",
+		CreditTermDays:  30,
+		IssueDate:       ict(10, 31, 0, 0),
+		DueDate:         ict(11, 30, 0, 0),
+		VATRate:         0.07,
+		WithholdingRate: 0.01,
+		Notes:           "Please transfer to Kasikorn Bank 123-4-56789-0",
+	}
+	for i, line := range []struct {
+		description string
+		quantity    float64
+		unitPrice   entity.Money
+	}{
+		{"Transport Bangkok - Chiang Mai, TH2610000041", 1, 725000},
+		{"Transport Bangkok - Lamphun, TH2610000057", 1, 648050},
+		{"Waiting time, TH2610000057", 2.5, 30000},
+	} {
+		inv.AddLine(entity.InvoiceLine{
+			ServiceDate: ict(10, 20+i, 14, 30),
+			Description: line.description,
+			Quantity:    line.quantity,
+			UnitPrice:   line.unitPrice,
+		})
+	}
+	return service.InvoiceDocument{Invoice: inv, Seller: seller, Customer: customer}
+}
+
+func testShipment() service.ShipmentDocument {
+	return service.ShipmentDocument{
+		TrackingNumber: "TH2610000041",
+		Reference:      "PO-88123",
+		TrackingURL:    "https://track.example.com/track/TH2610000041",
+		BookedAt:       *ict(10, 19, 9, 15),
+		Seller:         seller,
+		Customer:       customer,
+		Shipper:        service.Party{Name: "Siam Freight DC Bang Na", Address: "88 Bang Na-Trat Road, Bang Na, Bangkok 10260"},
+		Consignee:      consignee,
+		PickupFrom:     ict(10, 20, 8, 0),
+		PickupTo:       ict(10, 20, 12, 0),
+		DeliverFrom:    ict(10, 21, 9, 0),
+		DeliverTo:      ict(10, 21, 17, 0),
+		WeightKg:       820.5,
+		VolumeM3:       4.2,
+		Pallets:        3,
+		Notes:          "Fragile, keep upright",
+		TripNumber:     "TRP2610-000017",
+		VehiclePlate:   "70-1234 Bangkok",
+		DriverName:     "Prasert Wongsa",
+		PODNumber:      "POD2610-000032",
+		DeliveredAt:    ict(10, 21, 13, 42),
+		ReceivedBy:     "Malee Srisuk",
+	}
+}
+
+func TestRenderGolden(t *testing.T) {
+	thai := testThaiFont(t)
+	for suffix, r := range map[string]*pdfRenderer{
+		"":      goldenRenderer,
+		".thai": {regular: thai, bold: thai},
+	} {
+		testRenderGolden(t, r, suffix)
+	}
+}
+
+// testRenderGolden renders each document with r and compares it with testdata/<document><suffix>.pdf.golden
+func testRenderGolden(t *testing.T, r *pdfRenderer, suffix string) {
+	for name, render := range map[string]func() ([]byte, error){
+		"invoice": func() ([]byte, error) { return r.RenderInvoice(testInvoice()) },
+		"credit_note": func() ([]byte, error) {
+			original := testInvoice()
+			doc := testInvoice()
+			doc.Invoice.Kind = entity.InvoiceKindCreditNote
+			doc.Invoice.Number = "CN2611-000004"
+			doc.Invoice.Lines = doc.Invoice.Lines[2:]
+			doc.Invoice.Reason = "Waiting time charged in error"
+			doc.CreditedNumber = original.Invoice.Number
+			doc.CreditedIssueDate = original.Invoice.IssueDate
+			doc.CreditedSubtotal = original.Invoice.Subtotal
+			return r.RenderInvoice(doc)
+		},
+		"waybill":       func() ([]byte, error) { return r.RenderWaybill(testShipment()) },
+		"delivery_note": func() ([]byte, error) { return r.RenderDeliveryNote(testShipment()) },
+	} {
+		t.Run(name+suffix, func(t *testing.T) {
+			got, err := render()
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			again, err := render()
+			if err != nil {
+				t.Fatalf("render again: %v", err)
+			}
+			if !bytes.Equal(got, again) {
+				t.Fatal("rendering the same document twice gave different bytes")
+			}
+
+			path := filepath.Join("testdata", name+suffix+".pdf.golden")
+			if *update {
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read golden file (run go test -update to create it): %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("output differs from %s at byte %d (%d bytes, want %d); run go test -update and review the PDF",
+					path, firstDifference(got, want), len(got), len(want))
+			}
+		})
+	}
+}
+
+func TestLoadFontsDefaultsToBuiltIn(t *testing.T) {
+	r, err := loadFonts("", "")
+	if err != nil {
+		t.Fatalf("loadFonts: %v", err)
+	}
+	if _, err := defaultFonts.ReadFile(defaultRegularFont); err != nil {
+		if r.regular != nil {
+			t.Error("a font was loaded although the build has no built-in font")
+		}
+		t.Skipf("the build has no built-in font (%v); run make fonts", err)
+	}
+	if r.regular == nil || r.bold == nil {
+		t.Fatal("the built-in font was not loaded")
+	}
+	if !r.newSheet("test").thai {
+		t.Error("documents with the built-in font do not print Thai")
+	}
+}
+
+func firstDifference(a, b []byte) int {
+	for i := range min(len(a), len(b)) {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return min(len(a), len(b))
+}
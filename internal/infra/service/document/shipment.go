@@ -0,0 +1,151 @@
+package document
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/pdf"
+)
+
+// RenderWaybill lays out the consignment note: barcodes for scanning at the dock and by the
+// consignee, both parties, the goods and the signatures of everyone handling the shipment.
+func (r *pdfRenderer) RenderWaybill(d service.ShipmentDocument) ([]byte, error) {
+	s := r.newSheet("Waybill " + d.TrackingNumber)
+	width := s.contentWidth()
+	page := s.addPage()
+	y, err := s.header(page, d.Seller, "ใบกำกับการขนส่ง", "Waybill")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.code128(page, margin, y, 240, 50, d.TrackingNumber); err != nil {
+		return nil, fmt.Errorf("barcode: %w", err)
+	}
+	qrSize := 80.0
+	if d.TrackingURL != "" {
+		if err := s.qrCode(page, margin+width-qrSize, y-4, qrSize, d.TrackingURL); err != nil {
+			return nil, fmt.Errorf("qr code: %w", err)
+		}
+		page.TextCenter(margin+width-qrSize/2, y+qrSize+6, s.regular, smallSize, s.label("ติดตามพัสดุ", "Track shipment"))
+	}
+	s.fields(page, margin+260, y+8, 70, width-260-qrSize-10, [][2]string{
+		{s.label("อ้างอิง", "Reference"), d.Reference},
+		{s.label("วันที่", "Date"), s.formatDate(d.BookedAt)},
+	})
+	y += qrSize + 20
+
+	y = s.shipmentParties(page, y, d.Shipper, d.Consignee, s.label("ผู้ส่ง", "Shipper"), s.label("ผู้รับ", "Consignee")) + 14
+	y = s.goods(page, y, d) + 10
+
+	y = s.fields(page, margin, y, labelWidth, width, [][2]string{
+		{s.label("ผู้ว่าจ้าง", "Customer"), d.Customer.Name},
+		{s.label("กำหนดรับ", "Pickup"), s.formatWindow(d.PickupFrom, d.PickupTo)},
+		{s.label("กำหนดส่ง", "Delivery"), s.formatWindow(d.DeliverFrom, d.DeliverTo)},
+		{s.label("เที่ยววิ่ง", "Trip"), d.TripNumber},
+		{s.label("ทะเบียนรถ", "Vehicle"), d.VehiclePlate},
+		{s.label("พนักงานขับรถ", "Driver"), d.DriverName},
+		{s.label("หมายเหตุ", "Notes"), d.Notes},
+	}) + 20
+
+	if y+70 > s.bottom() {
+		page = s.addPage()
+		y = margin
+	}
+	signW := (width - 20) / 3
+	s.signature(page, margin, y, signW, s.label("ผู้ส่งสินค้า", "Shipper"))
+	s.signature(page, margin+signW+10, y, signW, s.label("พนักงานขับรถ", "Driver"))
+	s.signature(page, margin+2*(signW+10), y, signW, s.label("ผู้รับสินค้า", "Consignee"))
+
+	s.footers(d.TrackingNumber)
+	return s.doc.Bytes()
+}
+
+// RenderDeliveryNote lays out the note the consignee signs to acknowledge receipt of the goods.
+// Once delivered, it shows who received the shipment and the proof of delivery number.
+func (r *pdfRenderer) RenderDeliveryNote(d service.ShipmentDocument) ([]byte, error) {
+	s := r.newSheet("Delivery Note " + d.TrackingNumber)
+	width := s.contentWidth()
+	page := s.addPage()
+	y, err := s.header(page, d.Seller, "ใบส่งสินค้า", "Delivery Note")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.code128(page, margin, y, 200, 40, d.TrackingNumber); err != nil {
+		return nil, fmt.Errorf("barcode: %w", err)
+	}
+	s.fields(page, margin+260, y+8, 70, width-260, [][2]string{
+		{s.label("อ้างอิง", "Reference"), d.Reference},
+		{s.label("วันที่", "Date"), s.formatDate(d.BookedAt)},
+		{s.label("เที่ยววิ่ง", "Trip"), d.TripNumber},
+	})
+	y += 70
+
+	y = s.shipmentParties(page, y, d.Consignee, d.Shipper, s.label("ส่งถึง", "Deliver to"), s.label("ผู้ส่ง", "From")) + 14
+	y = s.goods(page, y, d) + 10
+
+	received := ""
+	if d.DeliveredAt != nil {
+		received = s.formatDateTime(*d.DeliveredAt)
+	}
+	y = s.fields(page, margin, y, labelWidth, width, [][2]string{
+		{s.label("กำหนดส่ง", "Delivery window"), s.formatWindow(d.DeliverFrom, d.DeliverTo)},
+		{s.label("ทะเบียนรถ", "Vehicle"), d.VehiclePlate},
+		{s.label("พนักงานขับรถ", "Driver"), d.DriverName},
+		{s.label("วันเวลาที่ส่ง", "Delivered at"), received},
+		{s.label("ผู้รับสินค้า", "Received by"), d.ReceivedBy},
+		{s.label("หลักฐานการส่ง", "Proof of delivery"), d.PODNumber},
+		{s.label("หมายเหตุ", "Notes"), d.Notes},
+	}) + 10
+
+	statement := "Received the above goods in good order and condition."
+	if s.thai {
+		statement = "ได้รับสินค้าตามรายการข้างต้นไว้ถูกต้องในสภาพเรียบร้อยแล้ว / " + statement
+	}
+	for _, line := range s.doc.Wrap(s.regular, 9, width, statement) {
+		page.Text(margin, y, s.regular, 9, line)
+		y += 12
+	}
+	y += 16
+
+	if y+70 > s.bottom() {
+		page = s.addPage()
+		y = margin
+	}
+	signW := 180.0
+	s.signature(page, margin, y, signW, s.label("ผู้ส่งสินค้า", "Delivered by"))
+	s.signature(page, margin+width-signW, y, signW, s.label("ผู้รับสินค้า", "Received by"))
+
+	s.footers(d.TrackingNumber)
+	return s.doc.Bytes()
+}
+
+// shipmentParties draws two party boxes side by side and returns the bottom of the taller one
+func (s *sheet) shipmentParties(page *pdf.Page, y float64, left, right service.Party, leftHeading, rightHeading string) float64 {
+	w := (s.contentWidth() - 10) / 2
+	return max(
+		s.partyBox(page, margin, y, w, leftHeading, left, false),
+		s.partyBox(page, margin+w+10, y, w, rightHeading, right, false),
+	)
+}
+
+// goods draws the shaded quantity row of a shipment and returns the y below it
+func (s *sheet) goods(page *pdf.Page, y float64, d service.ShipmentDocument) float64 {
+	width := s.contentWidth()
+	page.FillRect(margin, y, width, 20, 0.9)
+	page.Text(margin+tableRowPad, y+13, s.bold, 9, s.label("รายการสินค้า", "Goods"))
+
+	var quantities []string
+	if d.Pallets > 0 {
+		quantities = append(quantities, strconv.Itoa(d.Pallets)+" "+s.label("พาเลท", "pallets"))
+	}
+	quantities = append(quantities, strconv.FormatFloat(d.WeightKg, 'f', -1, 64)+" kg")
+	if d.VolumeM3 > 0 {
+		quantities = append(quantities, strconv.FormatFloat(d.VolumeM3, 'f', -1, 64)+" m³")
+	}
+	page.Text(margin+tableRowPad, y+36, s.regular, bodySize, strings.Join(quantities, ", "))
+	page.Rect(margin, y, width, 48, 0.5)
+	return y + 48
+}
@@ -0,0 +1,142 @@
+package document
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/pkg/timeutil"
+)
+
+// buddhistEraOffset converts a Gregorian year to the Buddhist era used on Thai documents
+const buddhistEraOffset = 543
+
+var (
+	thaiMonths    = [...]string{"ม.ค.", "ก.พ.", "มี.ค.", "เม.ย.", "พ.ค.", "มิ.ย.", "ก.ค.", "ส.ค.", "ก.ย.", "ต.ค.", "พ.ย.", "ธ.ค."}
+	englishMonths = [...]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+
+	thaiDigits = [...]string{"ศูนย์", "หนึ่ง", "สอง", "สาม", "สี่", "ห้า", "หก", "เจ็ด", "แปด", "เก้า"}
+	thaiPlaces = [...]string{"", "สิบ", "ร้อย", "พัน", "หมื่น", "แสน"}
+)
+
+// formatDate formats a date in Thai local time with a Buddhist-era year, e.g. "18 ต.ค. 2569".
+// Without a Thai font the month is abbreviated in English: "18 Oct 2569".
+func (s *sheet) formatDate(t time.Time) string {
+	t = t.In(timeutil.Thailand)
+	month := englishMonths[t.Month()-1]
+	if s.thai {
+		month = thaiMonths[t.Month()-1]
+	}
+	return fmt.Sprintf("%d %s %d", t.Day(), month, t.Year()+buddhistEraOffset)
+}
+
+// formatDateTime formats a time in Thai local time with a Buddhist-era year, e.g. "18 ต.ค. 2569 14:05"
+func (s *sheet) formatDateTime(t time.Time) string {
+	return s.formatDate(t) + " " + t.In(timeutil.Thailand).Format("15:04")
+}
+
+// formatWindow formats a pickup or delivery window, writing the date once when it starts and ends on the same day
+func (s *sheet) formatWindow(from, to *time.Time) string {
+	switch {
+	case from == nil && to == nil:
+		return ""
+	case from == nil:
+		return "- " + s.formatDateTime(*to)
+	case to == nil:
+		return s.formatDateTime(*from) + " -"
+	}
+	f, t := from.In(timeutil.Thailand), to.In(timeutil.Thailand)
+	if f.Year() == t.Year() && f.YearDay() == t.YearDay() {
+		return s.formatDateTime(f) + "-" + t.Format("15:04")
+	}
+	return s.formatDateTime(f) + " - " + s.formatDateTime(t)
+}
+
+// formatMoney formats an amount with thousands separators and two decimals, e.g. "12,345.60"
+func formatMoney(m entity.Money) string {
+	s := m.String()
+	var b strings.Builder
+	if m < 0 {
+		b.WriteByte('-')
+		s = s[1:]
+	}
+	whole, frac := s[:len(s)-3], s[len(s)-3:]
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	b.WriteString(frac)
+	return b.String()
+}
+
+// formatRate formats a tax rate as a percentage, e.g. 0.07 as "7%"
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(math.Round(rate*10000)/100, 'f', -1, 64) + "%"
+}
+
+// bahtText spells an amount in Thai words as printed on tax invoices,
+// e.g. 1070.50 as "หนึ่งพันเจ็ดสิบบาทห้าสิบสตางค์"
+func bahtText(amount entity.Money) string {
+	satang := int64(amount)
+	var b strings.Builder
+	if satang < 0 {
+		b.WriteString("ลบ")
+		satang = -satang
+	}
+	baht, fraction := satang/100, satang%100
+
+	if baht > 0 || fraction == 0 {
+		b.WriteString(thaiNumber(baht))
+		b.WriteString("บาท")
+	}
+	if fraction == 0 {
+		b.WriteString("ถ้วน")
+	} else {
+		b.WriteString(thaiNumber(fraction))
+		b.WriteString("สตางค์")
+	}
+	return b.String()
+}
+
+// thaiNumber spells a whole number in Thai words. A final one is "เอ็ด" after a higher digit,
+// two tens are "ยี่สิบ" and one ten is "สิบ".
+func thaiNumber(n int64) string {
+	if n == 0 {
+		return thaiDigits[0]
+	}
+
+	var b strings.Builder
+	if n >= 1000000 {
+		b.WriteString(thaiNumber(n / 1000000))
+		b.WriteString("ล้าน")
+		n %= 1000000
+	}
+	higher := b.Len() > 0
+
+	for place := len(thaiPlaces) - 1; place >= 0; place-- {
+		unit := int64(math.Pow10(place))
+		d := n / unit % 10
+		if d == 0 {
+			continue
+		}
+		switch {
+		case place == 1 && d == 1:
+			// สิบ, not หนึ่งสิบ
+		case place == 1 && d == 2:
+			b.WriteString("ยี่")
+		case place == 0 && d == 1 && higher:
+			b.WriteString("เอ็ด")
+			continue
+		default:
+			b.WriteString(thaiDigits[d])
+		}
+		b.WriteString(thaiPlaces[place])
+		higher = true
+	}
+	return b.String()
+}
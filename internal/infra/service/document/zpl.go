@@ -0,0 +1,101 @@
+package document
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"tms-core-service/internal/domain/service"
+)
+
+// zplEscaper hex-escapes the characters ZPL treats as commands inside ^FH field data
+var zplEscaper = strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E", "\r", " ", "\n", " ")
+
+// RenderZPL renders every label as its own ^XA…^XZ format in UTF-8 (^CI28). Thai text needs the
+// template's printer font; without one the printer's built-in font 0 is used and labels are in English.
+func (r *labelRenderer) RenderZPL(labels []service.ShippingLabel) ([]byte, error) {
+	var b strings.Builder
+	for _, l := range labels {
+		c := &zplCanvas{b: &b, dots: float64(l.Template.DPI) / 25.4, font: l.Template.ZPLFont}
+		w, h := labelDimensions(l.Template.Size)
+		b.WriteString("^XA\n^CI28\n")
+		fmt.Fprintf(&b, "^PW%d\n^LL%d\n^LH0,0\n", c.d(w), c.d(h))
+		if c.font != "" {
+			// Advanced text layout joins Thai vowels and tone marks to their consonants
+			b.WriteString("^PA0,1,1,0\n")
+		}
+		if err := drawLabel(c, l, c.font != ""); err != nil {
+			return nil, fmt.Errorf("label %s piece %d: %w", l.TrackingNumber, l.Piece, err)
+		}
+		b.WriteString("^XZ\n")
+	}
+	return []byte(b.String()), nil
+}
+
+type zplCanvas struct {
+	b    *strings.Builder
+	dots float64 // printer dots per millimetre
+	font string  // printer TrueType font, or empty for the built-in font 0
+}
+
+// d converts millimetres to printer dots
+func (c *zplCanvas) d(mm float64) int {
+	return int(math.Round(mm * c.dots))
+}
+
+func (c *zplCanvas) text(x, y, w, size float64, bold bool, align textAlign, lines int, s string) {
+	if s == "" {
+		return
+	}
+	justify := "L"
+	switch align {
+	case alignCenter:
+		justify = "C"
+	case alignRight:
+		justify = "R"
+	}
+	// Printer fonts have no bold weight; a second pass one dot to the right thickens the strokes
+	passes := 1
+	if bold {
+		passes = 2
+	}
+	for i := 0; i < passes; i++ {
+		fmt.Fprintf(c.b, "^FO%d,%d", c.d(x)+i, c.d(y))
+		if c.font != "" {
+			fmt.Fprintf(c.b, "^A@N,%d,%d,%s", c.d(size), c.d(size), c.font)
+		} else {
+			fmt.Fprintf(c.b, "^A0N,%d,%d", c.d(size), c.d(size))
+		}
+		fmt.Fprintf(c.b, "^FB%d,%d,%d,%s,0^FH^FD%s^FS\n", c.d(w), lines, c.d(size*(labelLineSpacing-1)), justify, zplEscaper.Replace(s))
+	}
+}
+
+func (c *zplCanvas) hline(x, y, w float64) {
+	t := max(c.d(0.25), 1)
+	fmt.Fprintf(c.b, "^FO%d,%d^GB%d,%d,%d^FS\n", c.d(x), c.d(y), c.d(w), t, t)
+}
+
+func (c *zplCanvas) box(x, y, w, h float64) {
+	fmt.Fprintf(c.b, "^FO%d,%d^GB%d,%d,%d^FS\n", c.d(x), c.d(y), c.d(w), c.d(h), max(c.d(0.25), 1))
+}
+
+// barcode sizes the bars in whole dots from the symbol's module count. GS1-128 data is sent in
+// code set C after an FNC1 (>;>8); other data lets the printer pick code sets (mode A).
+func (c *zplCanvas) barcode(x, y, w, h float64, data string, gs1 bool) error {
+	modules, err := barcodeModules(data, gs1)
+	if err != nil {
+		return err
+	}
+	unit := min(c.d(w)/len(modules), c.d(maxModuleWidth))
+	if unit < 1 {
+		return errors.New("barcode wider than the label")
+	}
+	left := c.d(x) + (c.d(w)-unit*len(modules))/2
+	if gs1 {
+		fmt.Fprintf(c.b, "^FO%d,%d^BY%d^BCN,%d,N,N,N,N^FD>;>8%s^FS\n", left, c.d(y), unit, c.d(h), data)
+	} else {
+		fmt.Fprintf(c.b, "^FO%d,%d^BY%d^BCN,%d,N,N,N,A^FD%s^FS\n", left, c.d(y), unit, c.d(h), data)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package geocoding
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/cache"
+	"tms-core-service/internal/domain/service"
+)
+
+const cacheKeyPrefix = "geocode:"
+
+type cachedGeocoder struct {
+	next  service.Geocoder
+	cache cache.CacheRepository
+	ttl   time.Duration
+}
+
+// NewCachedGeocoder wraps a geocoder with a result cache.
+// Addresses rarely move, so a long TTL is appropriate; misses are not cached.
+func NewCachedGeocoder(next service.Geocoder, cacheRepo cache.CacheRepository, ttl time.Duration) service.Geocoder {
+	return &cachedGeocoder{next: next, cache: cacheRepo, ttl: ttl}
+}
+
+func (g *cachedGeocoder) Geocode(ctx context.Context, query service.GeocodeQuery) (*service.GeocodeResult, error) {
+	return g.cached(ctx, forwardKey(query), func() (*service.GeocodeResult, error) {
+		return g.next.Geocode(ctx, query)
+	})
+}
+
+func (g *cachedGeocoder) ReverseGeocode(ctx context.Context, lat, lng float64) (*service.GeocodeResult, error) {
+	// ~1 m at the equator; close enough to share a cached address
+	key := fmt.Sprintf("%sreverse:%.5f,%.5f", cacheKeyPrefix, lat, lng)
+	return g.cached(ctx, key, func() (*service.GeocodeResult, error) {
+		return g.next.ReverseGeocode(ctx, lat, lng)
+	})
+}
+
+// cached serves a result from the cache or computes and stores it.
+// Cache failures fall through to the provider so geocoding keeps working when Redis is unavailable.
+func (g *cachedGeocoder) cached(ctx context.Context, key string, fetch func() (*service.GeocodeResult, error)) (*service.GeocodeResult, error) {
+	if data, err := g.cache.Get(ctx, key); err == nil && data != "" {
+		var result service.GeocodeResult
+		if json.Unmarshal([]byte(data), &result) == nil {
+			return &result, nil
+		}
+	}
+
+	result, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	_ = g.cache.Set(ctx, key, result, g.ttl)
+	return result, nil
+}
+
+func forwardKey(q service.GeocodeQuery) string {
+	normalized := strings.ToLower(strings.Join([]string{
+		strings.TrimSpace(q.Address),
+		strings.TrimSpace(q.Subdistrict),
+		strings.TrimSpace(q.District),
+		strings.TrimSpace(q.Province),
+		strings.TrimSpace(q.Postcode),
+	}, "|"))
+	sum := sha256.Sum256([]byte(normalized))
+	return cacheKeyPrefix + "forward:" + hex.EncodeToString(sum[:])
+}
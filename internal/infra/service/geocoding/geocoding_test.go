@@ -0,0 +1,132 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/cache"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/service"
+)
+
+func TestOfflineGeocode(t *testing.T) {
+	g := NewOfflineGeocoder()
+
+	result, err := g.Geocode(context.Background(), service.GeocodeQuery{
+		Subdistrict: "ต.สุเทพ", District: "อ.เมืองเชียงใหม่", Province: "Chiang Mai", Postcode: "50200",
+	})
+	if err != nil {
+		t.Fatalf("Geocode: %v", err)
+	}
+	if result.Precision != entity.GeoPrecisionSubdistrict || result.Provider != ProviderOffline {
+		t.Errorf("result = %+v, want the subdistrict centroid from the offline provider", result)
+	}
+
+	// A wrong subdistrict still lands on the postcode
+	result, err = g.Geocode(context.Background(), service.GeocodeQuery{Subdistrict: "nowhere", Postcode: "50200"})
+	if err != nil {
+		t.Fatalf("Geocode by postcode: %v", err)
+	}
+	if result.Precision != entity.GeoPrecisionPostcode {
+		t.Errorf("precision = %s, want postcode", result.Precision)
+	}
+
+	if _, err := g.Geocode(context.Background(), service.GeocodeQuery{Postcode: "00000"}); !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("unknown address: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestOfflineReverseGeocode(t *testing.T) {
+	g := NewOfflineGeocoder()
+
+	result, err := g.ReverseGeocode(context.Background(), 18.79, 98.95)
+	if err != nil {
+		t.Fatalf("ReverseGeocode: %v", err)
+	}
+	if result.Latitude != 18.79 || result.Longitude != 98.95 || result.FormattedAddress == "" {
+		t.Errorf("result = %+v, want the given position with the nearest address", result)
+	}
+
+	// the middle of the Andaman Sea is beyond every subdistrict
+	if _, err := g.ReverseGeocode(context.Background(), 9.0, 95.0); !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("far away: err = %v, want ErrNotFound", err)
+	}
+}
+
+type memoryCache struct {
+	cache.CacheRepository
+	values map[string]string
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) (string, error) {
+	return c.values[key], nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.values[key] = string(data)
+	return nil
+}
+
+type countingGeocoder struct {
+	calls int
+	err   error
+}
+
+func (g *countingGeocoder) Geocode(context.Context, service.GeocodeQuery) (*service.GeocodeResult, error) {
+	g.calls++
+	if g.err != nil {
+		return nil, g.err
+	}
+	return &service.GeocodeResult{Latitude: 13.73, Longitude: 100.54, Precision: entity.GeoPrecisionStreet}, nil
+}
+
+func (g *countingGeocoder) ReverseGeocode(context.Context, float64, float64) (*service.GeocodeResult, error) {
+	g.calls++
+	return &service.GeocodeResult{FormattedAddress: "ลุมพินี"}, nil
+}
+
+func TestCachedGeocoder(t *testing.T) {
+	next := &countingGeocoder{}
+	g := NewCachedGeocoder(next, &memoryCache{values: map[string]string{}}, time.Hour)
+	ctx := context.Background()
+
+	for _, q := range []service.GeocodeQuery{
+		{Address: "1 Rama IV Rd", Postcode: "10330"},
+		{Address: "  1 rama iv rd ", Postcode: "10330 "},
+	} {
+		result, err := g.Geocode(ctx, q)
+		if err != nil || result.Precision != entity.GeoPrecisionStreet {
+			t.Fatalf("Geocode(%+v) = %+v, %v", q, result, err)
+		}
+	}
+	if next.calls != 1 {
+		t.Errorf("provider called %d times, want once for the same normalized address", next.calls)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := g.ReverseGeocode(ctx, 13.730001, 100.540001); err != nil {
+			t.Fatalf("ReverseGeocode: %v", err)
+		}
+	}
+	if next.calls != 2 {
+		t.Errorf("provider called %d times, want the reverse lookup cached", next.calls)
+	}
+
+	next.err = errs.ErrNotFound
+	for i := 0; i < 2; i++ {
+		if _, err := g.Geocode(ctx, service.GeocodeQuery{Postcode: "99999"}); !errors.Is(err, errs.ErrNotFound) {
+			t.Fatalf("miss: err = %v, want ErrNotFound", err)
+		}
+	}
+	if next.calls != 4 {
+		t.Errorf("provider called %d times, want misses not cached", next.calls)
+	}
+}
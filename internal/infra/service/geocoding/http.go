@@ -0,0 +1,145 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/service"
+)
+
+// ProviderHTTP is the provider name recorded on results from the HTTP geocoder
+const ProviderHTTP = "http"
+
+type httpGeocoder struct {
+	client  *http.Client
+	baseURL string
+	apiKey  string
+}
+
+// NewHTTPGeocoder creates a geocoder for a Google Geocoding API compatible endpoint
+func NewHTTPGeocoder(baseURL, apiKey string, timeout time.Duration) service.Geocoder {
+	return &httpGeocoder{
+		client:  &http.Client{Timeout: timeout},
+		baseURL: baseURL,
+		apiKey:  apiKey,
+	}
+}
+
+type geocodeResponse struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message"`
+	Results      []struct {
+		FormattedAddress string   `json:"formatted_address"`
+		Types            []string `json:"types"`
+		Geometry         struct {
+			Location struct {
+				Lat float64 `json:"lat"`
+				Lng float64 `json:"lng"`
+			} `json:"location"`
+			LocationType string `json:"location_type"`
+		} `json:"geometry"`
+	} `json:"results"`
+}
+
+func (g *httpGeocoder) Geocode(ctx context.Context, query service.GeocodeQuery) (*service.GeocodeResult, error) {
+	params := url.Values{}
+	params.Set("address", joinAddress(query))
+	components := []string{"country:TH"}
+	if query.Postcode != "" {
+		components = append(components, "postal_code:"+query.Postcode)
+	}
+	params.Set("components", strings.Join(components, "|"))
+	return g.do(ctx, params)
+}
+
+func (g *httpGeocoder) ReverseGeocode(ctx context.Context, lat, lng float64) (*service.GeocodeResult, error) {
+	params := url.Values{}
+	params.Set("latlng", strconv.FormatFloat(lat, 'f', 6, 64)+","+strconv.FormatFloat(lng, 'f', 6, 64))
+	return g.do(ctx, params)
+}
+
+func (g *httpGeocoder) do(ctx context.Context, params url.Values) (*service.GeocodeResult, error) {
+	params.Set("key", g.apiKey)
+	params.Set("language", "th")
+	params.Set("region", "th")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build geocoding request: %w", err)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call geocoding provider: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("geocoding provider returned HTTP %d", resp.StatusCode)
+	}
+
+	var body geocodeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode geocoding response: %w", err)
+	}
+
+	switch body.Status {
+	case "OK":
+	case "ZERO_RESULTS":
+		return nil, errs.ErrNotFound
+	default:
+		return nil, fmt.Errorf("geocoding provider returned %s: %s", body.Status, body.ErrorMessage)
+	}
+	if len(body.Results) == 0 {
+		return nil, errs.ErrNotFound
+	}
+
+	best := body.Results[0]
+	return &service.GeocodeResult{
+		Latitude:         best.Geometry.Location.Lat,
+		Longitude:        best.Geometry.Location.Lng,
+		FormattedAddress: best.FormattedAddress,
+		Precision:        precisionOf(best.Geometry.LocationType, best.Types),
+		Provider:         ProviderHTTP,
+	}, nil
+}
+
+// precisionOf maps the provider's location type and result types onto our precision scale
+func precisionOf(locationType string, types []string) entity.GeoPrecision {
+	switch locationType {
+	case "ROOFTOP":
+		return entity.GeoPrecisionRooftop
+	case "RANGE_INTERPOLATED":
+		return entity.GeoPrecisionStreet
+	}
+
+	for _, t := range types {
+		switch {
+		case t == "street_address" || t == "premise" || t == "route":
+			return entity.GeoPrecisionStreet
+		case t == "postal_code":
+			return entity.GeoPrecisionPostcode
+		case strings.HasPrefix(t, "sublocality") || t == "administrative_area_level_3":
+			return entity.GeoPrecisionSubdistrict
+		}
+	}
+	return entity.GeoPrecisionApproximate
+}
+
+func joinAddress(q service.GeocodeQuery) string {
+	var parts []string
+	for _, p := range []string{q.Address, q.Subdistrict, q.District, q.Province, q.Postcode} {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,77 @@
+package geocoding
+
+import (
+	"context"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/geo"
+	"tms-core-service/pkg/thaiaddress"
+)
+
+// ProviderOffline is the provider name recorded on results from the offline geocoder
+const ProviderOffline = "offline"
+
+// maxReverseDistanceMeters bounds reverse lookups so points outside the dataset's coverage are not
+// attributed to a far-away subdistrict
+const maxReverseDistanceMeters = 30000
+
+type offlineGeocoder struct {
+	directory *thaiaddress.Directory
+}
+
+// NewOfflineGeocoder creates a geocoder that resolves to subdistrict or postcode centroids
+// from the embedded Thai administrative dataset. Intended for development and tests.
+func NewOfflineGeocoder() service.Geocoder {
+	return &offlineGeocoder{directory: thaiaddress.Default()}
+}
+
+func (g *offlineGeocoder) Geocode(_ context.Context, query service.GeocodeQuery) (*service.GeocodeResult, error) {
+	if area, err := g.directory.Match(query.Subdistrict, query.District, query.Province, query.Postcode); err == nil {
+		return toResult(*area, area.Latitude, area.Longitude, entity.GeoPrecisionSubdistrict), nil
+	}
+
+	if lat, lng, ok := g.directory.PostcodeCentroid(query.Postcode); ok {
+		areas := g.directory.ByPostcode(query.Postcode)
+		return toResult(areas[0], lat, lng, entity.GeoPrecisionPostcode), nil
+	}
+
+	return nil, errs.ErrNotFound
+}
+
+func (g *offlineGeocoder) ReverseGeocode(_ context.Context, lat, lng float64) (*service.GeocodeResult, error) {
+	area, ok := g.directory.Nearest(lat, lng)
+	if !ok {
+		return nil, errs.ErrNotFound
+	}
+
+	dist := geo.Haversine(geo.Point{Lat: lat, Lng: lng}, geo.Point{Lat: area.Latitude, Lng: area.Longitude})
+	if dist > maxReverseDistanceMeters {
+		return nil, errs.ErrNotFound
+	}
+
+	return toResult(*area, lat, lng, entity.GeoPrecisionSubdistrict), nil
+}
+
+func toResult(area thaiaddress.Area, lat, lng float64, precision entity.GeoPrecision) *service.GeocodeResult {
+	// A postcode can span several subdistricts, so only the province is certain
+	formatted := area.ProvinceTH + " " + area.Postcode
+	if precision == entity.GeoPrecisionSubdistrict {
+		location := entity.Location{
+			Subdistrict: area.SubdistrictTH,
+			District:    area.DistrictTH,
+			Province:    area.ProvinceTH,
+			Postcode:    area.Postcode,
+		}
+		formatted = location.FormattedAddress()
+	}
+
+	return &service.GeocodeResult{
+		Latitude:         lat,
+		Longitude:        lng,
+		FormattedAddress: formatted,
+		Precision:        precision,
+		Provider:         ProviderOffline,
+	}
+}
@@ -0,0 +1,42 @@
+package geometry
+
+import (
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/geo"
+)
+
+type geometry struct{}
+
+// NewGeometry creates a geometry service backed by pkg/geo
+func NewGeometry() service.Geometry {
+	return &geometry{}
+}
+
+func (g *geometry) Distance(fromLat, fromLng, toLat, toLng float64) float64 {
+	return geo.Haversine(geo.Point{Lat: fromLat, Lng: fromLng}, geo.Point{Lat: toLat, Lng: toLng})
+}
+
+func (g *geometry) Simplify(path []entity.Coordinate, toleranceM float64) []int {
+	return geo.Simplify(toPoints(path), toleranceM)
+}
+
+func (g *geometry) PolygonContains(polygon []entity.Coordinate, point entity.Coordinate) bool {
+	return geo.Contains(toPoints(polygon), toPoint(point))
+}
+
+func (g *geometry) DistanceToPolygon(polygon []entity.Coordinate, point entity.Coordinate) float64 {
+	return geo.DistanceToPolygon(toPoints(polygon), toPoint(point))
+}
+
+func toPoint(c entity.Coordinate) geo.Point {
+	return geo.Point{Lat: c.Latitude, Lng: c.Longitude}
+}
+
+func toPoints(cs []entity.Coordinate) []geo.Point {
+	points := make([]geo.Point, len(cs))
+	for i, c := range cs {
+		points[i] = toPoint(c)
+	}
+	return points
+}
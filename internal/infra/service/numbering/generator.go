@@ -0,0 +1,147 @@
+package numbering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/docnumber"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+// defaultFormats are used for document types without a configured default format
+var defaultFormats = map[entity.DocumentType]string{
+	entity.DocumentShipment:         "TH{YYMM}{seq:6}{mod11}",
+	entity.DocumentTrip:             "TRP-{YYMM}-{seq:5}",
+	entity.DocumentInvoice:          "INV-{YYYY}{MM}-{seq:6}",
+	entity.DocumentCreditNote:       "CN-{YYYY}{MM}-{seq:6}",
+	entity.DocumentProofOfDelivery:  "POD-{YYMM}-{seq:6}",
+	entity.DocumentDriverSettlement: "DS-{YYMM}-{seq:5}",
+	entity.DocumentWorkOrder:        "WO-{YYMM}-{seq:5}",
+	entity.DocumentIncident:         "INC-{YYMM}-{seq:5}",
+}
+
+// generator issues document numbers from per-organization formats and database sequences.
+// Numbers in a default format share one sequence across organizations; an organization's own format
+// has its own sequence, which is why its numbers must begin with text no other format's numbers begin with.
+type generator struct {
+	repo     repository.NumberingRepository
+	defaults map[entity.DocumentType]*docnumber.Format
+}
+
+// NewGenerator creates a number generator; formats overrides the default format of document types by name
+func NewGenerator(repo repository.NumberingRepository, formats map[string]string) (service.NumberGenerator, error) {
+	defaults := make(map[entity.DocumentType]*docnumber.Format, len(defaultFormats))
+	for _, docType := range slices.Concat(entity.DocumentTypes, entity.InternalDocumentTypes) {
+		source := defaultFormats[docType]
+		if configured, ok := formats[string(docType)]; ok && configured != "" {
+			source = configured
+		}
+		format, err := docnumber.Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("numbering format of %s: %w", docType, err)
+		}
+		defaults[docType] = format
+	}
+	return &generator{repo: repo, defaults: defaults}, nil
+}
+
+// Next issues the next number of a document type in the organization's format, or the default format
+func (g *generator) Next(ctx context.Context, organizationID uuid.UUID, documentType entity.DocumentType, at time.Time) (string, error) {
+	format, scope, err := g.formatOf(ctx, organizationID, documentType)
+	if err != nil {
+		return "", err
+	}
+
+	at = at.In(timeutil.Thailand)
+	seq, err := g.repo.NextValue(ctx, scope, documentType, format.PeriodKey(at))
+	if err != nil {
+		return "", fmt.Errorf("numbering repository: next value: %w", err)
+	}
+	return format.Render(at, seq), nil
+}
+
+// Plausible reports whether a number could have been issued. Numbers matching no format are plausible,
+// since they may predate the formats; only a wrong check character gives a mistyped number away.
+func (g *generator) Plausible(ctx context.Context, documentType entity.DocumentType, number string) (bool, error) {
+	custom, err := g.repo.ListFormatsByType(ctx, documentType)
+	if err != nil {
+		return false, fmt.Errorf("numbering repository: list formats: %w", err)
+	}
+
+	formats := []*docnumber.Format{g.defaults[documentType]}
+	for _, f := range custom {
+		if format, err := docnumber.Parse(f.Format); err == nil {
+			formats = append(formats, format)
+		}
+	}
+
+	matched := false
+	for _, format := range formats {
+		if format == nil || !format.Matches(number) {
+			continue
+		}
+		if format.Valid(number) {
+			return true, nil
+		}
+		matched = true
+	}
+	return !matched, nil
+}
+
+// DefaultFormat returns the format used by organizations without their own
+func (g *generator) DefaultFormat(documentType entity.DocumentType) string {
+	if format, ok := g.defaults[documentType]; ok {
+		return format.String()
+	}
+	return ""
+}
+
+// Preview renders the first number of a format in the period of at without issuing it
+func (g *generator) Preview(format string, at time.Time) (string, error) {
+	parsed, err := docnumber.Parse(format)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Render(at.In(timeutil.Thailand), 1), nil
+}
+
+// Prefix returns the text every number of a format begins with
+func (g *generator) Prefix(format string) (string, error) {
+	parsed, err := docnumber.Parse(format)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Prefix(), nil
+}
+
+// formatOf returns the format that applies to an organization and the sequence scope it counts in
+func (g *generator) formatOf(ctx context.Context, organizationID uuid.UUID, documentType entity.DocumentType) (*docnumber.Format, uuid.UUID, error) {
+	if organizationID != uuid.Nil {
+		custom, err := g.repo.FindFormat(ctx, organizationID, documentType)
+		switch {
+		case err == nil:
+			format, err := docnumber.Parse(custom.Format)
+			if err != nil {
+				return nil, uuid.Nil, fmt.Errorf("numbering format of organization %s: %w", organizationID, err)
+			}
+			return format, organizationID, nil
+		case !errors.Is(err, errs.ErrNotFound):
+			return nil, uuid.Nil, fmt.Errorf("numbering repository: find format: %w", err)
+		}
+	}
+
+	format, ok := g.defaults[documentType]
+	if !ok {
+		return nil, uuid.Nil, fmt.Errorf("unknown document type %q", documentType)
+	}
+	return format, uuid.Nil, nil
+}
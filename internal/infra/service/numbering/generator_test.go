@@ -0,0 +1,128 @@
+package numbering
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+// memoryRepo keeps formats and sequences in memory
+type memoryRepo struct {
+	repository.NumberingRepository
+	formats   map[uuid.UUID]*entity.NumberingFormat
+	sequences map[string]int64
+}
+
+func newMemoryRepo() *memoryRepo {
+	return &memoryRepo{formats: map[uuid.UUID]*entity.NumberingFormat{}, sequences: map[string]int64{}}
+}
+
+func (r *memoryRepo) FindFormat(_ context.Context, organizationID uuid.UUID, documentType entity.DocumentType) (*entity.NumberingFormat, error) {
+	if f, ok := r.formats[organizationID]; ok && f.DocumentType == documentType {
+		return f, nil
+	}
+	return nil, errs.ErrNotFound
+}
+
+func (r *memoryRepo) ListFormatsByType(_ context.Context, documentType entity.DocumentType) ([]*entity.NumberingFormat, error) {
+	var formats []*entity.NumberingFormat
+	for _, f := range r.formats {
+		if f.DocumentType == documentType {
+			formats = append(formats, f)
+		}
+	}
+	return formats, nil
+}
+
+func (r *memoryRepo) NextValue(_ context.Context, organizationID uuid.UUID, documentType entity.DocumentType, period string) (int64, error) {
+	key := fmt.Sprintf("%s/%s/%s", organizationID, documentType, period)
+	r.sequences[key]++
+	return r.sequences[key], nil
+}
+
+func TestNextUsesDefaultAndOrganizationFormats(t *testing.T) {
+	repo := newMemoryRepo()
+	own := uuid.New()
+	repo.formats[own] = &entity.NumberingFormat{OrganizationID: own, DocumentType: entity.DocumentTrip, Format: "T{YY}-{seq:3}"}
+
+	g, err := NewGenerator(repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	at := time.Date(2026, 3, 31, 20, 0, 0, 0, time.UTC) // already April in Thailand
+
+	for i, want := range []string{"TRP-2604-00001", "TRP-2604-00002"} {
+		got, err := g.Next(ctx, uuid.New(), entity.DocumentTrip, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("default number %d = %q, want %q", i+1, got, want)
+		}
+	}
+
+	got, err := g.Next(ctx, own, entity.DocumentTrip, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "T26-001" {
+		t.Errorf("organization number = %q, want T26-001, counted in its own sequence", got)
+	}
+}
+
+func TestNewGeneratorRejectsInvalidConfiguredFormat(t *testing.T) {
+	if _, err := NewGenerator(newMemoryRepo(), map[string]string{string(entity.DocumentTrip): "TRP-{seq"}); err == nil {
+		t.Fatal("expected an error for an unterminated placeholder")
+	}
+	g, err := NewGenerator(newMemoryRepo(), map[string]string{string(entity.DocumentTrip): "X{seq:4}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.DefaultFormat(entity.DocumentTrip); got != "X{seq:4}" {
+		t.Errorf("DefaultFormat = %q, want the configured format", got)
+	}
+}
+
+func TestPlausible(t *testing.T) {
+	g, err := NewGenerator(newMemoryRepo(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	issued, err := g.Next(ctx, uuid.Nil, entity.DocumentShipment, time.Date(2026, 5, 1, 0, 0, 0, 0, timeutil.Thailand))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// swap the last two sequence digits so the check character no longer fits
+	typo := []byte(issued)
+	n := len(typo)
+	typo[n-3], typo[n-2] = typo[n-2], typo[n-3]
+	if typo[n-3] == typo[n-2] {
+		t.Fatalf("issued number %q cannot be mistyped by a swap", issued)
+	}
+
+	tests := map[string]bool{
+		issued:       true,
+		string(typo): false,
+		"LEGACY-42":  true,
+	}
+	for number, want := range tests {
+		got, err := g.Plausible(ctx, entity.DocumentShipment, number)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("Plausible(%q) = %v, want %v", number, got, want)
+		}
+	}
+}
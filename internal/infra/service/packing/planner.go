@@ -0,0 +1,102 @@
+package packing
+
+import (
+	"fmt"
+
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/loadplan"
+)
+
+type loadPlanner struct{}
+
+// NewLoadPlanner creates a load planner backed by the in-process extreme-point packer
+func NewLoadPlanner() service.LoadPlanner {
+	return &loadPlanner{}
+}
+
+func (p *loadPlanner) Plan(space service.CargoSpace, units []service.HandlingUnit) (*service.LoadPlan, error) {
+	s := loadplan.Space{
+		Length:    space.LengthMM,
+		Width:     space.WidthMM,
+		Height:    space.HeightMM,
+		MaxWeight: space.MaxWeightKg,
+		FrontAxle: space.FrontAxleMM,
+		RearAxle:  space.RearAxleMM,
+	}
+
+	items := make([]loadplan.Item, len(units))
+	for i, u := range units {
+		items[i] = loadplan.Item{
+			ID:                u.ID,
+			Quantity:          u.Quantity,
+			Length:            u.LengthMM,
+			Width:             u.WidthMM,
+			Height:            u.HeightMM,
+			Weight:            u.WeightKg,
+			Stackable:         u.Stackable,
+			OrientationLocked: u.OrientationLocked,
+		}
+	}
+
+	plan, err := loadplan.Pack(s, items)
+	if err != nil {
+		return nil, fmt.Errorf("pack: %w", err)
+	}
+
+	result := &service.LoadPlan{
+		Placements:    make([]service.CargoPlacement, len(plan.Placements)),
+		Leftovers:     make([]service.CargoLeftover, len(plan.Leftovers)),
+		VolumeFillPct: plan.VolumeFill,
+		WeightFillPct: plan.WeightFill,
+		UsedLengthMM:  plan.UsedLength,
+		TotalWeightKg: plan.TotalWeight,
+		CenterOfGravity: service.CargoPoint{
+			XMM: plan.CenterOfGravity.X,
+			YMM: plan.CenterOfGravity.Y,
+			ZMM: plan.CenterOfGravity.Z,
+		},
+		LateralOffsetMM: plan.LateralOffset,
+		Warnings:        plan.Warnings,
+	}
+	for i, pl := range plan.Placements {
+		result.Placements[i] = service.CargoPlacement{
+			UnitID:   pl.ItemID,
+			Sequence: pl.Unit,
+			XMM:      pl.X,
+			YMM:      pl.Y,
+			ZMM:      pl.Z,
+			LengthMM: pl.Length,
+			WidthMM:  pl.Width,
+			HeightMM: pl.Height,
+			Rotated:  pl.Rotated,
+			WeightKg: pl.Weight,
+		}
+	}
+	for i, l := range plan.Leftovers {
+		result.Leftovers[i] = service.CargoLeftover{UnitID: l.ItemID, Sequence: l.Unit, Reason: l.Reason}
+	}
+	if plan.Axles != nil {
+		result.Axles = &service.AxleLoad{FrontKg: plan.Axles.Front, RearKg: plan.Axles.Rear}
+	}
+
+	views := loadplan.Layout(s, plan.Placements)
+	result.SVG = loadplan.SVG(views)
+	result.Views = make([]service.LayoutView, len(views))
+	for i, v := range views {
+		view := service.LayoutView{Name: v.Name, Width: v.Width, Height: v.Height, Rects: make([]service.LayoutRect, len(v.Rects))}
+		for j, r := range v.Rects {
+			view.Rects[j] = service.LayoutRect{
+				UnitID:   r.ItemID,
+				Sequence: r.Unit,
+				X:        r.X,
+				Y:        r.Y,
+				Width:    r.Width,
+				Height:   r.Height,
+				Color:    r.Color,
+			}
+		}
+		result.Views[i] = view
+	}
+
+	return result, nil
+}
@@ -0,0 +1,33 @@
+package routing
+
+import (
+	"context"
+	"time"
+
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/geo"
+)
+
+// Straight-line travel estimates, used when no road network data is available
+const (
+	detourFactor      = 1.3        // road distance over great-circle distance
+	averageSpeedMPerS = 40.0 / 3.6 // 40 km/h mixed urban/highway
+)
+
+type straightLineEstimator struct{}
+
+// NewStraightLineEstimator creates a travel estimator from great-circle distance, a detour factor and an average speed
+func NewStraightLineEstimator() service.TravelEstimator {
+	return &straightLineEstimator{}
+}
+
+func (e *straightLineEstimator) Estimate(_ context.Context, fromLat, fromLng, toLat, toLng float64) (float64, time.Duration, error) {
+	meters, seconds := estimate(geo.Point{Lat: fromLat, Lng: fromLng}, geo.Point{Lat: toLat, Lng: toLng})
+	return meters, time.Duration(seconds * float64(time.Second)), nil
+}
+
+// estimate returns the road distance in meters and driving time in seconds between two points
+func estimate(a, b geo.Point) (float64, float64) {
+	meters := geo.Haversine(a, b) * detourFactor
+	return meters, meters / averageSpeedMPerS
+}
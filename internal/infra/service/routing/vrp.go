@@ -0,0 +1,156 @@
+package routing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/geo"
+	"tms-core-service/pkg/vrp"
+)
+
+type vrpOptimizer struct{}
+
+// NewVRPOptimizer creates a route optimizer backed by the in-process VRP solver
+func NewVRPOptimizer() service.RouteOptimizer {
+	return &vrpOptimizer{}
+}
+
+func (o *vrpOptimizer) Optimize(ctx context.Context, problem service.RoutingProblem) (*service.RoutingSolution, error) {
+	epoch := earliestShift(problem.Vehicles)
+	seconds := func(t time.Time) float64 { return t.Sub(epoch).Seconds() }
+
+	p := vrp.Problem{
+		Depots:   make([]vrp.Depot, len(problem.Depots)),
+		Stops:    make([]vrp.Stop, len(problem.Stops)),
+		Vehicles: make([]vrp.Vehicle, len(problem.Vehicles)),
+	}
+
+	depotIndex := make(map[string]int, len(problem.Depots))
+	for i, d := range problem.Depots {
+		p.Depots[i] = vrp.Depot{ID: d.ID}
+		depotIndex[d.ID] = i
+	}
+
+	for i, s := range problem.Stops {
+		stop := vrp.Stop{
+			ID:      s.ID,
+			Demand:  dimensions(s.Demand),
+			Service: s.ServiceTime.Seconds(),
+		}
+		if s.WindowStart != nil || s.WindowEnd != nil {
+			window := &vrp.TimeWindow{Start: math.Inf(-1), End: math.Inf(1)}
+			if s.WindowStart != nil {
+				window.Start = seconds(*s.WindowStart)
+			}
+			if s.WindowEnd != nil {
+				window.End = seconds(*s.WindowEnd)
+			}
+			stop.Window = window
+		}
+		p.Stops[i] = stop
+	}
+
+	for i, v := range problem.Vehicles {
+		depot, ok := depotIndex[v.DepotID]
+		if !ok {
+			return nil, fmt.Errorf("vehicle %s: unknown depot %s", v.ID, v.DepotID)
+		}
+		vehicle := vrp.Vehicle{
+			ID:         v.ID,
+			Depot:      depot,
+			Capacity:   dimensions(v.Capacity),
+			ShiftStart: seconds(v.ShiftStart),
+		}
+		if v.ShiftEnd != nil {
+			// Shifts end after they start, so a real end is always positive from the epoch
+			vehicle.ShiftEnd = seconds(*v.ShiftEnd)
+		}
+		p.Vehicles[i] = vehicle
+	}
+
+	if problem.Matrix != nil {
+		p.Distance, p.Duration = problem.Matrix.DistancesM, problem.Matrix.DurationsS
+	} else {
+		p.Distance, p.Duration = estimateMatrix(problem)
+	}
+
+	sol, err := vrp.Solve(ctx, p, vrp.Options{TimeLimit: problem.TimeLimit})
+	if err != nil {
+		return nil, fmt.Errorf("solve: %w", err)
+	}
+
+	at := func(s float64) time.Time { return epoch.Add(time.Duration(s * float64(time.Second))) }
+	result := &service.RoutingSolution{
+		Routes:         make([]service.PlannedRoute, len(sol.Routes)),
+		Unassigned:     make([]service.UnassignedStop, len(sol.Unassigned)),
+		TotalDistanceM: sol.TotalDistance,
+		Iterations:     sol.Iterations,
+	}
+	for i, r := range sol.Routes {
+		route := service.PlannedRoute{
+			VehicleID: r.VehicleID,
+			Visits:    make([]service.PlannedVisit, len(r.Visits)),
+			DistanceM: r.Distance,
+			Start:     at(r.Start),
+			End:       at(r.End),
+			Load:      toLoad(r.Load),
+		}
+		for j, v := range r.Visits {
+			route.Visits[j] = service.PlannedVisit{
+				StopID:       v.StopID,
+				Arrival:      at(v.Arrival),
+				ServiceStart: at(v.ServiceStart),
+				Departure:    at(v.Departure),
+			}
+		}
+		result.Routes[i] = route
+	}
+	for i, u := range sol.Unassigned {
+		result.Unassigned[i] = service.UnassignedStop{StopID: u.StopID, Reason: u.Reason}
+	}
+	return result, nil
+}
+
+func earliestShift(vehicles []service.RoutingVehicle) time.Time {
+	var epoch time.Time
+	for i, v := range vehicles {
+		if i == 0 || v.ShiftStart.Before(epoch) {
+			epoch = v.ShiftStart
+		}
+	}
+	return epoch
+}
+
+// estimateMatrix derives travel distances and durations from great-circle distances
+func estimateMatrix(problem service.RoutingProblem) (distances, durations [][]float64) {
+	points := make([]geo.Point, 0, len(problem.Depots)+len(problem.Stops))
+	for _, d := range problem.Depots {
+		points = append(points, geo.Point{Lat: d.Latitude, Lng: d.Longitude})
+	}
+	for _, s := range problem.Stops {
+		points = append(points, geo.Point{Lat: s.Latitude, Lng: s.Longitude})
+	}
+
+	distances = make([][]float64, len(points))
+	durations = make([][]float64, len(points))
+	for i := range points {
+		distances[i] = make([]float64, len(points))
+		durations[i] = make([]float64, len(points))
+		for j := range points {
+			distances[i][j], durations[i][j] = estimate(points[i], points[j])
+		}
+	}
+	return distances, durations
+}
+
+func dimensions(l entity.Load) []float64 {
+	return []float64{l.WeightKg, l.VolumeM3, float64(l.Pallets)}
+}
+
+func toLoad(d []float64) entity.Load {
+	return entity.Load{WeightKg: d[0], VolumeM3: d[1], Pallets: int(math.Round(d[2]))}
+}
@@ -1,16 +1,21 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
+	"tms-core-service/internal/domain/errs"
 	"tms-core-service/internal/domain/service"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
 type s3Storage struct {
@@ -80,3 +85,59 @@ func (s *s3Storage) GenerateDownloadURL(ctx context.Context, key string) (string
 
 	return presigned.URL, nil
 }
+
+// ObjectExists checks for an object with a HEAD request
+func (s *s3Storage) ObjectExists(ctx context.Context, key string) (bool, error) {
+	_, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("s3: head object: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetObject downloads an object into memory
+func (s *s3Storage) GetObject(ctx context.Context, key string) ([]byte, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("s3: get object: %w", err)
+	}
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("s3: read object: %w", err)
+	}
+
+	return data, nil
+}
+
+// PutObject uploads an object from memory
+func (s *s3Storage) PutObject(ctx context.Context, key string, contentType string, data []byte) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(int64(len(data))),
+		Body:          bytes.NewReader(data),
+	})
+	if err != nil {
+		return fmt.Errorf("s3: put object: %w", err)
+	}
+
+	return nil
+}
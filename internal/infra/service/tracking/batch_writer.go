@@ -0,0 +1,152 @@
+package tracking
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+)
+
+const (
+	// defaultBatchSize and defaultFlushInterval are used when tracking settings are not configured
+	defaultBatchSize     = 500
+	defaultFlushInterval = 2 * time.Second
+
+	// queueLength is how many enqueued batches may wait for the writer before Enqueue blocks
+	queueLength = 1024
+
+	// maxPendingBatches bounds how many unwritten batches are kept for retry while the database is failing
+	maxPendingBatches = 20
+
+	// drainTimeout bounds the final flush on shutdown
+	drainTimeout = 10 * time.Second
+)
+
+// BatchWriter collects positions from ingestion requests and writes them to the position repository
+// in batches, when batchSize positions are pending or every flushInterval, whichever comes first.
+type BatchWriter struct {
+	repo          repository.PositionRepository
+	queue         chan []entity.VehiclePosition
+	batchSize     int
+	flushInterval time.Duration
+}
+
+// NewBatchWriter creates a batch writer; call Run to start writing
+func NewBatchWriter(repo repository.PositionRepository, batchSize int, flushInterval time.Duration) *BatchWriter {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+	return &BatchWriter{
+		repo:          repo,
+		queue:         make(chan []entity.VehiclePosition, queueLength),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+	}
+}
+
+// Enqueue hands positions over for writing
+func (w *BatchWriter) Enqueue(ctx context.Context, positions []entity.VehiclePosition) error {
+	if len(positions) == 0 {
+		return nil
+	}
+	select {
+	case w.queue <- positions:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Run writes queued positions until ctx is cancelled, then writes whatever is still queued
+func (w *BatchWriter) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	var pending []entity.VehiclePosition
+	for {
+		select {
+		case batch := <-w.queue:
+			pending = append(pending, batch...)
+			if len(pending) >= w.batchSize {
+				pending = w.flush(ctx, pending)
+			}
+		case <-ticker.C:
+			pending = w.flush(ctx, pending)
+		case <-ctx.Done():
+			w.drain(pending)
+			return
+		}
+	}
+}
+
+// drain writes the pending and queued positions with a fresh deadline after shutdown began
+func (w *BatchWriter) drain(pending []entity.VehiclePosition) {
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+
+	for {
+		select {
+		case batch := <-w.queue:
+			pending = append(pending, batch...)
+		default:
+			if rest := w.flush(ctx, pending); len(rest) > 0 {
+				log.Printf("[ERROR] position writer: dropped %d position(s) on shutdown", len(rest))
+			}
+			return
+		}
+	}
+}
+
+// flush writes the pending positions and returns those to retry on the next flush.
+// Positions the database refuses are dropped; on any other failure the unwritten positions are
+// kept unless that would exceed maxPendingBatches batches.
+func (w *BatchWriter) flush(ctx context.Context, pending []entity.VehiclePosition) []entity.VehiclePosition {
+	if len(pending) == 0 {
+		return pending
+	}
+
+	retry, err := w.write(ctx, pending)
+	if err != nil {
+		if ctx.Err() != nil {
+			return retry
+		}
+		if len(retry) > maxPendingBatches*w.batchSize {
+			log.Printf("[ERROR] position writer: dropped %d position(s): %v", len(retry), err)
+			return nil
+		}
+		log.Printf("[ERROR] position writer: %d position(s) will be retried: %v", len(retry), err)
+		return retry
+	}
+	return nil
+}
+
+// write stores the positions. When the database refuses the batch because of some of its positions,
+// the batch is split in halves until those positions are isolated and dropped, so that they do not
+// hold back the rest. It returns the positions left unwritten by any other error.
+func (w *BatchWriter) write(ctx context.Context, positions []entity.VehiclePosition) ([]entity.VehiclePosition, error) {
+	_, err := w.repo.CreateBatch(ctx, positions)
+	switch {
+	case err == nil:
+		return nil, nil
+	case !errors.Is(err, errs.ErrBadRequest):
+		return positions, err
+	case len(positions) == 1:
+		p := positions[0]
+		log.Printf("[ERROR] position writer: dropped the position of vehicle %s at %s: %v", p.VehicleID, p.RecordedAt.Format(time.RFC3339), err)
+		return nil, nil
+	}
+
+	mid := len(positions) / 2
+	retry, err := w.write(ctx, positions[:mid])
+	if err != nil {
+		return append(retry, positions[mid:]...), err
+	}
+	return w.write(ctx, positions[mid:])
+}
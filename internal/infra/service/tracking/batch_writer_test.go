@@ -0,0 +1,107 @@
+package tracking
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+
+	"github.com/google/uuid"
+)
+
+// fakePositionRepo refuses any batch holding a position of a deleted vehicle, like the foreign key does,
+// and fails everything while down
+type fakePositionRepo struct {
+	deleted map[uuid.UUID]bool
+	down    bool
+	stored  []entity.VehiclePosition
+	calls   int
+}
+
+func (r *fakePositionRepo) CreateBatch(_ context.Context, positions []entity.VehiclePosition) (int64, error) {
+	r.calls++
+	if r.down {
+		return 0, errors.New("connection refused")
+	}
+	for _, p := range positions {
+		if r.deleted[p.VehicleID] {
+			return 0, errs.ErrBadRequest
+		}
+	}
+	r.stored = append(r.stored, positions...)
+	return int64(len(positions)), nil
+}
+
+func (r *fakePositionRepo) ListTrack(context.Context, uuid.UUID, time.Time, time.Time, int) ([]entity.VehiclePosition, error) {
+	return nil, nil
+}
+
+func positionsOf(vehicles ...uuid.UUID) []entity.VehiclePosition {
+	start := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	positions := make([]entity.VehiclePosition, len(vehicles))
+	for i, v := range vehicles {
+		positions[i] = entity.VehiclePosition{VehicleID: v, RecordedAt: start.Add(time.Duration(i) * time.Second)}
+	}
+	return positions
+}
+
+func TestFlushDropsOnlyRefusedPositions(t *testing.T) {
+	good, gone := uuid.New(), uuid.New()
+	var vehicles []uuid.UUID
+	for i := 0; i < 37; i++ {
+		if i == 5 || i == 30 {
+			vehicles = append(vehicles, gone)
+		} else {
+			vehicles = append(vehicles, good)
+		}
+	}
+	repo := &fakePositionRepo{deleted: map[uuid.UUID]bool{gone: true}}
+	w := NewBatchWriter(repo, 10, time.Second)
+
+	if rest := w.flush(context.Background(), positionsOf(vehicles...)); len(rest) != 0 {
+		t.Errorf("%d position(s) left to retry, want none", len(rest))
+	}
+	if len(repo.stored) != 35 {
+		t.Fatalf("stored %d position(s), want the 35 of the existing vehicle", len(repo.stored))
+	}
+	for _, p := range repo.stored {
+		if p.VehicleID != good {
+			t.Errorf("stored a position of the deleted vehicle")
+		}
+	}
+}
+
+func TestFlushKeepsPositionsWhileDatabaseIsDown(t *testing.T) {
+	repo := &fakePositionRepo{down: true}
+	w := NewBatchWriter(repo, 10, time.Second)
+	positions := positionsOf(uuid.New(), uuid.New(), uuid.New())
+
+	rest := w.flush(context.Background(), positions)
+	if len(rest) != len(positions) {
+		t.Fatalf("%d position(s) kept, want all %d", len(rest), len(positions))
+	}
+	if repo.calls != 1 {
+		t.Errorf("%d write(s), want 1: an outage must not split the batch", repo.calls)
+	}
+
+	repo.down = false
+	if rest := w.flush(context.Background(), rest); len(rest) != 0 || len(repo.stored) != len(positions) {
+		t.Errorf("retry left %d and stored %d, want everything stored", len(rest), len(repo.stored))
+	}
+}
+
+func TestFlushDropsBeyondPendingLimit(t *testing.T) {
+	repo := &fakePositionRepo{down: true}
+	w := NewBatchWriter(repo, 1, time.Second)
+
+	vehicles := make([]uuid.UUID, maxPendingBatches+1)
+	for i := range vehicles {
+		vehicles[i] = uuid.New()
+	}
+	if rest := w.flush(context.Background(), positionsOf(vehicles...)); rest != nil {
+		t.Errorf("%d position(s) kept past the pending limit", len(rest))
+	}
+}
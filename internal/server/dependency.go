@@ -2,20 +2,118 @@ package server
 
 import (
 	"fmt"
+	"time"
 
 	"tms-core-service/internal/api/http/handler/auth"
+	"tms-core-service/internal/api/http/handler/carrier"
+	"tms-core-service/internal/api/http/handler/compliance"
+	"tms-core-service/internal/api/http/handler/dock"
+	"tms-core-service/internal/api/http/handler/document"
+	"tms-core-service/internal/api/http/handler/driver"
+	"tms-core-service/internal/api/http/handler/eta"
+	"tms-core-service/internal/api/http/handler/geocoding"
+	"tms-core-service/internal/api/http/handler/geofence"
 	"tms-core-service/internal/api/http/handler/healthcheck"
+	"tms-core-service/internal/api/http/handler/incident"
+	"tms-core-service/internal/api/http/handler/invoicing"
+	"tms-core-service/internal/api/http/handler/label"
+	"tms-core-service/internal/api/http/handler/loadplan"
+	"tms-core-service/internal/api/http/handler/location"
+	"tms-core-service/internal/api/http/handler/maintenance"
+	"tms-core-service/internal/api/http/handler/numbering"
+	"tms-core-service/internal/api/http/handler/organization"
+	"tms-core-service/internal/api/http/handler/planning"
+	"tms-core-service/internal/api/http/handler/pod"
+	"tms-core-service/internal/api/http/handler/pricing"
+	"tms-core-service/internal/api/http/handler/publictracking"
+	"tms-core-service/internal/api/http/handler/realtime"
+	"tms-core-service/internal/api/http/handler/settlement"
+	"tms-core-service/internal/api/http/handler/shipment"
+	"tms-core-service/internal/api/http/handler/tender"
+	"tms-core-service/internal/api/http/handler/tracking"
+	"tms-core-service/internal/api/http/handler/trip"
+	"tms-core-service/internal/api/http/handler/vehicle"
+	"tms-core-service/internal/api/http/handler/vehiclecost"
+	"tms-core-service/internal/api/http/middleware"
 	"tms-core-service/internal/api/http/route"
 	"tms-core-service/internal/config"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/service"
 	"tms-core-service/internal/infra/db"
+	carrierRepo "tms-core-service/internal/infra/db/repository/carrier"
+	complianceRepo "tms-core-service/internal/infra/db/repository/compliance"
+	deliveryAttemptRepo "tms-core-service/internal/infra/db/repository/deliveryattempt"
+	dieselPriceRepo "tms-core-service/internal/infra/db/repository/dieselprice"
+	dockRepo "tms-core-service/internal/infra/db/repository/dock"
+	driverRepo "tms-core-service/internal/infra/db/repository/driver"
+	expenseRepo "tms-core-service/internal/infra/db/repository/expense"
+	fuelLogRepo "tms-core-service/internal/infra/db/repository/fuellog"
+	geofenceRepo "tms-core-service/internal/infra/db/repository/geofence"
 	healthcheckRepo "tms-core-service/internal/infra/db/repository/healthcheck"
+	incidentRepo "tms-core-service/internal/infra/db/repository/incident"
+	invoiceRepo "tms-core-service/internal/infra/db/repository/invoice"
+	labelRepo "tms-core-service/internal/infra/db/repository/label"
+	laneSpeedRepo "tms-core-service/internal/infra/db/repository/lanespeed"
+	locationRepo "tms-core-service/internal/infra/db/repository/location"
+	maintenanceRepo "tms-core-service/internal/infra/db/repository/maintenance"
+	notificationRepo "tms-core-service/internal/infra/db/repository/notification"
+	numberingRepo "tms-core-service/internal/infra/db/repository/numbering"
+	organizationRepo "tms-core-service/internal/infra/db/repository/organization"
+	payRuleRepo "tms-core-service/internal/infra/db/repository/payrule"
+	podRepo "tms-core-service/internal/infra/db/repository/pod"
+	positionRepo "tms-core-service/internal/infra/db/repository/position"
+	rateCardRepo "tms-core-service/internal/infra/db/repository/ratecard"
+	settlementRepo "tms-core-service/internal/infra/db/repository/settlement"
+	shipmentRepo "tms-core-service/internal/infra/db/repository/shipment"
+	stopDelayRepo "tms-core-service/internal/infra/db/repository/stopdelay"
+	tenderRepo "tms-core-service/internal/infra/db/repository/tender"
+	tripRepo "tms-core-service/internal/infra/db/repository/trip"
 	userRepo "tms-core-service/internal/infra/db/repository/user"
+	vehicleRepo "tms-core-service/internal/infra/db/repository/vehicle"
+	realtimeSvc "tms-core-service/internal/infra/realtime"
 	"tms-core-service/internal/infra/redis"
+	addressSvc "tms-core-service/internal/infra/service/address"
+	documentSvc "tms-core-service/internal/infra/service/document"
+	geocodingSvc "tms-core-service/internal/infra/service/geocoding"
+	geometrySvc "tms-core-service/internal/infra/service/geometry"
 	hashSvc "tms-core-service/internal/infra/service/hash"
+	numberingSvc "tms-core-service/internal/infra/service/numbering"
+	packingSvc "tms-core-service/internal/infra/service/packing"
+	routingSvc "tms-core-service/internal/infra/service/routing"
 	storageSvc "tms-core-service/internal/infra/service/storage"
 	tokenSvc "tms-core-service/internal/infra/service/token"
+	trackingSvc "tms-core-service/internal/infra/service/tracking"
 	authUseCase "tms-core-service/internal/usecase/auth"
+	carrierUseCase "tms-core-service/internal/usecase/carrier"
+	complianceUseCase "tms-core-service/internal/usecase/compliance"
+	dockUseCase "tms-core-service/internal/usecase/dock"
+	documentUseCase "tms-core-service/internal/usecase/document"
+	driverUseCase "tms-core-service/internal/usecase/driver"
+	etaUseCase "tms-core-service/internal/usecase/eta"
+	geocodingUseCase "tms-core-service/internal/usecase/geocoding"
+	geofenceUseCase "tms-core-service/internal/usecase/geofence"
 	healthcheckUseCase "tms-core-service/internal/usecase/healthcheck"
+	incidentUseCase "tms-core-service/internal/usecase/incident"
+	invoicingUseCase "tms-core-service/internal/usecase/invoicing"
+	labelUseCase "tms-core-service/internal/usecase/label"
+	loadPlanUseCase "tms-core-service/internal/usecase/loadplan"
+	locationUseCase "tms-core-service/internal/usecase/location"
+	maintenanceUseCase "tms-core-service/internal/usecase/maintenance"
+	notificationUseCase "tms-core-service/internal/usecase/notification"
+	numberingUseCase "tms-core-service/internal/usecase/numbering"
+	organizationUseCase "tms-core-service/internal/usecase/organization"
+	planningUseCase "tms-core-service/internal/usecase/planning"
+	podUseCase "tms-core-service/internal/usecase/pod"
+	pricingUseCase "tms-core-service/internal/usecase/pricing"
+	publicTrackingUseCase "tms-core-service/internal/usecase/publictracking"
+	realtimeUseCase "tms-core-service/internal/usecase/realtime"
+	settlementUseCase "tms-core-service/internal/usecase/settlement"
+	shipmentUseCase "tms-core-service/internal/usecase/shipment"
+	tenderUseCase "tms-core-service/internal/usecase/tender"
+	trackingUseCase "tms-core-service/internal/usecase/tracking"
+	tripUseCase "tms-core-service/internal/usecase/trip"
+	vehicleUseCase "tms-core-service/internal/usecase/vehicle"
+	vehicleCostUseCase "tms-core-service/internal/usecase/vehiclecost"
 	"tms-core-service/pkg/jwt"
 
 	"github.com/gofiber/fiber/v2"
@@ -41,13 +139,85 @@ func WireDependencies(app *fiber.App, cfg *config.AppConfig) error {
 	// Initialize SOLID service wrappers (Domain Abstractions)
 	hashService := hashSvc.NewBcryptHashService()
 	tokenService := tokenSvc.NewJWTTokenService(jwtProvider)
+	addressDirectory := addressSvc.NewThaiAddressDirectory()
+	routeOptimizer := routingSvc.NewVRPOptimizer()
+	loadPlanner := packingSvc.NewLoadPlanner()
+	travelEstimator := routingSvc.NewStraightLineEstimator()
+	geometry := geometrySvc.NewGeometry()
+	documentRenderer, err := documentSvc.NewPDFRenderer(cfg.Documents.FontRegular, cfg.Documents.FontBold, cfg.Documents.Logo)
+	if err != nil {
+		return fmt.Errorf("failed to initialize document renderer: %w", err)
+	}
+	labelRenderer, err := documentSvc.NewLabelRenderer(cfg.Documents.FontRegular, cfg.Documents.FontBold)
+	if err != nil {
+		return fmt.Errorf("failed to initialize label renderer: %w", err)
+	}
 
 	// Initialize repositories
 	healthCheckRepo := healthcheckRepo.NewHealthCheckRepository(dbConn)
 	userRepository := userRepo.NewUserRepository(dbConn)
+	driverRepository := driverRepo.NewDriverRepository(dbConn)
+	organizationRepository := organizationRepo.NewOrganizationRepository(dbConn)
+	locationRepository := locationRepo.NewLocationRepository(dbConn)
+	vehicleRepository := vehicleRepo.NewVehicleRepository(dbConn)
+	shipmentRepository := shipmentRepo.NewShipmentRepository(dbConn)
+	tripRepository := tripRepo.NewTripRepository(dbConn)
+	rateCardRepository := rateCardRepo.NewRateCardRepository(dbConn)
+	dieselPriceRepository := dieselPriceRepo.NewDieselPriceRepository(dbConn)
+	carrierRepository := carrierRepo.NewCarrierRepository(dbConn)
+	tenderRepository := tenderRepo.NewTenderRepository(dbConn)
+	podRepository := podRepo.NewProofOfDeliveryRepository(dbConn)
+	deliveryAttemptRepository := deliveryAttemptRepo.NewDeliveryAttemptRepository(dbConn)
+	positionRepository := positionRepo.NewPositionRepository(dbConn)
+	geofenceRepository := geofenceRepo.NewGeofenceRepository(dbConn)
+	laneSpeedRepository := laneSpeedRepo.NewLaneSpeedRepository(dbConn)
+	stopDelayRepository := stopDelayRepo.NewStopDelayRepository(dbConn)
+	numberingRepository := numberingRepo.NewNumberingRepository(dbConn)
+	invoiceRepository := invoiceRepo.NewInvoiceRepository(dbConn)
+	labelRepository := labelRepo.NewLabelRepository(dbConn)
+	payRuleRepository := payRuleRepo.NewDriverPayRuleRepository(dbConn)
+	settlementRepository := settlementRepo.NewDriverSettlementRepository(dbConn)
+	fuelLogRepository := fuelLogRepo.NewFuelLogRepository(dbConn)
+	expenseRepository := expenseRepo.NewVehicleExpenseRepository(dbConn)
+	maintenancePlanRepository := maintenanceRepo.NewMaintenancePlanRepository(dbConn)
+	maintenanceScheduleRepository := maintenanceRepo.NewMaintenanceScheduleRepository(dbConn)
+	workOrderRepository := maintenanceRepo.NewWorkOrderRepository(dbConn)
+	complianceRepository := complianceRepo.NewComplianceDocumentRepository(dbConn)
+	dockRepository := dockRepo.NewDockRepository(dbConn)
+	dockAppointmentRepository := dockRepo.NewDockAppointmentRepository(dbConn)
+	incidentRepository := incidentRepo.NewIncidentRepository(dbConn)
+	notificationRepository := notificationRepo.NewNotificationRepository(dbConn)
+
+	// Initialize transaction manager
+	transactor := db.NewTransactor(dbConn)
+
+	// Initialize cache repository
+	cacheRepository := redis.NewCacheRepository(redisClient)
+	positionCache := redis.NewPositionCache(redisClient)
+	eventBus := redis.NewEventBus(redisClient)
+	hub := realtimeSvc.NewHub(cfg.Realtime.ClientBuffer, cfg.Realtime.MaxChannels)
+
+	// Initialize GPS history writer; it is started with the background workers
+	positionWriter := trackingSvc.NewBatchWriter(positionRepository, cfg.Tracking.BatchSize, cfg.Tracking.FlushInterval)
+
+	// Initialize document number generator with the configured default formats
+	numberGenerator, err := numberingSvc.NewGenerator(numberingRepository, cfg.Numbering.Formats)
+	if err != nil {
+		return fmt.Errorf("failed to initialize numbering: %w", err)
+	}
 
-	// Initialize cache repository (if needed)
-	_ = redis.NewCacheRepository(redisClient)
+	// Initialize geocoder: the offline dataset needs no cache, remote providers are cached in Redis
+	var geocoder service.Geocoder
+	switch cfg.Geocoding.Provider {
+	case "http":
+		geocoder = geocodingSvc.NewCachedGeocoder(
+			geocodingSvc.NewHTTPGeocoder(cfg.Geocoding.BaseURL, cfg.Geocoding.APIKey, cfg.Geocoding.Timeout),
+			cacheRepository,
+			cfg.Geocoding.CacheTTL,
+		)
+	default:
+		geocoder = geocodingSvc.NewOfflineGeocoder()
+	}
 
 	// Initialize S3 storage service
 	storageService := storageSvc.NewS3StorageService(
@@ -87,17 +257,311 @@ func WireDependencies(app *fiber.App, cfg *config.AppConfig) error {
 		int64(cfg.JWT.RefreshTokenExpiry.Hours()),
 	)
 
+	driverUC := driverUseCase.NewDriverUseCase(driverRepository, userRepository, transactor)
+	organizationUC := organizationUseCase.NewOrganizationUseCase(organizationRepository)
+	locationUC := locationUseCase.NewLocationUseCase(locationRepository, organizationRepository, addressDirectory, geocoder)
+	addressUC := locationUseCase.NewAddressUseCase(addressDirectory)
+	geocodingUC := geocodingUseCase.NewGeocodingUseCase(geocoder)
+	vehicleUC := vehicleUseCase.NewVehicleUseCase(vehicleRepository)
+	numberingUC := numberingUseCase.NewNumberingUseCase(numberingRepository, organizationRepository, numberGenerator)
+	shipmentUC := shipmentUseCase.NewShipmentUseCase(
+		shipmentRepository,
+		organizationRepository,
+		locationRepository,
+		tripRepository,
+		stopDelayRepository,
+		incidentRepository,
+		deliveryAttemptRepository,
+		numberGenerator,
+	)
+	complianceBlockingTypes := make([]entity.ComplianceDocumentType, len(cfg.Compliance.BlockingTypes))
+	for i, t := range cfg.Compliance.BlockingTypes {
+		complianceBlockingTypes[i] = entity.ComplianceDocumentType(t)
+	}
+	tripUC := tripUseCase.NewTripUseCase(
+		tripRepository,
+		vehicleRepository,
+		driverRepository,
+		shipmentRepository,
+		maintenanceScheduleRepository,
+		complianceRepository,
+		transactor,
+		eventBus,
+		numberGenerator,
+		complianceBlockingTypes,
+	)
+	planningUC := planningUseCase.NewPlanningUseCase(routeOptimizer, cacheRepository, cfg.Planning.Workers, cfg.Planning.QueueSize)
+	loadPlanUC := loadPlanUseCase.NewLoadPlanUseCase(loadPlanner)
+	rateCardUC := pricingUseCase.NewRateCardUseCase(rateCardRepository, dieselPriceRepository, organizationRepository)
+	pricingUC := pricingUseCase.NewPricingUseCase(rateCardRepository, dieselPriceRepository, shipmentRepository, locationRepository, travelEstimator)
+	carrierUC := carrierUseCase.NewCarrierUseCase(carrierRepository, userRepository)
+	tenderUC := tenderUseCase.NewTenderUseCase(
+		tenderRepository,
+		carrierRepository,
+		shipmentRepository,
+		tripRepository,
+		locationRepository,
+		complianceRepository,
+		transactor,
+		complianceBlockingTypes,
+	)
+	invoiceUC := invoicingUseCase.NewInvoiceUseCase(
+		invoiceRepository,
+		organizationRepository,
+		tripRepository,
+		vehicleRepository,
+		locationRepository,
+		pricingUC,
+		numberGenerator,
+		transactor,
+		cfg.Invoicing.VATRate,
+		cfg.Invoicing.WithholdingRate,
+		cfg.Invoicing.CreditTermDays,
+	)
+	documentUC := documentUseCase.NewDocumentUseCase(
+		invoiceRepository,
+		organizationRepository,
+		shipmentRepository,
+		locationRepository,
+		tripRepository,
+		vehicleRepository,
+		driverRepository,
+		podRepository,
+		storageService,
+		documentRenderer,
+		service.Party{
+			Name:       cfg.Documents.Company.Name,
+			TaxID:      cfg.Documents.Company.TaxID,
+			BranchCode: cfg.Documents.Company.BranchCode,
+			Address:    cfg.Documents.Company.Address,
+			Phone:      cfg.Documents.Company.Phone,
+		},
+		cfg.Documents.TrackingURL,
+	)
+	labelUC := labelUseCase.NewLabelUseCase(
+		labelRepository,
+		organizationRepository,
+		shipmentRepository,
+		locationRepository,
+		tripRepository,
+		numberingRepository,
+		labelRenderer,
+		transactor,
+		entity.LabelTemplate{
+			Size:             entity.LabelSize(cfg.Labels.Size),
+			DPI:              cfg.Labels.DPI,
+			ZPLFont:          cfg.Labels.ZPLFont,
+			GS1CompanyPrefix: cfg.Labels.GS1CompanyPrefix,
+			SSCCExtension:    cfg.Labels.SSCCExtension,
+			Footer:           cfg.Labels.Footer,
+		},
+		cfg.Documents.Company.Name,
+	)
+	payRuleUC := settlementUseCase.NewPayRuleUseCase(payRuleRepository)
+	settlementUC := settlementUseCase.NewSettlementUseCase(
+		settlementRepository,
+		payRuleRepository,
+		driverRepository,
+		vehicleRepository,
+		locationRepository,
+		travelEstimator,
+		numberGenerator,
+		transactor,
+	)
+	fuelBaselines := make(map[entity.VehicleType]float64, len(cfg.Fuel.BaselineKmPerLiter))
+	for vehicleType, kmPerLiter := range cfg.Fuel.BaselineKmPerLiter {
+		fuelBaselines[entity.VehicleType(vehicleType)] = kmPerLiter
+	}
+	vehicleCostUC := vehicleCostUseCase.NewVehicleCostUseCase(
+		fuelLogRepository,
+		expenseRepository,
+		vehicleRepository,
+		driverRepository,
+		tripRepository,
+		storageService,
+		transactor,
+		fuelBaselines,
+		cfg.Fuel.DeviationTolerance,
+	)
+	maintenancePlanUC := maintenanceUseCase.NewPlanUseCase(maintenancePlanRepository)
+	maintenanceUC := maintenanceUseCase.NewMaintenanceUseCase(
+		maintenanceScheduleRepository,
+		maintenancePlanRepository,
+		vehicleRepository,
+		transactor,
+		eventBus,
+		cfg.Maintenance.WarnKm,
+		cfg.Maintenance.WarnDays,
+	)
+	workOrderUC := maintenanceUseCase.NewWorkOrderUseCase(
+		workOrderRepository,
+		maintenancePlanRepository,
+		maintenanceScheduleRepository,
+		vehicleRepository,
+		expenseRepository,
+		numberGenerator,
+		transactor,
+		cfg.Maintenance.WarnKm,
+		cfg.Maintenance.WarnDays,
+	)
+	complianceUC := complianceUseCase.NewComplianceUseCase(
+		complianceRepository,
+		vehicleRepository,
+		driverRepository,
+		carrierRepository,
+		storageService,
+		transactor,
+		notificationRepository,
+		cfg.Compliance.ReminderDays,
+	)
+	notificationUC := notificationUseCase.NewNotificationUseCase(notificationRepository, eventBus, transactor)
+	dockUC := dockUseCase.NewDockUseCase(dockRepository, dockAppointmentRepository, locationRepository)
+	dockAppointmentUC := dockUseCase.NewAppointmentUseCase(
+		dockAppointmentRepository,
+		dockRepository,
+		tripRepository,
+		vehicleRepository,
+		carrierRepository,
+		tenderRepository,
+		transactor,
+	)
+	incidentResolveWithin := make(map[entity.IncidentSeverity]time.Duration, len(cfg.Incidents.ResolveWithin))
+	for severity, d := range cfg.Incidents.ResolveWithin {
+		incidentResolveWithin[entity.IncidentSeverity(severity)] = d
+	}
+	incidentUC := incidentUseCase.NewIncidentUseCase(
+		incidentRepository,
+		tripRepository,
+		shipmentRepository,
+		userRepository,
+		storageService,
+		numberGenerator,
+		transactor,
+		notificationRepository,
+		incidentResolveWithin,
+	)
+	podUC := podUseCase.NewProofOfDeliveryUseCase(
+		podRepository,
+		deliveryAttemptRepository,
+		tripRepository,
+		shipmentRepository,
+		locationRepository,
+		driverRepository,
+		vehicleRepository,
+		storageService,
+		geometry,
+		documentRenderer,
+		eventBus,
+		numberGenerator,
+		transactor,
+		cfg.Delivery.MaxDistanceM,
+		cfg.Delivery.MaxAttempts,
+	)
+
+	geofenceUC := geofenceUseCase.NewGeofenceUseCase(geofenceRepository, locationRepository, tripRepository, cacheRepository, geometry, eventBus, transactor)
+	etaUC := etaUseCase.NewETAUseCase(
+		tripRepository,
+		shipmentRepository,
+		locationRepository,
+		laneSpeedRepository,
+		stopDelayRepository,
+		cacheRepository,
+		travelEstimator,
+		eventBus,
+		transactor,
+		cfg.ETA.DefaultServiceTime,
+		cfg.ETA.DelayMargin,
+		cfg.ETA.MinInterval,
+	)
+	publicTrackingUC := publicTrackingUseCase.NewPublicTrackingUseCase(
+		shipmentRepository,
+		tripRepository,
+		locationRepository,
+		stopDelayRepository,
+		deliveryAttemptRepository,
+		cacheRepository,
+		numberGenerator,
+		eventBus,
+		cfg.PublicTracking.RequireChallenge,
+		cfg.PublicTracking.CacheTTL,
+	)
+	realtimeUC := realtimeUseCase.NewRealtimeUseCase(hub, eventBus, userRepository, carrierRepository, driverRepository, tripRepository)
+	// live positions are pushed first; geofences run before ETAs so ETAs see the stops they advance
+	trackingUC := trackingUseCase.NewTrackingUseCase(vehicleRepository, positionRepository, positionCache, positionWriter, geometry, realtimeUC, geofenceUC, etaUC)
+
 	// Initialize handlers
 	healthCheckHandler := healthcheck.NewHandler(healthCheckUC)
 	authHandler := auth.NewHandler(authUC, googleAuthUC, lineAuthUC, cfg.Server.FrontendURL)
+	driverHandler := driver.NewHandler(driverUC)
+	organizationHandler := organization.NewHandler(organizationUC)
+	locationHandler := location.NewHandler(locationUC, addressUC)
+	numberingHandler := numbering.NewHandler(numberingUC)
+	geocodingHandler := geocoding.NewHandler(geocodingUC)
+	vehicleHandler := vehicle.NewHandler(vehicleUC)
+	shipmentHandler := shipment.NewHandler(shipmentUC)
+	tripHandler := trip.NewHandler(tripUC)
+	planningHandler := planning.NewHandler(planningUC)
+	loadPlanHandler := loadplan.NewHandler(loadPlanUC)
+	pricingHandler := pricing.NewHandler(rateCardUC, pricingUC)
+	carrierHandler := carrier.NewHandler(carrierUC)
+	tenderHandler := tender.NewHandler(tenderUC)
+	invoiceHandler := invoicing.NewHandler(invoiceUC)
+	documentHandler := document.NewHandler(documentUC)
+	labelHandler := label.NewHandler(labelUC)
+	settlementHandler := settlement.NewHandler(payRuleUC, settlementUC)
+	vehicleCostHandler := vehiclecost.NewHandler(vehicleCostUC)
+	maintenanceHandler := maintenance.NewHandler(maintenancePlanUC, maintenanceUC, workOrderUC)
+	complianceHandler := compliance.NewHandler(complianceUC)
+	dockHandler := dock.NewHandler(dockUC, dockAppointmentUC)
+	incidentHandler := incident.NewHandler(incidentUC)
+	podHandler := pod.NewHandler(podUC)
+	trackingHandler := tracking.NewHandler(trackingUC)
+	geofenceHandler := geofence.NewHandler(geofenceUC)
+	etaHandler := eta.NewHandler(etaUC)
+	publicTrackHandler := publictracking.NewHandler(publicTrackingUC)
+	realtimeHandler := realtime.NewHandler(realtimeUC, cfg.Realtime.HeartbeatInterval, cfg.Realtime.WriteTimeout)
 
 	// Setup routes
 	deps := &route.Dependencies{
-		HealthCheckHandler: healthCheckHandler,
-		AuthHandler:        authHandler,
-		JWTService:         jwtProvider,
+		HealthCheckHandler:  healthCheckHandler,
+		AuthHandler:         authHandler,
+		DriverHandler:       driverHandler,
+		OrganizationHandler: organizationHandler,
+		LocationHandler:     locationHandler,
+		NumberingHandler:    numberingHandler,
+		GeocodingHandler:    geocodingHandler,
+		VehicleHandler:      vehicleHandler,
+		ShipmentHandler:     shipmentHandler,
+		TripHandler:         tripHandler,
+		PlanningHandler:     planningHandler,
+		LoadPlanHandler:     loadPlanHandler,
+		PricingHandler:      pricingHandler,
+		CarrierHandler:      carrierHandler,
+		TenderHandler:       tenderHandler,
+		InvoiceHandler:      invoiceHandler,
+		DocumentHandler:     documentHandler,
+		LabelHandler:        labelHandler,
+		SettlementHandler:   settlementHandler,
+		VehicleCostHandler:  vehicleCostHandler,
+		MaintenanceHandler:  maintenanceHandler,
+		ComplianceHandler:   complianceHandler,
+		DockHandler:         dockHandler,
+		IncidentHandler:     incidentHandler,
+		PODHandler:          podHandler,
+		TrackingHandler:     trackingHandler,
+		GeofenceHandler:     geofenceHandler,
+		ETAHandler:          etaHandler,
+		RealtimeHandler:     realtimeHandler,
+		PublicTrackHandler:  publicTrackHandler,
+		PublicRateLimiter:   middleware.RateLimit(cacheRepository, "track", cfg.PublicTracking.RateLimit, cfg.PublicTracking.RateWindow),
+		JWTService:          jwtProvider,
 	}
 	route.SetupRoutes(app, deps)
 
+	// Start background jobs
+	StartWorkers(app, tenderUC, cfg.Tendering.SweepInterval, maintenanceUC, cfg.Maintenance.CheckInterval,
+		complianceUC, cfg.Compliance.ReminderInterval, incidentUC, cfg.Incidents.CheckInterval,
+		notificationUC, cfg.Notifications.DispatchInterval, planningUC, trackingUC, positionWriter, eventBus, hub)
+
 	return nil
 }
@@ -24,6 +24,11 @@ func NewServer(cfg *config.AppConfig) (*Server, error) {
 		ReadTimeout:  cfg.Server.Timeout.Read,
 		WriteTimeout: cfg.Server.Timeout.Write,
 		IdleTimeout:  cfg.Server.Timeout.Idle,
+		// c.IP() reads ProxyHeader only on requests from a trusted proxy
+		ProxyHeader:             cfg.Server.ProxyHeader,
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          cfg.Server.TrustedProxies,
+		EnableIPValidation:      true,
 	})
 
 	// Apply global middleware
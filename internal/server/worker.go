@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	realtimeSvc "tms-core-service/internal/infra/realtime"
+	"tms-core-service/internal/infra/redis"
+	trackingSvc "tms-core-service/internal/infra/service/tracking"
+	complianceUseCase "tms-core-service/internal/usecase/compliance"
+	incidentUseCase "tms-core-service/internal/usecase/incident"
+	maintenanceUseCase "tms-core-service/internal/usecase/maintenance"
+	notificationUseCase "tms-core-service/internal/usecase/notification"
+	planningUseCase "tms-core-service/internal/usecase/planning"
+	tenderUseCase "tms-core-service/internal/usecase/tender"
+	trackingUseCase "tms-core-service/internal/usecase/tracking"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// defaultTenderSweepInterval is used when tendering.sweep_interval is not configured
+	defaultTenderSweepInterval = 30 * time.Second
+
+	// defaultMaintenanceCheckInterval is used when maintenance.check_interval is not configured
+	defaultMaintenanceCheckInterval = time.Hour
+
+	// defaultComplianceReminderInterval is used when compliance.reminder_interval is not configured
+	defaultComplianceReminderInterval = 24 * time.Hour
+
+	// defaultIncidentCheckInterval is used when incidents.check_interval is not configured
+	defaultIncidentCheckInterval = 5 * time.Minute
+
+	// defaultNotificationDispatchInterval is used when notifications.dispatch_interval is not configured
+	defaultNotificationDispatchInterval = 5 * time.Second
+)
+
+// StartWorkers runs background jobs until the app shuts down.
+// Shutdown waits for the position writer to flush what it has queued.
+func StartWorkers(
+	app *fiber.App,
+	tenderUC *tenderUseCase.TenderUseCase,
+	tenderSweepInterval time.Duration,
+	maintenanceUC *maintenanceUseCase.MaintenanceUseCase,
+	maintenanceCheckInterval time.Duration,
+	complianceUC *complianceUseCase.ComplianceUseCase,
+	complianceReminderInterval time.Duration,
+	incidentUC *incidentUseCase.IncidentUseCase,
+	incidentCheckInterval time.Duration,
+	notificationUC *notificationUseCase.NotificationUseCase,
+	notificationDispatchInterval time.Duration,
+	planningUC *planningUseCase.PlanningUseCase,
+	trackingUC *trackingUseCase.TrackingUseCase,
+	positionWriter *trackingSvc.BatchWriter,
+	eventBus *redis.EventBus,
+	hub *realtimeSvc.Hub,
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	app.Hooks().OnShutdown(func() error {
+		cancel()
+		wg.Wait()
+		return nil
+	})
+
+	if tenderSweepInterval <= 0 {
+		tenderSweepInterval = defaultTenderSweepInterval
+	}
+	go runTenderSweeper(ctx, tenderUC, tenderSweepInterval)
+
+	if maintenanceCheckInterval <= 0 {
+		maintenanceCheckInterval = defaultMaintenanceCheckInterval
+	}
+	go runMaintenanceScheduler(ctx, maintenanceUC, maintenanceCheckInterval)
+
+	if complianceReminderInterval <= 0 {
+		complianceReminderInterval = defaultComplianceReminderInterval
+	}
+	go runComplianceReminder(ctx, complianceUC, complianceReminderInterval)
+
+	if incidentCheckInterval <= 0 {
+		incidentCheckInterval = defaultIncidentCheckInterval
+	}
+	go runIncidentSLAChecker(ctx, incidentUC, incidentCheckInterval)
+
+	if notificationDispatchInterval <= 0 {
+		notificationDispatchInterval = defaultNotificationDispatchInterval
+	}
+	go runNotificationDispatcher(ctx, notificationUC, notificationDispatchInterval)
+
+	go planningUC.Run(ctx)
+
+	// geofence, ETA and realtime updates for ingested positions
+	go trackingUC.Run(ctx)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		positionWriter.Run(ctx)
+	}()
+
+	// relay realtime events published by any instance to the clients connected here
+	go eventBus.Run(ctx, hub.Dispatch)
+}
+
+// runTenderSweeper rolls tenders over to the next carrier once an offer passes its deadline
+func runTenderSweeper(ctx context.Context, uc *tenderUseCase.TenderUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := uc.ExpireOverdue(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("[ERROR] tender sweeper: %v", err)
+			}
+			if expired > 0 {
+				log.Printf("[INFO] tender sweeper: rolled over %d expired offer(s)", expired)
+			}
+		}
+	}
+}
+
+// runMaintenanceScheduler keeps the vehicles' maintenance schedules up to date and raises due and overdue alerts
+func runMaintenanceScheduler(ctx context.Context, uc *maintenanceUseCase.MaintenanceUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			alerts, err := uc.CheckSchedules(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("[ERROR] maintenance scheduler: %v", err)
+			}
+			if alerts > 0 {
+				log.Printf("[INFO] maintenance scheduler: raised %d due or overdue alert(s)", alerts)
+			}
+		}
+	}
+}
+
+// runComplianceReminder reminds of expiring and expired compliance documents. It also runs on startup, since
+// the interval is long; each reminder is sent once however often it runs.
+func runComplianceReminder(ctx context.Context, uc *complianceUseCase.ComplianceUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := uc.SendReminders(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[ERROR] compliance reminder: %v", err)
+		}
+		if sent > 0 {
+			log.Printf("[INFO] compliance reminder: sent %d expiry reminder(s)", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runIncidentSLAChecker announces open incidents that have run past their SLA
+func runIncidentSLAChecker(ctx context.Context, uc *incidentUseCase.IncidentUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			breached, err := uc.CheckSLA(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("[ERROR] incident sla checker: %v", err)
+			}
+			if breached > 0 {
+				log.Printf("[INFO] incident sla checker: announced %d sla breach(es)", breached)
+			}
+		}
+	}
+}
+
+// runNotificationDispatcher delivers the notifications recorded in the outbox, retrying failed deliveries
+func runNotificationDispatcher(ctx context.Context, uc *notificationUseCase.NotificationUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := uc.Dispatch(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("[ERROR] notification dispatcher: %v", err)
+			}
+		}
+	}
+}
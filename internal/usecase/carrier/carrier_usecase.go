@@ -0,0 +1,212 @@
+package carrier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// defaultBranchCode is the Revenue Department branch code of a head office
+const defaultBranchCode = "00000"
+
+// CarrierUseCase handles carrier master data and carrier user accounts
+type CarrierUseCase struct {
+	carrierRepo repository.CarrierRepository
+	userRepo    repository.UserRepository
+}
+
+// NewCarrierUseCase creates a new carrier use case
+func NewCarrierUseCase(carrierRepo repository.CarrierRepository, userRepo repository.UserRepository) *CarrierUseCase {
+	return &CarrierUseCase{carrierRepo: carrierRepo, userRepo: userRepo}
+}
+
+// Create creates a new carrier
+func (uc *CarrierUseCase) Create(ctx context.Context, input CarrierInput) (*CarrierOutput, error) {
+	carrier := &entity.Carrier{}
+	applyInput(carrier, input)
+
+	if err := uc.carrierRepo.Create(ctx, carrier); err != nil {
+		return nil, fmt.Errorf("carrier repository: create carrier: %w", err)
+	}
+
+	return toCarrierOutput(carrier, time.Now()), nil
+}
+
+// Get returns a carrier by ID
+func (uc *CarrierUseCase) Get(ctx context.Context, id uuid.UUID) (*CarrierOutput, error) {
+	carrier, err := uc.findCarrier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toCarrierOutput(carrier, time.Now()), nil
+}
+
+// List returns carriers matching the input criteria
+func (uc *CarrierUseCase) List(ctx context.Context, input ListCarriersInput) ([]*CarrierOutput, int64, error) {
+	carriers, total, err := uc.carrierRepo.List(ctx, repository.CarrierFilter{
+		Status:      input.Status,
+		VehicleType: input.VehicleType,
+		Province:    input.Province,
+		Search:      input.Search,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("carrier repository: list carriers: %w", err)
+	}
+
+	now := time.Now()
+	outputs := make([]*CarrierOutput, len(carriers))
+	for i, c := range carriers {
+		outputs[i] = toCarrierOutput(c, now)
+	}
+	return outputs, total, nil
+}
+
+// Update updates a carrier
+func (uc *CarrierUseCase) Update(ctx context.Context, id uuid.UUID, input CarrierInput) (*CarrierOutput, error) {
+	carrier, err := uc.findCarrier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applyInput(carrier, input)
+	if err := uc.carrierRepo.Update(ctx, carrier); err != nil {
+		return nil, fmt.Errorf("carrier repository: update carrier: %w", err)
+	}
+
+	return toCarrierOutput(carrier, time.Now()), nil
+}
+
+// Delete soft deletes a carrier
+func (uc *CarrierUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := uc.carrierRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("carrier repository: delete carrier: %w", err)
+	}
+	return nil
+}
+
+// AddUser lets an existing user account act for the carrier on the carrier API
+func (uc *CarrierUseCase) AddUser(ctx context.Context, carrierID, userID uuid.UUID) (*CarrierUserOutput, error) {
+	if _, err := uc.findCarrier(ctx, carrierID); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("user repository: find by id: %w", err)
+	}
+
+	if err := uc.carrierRepo.AddUser(ctx, carrierID, userID); err != nil {
+		return nil, fmt.Errorf("carrier repository: add user: %w", err)
+	}
+
+	return toCarrierUserOutput(user), nil
+}
+
+// RemoveUser revokes a user account's access to the carrier API
+func (uc *CarrierUseCase) RemoveUser(ctx context.Context, carrierID, userID uuid.UUID) error {
+	if err := uc.carrierRepo.RemoveUser(ctx, carrierID, userID); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("carrier repository: remove user: %w", err)
+	}
+	return nil
+}
+
+// ListUsers returns the user accounts acting for the carrier
+func (uc *CarrierUseCase) ListUsers(ctx context.Context, carrierID uuid.UUID) ([]*CarrierUserOutput, error) {
+	if _, err := uc.findCarrier(ctx, carrierID); err != nil {
+		return nil, err
+	}
+
+	ids, err := uc.carrierRepo.ListUserIDs(ctx, carrierID)
+	if err != nil {
+		return nil, fmt.Errorf("carrier repository: list user ids: %w", err)
+	}
+
+	outputs := make([]*CarrierUserOutput, 0, len(ids))
+	for _, id := range ids {
+		user, err := uc.userRepo.FindByID(ctx, id)
+		if err != nil {
+			// Deleted user accounts keep their link but no longer act for the carrier
+			if errors.Is(err, errs.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("user repository: find by id: %w", err)
+		}
+		outputs = append(outputs, toCarrierUserOutput(user))
+	}
+	return outputs, nil
+}
+
+func (uc *CarrierUseCase) findCarrier(ctx context.Context, id uuid.UUID) (*entity.Carrier, error) {
+	carrier, err := uc.carrierRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("carrier repository: find by id: %w", err)
+	}
+	return carrier, nil
+}
+
+func applyInput(carrier *entity.Carrier, input CarrierInput) {
+	carrier.Name = input.Name
+	carrier.TaxID = input.TaxID
+	carrier.BranchCode = input.BranchCode
+	if carrier.BranchCode == "" {
+		carrier.BranchCode = defaultBranchCode
+	}
+	carrier.ContactName = input.ContactName
+	carrier.ContactPhone = input.ContactPhone
+	carrier.ContactEmail = input.ContactEmail
+	carrier.FleetTypes = input.FleetTypes
+	carrier.ServiceAreas = input.ServiceAreas
+	carrier.InsuranceExpiry = input.InsuranceExpiry
+	carrier.Status = input.Status
+	if carrier.Status == "" {
+		carrier.Status = entity.CarrierStatusActive
+	}
+}
+
+func toCarrierOutput(c *entity.Carrier, now time.Time) *CarrierOutput {
+	return &CarrierOutput{
+		ID:              c.ID,
+		Name:            c.Name,
+		TaxID:           c.TaxID,
+		BranchCode:      c.BranchCode,
+		ContactName:     c.ContactName,
+		ContactPhone:    c.ContactPhone,
+		ContactEmail:    c.ContactEmail,
+		FleetTypes:      c.FleetTypes,
+		ServiceAreas:    c.ServiceAreas,
+		InsuranceExpiry: c.InsuranceExpiry,
+		Insured:         c.IsInsured(now),
+		Status:          c.Status,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+func toCarrierUserOutput(u *entity.User) *CarrierUserOutput {
+	return &CarrierUserOutput{
+		UserID:      u.ID,
+		FirstName:   u.FirstName,
+		LastName:    u.LastName,
+		Email:       u.Email,
+		PhoneNumber: u.PhoneNumber,
+	}
+}
@@ -0,0 +1,60 @@
+package carrier
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// CarrierInput represents data for creating or updating a carrier
+type CarrierInput struct {
+	Name            string
+	TaxID           string
+	BranchCode      string
+	ContactName     string
+	ContactPhone    string
+	ContactEmail    string
+	FleetTypes      []entity.VehicleType
+	ServiceAreas    []string
+	InsuranceExpiry *time.Time
+	Status          entity.CarrierStatus
+}
+
+// ListCarriersInput represents criteria for listing carriers
+type ListCarriersInput struct {
+	Status      *entity.CarrierStatus
+	VehicleType *entity.VehicleType
+	Province    string
+	Search      string
+	Limit       int
+	Offset      int
+}
+
+// CarrierOutput represents carrier output data
+type CarrierOutput struct {
+	ID              uuid.UUID
+	Name            string
+	TaxID           string
+	BranchCode      string
+	ContactName     string
+	ContactPhone    string
+	ContactEmail    string
+	FleetTypes      []entity.VehicleType
+	ServiceAreas    []string
+	InsuranceExpiry *time.Time
+	Insured         bool
+	Status          entity.CarrierStatus
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// CarrierUserOutput represents a user account acting for a carrier
+type CarrierUserOutput struct {
+	UserID      uuid.UUID
+	FirstName   string
+	LastName    string
+	Email       *string
+	PhoneNumber *string
+}
@@ -0,0 +1,378 @@
+package compliance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+// reminderBatchSize is how many documents a reminder run loads at a time
+const reminderBatchSize = 100
+
+// DefaultReminderDays are the days before expiry reminders are sent at when not configured
+var DefaultReminderDays = []int{60, 30, 7}
+
+// fileExtensions maps the accepted document file content types to file extensions
+var fileExtensions = map[string]string{
+	"application/pdf": "pdf",
+	"image/jpeg":      "jpg",
+	"image/png":       "png",
+}
+
+// ownerChannels maps owner types to the realtime channel kind their reminders are published on
+var ownerChannels = map[entity.ComplianceOwnerType]string{
+	entity.ComplianceOwnerVehicle: service.ChannelVehicle,
+	entity.ComplianceOwnerDriver:  service.ChannelDriver,
+	entity.ComplianceOwnerCarrier: service.ChannelCarrier,
+}
+
+// ComplianceUseCase keeps the registry of expiring vehicle, driver and carrier documents and reminds of their expiry
+type ComplianceUseCase struct {
+	documentRepo     repository.ComplianceDocumentRepository
+	vehicleRepo      repository.VehicleRepository
+	driverRepo       repository.DriverRepository
+	carrierRepo      repository.CarrierRepository
+	storageService   service.StorageService
+	transactor       repository.Transactor
+	notificationRepo repository.NotificationRepository
+	reminderDays     []int
+}
+
+// NewComplianceUseCase creates a new compliance use case.
+// Reminders are sent reminderDays before expiry and once expired; empty uses DefaultReminderDays.
+func NewComplianceUseCase(
+	documentRepo repository.ComplianceDocumentRepository,
+	vehicleRepo repository.VehicleRepository,
+	driverRepo repository.DriverRepository,
+	carrierRepo repository.CarrierRepository,
+	storageService service.StorageService,
+	transactor repository.Transactor,
+	notificationRepo repository.NotificationRepository,
+	reminderDays []int,
+) *ComplianceUseCase {
+	days := slices.DeleteFunc(slices.Clone(reminderDays), func(d int) bool { return d <= 0 })
+	if len(days) == 0 {
+		days = DefaultReminderDays
+	}
+	return &ComplianceUseCase{
+		documentRepo:     documentRepo,
+		vehicleRepo:      vehicleRepo,
+		driverRepo:       driverRepo,
+		carrierRepo:      carrierRepo,
+		storageService:   storageService,
+		transactor:       transactor,
+		notificationRepo: notificationRepo,
+		reminderDays:     days,
+	}
+}
+
+// UploadURL issues a presigned URL for the file of a vehicle's, driver's or carrier's document
+func (uc *ComplianceUseCase) UploadURL(ctx context.Context, ownerType entity.ComplianceOwnerType, ownerID uuid.UUID, contentType string) (*UploadURLOutput, error) {
+	if err := uc.checkOwner(ctx, ownerType, ownerID); err != nil {
+		return nil, err
+	}
+
+	ext, ok := fileExtensions[contentType]
+	if !ok {
+		return nil, errs.ValidationErrors{"content_type": {"unsupported"}}
+	}
+	key := fmt.Sprintf("%s%s.%s", filePrefix(ownerType, ownerID), uuid.New(), ext)
+
+	url, err := uc.storageService.GenerateUploadURL(ctx, key, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("storage service: generate upload url: %w", err)
+	}
+	return &UploadURLOutput{UploadURL: url, ObjectKey: key}, nil
+}
+
+// Create records a document. A document expiring after the owner's others of its type supersedes them.
+func (uc *ComplianceUseCase) Create(ctx context.Context, input DocumentInput) (*DocumentOutput, error) {
+	if !input.Type.AppliesTo(input.OwnerType) {
+		return nil, errs.ValidationErrors{"type": {"not_applicable"}}
+	}
+	if err := validateDates(input.IssuedOn, input.ExpiresOn); err != nil {
+		return nil, err
+	}
+	if err := uc.checkOwner(ctx, input.OwnerType, input.OwnerID); err != nil {
+		return nil, err
+	}
+	if err := uc.checkFile(ctx, input.OwnerType, input.OwnerID, input.FileKey); err != nil {
+		return nil, err
+	}
+
+	createdBy := input.CreatedBy
+	doc := &entity.ComplianceDocument{
+		OwnerType: input.OwnerType,
+		OwnerID:   input.OwnerID,
+		Type:      input.Type,
+		Number:    strings.TrimSpace(input.Number),
+		IssuedOn:  input.IssuedOn,
+		ExpiresOn: input.ExpiresOn,
+		FileKey:   input.FileKey,
+		Notes:     input.Notes,
+		CreatedBy: &createdBy,
+	}
+	if err := uc.documentRepo.Create(ctx, doc); err != nil {
+		return nil, fmt.Errorf("compliance document repository: create document: %w", err)
+	}
+	return uc.toOutput(ctx, doc)
+}
+
+// Update changes a document's details; a new expiry date starts its reminders over
+func (uc *ComplianceUseCase) Update(ctx context.Context, id uuid.UUID, input UpdateDocumentInput) (*DocumentOutput, error) {
+	if err := validateDates(input.IssuedOn, input.ExpiresOn); err != nil {
+		return nil, err
+	}
+
+	var doc *entity.ComplianceDocument
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		// The lock keeps a reminder run from overwriting the change
+		var err error
+		doc, err = uc.documentRepo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return errs.ErrNotFound
+			}
+			return fmt.Errorf("compliance document repository: find by id for update: %w", err)
+		}
+		if input.FileKey != doc.FileKey {
+			if err := uc.checkFile(ctx, doc.OwnerType, doc.OwnerID, input.FileKey); err != nil {
+				return err
+			}
+		}
+
+		doc.Number = strings.TrimSpace(input.Number)
+		doc.IssuedOn = input.IssuedOn
+		doc.Reschedule(input.ExpiresOn)
+		doc.FileKey = input.FileKey
+		doc.Notes = input.Notes
+		if err := uc.documentRepo.Update(ctx, doc); err != nil {
+			return fmt.Errorf("compliance document repository: update document: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.toOutput(ctx, doc)
+}
+
+// Delete deletes a document, e.g. one recorded in error; the owner's previous document of its type counts again
+func (uc *ComplianceUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := uc.documentRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("compliance document repository: delete document: %w", err)
+	}
+	return nil
+}
+
+// Get returns a document by ID with a link to download its file
+func (uc *ComplianceUseCase) Get(ctx context.Context, id uuid.UUID) (*DocumentOutput, error) {
+	doc, err := uc.documentRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("compliance document repository: find by id: %w", err)
+	}
+	return uc.toOutput(ctx, doc)
+}
+
+// List returns the documents matching the input criteria, soonest expiry first
+func (uc *ComplianceUseCase) List(ctx context.Context, input ListDocumentsInput) ([]*DocumentOutput, int64, error) {
+	filter := repository.ComplianceDocumentFilter{
+		OwnerType: input.OwnerType,
+		OwnerID:   input.OwnerID,
+		Type:      input.Type,
+	}
+	if input.ExpiringWithin != nil {
+		before := timeutil.CalendarDay(time.Now()).AddDate(0, 0, *input.ExpiringWithin)
+		filter.ExpiringBefore = &before
+	}
+
+	docs, total, err := uc.documentRepo.List(ctx, filter, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("compliance document repository: list documents: %w", err)
+	}
+
+	outputs := make([]*DocumentOutput, len(docs))
+	for i, d := range docs {
+		if outputs[i], err = uc.toOutput(ctx, d); err != nil {
+			return nil, 0, err
+		}
+	}
+	return outputs, total, nil
+}
+
+// SendReminders records a reminder in the notification outbox for each document that reached a reminder stage
+// since the last run, to be delivered on its owner's channel, and returns how many reminders were recorded.
+// Each stage is reminded of once, so runs may overlap or repeat.
+// It is run daily by a background worker.
+func (uc *ComplianceUseCase) SendReminders(ctx context.Context) (int, error) {
+	now := time.Now()
+	today := timeutil.CalendarDay(now)
+	before := today.AddDate(0, 0, slices.Max(uc.reminderDays))
+
+	// Reminding takes documents out of the listing, so every page is loaded before any is reminded
+	var docs []*entity.ComplianceDocument
+	for offset := 0; ; offset += reminderBatchSize {
+		batch, err := uc.documentRepo.ListUnreminded(ctx, before, reminderBatchSize, offset)
+		if err != nil {
+			return 0, fmt.Errorf("compliance document repository: list unreminded: %w", err)
+		}
+		docs = append(docs, batch...)
+		if len(batch) < reminderBatchSize {
+			break
+		}
+	}
+
+	sent := 0
+	for _, d := range docs {
+		reminded := false
+		err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+			doc, err := uc.documentRepo.FindByIDForUpdate(ctx, d.ID)
+			if err != nil {
+				if errors.Is(err, errs.ErrNotFound) {
+					return nil
+				}
+				return fmt.Errorf("compliance document repository: find by id for update: %w", err)
+			}
+			stage, due := doc.ReminderDue(today, uc.reminderDays)
+			if !due {
+				return nil
+			}
+			doc.Reminded(stage, now)
+			if err := uc.documentRepo.Update(ctx, doc); err != nil {
+				return fmt.Errorf("compliance document repository: update document: %w", err)
+			}
+			if err := uc.notificationRepo.Create(ctx, service.NewNotification(complianceReminder(doc, today, now))); err != nil {
+				return fmt.Errorf("notification repository: create notification: %w", err)
+			}
+			reminded = true
+			return nil
+		})
+		if err != nil {
+			return sent, err
+		}
+		if reminded {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// checkOwner confirms the vehicle, driver or carrier exists
+func (uc *ComplianceUseCase) checkOwner(ctx context.Context, ownerType entity.ComplianceOwnerType, ownerID uuid.UUID) error {
+	var err error
+	switch ownerType {
+	case entity.ComplianceOwnerVehicle:
+		_, err = uc.vehicleRepo.FindByID(ctx, ownerID)
+	case entity.ComplianceOwnerDriver:
+		_, err = uc.driverRepo.FindByID(ctx, ownerID)
+	case entity.ComplianceOwnerCarrier:
+		_, err = uc.carrierRepo.FindByID(ctx, ownerID)
+	default:
+		return errs.ValidationErrors{"owner_type": {"invalid"}}
+	}
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("%s repository: find by id: %w", ownerType, err)
+	}
+	return nil
+}
+
+// checkFile confirms that a file key was issued for the owner and that the file has been uploaded
+func (uc *ComplianceUseCase) checkFile(ctx context.Context, ownerType entity.ComplianceOwnerType, ownerID uuid.UUID, key string) error {
+	if key == "" {
+		return nil
+	}
+	if !strings.HasPrefix(key, filePrefix(ownerType, ownerID)) {
+		return errs.ValidationErrors{"file_key": {"invalid"}}
+	}
+	ok, err := uc.storageService.ObjectExists(ctx, key)
+	if err != nil {
+		return fmt.Errorf("storage service: object exists: %w", err)
+	}
+	if !ok {
+		return errs.ValidationErrors{"file_key": {"not_uploaded"}}
+	}
+	return nil
+}
+
+func (uc *ComplianceUseCase) toOutput(ctx context.Context, d *entity.ComplianceDocument) (*DocumentOutput, error) {
+	var url string
+	if d.FileKey != "" {
+		var err error
+		if url, err = uc.storageService.GenerateDownloadURL(ctx, d.FileKey); err != nil {
+			return nil, fmt.Errorf("storage service: generate download url: %w", err)
+		}
+	}
+	today := timeutil.CalendarDay(time.Now())
+	return &DocumentOutput{
+		ID:           d.ID,
+		OwnerType:    d.OwnerType,
+		OwnerID:      d.OwnerID,
+		Type:         d.Type,
+		Number:       d.Number,
+		IssuedOn:     d.IssuedOn,
+		ExpiresOn:    d.ExpiresOn,
+		DaysLeft:     d.DaysLeft(today),
+		Status:       d.Status(today, slices.Max(uc.reminderDays)),
+		FileKey:      d.FileKey,
+		FileURL:      url,
+		Notes:        d.Notes,
+		RemindedDays: d.RemindedDays,
+		RemindedAt:   d.RemindedAt,
+		CreatedBy:    d.CreatedBy,
+		CreatedAt:    d.CreatedAt,
+		UpdatedAt:    d.UpdatedAt,
+	}, nil
+}
+
+// complianceReminder builds the event announcing on the owner's channel that a document is expiring or has expired
+func complianceReminder(d *entity.ComplianceDocument, today, now time.Time) service.RealtimeEvent {
+	eventType := "compliance.document_expiring"
+	if d.Expired(today) {
+		eventType = "compliance.document_expired"
+	}
+	return service.RealtimeEvent{
+		Channels: []string{service.Channel(ownerChannels[d.OwnerType], d.OwnerID)},
+		Type:     eventType,
+		Data: map[string]interface{}{
+			"document_id": d.ID,
+			"owner_type":  d.OwnerType,
+			"owner_id":    d.OwnerID,
+			"type":        d.Type,
+			"number":      d.Number,
+			"expires_on":  d.ExpiresOn.Format("2006-01-02"),
+			"days_left":   d.DaysLeft(today),
+		},
+		OccurredAt: now,
+	}
+}
+
+func validateDates(issuedOn *time.Time, expiresOn time.Time) error {
+	if issuedOn != nil && expiresOn.Before(*issuedOn) {
+		return errs.ValidationErrors{"expires_on": {"before_issued_on"}}
+	}
+	return nil
+}
+
+func filePrefix(ownerType entity.ComplianceOwnerType, ownerID uuid.UUID) string {
+	return fmt.Sprintf("compliance/%s/%s/", ownerType, ownerID)
+}
@@ -0,0 +1,92 @@
+package compliance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+type transactor struct{}
+
+func (transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type documentRepo struct {
+	repository.ComplianceDocumentRepository
+	docs map[uuid.UUID]*entity.ComplianceDocument
+}
+
+func (r *documentRepo) ListUnreminded(_ context.Context, before time.Time, limit, offset int) ([]*entity.ComplianceDocument, error) {
+	var docs []*entity.ComplianceDocument
+	for _, d := range r.docs {
+		if !d.ExpiresOn.After(before) {
+			c := *d
+			docs = append(docs, &c)
+		}
+	}
+	if offset >= len(docs) {
+		return nil, nil
+	}
+	return docs[offset:min(offset+limit, len(docs))], nil
+}
+
+func (r *documentRepo) FindByIDForUpdate(_ context.Context, id uuid.UUID) (*entity.ComplianceDocument, error) {
+	c := *r.docs[id]
+	return &c, nil
+}
+
+func (r *documentRepo) Update(_ context.Context, d *entity.ComplianceDocument) error {
+	r.docs[d.ID] = d
+	return nil
+}
+
+type notificationRepo struct {
+	repository.NotificationRepository
+	outbox []*entity.Notification
+}
+
+func (r *notificationRepo) Create(_ context.Context, notifications ...*entity.Notification) error {
+	r.outbox = append(r.outbox, notifications...)
+	return nil
+}
+
+func TestSendRemindersRecordsThemInTheOutbox(t *testing.T) {
+	today := timeutil.CalendarDay(time.Now())
+	vehicle := uuid.New()
+	doc := &entity.ComplianceDocument{
+		ID:        uuid.New(),
+		OwnerType: entity.ComplianceOwnerVehicle,
+		OwnerID:   vehicle,
+		ExpiresOn: today.AddDate(0, 0, 5),
+	}
+	docs := &documentRepo{docs: map[uuid.UUID]*entity.ComplianceDocument{doc.ID: doc}}
+	outbox := &notificationRepo{}
+	uc := NewComplianceUseCase(docs, nil, nil, nil, nil, transactor{}, outbox, []int{30, 7})
+
+	sent, err := uc.SendReminders(context.Background())
+	if err != nil {
+		t.Fatalf("SendReminders: %v", err)
+	}
+	if sent != 1 || len(outbox.outbox) != 1 {
+		t.Fatalf("sent %d, %d notification(s) in the outbox; want 1", sent, len(outbox.outbox))
+	}
+	n := outbox.outbox[0]
+	if n.Type != "compliance.document_expiring" || len(n.Channels) != 1 || n.Channels[0] != service.Channel(service.ChannelVehicle, vehicle) {
+		t.Errorf("notification = %s on %v, want an expiring reminder on the vehicle's channel", n.Type, n.Channels)
+	}
+	if reminded := docs.docs[doc.ID].RemindedDays; reminded == nil || *reminded != 7 {
+		t.Errorf("reminded stage = %v, want 7", reminded)
+	}
+
+	if sent, err = uc.SendReminders(context.Background()); err != nil || sent != 0 || len(outbox.outbox) != 1 {
+		t.Errorf("second run sent %d, err %v; want the stage reminded of once", sent, err)
+	}
+}
@@ -0,0 +1,70 @@
+package compliance
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// UploadURLOutput represents a presigned upload URL and the key the document file will be stored under
+type UploadURLOutput struct {
+	UploadURL string
+	ObjectKey string
+}
+
+// DocumentInput represents a compliance document to record. Dates are calendar days; the file key is one
+// returned by UploadURL for the same owner.
+type DocumentInput struct {
+	OwnerType entity.ComplianceOwnerType
+	OwnerID   uuid.UUID
+	Type      entity.ComplianceDocumentType
+	Number    string
+	IssuedOn  *time.Time
+	ExpiresOn time.Time
+	FileKey   string
+	Notes     string
+	CreatedBy uuid.UUID
+}
+
+// UpdateDocumentInput represents changes to a compliance document; its owner and type cannot change
+type UpdateDocumentInput struct {
+	Number    string
+	IssuedOn  *time.Time
+	ExpiresOn time.Time
+	FileKey   string
+	Notes     string
+}
+
+// ListDocumentsInput represents criteria for listing compliance documents.
+// ExpiringWithin keeps the documents that count for their owner and expire within as many days, expired ones included.
+type ListDocumentsInput struct {
+	OwnerType      *entity.ComplianceOwnerType
+	OwnerID        *uuid.UUID
+	Type           *entity.ComplianceDocumentType
+	ExpiringWithin *int
+	Limit          int
+	Offset         int
+}
+
+// DocumentOutput represents a compliance document with its standing today
+type DocumentOutput struct {
+	ID           uuid.UUID
+	OwnerType    entity.ComplianceOwnerType
+	OwnerID      uuid.UUID
+	Type         entity.ComplianceDocumentType
+	Number       string
+	IssuedOn     *time.Time
+	ExpiresOn    time.Time
+	DaysLeft     int
+	Status       entity.ComplianceStatus
+	FileKey      string
+	FileURL      string
+	Notes        string
+	RemindedDays *int
+	RemindedAt   *time.Time
+	CreatedBy    *uuid.UUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,484 @@
+package dock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+// AppointmentUseCase handles booking docks for inbound and outbound trucks, by staff and by carriers,
+// and the trucks' check-in and check-out
+type AppointmentUseCase struct {
+	appointmentRepo repository.DockAppointmentRepository
+	dockRepo        repository.DockRepository
+	tripRepo        repository.TripRepository
+	vehicleRepo     repository.VehicleRepository
+	carrierRepo     repository.CarrierRepository
+	tenderRepo      repository.TenderRepository
+	transactor      repository.Transactor
+}
+
+// NewAppointmentUseCase creates a new dock appointment use case
+func NewAppointmentUseCase(
+	appointmentRepo repository.DockAppointmentRepository,
+	dockRepo repository.DockRepository,
+	tripRepo repository.TripRepository,
+	vehicleRepo repository.VehicleRepository,
+	carrierRepo repository.CarrierRepository,
+	tenderRepo repository.TenderRepository,
+	transactor repository.Transactor,
+) *AppointmentUseCase {
+	return &AppointmentUseCase{
+		appointmentRepo: appointmentRepo,
+		dockRepo:        dockRepo,
+		tripRepo:        tripRepo,
+		vehicleRepo:     vehicleRepo,
+		carrierRepo:     carrierRepo,
+		tenderRepo:      tenderRepo,
+		transactor:      transactor,
+	}
+}
+
+// Book books a dock for a truck
+func (uc *AppointmentUseCase) Book(ctx context.Context, input AppointmentInput) (*AppointmentOutput, error) {
+	return uc.book(ctx, input, nil)
+}
+
+// CarrierBook books a dock for a trip awarded to the user's carrier
+func (uc *AppointmentUseCase) CarrierBook(ctx context.Context, userID uuid.UUID, input AppointmentInput) (*AppointmentOutput, error) {
+	carrier, err := service.CarrierForUser(ctx, uc.carrierRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if input.TripID == nil {
+		return nil, errs.ValidationErrors{"trip_id": {"required"}}
+	}
+	if err := uc.checkAwarded(ctx, carrier, *input.TripID); err != nil {
+		return nil, err
+	}
+	return uc.book(ctx, input, &carrier.ID)
+}
+
+// Reschedule moves a booked appointment to another period, or another dock at the same site
+func (uc *AppointmentUseCase) Reschedule(ctx context.Context, id uuid.UUID, input RescheduleInput) (*AppointmentOutput, error) {
+	var appointment *entity.DockAppointment
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		appointment, err = uc.findAppointmentForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !appointment.IsEditable() {
+			return errs.ErrResourceLocked
+		}
+
+		dockID := appointment.DockID
+		if input.DockID != nil {
+			dockID = *input.DockID
+		}
+		end := input.StartAt.Add(appointment.EndAt.Sub(appointment.StartAt))
+		if input.EndAt != nil {
+			end = *input.EndAt
+		}
+		if appointment.TripID != nil {
+			if _, err := uc.lockTrip(ctx, *appointment.TripID); err != nil {
+				return err
+			}
+		}
+		dock, err := uc.lockDock(ctx, dockID)
+		if err != nil {
+			return err
+		}
+		if dock.LocationID != appointment.LocationID {
+			return errs.ValidationErrors{"dock_id": {"other_site"}}
+		}
+
+		appointment.DockID = dock.ID
+		appointment.StartAt = input.StartAt
+		appointment.EndAt = end
+		if err := uc.reserve(ctx, dock, appointment); err != nil {
+			return err
+		}
+		if err := uc.appointmentRepo.Update(ctx, appointment); err != nil {
+			return fmt.Errorf("dock appointment repository: update appointment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toAppointmentOutput(appointment), nil
+}
+
+// CheckIn records the truck's arrival at the site
+func (uc *AppointmentUseCase) CheckIn(ctx context.Context, id uuid.UUID, at *time.Time) (*AppointmentOutput, error) {
+	return uc.transition(ctx, id, func(a *entity.DockAppointment) error {
+		return a.CheckIn(timeOrNow(at))
+	})
+}
+
+// CheckOut records the truck leaving the site, completing the appointment
+func (uc *AppointmentUseCase) CheckOut(ctx context.Context, id uuid.UUID, at *time.Time) (*AppointmentOutput, error) {
+	return uc.transition(ctx, id, func(a *entity.DockAppointment) error {
+		return a.CheckOut(timeOrNow(at))
+	})
+}
+
+// Cancel cancels a booked appointment, releasing its slots
+func (uc *AppointmentUseCase) Cancel(ctx context.Context, id uuid.UUID, reason string) (*AppointmentOutput, error) {
+	return uc.transition(ctx, id, func(a *entity.DockAppointment) error {
+		return a.Cancel(reason)
+	})
+}
+
+// CarrierCancel cancels an appointment booked by the user's carrier
+func (uc *AppointmentUseCase) CarrierCancel(ctx context.Context, userID, id uuid.UUID, reason string) (*AppointmentOutput, error) {
+	carrier, err := service.CarrierForUser(ctx, uc.carrierRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.transition(ctx, id, func(a *entity.DockAppointment) error {
+		if a.CarrierID == nil || *a.CarrierID != carrier.ID {
+			return errs.ErrNotFound
+		}
+		return a.Cancel(reason)
+	})
+}
+
+// MarkNoShow releases the slots of an appointment the truck never came to
+func (uc *AppointmentUseCase) MarkNoShow(ctx context.Context, id uuid.UUID) (*AppointmentOutput, error) {
+	return uc.transition(ctx, id, func(a *entity.DockAppointment) error {
+		return a.MarkNoShow()
+	})
+}
+
+// Get returns an appointment by ID
+func (uc *AppointmentUseCase) Get(ctx context.Context, id uuid.UUID) (*AppointmentOutput, error) {
+	appointment, err := uc.appointmentRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("dock appointment repository: find by id: %w", err)
+	}
+	return toAppointmentOutput(appointment), nil
+}
+
+// List returns the appointments matching the input criteria, by booked start
+func (uc *AppointmentUseCase) List(ctx context.Context, input ListAppointmentsInput) ([]*AppointmentOutput, int64, error) {
+	appointments, total, err := uc.appointmentRepo.List(ctx, repository.DockAppointmentFilter{
+		LocationID: input.LocationID,
+		DockID:     input.DockID,
+		TripID:     input.TripID,
+		CarrierID:  input.CarrierID,
+		Status:     input.Status,
+		From:       input.From,
+		To:         input.To,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("dock appointment repository: list appointments: %w", err)
+	}
+
+	outputs := make([]*AppointmentOutput, len(appointments))
+	for i, a := range appointments {
+		outputs[i] = toAppointmentOutput(a)
+	}
+	return outputs, total, nil
+}
+
+// CarrierList returns the appointments booked by the user's carrier
+func (uc *AppointmentUseCase) CarrierList(ctx context.Context, userID uuid.UUID, input ListAppointmentsInput) ([]*AppointmentOutput, int64, error) {
+	carrier, err := service.CarrierForUser(ctx, uc.carrierRepo, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	input.CarrierID = &carrier.ID
+	return uc.List(ctx, input)
+}
+
+// Turnaround reports per dock how many trucks were booked and how long they waited and spent at the site
+func (uc *AppointmentUseCase) Turnaround(ctx context.Context, input TurnaroundInput) ([]*TurnaroundOutput, error) {
+	if !input.To.After(input.From) {
+		return nil, errs.ValidationErrors{"to": {"before_from"}}
+	}
+	summaries, err := uc.appointmentRepo.Turnaround(ctx, repository.DockAppointmentFilter{
+		LocationID: input.LocationID,
+		DockID:     input.DockID,
+		From:       &input.From,
+		To:         &input.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("dock appointment repository: turnaround: %w", err)
+	}
+
+	outputs := make([]*TurnaroundOutput, len(summaries))
+	for i, s := range summaries {
+		output := &TurnaroundOutput{
+			DockID:               s.DockID,
+			Appointments:         s.Appointments,
+			Completed:            s.Completed,
+			NoShows:              s.NoShows,
+			Cancelled:            s.Cancelled,
+			AvgWaitMinutes:       roundMinutes(s.AvgWaitMinutes),
+			AvgTurnaroundMinutes: roundMinutes(s.AvgTurnaroundMinutes),
+			MaxTurnaroundMinutes: roundMinutes(s.MaxTurnaroundMinutes),
+		}
+		dock, err := uc.dockRepo.FindByID(ctx, s.DockID)
+		if err != nil && !errors.Is(err, errs.ErrNotFound) {
+			return nil, fmt.Errorf("dock repository: find by id: %w", err)
+		}
+		if dock != nil {
+			output.DockName = dock.Name
+			output.LocationID = dock.LocationID
+		}
+		outputs[i] = output
+	}
+	return outputs, nil
+}
+
+// book validates the input and books the dock for it; carrierID is set when a carrier books
+func (uc *AppointmentUseCase) book(ctx context.Context, input AppointmentInput, carrierID *uuid.UUID) (*AppointmentOutput, error) {
+	createdBy := input.CreatedBy
+	appointment := &entity.DockAppointment{
+		DockID:      input.DockID,
+		Direction:   input.Direction,
+		TripID:      input.TripID,
+		CarrierID:   carrierID,
+		VehicleType: input.VehicleType,
+		PlateNumber: strings.TrimSpace(input.PlateNumber),
+		DriverName:  strings.TrimSpace(input.DriverName),
+		Reference:   strings.TrimSpace(input.Reference),
+		StartAt:     input.StartAt,
+		Status:      entity.DockAppointmentBooked,
+		Notes:       input.Notes,
+		CreatedBy:   &createdBy,
+	}
+
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if input.TripID != nil {
+			if err := uc.applyTrip(ctx, appointment, *input.TripID); err != nil {
+				return err
+			}
+		}
+		dock, err := uc.lockDock(ctx, input.DockID)
+		if err != nil {
+			return err
+		}
+		if !dock.Active {
+			return errs.ValidationErrors{"dock_id": {"inactive"}}
+		}
+		if !dock.Handles(appointment.Direction) {
+			return errs.ValidationErrors{"direction": {"not_handled_by_dock"}}
+		}
+		if !dock.Accepts(appointment.VehicleType) {
+			return errs.ValidationErrors{"vehicle_type": {"not_accepted_by_dock"}}
+		}
+
+		appointment.LocationID = dock.LocationID
+		appointment.EndAt = input.StartAt.Add(time.Duration(dock.SlotMinutes) * time.Minute)
+		if input.EndAt != nil {
+			appointment.EndAt = *input.EndAt
+		}
+		if err := uc.reserve(ctx, dock, appointment); err != nil {
+			return err
+		}
+		if err := uc.appointmentRepo.Create(ctx, appointment); err != nil {
+			return fmt.Errorf("dock appointment repository: create appointment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toAppointmentOutput(appointment), nil
+}
+
+// reserve checks that the appointment covers whole slots of the dock's operating hours that have room for it
+// and that its trip is not booked elsewhere at the time. The dock and the trip must be locked, so concurrent
+// bookings of the dock or the trip see each other.
+func (uc *AppointmentUseCase) reserve(ctx context.Context, dock *entity.Dock, appointment *entity.DockAppointment) error {
+	if !appointment.StartAt.After(time.Now()) {
+		return errs.ValidationErrors{"start_at": {"in_past"}}
+	}
+	if !dock.FitsSchedule(appointment.StartAt, appointment.EndAt, timeutil.Thailand) {
+		return errs.ValidationErrors{"start_at": {"outside_slots"}}
+	}
+
+	var excludeID *uuid.UUID
+	if appointment.ID != uuid.Nil {
+		excludeID = &appointment.ID
+	}
+	occupying, err := uc.appointmentRepo.FindOccupying(ctx, dock.ID, appointment.StartAt, appointment.EndAt, excludeID)
+	if err != nil {
+		return fmt.Errorf("dock appointment repository: find occupying: %w", err)
+	}
+	if entity.PeakOccupancy(occupying, appointment.StartAt, appointment.EndAt) >= dock.Capacity {
+		return fmt.Errorf("%w: %s is fully booked", errs.ErrSlotUnavailable, dock.Name)
+	}
+
+	if appointment.TripID != nil {
+		booked, err := uc.appointmentRepo.FindOccupyingByTrip(ctx, *appointment.TripID, appointment.StartAt, appointment.EndAt, excludeID)
+		if err != nil {
+			return fmt.Errorf("dock appointment repository: find occupying by trip: %w", err)
+		}
+		if len(booked) > 0 {
+			return fmt.Errorf("%w: trip is booked at another dock by appointment %s", errs.ErrScheduleConflict, booked[0].ID)
+		}
+	}
+	return nil
+}
+
+// applyTrip locks the trip and fills in the vehicle type and plate of the trip's vehicle the input did not give
+func (uc *AppointmentUseCase) applyTrip(ctx context.Context, appointment *entity.DockAppointment, tripID uuid.UUID) error {
+	trip, err := uc.lockTrip(ctx, tripID)
+	if err != nil {
+		return err
+	}
+	if !trip.IsActive() {
+		return errs.ValidationErrors{"trip_id": {"not_active"}}
+	}
+
+	vehicle, err := uc.vehicleRepo.FindByID(ctx, trip.VehicleID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("vehicle repository: find by id: %w", err)
+	}
+	if appointment.VehicleType == "" {
+		appointment.VehicleType = vehicle.Type
+	}
+	if appointment.PlateNumber == "" {
+		appointment.PlateNumber = vehicle.PlateNumber
+	}
+	return nil
+}
+
+// checkAwarded confirms the trip was tendered to the carrier and the carrier accepted it
+func (uc *AppointmentUseCase) checkAwarded(ctx context.Context, carrier *entity.Carrier, tripID uuid.UUID) error {
+	accepted := entity.TenderStatusAccepted
+	tenders, _, err := uc.tenderRepo.List(ctx, repository.TenderFilter{TripID: &tripID, Status: &accepted}, 1, 0)
+	if err != nil {
+		return fmt.Errorf("tender repository: list tenders: %w", err)
+	}
+	if len(tenders) == 0 || tenders[0].AwardedCarrierID == nil || *tenders[0].AwardedCarrierID != carrier.ID {
+		return errs.ErrForbidden
+	}
+	return nil
+}
+
+// transition applies a status change to an appointment under its lock
+func (uc *AppointmentUseCase) transition(ctx context.Context, id uuid.UUID, change func(*entity.DockAppointment) error) (*AppointmentOutput, error) {
+	var appointment *entity.DockAppointment
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		appointment, err = uc.findAppointmentForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := change(appointment); err != nil {
+			return err
+		}
+		if err := uc.appointmentRepo.Update(ctx, appointment); err != nil {
+			return fmt.Errorf("dock appointment repository: update appointment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toAppointmentOutput(appointment), nil
+}
+
+// lockDock loads a dock and locks it, serializing the bookings of the dock until the transaction ends
+func (uc *AppointmentUseCase) lockDock(ctx context.Context, id uuid.UUID) (*entity.Dock, error) {
+	dock, err := uc.dockRepo.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("dock repository: find by id for update: %w", err)
+	}
+	return dock, nil
+}
+
+// lockTrip loads a trip and locks it, serializing the bookings of the trip until the transaction ends.
+// Bookings at different docks lock different docks, so only the trip lock lets them see each other.
+// It is taken before the dock's.
+func (uc *AppointmentUseCase) lockTrip(ctx context.Context, id uuid.UUID) (*entity.Trip, error) {
+	trip, err := uc.tripRepo.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("trip repository: find by id for update: %w", err)
+	}
+	return trip, nil
+}
+
+func (uc *AppointmentUseCase) findAppointmentForUpdate(ctx context.Context, id uuid.UUID) (*entity.DockAppointment, error) {
+	appointment, err := uc.appointmentRepo.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("dock appointment repository: find by id for update: %w", err)
+	}
+	return appointment, nil
+}
+
+func timeOrNow(at *time.Time) time.Time {
+	if at == nil {
+		return time.Now()
+	}
+	return *at
+}
+
+func roundMinutes(m float64) float64 {
+	return math.Round(m*10) / 10
+}
+
+func toAppointmentOutput(a *entity.DockAppointment) *AppointmentOutput {
+	output := &AppointmentOutput{
+		ID:           a.ID,
+		DockID:       a.DockID,
+		LocationID:   a.LocationID,
+		Direction:    a.Direction,
+		TripID:       a.TripID,
+		CarrierID:    a.CarrierID,
+		VehicleType:  a.VehicleType,
+		PlateNumber:  a.PlateNumber,
+		DriverName:   a.DriverName,
+		Reference:    a.Reference,
+		StartAt:      a.StartAt,
+		EndAt:        a.EndAt,
+		Status:       a.Status,
+		CheckedInAt:  a.CheckedInAt,
+		CheckedOutAt: a.CheckedOutAt,
+		CancelReason: a.CancelReason,
+		Notes:        a.Notes,
+		CreatedBy:    a.CreatedBy,
+		CreatedAt:    a.CreatedAt,
+		UpdatedAt:    a.UpdatedAt,
+	}
+	if a.CheckedInAt != nil {
+		wait := roundMinutes(a.CheckedInAt.Sub(a.StartAt).Minutes())
+		output.WaitMinutes = &wait
+	}
+	if t := a.Turnaround(); t != nil {
+		turnaround := roundMinutes(t.Minutes())
+		output.TurnaroundMinutes = &turnaround
+	}
+	return output
+}
@@ -0,0 +1,176 @@
+package dock
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+// store stands in for the database: a row lock on the trip is held until the transaction that took it ends
+type store struct {
+	tripLock     sync.Mutex
+	mu           sync.Mutex
+	appointments []*entity.DockAppointment
+}
+
+type txKey struct{}
+
+type tx struct{ locked bool }
+
+type transactor struct{ store *store }
+
+func (t transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx := &tx{}
+	err := fn(context.WithValue(ctx, txKey{}, tx))
+	if tx.locked {
+		t.store.tripLock.Unlock()
+	}
+	return err
+}
+
+type tripRepo struct {
+	repository.TripRepository
+	store *store
+	trip  *entity.Trip
+}
+
+func (r tripRepo) FindByIDForUpdate(ctx context.Context, _ uuid.UUID) (*entity.Trip, error) {
+	tx := ctx.Value(txKey{}).(*tx)
+	if !tx.locked {
+		r.store.tripLock.Lock()
+		tx.locked = true
+	}
+	return r.trip, nil
+}
+
+type vehicleRepo struct{ repository.VehicleRepository }
+
+func (vehicleRepo) FindByID(context.Context, uuid.UUID) (*entity.Vehicle, error) {
+	return &entity.Vehicle{Type: entity.VehicleType("6w"), PlateNumber: "1กข 1234"}, nil
+}
+
+type carrierRepo struct {
+	repository.CarrierRepository
+	carrier *entity.Carrier
+}
+
+func (r carrierRepo) FindByUserID(context.Context, uuid.UUID) (*entity.Carrier, error) {
+	return r.carrier, nil
+}
+
+type tenderRepo struct {
+	repository.TenderRepository
+	carrierID uuid.UUID
+}
+
+func (r tenderRepo) List(context.Context, repository.TenderFilter, int, int) ([]*entity.Tender, int64, error) {
+	return []*entity.Tender{{Status: entity.TenderStatusAccepted, AwardedCarrierID: &r.carrierID}}, 1, nil
+}
+
+type dockRepo struct {
+	repository.DockRepository
+	docks map[uuid.UUID]*entity.Dock
+}
+
+func (r dockRepo) FindByIDForUpdate(_ context.Context, id uuid.UUID) (*entity.Dock, error) {
+	return r.docks[id], nil
+}
+
+type appointmentRepo struct {
+	repository.DockAppointmentRepository
+	store *store
+}
+
+func (r appointmentRepo) Create(_ context.Context, appointment *entity.DockAppointment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	appointment.ID = uuid.New()
+	r.store.appointments = append(r.store.appointments, appointment)
+	return nil
+}
+
+func (r appointmentRepo) FindOccupying(_ context.Context, dockID uuid.UUID, start, end time.Time, _ *uuid.UUID) ([]*entity.DockAppointment, error) {
+	return r.find(func(a *entity.DockAppointment) bool { return a.DockID == dockID }, start, end), nil
+}
+
+func (r appointmentRepo) FindOccupyingByTrip(_ context.Context, tripID uuid.UUID, start, end time.Time, _ *uuid.UUID) ([]*entity.DockAppointment, error) {
+	return r.find(func(a *entity.DockAppointment) bool { return a.TripID != nil && *a.TripID == tripID }, start, end), nil
+}
+
+func (r appointmentRepo) find(match func(*entity.DockAppointment) bool, start, end time.Time) []*entity.DockAppointment {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	var found []*entity.DockAppointment
+	for _, a := range r.store.appointments {
+		if match(a) && a.StartAt.Before(end) && a.EndAt.After(start) {
+			found = append(found, a)
+		}
+	}
+	return found
+}
+
+func TestConcurrentCarrierBookingsOfATrip(t *testing.T) {
+	const docks = 8
+	s := &store{}
+	carrier := &entity.Carrier{ID: uuid.New()}
+	trip := &entity.Trip{ID: uuid.New(), VehicleID: uuid.New(), Status: entity.TripStatusPlanned}
+
+	var hours []entity.OpeningHours
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		hours = append(hours, entity.OpeningHours{Weekday: day, Open: "06:00", Close: "18:00"})
+	}
+	site := uuid.New()
+	byID := map[uuid.UUID]*entity.Dock{}
+	var ids []uuid.UUID
+	for i := 0; i < docks; i++ {
+		d := &entity.Dock{
+			ID: uuid.New(), LocationID: site, Direction: entity.DockBoth, Capacity: 1,
+			SlotMinutes: 60, OperatingHours: hours, Active: true,
+		}
+		byID[d.ID] = d
+		ids = append(ids, d.ID)
+	}
+
+	uc := NewAppointmentUseCase(appointmentRepo{store: s}, dockRepo{docks: byID}, tripRepo{store: s, trip: trip},
+		vehicleRepo{}, carrierRepo{carrier: carrier}, tenderRepo{carrierID: carrier.ID}, transactor{store: s})
+
+	tomorrow := timeutil.StartOfDay(timeutil.CalendarDay(time.Now()).AddDate(0, 0, 1))
+	start := tomorrow.Add(10 * time.Hour)
+
+	var wg sync.WaitGroup
+	results := make(chan error, docks)
+	for _, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := uc.CarrierBook(context.Background(), uuid.New(), AppointmentInput{
+				DockID: id, Direction: entity.DockInbound, TripID: &trip.ID, StartAt: start,
+			})
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	booked := 0
+	for err := range results {
+		switch {
+		case err == nil:
+			booked++
+		case !errors.Is(err, errs.ErrScheduleConflict):
+			t.Errorf("CarrierBook: %v, want ErrScheduleConflict", err)
+		}
+	}
+	if booked != 1 || len(s.appointments) != 1 {
+		t.Errorf("%d booking(s) succeeded, %d stored; want the trip booked once", booked, len(s.appointments))
+	}
+}
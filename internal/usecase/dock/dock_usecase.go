@@ -0,0 +1,193 @@
+package dock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultSlotMinutes is the slot length of a dock that does not set one
+	DefaultSlotMinutes = 30
+
+	// DefaultCapacity is how many trucks a dock that does not say serves at the same time
+	DefaultCapacity = 1
+)
+
+// DockUseCase handles the docks of sites and their bookable slots
+type DockUseCase struct {
+	dockRepo        repository.DockRepository
+	appointmentRepo repository.DockAppointmentRepository
+	locationRepo    repository.LocationRepository
+}
+
+// NewDockUseCase creates a new dock use case
+func NewDockUseCase(
+	dockRepo repository.DockRepository,
+	appointmentRepo repository.DockAppointmentRepository,
+	locationRepo repository.LocationRepository,
+) *DockUseCase {
+	return &DockUseCase{
+		dockRepo:        dockRepo,
+		appointmentRepo: appointmentRepo,
+		locationRepo:    locationRepo,
+	}
+}
+
+// Create adds a dock to a site
+func (uc *DockUseCase) Create(ctx context.Context, input DockInput) (*DockOutput, error) {
+	dock := &entity.Dock{}
+	if err := uc.apply(ctx, dock, input); err != nil {
+		return nil, err
+	}
+	if err := uc.dockRepo.Create(ctx, dock); err != nil {
+		return nil, fmt.Errorf("dock repository: create dock: %w", err)
+	}
+	return toDockOutput(dock), nil
+}
+
+// Update replaces a dock's details. Appointments already booked are kept even if they no longer fit the hours.
+func (uc *DockUseCase) Update(ctx context.Context, id uuid.UUID, input DockInput) (*DockOutput, error) {
+	dock, err := uc.findDock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.apply(ctx, dock, input); err != nil {
+		return nil, err
+	}
+	if err := uc.dockRepo.Update(ctx, dock); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("dock repository: update dock: %w", err)
+	}
+	return toDockOutput(dock), nil
+}
+
+// Get returns a dock by ID
+func (uc *DockUseCase) Get(ctx context.Context, id uuid.UUID) (*DockOutput, error) {
+	dock, err := uc.findDock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toDockOutput(dock), nil
+}
+
+// List returns the docks matching the input criteria, by site and name
+func (uc *DockUseCase) List(ctx context.Context, input ListDocksInput) ([]*DockOutput, int64, error) {
+	docks, total, err := uc.dockRepo.List(ctx, repository.DockFilter{
+		LocationID: input.LocationID,
+		Direction:  input.Direction,
+		Active:     input.Active,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("dock repository: list docks: %w", err)
+	}
+
+	outputs := make([]*DockOutput, len(docks))
+	for i, d := range docks {
+		outputs[i] = toDockOutput(d)
+	}
+	return outputs, total, nil
+}
+
+// Slots returns a dock's slots on a calendar day with how many trucks are booked in each.
+// A slot is available while the dock has room in it and it has not started yet.
+func (uc *DockUseCase) Slots(ctx context.Context, id uuid.UUID, day time.Time) ([]*SlotOutput, error) {
+	dock, err := uc.findDock(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := dock.Slots(day, timeutil.Thailand)
+	if len(slots) == 0 {
+		return []*SlotOutput{}, nil
+	}
+	appointments, err := uc.appointmentRepo.FindOccupying(ctx, dock.ID, slots[0].Start, slots[len(slots)-1].End, nil)
+	if err != nil {
+		return nil, fmt.Errorf("dock appointment repository: find occupying: %w", err)
+	}
+
+	now := time.Now()
+	outputs := make([]*SlotOutput, len(slots))
+	for i, s := range slots {
+		booked := entity.PeakOccupancy(appointments, s.Start, s.End)
+		outputs[i] = &SlotOutput{
+			Start:     s.Start,
+			End:       s.End,
+			Booked:    booked,
+			Capacity:  dock.Capacity,
+			Available: dock.Active && booked < dock.Capacity && s.Start.After(now),
+		}
+	}
+	return outputs, nil
+}
+
+// apply validates the input and applies it to the dock
+func (uc *DockUseCase) apply(ctx context.Context, dock *entity.Dock, input DockInput) error {
+	for _, h := range input.OperatingHours {
+		if !h.IsValid() {
+			return errs.ValidationErrors{"operating_hours": {"close_before_open"}}
+		}
+	}
+	if _, err := uc.locationRepo.FindByID(ctx, input.LocationID); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("location repository: find by id: %w", err)
+	}
+
+	dock.LocationID = input.LocationID
+	dock.Name = strings.TrimSpace(input.Name)
+	dock.Code = strings.TrimSpace(input.Code)
+	dock.Direction = input.Direction
+	dock.Capacity = input.Capacity
+	if dock.Capacity <= 0 {
+		dock.Capacity = DefaultCapacity
+	}
+	dock.SlotMinutes = input.SlotMinutes
+	if dock.SlotMinutes <= 0 {
+		dock.SlotMinutes = DefaultSlotMinutes
+	}
+	dock.OperatingHours = input.OperatingHours
+	dock.VehicleTypes = input.VehicleTypes
+	dock.Active = input.Active
+	return nil
+}
+
+func (uc *DockUseCase) findDock(ctx context.Context, id uuid.UUID) (*entity.Dock, error) {
+	dock, err := uc.dockRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("dock repository: find by id: %w", err)
+	}
+	return dock, nil
+}
+
+func toDockOutput(d *entity.Dock) *DockOutput {
+	return &DockOutput{
+		ID:             d.ID,
+		LocationID:     d.LocationID,
+		Name:           d.Name,
+		Code:           d.Code,
+		Direction:      d.Direction,
+		Capacity:       d.Capacity,
+		SlotMinutes:    d.SlotMinutes,
+		OperatingHours: d.OperatingHours,
+		VehicleTypes:   d.VehicleTypes,
+		Active:         d.Active,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
@@ -0,0 +1,142 @@
+package dock
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// DockInput represents a dock to create or replace. A zero capacity or slot length uses the defaults.
+type DockInput struct {
+	LocationID     uuid.UUID
+	Name           string
+	Code           string
+	Direction      entity.DockDirection
+	Capacity       int
+	SlotMinutes    int
+	OperatingHours []entity.OpeningHours
+	VehicleTypes   []entity.VehicleType
+	Active         bool
+}
+
+// ListDocksInput represents criteria for listing docks
+type ListDocksInput struct {
+	LocationID *uuid.UUID
+	Direction  *entity.DockDirection
+	Active     *bool
+	Limit      int
+	Offset     int
+}
+
+// DockOutput represents a dock
+type DockOutput struct {
+	ID             uuid.UUID
+	LocationID     uuid.UUID
+	Name           string
+	Code           string
+	Direction      entity.DockDirection
+	Capacity       int
+	SlotMinutes    int
+	OperatingHours []entity.OpeningHours
+	VehicleTypes   []entity.VehicleType
+	Active         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// SlotOutput represents one slot of a dock and how many trucks are booked in it
+type SlotOutput struct {
+	Start     time.Time
+	End       time.Time
+	Booked    int
+	Capacity  int
+	Available bool
+}
+
+// AppointmentInput represents an appointment to book. Without an end the appointment lasts one slot.
+// The vehicle type and plate default to those of the trip's vehicle.
+type AppointmentInput struct {
+	DockID      uuid.UUID
+	Direction   entity.DockDirection
+	TripID      *uuid.UUID
+	VehicleType entity.VehicleType
+	PlateNumber string
+	DriverName  string
+	Reference   string
+	StartAt     time.Time
+	EndAt       *time.Time
+	Notes       string
+	CreatedBy   uuid.UUID
+}
+
+// RescheduleInput represents a new dock or period for a booked appointment.
+// Without a dock it stays at its dock; without an end it keeps its length.
+type RescheduleInput struct {
+	DockID  *uuid.UUID
+	StartAt time.Time
+	EndAt   *time.Time
+}
+
+// ListAppointmentsInput represents criteria for listing dock appointments; From and To bound the booked start
+type ListAppointmentsInput struct {
+	LocationID *uuid.UUID
+	DockID     *uuid.UUID
+	TripID     *uuid.UUID
+	CarrierID  *uuid.UUID
+	Status     *entity.DockAppointmentStatus
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// AppointmentOutput represents a dock appointment. Wait is from the booked start to check-in, negative when
+// early; turnaround is from check-in to check-out.
+type AppointmentOutput struct {
+	ID                uuid.UUID
+	DockID            uuid.UUID
+	LocationID        uuid.UUID
+	Direction         entity.DockDirection
+	TripID            *uuid.UUID
+	CarrierID         *uuid.UUID
+	VehicleType       entity.VehicleType
+	PlateNumber       string
+	DriverName        string
+	Reference         string
+	StartAt           time.Time
+	EndAt             time.Time
+	Status            entity.DockAppointmentStatus
+	CheckedInAt       *time.Time
+	CheckedOutAt      *time.Time
+	WaitMinutes       *float64
+	TurnaroundMinutes *float64
+	CancelReason      string
+	Notes             string
+	CreatedBy         *uuid.UUID
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// TurnaroundInput represents the site or dock and period of a turnaround report; the period bounds the booked start
+type TurnaroundInput struct {
+	LocationID *uuid.UUID
+	DockID     *uuid.UUID
+	From       time.Time
+	To         time.Time
+}
+
+// TurnaroundOutput summarizes the appointments of a dock over a period
+type TurnaroundOutput struct {
+	DockID               uuid.UUID
+	DockName             string
+	LocationID           uuid.UUID
+	Appointments         int
+	Completed            int
+	NoShows              int
+	Cancelled            int
+	AvgWaitMinutes       float64
+	AvgTurnaroundMinutes float64
+	MaxTurnaroundMinutes float64
+}
@@ -0,0 +1,259 @@
+package document
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// DocumentUseCase generates printable invoices, waybills and delivery notes and stores them for download
+type DocumentUseCase struct {
+	invoiceRepo    repository.InvoiceRepository
+	orgRepo        repository.OrganizationRepository
+	shipmentRepo   repository.ShipmentRepository
+	locationRepo   repository.LocationRepository
+	tripRepo       repository.TripRepository
+	vehicleRepo    repository.VehicleRepository
+	driverRepo     repository.DriverRepository
+	podRepo        repository.ProofOfDeliveryRepository
+	storageService service.StorageService
+	renderer       service.DocumentRenderer
+	seller         service.Party
+	trackingURL    string
+}
+
+// NewDocumentUseCase creates a new document use case.
+// seller is the carrier printed on every document; trackingURL is the public tracking page
+// the tracking number is appended to for the QR code on waybills, or empty for none.
+func NewDocumentUseCase(
+	invoiceRepo repository.InvoiceRepository,
+	orgRepo repository.OrganizationRepository,
+	shipmentRepo repository.ShipmentRepository,
+	locationRepo repository.LocationRepository,
+	tripRepo repository.TripRepository,
+	vehicleRepo repository.VehicleRepository,
+	driverRepo repository.DriverRepository,
+	podRepo repository.ProofOfDeliveryRepository,
+	storageService service.StorageService,
+	renderer service.DocumentRenderer,
+	seller service.Party,
+	trackingURL string,
+) *DocumentUseCase {
+	return &DocumentUseCase{
+		invoiceRepo:    invoiceRepo,
+		orgRepo:        orgRepo,
+		shipmentRepo:   shipmentRepo,
+		locationRepo:   locationRepo,
+		tripRepo:       tripRepo,
+		vehicleRepo:    vehicleRepo,
+		driverRepo:     driverRepo,
+		podRepo:        podRepo,
+		storageService: storageService,
+		renderer:       renderer,
+		seller:         seller,
+		trackingURL:    trackingURL,
+	}
+}
+
+// Invoice generates the invoice/tax invoice or credit note PDF of an invoice
+func (uc *DocumentUseCase) Invoice(ctx context.Context, id uuid.UUID) (*DocumentOutput, error) {
+	invoice, err := uc.invoiceRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("invoice repository: find by id: %w", err)
+	}
+	customer, err := uc.orgRepo.FindByID(ctx, invoice.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("organization repository: find by id: %w", err)
+	}
+
+	doc := service.InvoiceDocument{
+		Invoice:  invoice,
+		Seller:   uc.seller,
+		Customer: organizationParty(customer),
+	}
+	if invoice.CreditedInvoiceID != nil {
+		credited, err := uc.invoiceRepo.FindByID(ctx, *invoice.CreditedInvoiceID)
+		if err != nil {
+			return nil, fmt.Errorf("invoice repository: find credited invoice: %w", err)
+		}
+		doc.CreditedNumber = credited.Number
+		doc.CreditedIssueDate = credited.IssueDate
+		doc.CreditedSubtotal = credited.Subtotal
+	}
+
+	content, err := uc.renderer.RenderInvoice(doc)
+	if err != nil {
+		return nil, fmt.Errorf("document renderer: render invoice: %w", err)
+	}
+
+	name := invoice.Number
+	if name == "" {
+		name = "draft-" + invoice.ID.String()
+	}
+	prefix := "invoice"
+	if invoice.IsCreditNote() {
+		prefix = "credit-note"
+	}
+	return uc.store(ctx, "invoices", fmt.Sprintf("%s-%s.pdf", prefix, name), content)
+}
+
+// Waybill generates the waybill PDF of a shipment
+func (uc *DocumentUseCase) Waybill(ctx context.Context, shipmentID uuid.UUID) (*DocumentOutput, error) {
+	doc, err := uc.shipmentDocument(ctx, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+	content, err := uc.renderer.RenderWaybill(*doc)
+	if err != nil {
+		return nil, fmt.Errorf("document renderer: render waybill: %w", err)
+	}
+	return uc.store(ctx, "waybills", fmt.Sprintf("waybill-%s.pdf", doc.TrackingNumber), content)
+}
+
+// DeliveryNote generates the delivery note PDF of a shipment
+func (uc *DocumentUseCase) DeliveryNote(ctx context.Context, shipmentID uuid.UUID) (*DocumentOutput, error) {
+	doc, err := uc.shipmentDocument(ctx, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+	content, err := uc.renderer.RenderDeliveryNote(*doc)
+	if err != nil {
+		return nil, fmt.Errorf("document renderer: render delivery note: %w", err)
+	}
+	return uc.store(ctx, "delivery-notes", fmt.Sprintf("delivery-note-%s.pdf", doc.TrackingNumber), content)
+}
+
+// shipmentDocument gathers the parties of a shipment and, once it is planned or delivered,
+// its trip, vehicle, driver and proof of delivery
+func (uc *DocumentUseCase) shipmentDocument(ctx context.Context, shipmentID uuid.UUID) (*service.ShipmentDocument, error) {
+	shipment, err := uc.shipmentRepo.FindByID(ctx, shipmentID)
+	if err != nil {
+		return nil, fmt.Errorf("shipment repository: find by id: %w", err)
+	}
+	customer, err := uc.orgRepo.FindByID(ctx, shipment.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("organization repository: find by id: %w", err)
+	}
+	pickup, err := uc.locationRepo.FindByID(ctx, shipment.PickupLocationID)
+	if err != nil {
+		return nil, fmt.Errorf("location repository: find pickup: %w", err)
+	}
+	delivery, err := uc.locationRepo.FindByID(ctx, shipment.DeliveryLocationID)
+	if err != nil {
+		return nil, fmt.Errorf("location repository: find delivery: %w", err)
+	}
+
+	doc := &service.ShipmentDocument{
+		TrackingNumber: shipment.TrackingNumber,
+		Reference:      shipment.Reference,
+		BookedAt:       shipment.CreatedAt,
+		Seller:         uc.seller,
+		Customer:       organizationParty(customer),
+		Shipper:        locationParty(pickup),
+		Consignee:      locationParty(delivery),
+		PickupFrom:     shipment.PickupFrom,
+		PickupTo:       shipment.PickupTo,
+		DeliverFrom:    shipment.DeliverFrom,
+		DeliverTo:      shipment.DeliverTo,
+		WeightKg:       shipment.WeightKg,
+		VolumeM3:       shipment.VolumeM3,
+		Pallets:        shipment.Pallets,
+		Notes:          shipment.Notes,
+	}
+	if uc.trackingURL != "" {
+		doc.TrackingURL = uc.trackingURL + shipment.TrackingNumber
+	}
+
+	trip, err := uc.tripRepo.FindByShipment(ctx, shipment.ID)
+	if errors.Is(err, errs.ErrNotFound) {
+		// Not planned yet: the trip and delivery details stay blank for handwriting
+		return doc, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("trip repository: find by shipment: %w", err)
+	}
+	doc.TripNumber = trip.Number
+
+	vehicle, err := uc.vehicleRepo.FindByID(ctx, trip.VehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("vehicle repository: find by id: %w", err)
+	}
+	doc.VehiclePlate = strings.TrimSpace(vehicle.PlateNumber + " " + vehicle.PlateProvince)
+
+	driver, err := uc.driverRepo.FindByID(ctx, trip.DriverID)
+	if err != nil {
+		return nil, fmt.Errorf("driver repository: find by id: %w", err)
+	}
+	if driver.User != nil {
+		doc.DriverName = strings.TrimSpace(driver.User.FirstName + " " + driver.User.LastName)
+	}
+
+	for _, stop := range trip.Stops {
+		if stop.ShipmentID != shipment.ID || stop.Type != entity.StopTypeDelivery {
+			continue
+		}
+		pod, err := uc.podRepo.FindByStopID(ctx, stop.ID)
+		if errors.Is(err, errs.ErrNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("pod repository: find by stop id: %w", err)
+		}
+		doc.PODNumber = pod.Number
+		doc.DeliveredAt = &pod.CapturedAt
+		doc.ReceivedBy = pod.RecipientName
+		break
+	}
+	return doc, nil
+}
+
+// store uploads a document under a key derived from its content, so an unchanged document is
+// uploaded once and a changed one never overwrites a link already handed out
+func (uc *DocumentUseCase) store(ctx context.Context, folder, filename string, content []byte) (*DocumentOutput, error) {
+	sum := sha256.Sum256(content)
+	key := fmt.Sprintf("documents/%s/%s/%s", folder, hex.EncodeToString(sum[:16]), filename)
+
+	exists, err := uc.storageService.ObjectExists(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("storage service: object exists: %w", err)
+	}
+	if !exists {
+		if err := uc.storageService.PutObject(ctx, key, "application/pdf", content); err != nil {
+			return nil, fmt.Errorf("storage service: put document: %w", err)
+		}
+	}
+
+	url, err := uc.storageService.GenerateDownloadURL(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("storage service: download url: %w", err)
+	}
+	return &DocumentOutput{Filename: filename, URL: url}, nil
+}
+
+func organizationParty(org *entity.Organization) service.Party {
+	return service.Party{
+		Name:       org.Name,
+		TaxID:      org.TaxID,
+		BranchCode: org.BranchCode,
+		Phone:      org.Phone,
+	}
+}
+
+func locationParty(l *entity.Location) service.Party {
+	return service.Party{
+		Name:    l.Name,
+		Address: l.FormattedAddress(),
+		Contact: l.ContactName,
+		Phone:   l.ContactPhone,
+	}
+}
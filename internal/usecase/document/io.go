@@ -0,0 +1,7 @@
+package document
+
+// DocumentOutput represents a generated document stored for download
+type DocumentOutput struct {
+	Filename string
+	URL      string // presigned download URL
+}
@@ -0,0 +1,224 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// DriverUseCase handles driver management operations
+type DriverUseCase struct {
+	driverRepo repository.DriverRepository
+	userRepo   repository.UserRepository
+	transactor repository.Transactor
+}
+
+// NewDriverUseCase creates a new driver use case
+func NewDriverUseCase(
+	driverRepo repository.DriverRepository,
+	userRepo repository.UserRepository,
+	transactor repository.Transactor,
+) *DriverUseCase {
+	return &DriverUseCase{
+		driverRepo: driverRepo,
+		userRepo:   userRepo,
+		transactor: transactor,
+	}
+}
+
+// Create creates a driver profile for an existing user
+func (uc *DriverUseCase) Create(ctx context.Context, input CreateDriverInput) (*DriverOutput, error) {
+	user, err := uc.userRepo.FindByID(ctx, input.UserID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("user repository: find by id: %w", err)
+	}
+
+	// A user can only have one driver profile
+	existing, err := uc.driverRepo.FindByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return nil, fmt.Errorf("driver repository: find by user id: %w", err)
+	}
+	if existing != nil {
+		return nil, errs.ErrConflict
+	}
+
+	driver := newDriver(user, input.ProfileInput)
+	if err := uc.driverRepo.Create(ctx, driver); err != nil {
+		return nil, fmt.Errorf("driver repository: create driver: %w", err)
+	}
+
+	return toDriverOutput(driver, time.Now()), nil
+}
+
+// Invite creates a phone-only user account together with its driver profile
+func (uc *DriverUseCase) Invite(ctx context.Context, input InviteDriverInput) (*DriverOutput, error) {
+	var driver *entity.Driver
+
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		existingUser, err := uc.userRepo.FindByPhoneNumber(ctx, input.PhoneNumber)
+		if err != nil && !errors.Is(err, errs.ErrNotFound) {
+			return fmt.Errorf("user repository: find by phone number: %w", err)
+		}
+		if existingUser != nil {
+			return errs.ErrConflict
+		}
+
+		phone := input.PhoneNumber
+		user := &entity.User{
+			PhoneNumber: &phone,
+			FirstName:   input.FirstName,
+			LastName:    input.LastName,
+		}
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			return fmt.Errorf("user repository: create user: %w", err)
+		}
+
+		driver = newDriver(user, input.ProfileInput)
+		if err := uc.driverRepo.Create(ctx, driver); err != nil {
+			return fmt.Errorf("driver repository: create driver: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDriverOutput(driver, time.Now()), nil
+}
+
+// Get returns a driver by ID
+func (uc *DriverUseCase) Get(ctx context.Context, id uuid.UUID) (*DriverOutput, error) {
+	driver, err := uc.findDriver(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toDriverOutput(driver, time.Now()), nil
+}
+
+// List returns drivers matching the input criteria
+func (uc *DriverUseCase) List(ctx context.Context, input ListDriversInput) ([]*DriverOutput, int64, error) {
+	drivers, total, err := uc.driverRepo.List(ctx, repository.DriverFilter{
+		Status: input.Status,
+		Search: input.Search,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("driver repository: list drivers: %w", err)
+	}
+
+	now := time.Now()
+	outputs := make([]*DriverOutput, len(drivers))
+	for i, d := range drivers {
+		outputs[i] = toDriverOutput(d, now)
+	}
+	return outputs, total, nil
+}
+
+// Update updates a driver's profile
+func (uc *DriverUseCase) Update(ctx context.Context, id uuid.UUID, input UpdateDriverInput) (*DriverOutput, error) {
+	driver, err := uc.findDriver(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applyProfile(driver, input.ProfileInput)
+	if err := uc.driverRepo.Update(ctx, driver); err != nil {
+		return nil, fmt.Errorf("driver repository: update driver: %w", err)
+	}
+
+	return toDriverOutput(driver, time.Now()), nil
+}
+
+// UpdateStatus changes a driver's working status
+func (uc *DriverUseCase) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.DriverStatus) (*DriverOutput, error) {
+	driver, err := uc.findDriver(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	driver.Status = status
+	if err := uc.driverRepo.Update(ctx, driver); err != nil {
+		return nil, fmt.Errorf("driver repository: update driver: %w", err)
+	}
+
+	return toDriverOutput(driver, time.Now()), nil
+}
+
+// Delete removes a driver profile. The linked user account is kept.
+func (uc *DriverUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := uc.driverRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("driver repository: delete driver: %w", err)
+	}
+	return nil
+}
+
+// findDriver loads a driver and normalizes repository errors
+func (uc *DriverUseCase) findDriver(ctx context.Context, id uuid.UUID) (*entity.Driver, error) {
+	driver, err := uc.driverRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("driver repository: find by id: %w", err)
+	}
+	return driver, nil
+}
+
+func newDriver(user *entity.User, profile ProfileInput) *entity.Driver {
+	driver := &entity.Driver{
+		UserID: user.ID,
+		Status: entity.DriverStatusAvailable,
+		User:   user,
+	}
+	applyProfile(driver, profile)
+	return driver
+}
+
+func applyProfile(driver *entity.Driver, profile ProfileInput) {
+	driver.LicenseNumber = profile.LicenseNumber
+	driver.LicenseClass = profile.LicenseClass
+	driver.LicenseExpiry = profile.LicenseExpiry
+	driver.EmploymentType = profile.EmploymentType
+	driver.HomeDepot = profile.HomeDepot
+	driver.EmergencyContactName = profile.EmergencyContactName
+	driver.EmergencyContactPhone = profile.EmergencyContactPhone
+}
+
+func toDriverOutput(d *entity.Driver, now time.Time) *DriverOutput {
+	out := &DriverOutput{
+		ID:                    d.ID,
+		UserID:                d.UserID,
+		LicenseNumber:         d.LicenseNumber,
+		LicenseClass:          d.LicenseClass,
+		LicenseExpiry:         d.LicenseExpiry,
+		LicenseValid:          d.IsLicenseValid(now),
+		EmploymentType:        d.EmploymentType,
+		HomeDepot:             d.HomeDepot,
+		EmergencyContactName:  d.EmergencyContactName,
+		EmergencyContactPhone: d.EmergencyContactPhone,
+		Status:                d.Status,
+		CreatedAt:             d.CreatedAt,
+		UpdatedAt:             d.UpdatedAt,
+	}
+	if d.User != nil {
+		out.FirstName = d.User.FirstName
+		out.LastName = d.User.LastName
+		out.Email = d.User.Email
+		if d.User.PhoneNumber != nil {
+			out.PhoneNumber = *d.User.PhoneNumber
+		}
+	}
+	return out
+}
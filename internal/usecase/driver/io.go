@@ -0,0 +1,68 @@
+package driver
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ProfileInput represents the driver-specific profile fields
+type ProfileInput struct {
+	LicenseNumber         string
+	LicenseClass          string
+	LicenseExpiry         time.Time
+	EmploymentType        entity.EmploymentType
+	HomeDepot             string
+	EmergencyContactName  string
+	EmergencyContactPhone string
+}
+
+// CreateDriverInput represents data for creating a driver profile for an existing user
+type CreateDriverInput struct {
+	UserID uuid.UUID
+	ProfileInput
+}
+
+// InviteDriverInput represents data for inviting a new driver with a phone-only account
+type InviteDriverInput struct {
+	PhoneNumber string
+	FirstName   string
+	LastName    string
+	ProfileInput
+}
+
+// UpdateDriverInput represents data for updating a driver profile
+type UpdateDriverInput struct {
+	ProfileInput
+}
+
+// ListDriversInput represents criteria for listing drivers
+type ListDriversInput struct {
+	Status *entity.DriverStatus
+	Search string
+	Limit  int
+	Offset int
+}
+
+// DriverOutput represents driver output data
+type DriverOutput struct {
+	ID                    uuid.UUID
+	UserID                uuid.UUID
+	FirstName             string
+	LastName              string
+	PhoneNumber           string
+	Email                 *string
+	LicenseNumber         string
+	LicenseClass          string
+	LicenseExpiry         time.Time
+	LicenseValid          bool
+	EmploymentType        entity.EmploymentType
+	HomeDepot             string
+	EmergencyContactName  string
+	EmergencyContactPhone string
+	Status                entity.DriverStatus
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
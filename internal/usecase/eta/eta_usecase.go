@@ -0,0 +1,425 @@
+package eta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"tms-core-service/internal/domain/cache"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultServiceTime is the time planned at a stop that does not give its own
+	DefaultServiceTime = 15 * time.Minute
+
+	// minLaneSamples is how many observed legs a lane hour needs before its speed replaces the estimate
+	minLaneSamples = 3
+
+	// legs slower or faster than this are treated as noise (breaks, missed geofences) and not learned from
+	minLegSpeedKmh = 5.0
+	maxLegSpeedKmh = 120.0
+
+	throttleKeyPrefix   = "eta:throttle:"
+	laneSampleKeyPrefix = "eta:lane-sample:"
+	laneSampleTTL       = 30 * 24 * time.Hour
+)
+
+// ETAUseCase predicts the arrival at every remaining stop of a vehicle's active trips as positions come in,
+// flags stops whose predicted arrival misses their time window and learns lane speeds from completed legs
+type ETAUseCase struct {
+	tripRepo           repository.TripRepository
+	shipmentRepo       repository.ShipmentRepository
+	locationRepo       repository.LocationRepository
+	laneSpeedRepo      repository.LaneSpeedRepository
+	delayRepo          repository.StopDelayRepository
+	cacheRepo          cache.CacheRepository
+	estimator          service.TravelEstimator
+	publisher          service.EventPublisher
+	transactor         repository.Transactor
+	defaultServiceTime time.Duration
+	delayMargin        time.Duration
+	minInterval        time.Duration
+}
+
+// NewETAUseCase creates a new ETA use case. A stop is delayed once its ETA is more than delayMargin past
+// its window; ETAs of a vehicle are recomputed at most once per minInterval, or on every fix when zero.
+func NewETAUseCase(
+	tripRepo repository.TripRepository,
+	shipmentRepo repository.ShipmentRepository,
+	locationRepo repository.LocationRepository,
+	laneSpeedRepo repository.LaneSpeedRepository,
+	delayRepo repository.StopDelayRepository,
+	cacheRepo cache.CacheRepository,
+	estimator service.TravelEstimator,
+	publisher service.EventPublisher,
+	transactor repository.Transactor,
+	defaultServiceTime time.Duration,
+	delayMargin time.Duration,
+	minInterval time.Duration,
+) *ETAUseCase {
+	if defaultServiceTime <= 0 {
+		defaultServiceTime = DefaultServiceTime
+	}
+	return &ETAUseCase{
+		tripRepo:           tripRepo,
+		shipmentRepo:       shipmentRepo,
+		locationRepo:       locationRepo,
+		laneSpeedRepo:      laneSpeedRepo,
+		delayRepo:          delayRepo,
+		cacheRepo:          cacheRepo,
+		estimator:          estimator,
+		publisher:          publisher,
+		transactor:         transactor,
+		defaultServiceTime: defaultServiceTime,
+		delayMargin:        delayMargin,
+		minInterval:        minInterval,
+	}
+}
+
+// ObservePositions recomputes the ETAs of the vehicles in a batch of positions from their newest fix
+func (uc *ETAUseCase) ObservePositions(ctx context.Context, positions []entity.VehiclePosition) error {
+	newest := make(map[uuid.UUID]entity.VehiclePosition)
+	var vehicleIDs []uuid.UUID
+	for _, p := range positions {
+		n, ok := newest[p.VehicleID]
+		if !ok {
+			vehicleIDs = append(vehicleIDs, p.VehicleID)
+		}
+		if !ok || p.RecordedAt.After(n.RecordedAt) {
+			newest[p.VehicleID] = p
+		}
+	}
+
+	for _, id := range vehicleIDs {
+		if uc.minInterval > 0 {
+			ok, err := uc.cacheRepo.SetNX(ctx, throttleKeyPrefix+id.String(), "1", uc.minInterval)
+			if err != nil {
+				return fmt.Errorf("cache repository: set eta throttle: %w", err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		if err := uc.updateVehicle(ctx, newest[id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Delays returns the delays detected on a trip, newest first
+func (uc *ETAUseCase) Delays(ctx context.Context, tripID uuid.UUID) ([]*DelayOutput, error) {
+	if _, err := uc.tripRepo.FindByID(ctx, tripID); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("trip repository: find by id: %w", err)
+	}
+
+	delays, err := uc.delayRepo.ListByTrip(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("stop delay repository: list by trip: %w", err)
+	}
+
+	outputs := make([]*DelayOutput, len(delays))
+	for i, d := range delays {
+		outputs[i] = &DelayOutput{
+			ID:         d.ID,
+			TripID:     d.TripID,
+			StopID:     d.StopID,
+			ShipmentID: d.ShipmentID,
+			VehicleID:  d.VehicleID,
+			ETA:        d.ETA,
+			WindowEnd:  d.WindowEnd,
+			Delay:      d.Delay(),
+			DetectedAt: d.DetectedAt,
+		}
+	}
+	return outputs, nil
+}
+
+// updateVehicle walks the vehicle's active trips in order from its current position. Each pending stop
+// is reached after the drive from the previous point, at the lane's historical speed for the hour when
+// known, and left after waiting for its window to open and the planned service time.
+func (uc *ETAUseCase) updateVehicle(ctx context.Context, position entity.VehiclePosition) error {
+	trips, err := uc.tripRepo.FindActiveByVehicle(ctx, position.VehicleID)
+	if err != nil {
+		return fmt.Errorf("trip repository: find active by vehicle: %w", err)
+	}
+	if len(trips) == 0 {
+		return nil
+	}
+
+	shipments, locations, err := uc.loadStopData(ctx, trips)
+	if err != nil {
+		return err
+	}
+	lanes := &laneSpeeds{repo: uc.laneSpeedRepo, byLane: make(map[[2]string][]*entity.LaneSpeed)}
+
+	now := time.Now()
+	cursor := position.RecordedAt
+	fromLat, fromLng := position.Latitude, position.Longitude
+	fromProvince := ""
+
+	for _, trip := range trips {
+		if err := uc.learnLegs(ctx, trip, locations); err != nil {
+			return err
+		}
+		if trip.Status == entity.TripStatusDispatched && cursor.Before(trip.PlannedStart) {
+			cursor = trip.PlannedStart
+		}
+
+		var changed []*entity.TripStop
+		var delays []*entity.StopDelay
+		for i := range trip.Stops {
+			stop := &trip.Stops[i]
+			location := locations[stop.LocationID]
+			if location == nil || !location.HasCoordinates() {
+				continue // cannot be predicted; later stops are timed from the vehicle instead
+			}
+
+			switch stop.Status {
+			case entity.StopStatusDeparted:
+				fromProvince = location.Province
+				continue
+			case entity.StopStatusArrived:
+				if leave := stop.ArrivedAt.Add(uc.serviceTime(stop)); leave.After(cursor) {
+					cursor = leave
+				}
+				fromProvince = location.Province
+				continue
+			}
+
+			meters, drive, err := uc.estimator.Estimate(ctx, fromLat, fromLng, *location.Latitude, *location.Longitude)
+			if err != nil {
+				return fmt.Errorf("travel estimator: estimate: %w", err)
+			}
+			origin := fromProvince
+			if origin == "" {
+				origin = location.Province
+			}
+			speedKmh, err := lanes.speed(ctx, origin, location.Province, cursor.In(timeutil.Thailand).Hour())
+			if err != nil {
+				return err
+			}
+			if speedKmh > 0 {
+				drive = time.Duration(meters / 1000 / speedKmh * float64(time.Hour))
+			}
+
+			eta := cursor.Add(drive)
+			stop.ETA = &eta
+			changed = append(changed, stop)
+
+			shipment := shipments[stop.ShipmentID]
+			var windowFrom, windowTo *time.Time
+			if shipment != nil {
+				windowFrom, windowTo = shipment.Window(stop.Type)
+			}
+			start := eta
+			if windowFrom != nil && windowFrom.After(start) {
+				start = *windowFrom
+			}
+			cursor = start.Add(uc.serviceTime(stop))
+			fromLat, fromLng, fromProvince = *location.Latitude, *location.Longitude, location.Province
+
+			late := windowTo != nil && eta.After(windowTo.Add(uc.delayMargin))
+			switch {
+			case late && stop.DelayedAt == nil:
+				stop.DelayedAt = &now
+				delays = append(delays, &entity.StopDelay{
+					TripID:     trip.ID,
+					StopID:     stop.ID,
+					ShipmentID: stop.ShipmentID,
+					VehicleID:  trip.VehicleID,
+					ETA:        eta,
+					WindowEnd:  *windowTo,
+					DetectedAt: now,
+				})
+			case !late && stop.DelayedAt != nil:
+				stop.DelayedAt = nil
+			}
+		}
+
+		if err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+			if err := uc.tripRepo.UpdateStopETAs(ctx, changed); err != nil {
+				return fmt.Errorf("trip repository: update stop etas: %w", err)
+			}
+			for _, d := range delays {
+				if err := uc.delayRepo.Create(ctx, d); err != nil {
+					return fmt.Errorf("stop delay repository: create: %w", err)
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		uc.publish(ctx, trip, changed, delays, shipments)
+	}
+	return nil
+}
+
+// publish pushes a trip's new ETAs to its channel and newly detected delays to the trip's, shipment's and
+// organization's channels. The ETAs are already saved, so failures are only logged.
+func (uc *ETAUseCase) publish(ctx context.Context, trip *entity.Trip, changed []*entity.TripStop, delays []*entity.StopDelay, shipments map[uuid.UUID]*entity.Shipment) {
+	if len(changed) == 0 {
+		return
+	}
+
+	stops := make([]map[string]interface{}, len(changed))
+	for i, s := range changed {
+		stops[i] = map[string]interface{}{
+			"stop_id":    s.ID,
+			"sequence":   s.Sequence,
+			"eta":        s.ETA,
+			"delayed_at": s.DelayedAt,
+		}
+	}
+	events := []service.RealtimeEvent{{
+		Channels:   []string{service.Channel(service.ChannelTrip, trip.ID)},
+		Type:       "trip.eta_updated",
+		Data:       map[string]interface{}{"trip_id": trip.ID, "stops": stops},
+		OccurredAt: time.Now(),
+	}}
+
+	for _, d := range delays {
+		channels := []string{
+			service.Channel(service.ChannelTrip, d.TripID),
+			service.Channel(service.ChannelShipment, d.ShipmentID),
+		}
+		data := map[string]interface{}{
+			"trip_id":       d.TripID,
+			"stop_id":       d.StopID,
+			"shipment_id":   d.ShipmentID,
+			"vehicle_id":    d.VehicleID,
+			"eta":           d.ETA,
+			"window_end":    d.WindowEnd,
+			"delay_minutes": int(d.Delay() / time.Minute),
+		}
+		if shipment := shipments[d.ShipmentID]; shipment != nil {
+			channels = append(channels, service.Channel(service.ChannelOrganization, shipment.OrganizationID))
+			data["reference"] = shipment.Reference
+		}
+		events = append(events, service.RealtimeEvent{
+			Channels:   channels,
+			Type:       "shipment.delayed",
+			Data:       data,
+			OccurredAt: d.DetectedAt,
+		})
+	}
+
+	if err := uc.publisher.Publish(ctx, events...); err != nil {
+		log.Printf("[ERROR] realtime: publish etas: %v", err)
+	}
+}
+
+// learnLegs records the speed of every leg of the trip that has been driven, once per leg.
+// A leg runs from the departure at one stop to the arrival at the next stop at another location.
+func (uc *ETAUseCase) learnLegs(ctx context.Context, trip *entity.Trip, locations map[uuid.UUID]*entity.Location) error {
+	for i := 1; i < len(trip.Stops); i++ {
+		prev, stop := trip.Stops[i-1], trip.Stops[i]
+		if prev.DepartedAt == nil || stop.ArrivedAt == nil || prev.LocationID == stop.LocationID {
+			continue
+		}
+		driven := stop.ArrivedAt.Sub(*prev.DepartedAt)
+		from, to := locations[prev.LocationID], locations[stop.LocationID]
+		if driven <= 0 || from == nil || to == nil || !from.HasCoordinates() || !to.HasCoordinates() {
+			continue
+		}
+
+		first, err := uc.cacheRepo.SetNX(ctx, laneSampleKeyPrefix+stop.ID.String(), "1", laneSampleTTL)
+		if err != nil {
+			return fmt.Errorf("cache repository: mark lane sample: %w", err)
+		}
+		if !first {
+			continue
+		}
+
+		meters, _, err := uc.estimator.Estimate(ctx, *from.Latitude, *from.Longitude, *to.Latitude, *to.Longitude)
+		if err != nil {
+			return fmt.Errorf("travel estimator: estimate: %w", err)
+		}
+		speedKmh := meters / 1000 / driven.Hours()
+		if speedKmh < minLegSpeedKmh || speedKmh > maxLegSpeedKmh {
+			continue
+		}
+
+		hour := prev.DepartedAt.In(timeutil.Thailand).Hour()
+		if err := uc.laneSpeedRepo.RecordSample(ctx, from.Province, to.Province, hour, speedKmh); err != nil {
+			return fmt.Errorf("lane speed repository: record sample: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadStopData fetches the shipments and locations of every stop on the trips
+func (uc *ETAUseCase) loadStopData(ctx context.Context, trips []*entity.Trip) (map[uuid.UUID]*entity.Shipment, map[uuid.UUID]*entity.Location, error) {
+	var shipmentIDs, locationIDs []uuid.UUID
+	for _, t := range trips {
+		shipmentIDs = append(shipmentIDs, t.ShipmentIDs()...)
+		for _, s := range t.Stops {
+			locationIDs = append(locationIDs, s.LocationID)
+		}
+	}
+
+	foundShipments, err := uc.shipmentRepo.FindByIDs(ctx, shipmentIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("shipment repository: find by ids: %w", err)
+	}
+	shipments := make(map[uuid.UUID]*entity.Shipment, len(foundShipments))
+	for _, s := range foundShipments {
+		shipments[s.ID] = s
+	}
+
+	foundLocations, err := uc.locationRepo.FindByIDs(ctx, locationIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("location repository: find by ids: %w", err)
+	}
+	locations := make(map[uuid.UUID]*entity.Location, len(foundLocations))
+	for _, l := range foundLocations {
+		locations[l.ID] = l
+	}
+	return shipments, locations, nil
+}
+
+func (uc *ETAUseCase) serviceTime(stop *entity.TripStop) time.Duration {
+	if stop.ServiceTime != nil {
+		return *stop.ServiceTime
+	}
+	return uc.defaultServiceTime
+}
+
+// laneSpeeds looks up historical lane speeds, reading each lane once
+type laneSpeeds struct {
+	repo   repository.LaneSpeedRepository
+	byLane map[[2]string][]*entity.LaneSpeed
+}
+
+// speed returns the lane's average speed for the hour, or zero when it has too few samples
+func (l *laneSpeeds) speed(ctx context.Context, origin, destination string, hour int) (float64, error) {
+	key := [2]string{origin, destination}
+	speeds, ok := l.byLane[key]
+	if !ok {
+		var err error
+		speeds, err = l.repo.FindByLane(ctx, origin, destination)
+		if err != nil {
+			return 0, fmt.Errorf("lane speed repository: find by lane: %w", err)
+		}
+		l.byLane[key] = speeds
+	}
+
+	for _, s := range speeds {
+		if s.Hour == hour && s.Samples >= minLaneSamples {
+			return s.SpeedKmh, nil
+		}
+	}
+	return 0, nil
+}
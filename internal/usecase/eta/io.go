@@ -0,0 +1,20 @@
+package eta
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DelayOutput represents a detected stop delay
+type DelayOutput struct {
+	ID         uuid.UUID
+	TripID     uuid.UUID
+	StopID     uuid.UUID
+	ShipmentID uuid.UUID
+	VehicleID  uuid.UUID
+	ETA        time.Time
+	WindowEnd  time.Time
+	Delay      time.Duration
+	DetectedAt time.Time
+}
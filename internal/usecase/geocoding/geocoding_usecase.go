@@ -0,0 +1,61 @@
+package geocoding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/service"
+)
+
+// GeocodingUseCase handles address/coordinate lookups for planners
+type GeocodingUseCase struct {
+	geocoder service.Geocoder
+}
+
+// NewGeocodingUseCase creates a new geocoding use case
+func NewGeocodingUseCase(geocoder service.Geocoder) *GeocodingUseCase {
+	return &GeocodingUseCase{geocoder: geocoder}
+}
+
+// Geocode resolves an address to coordinates
+func (uc *GeocodingUseCase) Geocode(ctx context.Context, input GeocodeInput) (*GeocodeOutput, error) {
+	result, err := uc.geocoder.Geocode(ctx, service.GeocodeQuery{
+		Address:     input.Address,
+		Subdistrict: input.Subdistrict,
+		District:    input.District,
+		Province:    input.Province,
+		Postcode:    input.Postcode,
+	})
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("geocoder: geocode: %w", err)
+	}
+	return toGeocodeOutput(result), nil
+}
+
+// ReverseGeocode resolves coordinates to the nearest known address
+func (uc *GeocodingUseCase) ReverseGeocode(ctx context.Context, lat, lng float64) (*GeocodeOutput, error) {
+	result, err := uc.geocoder.ReverseGeocode(ctx, lat, lng)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("geocoder: reverse geocode: %w", err)
+	}
+	return toGeocodeOutput(result), nil
+}
+
+func toGeocodeOutput(r *service.GeocodeResult) *GeocodeOutput {
+	return &GeocodeOutput{
+		Latitude:         r.Latitude,
+		Longitude:        r.Longitude,
+		FormattedAddress: r.FormattedAddress,
+		Precision:        r.Precision,
+		Approximate:      r.Precision.IsApproximate(),
+		Provider:         r.Provider,
+	}
+}
@@ -0,0 +1,22 @@
+package geocoding
+
+import "tms-core-service/internal/domain/entity"
+
+// GeocodeInput represents an address to resolve to coordinates
+type GeocodeInput struct {
+	Address     string
+	Subdistrict string
+	District    string
+	Province    string
+	Postcode    string
+}
+
+// GeocodeOutput represents a geocoded position
+type GeocodeOutput struct {
+	Latitude         float64
+	Longitude        float64
+	FormattedAddress string
+	Precision        entity.GeoPrecision
+	Approximate      bool
+	Provider         string
+}
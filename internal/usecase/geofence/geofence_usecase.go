@@ -0,0 +1,606 @@
+package geofence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"tms-core-service/internal/domain/cache"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultDwellTime is how long a vehicle must stay inside a geofence before it counts as arrived
+	DefaultDwellTime = 2 * time.Minute
+
+	// DefaultExitBufferM is how far outside a geofence a vehicle must be before it counts as departed
+	DefaultExitBufferM = 30.0
+
+	// stateKeyPrefix keys the geofence presence of each vehicle in the cache
+	stateKeyPrefix = "geofence:state:"
+
+	// stateTTL is how long presence survives without new fixes
+	stateTTL = 7 * 24 * time.Hour
+
+	// lockKeyPrefix keys the lock that serializes the evaluation of each vehicle's fixes across instances
+	lockKeyPrefix = "geofence:lock:"
+
+	// lockTTL releases the lock of an instance that died while holding it
+	lockTTL = time.Minute
+
+	// lockWait bounds how long an evaluation waits for another instance to release the vehicle
+	lockWait = 10 * time.Second
+
+	// versionKey changes whenever a geofence does, telling every instance to reload the active ones
+	versionKey = "geofence:version"
+
+	// activeTTL bounds how long the active geofences are kept in memory when a change was missed
+	activeTTL = 5 * time.Minute
+)
+
+// activeGeofences is the in-memory copy of the active geofences with their bounding boxes
+type activeGeofences struct {
+	version   string
+	loadedAt  time.Time
+	geofences []*entity.Geofence
+	min, max  []entity.Coordinate // bounds of each geofence, by index
+	ids       map[uuid.UUID]bool
+}
+
+// near reports whether a point lies within the bounds of the i-th geofence
+func (a *activeGeofences) near(i int, point entity.Coordinate) bool {
+	return point.Latitude >= a.min[i].Latitude && point.Latitude <= a.max[i].Latitude &&
+		point.Longitude >= a.min[i].Longitude && point.Longitude <= a.max[i].Longitude
+}
+
+// GeofenceUseCase handles geofence management and turns vehicle positions into arrival and departure events
+type GeofenceUseCase struct {
+	geofenceRepo repository.GeofenceRepository
+	locationRepo repository.LocationRepository
+	tripRepo     repository.TripRepository
+	cacheRepo    cache.CacheRepository
+	geometry     service.Geometry
+	publisher    service.EventPublisher
+	transactor   repository.Transactor
+
+	mu     sync.Mutex
+	active *activeGeofences
+}
+
+// NewGeofenceUseCase creates a new geofence use case
+func NewGeofenceUseCase(
+	geofenceRepo repository.GeofenceRepository,
+	locationRepo repository.LocationRepository,
+	tripRepo repository.TripRepository,
+	cacheRepo cache.CacheRepository,
+	geometry service.Geometry,
+	publisher service.EventPublisher,
+	transactor repository.Transactor,
+) *GeofenceUseCase {
+	return &GeofenceUseCase{
+		geofenceRepo: geofenceRepo,
+		locationRepo: locationRepo,
+		tripRepo:     tripRepo,
+		cacheRepo:    cacheRepo,
+		geometry:     geometry,
+		publisher:    publisher,
+		transactor:   transactor,
+	}
+}
+
+// Create adds a geofence to a location
+func (uc *GeofenceUseCase) Create(ctx context.Context, locationID uuid.UUID, input GeofenceInput) (*GeofenceOutput, error) {
+	location, err := uc.locationRepo.FindByID(ctx, locationID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("location repository: find by id: %w", err)
+	}
+
+	geofence := &entity.Geofence{LocationID: locationID}
+	if err := applyInput(geofence, location, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.geofenceRepo.Create(ctx, geofence); err != nil {
+		return nil, fmt.Errorf("geofence repository: create geofence: %w", err)
+	}
+	uc.invalidate(ctx)
+	return toGeofenceOutput(geofence), nil
+}
+
+// ListByLocation returns the geofences of a location
+func (uc *GeofenceUseCase) ListByLocation(ctx context.Context, locationID uuid.UUID) ([]*GeofenceOutput, error) {
+	if _, err := uc.locationRepo.FindByID(ctx, locationID); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("location repository: find by id: %w", err)
+	}
+
+	geofences, err := uc.geofenceRepo.ListByLocation(ctx, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("geofence repository: list by location: %w", err)
+	}
+
+	outputs := make([]*GeofenceOutput, len(geofences))
+	for i, g := range geofences {
+		outputs[i] = toGeofenceOutput(g)
+	}
+	return outputs, nil
+}
+
+// Get returns a geofence by ID
+func (uc *GeofenceUseCase) Get(ctx context.Context, id uuid.UUID) (*GeofenceOutput, error) {
+	geofence, err := uc.findGeofence(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toGeofenceOutput(geofence), nil
+}
+
+// Update changes a geofence's boundary and thresholds
+func (uc *GeofenceUseCase) Update(ctx context.Context, id uuid.UUID, input GeofenceInput) (*GeofenceOutput, error) {
+	geofence, err := uc.findGeofence(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := uc.locationRepo.FindByID(ctx, geofence.LocationID)
+	if err != nil {
+		return nil, fmt.Errorf("location repository: find by id: %w", err)
+	}
+	if err := applyInput(geofence, location, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.geofenceRepo.Update(ctx, geofence); err != nil {
+		return nil, fmt.Errorf("geofence repository: update geofence: %w", err)
+	}
+	uc.invalidate(ctx)
+	return toGeofenceOutput(geofence), nil
+}
+
+// Delete removes a geofence. Vehicles inside it are forgotten on their next fix without an exit event.
+func (uc *GeofenceUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := uc.geofenceRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("geofence repository: delete geofence: %w", err)
+	}
+	uc.invalidate(ctx)
+	return nil
+}
+
+// ListEvents returns geofence events matching the filters, newest first
+func (uc *GeofenceUseCase) ListEvents(ctx context.Context, input ListEventsInput) ([]*EventOutput, int64, error) {
+	filter := repository.GeofenceEventFilter{
+		VehicleID:  input.VehicleID,
+		GeofenceID: input.GeofenceID,
+		LocationID: input.LocationID,
+		TripID:     input.TripID,
+		From:       input.From,
+		To:         input.To,
+	}
+	events, total, err := uc.geofenceRepo.ListEvents(ctx, filter, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("geofence repository: list events: %w", err)
+	}
+
+	outputs := make([]*EventOutput, len(events))
+	for i, e := range events {
+		outputs[i] = toEventOutput(e)
+	}
+	return outputs, total, nil
+}
+
+// ObservePositions evaluates newly ingested positions against the active geofences, records the
+// enter, dwell and exit events they cause and advances the stops of the vehicles' active trips:
+// a dwell marks the vehicle arrived at the stops at that location and an exit marks it departed.
+func (uc *GeofenceUseCase) ObservePositions(ctx context.Context, positions []entity.VehiclePosition) error {
+	if len(positions) == 0 {
+		return nil
+	}
+
+	active, err := uc.activeGeofences(ctx)
+	if err != nil {
+		return err
+	}
+
+	byVehicle := make(map[uuid.UUID][]entity.VehiclePosition)
+	var vehicleIDs []uuid.UUID
+	for _, p := range positions {
+		if _, ok := byVehicle[p.VehicleID]; !ok {
+			vehicleIDs = append(vehicleIDs, p.VehicleID)
+		}
+		byVehicle[p.VehicleID] = append(byVehicle[p.VehicleID], p)
+	}
+
+	for _, vehicleID := range vehicleIDs {
+		track := byVehicle[vehicleID]
+		sort.Slice(track, func(i, j int) bool { return track[i].RecordedAt.Before(track[j].RecordedAt) })
+		if err := uc.observeVehicle(ctx, vehicleID, track, active); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// observeVehicle replays one vehicle's fixes in time order. Fixes older than the last one
+// evaluated are skipped so late or repeated batches cannot re-trigger events. The vehicle is locked
+// from loading its state to saving it, so that no other instance evaluates its fixes meanwhile.
+func (uc *GeofenceUseCase) observeVehicle(ctx context.Context, vehicleID uuid.UUID, track []entity.VehiclePosition, active *activeGeofences) error {
+	unlock, err := uc.lockVehicle(ctx, vehicleID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := uc.loadState(ctx, vehicleID)
+	if err != nil {
+		return err
+	}
+	state.Forget(active.ids)
+
+	var events []*entity.GeofenceEvent
+	enteredAt := make(map[*entity.GeofenceEvent]time.Time) // dwell events: when the vehicle entered
+	for _, p := range track {
+		if !p.RecordedAt.After(state.LastAt) {
+			continue
+		}
+		state.LastAt = p.RecordedAt
+
+		point := entity.Coordinate{Latitude: p.Latitude, Longitude: p.Longitude}
+		for i, g := range active.geofences {
+			inside, clear := false, true
+			if active.near(i, point) {
+				inside, clear = uc.locate(g, point)
+			}
+			for _, t := range state.Observe(g, p.RecordedAt, inside, clear) {
+				event := &entity.GeofenceEvent{
+					GeofenceID: g.ID,
+					LocationID: g.LocationID,
+					VehicleID:  vehicleID,
+					Type:       t,
+					OccurredAt: p.RecordedAt,
+					Latitude:   p.Latitude,
+					Longitude:  p.Longitude,
+				}
+				if t == entity.GeofenceEventDwell {
+					enteredAt[event] = state.Presence[g.ID].EnteredAt
+				}
+				events = append(events, event)
+			}
+		}
+	}
+
+	if len(events) > 0 {
+		if err := uc.recordEvents(ctx, vehicleID, events, enteredAt); err != nil {
+			return err
+		}
+	}
+	return uc.saveState(ctx, vehicleID, state)
+}
+
+// recordEvents stores the events and applies arrivals and departures to the vehicle's active trips.
+// A vehicle arrives when it entered the geofence, not when its dwell time ran out.
+func (uc *GeofenceUseCase) recordEvents(ctx context.Context, vehicleID uuid.UUID, events []*entity.GeofenceEvent, enteredAt map[*entity.GeofenceEvent]time.Time) error {
+	trips, err := uc.tripRepo.FindActiveByVehicle(ctx, vehicleID)
+	if err != nil {
+		return fmt.Errorf("trip repository: find active by vehicle: %w", err)
+	}
+
+	changed := make(map[uuid.UUID]*entity.TripStop)
+	for _, e := range events {
+		var stops []*entity.TripStop
+		var trip *entity.Trip
+		switch e.Type {
+		case entity.GeofenceEventDwell:
+			for _, t := range trips {
+				if stops = t.ArriveAt(e.LocationID, enteredAt[e]); len(stops) > 0 {
+					trip = t
+					break
+				}
+			}
+		case entity.GeofenceEventExit:
+			for _, t := range trips {
+				if stops = t.DepartFrom(e.LocationID, e.OccurredAt); len(stops) > 0 {
+					trip = t
+					break
+				}
+			}
+		}
+		if trip == nil {
+			continue
+		}
+		e.TripID = &trip.ID
+		e.StopID = &stops[0].ID
+		for _, s := range stops {
+			changed[s.ID] = s
+		}
+	}
+
+	stops := make([]*entity.TripStop, 0, len(changed))
+	for _, s := range changed {
+		stops = append(stops, s)
+	}
+
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.geofenceRepo.CreateEvents(ctx, events); err != nil {
+			return fmt.Errorf("geofence repository: create events: %w", err)
+		}
+		if err := uc.tripRepo.UpdateStopProgress(ctx, stops); err != nil {
+			return fmt.Errorf("trip repository: update stop progress: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.publish(ctx, events, stops)
+	return nil
+}
+
+// publish pushes geofence events to the vehicle's channel and stop progress to the trip's and shipment's.
+// The events are already saved, so failures are only logged.
+func (uc *GeofenceUseCase) publish(ctx context.Context, events []*entity.GeofenceEvent, stops []*entity.TripStop) {
+	realtime := make([]service.RealtimeEvent, 0, len(events)+len(stops))
+	for _, e := range events {
+		data := map[string]interface{}{
+			"geofence_id": e.GeofenceID,
+			"location_id": e.LocationID,
+			"vehicle_id":  e.VehicleID,
+			"lat":         e.Latitude,
+			"lng":         e.Longitude,
+		}
+		if e.TripID != nil {
+			data["trip_id"] = *e.TripID
+			data["stop_id"] = *e.StopID
+		}
+		realtime = append(realtime, service.RealtimeEvent{
+			Channels:   []string{service.Channel(service.ChannelVehicle, e.VehicleID)},
+			Type:       "geofence." + string(e.Type),
+			Data:       data,
+			OccurredAt: e.OccurredAt,
+		})
+	}
+
+	now := time.Now()
+	for _, s := range stops {
+		realtime = append(realtime, service.RealtimeEvent{
+			Channels: []string{
+				service.Channel(service.ChannelTrip, s.TripID),
+				service.Channel(service.ChannelShipment, s.ShipmentID),
+			},
+			Type: "trip.stop_updated",
+			Data: map[string]interface{}{
+				"trip_id":     s.TripID,
+				"stop_id":     s.ID,
+				"shipment_id": s.ShipmentID,
+				"location_id": s.LocationID,
+				"sequence":    s.Sequence,
+				"type":        s.Type,
+				"status":      s.Status,
+				"arrived_at":  s.ArrivedAt,
+				"departed_at": s.DepartedAt,
+			},
+			OccurredAt: now,
+		})
+	}
+
+	if err := uc.publisher.Publish(ctx, realtime...); err != nil {
+		log.Printf("[ERROR] realtime: publish geofence events: %v", err)
+	}
+}
+
+// locate reports whether a point lies inside the geofence and whether it lies beyond its exit buffer
+func (uc *GeofenceUseCase) locate(g *entity.Geofence, point entity.Coordinate) (inside, clear bool) {
+	switch g.Shape {
+	case entity.GeofenceShapeCircle:
+		d := uc.geometry.Distance(g.Center.Latitude, g.Center.Longitude, point.Latitude, point.Longitude)
+		return d <= g.RadiusM, d > g.RadiusM+g.ExitBufferM
+	case entity.GeofenceShapePolygon:
+		if uc.geometry.PolygonContains(g.Polygon, point) {
+			return true, false
+		}
+		return false, uc.geometry.DistanceToPolygon(g.Polygon, point) > g.ExitBufferM
+	}
+	return false, true
+}
+
+// activeGeofences returns the active geofences, reloading them when any geofence changed since they
+// were loaded, as told by the version key, or when they have been kept for activeTTL
+func (uc *GeofenceUseCase) activeGeofences(ctx context.Context) (*activeGeofences, error) {
+	version, err := uc.cacheRepo.Get(ctx, versionKey)
+	if err != nil {
+		return nil, fmt.Errorf("cache repository: get geofence version: %w", err)
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if a := uc.active; a != nil && a.version == version && time.Since(a.loadedAt) < activeTTL {
+		return a, nil
+	}
+
+	geofences, err := uc.geofenceRepo.ListActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("geofence repository: list active: %w", err)
+	}
+	a := &activeGeofences{
+		version:   version,
+		loadedAt:  time.Now(),
+		geofences: geofences,
+		min:       make([]entity.Coordinate, len(geofences)),
+		max:       make([]entity.Coordinate, len(geofences)),
+		ids:       make(map[uuid.UUID]bool, len(geofences)),
+	}
+	for i, g := range geofences {
+		a.min[i], a.max[i] = g.Bounds()
+		a.ids[g.ID] = true
+	}
+	uc.active = a
+	return a, nil
+}
+
+// invalidate makes every instance reload the active geofences. The change is already saved, so a
+// failure is only logged; the geofences are then reloaded within activeTTL.
+func (uc *GeofenceUseCase) invalidate(ctx context.Context) {
+	uc.mu.Lock()
+	uc.active = nil
+	uc.mu.Unlock()
+
+	if err := uc.cacheRepo.Set(ctx, versionKey, uuid.NewString(), 0); err != nil {
+		log.Printf("[ERROR] cache repository: set geofence version: %v", err)
+	}
+}
+
+// lockVehicle waits up to lockWait for the vehicle's lock and returns the function releasing it
+func (uc *GeofenceUseCase) lockVehicle(ctx context.Context, vehicleID uuid.UUID) (func(), error) {
+	key, token := lockKeyPrefix+vehicleID.String(), uuid.NewString()
+	deadline := time.Now().Add(lockWait)
+	for {
+		ok, err := uc.cacheRepo.SetNX(ctx, key, token, lockTTL)
+		if err != nil {
+			return nil, fmt.Errorf("cache repository: lock vehicle: %w", err)
+		}
+		if ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("vehicle %s: %w", vehicleID, errs.ErrResourceLocked)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	return func() {
+		// released even when ctx is done, or the vehicle stays locked until lockTTL
+		if _, err := uc.cacheRepo.DeleteIfEquals(context.WithoutCancel(ctx), key, token); err != nil {
+			log.Printf("[ERROR] cache repository: unlock vehicle %s: %v", vehicleID, err)
+		}
+	}, nil
+}
+
+func (uc *GeofenceUseCase) loadState(ctx context.Context, vehicleID uuid.UUID) (*entity.GeofenceState, error) {
+	data, err := uc.cacheRepo.Get(ctx, stateKeyPrefix+vehicleID.String())
+	if err != nil {
+		return nil, fmt.Errorf("cache repository: get geofence state: %w", err)
+	}
+
+	state := entity.NewGeofenceState()
+	if data == "" {
+		return state, nil
+	}
+	if err := json.Unmarshal([]byte(data), state); err != nil {
+		return nil, fmt.Errorf("decode geofence state: %w", err)
+	}
+	if state.Presence == nil {
+		state.Presence = make(map[uuid.UUID]*entity.GeofencePresence)
+	}
+	return state, nil
+}
+
+func (uc *GeofenceUseCase) saveState(ctx context.Context, vehicleID uuid.UUID, state *entity.GeofenceState) error {
+	if err := uc.cacheRepo.Set(ctx, stateKeyPrefix+vehicleID.String(), state, stateTTL); err != nil {
+		return fmt.Errorf("cache repository: save geofence state: %w", err)
+	}
+	return nil
+}
+
+func (uc *GeofenceUseCase) findGeofence(ctx context.Context, id uuid.UUID) (*entity.Geofence, error) {
+	geofence, err := uc.geofenceRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("geofence repository: find by id: %w", err)
+	}
+	return geofence, nil
+}
+
+// applyInput validates the boundary and copies the input onto the geofence
+func applyInput(geofence *entity.Geofence, location *entity.Location, input GeofenceInput) error {
+	geofence.Name = input.Name
+	geofence.Shape = input.Shape
+	geofence.Active = input.Active
+	geofence.Center = entity.Coordinate{}
+	geofence.RadiusM = 0
+	geofence.Polygon = nil
+
+	switch input.Shape {
+	case entity.GeofenceShapeCircle:
+		switch {
+		case input.Center != nil:
+			geofence.Center = *input.Center
+		case location.HasCoordinates():
+			geofence.Center = entity.Coordinate{Latitude: *location.Latitude, Longitude: *location.Longitude}
+		default:
+			return errs.ValidationErrors{"center": {"required_without_location_pin"}}
+		}
+		geofence.RadiusM = input.RadiusM
+	case entity.GeofenceShapePolygon:
+		geofence.Polygon = input.Polygon
+	}
+
+	geofence.DwellTime = DefaultDwellTime
+	if input.DwellTime != nil {
+		geofence.DwellTime = *input.DwellTime
+	}
+	geofence.ExitBufferM = DefaultExitBufferM
+	if input.ExitBufferM != nil {
+		geofence.ExitBufferM = *input.ExitBufferM
+	}
+	return nil
+}
+
+func toGeofenceOutput(g *entity.Geofence) *GeofenceOutput {
+	output := &GeofenceOutput{
+		ID:          g.ID,
+		LocationID:  g.LocationID,
+		Name:        g.Name,
+		Shape:       g.Shape,
+		RadiusM:     g.RadiusM,
+		Polygon:     g.Polygon,
+		DwellTime:   g.DwellTime,
+		ExitBufferM: g.ExitBufferM,
+		Active:      g.Active,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+	if g.Shape == entity.GeofenceShapeCircle {
+		center := g.Center
+		output.Center = &center
+	}
+	return output
+}
+
+func toEventOutput(e *entity.GeofenceEvent) *EventOutput {
+	return &EventOutput{
+		ID:         e.ID,
+		GeofenceID: e.GeofenceID,
+		LocationID: e.LocationID,
+		VehicleID:  e.VehicleID,
+		TripID:     e.TripID,
+		StopID:     e.StopID,
+		Type:       e.Type,
+		OccurredAt: e.OccurredAt,
+		Latitude:   e.Latitude,
+		Longitude:  e.Longitude,
+	}
+}
@@ -0,0 +1,212 @@
+package geofence
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/internal/infra/service/geometry"
+
+	"github.com/google/uuid"
+)
+
+// memoryCache is an in-process cache.CacheRepository storing strings as given, like Redis
+type memoryCache struct {
+	mu   sync.Mutex
+	data map[string]string
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data[key], nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch v := value.(type) {
+	case string:
+		c.data[key] = v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		c.data[key] = string(data)
+	}
+	return nil
+}
+
+func (c *memoryCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data, key)
+	return nil
+}
+
+func (c *memoryCache) Exists(_ context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.data[key]
+	return ok, nil
+}
+
+func (c *memoryCache) SetNX(ctx context.Context, key string, value interface{}, exp time.Duration) (bool, error) {
+	if ok, _ := c.Exists(ctx, key); ok {
+		return false, nil
+	}
+	return true, c.Set(ctx, key, value, exp)
+}
+
+func (c *memoryCache) Increment(_ context.Context, key string, exp time.Duration) (int64, time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, _ := strconv.ParseInt(c.data[key], 10, 64)
+	n++
+	c.data[key] = strconv.FormatInt(n, 10)
+	return n, exp, nil
+}
+
+func (c *memoryCache) DeleteIfEquals(_ context.Context, key, value string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.data[key] != value {
+		return false, nil
+	}
+	delete(c.data, key)
+	return true, nil
+}
+
+type fakeGeofenceRepo struct {
+	repository.GeofenceRepository
+	geofences []*entity.Geofence
+	loads     int
+	events    []*entity.GeofenceEvent
+}
+
+func (r *fakeGeofenceRepo) ListActive(context.Context) ([]*entity.Geofence, error) {
+	r.loads++
+	return r.geofences, nil
+}
+
+func (r *fakeGeofenceRepo) CreateEvents(_ context.Context, events []*entity.GeofenceEvent) error {
+	r.events = append(r.events, events...)
+	return nil
+}
+
+type fakeTripRepo struct {
+	repository.TripRepository
+}
+
+func (fakeTripRepo) FindActiveByVehicle(context.Context, uuid.UUID) ([]*entity.Trip, error) {
+	return nil, nil
+}
+
+func (fakeTripRepo) UpdateStopProgress(context.Context, []*entity.TripStop) error {
+	return nil
+}
+
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+type discardPublisher struct{}
+
+func (discardPublisher) Publish(context.Context, ...service.RealtimeEvent) error { return nil }
+
+func newTestUseCase(geofences ...*entity.Geofence) (*GeofenceUseCase, *fakeGeofenceRepo, *memoryCache) {
+	repo := &fakeGeofenceRepo{geofences: geofences}
+	cacheRepo := &memoryCache{data: make(map[string]string)}
+	uc := NewGeofenceUseCase(repo, nil, fakeTripRepo{}, cacheRepo, geometry.NewGeometry(), discardPublisher{}, fakeTransactor{})
+	return uc, repo, cacheRepo
+}
+
+func TestObservePositionsEntersAndLeaves(t *testing.T) {
+	depot := &entity.Geofence{
+		ID:          uuid.New(),
+		LocationID:  uuid.New(),
+		Shape:       entity.GeofenceShapeCircle,
+		Center:      entity.Coordinate{Latitude: 13.7, Longitude: 100.5},
+		RadiusM:     200,
+		DwellTime:   5 * time.Minute,
+		ExitBufferM: 30,
+		Active:      true,
+	}
+	elsewhere := &entity.Geofence{
+		ID:         uuid.New(),
+		LocationID: uuid.New(),
+		Shape:      entity.GeofenceShapePolygon,
+		Polygon:    []entity.Coordinate{{Latitude: 18.7, Longitude: 98.9}, {Latitude: 18.8, Longitude: 98.9}, {Latitude: 18.8, Longitude: 99}},
+		Active:     true,
+	}
+	uc, repo, cacheRepo := newTestUseCase(depot, elsewhere)
+
+	vehicleID := uuid.New()
+	start := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	fix := func(minutes int, lat float64) entity.VehiclePosition {
+		return entity.VehiclePosition{VehicleID: vehicleID, RecordedAt: start.Add(time.Duration(minutes) * time.Minute), Latitude: lat, Longitude: 100.5}
+	}
+
+	ctx := context.Background()
+	if err := uc.ObservePositions(ctx, []entity.VehiclePosition{fix(0, 13.7), fix(1, 13.7001)}); err != nil {
+		t.Fatalf("ObservePositions: %v", err)
+	}
+	// 0.0021° is about 233 m from the centre: outside the circle but within the exit buffer
+	if err := uc.ObservePositions(ctx, []entity.VehiclePosition{fix(2, 13.7021), fix(3, 13.71)}); err != nil {
+		t.Fatalf("ObservePositions: %v", err)
+	}
+
+	var types []entity.GeofenceEventType
+	for _, e := range repo.events {
+		if e.GeofenceID != depot.ID {
+			t.Errorf("event %s at geofence %s, want the depot", e.Type, e.GeofenceID)
+		}
+		types = append(types, e.Type)
+	}
+	if len(types) != 2 || types[0] != entity.GeofenceEventEnter || types[1] != entity.GeofenceEventExit {
+		t.Errorf("events = %v, want enter, exit", types)
+	}
+	if repo.loads != 1 {
+		t.Errorf("active geofences loaded %d times, want once", repo.loads)
+	}
+	if ok, _ := cacheRepo.Exists(ctx, lockKeyPrefix+vehicleID.String()); ok {
+		t.Error("vehicle lock not released")
+	}
+}
+
+func TestActiveGeofencesReloadAfterChange(t *testing.T) {
+	uc, repo, _ := newTestUseCase()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := uc.activeGeofences(ctx); err != nil {
+			t.Fatalf("activeGeofences: %v", err)
+		}
+	}
+	if repo.loads != 1 {
+		t.Fatalf("loaded %d times, want once", repo.loads)
+	}
+
+	// another instance changed a geofence
+	other, _, _ := newTestUseCase()
+	other.cacheRepo = uc.cacheRepo
+	other.invalidate(ctx)
+
+	repo.geofences = []*entity.Geofence{{ID: uuid.New(), Shape: entity.GeofenceShapeCircle, Active: true}}
+	active, err := uc.activeGeofences(ctx)
+	if err != nil {
+		t.Fatalf("activeGeofences: %v", err)
+	}
+	if repo.loads != 2 || len(active.geofences) != 1 {
+		t.Errorf("loaded %d times with %d geofence(s), want a reload with the new one", repo.loads, len(active.geofences))
+	}
+}
@@ -0,0 +1,64 @@
+package geofence
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// GeofenceInput represents geofence input data.
+// A circle without a center is drawn around the location's pin.
+type GeofenceInput struct {
+	Name        string
+	Shape       entity.GeofenceShape
+	Center      *entity.Coordinate
+	RadiusM     float64
+	Polygon     []entity.Coordinate
+	DwellTime   *time.Duration // DefaultDwellTime when nil
+	ExitBufferM *float64       // DefaultExitBufferM when nil
+	Active      bool
+}
+
+// GeofenceOutput represents geofence output data
+type GeofenceOutput struct {
+	ID          uuid.UUID
+	LocationID  uuid.UUID
+	Name        string
+	Shape       entity.GeofenceShape
+	Center      *entity.Coordinate
+	RadiusM     float64
+	Polygon     []entity.Coordinate
+	DwellTime   time.Duration
+	ExitBufferM float64
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ListEventsInput represents geofence event list filters
+type ListEventsInput struct {
+	VehicleID  *uuid.UUID
+	GeofenceID *uuid.UUID
+	LocationID *uuid.UUID
+	TripID     *uuid.UUID
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// EventOutput represents geofence event output data
+type EventOutput struct {
+	ID         uuid.UUID
+	GeofenceID uuid.UUID
+	LocationID uuid.UUID
+	VehicleID  uuid.UUID
+	TripID     *uuid.UUID
+	StopID     *uuid.UUID
+	Type       entity.GeofenceEventType
+	OccurredAt time.Time
+	Latitude   float64
+	Longitude  float64
+}
@@ -0,0 +1,635 @@
+package incident
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxPhotos bounds the number of photos per incident
+	MaxPhotos = 10
+
+	// slaBatchSize is how many incidents an SLA check loads at a time
+	slaBatchSize = 100
+)
+
+// DefaultResolveWithin is how long incidents of each severity may stay open when not configured
+var DefaultResolveWithin = map[entity.IncidentSeverity]time.Duration{
+	entity.IncidentSeverityLow:      72 * time.Hour,
+	entity.IncidentSeverityMedium:   24 * time.Hour,
+	entity.IncidentSeverityHigh:     8 * time.Hour,
+	entity.IncidentSeverityCritical: 2 * time.Hour,
+}
+
+// IncidentUseCase handles the exceptions reported on trips and shipments, from report to resolution, under an SLA
+type IncidentUseCase struct {
+	incidentRepo     repository.IncidentRepository
+	tripRepo         repository.TripRepository
+	shipmentRepo     repository.ShipmentRepository
+	userRepo         repository.UserRepository
+	storageService   service.StorageService
+	numbering        service.NumberGenerator
+	transactor       repository.Transactor
+	notificationRepo repository.NotificationRepository
+	resolveWithin    map[entity.IncidentSeverity]time.Duration
+}
+
+// NewIncidentUseCase creates a new incident use case.
+// Incidents must be resolved within resolveWithin of occurring, by severity; severities not configured use
+// DefaultResolveWithin.
+func NewIncidentUseCase(
+	incidentRepo repository.IncidentRepository,
+	tripRepo repository.TripRepository,
+	shipmentRepo repository.ShipmentRepository,
+	userRepo repository.UserRepository,
+	storageService service.StorageService,
+	numbering service.NumberGenerator,
+	transactor repository.Transactor,
+	notificationRepo repository.NotificationRepository,
+	resolveWithin map[entity.IncidentSeverity]time.Duration,
+) *IncidentUseCase {
+	windows := make(map[entity.IncidentSeverity]time.Duration, len(DefaultResolveWithin))
+	for severity, d := range DefaultResolveWithin {
+		windows[severity] = d
+		if configured := resolveWithin[severity]; configured > 0 {
+			windows[severity] = configured
+		}
+	}
+	return &IncidentUseCase{
+		incidentRepo:     incidentRepo,
+		tripRepo:         tripRepo,
+		shipmentRepo:     shipmentRepo,
+		userRepo:         userRepo,
+		storageService:   storageService,
+		numbering:        numbering,
+		transactor:       transactor,
+		notificationRepo: notificationRepo,
+		resolveWithin:    windows,
+	}
+}
+
+// UploadURLs issues presigned URLs for the photos of an incident the user is about to report or document
+func (uc *IncidentUseCase) UploadURLs(ctx context.Context, userID uuid.UUID, contentTypes []string) ([]UploadURLOutput, error) {
+	if len(contentTypes) > MaxPhotos {
+		return nil, errs.ValidationErrors{"content_types": {"too_many"}}
+	}
+
+	outputs := make([]UploadURLOutput, len(contentTypes))
+	for i, contentType := range contentTypes {
+		url, key, err := service.ImageUploadURL(ctx, uc.storageService, photoPrefix(userID), contentType, "content_types")
+		if err != nil {
+			return nil, err
+		}
+		outputs[i] = UploadURLOutput{UploadURL: url, ObjectKey: key}
+	}
+	return outputs, nil
+}
+
+// Create reports an incident and notifies its owner, by default the dispatcher of the trip involved.
+// An incident left without an owner is put on the dispatch queue of the organizations whose shipments are
+// involved, for any of their dispatchers to take.
+func (uc *IncidentUseCase) Create(ctx context.Context, input IncidentInput) (*IncidentOutput, error) {
+	now := time.Now()
+	occurredAt := now
+	if input.OccurredAt != nil {
+		if entity.InFuture(*input.OccurredAt, now) {
+			return nil, errs.ValidationErrors{"occurred_at": {"in_future"}}
+		}
+		occurredAt = *input.OccurredAt
+	}
+	if len(input.PhotoKeys) > MaxPhotos {
+		return nil, errs.ValidationErrors{"photo_keys": {"too_many"}}
+	}
+
+	incident := &entity.Incident{
+		Category:    input.Category,
+		Severity:    input.Severity,
+		Status:      entity.IncidentStatusOpen,
+		TripID:      input.TripID,
+		StopID:      input.StopID,
+		ShipmentID:  input.ShipmentID,
+		Description: input.Description,
+		PhotoKeys:   input.PhotoKeys,
+		Latitude:    input.Latitude,
+		Longitude:   input.Longitude,
+		ReportedBy:  input.ReportedBy,
+		OccurredAt:  occurredAt,
+		OwnerID:     input.OwnerID,
+		DueAt:       occurredAt.Add(uc.resolveWithin[input.Severity]),
+	}
+	trip, err := uc.resolveSubject(ctx, incident)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkOwner(ctx, input.OwnerID); err != nil {
+		return nil, err
+	}
+	if incident.OwnerID == nil && trip != nil {
+		incident.OwnerID = trip.DispatchedBy
+	}
+	if err := uc.checkUploads(ctx, photoPrefix(input.ReportedBy), input.PhotoKeys); err != nil {
+		return nil, err
+	}
+
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		number, err := uc.numbering.Next(ctx, uuid.Nil, entity.DocumentIncident, now)
+		if err != nil {
+			return fmt.Errorf("number generator: next incident number: %w", err)
+		}
+		incident.Number = number
+		if err := uc.incidentRepo.Create(ctx, incident); err != nil {
+			return fmt.Errorf("incident repository: create incident: %w", err)
+		}
+		return uc.notify(ctx, "incident.reported", incident, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.toOutput(ctx, incident)
+}
+
+// Update changes the details or the owner of an open incident, notifying a new owner
+func (uc *IncidentUseCase) Update(ctx context.Context, id uuid.UUID, input UpdateIncidentInput) (*IncidentOutput, error) {
+	if err := uc.checkOwner(ctx, input.OwnerID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var incident *entity.Incident
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if incident, err = uc.findForUpdate(ctx, id); err != nil {
+			return err
+		}
+		if !incident.IsOpen() {
+			return errs.ErrResourceLocked
+		}
+
+		reassigned := input.OwnerID != nil && (incident.OwnerID == nil || *incident.OwnerID != *input.OwnerID)
+		incident.Category = input.Category
+		incident.Description = input.Description
+		incident.OwnerID = input.OwnerID
+		if input.Severity != incident.Severity {
+			incident.Severity = input.Severity
+			incident.DueAt = incident.OccurredAt.Add(uc.resolveWithin[input.Severity])
+			if !incident.Overdue(now) {
+				// A breach of the new due time is announced again
+				incident.BreachedAt = nil
+			}
+		}
+		if err := uc.incidentRepo.Update(ctx, incident); err != nil {
+			return fmt.Errorf("incident repository: update incident: %w", err)
+		}
+		if reassigned {
+			return uc.notify(ctx, "incident.assigned", incident, now)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.toOutput(ctx, incident)
+}
+
+// AddPhotos attaches photos uploaded by the user to an incident that is not closed or cancelled
+func (uc *IncidentUseCase) AddPhotos(ctx context.Context, id, userID uuid.UUID, photoKeys []string) (*IncidentOutput, error) {
+	if err := uc.checkUploads(ctx, photoPrefix(userID), photoKeys); err != nil {
+		return nil, err
+	}
+
+	var incident *entity.Incident
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if incident, err = uc.findForUpdate(ctx, id); err != nil {
+			return err
+		}
+		if incident.Status == entity.IncidentStatusClosed || incident.Status == entity.IncidentStatusCancelled {
+			return errs.ErrResourceLocked
+		}
+		for _, key := range photoKeys {
+			for _, existing := range incident.PhotoKeys {
+				if key == existing {
+					return errs.ValidationErrors{"photo_keys": {"invalid"}}
+				}
+			}
+		}
+		if len(incident.PhotoKeys)+len(photoKeys) > MaxPhotos {
+			return errs.ValidationErrors{"photo_keys": {"too_many"}}
+		}
+
+		incident.PhotoKeys = append(incident.PhotoKeys, photoKeys...)
+		if err := uc.incidentRepo.Update(ctx, incident); err != nil {
+			return fmt.Errorf("incident repository: update incident: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.toOutput(ctx, incident)
+}
+
+// Start marks an incident as being worked on, reopening it when its resolution was not accepted
+func (uc *IncidentUseCase) Start(ctx context.Context, id uuid.UUID) (*IncidentOutput, error) {
+	return uc.transition(ctx, id, "", func(i *entity.Incident) error {
+		return i.Start()
+	})
+}
+
+// Resolve records how an incident was resolved
+func (uc *IncidentUseCase) Resolve(ctx context.Context, id, userID uuid.UUID, resolution string) (*IncidentOutput, error) {
+	return uc.transition(ctx, id, "incident.resolved", func(i *entity.Incident) error {
+		return i.Resolve(resolution, userID, time.Now())
+	})
+}
+
+// Close accepts the resolution of an incident
+func (uc *IncidentUseCase) Close(ctx context.Context, id uuid.UUID) (*IncidentOutput, error) {
+	return uc.transition(ctx, id, "", func(i *entity.Incident) error {
+		return i.Close(time.Now())
+	})
+}
+
+// Cancel withdraws an incident reported in error
+func (uc *IncidentUseCase) Cancel(ctx context.Context, id uuid.UUID, reason string) (*IncidentOutput, error) {
+	return uc.transition(ctx, id, "", func(i *entity.Incident) error {
+		return i.Cancel(reason)
+	})
+}
+
+// Get returns an incident with download URLs for its photos
+func (uc *IncidentUseCase) Get(ctx context.Context, id uuid.UUID) (*IncidentOutput, error) {
+	incident, err := uc.incidentRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("incident repository: find by id: %w", err)
+	}
+	return uc.toOutput(ctx, incident)
+}
+
+// List returns the incidents matching the input criteria, most recent first
+func (uc *IncidentUseCase) List(ctx context.Context, input ListIncidentsInput) ([]*IncidentOutput, int64, error) {
+	filter := repository.IncidentFilter{
+		TripID:     input.TripID,
+		ShipmentID: input.ShipmentID,
+		OwnerID:    input.OwnerID,
+		Category:   input.Category,
+		Severity:   input.Severity,
+		Status:     input.Status,
+		Open:       input.Open,
+	}
+	if input.Overdue {
+		now := time.Now()
+		filter.OverdueAt = &now
+	}
+
+	incidents, total, err := uc.incidentRepo.List(ctx, filter, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("incident repository: list incidents: %w", err)
+	}
+
+	outputs := make([]*IncidentOutput, len(incidents))
+	for i, incident := range incidents {
+		if outputs[i], err = uc.toOutput(ctx, incident); err != nil {
+			return nil, 0, err
+		}
+	}
+	return outputs, total, nil
+}
+
+// CheckSLA announces each open incident found past its SLA, to its owner or dispatch queue and on the channels
+// of the trip and shipment involved, and returns how many breaches were announced. Each breach is announced once, so runs may
+// overlap or repeat. It is run periodically by a background worker.
+func (uc *IncidentUseCase) CheckSLA(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	// Announcing a breach takes the incident out of the listing, so the first batch is loaded until it runs short
+	sent := 0
+	for {
+		batch, err := uc.incidentRepo.ListUnbreached(ctx, now, slaBatchSize)
+		if err != nil {
+			return sent, fmt.Errorf("incident repository: list unbreached: %w", err)
+		}
+		for _, i := range batch {
+			breached := false
+			err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+				incident, err := uc.incidentRepo.FindByIDForUpdate(ctx, i.ID)
+				if err != nil {
+					if errors.Is(err, errs.ErrNotFound) {
+						return nil
+					}
+					return fmt.Errorf("incident repository: find by id for update: %w", err)
+				}
+				if !incident.Overdue(now) || incident.BreachedAt != nil {
+					return nil
+				}
+				incident.Breached(now)
+				if err := uc.incidentRepo.Update(ctx, incident); err != nil {
+					return fmt.Errorf("incident repository: update incident: %w", err)
+				}
+				if err := uc.notify(ctx, "incident.sla_breached", incident, now); err != nil {
+					return err
+				}
+				breached = true
+				return nil
+			})
+			if err != nil {
+				return sent, err
+			}
+			if breached {
+				sent++
+			}
+		}
+		if len(batch) < slaBatchSize {
+			return sent, nil
+		}
+	}
+}
+
+// transition applies a status change to an incident, announcing it as eventType unless empty
+func (uc *IncidentUseCase) transition(ctx context.Context, id uuid.UUID, eventType string, apply func(*entity.Incident) error) (*IncidentOutput, error) {
+	var incident *entity.Incident
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if incident, err = uc.findForUpdate(ctx, id); err != nil {
+			return err
+		}
+		if err := apply(incident); err != nil {
+			return err
+		}
+		if err := uc.incidentRepo.Update(ctx, incident); err != nil {
+			return fmt.Errorf("incident repository: update incident: %w", err)
+		}
+		if eventType != "" {
+			return uc.notify(ctx, eventType, incident, time.Now())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.toOutput(ctx, incident)
+}
+
+// notify records a notification about an incident in the outbox, to be delivered once the transaction commits.
+// An incident without an owner is announced on the dispatch queues of its organizations instead.
+func (uc *IncidentUseCase) notify(ctx context.Context, eventType string, incident *entity.Incident, now time.Time) error {
+	var queues []uuid.UUID
+	if incident.OwnerID == nil {
+		var err error
+		if queues, err = uc.organizations(ctx, incident); err != nil {
+			return err
+		}
+	}
+	notification := service.NewNotification(incidentEvent(eventType, incident, queues, now))
+	if err := uc.notificationRepo.Create(ctx, notification); err != nil {
+		return fmt.Errorf("notification repository: create notification: %w", err)
+	}
+	return nil
+}
+
+// organizations returns the organizations whose shipments are involved in an incident: the shipment's, or those
+// of the shipments on the trip
+func (uc *IncidentUseCase) organizations(ctx context.Context, incident *entity.Incident) ([]uuid.UUID, error) {
+	var shipmentIDs []uuid.UUID
+	switch {
+	case incident.ShipmentID != nil:
+		shipmentIDs = []uuid.UUID{*incident.ShipmentID}
+	case incident.TripID != nil:
+		trip, err := uc.tripRepo.FindByID(ctx, *incident.TripID)
+		if err != nil {
+			return nil, fmt.Errorf("trip repository: find by id: %w", err)
+		}
+		for _, stop := range trip.Stops {
+			if !slices.Contains(shipmentIDs, stop.ShipmentID) {
+				shipmentIDs = append(shipmentIDs, stop.ShipmentID)
+			}
+		}
+	}
+	if len(shipmentIDs) == 0 {
+		return nil, nil
+	}
+
+	shipments, err := uc.shipmentRepo.FindByIDs(ctx, shipmentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("shipment repository: find by ids: %w", err)
+	}
+	var organizations []uuid.UUID
+	for _, shipment := range shipments {
+		if !slices.Contains(organizations, shipment.OrganizationID) {
+			organizations = append(organizations, shipment.OrganizationID)
+		}
+	}
+	return organizations, nil
+}
+
+// resolveSubject confirms the trip, stop and shipment the incident is reported against and fills in what they
+// imply: the shipment and location of a stop. It returns the trip whose dispatcher is responsible for the incident,
+// the latest trip carrying the shipment when none was given, or nil.
+func (uc *IncidentUseCase) resolveSubject(ctx context.Context, incident *entity.Incident) (*entity.Trip, error) {
+	if incident.TripID == nil && incident.ShipmentID == nil {
+		if incident.StopID != nil {
+			return nil, errs.ValidationErrors{"trip_id": {"required"}}
+		}
+		return nil, errs.ValidationErrors{"trip_id": {"required_without_shipment"}}
+	}
+
+	var trip *entity.Trip
+	if incident.TripID != nil {
+		var err error
+		if trip, err = uc.tripRepo.FindByID(ctx, *incident.TripID); err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return nil, errs.ValidationErrors{"trip_id": {"not_found"}}
+			}
+			return nil, fmt.Errorf("trip repository: find by id: %w", err)
+		}
+
+		if incident.StopID != nil {
+			stop, ok := trip.Stop(*incident.StopID)
+			if !ok {
+				return nil, errs.ValidationErrors{"stop_id": {"not_found"}}
+			}
+			if incident.ShipmentID != nil && *incident.ShipmentID != stop.ShipmentID {
+				return nil, errs.ValidationErrors{"shipment_id": {"not_on_stop"}}
+			}
+			incident.ShipmentID = &stop.ShipmentID
+			incident.LocationID = &stop.LocationID
+		} else if incident.ShipmentID != nil && !carries(trip, *incident.ShipmentID) {
+			return nil, errs.ValidationErrors{"shipment_id": {"not_on_trip"}}
+		}
+	} else if incident.StopID != nil {
+		return nil, errs.ValidationErrors{"trip_id": {"required"}}
+	}
+
+	if incident.ShipmentID == nil {
+		return trip, nil
+	}
+	if _, err := uc.shipmentRepo.FindByID(ctx, *incident.ShipmentID); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ValidationErrors{"shipment_id": {"not_found"}}
+		}
+		return nil, fmt.Errorf("shipment repository: find by id: %w", err)
+	}
+	if trip != nil {
+		return trip, nil
+	}
+
+	trip, err := uc.tripRepo.FindByShipment(ctx, *incident.ShipmentID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("trip repository: find by shipment: %w", err)
+	}
+	return trip, nil
+}
+
+// checkOwner confirms the owner, when given, is a user
+func (uc *IncidentUseCase) checkOwner(ctx context.Context, ownerID *uuid.UUID) error {
+	if ownerID == nil {
+		return nil
+	}
+	if _, err := uc.userRepo.FindByID(ctx, *ownerID); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ValidationErrors{"owner_id": {"not_found"}}
+		}
+		return fmt.Errorf("user repository: find by id: %w", err)
+	}
+	return nil
+}
+
+// checkUploads confirms that the keys were issued to the user and that the photos have been uploaded
+func (uc *IncidentUseCase) checkUploads(ctx context.Context, prefix string, photoKeys []string) error {
+	seen := make(map[string]bool, len(photoKeys))
+	for _, key := range photoKeys {
+		if !strings.HasPrefix(key, prefix) || seen[key] {
+			return errs.ValidationErrors{"photo_keys": {"invalid"}}
+		}
+		seen[key] = true
+	}
+
+	for _, key := range photoKeys {
+		ok, err := uc.storageService.ObjectExists(ctx, key)
+		if err != nil {
+			return fmt.Errorf("storage service: object exists: %w", err)
+		}
+		if !ok {
+			return errs.ValidationErrors{"photo_keys": {"not_uploaded"}}
+		}
+	}
+	return nil
+}
+
+func (uc *IncidentUseCase) findForUpdate(ctx context.Context, id uuid.UUID) (*entity.Incident, error) {
+	incident, err := uc.incidentRepo.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("incident repository: find by id for update: %w", err)
+	}
+	return incident, nil
+}
+
+func (uc *IncidentUseCase) toOutput(ctx context.Context, i *entity.Incident) (*IncidentOutput, error) {
+	output := &IncidentOutput{
+		ID:           i.ID,
+		Number:       i.Number,
+		Category:     i.Category,
+		Severity:     i.Severity,
+		Status:       i.Status,
+		TripID:       i.TripID,
+		StopID:       i.StopID,
+		ShipmentID:   i.ShipmentID,
+		Description:  i.Description,
+		PhotoKeys:    i.PhotoKeys,
+		PhotoURLs:    make([]string, len(i.PhotoKeys)),
+		Latitude:     i.Latitude,
+		Longitude:    i.Longitude,
+		LocationID:   i.LocationID,
+		ReportedBy:   i.ReportedBy,
+		OccurredAt:   i.OccurredAt,
+		OwnerID:      i.OwnerID,
+		DueAt:        i.DueAt,
+		Overdue:      i.Overdue(time.Now()),
+		BreachedAt:   i.BreachedAt,
+		Resolution:   i.Resolution,
+		ResolvedBy:   i.ResolvedBy,
+		ResolvedAt:   i.ResolvedAt,
+		ClosedAt:     i.ClosedAt,
+		CancelReason: i.CancelReason,
+		CreatedAt:    i.CreatedAt,
+		UpdatedAt:    i.UpdatedAt,
+	}
+	for n, key := range i.PhotoKeys {
+		var err error
+		if output.PhotoURLs[n], err = uc.storageService.GenerateDownloadURL(ctx, key); err != nil {
+			return nil, fmt.Errorf("storage service: generate download url: %w", err)
+		}
+	}
+	return output, nil
+}
+
+// incidentEvent builds a notification about an incident for its owner, or the dispatch queues of the given
+// organizations, and the trip and shipment involved
+func incidentEvent(eventType string, i *entity.Incident, queues []uuid.UUID, now time.Time) service.RealtimeEvent {
+	var channels []string
+	if i.OwnerID != nil {
+		channels = append(channels, service.Channel(service.ChannelUser, *i.OwnerID))
+	}
+	for _, organizationID := range queues {
+		channels = append(channels, service.Channel(service.ChannelDispatch, organizationID))
+	}
+	if i.TripID != nil {
+		channels = append(channels, service.Channel(service.ChannelTrip, *i.TripID))
+	}
+	if i.ShipmentID != nil {
+		channels = append(channels, service.Channel(service.ChannelShipment, *i.ShipmentID))
+	}
+	return service.RealtimeEvent{
+		Channels: channels,
+		Type:     eventType,
+		Data: map[string]interface{}{
+			"incident_id": i.ID,
+			"number":      i.Number,
+			"category":    i.Category,
+			"severity":    i.Severity,
+			"status":      i.Status,
+			"trip_id":     i.TripID,
+			"stop_id":     i.StopID,
+			"shipment_id": i.ShipmentID,
+			"owner_id":    i.OwnerID,
+			"occurred_at": i.OccurredAt,
+			"due_at":      i.DueAt,
+		},
+		OccurredAt: now,
+	}
+}
+
+// carries reports whether one of the trip's stops is for the shipment
+func carries(trip *entity.Trip, shipmentID uuid.UUID) bool {
+	for _, stop := range trip.Stops {
+		if stop.ShipmentID == shipmentID {
+			return true
+		}
+	}
+	return false
+}
+
+// photoPrefix is the storage folder of the incident photos uploaded by a user
+func photoPrefix(userID uuid.UUID) string {
+	return fmt.Sprintf("incidents/%s/photo-", userID)
+}
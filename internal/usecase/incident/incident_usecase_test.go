@@ -0,0 +1,129 @@
+package incident
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+type transactor struct{}
+
+func (transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type incidentRepo struct{ repository.IncidentRepository }
+
+func (incidentRepo) Create(_ context.Context, incident *entity.Incident) error {
+	incident.ID = uuid.New()
+	return nil
+}
+
+type tripRepo struct {
+	repository.TripRepository
+	trip *entity.Trip
+}
+
+func (r tripRepo) FindByID(context.Context, uuid.UUID) (*entity.Trip, error) {
+	return r.trip, nil
+}
+
+type shipmentRepo struct {
+	repository.ShipmentRepository
+	shipments []*entity.Shipment
+}
+
+func (r shipmentRepo) FindByIDs(_ context.Context, ids []uuid.UUID) ([]*entity.Shipment, error) {
+	var found []*entity.Shipment
+	for _, s := range r.shipments {
+		if slices.Contains(ids, s.ID) {
+			found = append(found, s)
+		}
+	}
+	return found, nil
+}
+
+type numbering struct{ service.NumberGenerator }
+
+func (numbering) Next(context.Context, uuid.UUID, entity.DocumentType, time.Time) (string, error) {
+	return "INC-2610-00001", nil
+}
+
+type notificationRepo struct {
+	repository.NotificationRepository
+	outbox []*entity.Notification
+}
+
+func (r *notificationRepo) Create(_ context.Context, notifications ...*entity.Notification) error {
+	r.outbox = append(r.outbox, notifications...)
+	return nil
+}
+
+func TestCreateNotifiesTheOwnerOrTheDispatchQueue(t *testing.T) {
+	north, south := uuid.New(), uuid.New()
+	shipments := []*entity.Shipment{
+		{ID: uuid.New(), OrganizationID: north},
+		{ID: uuid.New(), OrganizationID: south},
+		{ID: uuid.New(), OrganizationID: north},
+	}
+	trip := &entity.Trip{ID: uuid.New(), Status: entity.TripStatusInProgress}
+	for _, s := range shipments {
+		trip.Stops = append(trip.Stops, entity.TripStop{ID: uuid.New(), TripID: trip.ID, ShipmentID: s.ID})
+	}
+
+	for _, tc := range []struct {
+		name       string
+		dispatcher *uuid.UUID
+		want       []string
+	}{
+		{"dispatched", &north, []string{service.Channel(service.ChannelUser, north)}},
+		{"unowned", nil, []string{
+			service.Channel(service.ChannelDispatch, north),
+			service.Channel(service.ChannelDispatch, south),
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			trip.DispatchedBy = tc.dispatcher
+			outbox := &notificationRepo{}
+			uc := NewIncidentUseCase(incidentRepo{}, tripRepo{trip: trip}, shipmentRepo{shipments: shipments}, nil, nil,
+				numbering{}, transactor{}, outbox, nil)
+
+			output, err := uc.Create(context.Background(), IncidentInput{
+				Category:   entity.IncidentBreakdown,
+				Severity:   entity.IncidentSeverityHigh,
+				TripID:     &trip.ID,
+				ReportedBy: uuid.New(),
+			})
+			if err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			if (output.OwnerID == nil) != (tc.dispatcher == nil) {
+				t.Errorf("owner = %v, want the trip's dispatcher %v", output.OwnerID, tc.dispatcher)
+			}
+			if len(outbox.outbox) != 1 {
+				t.Fatalf("%d notification(s) in the outbox, want 1", len(outbox.outbox))
+			}
+			n := outbox.outbox[0]
+			if n.Type != "incident.reported" {
+				t.Errorf("type = %s, want incident.reported", n.Type)
+			}
+			for _, channel := range tc.want {
+				if !slices.Contains(n.Channels, channel) {
+					t.Errorf("channels = %v, missing %s", n.Channels, channel)
+				}
+			}
+			if queued := slices.ContainsFunc(n.Channels, func(c string) bool {
+				return c == service.Channel(service.ChannelDispatch, north)
+			}); queued != (tc.dispatcher == nil) {
+				t.Errorf("channels = %v; want the dispatch queue only when unowned", n.Channels)
+			}
+		})
+	}
+}
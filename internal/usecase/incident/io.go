@@ -0,0 +1,88 @@
+package incident
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// UploadURLOutput represents a presigned upload URL and the key the photo will be stored under
+type UploadURLOutput struct {
+	UploadURL string
+	ObjectKey string
+}
+
+// IncidentInput represents an incident to report against a trip, one of its stops or a shipment.
+// The photo keys are those returned by UploadURLs to the reporter, after the files have been uploaded.
+// The owner defaults to the dispatcher of the trip; the time of occurrence defaults to now.
+type IncidentInput struct {
+	Category    entity.IncidentCategory
+	Severity    entity.IncidentSeverity
+	TripID      *uuid.UUID
+	StopID      *uuid.UUID
+	ShipmentID  *uuid.UUID
+	Description string
+	PhotoKeys   []string
+	Latitude    *float64
+	Longitude   *float64
+	OccurredAt  *time.Time
+	OwnerID     *uuid.UUID
+	ReportedBy  uuid.UUID
+}
+
+// UpdateIncidentInput represents changes to an open incident; what it is linked to cannot change.
+// Changing the severity moves the SLA due time.
+type UpdateIncidentInput struct {
+	Category    entity.IncidentCategory
+	Severity    entity.IncidentSeverity
+	Description string
+	OwnerID     *uuid.UUID
+}
+
+// ListIncidentsInput represents criteria for listing incidents.
+// Open keeps the incidents awaiting a resolution; Overdue keeps those open past their SLA.
+type ListIncidentsInput struct {
+	TripID     *uuid.UUID
+	ShipmentID *uuid.UUID
+	OwnerID    *uuid.UUID
+	Category   *entity.IncidentCategory
+	Severity   *entity.IncidentSeverity
+	Status     *entity.IncidentStatus
+	Open       bool
+	Overdue    bool
+	Limit      int
+	Offset     int
+}
+
+// IncidentOutput represents incident output data with download URLs for its photos
+type IncidentOutput struct {
+	ID           uuid.UUID
+	Number       string
+	Category     entity.IncidentCategory
+	Severity     entity.IncidentSeverity
+	Status       entity.IncidentStatus
+	TripID       *uuid.UUID
+	StopID       *uuid.UUID
+	ShipmentID   *uuid.UUID
+	Description  string
+	PhotoKeys    []string
+	PhotoURLs    []string
+	Latitude     *float64
+	Longitude    *float64
+	LocationID   *uuid.UUID
+	ReportedBy   uuid.UUID
+	OccurredAt   time.Time
+	OwnerID      *uuid.UUID
+	DueAt        time.Time
+	Overdue      bool
+	BreachedAt   *time.Time
+	Resolution   string
+	ResolvedBy   *uuid.UUID
+	ResolvedAt   *time.Time
+	ClosedAt     *time.Time
+	CancelReason string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
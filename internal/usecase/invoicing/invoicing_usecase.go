@@ -0,0 +1,524 @@
+package invoicing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultVATRate is the Thai VAT rate charged on transport services
+	DefaultVATRate = 0.07
+
+	// DefaultWithholdingRate is the withholding tax a juristic customer deducts from transport charges
+	DefaultWithholdingRate = 0.01
+
+	// DefaultCreditTermDays applies to customers without their own credit term
+	DefaultCreditTermDays = 30
+)
+
+// ShipmentPricer prices a delivered shipment from its customer's rate card
+type ShipmentPricer interface {
+	Charge(ctx context.Context, shipment *entity.Shipment, vehicleType entity.VehicleType) (*entity.Quote, error)
+}
+
+// InvoiceUseCase handles billing customers for delivered shipments and correcting invoices with credit notes
+type InvoiceUseCase struct {
+	invoiceRepo     repository.InvoiceRepository
+	orgRepo         repository.OrganizationRepository
+	tripRepo        repository.TripRepository
+	vehicleRepo     repository.VehicleRepository
+	locationRepo    repository.LocationRepository
+	pricer          ShipmentPricer
+	numbering       service.NumberGenerator
+	transactor      repository.Transactor
+	vatRate         float64
+	withholdingRate float64
+	creditTermDays  int
+}
+
+// NewInvoiceUseCase creates a new invoice use case. Zero rates and terms fall back to the defaults.
+func NewInvoiceUseCase(
+	invoiceRepo repository.InvoiceRepository,
+	orgRepo repository.OrganizationRepository,
+	tripRepo repository.TripRepository,
+	vehicleRepo repository.VehicleRepository,
+	locationRepo repository.LocationRepository,
+	pricer ShipmentPricer,
+	numbering service.NumberGenerator,
+	transactor repository.Transactor,
+	vatRate, withholdingRate float64,
+	creditTermDays int,
+) *InvoiceUseCase {
+	if vatRate <= 0 {
+		vatRate = DefaultVATRate
+	}
+	if withholdingRate <= 0 {
+		withholdingRate = DefaultWithholdingRate
+	}
+	if creditTermDays <= 0 {
+		creditTermDays = DefaultCreditTermDays
+	}
+	return &InvoiceUseCase{
+		invoiceRepo:     invoiceRepo,
+		orgRepo:         orgRepo,
+		tripRepo:        tripRepo,
+		vehicleRepo:     vehicleRepo,
+		locationRepo:    locationRepo,
+		pricer:          pricer,
+		numbering:       numbering,
+		transactor:      transactor,
+		vatRate:         vatRate,
+		withholdingRate: withholdingRate,
+		creditTermDays:  creditTermDays,
+	}
+}
+
+// CreateDraft bills every shipment of the organization delivered in the period that is not on a live
+// invoice yet. Each shipment is priced from the customer's rate card with the vehicle type that carried it.
+func (uc *InvoiceUseCase) CreateDraft(ctx context.Context, input CreateInvoiceInput) (*InvoiceOutput, error) {
+	periodFrom := timeutil.CalendarDay(input.PeriodFrom)
+	periodTo := timeutil.CalendarDay(input.PeriodTo)
+	if periodTo.Before(periodFrom) {
+		return nil, errs.ValidationErrors{"period_to": {"before_period_from"}}
+	}
+	from := timeutil.StartOfDay(periodFrom)
+	to := timeutil.StartOfDay(periodTo.AddDate(0, 0, 1))
+
+	var invoice *entity.Invoice
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		// The organization row serializes drafts so no shipment lands on two invoices
+		org, err := uc.orgRepo.FindByIDForUpdate(ctx, input.OrganizationID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return errs.ErrNotFound
+			}
+			return fmt.Errorf("organization repository: find by id for update: %w", err)
+		}
+
+		billable, err := uc.invoiceRepo.ListBillableShipments(ctx, org.ID, from, to)
+		if err != nil {
+			return fmt.Errorf("invoice repository: list billable shipments: %w", err)
+		}
+		if len(billable) == 0 {
+			return errs.ValidationErrors{"period": {"no_billable_shipments"}}
+		}
+
+		invoice = &entity.Invoice{
+			Kind:           entity.InvoiceKindInvoice,
+			Status:         entity.InvoiceStatusDraft,
+			OrganizationID: org.ID,
+			PeriodFrom:     periodFrom,
+			PeriodTo:       periodTo,
+			CreditTermDays: uc.creditTermDays,
+			VATRate:        uc.vatRate,
+			Notes:          input.Notes,
+		}
+		if org.CreditTermDays != nil {
+			invoice.CreditTermDays = *org.CreditTermDays
+		}
+		if org.WithholdsTax {
+			invoice.WithholdingRate = uc.withholdingRate
+		}
+
+		for _, b := range billable {
+			if err := uc.addShipment(ctx, invoice, b); err != nil {
+				return err
+			}
+		}
+
+		if err := uc.invoiceRepo.Create(ctx, invoice); err != nil {
+			return fmt.Errorf("invoice repository: create invoice: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toInvoiceOutput(invoice, time.Now()), nil
+}
+
+// CreateCreditNote drafts a credit note against an issued or paid invoice. The credit notes of an
+// invoice may not credit more than its subtotal in total.
+func (uc *InvoiceUseCase) CreateCreditNote(ctx context.Context, invoiceID uuid.UUID, input CreditNoteInput) (*InvoiceOutput, error) {
+	if strings.TrimSpace(input.Reason) == "" {
+		return nil, errs.ValidationErrors{"reason": {"required"}}
+	}
+
+	var note *entity.Invoice
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		original, err := uc.lockInvoice(ctx, invoiceID)
+		if err != nil {
+			return err
+		}
+		if !original.IsCreditable() {
+			return errs.ErrInvalidStatusTransition
+		}
+
+		note = &entity.Invoice{
+			Kind:              entity.InvoiceKindCreditNote,
+			Status:            entity.InvoiceStatusDraft,
+			OrganizationID:    original.OrganizationID,
+			CreditedInvoiceID: &original.ID,
+			PeriodFrom:        original.PeriodFrom,
+			PeriodTo:          original.PeriodTo,
+			Currency:          original.Currency,
+			VATRate:           original.VATRate,
+			WithholdingRate:   original.WithholdingRate,
+			Reason:            input.Reason,
+			Notes:             input.Notes,
+		}
+		if err := addCreditLines(note, original, input.Lines); err != nil {
+			return err
+		}
+		if err := uc.checkCreditBalance(ctx, original, note); err != nil {
+			return err
+		}
+
+		if err := uc.invoiceRepo.Create(ctx, note); err != nil {
+			return fmt.Errorf("invoice repository: create credit note: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toInvoiceOutput(note, time.Now()), nil
+}
+
+// Issue numbers a draft invoice or credit note in its organization's sequence and starts its credit term.
+// Numbers are taken inside the transaction so the sequence has no gaps.
+func (uc *InvoiceUseCase) Issue(ctx context.Context, id uuid.UUID) (*InvoiceOutput, error) {
+	return uc.change(ctx, id, func(ctx context.Context, inv *entity.Invoice, now time.Time) error {
+		if inv.Status != entity.InvoiceStatusDraft {
+			return errs.ErrInvalidStatusTransition
+		}
+
+		docType := entity.DocumentInvoice
+		if inv.IsCreditNote() {
+			docType = entity.DocumentCreditNote
+			original, err := uc.lockInvoice(ctx, *inv.CreditedInvoiceID)
+			if err != nil {
+				return err
+			}
+			// The invoice may have been voided or credited further since the draft was made
+			if !original.IsCreditable() {
+				return errs.ErrInvalidStatusTransition
+			}
+			if err := uc.checkCreditBalance(ctx, original, inv); err != nil {
+				return err
+			}
+		}
+
+		number, err := uc.numbering.Next(ctx, inv.OrganizationID, docType, now)
+		if err != nil {
+			return fmt.Errorf("number generator: next: %w", err)
+		}
+		return inv.Issue(number, timeutil.CalendarDay(now))
+	})
+}
+
+// MarkPaid records the customer's payment of an issued invoice
+func (uc *InvoiceUseCase) MarkPaid(ctx context.Context, id uuid.UUID, input PaymentInput) (*InvoiceOutput, error) {
+	return uc.change(ctx, id, func(_ context.Context, inv *entity.Invoice, now time.Time) error {
+		paidAt := input.PaidAt
+		if paidAt.IsZero() {
+			paidAt = now
+		}
+		if paidAt.After(now) {
+			return errs.ValidationErrors{"paid_at": {"in_future"}}
+		}
+		return inv.MarkPaid(paidAt, input.Reference)
+	})
+}
+
+// Void cancels a draft or an unpaid invoice so its shipments can be billed again.
+// An invoice with live credit notes must have them voided first.
+func (uc *InvoiceUseCase) Void(ctx context.Context, id uuid.UUID, reason string) (*InvoiceOutput, error) {
+	return uc.change(ctx, id, func(ctx context.Context, inv *entity.Invoice, now time.Time) error {
+		if !inv.IsCreditNote() {
+			notes, err := uc.invoiceRepo.ListCreditNotes(ctx, inv.ID)
+			if err != nil {
+				return fmt.Errorf("invoice repository: list credit notes: %w", err)
+			}
+			if len(notes) > 0 {
+				return errs.ErrInvalidStatusTransition
+			}
+		}
+		return inv.Void(reason, now)
+	})
+}
+
+// Get returns an invoice or credit note with its lines by ID
+func (uc *InvoiceUseCase) Get(ctx context.Context, id uuid.UUID) (*InvoiceOutput, error) {
+	inv, err := uc.invoiceRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("invoice repository: find by id: %w", err)
+	}
+	return toInvoiceOutput(inv, time.Now()), nil
+}
+
+// List returns invoices and credit notes matching the input criteria
+func (uc *InvoiceUseCase) List(ctx context.Context, input ListInvoicesInput) ([]*InvoiceOutput, int64, error) {
+	invoices, total, err := uc.invoiceRepo.List(ctx, repository.InvoiceFilter{
+		OrganizationID: input.OrganizationID,
+		Kind:           input.Kind,
+		Status:         input.Status,
+		Search:         input.Search,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invoice repository: list invoices: %w", err)
+	}
+
+	now := time.Now()
+	outputs := make([]*InvoiceOutput, len(invoices))
+	for i, inv := range invoices {
+		outputs[i] = toInvoiceOutput(inv, now)
+	}
+	return outputs, total, nil
+}
+
+// change loads and locks an invoice, applies fn and saves the result in one transaction
+func (uc *InvoiceUseCase) change(ctx context.Context, id uuid.UUID, fn func(context.Context, *entity.Invoice, time.Time) error) (*InvoiceOutput, error) {
+	var inv *entity.Invoice
+
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		inv, err = uc.lockInvoice(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(ctx, inv, time.Now()); err != nil {
+			return err
+		}
+		if err := uc.invoiceRepo.Update(ctx, inv); err != nil {
+			return fmt.Errorf("invoice repository: update invoice: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toInvoiceOutput(inv, time.Now()), nil
+}
+
+// addShipment prices a delivered shipment and bills it as one line
+func (uc *InvoiceUseCase) addShipment(ctx context.Context, inv *entity.Invoice, billable *entity.BillableShipment) error {
+	shipment := billable.Shipment
+
+	vehicleType, err := uc.vehicleType(ctx, shipment.ID)
+	if err != nil {
+		return err
+	}
+	quote, err := uc.pricer.Charge(ctx, shipment, vehicleType)
+	if err != nil {
+		return fmt.Errorf("price shipment %s: %w", shipment.TrackingNumber, err)
+	}
+	if inv.Currency == "" {
+		inv.Currency = quote.Currency
+	} else if quote.Currency != inv.Currency {
+		return fmt.Errorf("price shipment %s: currency %s differs from invoice currency %s",
+			shipment.TrackingNumber, quote.Currency, inv.Currency)
+	}
+
+	description, err := uc.describe(ctx, shipment)
+	if err != nil {
+		return err
+	}
+
+	deliveredAt := billable.DeliveredAt
+	inv.AddLine(entity.InvoiceLine{
+		ShipmentID:  &shipment.ID,
+		ServiceDate: &deliveredAt,
+		Description: description,
+		Quantity:    1,
+		UnitPrice:   quote.Total,
+	})
+	return nil
+}
+
+// vehicleType returns the type of the vehicle that carried the shipment; it is empty when the
+// shipment was not delivered on a trip, and the rate card's default rates apply
+func (uc *InvoiceUseCase) vehicleType(ctx context.Context, shipmentID uuid.UUID) (entity.VehicleType, error) {
+	trip, err := uc.tripRepo.FindByShipment(ctx, shipmentID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("trip repository: find by shipment: %w", err)
+	}
+	vehicle, err := uc.vehicleRepo.FindByID(ctx, trip.VehicleID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("vehicle repository: find by id: %w", err)
+	}
+	return vehicle.Type, nil
+}
+
+// describe names the transport of a shipment, e.g. "Transport TH2603000042X Bangkok → Chiang Mai (PO-1182)"
+func (uc *InvoiceUseCase) describe(ctx context.Context, shipment *entity.Shipment) (string, error) {
+	locations, err := uc.locationRepo.FindByIDs(ctx, []uuid.UUID{shipment.PickupLocationID, shipment.DeliveryLocationID})
+	if err != nil {
+		return "", fmt.Errorf("location repository: find by ids: %w", err)
+	}
+	provinces := make(map[uuid.UUID]string, len(locations))
+	for _, l := range locations {
+		provinces[l.ID] = l.Province
+	}
+
+	description := "Transport " + shipment.TrackingNumber
+	origin, destination := provinces[shipment.PickupLocationID], provinces[shipment.DeliveryLocationID]
+	if origin != "" && destination != "" {
+		description += " " + origin + " → " + destination
+	}
+	if shipment.Reference != "" {
+		description += " (" + shipment.Reference + ")"
+	}
+	return description, nil
+}
+
+// checkCreditBalance verifies the note and the other live credit notes of the invoice do not credit more than its subtotal
+func (uc *InvoiceUseCase) checkCreditBalance(ctx context.Context, original, note *entity.Invoice) error {
+	notes, err := uc.invoiceRepo.ListCreditNotes(ctx, original.ID)
+	if err != nil {
+		return fmt.Errorf("invoice repository: list credit notes: %w", err)
+	}
+
+	credited := note.Subtotal
+	for _, n := range notes {
+		if n.ID != note.ID {
+			credited += n.Subtotal
+		}
+	}
+	if credited > original.Subtotal {
+		return errs.ValidationErrors{"lines": {"exceeds_invoice_balance"}}
+	}
+	return nil
+}
+
+func (uc *InvoiceUseCase) lockInvoice(ctx context.Context, id uuid.UUID) (*entity.Invoice, error) {
+	inv, err := uc.invoiceRepo.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("invoice repository: find by id for update: %w", err)
+	}
+	return inv, nil
+}
+
+// addCreditLines credits the given lines, or every line of the original when none are given
+func addCreditLines(note, original *entity.Invoice, lines []CreditNoteLineInput) error {
+	if len(lines) == 0 {
+		for _, l := range original.Lines {
+			note.AddLine(entity.InvoiceLine{
+				ShipmentID:  l.ShipmentID,
+				ServiceDate: l.ServiceDate,
+				Description: l.Description,
+				Quantity:    l.Quantity,
+				UnitPrice:   l.UnitPrice,
+			})
+		}
+		return nil
+	}
+
+	billed := make(map[uuid.UUID]*entity.InvoiceLine, len(original.Lines))
+	for i := range original.Lines {
+		if id := original.Lines[i].ShipmentID; id != nil {
+			billed[*id] = &original.Lines[i]
+		}
+	}
+
+	for i, l := range lines {
+		if l.Amount <= 0 {
+			return errs.ValidationErrors{fmt.Sprintf("lines[%d].amount", i): {"must_be_positive"}}
+		}
+		line := entity.InvoiceLine{
+			ShipmentID:  l.ShipmentID,
+			Description: l.Description,
+			Quantity:    1,
+			UnitPrice:   l.Amount,
+		}
+		if l.ShipmentID != nil {
+			b, ok := billed[*l.ShipmentID]
+			if !ok {
+				return errs.ValidationErrors{fmt.Sprintf("lines[%d].shipment_id", i): {"not_on_invoice"}}
+			}
+			line.ServiceDate = b.ServiceDate
+			if line.Description == "" {
+				line.Description = b.Description
+			}
+		}
+		if strings.TrimSpace(line.Description) == "" {
+			return errs.ValidationErrors{fmt.Sprintf("lines[%d].description", i): {"required"}}
+		}
+		note.AddLine(line)
+	}
+	return nil
+}
+
+func toInvoiceOutput(inv *entity.Invoice, now time.Time) *InvoiceOutput {
+	lines := make([]InvoiceLineOutput, len(inv.Lines))
+	for i, l := range inv.Lines {
+		lines[i] = InvoiceLineOutput{
+			Sequence:    l.Sequence,
+			ShipmentID:  l.ShipmentID,
+			ServiceDate: l.ServiceDate,
+			Description: l.Description,
+			Quantity:    l.Quantity,
+			UnitPrice:   l.UnitPrice,
+			Amount:      l.Amount,
+		}
+	}
+
+	return &InvoiceOutput{
+		ID:                inv.ID,
+		Number:            inv.Number,
+		Kind:              inv.Kind,
+		Status:            inv.Status,
+		OrganizationID:    inv.OrganizationID,
+		CreditedInvoiceID: inv.CreditedInvoiceID,
+		PeriodFrom:        inv.PeriodFrom,
+		PeriodTo:          inv.PeriodTo,
+		Currency:          inv.Currency,
+		CreditTermDays:    inv.CreditTermDays,
+		IssueDate:         inv.IssueDate,
+		DueDate:           inv.DueDate,
+		Overdue:           inv.IsOverdue(now),
+		Lines:             lines,
+		Subtotal:          inv.Subtotal,
+		VATRate:           inv.VATRate,
+		VATAmount:         inv.VATAmount,
+		Total:             inv.Total,
+		WithholdingRate:   inv.WithholdingRate,
+		WithholdingTax:    inv.WithholdingTax,
+		AmountDue:         inv.AmountDue,
+		Reason:            inv.Reason,
+		Notes:             inv.Notes,
+		PaidAt:            inv.PaidAt,
+		PaymentReference:  inv.PaymentReference,
+		VoidedAt:          inv.VoidedAt,
+		VoidReason:        inv.VoidReason,
+		CreatedAt:         inv.CreatedAt,
+		UpdatedAt:         inv.UpdatedAt,
+	}
+}
@@ -0,0 +1,91 @@
+package invoicing
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// CreateInvoiceInput represents a request to bill a customer's delivered shipments of a billing period
+type CreateInvoiceInput struct {
+	OrganizationID uuid.UUID
+	PeriodFrom     time.Time // first day, inclusive
+	PeriodTo       time.Time // last day, inclusive
+	Notes          string
+}
+
+// CreditNoteLineInput represents one amount credited on a credit note
+type CreditNoteLineInput struct {
+	Description string
+	ShipmentID  *uuid.UUID   // must be billed on the credited invoice
+	Amount      entity.Money // before VAT
+}
+
+// CreditNoteInput represents a correction of an issued invoice. Without lines, every line is credited in full.
+type CreditNoteInput struct {
+	Reason string
+	Lines  []CreditNoteLineInput
+	Notes  string
+}
+
+// PaymentInput represents a customer's payment of an invoice
+type PaymentInput struct {
+	PaidAt    time.Time
+	Reference string
+}
+
+// ListInvoicesInput represents criteria for listing invoices and credit notes
+type ListInvoicesInput struct {
+	OrganizationID *uuid.UUID
+	Kind           *entity.InvoiceKind
+	Status         *entity.InvoiceStatus
+	Search         string
+	Limit          int
+	Offset         int
+}
+
+// InvoiceLineOutput represents invoice line output data
+type InvoiceLineOutput struct {
+	Sequence    int
+	ShipmentID  *uuid.UUID
+	ServiceDate *time.Time
+	Description string
+	Quantity    float64
+	UnitPrice   entity.Money
+	Amount      entity.Money
+}
+
+// InvoiceOutput represents invoice or credit note output data
+type InvoiceOutput struct {
+	ID                uuid.UUID
+	Number            string
+	Kind              entity.InvoiceKind
+	Status            entity.InvoiceStatus
+	OrganizationID    uuid.UUID
+	CreditedInvoiceID *uuid.UUID
+	PeriodFrom        time.Time
+	PeriodTo          time.Time
+	Currency          string
+	CreditTermDays    int
+	IssueDate         *time.Time
+	DueDate           *time.Time
+	Overdue           bool
+	Lines             []InvoiceLineOutput
+	Subtotal          entity.Money
+	VATRate           float64
+	VATAmount         entity.Money
+	Total             entity.Money
+	WithholdingRate   float64
+	WithholdingTax    entity.Money
+	AmountDue         entity.Money
+	Reason            string
+	Notes             string
+	PaidAt            *time.Time
+	PaymentReference  string
+	VoidedAt          *time.Time
+	VoidReason        string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
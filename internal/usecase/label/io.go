@@ -0,0 +1,32 @@
+package label
+
+import (
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// SetTemplateInput represents an organization's label template
+type SetTemplateInput struct {
+	OrganizationID   uuid.UUID
+	Size             entity.LabelSize
+	DPI              int
+	ZPLFont          string
+	GS1CompanyPrefix string
+	SSCCExtension    int
+	Footer           string
+}
+
+// TemplateOutput represents the label template that applies to an organization
+type TemplateOutput struct {
+	Template *entity.LabelTemplate
+	Custom   bool // the organization's own template rather than the default
+}
+
+// LabelOutput represents rendered labels ready to send to a printer
+type LabelOutput struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+	Count       int // labels printed
+}
@@ -0,0 +1,390 @@
+package label
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// DefaultDPI is the resolution of most thermal label printers
+const DefaultDPI = 203
+
+var (
+	labelDPIs        = map[int]bool{203: true, 300: true, 600: true}
+	gs1PrefixPattern = regexp.MustCompile(`^[0-9]{7,10}$`)
+	zplFontPattern   = regexp.MustCompile(`^[REBA]:[A-Za-z0-9_.-]{1,40}$`)
+)
+
+// LabelUseCase handles label templates and prints shipping labels for shipments and whole trips
+type LabelUseCase struct {
+	labelRepo     repository.LabelRepository
+	orgRepo       repository.OrganizationRepository
+	shipmentRepo  repository.ShipmentRepository
+	locationRepo  repository.LocationRepository
+	tripRepo      repository.TripRepository
+	numberingRepo repository.NumberingRepository
+	renderer      service.LabelRenderer
+	transactor    repository.Transactor
+	defaults      entity.LabelTemplate
+	carrier       string
+}
+
+// NewLabelUseCase creates a new label use case. defaults is the template of organizations without their own;
+// its SSCCs use the carrier's company prefix. carrier is the name printed at the top of every label.
+func NewLabelUseCase(
+	labelRepo repository.LabelRepository,
+	orgRepo repository.OrganizationRepository,
+	shipmentRepo repository.ShipmentRepository,
+	locationRepo repository.LocationRepository,
+	tripRepo repository.TripRepository,
+	numberingRepo repository.NumberingRepository,
+	renderer service.LabelRenderer,
+	transactor repository.Transactor,
+	defaults entity.LabelTemplate,
+	carrier string,
+) *LabelUseCase {
+	defaults.OrganizationID = uuid.Nil
+	if defaults.Size == "" {
+		defaults.Size = entity.LabelSize4x6
+	}
+	if defaults.DPI == 0 {
+		defaults.DPI = DefaultDPI
+	}
+	return &LabelUseCase{
+		labelRepo:     labelRepo,
+		orgRepo:       orgRepo,
+		shipmentRepo:  shipmentRepo,
+		locationRepo:  locationRepo,
+		tripRepo:      tripRepo,
+		numberingRepo: numberingRepo,
+		renderer:      renderer,
+		transactor:    transactor,
+		defaults:      defaults,
+		carrier:       carrier,
+	}
+}
+
+// GetTemplate returns the label template that applies to an organization
+func (uc *LabelUseCase) GetTemplate(ctx context.Context, organizationID uuid.UUID) (*TemplateOutput, error) {
+	if err := uc.ensureOrganization(ctx, organizationID); err != nil {
+		return nil, err
+	}
+
+	template, err := uc.labelRepo.FindTemplate(ctx, organizationID)
+	if errors.Is(err, errs.ErrNotFound) {
+		defaults := uc.defaults
+		return &TemplateOutput{Template: &defaults}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("label repository: find template: %w", err)
+	}
+	return &TemplateOutput{Template: template, Custom: true}, nil
+}
+
+// SetTemplate sets an organization's own label template. SSCCs of a template with its own company prefix
+// are numbered in a sequence of the organization; labels printed before keep their SSCCs.
+func (uc *LabelUseCase) SetTemplate(ctx context.Context, input SetTemplateInput) (*TemplateOutput, error) {
+	template := &entity.LabelTemplate{
+		OrganizationID:   input.OrganizationID,
+		Size:             input.Size,
+		DPI:              input.DPI,
+		ZPLFont:          strings.TrimSpace(input.ZPLFont),
+		GS1CompanyPrefix: strings.TrimSpace(input.GS1CompanyPrefix),
+		SSCCExtension:    input.SSCCExtension,
+		Footer:           strings.TrimSpace(input.Footer),
+	}
+	if template.DPI == 0 {
+		template.DPI = DefaultDPI
+	}
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+	if err := uc.ensureOrganization(ctx, input.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.labelRepo.SaveTemplate(ctx, template); err != nil {
+		return nil, fmt.Errorf("label repository: save template: %w", err)
+	}
+	return &TemplateOutput{Template: template, Custom: true}, nil
+}
+
+// DeleteTemplate removes an organization's own label template so the default applies again
+func (uc *LabelUseCase) DeleteTemplate(ctx context.Context, organizationID uuid.UUID) error {
+	if err := uc.labelRepo.DeleteTemplate(ctx, organizationID); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("label repository: delete template: %w", err)
+	}
+	return nil
+}
+
+// ShipmentLabels prints a label for every piece of a shipment
+func (uc *LabelUseCase) ShipmentLabels(ctx context.Context, shipmentID uuid.UUID, format entity.LabelFormat) (*LabelOutput, error) {
+	shipment, err := uc.shipmentRepo.FindByID(ctx, shipmentID)
+	if err != nil {
+		return nil, fmt.Errorf("shipment repository: find by id: %w", err)
+	}
+	if shipment.Status == entity.ShipmentStatusCancelled {
+		return nil, errs.ErrShipmentUnavailable
+	}
+
+	routes := make(map[uuid.UUID]string)
+	trip, err := uc.tripRepo.FindByShipment(ctx, shipment.ID)
+	switch {
+	case err == nil:
+		routes = tripRoutes(trip)
+	case !errors.Is(err, errs.ErrNotFound):
+		return nil, fmt.Errorf("trip repository: find by shipment: %w", err)
+	}
+
+	return uc.print(ctx, []*entity.Shipment{shipment}, routes, format, "labels-"+shipment.TrackingNumber)
+}
+
+// TripLabels prints the labels of every shipment on a trip in a single batch, in the order the
+// shipments are picked up. Cancelled shipments are left out.
+func (uc *LabelUseCase) TripLabels(ctx context.Context, tripID uuid.UUID, format entity.LabelFormat) (*LabelOutput, error) {
+	trip, err := uc.tripRepo.FindByID(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("trip repository: find by id: %w", err)
+	}
+
+	ids := trip.ShipmentIDs()
+	found, err := uc.shipmentRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("shipment repository: find by ids: %w", err)
+	}
+	byID := make(map[uuid.UUID]*entity.Shipment, len(found))
+	for _, s := range found {
+		byID[s.ID] = s
+	}
+	var shipments []*entity.Shipment
+	for _, id := range ids {
+		if s, ok := byID[id]; ok && s.Status != entity.ShipmentStatusCancelled {
+			shipments = append(shipments, s)
+		}
+	}
+	if len(shipments) == 0 {
+		return nil, errs.ErrShipmentUnavailable
+	}
+
+	return uc.print(ctx, shipments, tripRoutes(trip), format, "labels-"+trip.Number)
+}
+
+// print renders a label for every piece of the shipments, in order
+func (uc *LabelUseCase) print(ctx context.Context, shipments []*entity.Shipment, routes map[uuid.UUID]string, format entity.LabelFormat, name string) (*LabelOutput, error) {
+	templates, err := uc.templates(ctx, shipments)
+	if err != nil {
+		return nil, err
+	}
+	pieces, err := uc.pieces(ctx, shipments, templates)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := uc.locations(ctx, shipments)
+	if err != nil {
+		return nil, err
+	}
+
+	var labels []service.ShippingLabel
+	for _, s := range shipments {
+		pickup, delivery := locations[s.PickupLocationID], locations[s.DeliveryLocationID]
+		if pickup == nil || delivery == nil {
+			return nil, fmt.Errorf("shipment %s: location not found", s.ID)
+		}
+		for _, piece := range pieces[s.ID][:s.Pieces()] {
+			labels = append(labels, service.ShippingLabel{
+				Template:       templates[s.OrganizationID],
+				Carrier:        uc.carrier,
+				TrackingNumber: s.TrackingNumber,
+				Reference:      s.Reference,
+				SSCC:           piece.SSCC,
+				Piece:          piece.Piece,
+				Pieces:         s.Pieces(),
+				Shipper:        locationParty(pickup),
+				Consignee:      locationParty(delivery),
+				SortCode:       delivery.Postcode,
+				Route:          routes[s.ID],
+				WeightKg:       s.WeightKg,
+				DeliverBy:      s.DeliverTo,
+			})
+		}
+	}
+
+	output := &LabelOutput{Count: len(labels)}
+	switch format {
+	case entity.LabelFormatPDF:
+		output.Content, err = uc.renderer.RenderPDF(labels)
+		output.Filename = name + ".pdf"
+		output.ContentType = "application/pdf"
+	default:
+		output.Content, err = uc.renderer.RenderZPL(labels)
+		output.Filename = name + ".zpl"
+		output.ContentType = "application/x-zpl"
+	}
+	if err != nil {
+		return nil, fmt.Errorf("label renderer: render %s: %w", format, err)
+	}
+	return output, nil
+}
+
+// templates returns the label template of each shipment's organization
+func (uc *LabelUseCase) templates(ctx context.Context, shipments []*entity.Shipment) (map[uuid.UUID]*entity.LabelTemplate, error) {
+	templates := make(map[uuid.UUID]*entity.LabelTemplate)
+	for _, s := range shipments {
+		if _, ok := templates[s.OrganizationID]; ok {
+			continue
+		}
+		template, err := uc.labelRepo.FindTemplate(ctx, s.OrganizationID)
+		if errors.Is(err, errs.ErrNotFound) {
+			defaults := uc.defaults
+			template = &defaults
+		} else if err != nil {
+			return nil, fmt.Errorf("label repository: find template: %w", err)
+		}
+		templates[s.OrganizationID] = template
+	}
+	return templates, nil
+}
+
+// pieces returns the labelled pieces of each shipment, first recording the pieces labelled for the
+// first time with new SSCCs. The shipments are locked while their pieces are numbered, so a concurrent
+// print of the same shipment waits and then finds the pieces recorded here.
+func (uc *LabelUseCase) pieces(ctx context.Context, shipments []*entity.Shipment, templates map[uuid.UUID]*entity.LabelTemplate) (map[uuid.UUID][]*entity.ShipmentPiece, error) {
+	ids := make([]uuid.UUID, len(shipments))
+	for i, s := range shipments {
+		ids[i] = s.ID
+	}
+
+	var pieces map[uuid.UUID][]*entity.ShipmentPiece
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := uc.shipmentRepo.FindByIDsForUpdate(ctx, ids); err != nil {
+			return fmt.Errorf("shipment repository: find by ids for update: %w", err)
+		}
+		found, err := uc.labelRepo.FindPieces(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("label repository: find pieces: %w", err)
+		}
+		pieces = make(map[uuid.UUID][]*entity.ShipmentPiece, len(shipments))
+		for _, p := range found {
+			pieces[p.ShipmentID] = append(pieces[p.ShipmentID], p)
+		}
+
+		var created []*entity.ShipmentPiece
+		for _, s := range shipments {
+			for n := len(pieces[s.ID]) + 1; n <= s.Pieces(); n++ {
+				sscc, err := uc.nextSSCC(ctx, templates[s.OrganizationID])
+				if err != nil {
+					return err
+				}
+				piece := &entity.ShipmentPiece{ShipmentID: s.ID, Piece: n, SSCC: sscc}
+				pieces[s.ID] = append(pieces[s.ID], piece)
+				created = append(created, piece)
+			}
+		}
+		if err := uc.labelRepo.CreatePieces(ctx, created); err != nil {
+			return fmt.Errorf("label repository: create pieces: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pieces, nil
+}
+
+// nextSSCC numbers the SSCC of a new piece; templates without a company prefix print no SSCC.
+// The default template's serial references are counted under the nil organization ID.
+func (uc *LabelUseCase) nextSSCC(ctx context.Context, template *entity.LabelTemplate) (string, error) {
+	if template.GS1CompanyPrefix == "" {
+		return "", nil
+	}
+	serial, err := uc.numberingRepo.NextValue(ctx, template.OrganizationID, entity.DocumentSSCC, "")
+	if err != nil {
+		return "", fmt.Errorf("numbering repository: next value: %w", err)
+	}
+	sscc, ok := template.SSCC(serial)
+	if !ok {
+		return "", fmt.Errorf("sscc serial references of company prefix %s are used up", template.GS1CompanyPrefix)
+	}
+	return sscc, nil
+}
+
+func (uc *LabelUseCase) locations(ctx context.Context, shipments []*entity.Shipment) (map[uuid.UUID]*entity.Location, error) {
+	var ids []uuid.UUID
+	for _, s := range shipments {
+		ids = append(ids, s.PickupLocationID, s.DeliveryLocationID)
+	}
+	found, err := uc.locationRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("location repository: find by ids: %w", err)
+	}
+	locations := make(map[uuid.UUID]*entity.Location, len(found))
+	for _, l := range found {
+		locations[l.ID] = l
+	}
+	return locations, nil
+}
+
+func (uc *LabelUseCase) ensureOrganization(ctx context.Context, organizationID uuid.UUID) error {
+	if _, err := uc.orgRepo.FindByID(ctx, organizationID); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("organization repository: find by id: %w", err)
+	}
+	return nil
+}
+
+// tripRoutes returns the trip number and delivery stop of every shipment on a trip, e.g. TRP-2610-00042/3
+func tripRoutes(trip *entity.Trip) map[uuid.UUID]string {
+	routes := make(map[uuid.UUID]string)
+	for _, stop := range trip.Stops {
+		if stop.Type == entity.StopTypeDelivery {
+			routes[stop.ShipmentID] = fmt.Sprintf("%s/%d", trip.Number, stop.Sequence)
+		}
+	}
+	return routes
+}
+
+func validateTemplate(t *entity.LabelTemplate) error {
+	fields := errs.ValidationErrors{}
+	if t.Size != entity.LabelSize4x6 && t.Size != entity.LabelSizeA6 {
+		fields["size"] = []string{"unknown_label_size"}
+	}
+	if !labelDPIs[t.DPI] {
+		fields["dpi"] = []string{"unsupported_dpi"}
+	}
+	if t.ZPLFont != "" && !zplFontPattern.MatchString(t.ZPLFont) {
+		fields["zpl_font"] = []string{"invalid_printer_font"}
+	}
+	if t.GS1CompanyPrefix != "" && !gs1PrefixPattern.MatchString(t.GS1CompanyPrefix) {
+		fields["gs1_company_prefix"] = []string{"invalid_company_prefix"}
+	}
+	if t.SSCCExtension < 0 || t.SSCCExtension > 9 {
+		fields["sscc_extension"] = []string{"invalid_extension_digit"}
+	}
+	if len(fields) > 0 {
+		return fields
+	}
+	return nil
+}
+
+func locationParty(l *entity.Location) service.Party {
+	return service.Party{
+		Name:    l.Name,
+		Address: l.FormattedAddress(),
+		Contact: l.ContactName,
+		Phone:   l.ContactPhone,
+	}
+}
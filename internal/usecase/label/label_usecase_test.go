@@ -0,0 +1,162 @@
+package label
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// store stands in for the database: a row lock on the shipments is held until the transaction that
+// took it ends
+type store struct {
+	rowLock sync.Mutex
+	mu      sync.Mutex
+	pieces  []*entity.ShipmentPiece
+	serial  int64
+}
+
+type txKey struct{}
+
+type tx struct{ locked bool }
+
+type transactor struct{ store *store }
+
+func (t transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx := &tx{}
+	err := fn(context.WithValue(ctx, txKey{}, tx))
+	if tx.locked {
+		t.store.rowLock.Unlock()
+	}
+	return err
+}
+
+type shipmentRepo struct {
+	repository.ShipmentRepository
+	store *store
+}
+
+func (r shipmentRepo) FindByIDsForUpdate(ctx context.Context, _ []uuid.UUID) ([]*entity.Shipment, error) {
+	tx := ctx.Value(txKey{}).(*tx)
+	if !tx.locked {
+		r.store.rowLock.Lock()
+		tx.locked = true
+	}
+	return nil, nil
+}
+
+type labelRepo struct {
+	repository.LabelRepository
+	store *store
+}
+
+func (r labelRepo) FindPieces(context.Context, []uuid.UUID) ([]*entity.ShipmentPiece, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	return append([]*entity.ShipmentPiece(nil), r.store.pieces...), nil
+}
+
+func (r labelRepo) CreatePieces(_ context.Context, pieces []*entity.ShipmentPiece) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	for _, p := range pieces {
+		for _, existing := range r.store.pieces {
+			if existing.ShipmentID == p.ShipmentID && existing.Piece == p.Piece {
+				return errs.ErrConflict
+			}
+		}
+	}
+	r.store.pieces = append(r.store.pieces, pieces...)
+	return nil
+}
+
+type numberingRepo struct {
+	repository.NumberingRepository
+	store *store
+}
+
+func (r numberingRepo) NextValue(context.Context, uuid.UUID, entity.DocumentType, string) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.serial++
+	// Widen the window between reading and recording the pieces
+	time.Sleep(time.Millisecond)
+	return r.store.serial, nil
+}
+
+func TestConcurrentPrintsShareSSCCs(t *testing.T) {
+	store := &store{}
+	uc := &LabelUseCase{
+		labelRepo:     labelRepo{store: store},
+		shipmentRepo:  shipmentRepo{store: store},
+		numberingRepo: numberingRepo{store: store},
+		transactor:    transactor{store: store},
+	}
+	shipment := &entity.Shipment{ID: uuid.New(), OrganizationID: uuid.New(), Pallets: 3}
+	templates := map[uuid.UUID]*entity.LabelTemplate{
+		shipment.OrganizationID: {OrganizationID: shipment.OrganizationID, GS1CompanyPrefix: "0614141"},
+	}
+
+	const prints = 4
+	results := make([]map[uuid.UUID][]*entity.ShipmentPiece, prints)
+	failures := make([]error, prints)
+	var wg sync.WaitGroup
+	for i := range prints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], failures[i] = uc.pieces(context.Background(), []*entity.Shipment{shipment}, templates)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range failures {
+		if err != nil {
+			t.Fatalf("print %d: %v", i, err)
+		}
+	}
+	if store.serial != 3 {
+		t.Errorf("%d SSCC serials allocated, want one per pallet", store.serial)
+	}
+	want := results[0][shipment.ID]
+	if len(want) != 3 {
+		t.Fatalf("%d pieces, want 3", len(want))
+	}
+	for i, result := range results[1:] {
+		got := result[shipment.ID]
+		for n := range want {
+			if got[n].SSCC != want[n].SSCC {
+				t.Errorf("print %d piece %d has SSCC %s, another print has %s", i+1, n+1, got[n].SSCC, want[n].SSCC)
+			}
+		}
+	}
+}
+
+func TestPiecesReportsUsedUpSerials(t *testing.T) {
+	store := &store{serial: 999999999}
+	uc := &LabelUseCase{
+		labelRepo:     labelRepo{store: store},
+		shipmentRepo:  shipmentRepo{store: store},
+		numberingRepo: numberingRepo{store: store},
+		transactor:    transactor{store: store},
+	}
+	shipment := &entity.Shipment{ID: uuid.New(), OrganizationID: uuid.New()}
+	templates := map[uuid.UUID]*entity.LabelTemplate{
+		shipment.OrganizationID: {OrganizationID: shipment.OrganizationID, GS1CompanyPrefix: "0614141"},
+	}
+
+	_, err := uc.pieces(context.Background(), []*entity.Shipment{shipment}, templates)
+	if err == nil || errors.Is(err, errs.ErrConflict) {
+		t.Errorf("err = %v, want serial references used up", err)
+	}
+	if len(store.pieces) != 0 {
+		t.Errorf("%d pieces recorded without an SSCC", len(store.pieces))
+	}
+}
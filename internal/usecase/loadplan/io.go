@@ -0,0 +1,9 @@
+package loadplan
+
+import "tms-core-service/internal/domain/service"
+
+// PlanInput represents the cargo space and handling units to pack
+type PlanInput struct {
+	Space service.CargoSpace
+	Units []service.HandlingUnit
+}
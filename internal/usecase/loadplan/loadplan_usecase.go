@@ -0,0 +1,48 @@
+package loadplan
+
+import (
+	"context"
+	"fmt"
+
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/service"
+)
+
+// MaxUnits bounds the total quantity of handling units in one plan
+const MaxUnits = 1000
+
+// LoadPlanUseCase builds truck-fill plans
+type LoadPlanUseCase struct {
+	planner service.LoadPlanner
+}
+
+// NewLoadPlanUseCase creates a new load plan use case
+func NewLoadPlanUseCase(planner service.LoadPlanner) *LoadPlanUseCase {
+	return &LoadPlanUseCase{planner: planner}
+}
+
+// Plan packs the handling units into the cargo space
+func (uc *LoadPlanUseCase) Plan(_ context.Context, input PlanInput) (*service.LoadPlan, error) {
+	validationErrs := make(errs.ValidationErrors)
+	seen := make(map[string]bool, len(input.Units))
+	total := 0
+	for i, u := range input.Units {
+		if seen[u.ID] {
+			validationErrs[fmt.Sprintf("units[%d].id", i)] = []string{"duplicate_id"}
+		}
+		seen[u.ID] = true
+		total += u.Quantity
+	}
+	if total > MaxUnits {
+		validationErrs["units"] = []string{"too_many_units"}
+	}
+	if len(validationErrs) > 0 {
+		return nil, validationErrs
+	}
+
+	plan, err := uc.planner.Plan(input.Space, input.Units)
+	if err != nil {
+		return nil, fmt.Errorf("load planner: plan: %w", err)
+	}
+	return plan, nil
+}
@@ -0,0 +1,71 @@
+package location
+
+import (
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/service"
+)
+
+// maxSearchResults caps autocomplete responses
+const maxSearchResults = 50
+
+// AddressUseCase handles Thai administrative-area lookups for address autocomplete
+type AddressUseCase struct {
+	directory service.AddressDirectory
+}
+
+// NewAddressUseCase creates a new address use case
+func NewAddressUseCase(directory service.AddressDirectory) *AddressUseCase {
+	return &AddressUseCase{directory: directory}
+}
+
+// Provinces lists all provinces
+func (uc *AddressUseCase) Provinces() []NameOutput {
+	return toNameOutputs(uc.directory.Provinces())
+}
+
+// Districts lists the districts of a province
+func (uc *AddressUseCase) Districts(province string) []NameOutput {
+	return toNameOutputs(uc.directory.Districts(province))
+}
+
+// Subdistricts lists the subdistricts of a district
+func (uc *AddressUseCase) Subdistricts(province, district string) []AreaOutput {
+	return toAreaOutputs(uc.directory.Subdistricts(province, district))
+}
+
+// ByPostcode lists the areas served by a postcode
+func (uc *AddressUseCase) ByPostcode(postcode string) []AreaOutput {
+	return toAreaOutputs(uc.directory.ByPostcode(postcode))
+}
+
+// Search returns areas matching a free-text autocomplete query
+func (uc *AddressUseCase) Search(query string, limit int) []AreaOutput {
+	if limit <= 0 || limit > maxSearchResults {
+		limit = maxSearchResults
+	}
+	return toAreaOutputs(uc.directory.Search(query, limit))
+}
+
+func toNameOutputs(names []entity.AdminName) []NameOutput {
+	outputs := make([]NameOutput, len(names))
+	for i, n := range names {
+		outputs[i] = NameOutput{TH: n.TH, EN: n.EN}
+	}
+	return outputs
+}
+
+func toAreaOutputs(areas []entity.AdminArea) []AreaOutput {
+	outputs := make([]AreaOutput, len(areas))
+	for i, a := range areas {
+		outputs[i] = AreaOutput{
+			SubdistrictTH: a.SubdistrictTH,
+			SubdistrictEN: a.SubdistrictEN,
+			DistrictTH:    a.DistrictTH,
+			DistrictEN:    a.DistrictEN,
+			ProvinceTH:    a.ProvinceTH,
+			ProvinceEN:    a.ProvinceEN,
+			Postcode:      a.Postcode,
+		}
+	}
+	return outputs
+}
@@ -0,0 +1,86 @@
+package location
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// LocationInput represents data for creating or updating a location
+type LocationInput struct {
+	Name         string
+	Code         string
+	HouseNumber  string
+	Moo          string
+	Soi          string
+	Road         string
+	Subdistrict  string
+	District     string
+	Province     string
+	Postcode     string
+	Latitude     *float64
+	Longitude    *float64
+	ContactName  string
+	ContactPhone string
+	OpeningHours []entity.OpeningHours
+	Notes        string
+}
+
+// ListLocationsInput represents criteria for searching an address book
+type ListLocationsInput struct {
+	OrganizationID uuid.UUID
+	Search         string
+	Limit          int
+	Offset         int
+}
+
+// WarningAddressUnlisted reports an address whose district or subdistrict is not in the address dataset;
+// it is saved as entered, so a typo goes unnoticed
+const WarningAddressUnlisted = "address_unlisted"
+
+// LocationOutput represents location output data.
+// AddressWarnings is only set by Create and Update.
+type LocationOutput struct {
+	ID               uuid.UUID
+	OrganizationID   uuid.UUID
+	Name             string
+	Code             string
+	HouseNumber      string
+	Moo              string
+	Soi              string
+	Road             string
+	Subdistrict      string
+	District         string
+	Province         string
+	Postcode         string
+	FormattedAddress string
+	Latitude         *float64
+	Longitude        *float64
+	GeoPrecision     entity.GeoPrecision
+	ContactName      string
+	ContactPhone     string
+	OpeningHours     []entity.OpeningHours
+	Notes            string
+	AddressWarnings  []string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// AreaOutput represents a Thai subdistrict with its parent areas
+type AreaOutput struct {
+	SubdistrictTH string
+	SubdistrictEN string
+	DistrictTH    string
+	DistrictEN    string
+	ProvinceTH    string
+	ProvinceEN    string
+	Postcode      string
+}
+
+// NameOutput represents a Thai administrative name with its English transliteration
+type NameOutput struct {
+	TH string
+	EN string
+}
@@ -0,0 +1,215 @@
+package location
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// LocationUseCase handles address book operations
+type LocationUseCase struct {
+	locationRepo repository.LocationRepository
+	orgRepo      repository.OrganizationRepository
+	directory    service.AddressDirectory
+	geocoder     service.Geocoder
+}
+
+// NewLocationUseCase creates a new location use case
+func NewLocationUseCase(
+	locationRepo repository.LocationRepository,
+	orgRepo repository.OrganizationRepository,
+	directory service.AddressDirectory,
+	geocoder service.Geocoder,
+) *LocationUseCase {
+	return &LocationUseCase{
+		locationRepo: locationRepo,
+		orgRepo:      orgRepo,
+		directory:    directory,
+		geocoder:     geocoder,
+	}
+}
+
+// Create saves a new location in an organization's address book
+func (uc *LocationUseCase) Create(ctx context.Context, organizationID uuid.UUID, input LocationInput) (*LocationOutput, error) {
+	if _, err := uc.orgRepo.FindByID(ctx, organizationID); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("organization repository: find by id: %w", err)
+	}
+
+	location := &entity.Location{OrganizationID: organizationID}
+	warnings, err := uc.applyInput(location, input)
+	if err != nil {
+		return nil, err
+	}
+	uc.locate(ctx, location)
+
+	if err := uc.locationRepo.Create(ctx, location); err != nil {
+		return nil, fmt.Errorf("location repository: create location: %w", err)
+	}
+
+	output := toLocationOutput(location)
+	output.AddressWarnings = warnings
+	return output, nil
+}
+
+// Get returns a location by ID
+func (uc *LocationUseCase) Get(ctx context.Context, id uuid.UUID) (*LocationOutput, error) {
+	location, err := uc.findLocation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toLocationOutput(location), nil
+}
+
+// List searches an organization's address book
+func (uc *LocationUseCase) List(ctx context.Context, input ListLocationsInput) ([]*LocationOutput, int64, error) {
+	locations, total, err := uc.locationRepo.ListByOrganization(ctx, input.OrganizationID, input.Search, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("location repository: list by organization: %w", err)
+	}
+
+	outputs := make([]*LocationOutput, len(locations))
+	for i, l := range locations {
+		outputs[i] = toLocationOutput(l)
+	}
+	return outputs, total, nil
+}
+
+// Update updates a saved location
+func (uc *LocationUseCase) Update(ctx context.Context, id uuid.UUID, input LocationInput) (*LocationOutput, error) {
+	location, err := uc.findLocation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings, err := uc.applyInput(location, input)
+	if err != nil {
+		return nil, err
+	}
+	uc.locate(ctx, location)
+
+	if err := uc.locationRepo.Update(ctx, location); err != nil {
+		return nil, fmt.Errorf("location repository: update location: %w", err)
+	}
+
+	output := toLocationOutput(location)
+	output.AddressWarnings = warnings
+	return output, nil
+}
+
+// Delete removes a location from its address book
+func (uc *LocationUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := uc.locationRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("location repository: delete location: %w", err)
+	}
+	return nil
+}
+
+// applyInput validates the administrative area and copies the input onto the location,
+// storing the canonical Thai area names from the dataset. An area the dataset does not list is
+// saved as given and reported in the returned warnings.
+func (uc *LocationUseCase) applyInput(location *entity.Location, input LocationInput) ([]string, error) {
+	area, err := uc.directory.Resolve(input.Subdistrict, input.District, input.Province, input.Postcode)
+	if err != nil {
+		return nil, fmt.Errorf("address directory: resolve: %w", err)
+	}
+	var warnings []string
+	if area.Unlisted {
+		warnings = append(warnings, WarningAddressUnlisted)
+	}
+
+	location.Name = input.Name
+	location.Code = input.Code
+	location.HouseNumber = input.HouseNumber
+	location.Moo = input.Moo
+	location.Soi = input.Soi
+	location.Road = input.Road
+	location.Subdistrict = area.SubdistrictTH
+	location.District = area.DistrictTH
+	location.Province = area.ProvinceTH
+	location.Postcode = area.Postcode
+	location.Latitude = input.Latitude
+	location.Longitude = input.Longitude
+	location.GeoPrecision = ""
+	if location.HasCoordinates() {
+		location.GeoPrecision = entity.GeoPrecisionManual
+	}
+	location.ContactName = input.ContactName
+	location.ContactPhone = input.ContactPhone
+	location.OpeningHours = input.OpeningHours
+	location.Notes = input.Notes
+	return warnings, nil
+}
+
+// locate geocodes a location that was saved without a pin.
+// Geocoding is best effort: an address the provider cannot find is still saved, just without coordinates.
+func (uc *LocationUseCase) locate(ctx context.Context, location *entity.Location) {
+	if location.HasCoordinates() {
+		return
+	}
+
+	result, err := uc.geocoder.Geocode(ctx, service.GeocodeQuery{
+		Address:     location.StreetAddress(),
+		Subdistrict: location.Subdistrict,
+		District:    location.District,
+		Province:    location.Province,
+		Postcode:    location.Postcode,
+	})
+	if err != nil {
+		return
+	}
+
+	location.Latitude = &result.Latitude
+	location.Longitude = &result.Longitude
+	location.GeoPrecision = result.Precision
+}
+
+func (uc *LocationUseCase) findLocation(ctx context.Context, id uuid.UUID) (*entity.Location, error) {
+	location, err := uc.locationRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("location repository: find by id: %w", err)
+	}
+	return location, nil
+}
+
+func toLocationOutput(l *entity.Location) *LocationOutput {
+	return &LocationOutput{
+		ID:               l.ID,
+		OrganizationID:   l.OrganizationID,
+		Name:             l.Name,
+		Code:             l.Code,
+		HouseNumber:      l.HouseNumber,
+		Moo:              l.Moo,
+		Soi:              l.Soi,
+		Road:             l.Road,
+		Subdistrict:      l.Subdistrict,
+		District:         l.District,
+		Province:         l.Province,
+		Postcode:         l.Postcode,
+		FormattedAddress: l.FormattedAddress(),
+		Latitude:         l.Latitude,
+		Longitude:        l.Longitude,
+		GeoPrecision:     l.GeoPrecision,
+		ContactName:      l.ContactName,
+		ContactPhone:     l.ContactPhone,
+		OpeningHours:     l.OpeningHours,
+		Notes:            l.Notes,
+		CreatedAt:        l.CreatedAt,
+		UpdatedAt:        l.UpdatedAt,
+	}
+}
@@ -0,0 +1,152 @@
+package maintenance
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// PlanInput represents a maintenance plan to create or replace; at least one of the intervals is required
+type PlanInput struct {
+	Name         string
+	VehicleType  entity.VehicleType
+	IntervalKm   float64
+	IntervalDays int
+	Description  string
+	Active       bool
+}
+
+// ListPlansInput represents criteria for listing maintenance plans
+type ListPlansInput struct {
+	VehicleType *entity.VehicleType
+	Active      *bool
+	Limit       int
+	Offset      int
+}
+
+// PlanOutput represents maintenance plan output data
+type PlanOutput struct {
+	ID           uuid.UUID
+	Name         string
+	VehicleType  entity.VehicleType
+	IntervalKm   float64
+	IntervalDays int
+	Description  string
+	Active       bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ListSchedulesInput represents criteria for listing maintenance schedules
+type ListSchedulesInput struct {
+	VehicleID *uuid.UUID
+	Status    *entity.MaintenanceStatus
+	Limit     int
+	Offset    int
+}
+
+// ScheduleOutput represents when a vehicle is next due for the service of a plan.
+// RemainingKm is negative once the vehicle has run past the due distance.
+type ScheduleOutput struct {
+	ID              uuid.UUID
+	VehicleID       uuid.UUID
+	PlanID          uuid.UUID
+	PlanName        string
+	LastServiceAt   time.Time
+	LastServiceKm   float64
+	LastWorkOrderID *uuid.UUID
+	CurrentKm       float64
+	NextDueAt       *time.Time
+	NextDueKm       *float64
+	RemainingKm     *float64
+	Status          entity.MaintenanceStatus
+	CheckedAt       time.Time
+}
+
+// PartInput represents a part fitted under a work order
+type PartInput struct {
+	PartNumber string
+	Name       string
+	Quantity   float64
+	UnitCost   entity.Money
+}
+
+// WorkOrderInput represents a work order to open. A preventive work order services a plan for the vehicle's type.
+type WorkOrderInput struct {
+	VehicleID   uuid.UUID
+	PlanID      *uuid.UUID
+	Type        entity.WorkOrderType
+	Workshop    string
+	Description string
+	OdometerKm  float64
+	Parts       []PartInput
+	LaborCost   entity.Money
+	Notes       string
+	CreatedBy   uuid.UUID
+}
+
+// UpdateWorkOrderInput represents the details of a work order that stay editable until it is closed
+type UpdateWorkOrderInput struct {
+	Workshop    string
+	Description string
+	OdometerKm  float64
+	Parts       []PartInput
+	LaborCost   entity.Money
+	Notes       string
+}
+
+// CompleteWorkOrderInput represents the completion of a work order.
+// StartedAt is when the vehicle went off the road, for a work order that was never started;
+// a zero odometer keeps the reading taken when the work order was opened.
+type CompleteWorkOrderInput struct {
+	CompletedAt time.Time
+	StartedAt   *time.Time
+	OdometerKm  float64
+	CompletedBy uuid.UUID
+}
+
+// ListWorkOrdersInput represents criteria for listing work orders
+type ListWorkOrdersInput struct {
+	VehicleID *uuid.UUID
+	Status    *entity.WorkOrderStatus
+	Type      *entity.WorkOrderType
+	Search    string
+	Limit     int
+	Offset    int
+}
+
+// WorkOrderPartOutput represents a part fitted under a work order
+type WorkOrderPartOutput struct {
+	Sequence   int
+	PartNumber string
+	Name       string
+	Quantity   float64
+	UnitCost   entity.Money
+	Amount     entity.Money
+}
+
+// WorkOrderOutput represents work order output data
+type WorkOrderOutput struct {
+	ID            uuid.UUID
+	Number        string
+	VehicleID     uuid.UUID
+	PlanID        *uuid.UUID
+	Type          entity.WorkOrderType
+	Status        entity.WorkOrderStatus
+	Workshop      string
+	Description   string
+	OdometerKm    float64
+	Parts         []WorkOrderPartOutput
+	PartsCost     entity.Money
+	LaborCost     entity.Money
+	TotalCost     entity.Money
+	StartedAt     *time.Time
+	CompletedAt   *time.Time
+	DowntimeHours float64
+	Notes         string
+	CreatedBy     *uuid.UUID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
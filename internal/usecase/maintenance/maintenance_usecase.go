@@ -0,0 +1,304 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultWarnKm is how close to the due distance a service becomes due when not configured
+	DefaultWarnKm = 1000
+
+	// DefaultWarnDays is how close to the due date a service becomes due when not configured
+	DefaultWarnDays = 7
+
+	// checkBatchSize is how many vehicles or plans a check loads at a time
+	checkBatchSize = 100
+)
+
+// MaintenanceUseCase works out when each vehicle is next due for the services of the plans for its type
+type MaintenanceUseCase struct {
+	scheduleRepo repository.MaintenanceScheduleRepository
+	planRepo     repository.MaintenancePlanRepository
+	vehicleRepo  repository.VehicleRepository
+	transactor   repository.Transactor
+	publisher    service.EventPublisher
+	warnKm       float64
+	warnDays     int
+}
+
+// NewMaintenanceUseCase creates a new maintenance use case.
+// A service becomes due within warnKm or warnDays of falling due; zero uses DefaultWarnKm and DefaultWarnDays.
+func NewMaintenanceUseCase(
+	scheduleRepo repository.MaintenanceScheduleRepository,
+	planRepo repository.MaintenancePlanRepository,
+	vehicleRepo repository.VehicleRepository,
+	transactor repository.Transactor,
+	publisher service.EventPublisher,
+	warnKm float64,
+	warnDays int,
+) *MaintenanceUseCase {
+	if warnKm <= 0 {
+		warnKm = DefaultWarnKm
+	}
+	if warnDays <= 0 {
+		warnDays = DefaultWarnDays
+	}
+	return &MaintenanceUseCase{
+		scheduleRepo: scheduleRepo,
+		planRepo:     planRepo,
+		vehicleRepo:  vehicleRepo,
+		transactor:   transactor,
+		publisher:    publisher,
+		warnKm:       warnKm,
+		warnDays:     warnDays,
+	}
+}
+
+// CheckSchedules brings the schedules of every vehicle in service up to date with its latest odometer reading,
+// raises an alert for each service that became due or overdue, and returns how many alerts were raised.
+// It is run periodically by a background worker.
+func (uc *MaintenanceUseCase) CheckSchedules(ctx context.Context) (int, error) {
+	plans, err := uc.loadPlans(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	alerts := 0
+	for _, status := range []entity.VehicleStatus{entity.VehicleStatusActive, entity.VehicleStatusMaintenance} {
+		for offset := 0; ; offset += checkBatchSize {
+			vehicles, _, err := uc.vehicleRepo.List(ctx, repository.VehicleFilter{Status: &status}, checkBatchSize, offset)
+			if err != nil {
+				return alerts, fmt.Errorf("vehicle repository: list vehicles: %w", err)
+			}
+			raised, err := uc.check(ctx, vehicles, plans, time.Now())
+			alerts += raised
+			if err != nil {
+				return alerts, err
+			}
+			if len(vehicles) < checkBatchSize {
+				break
+			}
+		}
+	}
+	return alerts, nil
+}
+
+// VehicleSchedules checks a vehicle's schedules and returns them, most urgent first
+func (uc *MaintenanceUseCase) VehicleSchedules(ctx context.Context, vehicleID uuid.UUID) ([]*ScheduleOutput, error) {
+	vehicle, err := uc.vehicleRepo.FindByID(ctx, vehicleID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("vehicle repository: find by id: %w", err)
+	}
+
+	plans, err := uc.loadPlans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.check(ctx, []*entity.Vehicle{vehicle}, plans, time.Now()); err != nil {
+		return nil, err
+	}
+
+	schedules, _, err := uc.scheduleRepo.List(ctx, repository.MaintenanceScheduleFilter{VehicleID: &vehicleID}, len(plans)+1, 0)
+	if err != nil {
+		return nil, fmt.Errorf("maintenance schedule repository: list schedules: %w", err)
+	}
+	return toScheduleOutputs(schedules, plans), nil
+}
+
+// ListSchedules returns the schedules matching the input criteria as of the last check, most urgent first
+func (uc *MaintenanceUseCase) ListSchedules(ctx context.Context, input ListSchedulesInput) ([]*ScheduleOutput, int64, error) {
+	schedules, total, err := uc.scheduleRepo.List(ctx, repository.MaintenanceScheduleFilter{
+		VehicleID: input.VehicleID,
+		Status:    input.Status,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("maintenance schedule repository: list schedules: %w", err)
+	}
+
+	plans, err := uc.loadPlans(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return toScheduleOutputs(schedules, plans), total, nil
+}
+
+// check evaluates the schedules of the vehicles against the active plans for their types, starting a schedule
+// for each plan that newly applies and dropping those of plans that no longer do, and publishes the alerts raised
+func (uc *MaintenanceUseCase) check(ctx context.Context, vehicles []*entity.Vehicle, plans []*entity.MaintenancePlan, now time.Time) (int, error) {
+	if len(vehicles) == 0 {
+		return 0, nil
+	}
+	ids := make([]uuid.UUID, len(vehicles))
+	for i, v := range vehicles {
+		ids[i] = v.ID
+	}
+	odometers, err := uc.scheduleRepo.LatestOdometers(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("maintenance schedule repository: latest odometers: %w", err)
+	}
+
+	var events []service.RealtimeEvent
+	for _, v := range vehicles {
+		var alerts []service.RealtimeEvent
+		err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+			// The vehicle row serializes the check with the completion of the vehicle's work orders
+			vehicle, err := uc.vehicleRepo.FindByIDForUpdate(ctx, v.ID)
+			if err != nil {
+				if errors.Is(err, errs.ErrNotFound) {
+					return nil
+				}
+				return fmt.Errorf("vehicle repository: find by id for update: %w", err)
+			}
+			schedules, err := uc.scheduleRepo.FindByVehicles(ctx, []uuid.UUID{vehicle.ID})
+			if err != nil {
+				return fmt.Errorf("maintenance schedule repository: find by vehicles: %w", err)
+			}
+
+			byPlan := make(map[uuid.UUID]*entity.MaintenanceSchedule, len(schedules))
+			var stale []uuid.UUID
+			for _, s := range schedules {
+				// Schedules of retired plans are kept, so the plan resumes from the last service if brought back
+				plan := findPlan(plans, s.PlanID)
+				if plan == nil || plan.VehicleType != vehicle.Type {
+					stale = append(stale, s.ID)
+					continue
+				}
+				byPlan[s.PlanID] = s
+			}
+			if err := uc.scheduleRepo.Delete(ctx, stale); err != nil {
+				return fmt.Errorf("maintenance schedule repository: delete schedules: %w", err)
+			}
+
+			odometer, known := odometers[vehicle.ID]
+			for _, plan := range plans {
+				if !plan.Active || plan.VehicleType != vehicle.Type {
+					continue
+				}
+				s, ok := byPlan[plan.ID]
+				if !ok {
+					s = &entity.MaintenanceSchedule{
+						VehicleID:     vehicle.ID,
+						PlanID:        plan.ID,
+						LastServiceAt: now,
+						LastServiceKm: odometer,
+						Status:        entity.MaintenanceStatusOK,
+					}
+				} else if known && s.LastWorkOrderID == nil && s.CurrentKm == 0 {
+					// A vehicle that had no odometer reading when the plan started to apply is counted from its first
+					s.LastServiceKm = odometer
+				}
+				if s.Evaluate(plan, odometer, now, uc.warnKm, uc.warnDays) {
+					alerts = append(alerts, maintenanceAlert(vehicle, plan, s))
+				}
+				if err := uc.scheduleRepo.Save(ctx, s); err != nil {
+					return fmt.Errorf("maintenance schedule repository: save schedule: %w", err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			uc.publish(ctx, events...)
+			return len(events), err
+		}
+		events = append(events, alerts...)
+	}
+
+	uc.publish(ctx, events...)
+	return len(events), nil
+}
+
+// loadPlans loads every plan, retired ones included, by vehicle type and name
+func (uc *MaintenanceUseCase) loadPlans(ctx context.Context) ([]*entity.MaintenancePlan, error) {
+	var plans []*entity.MaintenancePlan
+	for offset := 0; ; offset += checkBatchSize {
+		batch, _, err := uc.planRepo.List(ctx, repository.MaintenancePlanFilter{}, checkBatchSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("maintenance plan repository: list plans: %w", err)
+		}
+		plans = append(plans, batch...)
+		if len(batch) < checkBatchSize {
+			return plans, nil
+		}
+	}
+}
+
+// publish pushes alerts to connected clients; the schedules are already saved, so failures are only logged
+func (uc *MaintenanceUseCase) publish(ctx context.Context, events ...service.RealtimeEvent) {
+	if len(events) == 0 {
+		return
+	}
+	if err := uc.publisher.Publish(ctx, events...); err != nil {
+		log.Printf("[ERROR] realtime: publish: %v", err)
+	}
+}
+
+// maintenanceAlert builds the event announcing on the vehicle's channel that a service became due or overdue
+func maintenanceAlert(vehicle *entity.Vehicle, plan *entity.MaintenancePlan, s *entity.MaintenanceSchedule) service.RealtimeEvent {
+	return service.RealtimeEvent{
+		Channels: []string{service.Channel(service.ChannelVehicle, vehicle.ID)},
+		Type:     "vehicle.maintenance_" + string(s.Status),
+		Data: map[string]interface{}{
+			"vehicle_id":   vehicle.ID,
+			"plate_number": vehicle.PlateNumber,
+			"plan_id":      plan.ID,
+			"plan_name":    plan.Name,
+			"status":       s.Status,
+			"current_km":   s.CurrentKm,
+			"next_due_km":  s.NextDueKm,
+			"next_due_at":  s.NextDueAt,
+		},
+		OccurredAt: s.CheckedAt,
+	}
+}
+
+func findPlan(plans []*entity.MaintenancePlan, id uuid.UUID) *entity.MaintenancePlan {
+	i := slices.IndexFunc(plans, func(p *entity.MaintenancePlan) bool { return p.ID == id })
+	if i < 0 {
+		return nil
+	}
+	return plans[i]
+}
+
+func toScheduleOutputs(schedules []*entity.MaintenanceSchedule, plans []*entity.MaintenancePlan) []*ScheduleOutput {
+	outputs := make([]*ScheduleOutput, len(schedules))
+	for i, s := range schedules {
+		output := &ScheduleOutput{
+			ID:              s.ID,
+			VehicleID:       s.VehicleID,
+			PlanID:          s.PlanID,
+			LastServiceAt:   s.LastServiceAt,
+			LastServiceKm:   s.LastServiceKm,
+			LastWorkOrderID: s.LastWorkOrderID,
+			CurrentKm:       s.CurrentKm,
+			NextDueAt:       s.NextDueAt,
+			NextDueKm:       s.NextDueKm,
+			Status:          s.Status,
+			CheckedAt:       s.CheckedAt,
+		}
+		if plan := findPlan(plans, s.PlanID); plan != nil {
+			output.PlanName = plan.Name
+		}
+		if s.NextDueKm != nil {
+			remaining := math.Round((*s.NextDueKm-s.CurrentKm)*10) / 10
+			output.RemainingKm = &remaining
+		}
+		outputs[i] = output
+	}
+	return outputs
+}
@@ -0,0 +1,132 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// PlanUseCase manages the preventive maintenance plans of each vehicle type
+type PlanUseCase struct {
+	planRepo repository.MaintenancePlanRepository
+}
+
+// NewPlanUseCase creates a new maintenance plan use case
+func NewPlanUseCase(planRepo repository.MaintenancePlanRepository) *PlanUseCase {
+	return &PlanUseCase{planRepo: planRepo}
+}
+
+// Create adds a plan. The scheduler starts counting vehicles of its type from their odometer when it next runs.
+func (uc *PlanUseCase) Create(ctx context.Context, input PlanInput) (*PlanOutput, error) {
+	if err := validatePlan(input); err != nil {
+		return nil, err
+	}
+
+	plan := &entity.MaintenancePlan{}
+	applyPlan(plan, input)
+	if err := uc.planRepo.Create(ctx, plan); err != nil {
+		return nil, fmt.Errorf("maintenance plan repository: create plan: %w", err)
+	}
+	return toPlanOutput(plan), nil
+}
+
+// Update replaces a plan, e.g. to change its intervals or retire it. The new intervals count from each vehicle's
+// last service; moving the plan to another vehicle type drops the schedules of vehicles it no longer applies to.
+func (uc *PlanUseCase) Update(ctx context.Context, id uuid.UUID, input PlanInput) (*PlanOutput, error) {
+	if err := validatePlan(input); err != nil {
+		return nil, err
+	}
+
+	plan, err := uc.planRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("maintenance plan repository: find by id: %w", err)
+	}
+
+	applyPlan(plan, input)
+	if err := uc.planRepo.Update(ctx, plan); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("maintenance plan repository: update plan: %w", err)
+	}
+	return toPlanOutput(plan), nil
+}
+
+// Get returns a plan by ID
+func (uc *PlanUseCase) Get(ctx context.Context, id uuid.UUID) (*PlanOutput, error) {
+	plan, err := uc.planRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("maintenance plan repository: find by id: %w", err)
+	}
+	return toPlanOutput(plan), nil
+}
+
+// List returns plans matching the input criteria
+func (uc *PlanUseCase) List(ctx context.Context, input ListPlansInput) ([]*PlanOutput, int64, error) {
+	plans, total, err := uc.planRepo.List(ctx, repository.MaintenancePlanFilter{
+		VehicleType: input.VehicleType,
+		Active:      input.Active,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("maintenance plan repository: list plans: %w", err)
+	}
+
+	outputs := make([]*PlanOutput, len(plans))
+	for i, p := range plans {
+		outputs[i] = toPlanOutput(p)
+	}
+	return outputs, total, nil
+}
+
+func validatePlan(input PlanInput) error {
+	verrs := errs.ValidationErrors{}
+	if input.IntervalKm < 0 {
+		verrs["interval_km"] = []string{"must_not_be_negative"}
+	}
+	if input.IntervalDays < 0 {
+		verrs["interval_days"] = []string{"must_not_be_negative"}
+	}
+	if input.IntervalKm == 0 && input.IntervalDays == 0 {
+		verrs["interval_km"] = []string{"required_without_interval_days"}
+	}
+	if len(verrs) > 0 {
+		return verrs
+	}
+	return nil
+}
+
+func applyPlan(plan *entity.MaintenancePlan, input PlanInput) {
+	plan.Name = strings.TrimSpace(input.Name)
+	plan.VehicleType = input.VehicleType
+	plan.IntervalKm = input.IntervalKm
+	plan.IntervalDays = input.IntervalDays
+	plan.Description = input.Description
+	plan.Active = input.Active
+}
+
+func toPlanOutput(p *entity.MaintenancePlan) *PlanOutput {
+	return &PlanOutput{
+		ID:           p.ID,
+		Name:         p.Name,
+		VehicleType:  p.VehicleType,
+		IntervalKm:   p.IntervalKm,
+		IntervalDays: p.IntervalDays,
+		Description:  p.Description,
+		Active:       p.Active,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+	}
+}
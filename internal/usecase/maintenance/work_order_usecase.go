@@ -0,0 +1,381 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// WorkOrderUseCase handles the maintenance and repair work carried out on fleet vehicles
+type WorkOrderUseCase struct {
+	workOrderRepo repository.WorkOrderRepository
+	planRepo      repository.MaintenancePlanRepository
+	scheduleRepo  repository.MaintenanceScheduleRepository
+	vehicleRepo   repository.VehicleRepository
+	expenseRepo   repository.VehicleExpenseRepository
+	numbering     service.NumberGenerator
+	transactor    repository.Transactor
+	warnKm        float64
+	warnDays      int
+}
+
+// NewWorkOrderUseCase creates a new work order use case.
+// warnKm and warnDays are those of the maintenance use case; zero uses DefaultWarnKm and DefaultWarnDays.
+func NewWorkOrderUseCase(
+	workOrderRepo repository.WorkOrderRepository,
+	planRepo repository.MaintenancePlanRepository,
+	scheduleRepo repository.MaintenanceScheduleRepository,
+	vehicleRepo repository.VehicleRepository,
+	expenseRepo repository.VehicleExpenseRepository,
+	numbering service.NumberGenerator,
+	transactor repository.Transactor,
+	warnKm float64,
+	warnDays int,
+) *WorkOrderUseCase {
+	if warnKm <= 0 {
+		warnKm = DefaultWarnKm
+	}
+	if warnDays <= 0 {
+		warnDays = DefaultWarnDays
+	}
+	return &WorkOrderUseCase{
+		workOrderRepo: workOrderRepo,
+		planRepo:      planRepo,
+		scheduleRepo:  scheduleRepo,
+		vehicleRepo:   vehicleRepo,
+		expenseRepo:   expenseRepo,
+		numbering:     numbering,
+		transactor:    transactor,
+		warnKm:        warnKm,
+		warnDays:      warnDays,
+	}
+}
+
+// Create opens a work order. A preventive work order services a plan for the vehicle's type.
+func (uc *WorkOrderUseCase) Create(ctx context.Context, input WorkOrderInput) (*WorkOrderOutput, error) {
+	if err := validateParts(input.Parts, input.LaborCost, input.OdometerKm); err != nil {
+		return nil, err
+	}
+
+	vehicle, err := uc.vehicleRepo.FindByID(ctx, input.VehicleID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("vehicle repository: find by id: %w", err)
+	}
+	switch input.Type {
+	case entity.WorkOrderPreventive:
+		if input.PlanID == nil {
+			return nil, errs.ValidationErrors{"plan_id": {"required_for_preventive"}}
+		}
+		plan, err := uc.planRepo.FindByID(ctx, *input.PlanID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return nil, errs.ValidationErrors{"plan_id": {"not_found"}}
+			}
+			return nil, fmt.Errorf("maintenance plan repository: find by id: %w", err)
+		}
+		if plan.VehicleType != vehicle.Type {
+			return nil, errs.ValidationErrors{"plan_id": {"vehicle_type_mismatch"}}
+		}
+	case entity.WorkOrderCorrective:
+		if input.PlanID != nil {
+			return nil, errs.ValidationErrors{"plan_id": {"not_allowed_for_corrective"}}
+		}
+	default:
+		return nil, errs.ValidationErrors{"type": {"invalid"}}
+	}
+
+	createdBy := input.CreatedBy
+	order := &entity.WorkOrder{
+		VehicleID:   vehicle.ID,
+		PlanID:      input.PlanID,
+		Type:        input.Type,
+		Status:      entity.WorkOrderStatusOpen,
+		Workshop:    strings.TrimSpace(input.Workshop),
+		Description: input.Description,
+		OdometerKm:  input.OdometerKm,
+		Notes:       input.Notes,
+		CreatedBy:   &createdBy,
+	}
+	if err := order.SetCosts(toParts(input.Parts), input.LaborCost); err != nil {
+		return nil, err
+	}
+
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		// work orders are the carrier's own records, so they are numbered in the default format
+		number, err := uc.numbering.Next(ctx, uuid.Nil, entity.DocumentWorkOrder, time.Now())
+		if err != nil {
+			return fmt.Errorf("number generator: next work order number: %w", err)
+		}
+		order.Number = number
+		if err := uc.workOrderRepo.Create(ctx, order); err != nil {
+			if errors.Is(err, errs.ErrConflict) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("work order repository: create work order: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toWorkOrderOutput(order), nil
+}
+
+// Update replaces the details, parts and labor of a work order that is not yet closed
+func (uc *WorkOrderUseCase) Update(ctx context.Context, id uuid.UUID, input UpdateWorkOrderInput) (*WorkOrderOutput, error) {
+	if err := validateParts(input.Parts, input.LaborCost, input.OdometerKm); err != nil {
+		return nil, err
+	}
+	return uc.change(ctx, id, func(_ context.Context, order *entity.WorkOrder) error {
+		if err := order.SetCosts(toParts(input.Parts), input.LaborCost); err != nil {
+			return err
+		}
+		order.Workshop = strings.TrimSpace(input.Workshop)
+		order.Description = input.Description
+		order.OdometerKm = input.OdometerKm
+		order.Notes = input.Notes
+		return nil
+	})
+}
+
+// Start records that the vehicle went off the road for the work
+func (uc *WorkOrderUseCase) Start(ctx context.Context, id uuid.UUID, at time.Time) (*WorkOrderOutput, error) {
+	if entity.InFuture(at, time.Now()) {
+		return nil, errs.ValidationErrors{"started_at": {"in_future"}}
+	}
+	return uc.change(ctx, id, func(_ context.Context, order *entity.WorkOrder) error {
+		return order.Start(at)
+	})
+}
+
+// Complete closes a work order when the vehicle goes back on the road. Completing a preventive work order
+// restarts the vehicle's schedule for its plan from the odometer reading, and the cost of any work order is
+// recorded as a maintenance or repair expense of the vehicle.
+func (uc *WorkOrderUseCase) Complete(ctx context.Context, id uuid.UUID, input CompleteWorkOrderInput) (*WorkOrderOutput, error) {
+	verrs := errs.ValidationErrors{}
+	if entity.InFuture(input.CompletedAt, time.Now()) {
+		verrs["completed_at"] = []string{"in_future"}
+	}
+	if input.OdometerKm < 0 {
+		verrs["odometer_km"] = []string{"must_not_be_negative"}
+	}
+	if len(verrs) > 0 {
+		return nil, verrs
+	}
+
+	return uc.change(ctx, id, func(ctx context.Context, order *entity.WorkOrder) error {
+		// The vehicle row serializes the completion with the scheduler's checks of the vehicle
+		if _, err := uc.vehicleRepo.FindByIDForUpdate(ctx, order.VehicleID); err != nil {
+			return fmt.Errorf("vehicle repository: find by id for update: %w", err)
+		}
+		odometerKm := input.OdometerKm
+		if odometerKm == 0 {
+			odometerKm = order.OdometerKm
+		}
+		if err := order.Complete(input.CompletedAt, odometerKm, input.StartedAt); err != nil {
+			return err
+		}
+		if order.Type == entity.WorkOrderPreventive && order.PlanID != nil {
+			if err := uc.restartSchedule(ctx, order); err != nil {
+				return err
+			}
+		}
+		if order.TotalCost > 0 {
+			completedBy := input.CompletedBy
+			expense := &entity.VehicleExpense{
+				VehicleID:   order.VehicleID,
+				Type:        entity.ExpenseRepair,
+				IncurredAt:  *order.CompletedAt,
+				Amount:      order.TotalCost,
+				Description: strings.TrimSpace("Work order " + order.Number + " " + order.Workshop),
+				Reference:   order.Number,
+				CreatedBy:   &completedBy,
+			}
+			if order.Type == entity.WorkOrderPreventive {
+				expense.Type = entity.ExpenseMaintenance
+			}
+			if err := uc.expenseRepo.Create(ctx, expense); err != nil {
+				return fmt.Errorf("vehicle expense repository: create expense: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// Cancel drops a work order that was not carried out
+func (uc *WorkOrderUseCase) Cancel(ctx context.Context, id uuid.UUID) (*WorkOrderOutput, error) {
+	return uc.change(ctx, id, func(_ context.Context, order *entity.WorkOrder) error {
+		return order.Cancel()
+	})
+}
+
+// Get returns a work order by ID
+func (uc *WorkOrderUseCase) Get(ctx context.Context, id uuid.UUID) (*WorkOrderOutput, error) {
+	order, err := uc.workOrderRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("work order repository: find by id: %w", err)
+	}
+	return toWorkOrderOutput(order), nil
+}
+
+// List returns work orders matching the input criteria
+func (uc *WorkOrderUseCase) List(ctx context.Context, input ListWorkOrdersInput) ([]*WorkOrderOutput, int64, error) {
+	orders, total, err := uc.workOrderRepo.List(ctx, repository.WorkOrderFilter{
+		VehicleID: input.VehicleID,
+		Status:    input.Status,
+		Type:      input.Type,
+		Search:    input.Search,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("work order repository: list work orders: %w", err)
+	}
+
+	outputs := make([]*WorkOrderOutput, len(orders))
+	for i, o := range orders {
+		outputs[i] = toWorkOrderOutput(o)
+	}
+	return outputs, total, nil
+}
+
+// change applies fn to a locked work order and saves it
+func (uc *WorkOrderUseCase) change(ctx context.Context, id uuid.UUID, fn func(context.Context, *entity.WorkOrder) error) (*WorkOrderOutput, error) {
+	var order *entity.WorkOrder
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		order, err = uc.workOrderRepo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return errs.ErrNotFound
+			}
+			return fmt.Errorf("work order repository: find by id for update: %w", err)
+		}
+		if err := fn(ctx, order); err != nil {
+			return err
+		}
+		if err := uc.workOrderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("work order repository: update work order: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toWorkOrderOutput(order), nil
+}
+
+// restartSchedule counts the vehicle's next service for the work order's plan from the completed work
+func (uc *WorkOrderUseCase) restartSchedule(ctx context.Context, order *entity.WorkOrder) error {
+	plan, err := uc.planRepo.FindByID(ctx, *order.PlanID)
+	if err != nil {
+		return fmt.Errorf("maintenance plan repository: find by id: %w", err)
+	}
+
+	schedule, err := uc.scheduleRepo.FindForUpdate(ctx, order.VehicleID, plan.ID)
+	if err != nil {
+		if !errors.Is(err, errs.ErrNotFound) {
+			return fmt.Errorf("maintenance schedule repository: find for update: %w", err)
+		}
+		schedule = &entity.MaintenanceSchedule{VehicleID: order.VehicleID, PlanID: plan.ID}
+	}
+	// An older service recorded late does not set the schedule back
+	if schedule.LastWorkOrderID != nil && order.CompletedAt.Before(schedule.LastServiceAt) {
+		return nil
+	}
+
+	schedule.Serviced(*order.CompletedAt, order.OdometerKm, order.ID)
+	schedule.Evaluate(plan, order.OdometerKm, time.Now(), uc.warnKm, uc.warnDays)
+	if err := uc.scheduleRepo.Save(ctx, schedule); err != nil {
+		return fmt.Errorf("maintenance schedule repository: save schedule: %w", err)
+	}
+	return nil
+}
+
+func validateParts(parts []PartInput, laborCost entity.Money, odometerKm float64) error {
+	verrs := errs.ValidationErrors{}
+	for i, p := range parts {
+		if strings.TrimSpace(p.Name) == "" {
+			verrs[fmt.Sprintf("parts[%d].name", i)] = []string{"required"}
+		}
+		if p.Quantity <= 0 {
+			verrs[fmt.Sprintf("parts[%d].quantity", i)] = []string{"must_be_positive"}
+		}
+		if p.UnitCost < 0 {
+			verrs[fmt.Sprintf("parts[%d].unit_cost", i)] = []string{"must_not_be_negative"}
+		}
+	}
+	if laborCost < 0 {
+		verrs["labor_cost"] = []string{"must_not_be_negative"}
+	}
+	if odometerKm < 0 {
+		verrs["odometer_km"] = []string{"must_not_be_negative"}
+	}
+	if len(verrs) > 0 {
+		return verrs
+	}
+	return nil
+}
+
+func toParts(inputs []PartInput) []entity.WorkOrderPart {
+	parts := make([]entity.WorkOrderPart, len(inputs))
+	for i, p := range inputs {
+		parts[i] = entity.WorkOrderPart{
+			PartNumber: strings.TrimSpace(p.PartNumber),
+			Name:       strings.TrimSpace(p.Name),
+			Quantity:   p.Quantity,
+			UnitCost:   p.UnitCost,
+		}
+	}
+	return parts
+}
+
+func toWorkOrderOutput(o *entity.WorkOrder) *WorkOrderOutput {
+	parts := make([]WorkOrderPartOutput, len(o.Parts))
+	for i, p := range o.Parts {
+		parts[i] = WorkOrderPartOutput{
+			Sequence:   p.Sequence,
+			PartNumber: p.PartNumber,
+			Name:       p.Name,
+			Quantity:   p.Quantity,
+			UnitCost:   p.UnitCost,
+			Amount:     p.Amount,
+		}
+	}
+
+	return &WorkOrderOutput{
+		ID:            o.ID,
+		Number:        o.Number,
+		VehicleID:     o.VehicleID,
+		PlanID:        o.PlanID,
+		Type:          o.Type,
+		Status:        o.Status,
+		Workshop:      o.Workshop,
+		Description:   o.Description,
+		OdometerKm:    o.OdometerKm,
+		Parts:         parts,
+		PartsCost:     o.PartsCost,
+		LaborCost:     o.LaborCost,
+		TotalCost:     o.TotalCost,
+		StartedAt:     o.StartedAt,
+		CompletedAt:   o.CompletedAt,
+		DowntimeHours: o.DowntimeHours,
+		Notes:         o.Notes,
+		CreatedBy:     o.CreatedBy,
+		CreatedAt:     o.CreatedAt,
+		UpdatedAt:     o.UpdatedAt,
+	}
+}
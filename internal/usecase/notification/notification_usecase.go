@@ -0,0 +1,70 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+)
+
+// dispatchBatchSize is how many notifications a dispatch run delivers per transaction
+const dispatchBatchSize = 100
+
+// NotificationUseCase delivers the notifications recorded in the outbox
+type NotificationUseCase struct {
+	notificationRepo repository.NotificationRepository
+	publisher        service.EventPublisher
+	transactor       repository.Transactor
+}
+
+// NewNotificationUseCase creates a new notification use case
+func NewNotificationUseCase(
+	notificationRepo repository.NotificationRepository,
+	publisher service.EventPublisher,
+	transactor repository.Transactor,
+) *NotificationUseCase {
+	return &NotificationUseCase{
+		notificationRepo: notificationRepo,
+		publisher:        publisher,
+		transactor:       transactor,
+	}
+}
+
+// Dispatch delivers the pending notifications that are due and returns how many were delivered.
+// Failed deliveries are retried with a growing delay until MaxDeliveryAttempts. Notifications are locked
+// while being delivered, so dispatchers on several instances never deliver the same one twice.
+// It is run by a background worker.
+func (uc *NotificationUseCase) Dispatch(ctx context.Context) (int, error) {
+	delivered := 0
+	for {
+		var batch int
+		err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+			now := time.Now()
+			due, err := uc.notificationRepo.ListDueForUpdate(ctx, now, dispatchBatchSize)
+			if err != nil {
+				return fmt.Errorf("notification repository: list due for update: %w", err)
+			}
+			batch = len(due)
+			for _, n := range due {
+				if err := uc.publisher.Publish(ctx, service.NotificationEvent(n)); err != nil {
+					n.DeliveryFailed(err, now)
+				} else {
+					n.Delivered(now)
+					delivered++
+				}
+				if err := uc.notificationRepo.Update(ctx, n); err != nil {
+					return fmt.Errorf("notification repository: update notification: %w", err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return delivered, err
+		}
+		if batch < dispatchBatchSize {
+			return delivered, nil
+		}
+	}
+}
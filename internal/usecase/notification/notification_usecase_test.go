@@ -0,0 +1,108 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/service"
+)
+
+type transactor struct{}
+
+func (transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type notificationRepo struct {
+	outbox []*entity.Notification
+}
+
+func (r *notificationRepo) Create(_ context.Context, notifications ...*entity.Notification) error {
+	for _, n := range notifications {
+		n.Status = entity.NotificationStatusPending
+		r.outbox = append(r.outbox, n)
+	}
+	return nil
+}
+
+func (r *notificationRepo) ListDueForUpdate(_ context.Context, at time.Time, limit int) ([]*entity.Notification, error) {
+	var due []*entity.Notification
+	for _, n := range r.outbox {
+		if n.Status == entity.NotificationStatusPending && !n.NextAttemptAt.After(at) && len(due) < limit {
+			locked := *n
+			due = append(due, &locked)
+		}
+	}
+	return due, nil
+}
+
+func (r *notificationRepo) Update(_ context.Context, notification *entity.Notification) error {
+	for i, n := range r.outbox {
+		if n.Type == notification.Type {
+			r.outbox[i] = notification
+		}
+	}
+	return nil
+}
+
+type publisher struct {
+	fail      map[string]bool
+	published []string
+}
+
+func (p *publisher) Publish(_ context.Context, events ...service.RealtimeEvent) error {
+	for _, e := range events {
+		if p.fail[e.Type] {
+			return errors.New("redis: connection refused")
+		}
+		p.published = append(p.published, e.Type)
+	}
+	return nil
+}
+
+func TestDispatch(t *testing.T) {
+	repo := &notificationRepo{}
+	pub := &publisher{fail: map[string]bool{"incident.reported": true}}
+	uc := NewNotificationUseCase(repo, pub, transactor{})
+
+	if err := repo.Create(context.Background(),
+		service.NewNotification(service.RealtimeEvent{Type: "compliance.document_expiring", Channels: []string{"vehicle:1"}}),
+		service.NewNotification(service.RealtimeEvent{Type: "incident.reported", Channels: []string{"user:1"}}),
+	); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	delivered, err := uc.Dispatch(context.Background())
+	if err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if delivered != 1 || len(pub.published) != 1 || pub.published[0] != "compliance.document_expiring" {
+		t.Fatalf("delivered %d, published %v; want only the reminder", delivered, pub.published)
+	}
+
+	reminder, incident := repo.outbox[0], repo.outbox[1]
+	if reminder.Status != entity.NotificationStatusDelivered || reminder.DeliveredAt == nil {
+		t.Errorf("reminder status = %s, want delivered", reminder.Status)
+	}
+	if incident.Status != entity.NotificationStatusPending || incident.Attempts != 1 || incident.LastError == "" {
+		t.Errorf("incident = %s after %d attempt(s), want pending with the error recorded", incident.Status, incident.Attempts)
+	}
+
+	// the failed notification is not retried before its delay is up
+	delivered, err = uc.Dispatch(context.Background())
+	if err != nil || delivered != 0 || incident.Attempts != 1 {
+		t.Errorf("second dispatch delivered %d with %d attempt(s), err %v; want nothing retried yet", delivered, incident.Attempts, err)
+	}
+
+	delete(pub.fail, "incident.reported")
+	incident.NextAttemptAt = time.Now().Add(-time.Second)
+	if delivered, err = uc.Dispatch(context.Background()); err != nil || delivered != 1 {
+		t.Fatalf("retry delivered %d, err %v; want 1", delivered, err)
+	}
+	if repo.outbox[1].Status != entity.NotificationStatusDelivered {
+		t.Errorf("incident status = %s after the retry, want delivered", repo.outbox[1].Status)
+	}
+}
@@ -0,0 +1,16 @@
+package numbering
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+)
+
+// FormatOutput represents the number format that applies to one document type of an organization
+type FormatOutput struct {
+	DocumentType entity.DocumentType
+	Format       string
+	Custom       bool   // the organization's own format rather than the default
+	Example      string // the first number of the format this period
+	UpdatedAt    *time.Time
+}
@@ -0,0 +1,179 @@
+package numbering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// NumberingUseCase handles organizations' document number formats
+type NumberingUseCase struct {
+	numberingRepo repository.NumberingRepository
+	orgRepo       repository.OrganizationRepository
+	generator     service.NumberGenerator
+}
+
+// NewNumberingUseCase creates a new numbering use case
+func NewNumberingUseCase(
+	numberingRepo repository.NumberingRepository,
+	orgRepo repository.OrganizationRepository,
+	generator service.NumberGenerator,
+) *NumberingUseCase {
+	return &NumberingUseCase{
+		numberingRepo: numberingRepo,
+		orgRepo:       orgRepo,
+		generator:     generator,
+	}
+}
+
+// ListFormats returns the format of every document type that applies to an organization
+func (uc *NumberingUseCase) ListFormats(ctx context.Context, organizationID uuid.UUID) ([]*FormatOutput, error) {
+	if err := uc.ensureOrganization(ctx, organizationID); err != nil {
+		return nil, err
+	}
+
+	custom, err := uc.numberingRepo.ListFormats(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("numbering repository: list formats: %w", err)
+	}
+	byType := make(map[entity.DocumentType]*entity.NumberingFormat, len(custom))
+	for _, f := range custom {
+		byType[f.DocumentType] = f
+	}
+
+	now := time.Now()
+	outputs := make([]*FormatOutput, 0, len(entity.DocumentTypes))
+	for _, docType := range entity.DocumentTypes {
+		output := &FormatOutput{DocumentType: docType, Format: uc.generator.DefaultFormat(docType)}
+		if f, ok := byType[docType]; ok {
+			output.Format = f.Format
+			output.Custom = true
+			output.UpdatedAt = &f.UpdatedAt
+		}
+		output.Example, _ = uc.generator.Preview(output.Format, now)
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// SetFormat sets an organization's own format for a document type. The organization's numbers are counted
+// apart from everyone else's, so they must begin with text that neither begins nor is begun by the numbers
+// of the default format or another organization's format. Numbers in the new format continue the
+// organization's sequence of the current period; numbers already issued keep their format.
+func (uc *NumberingUseCase) SetFormat(ctx context.Context, organizationID uuid.UUID, documentType entity.DocumentType, format string) (*FormatOutput, error) {
+	if err := validateDocumentType(documentType); err != nil {
+		return nil, err
+	}
+	if err := uc.ensureOrganization(ctx, organizationID); err != nil {
+		return nil, err
+	}
+
+	example, err := uc.generator.Preview(format, time.Now())
+	if err != nil {
+		return nil, errs.ValidationErrors{"format": {"invalid_format"}}
+	}
+	// Tracking numbers are typed in by consignees, so a check character must catch typos
+	if documentType == entity.DocumentShipment && !hasCheck(format) {
+		return nil, errs.ValidationErrors{"format": {"check_digit_required"}}
+	}
+	if err := uc.ensureOwnPrefix(ctx, organizationID, documentType, format); err != nil {
+		return nil, err
+	}
+
+	numbering := &entity.NumberingFormat{
+		OrganizationID: organizationID,
+		DocumentType:   documentType,
+		Format:         format,
+	}
+	if err := uc.numberingRepo.SaveFormat(ctx, numbering); err != nil {
+		return nil, fmt.Errorf("numbering repository: save format: %w", err)
+	}
+
+	return &FormatOutput{
+		DocumentType: documentType,
+		Format:       format,
+		Custom:       true,
+		Example:      example,
+		UpdatedAt:    &numbering.UpdatedAt,
+	}, nil
+}
+
+// DeleteFormat removes an organization's own format for a document type so the default applies again
+func (uc *NumberingUseCase) DeleteFormat(ctx context.Context, organizationID uuid.UUID, documentType entity.DocumentType) error {
+	if err := validateDocumentType(documentType); err != nil {
+		return err
+	}
+
+	if err := uc.numberingRepo.DeleteFormat(ctx, organizationID, documentType); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("numbering repository: delete format: %w", err)
+	}
+	return nil
+}
+
+func (uc *NumberingUseCase) ensureOrganization(ctx context.Context, organizationID uuid.UUID) error {
+	if _, err := uc.orgRepo.FindByID(ctx, organizationID); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("organization repository: find by id: %w", err)
+	}
+	return nil
+}
+
+// ensureOwnPrefix checks that no other sequence can issue a number in the format
+func (uc *NumberingUseCase) ensureOwnPrefix(ctx context.Context, organizationID uuid.UUID, documentType entity.DocumentType, format string) error {
+	prefix, err := uc.generator.Prefix(format)
+	if err != nil {
+		return errs.ValidationErrors{"format": {"invalid_format"}}
+	}
+	if prefix == "" {
+		return errs.ValidationErrors{"format": {"prefix_required"}}
+	}
+
+	others := []string{uc.generator.DefaultFormat(documentType)}
+	custom, err := uc.numberingRepo.ListFormatsByType(ctx, documentType)
+	if err != nil {
+		return fmt.Errorf("numbering repository: list formats: %w", err)
+	}
+	for _, f := range custom {
+		if f.OrganizationID != organizationID {
+			others = append(others, f.Format)
+		}
+	}
+	for _, other := range others {
+		taken, err := uc.generator.Prefix(other)
+		if err != nil {
+			continue
+		}
+		if strings.HasPrefix(prefix, taken) || strings.HasPrefix(taken, prefix) {
+			return errs.ValidationErrors{"format": {"prefix_taken"}}
+		}
+	}
+	return nil
+}
+
+func validateDocumentType(documentType entity.DocumentType) error {
+	for _, t := range entity.DocumentTypes {
+		if t == documentType {
+			return nil
+		}
+	}
+	return errs.ValidationErrors{"type": {"unknown_document_type"}}
+}
+
+// hasCheck reports whether a valid format ends in a check character placeholder
+func hasCheck(format string) bool {
+	return strings.HasSuffix(format, "{mod11}") || strings.HasSuffix(format, "{mod10}")
+}
@@ -0,0 +1,90 @@
+package numbering
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+type fakeOrganizationRepo struct {
+	repository.OrganizationRepository
+}
+
+func (fakeOrganizationRepo) FindByID(_ context.Context, id uuid.UUID) (*entity.Organization, error) {
+	return &entity.Organization{ID: id}, nil
+}
+
+type fakeNumberingRepo struct {
+	repository.NumberingRepository
+	formats []*entity.NumberingFormat
+}
+
+func (r *fakeNumberingRepo) ListFormatsByType(_ context.Context, documentType entity.DocumentType) ([]*entity.NumberingFormat, error) {
+	var formats []*entity.NumberingFormat
+	for _, f := range r.formats {
+		if f.DocumentType == documentType {
+			formats = append(formats, f)
+		}
+	}
+	return formats, nil
+}
+
+func (r *fakeNumberingRepo) SaveFormat(_ context.Context, format *entity.NumberingFormat) error {
+	r.formats = append(r.formats, format)
+	return nil
+}
+
+// generator knows formats only by their text up to the first placeholder
+type generator struct{ service.NumberGenerator }
+
+func (generator) DefaultFormat(entity.DocumentType) string { return "INV-{YYYY}{MM}-{seq:6}" }
+
+func (generator) Preview(format string, _ time.Time) (string, error) {
+	if !strings.Contains(format, "{seq") {
+		return "", errors.New("no sequence")
+	}
+	return format, nil
+}
+
+func (generator) Prefix(format string) (string, error) {
+	prefix, _, _ := strings.Cut(format, "{")
+	return prefix, nil
+}
+
+func TestSetFormatRequiresAPrefixOfItsOwn(t *testing.T) {
+	own, other := uuid.New(), uuid.New()
+	repo := &fakeNumberingRepo{formats: []*entity.NumberingFormat{
+		{OrganizationID: other, DocumentType: entity.DocumentInvoice, Format: "ACME-{YY}-{seq:5}"},
+		{OrganizationID: own, DocumentType: entity.DocumentInvoice, Format: "OWN-{seq}"},
+	}}
+	uc := NewNumberingUseCase(repo, fakeOrganizationRepo{}, generator{})
+
+	for format, want := range map[string]string{
+		"INV-{YYYY}{MM}-{seq:6}": "prefix_taken", // the default format
+		"INV-X{seq}":             "prefix_taken",
+		"IN{seq}":                "prefix_taken",
+		"ACME-{YY}-{seq:5}":      "prefix_taken", // another organization's format
+		"ACME{seq}":              "prefix_taken",
+		"{YYYY}-{seq}":           "prefix_required",
+		"OWN-{YY}-{seq}":         "", // replaces the organization's own format
+		"BILL-{YYYY}-{seq:5}":    "",
+	} {
+		_, err := uc.SetFormat(context.Background(), own, entity.DocumentInvoice, format)
+		var validationErrs errs.ValidationErrors
+		switch {
+		case want == "" && err != nil:
+			t.Errorf("%s: err = %v, want accepted", format, err)
+		case want != "" && (!errors.As(err, &validationErrs) || validationErrs["format"][0] != want):
+			t.Errorf("%s: err = %v, want %s", format, err, want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package organization
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationInput represents data for creating or updating an organization
+type OrganizationInput struct {
+	Name           string
+	TaxID          string
+	BranchCode     string
+	Phone          string
+	Email          string
+	CreditTermDays *int
+	WithholdsTax   bool
+}
+
+// ListOrganizationsInput represents criteria for listing organizations
+type ListOrganizationsInput struct {
+	Search string
+	Limit  int
+	Offset int
+}
+
+// OrganizationOutput represents organization output data
+type OrganizationOutput struct {
+	ID             uuid.UUID
+	Name           string
+	TaxID          string
+	BranchCode     string
+	Phone          string
+	Email          string
+	CreditTermDays *int
+	WithholdsTax   bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
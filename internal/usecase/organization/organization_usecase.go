@@ -0,0 +1,110 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// defaultBranchCode is the Revenue Department branch code of a head office
+const defaultBranchCode = "00000"
+
+// OrganizationUseCase handles organization operations
+type OrganizationUseCase struct {
+	orgRepo repository.OrganizationRepository
+}
+
+// NewOrganizationUseCase creates a new organization use case
+func NewOrganizationUseCase(orgRepo repository.OrganizationRepository) *OrganizationUseCase {
+	return &OrganizationUseCase{orgRepo: orgRepo}
+}
+
+// Create creates a new organization
+func (uc *OrganizationUseCase) Create(ctx context.Context, input OrganizationInput) (*OrganizationOutput, error) {
+	org := &entity.Organization{}
+	applyInput(org, input)
+
+	if err := uc.orgRepo.Create(ctx, org); err != nil {
+		return nil, fmt.Errorf("organization repository: create organization: %w", err)
+	}
+
+	return toOrganizationOutput(org), nil
+}
+
+// Get returns an organization by ID
+func (uc *OrganizationUseCase) Get(ctx context.Context, id uuid.UUID) (*OrganizationOutput, error) {
+	org, err := uc.orgRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("organization repository: find by id: %w", err)
+	}
+	return toOrganizationOutput(org), nil
+}
+
+// List returns organizations matching the input criteria
+func (uc *OrganizationUseCase) List(ctx context.Context, input ListOrganizationsInput) ([]*OrganizationOutput, int64, error) {
+	orgs, total, err := uc.orgRepo.List(ctx, input.Search, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("organization repository: list organizations: %w", err)
+	}
+
+	outputs := make([]*OrganizationOutput, len(orgs))
+	for i, o := range orgs {
+		outputs[i] = toOrganizationOutput(o)
+	}
+	return outputs, total, nil
+}
+
+// Update updates an organization
+func (uc *OrganizationUseCase) Update(ctx context.Context, id uuid.UUID, input OrganizationInput) (*OrganizationOutput, error) {
+	org, err := uc.orgRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("organization repository: find by id: %w", err)
+	}
+
+	applyInput(org, input)
+	if err := uc.orgRepo.Update(ctx, org); err != nil {
+		return nil, fmt.Errorf("organization repository: update organization: %w", err)
+	}
+
+	return toOrganizationOutput(org), nil
+}
+
+func applyInput(org *entity.Organization, input OrganizationInput) {
+	org.Name = input.Name
+	org.TaxID = input.TaxID
+	org.BranchCode = input.BranchCode
+	if org.BranchCode == "" {
+		org.BranchCode = defaultBranchCode
+	}
+	org.Phone = input.Phone
+	org.Email = input.Email
+	org.CreditTermDays = input.CreditTermDays
+	org.WithholdsTax = input.WithholdsTax
+}
+
+func toOrganizationOutput(o *entity.Organization) *OrganizationOutput {
+	return &OrganizationOutput{
+		ID:             o.ID,
+		Name:           o.Name,
+		TaxID:          o.TaxID,
+		BranchCode:     o.BranchCode,
+		Phone:          o.Phone,
+		Email:          o.Email,
+		CreditTermDays: o.CreditTermDays,
+		WithholdsTax:   o.WithholdsTax,
+		CreatedAt:      o.CreatedAt,
+		UpdatedAt:      o.UpdatedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package planning
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// JobStatus represents the progress of an optimization job
+type JobStatus string
+
+const (
+	JobStatusQueued    JobStatus = "queued"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+// OptimizeInput represents a routing problem to optimize.
+// A nil matrix makes the optimizer estimate travel from coordinates.
+type OptimizeInput struct {
+	Depots    []service.RoutingDepot
+	Stops     []service.RoutingStop
+	Vehicles  []service.RoutingVehicle
+	Matrix    *service.TravelMatrix
+	TimeLimit time.Duration
+}
+
+// JobOutput represents an optimization job and, once completed, its proposed plan
+type JobOutput struct {
+	ID          uuid.UUID
+	Status      JobStatus
+	TimeLimit   time.Duration
+	Error       string
+	Solution    *service.RoutingSolution
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
@@ -0,0 +1,255 @@
+package planning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"tms-core-service/internal/domain/cache"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultTimeLimit is the search time when the request does not set one
+	DefaultTimeLimit = 5 * time.Second
+	// MaxTimeLimit bounds the search time a request may ask for
+	MaxTimeLimit = 60 * time.Second
+
+	jobKeyPrefix = "planning:job:"
+	jobTTL       = 24 * time.Hour
+	// jobGrace is added to the time limit before a running job is abandoned
+	jobGrace = 30 * time.Second
+
+	// Defaults used when the worker pool is not configured
+	DefaultWorkers   = 2
+	DefaultQueueSize = 20
+)
+
+// queuedJob is an optimization job waiting for a worker
+type queuedJob struct {
+	job     JobOutput
+	problem service.RoutingProblem
+}
+
+// PlanningUseCase runs route optimization as background jobs whose state is kept in the cache.
+// Jobs are solved by a fixed number of workers; when the queue in front of them is full, new jobs are rejected.
+type PlanningUseCase struct {
+	optimizer service.RouteOptimizer
+	cacheRepo cache.CacheRepository
+	workers   int
+	queue     chan queuedJob
+}
+
+// NewPlanningUseCase creates a new planning use case; Run must be started for queued jobs to be solved
+func NewPlanningUseCase(optimizer service.RouteOptimizer, cacheRepo cache.CacheRepository, workers, queueSize int) *PlanningUseCase {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	return &PlanningUseCase{
+		optimizer: optimizer,
+		cacheRepo: cacheRepo,
+		workers:   workers,
+		queue:     make(chan queuedJob, queueSize),
+	}
+}
+
+// Run solves queued jobs with the configured number of workers until the context is cancelled.
+// Jobs still queued at that point stay queued in the cache and expire with it.
+func (uc *PlanningUseCase) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < uc.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case q := <-uc.queue:
+					uc.run(q.job, q.problem)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Optimize validates the problem and queues an optimization job.
+// It returns errs.ErrServiceBusy when the queue is full.
+func (uc *PlanningUseCase) Optimize(ctx context.Context, input OptimizeInput) (*JobOutput, error) {
+	if err := validateProblem(input); err != nil {
+		return nil, err
+	}
+
+	if input.TimeLimit <= 0 {
+		input.TimeLimit = DefaultTimeLimit
+	}
+	if input.TimeLimit > MaxTimeLimit {
+		input.TimeLimit = MaxTimeLimit
+	}
+
+	job := &JobOutput{
+		ID:        uuid.New(),
+		Status:    JobStatusQueued,
+		TimeLimit: input.TimeLimit,
+		CreatedAt: time.Now(),
+	}
+	// The job is saved before it is queued so that a worker picking it up cannot be overwritten by the queued state
+	if err := uc.saveJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	select {
+	case uc.queue <- queuedJob{job: *job, problem: service.RoutingProblem{
+		Depots:    input.Depots,
+		Stops:     input.Stops,
+		Vehicles:  input.Vehicles,
+		Matrix:    input.Matrix,
+		TimeLimit: input.TimeLimit,
+	}}:
+	default:
+		if err := uc.cacheRepo.Delete(ctx, jobKeyPrefix+job.ID.String()); err != nil {
+			log.Printf("[ERROR] planning job %s: cache repository: delete job: %v", job.ID, err)
+		}
+		return nil, errs.ErrServiceBusy
+	}
+
+	return job, nil
+}
+
+// GetJob returns an optimization job by ID
+func (uc *PlanningUseCase) GetJob(ctx context.Context, id uuid.UUID) (*JobOutput, error) {
+	data, err := uc.cacheRepo.Get(ctx, jobKeyPrefix+id.String())
+	if err != nil {
+		return nil, fmt.Errorf("cache repository: get job: %w", err)
+	}
+	if data == "" {
+		return nil, errs.ErrNotFound
+	}
+
+	var job JobOutput
+	if err := json.Unmarshal([]byte(data), &job); err != nil {
+		return nil, fmt.Errorf("decode job: %w", err)
+	}
+	return &job, nil
+}
+
+// run solves the problem outside the request lifecycle and records the outcome
+func (uc *PlanningUseCase) run(job JobOutput, problem service.RoutingProblem) {
+	ctx, cancel := context.WithTimeout(context.Background(), problem.TimeLimit+jobGrace)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			uc.finish(ctx, &job, nil, fmt.Errorf("optimizer panic: %v", r))
+		}
+	}()
+
+	startedAt := time.Now()
+	job.Status = JobStatusRunning
+	job.StartedAt = &startedAt
+	if err := uc.saveJob(ctx, &job); err != nil {
+		log.Printf("[ERROR] planning job %s: %v", job.ID, err)
+	}
+
+	solution, err := uc.optimizer.Optimize(ctx, problem)
+	uc.finish(ctx, &job, solution, err)
+}
+
+func (uc *PlanningUseCase) finish(ctx context.Context, job *JobOutput, solution *service.RoutingSolution, err error) {
+	completedAt := time.Now()
+	job.CompletedAt = &completedAt
+	if err != nil {
+		log.Printf("[ERROR] planning job %s: %v", job.ID, err)
+		job.Status = JobStatusFailed
+		job.Error = "optimization failed"
+	} else {
+		job.Status = JobStatusCompleted
+		job.Solution = solution
+	}
+
+	if err := uc.saveJob(ctx, job); err != nil {
+		log.Printf("[ERROR] planning job %s: %v", job.ID, err)
+	}
+}
+
+func (uc *PlanningUseCase) saveJob(ctx context.Context, job *JobOutput) error {
+	if err := uc.cacheRepo.Set(ctx, jobKeyPrefix+job.ID.String(), job, jobTTL); err != nil {
+		return fmt.Errorf("cache repository: save job: %w", err)
+	}
+	return nil
+}
+
+// validateProblem checks references and dimensions the request schema cannot express
+func validateProblem(input OptimizeInput) error {
+	validationErrs := make(errs.ValidationErrors)
+
+	depots := make(map[string]bool, len(input.Depots))
+	for i, d := range input.Depots {
+		if depots[d.ID] {
+			validationErrs[fmt.Sprintf("depots[%d].id", i)] = []string{"duplicate_id"}
+		}
+		depots[d.ID] = true
+	}
+
+	stops := make(map[string]bool, len(input.Stops))
+	for i, s := range input.Stops {
+		if stops[s.ID] {
+			validationErrs[fmt.Sprintf("stops[%d].id", i)] = []string{"duplicate_id"}
+		}
+		stops[s.ID] = true
+		if s.WindowStart != nil && s.WindowEnd != nil && s.WindowEnd.Before(*s.WindowStart) {
+			validationErrs[fmt.Sprintf("stops[%d].window_end", i)] = []string{"before_window_start"}
+		}
+	}
+
+	vehicles := make(map[string]bool, len(input.Vehicles))
+	for i, v := range input.Vehicles {
+		if vehicles[v.ID] {
+			validationErrs[fmt.Sprintf("vehicles[%d].id", i)] = []string{"duplicate_id"}
+		}
+		vehicles[v.ID] = true
+		if !depots[v.DepotID] {
+			validationErrs[fmt.Sprintf("vehicles[%d].depot_id", i)] = []string{"unknown_depot"}
+		}
+		if v.ShiftEnd != nil && !v.ShiftEnd.After(v.ShiftStart) {
+			validationErrs[fmt.Sprintf("vehicles[%d].shift_end", i)] = []string{"before_shift_start"}
+		}
+	}
+
+	if input.Matrix != nil {
+		nodes := len(input.Depots) + len(input.Stops)
+		if !isSquare(input.Matrix.DistancesM, nodes) {
+			validationErrs["matrix.distances_m"] = []string{"invalid_dimensions"}
+		}
+		if !isSquare(input.Matrix.DurationsS, nodes) {
+			validationErrs["matrix.durations_s"] = []string{"invalid_dimensions"}
+		}
+	}
+
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+	return nil
+}
+
+func isSquare(m [][]float64, n int) bool {
+	if len(m) != n {
+		return false
+	}
+	for _, row := range m {
+		if len(row) != n {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package planning
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/service"
+)
+
+// memoryCache is an in-process cache.CacheRepository
+type memoryCache struct {
+	mu   sync.Mutex
+	data map[string]string
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{data: make(map[string]string)}
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data[key], nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[key] = string(data)
+	return nil
+}
+
+func (c *memoryCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data, key)
+	return nil
+}
+
+func (c *memoryCache) Exists(_ context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.data[key]
+	return ok, nil
+}
+
+func (c *memoryCache) SetNX(ctx context.Context, key string, value interface{}, exp time.Duration) (bool, error) {
+	if ok, _ := c.Exists(ctx, key); ok {
+		return false, nil
+	}
+	return true, c.Set(ctx, key, value, exp)
+}
+
+func (c *memoryCache) Increment(_ context.Context, key string, exp time.Duration) (int64, time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n, _ := strconv.ParseInt(c.data[key], 10, 64)
+	n++
+	c.data[key] = strconv.FormatInt(n, 10)
+	return n, exp, nil
+}
+
+func (c *memoryCache) DeleteIfEquals(_ context.Context, key, value string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.data[key]
+	if !ok || data != value {
+		return false, nil
+	}
+	delete(c.data, key)
+	return true, nil
+}
+
+// blockingOptimizer holds every job until release is closed
+type blockingOptimizer struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (o *blockingOptimizer) Optimize(ctx context.Context, _ service.RoutingProblem) (*service.RoutingSolution, error) {
+	o.started <- struct{}{}
+	select {
+	case <-o.release:
+		return &service.RoutingSolution{}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func validInput() OptimizeInput {
+	return OptimizeInput{
+		Depots:   []service.RoutingDepot{{ID: "d"}},
+		Stops:    []service.RoutingStop{{ID: "s"}},
+		Vehicles: []service.RoutingVehicle{{ID: "v", DepotID: "d", ShiftStart: time.Now()}},
+	}
+}
+
+func waitForStatus(t *testing.T, uc *PlanningUseCase, job *JobOutput, status JobStatus) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		got, err := uc.GetJob(context.Background(), job.ID)
+		if err != nil {
+			t.Fatalf("GetJob: %v", err)
+		}
+		if got.Status == status {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s never reached %s", job.ID, status)
+}
+
+func TestOptimizeRejectsWhenQueueIsFull(t *testing.T) {
+	optimizer := &blockingOptimizer{started: make(chan struct{}, 4), release: make(chan struct{})}
+	cacheRepo := newMemoryCache()
+	uc := NewPlanningUseCase(optimizer, cacheRepo, 1, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go uc.Run(ctx)
+
+	running, err := uc.Optimize(ctx, validInput())
+	if err != nil {
+		t.Fatalf("first job: %v", err)
+	}
+	<-optimizer.started
+
+	queued, err := uc.Optimize(ctx, validInput())
+	if err != nil {
+		t.Fatalf("second job: %v", err)
+	}
+
+	if _, err := uc.Optimize(ctx, validInput()); !errors.Is(err, errs.ErrServiceBusy) {
+		t.Fatalf("third job: err = %v, want ErrServiceBusy", err)
+	}
+	if n := len(cacheRepo.data); n != 2 {
+		t.Errorf("%d jobs saved, want the 2 accepted ones", n)
+	}
+
+	close(optimizer.release)
+	waitForStatus(t, uc, running, JobStatusCompleted)
+	waitForStatus(t, uc, queued, JobStatusCompleted)
+
+	// the queue has room again
+	if _, err := uc.Optimize(ctx, validInput()); err != nil {
+		t.Fatalf("job after the queue drained: %v", err)
+	}
+}
+
+func TestOptimizeValidatesProblem(t *testing.T) {
+	uc := NewPlanningUseCase(&blockingOptimizer{}, newMemoryCache(), 1, 1)
+
+	input := validInput()
+	input.Vehicles[0].DepotID = "elsewhere"
+	var validationErrs errs.ValidationErrors
+	if _, err := uc.Optimize(context.Background(), input); !errors.As(err, &validationErrs) {
+		t.Fatalf("err = %v, want validation errors", err)
+	}
+	if _, ok := validationErrs["vehicles[0].depot_id"]; !ok {
+		t.Errorf("validation errors = %v, want vehicles[0].depot_id", validationErrs)
+	}
+}
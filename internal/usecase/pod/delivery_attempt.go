@@ -0,0 +1,248 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// validFailureReasons lists the reason codes a driver may give for a failed delivery
+var validFailureReasons = map[entity.FailureReason]bool{
+	entity.FailureNobodyHome:   true,
+	entity.FailureWrongAddress: true,
+	entity.FailureRefused:      true,
+	entity.FailureClosed:       true,
+}
+
+// AttemptUploadURL issues a presigned URL for the evidence photo of a failed delivery at a stop on the driver's trip
+func (uc *ProofOfDeliveryUseCase) AttemptUploadURL(ctx context.Context, input AttemptUploadURLInput) (*UploadURLOutput, error) {
+	trip, stop, _, err := uc.deliveryStop(ctx, input.UserID, input.TripID, input.StopID)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := uc.uploadURL(ctx, attemptKeyPrefix(trip.ID, stop.ID)+"photo-", input.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// Fail records that the delivery at a stop could not be made, marks its shipment failed and books the leg that
+// follows it up: another attempt, or a return to the sender once the configured number of attempts was made.
+// The evidence photo must have been uploaded and, unless the address was wrong, the device must have been near
+// the delivery location.
+func (uc *ProofOfDeliveryUseCase) Fail(ctx context.Context, input FailInput) (*DeliveryAttemptOutput, error) {
+	if !validFailureReasons[input.Reason] {
+		return nil, errs.ValidationErrors{"reason": {"invalid"}}
+	}
+	if entity.InFuture(input.AttemptedAt, time.Now()) {
+		return nil, errs.ValidationErrors{"attempted_at": {"in_future"}}
+	}
+
+	trip, stop, driver, err := uc.deliveryStop(ctx, input.UserID, input.TripID, input.StopID)
+	if err != nil {
+		return nil, err
+	}
+	// Deliveries are only attempted on a trip under way
+	if trip.Status != entity.TripStatusInProgress {
+		return nil, errs.ErrInvalidStatusTransition
+	}
+
+	if err := uc.checkPhoto(ctx, attemptKeyPrefix(trip.ID, stop.ID), input.PhotoKey); err != nil {
+		return nil, err
+	}
+
+	// A delivered stop cannot fail afterwards
+	if _, err := uc.podRepo.FindByStopID(ctx, stop.ID); err == nil {
+		return nil, errs.ErrConflict
+	} else if !errors.Is(err, errs.ErrNotFound) {
+		return nil, fmt.Errorf("pod repository: find by stop id: %w", err)
+	}
+
+	location, err := uc.locationRepo.FindByID(ctx, stop.LocationID)
+	if err != nil {
+		return nil, fmt.Errorf("location repository: find by id: %w", err)
+	}
+	var distance *float64
+	if location.HasCoordinates() {
+		d := uc.geometry.Distance(input.Latitude, input.Longitude, *location.Latitude, *location.Longitude)
+		if input.Reason.RequiresPresence() && d > uc.maxDistanceM {
+			return nil, fmt.Errorf("%w: %.0f m from the delivery location", errs.ErrOutOfRange, d)
+		}
+		distance = &d
+	}
+
+	attempt := &entity.DeliveryAttempt{
+		TripID:      trip.ID,
+		StopID:      stop.ID,
+		DriverID:    driver.ID,
+		Reason:      input.Reason,
+		PhotoKey:    input.PhotoKey,
+		Latitude:    input.Latitude,
+		Longitude:   input.Longitude,
+		AccuracyM:   input.AccuracyM,
+		DistanceM:   distance,
+		AttemptedAt: input.AttemptedAt,
+		Notes:       strings.TrimSpace(input.Notes),
+	}
+
+	var shipment, leg *entity.Shipment
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if shipment, err = uc.lockDeliverable(ctx, stop.ShipmentID); err != nil {
+			return err
+		}
+		leg = shipment.FollowUpLeg(uc.maxAttempts)
+		attempt.ShipmentID = shipment.ID
+		attempt.Attempt = shipment.Attempt
+		attempt.Leg = leg.Leg
+
+		number, err := uc.numbering.Next(ctx, shipment.OrganizationID, entity.DocumentShipment, time.Now())
+		if err != nil {
+			return fmt.Errorf("number generator: next tracking number: %w", err)
+		}
+		leg.TrackingNumber = number
+		// A taken tracking number aborts the transaction, so it is reported rather than retried
+		if err := uc.shipmentRepo.Create(ctx, leg); err != nil {
+			if errors.Is(err, errs.ErrConflict) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("shipment repository: create shipment: %w", err)
+		}
+		if err := uc.shipmentRepo.UpdateStatus(ctx, []uuid.UUID{shipment.ID}, entity.ShipmentStatusFailed); err != nil {
+			return fmt.Errorf("shipment repository: update status: %w", err)
+		}
+		attempt.LegID = leg.ID
+		if err := uc.attemptRepo.Create(ctx, attempt); err != nil {
+			if errors.Is(err, errs.ErrConflict) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("delivery attempt repository: create attempt: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publishFailed(ctx, shipment, attempt, leg)
+	return uc.toAttemptOutput(ctx, attempt, leg)
+}
+
+// GetAttempt returns the failed delivery recorded at a trip stop with a download URL for its photo
+func (uc *ProofOfDeliveryUseCase) GetAttempt(ctx context.Context, tripID, stopID uuid.UUID) (*DeliveryAttemptOutput, error) {
+	attempt, err := uc.attemptRepo.FindByStopID(ctx, stopID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("delivery attempt repository: find by stop id: %w", err)
+	}
+	if attempt.TripID != tripID {
+		return nil, errs.ErrNotFound
+	}
+
+	leg, err := uc.shipmentRepo.FindByID(ctx, attempt.LegID)
+	if err != nil {
+		return nil, fmt.Errorf("shipment repository: find by id: %w", err)
+	}
+	return uc.toAttemptOutput(ctx, attempt, leg)
+}
+
+// checkNoAttempt rejects a stop at which a failed delivery was already recorded
+func (uc *ProofOfDeliveryUseCase) checkNoAttempt(ctx context.Context, stopID uuid.UUID) error {
+	_, err := uc.attemptRepo.FindByStopID(ctx, stopID)
+	if err == nil {
+		return errs.ErrConflict
+	}
+	if !errors.Is(err, errs.ErrNotFound) {
+		return fmt.Errorf("delivery attempt repository: find by stop id: %w", err)
+	}
+	return nil
+}
+
+// checkPhoto confirms that the key was issued for the stop and that the file has been uploaded
+func (uc *ProofOfDeliveryUseCase) checkPhoto(ctx context.Context, prefix, key string) error {
+	if !strings.HasPrefix(key, prefix+"photo-") {
+		return errs.ValidationErrors{"photo_key": {"invalid"}}
+	}
+	ok, err := uc.storageService.ObjectExists(ctx, key)
+	if err != nil {
+		return fmt.Errorf("storage service: object exists: %w", err)
+	}
+	if !ok {
+		return errs.ValidationErrors{"photo_key": {"not_uploaded"}}
+	}
+	return nil
+}
+
+// publishFailed announces a failed delivery and its follow-up leg to connected clients;
+// the attempt is already saved, so failures are only logged
+func (uc *ProofOfDeliveryUseCase) publishFailed(ctx context.Context, shipment *entity.Shipment, a *entity.DeliveryAttempt, leg *entity.Shipment) {
+	failed := service.RealtimeEvent{
+		Channels: []string{
+			service.Channel(service.ChannelShipment, shipment.ID),
+			service.Channel(service.ChannelShipment, leg.ID),
+			service.Channel(service.ChannelOrganization, shipment.OrganizationID),
+		},
+		Type: "shipment.delivery_failed",
+		Data: map[string]interface{}{
+			"shipment_id":         shipment.ID,
+			"reference":           shipment.Reference,
+			"trip_id":             a.TripID,
+			"stop_id":             a.StopID,
+			"attempt":             a.Attempt,
+			"reason":              a.Reason,
+			"leg_id":              leg.ID,
+			"leg":                 leg.Leg,
+			"leg_tracking_number": leg.TrackingNumber,
+		},
+		OccurredAt: a.AttemptedAt,
+	}
+	if err := uc.publisher.Publish(ctx, service.ShipmentStatusChanged(shipment, entity.ShipmentStatusFailed, a.AttemptedAt), failed); err != nil {
+		log.Printf("[ERROR] realtime: publish: %v", err)
+	}
+}
+
+func (uc *ProofOfDeliveryUseCase) toAttemptOutput(ctx context.Context, a *entity.DeliveryAttempt, leg *entity.Shipment) (*DeliveryAttemptOutput, error) {
+	url, err := uc.storageService.GenerateDownloadURL(ctx, a.PhotoKey)
+	if err != nil {
+		return nil, fmt.Errorf("storage service: generate download url: %w", err)
+	}
+	return &DeliveryAttemptOutput{
+		ID:                a.ID,
+		TripID:            a.TripID,
+		StopID:            a.StopID,
+		ShipmentID:        a.ShipmentID,
+		DriverID:          a.DriverID,
+		Attempt:           a.Attempt,
+		Reason:            a.Reason,
+		PhotoKey:          a.PhotoKey,
+		PhotoURL:          url,
+		Latitude:          a.Latitude,
+		Longitude:         a.Longitude,
+		AccuracyM:         a.AccuracyM,
+		DistanceM:         a.DistanceM,
+		AttemptedAt:       a.AttemptedAt,
+		Notes:             a.Notes,
+		LegID:             a.LegID,
+		Leg:               a.Leg,
+		LegTrackingNumber: leg.TrackingNumber,
+		CreatedAt:         a.CreatedAt,
+	}, nil
+}
+
+// attemptKeyPrefix is the storage folder of a stop's failed-delivery evidence
+func attemptKeyPrefix(tripID, stopID uuid.UUID) string {
+	return fmt.Sprintf("delivery-attempts/%s/%s/", tripID, stopID)
+}
@@ -0,0 +1,120 @@
+package pod
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// UploadURLsInput represents a request for presigned URLs to upload a stop's signature and photos
+type UploadURLsInput struct {
+	UserID               uuid.UUID
+	TripID               uuid.UUID
+	StopID               uuid.UUID
+	SignatureContentType string
+	PhotoContentTypes    []string
+}
+
+// UploadURLOutput represents a presigned upload URL and the key the file will be stored under
+type UploadURLOutput struct {
+	UploadURL string
+	ObjectKey string
+}
+
+// UploadURLsOutput represents the upload URLs for a stop's signature and photos
+type UploadURLsOutput struct {
+	Signature UploadURLOutput
+	Photos    []UploadURLOutput
+}
+
+// SubmitInput represents a proof of delivery captured by the driver app.
+// The keys are those returned by UploadURLs, after the files have been uploaded.
+type SubmitInput struct {
+	UserID        uuid.UUID
+	TripID        uuid.UUID
+	StopID        uuid.UUID
+	RecipientName string
+	SignatureKey  string
+	PhotoKeys     []string
+	Latitude      float64
+	Longitude     float64
+	AccuracyM     *float64
+	CapturedAt    time.Time
+	Notes         string
+}
+
+// ProofOfDeliveryOutput represents proof-of-delivery output data with download URLs for its files
+type ProofOfDeliveryOutput struct {
+	ID            uuid.UUID
+	Number        string
+	TripID        uuid.UUID
+	StopID        uuid.UUID
+	ShipmentID    uuid.UUID
+	DriverID      uuid.UUID
+	RecipientName string
+	SignatureKey  string
+	SignatureURL  string
+	PhotoKeys     []string
+	PhotoURLs     []string
+	Latitude      float64
+	Longitude     float64
+	AccuracyM     *float64
+	DistanceM     *float64
+	CapturedAt    time.Time
+	Notes         string
+	CreatedAt     time.Time
+}
+
+// DocumentOutput represents a rendered document
+type DocumentOutput struct {
+	Filename string
+	Content  []byte
+}
+
+// AttemptUploadURLInput represents a request for a presigned URL to upload the evidence photo of a failed delivery
+type AttemptUploadURLInput struct {
+	UserID      uuid.UUID
+	TripID      uuid.UUID
+	StopID      uuid.UUID
+	ContentType string
+}
+
+// FailInput represents a failed delivery recorded by the driver app.
+// The photo key is the one returned by AttemptUploadURL, after the file has been uploaded.
+type FailInput struct {
+	UserID      uuid.UUID
+	TripID      uuid.UUID
+	StopID      uuid.UUID
+	Reason      entity.FailureReason
+	PhotoKey    string
+	Latitude    float64
+	Longitude   float64
+	AccuracyM   *float64
+	AttemptedAt time.Time
+	Notes       string
+}
+
+// DeliveryAttemptOutput represents a failed delivery with a download URL for its photo and the leg following it up
+type DeliveryAttemptOutput struct {
+	ID                uuid.UUID
+	TripID            uuid.UUID
+	StopID            uuid.UUID
+	ShipmentID        uuid.UUID
+	DriverID          uuid.UUID
+	Attempt           int
+	Reason            entity.FailureReason
+	PhotoKey          string
+	PhotoURL          string
+	Latitude          float64
+	Longitude         float64
+	AccuracyM         *float64
+	DistanceM         *float64
+	AttemptedAt       time.Time
+	Notes             string
+	LegID             uuid.UUID
+	Leg               entity.ShipmentLeg
+	LegTrackingNumber string
+	CreatedAt         time.Time
+}
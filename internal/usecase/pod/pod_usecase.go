@@ -0,0 +1,454 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultMaxDistanceM is how far from the delivery location a POD may be captured when not configured
+	DefaultMaxDistanceM = 500.0
+
+	// DefaultMaxAttempts is how many delivery attempts are made before the goods are returned when not configured
+	DefaultMaxAttempts = 3
+
+	// MaxPhotos bounds the number of delivery photos per stop
+	MaxPhotos = 10
+)
+
+// ProofOfDeliveryUseCase handles the outcome of delivery stops as captured by drivers:
+// electronic proof of delivery, or a failed attempt that books a reattempt or return leg
+type ProofOfDeliveryUseCase struct {
+	podRepo        repository.ProofOfDeliveryRepository
+	attemptRepo    repository.DeliveryAttemptRepository
+	tripRepo       repository.TripRepository
+	shipmentRepo   repository.ShipmentRepository
+	locationRepo   repository.LocationRepository
+	driverRepo     repository.DriverRepository
+	vehicleRepo    repository.VehicleRepository
+	storageService service.StorageService
+	geometry       service.Geometry
+	renderer       service.DocumentRenderer
+	publisher      service.EventPublisher
+	numbering      service.NumberGenerator
+	transactor     repository.Transactor
+	maxDistanceM   float64
+	maxAttempts    int
+}
+
+// NewProofOfDeliveryUseCase creates a new proof-of-delivery use case.
+// A POD captured more than maxDistanceM from the delivery location is rejected; zero uses DefaultMaxDistanceM.
+// After maxAttempts failed deliveries the goods are returned to the sender; zero uses DefaultMaxAttempts.
+func NewProofOfDeliveryUseCase(
+	podRepo repository.ProofOfDeliveryRepository,
+	attemptRepo repository.DeliveryAttemptRepository,
+	tripRepo repository.TripRepository,
+	shipmentRepo repository.ShipmentRepository,
+	locationRepo repository.LocationRepository,
+	driverRepo repository.DriverRepository,
+	vehicleRepo repository.VehicleRepository,
+	storageService service.StorageService,
+	geometry service.Geometry,
+	renderer service.DocumentRenderer,
+	publisher service.EventPublisher,
+	numbering service.NumberGenerator,
+	transactor repository.Transactor,
+	maxDistanceM float64,
+	maxAttempts int,
+) *ProofOfDeliveryUseCase {
+	if maxDistanceM <= 0 {
+		maxDistanceM = DefaultMaxDistanceM
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	return &ProofOfDeliveryUseCase{
+		podRepo:        podRepo,
+		attemptRepo:    attemptRepo,
+		tripRepo:       tripRepo,
+		shipmentRepo:   shipmentRepo,
+		locationRepo:   locationRepo,
+		driverRepo:     driverRepo,
+		vehicleRepo:    vehicleRepo,
+		storageService: storageService,
+		geometry:       geometry,
+		renderer:       renderer,
+		publisher:      publisher,
+		numbering:      numbering,
+		transactor:     transactor,
+		maxDistanceM:   maxDistanceM,
+		maxAttempts:    maxAttempts,
+	}
+}
+
+// UploadURLs issues presigned URLs for the signature and photos of a delivery stop on the driver's trip
+func (uc *ProofOfDeliveryUseCase) UploadURLs(ctx context.Context, input UploadURLsInput) (*UploadURLsOutput, error) {
+	if len(input.PhotoContentTypes) > MaxPhotos {
+		return nil, errs.ValidationErrors{"photo_content_types": {"too_many"}}
+	}
+
+	trip, stop, _, err := uc.deliveryStop(ctx, input.UserID, input.TripID, input.StopID)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := keyPrefix(trip.ID, stop.ID)
+	output := &UploadURLsOutput{Photos: make([]UploadURLOutput, len(input.PhotoContentTypes))}
+	output.Signature, err = uc.uploadURL(ctx, prefix+"signature-", input.SignatureContentType)
+	if err != nil {
+		return nil, err
+	}
+	for i, contentType := range input.PhotoContentTypes {
+		output.Photos[i], err = uc.uploadURL(ctx, prefix+"photo-", contentType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+// Submit records the proof of delivery for a delivery stop and marks its shipment delivered.
+// The uploaded files must exist and the device must have been near the delivery location.
+func (uc *ProofOfDeliveryUseCase) Submit(ctx context.Context, input SubmitInput) (*ProofOfDeliveryOutput, error) {
+	if len(input.PhotoKeys) > MaxPhotos {
+		return nil, errs.ValidationErrors{"photo_keys": {"too_many"}}
+	}
+	if entity.InFuture(input.CapturedAt, time.Now()) {
+		return nil, errs.ValidationErrors{"captured_at": {"in_future"}}
+	}
+
+	trip, stop, driver, err := uc.deliveryStop(ctx, input.UserID, input.TripID, input.StopID)
+	if err != nil {
+		return nil, err
+	}
+	// Deliveries are only made on a trip under way
+	if trip.Status != entity.TripStatusInProgress {
+		return nil, errs.ErrInvalidStatusTransition
+	}
+
+	if err := uc.checkUploads(ctx, keyPrefix(trip.ID, stop.ID), input.SignatureKey, input.PhotoKeys); err != nil {
+		return nil, err
+	}
+	// A stop whose delivery failed has been handed over to a follow-up leg
+	if err := uc.checkNoAttempt(ctx, stop.ID); err != nil {
+		return nil, err
+	}
+
+	location, err := uc.locationRepo.FindByID(ctx, stop.LocationID)
+	if err != nil {
+		return nil, fmt.Errorf("location repository: find by id: %w", err)
+	}
+	var distance *float64
+	if location.HasCoordinates() {
+		d := uc.geometry.Distance(input.Latitude, input.Longitude, *location.Latitude, *location.Longitude)
+		if d > uc.maxDistanceM {
+			return nil, fmt.Errorf("%w: %.0f m from the delivery location", errs.ErrOutOfRange, d)
+		}
+		distance = &d
+	}
+
+	pod := &entity.ProofOfDelivery{
+		TripID:        trip.ID,
+		StopID:        stop.ID,
+		ShipmentID:    stop.ShipmentID,
+		DriverID:      driver.ID,
+		RecipientName: strings.TrimSpace(input.RecipientName),
+		SignatureKey:  input.SignatureKey,
+		PhotoKeys:     input.PhotoKeys,
+		Latitude:      input.Latitude,
+		Longitude:     input.Longitude,
+		AccuracyM:     input.AccuracyM,
+		DistanceM:     distance,
+		CapturedAt:    input.CapturedAt,
+		Notes:         input.Notes,
+	}
+
+	var shipment *entity.Shipment
+	err = uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if shipment, err = uc.lockDeliverable(ctx, stop.ShipmentID); err != nil {
+			return err
+		}
+		number, err := uc.numbering.Next(ctx, shipment.OrganizationID, entity.DocumentProofOfDelivery, time.Now())
+		if err != nil {
+			return fmt.Errorf("number generator: next pod number: %w", err)
+		}
+		pod.Number = number
+		if err := uc.podRepo.Create(ctx, pod); err != nil {
+			if errors.Is(err, errs.ErrConflict) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("pod repository: create pod: %w", err)
+		}
+		if err := uc.shipmentRepo.UpdateStatus(ctx, []uuid.UUID{stop.ShipmentID}, entity.ShipmentStatusDelivered); err != nil {
+			return fmt.Errorf("shipment repository: update status: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publishDelivered(ctx, shipment, pod.CapturedAt)
+	return uc.toOutput(ctx, pod)
+}
+
+// lockDeliverable loads the shipment of a delivery stop and locks it, so that a delivery and a failed attempt
+// at the stop exclude each other. It returns errs.ErrConflict when the outcome of the delivery is already
+// recorded and errs.ErrInvalidStatusTransition when the shipment is not out for delivery.
+func (uc *ProofOfDeliveryUseCase) lockDeliverable(ctx context.Context, id uuid.UUID) (*entity.Shipment, error) {
+	shipment, err := uc.shipmentRepo.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("shipment repository: find by id for update: %w", err)
+	}
+	switch shipment.Status {
+	case entity.ShipmentStatusPlanned, entity.ShipmentStatusInTransit:
+		return shipment, nil
+	case entity.ShipmentStatusDelivered, entity.ShipmentStatusFailed:
+		return nil, errs.ErrConflict
+	default:
+		return nil, errs.ErrInvalidStatusTransition
+	}
+}
+
+// publishDelivered announces a delivered shipment to connected clients; the POD is already saved, so failures are only logged
+func (uc *ProofOfDeliveryUseCase) publishDelivered(ctx context.Context, shipment *entity.Shipment, at time.Time) {
+	if err := uc.publisher.Publish(ctx, service.ShipmentStatusChanged(shipment, entity.ShipmentStatusDelivered, at)); err != nil {
+		log.Printf("[ERROR] realtime: publish: %v", err)
+	}
+}
+
+// Get returns the proof of delivery of a trip stop with download URLs for its files
+func (uc *ProofOfDeliveryUseCase) Get(ctx context.Context, tripID, stopID uuid.UUID) (*ProofOfDeliveryOutput, error) {
+	pod, err := uc.findPOD(ctx, tripID, stopID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.toOutput(ctx, pod)
+}
+
+// Document renders the proof of delivery of a trip stop as a PDF
+func (uc *ProofOfDeliveryUseCase) Document(ctx context.Context, tripID, stopID uuid.UUID) (*DocumentOutput, error) {
+	pod, err := uc.findPOD(ctx, tripID, stopID)
+	if err != nil {
+		return nil, err
+	}
+
+	trip, err := uc.tripRepo.FindByID(ctx, pod.TripID)
+	if err != nil {
+		return nil, fmt.Errorf("trip repository: find by id: %w", err)
+	}
+	stop, ok := trip.Stop(pod.StopID)
+	if !ok {
+		return nil, errs.ErrNotFound
+	}
+	shipment, err := uc.shipmentRepo.FindByID(ctx, pod.ShipmentID)
+	if err != nil {
+		return nil, fmt.Errorf("shipment repository: find by id: %w", err)
+	}
+	location, err := uc.locationRepo.FindByID(ctx, stop.LocationID)
+	if err != nil {
+		return nil, fmt.Errorf("location repository: find by id: %w", err)
+	}
+	driver, err := uc.driverRepo.FindByID(ctx, pod.DriverID)
+	if err != nil {
+		return nil, fmt.Errorf("driver repository: find by id: %w", err)
+	}
+	vehicle, err := uc.vehicleRepo.FindByID(ctx, trip.VehicleID)
+	if err != nil {
+		return nil, fmt.Errorf("vehicle repository: find by id: %w", err)
+	}
+
+	doc := service.DeliveryDocument{
+		DocumentNumber:    pod.Number,
+		ShipmentReference: shipment.Reference,
+		TripNumber:        trip.Number,
+		StopSequence:      stop.Sequence,
+		LocationName:      location.Name,
+		Address:           location.FormattedAddress(),
+		VehiclePlate:      strings.TrimSpace(vehicle.PlateNumber + " " + vehicle.PlateProvince),
+		RecipientName:     pod.RecipientName,
+		CapturedAt:        pod.CapturedAt,
+		Latitude:          pod.Latitude,
+		Longitude:         pod.Longitude,
+		AccuracyM:         pod.AccuracyM,
+		DistanceM:         pod.DistanceM,
+		Notes:             pod.Notes,
+	}
+	if driver.User != nil {
+		doc.DriverName = strings.TrimSpace(driver.User.FirstName + " " + driver.User.LastName)
+	}
+
+	doc.Signature, err = uc.storageService.GetObject(ctx, pod.SignatureKey)
+	if err != nil {
+		return nil, fmt.Errorf("storage service: get signature: %w", err)
+	}
+	for _, key := range pod.PhotoKeys {
+		photo, err := uc.storageService.GetObject(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("storage service: get photo: %w", err)
+		}
+		doc.Photos = append(doc.Photos, photo)
+	}
+
+	content, err := uc.renderer.RenderProofOfDelivery(doc)
+	if err != nil {
+		return nil, fmt.Errorf("document renderer: render pod: %w", err)
+	}
+
+	reference := shipment.Reference
+	if reference == "" {
+		reference = shipment.ID.String()
+	}
+	return &DocumentOutput{
+		Filename: fmt.Sprintf("pod-%s-%d.pdf", reference, stop.Sequence),
+		Content:  content,
+	}, nil
+}
+
+// deliveryStop loads the trip and checks that the stop is one of its deliveries and that the user drives it
+func (uc *ProofOfDeliveryUseCase) deliveryStop(ctx context.Context, userID, tripID, stopID uuid.UUID) (*entity.Trip, *entity.TripStop, *entity.Driver, error) {
+	driver, err := uc.driverRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		// Users without a driver profile may not capture PODs
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, nil, nil, errs.ErrForbidden
+		}
+		return nil, nil, nil, fmt.Errorf("driver repository: find by user id: %w", err)
+	}
+
+	trip, err := uc.tripRepo.FindByID(ctx, tripID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, nil, nil, errs.ErrNotFound
+		}
+		return nil, nil, nil, fmt.Errorf("trip repository: find by id: %w", err)
+	}
+	if !trip.IsDrivenBy(driver.ID) {
+		return nil, nil, nil, errs.ErrForbidden
+	}
+
+	stop, ok := trip.Stop(stopID)
+	if !ok {
+		return nil, nil, nil, errs.ErrNotFound
+	}
+	if stop.Type != entity.StopTypeDelivery {
+		return nil, nil, nil, errs.ValidationErrors{"stop_id": {"not_delivery_stop"}}
+	}
+	return trip, stop, driver, nil
+}
+
+func (uc *ProofOfDeliveryUseCase) uploadURL(ctx context.Context, prefix, contentType string) (UploadURLOutput, error) {
+	url, key, err := service.ImageUploadURL(ctx, uc.storageService, prefix, contentType, "content_type")
+	if err != nil {
+		return UploadURLOutput{}, err
+	}
+	return UploadURLOutput{UploadURL: url, ObjectKey: key}, nil
+}
+
+// checkUploads confirms that the keys were issued for the stop and that the files have been uploaded
+func (uc *ProofOfDeliveryUseCase) checkUploads(ctx context.Context, prefix, signatureKey string, photoKeys []string) error {
+	verrs := errs.ValidationErrors{}
+	if !strings.HasPrefix(signatureKey, prefix+"signature-") {
+		verrs["signature_key"] = []string{"invalid"}
+	}
+	seen := make(map[string]bool, len(photoKeys))
+	for _, key := range photoKeys {
+		if !strings.HasPrefix(key, prefix+"photo-") || seen[key] {
+			verrs["photo_keys"] = []string{"invalid"}
+		}
+		seen[key] = true
+	}
+	if len(verrs) > 0 {
+		return verrs
+	}
+
+	check := func(field, key string) error {
+		ok, err := uc.storageService.ObjectExists(ctx, key)
+		if err != nil {
+			return fmt.Errorf("storage service: object exists: %w", err)
+		}
+		if !ok {
+			verrs[field] = []string{"not_uploaded"}
+		}
+		return nil
+	}
+	if err := check("signature_key", signatureKey); err != nil {
+		return err
+	}
+	for _, key := range photoKeys {
+		if err := check("photo_keys", key); err != nil {
+			return err
+		}
+	}
+	if len(verrs) > 0 {
+		return verrs
+	}
+	return nil
+}
+
+// findPOD loads the proof of delivery of a stop, reporting stops of other trips as not found
+func (uc *ProofOfDeliveryUseCase) findPOD(ctx context.Context, tripID, stopID uuid.UUID) (*entity.ProofOfDelivery, error) {
+	pod, err := uc.podRepo.FindByStopID(ctx, stopID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("pod repository: find by stop id: %w", err)
+	}
+	if pod.TripID != tripID {
+		return nil, errs.ErrNotFound
+	}
+	return pod, nil
+}
+
+func (uc *ProofOfDeliveryUseCase) toOutput(ctx context.Context, p *entity.ProofOfDelivery) (*ProofOfDeliveryOutput, error) {
+	output := &ProofOfDeliveryOutput{
+		ID:            p.ID,
+		Number:        p.Number,
+		TripID:        p.TripID,
+		StopID:        p.StopID,
+		ShipmentID:    p.ShipmentID,
+		DriverID:      p.DriverID,
+		RecipientName: p.RecipientName,
+		SignatureKey:  p.SignatureKey,
+		PhotoKeys:     p.PhotoKeys,
+		PhotoURLs:     make([]string, len(p.PhotoKeys)),
+		Latitude:      p.Latitude,
+		Longitude:     p.Longitude,
+		AccuracyM:     p.AccuracyM,
+		DistanceM:     p.DistanceM,
+		CapturedAt:    p.CapturedAt,
+		Notes:         p.Notes,
+		CreatedAt:     p.CreatedAt,
+	}
+
+	var err error
+	output.SignatureURL, err = uc.storageService.GenerateDownloadURL(ctx, p.SignatureKey)
+	if err != nil {
+		return nil, fmt.Errorf("storage service: generate download url: %w", err)
+	}
+	for i, key := range p.PhotoKeys {
+		output.PhotoURLs[i], err = uc.storageService.GenerateDownloadURL(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("storage service: generate download url: %w", err)
+		}
+	}
+	return output, nil
+}
+
+// keyPrefix is the storage folder of a stop's POD files
+func keyPrefix(tripID, stopID uuid.UUID) string {
+	return fmt.Sprintf("pods/%s/%s/", tripID, stopID)
+}
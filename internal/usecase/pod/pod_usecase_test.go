@@ -0,0 +1,207 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// store stands in for the database: a row lock on the shipment is held until the transaction that took it ends
+type store struct {
+	rowLock  sync.Mutex
+	mu       sync.Mutex
+	shipment entity.Shipment
+	pods     int
+	attempts int
+	// checked holds back both outcomes until each has passed the checks made before its transaction
+	checked sync.WaitGroup
+}
+
+type txKey struct{}
+
+type tx struct{ locked bool }
+
+type transactor struct{ store *store }
+
+func (t transactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx := &tx{}
+	err := fn(context.WithValue(ctx, txKey{}, tx))
+	if tx.locked {
+		t.store.rowLock.Unlock()
+	}
+	return err
+}
+
+type shipmentRepo struct {
+	repository.ShipmentRepository
+	store *store
+}
+
+func (r shipmentRepo) FindByIDForUpdate(ctx context.Context, _ uuid.UUID) (*entity.Shipment, error) {
+	tx := ctx.Value(txKey{}).(*tx)
+	if !tx.locked {
+		r.store.rowLock.Lock()
+		tx.locked = true
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	shipment := r.store.shipment
+	return &shipment, nil
+}
+
+func (r shipmentRepo) Create(_ context.Context, shipment *entity.Shipment) error {
+	shipment.ID = uuid.New()
+	return nil
+}
+
+func (r shipmentRepo) UpdateStatus(_ context.Context, _ []uuid.UUID, status entity.ShipmentStatus) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.shipment.Status = status
+	return nil
+}
+
+type podRepo struct {
+	repository.ProofOfDeliveryRepository
+	store *store
+}
+
+func (podRepo) FindByStopID(context.Context, uuid.UUID) (*entity.ProofOfDelivery, error) {
+	return nil, errs.ErrNotFound
+}
+
+func (r podRepo) Create(context.Context, *entity.ProofOfDelivery) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.pods++
+	return nil
+}
+
+type attemptRepo struct {
+	repository.DeliveryAttemptRepository
+	store *store
+}
+
+func (attemptRepo) FindByStopID(context.Context, uuid.UUID) (*entity.DeliveryAttempt, error) {
+	return nil, errs.ErrNotFound
+}
+
+func (r attemptRepo) Create(context.Context, *entity.DeliveryAttempt) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.attempts++
+	return nil
+}
+
+type locationRepo struct {
+	repository.LocationRepository
+	store *store
+}
+
+func (r locationRepo) FindByID(context.Context, uuid.UUID) (*entity.Location, error) {
+	r.store.checked.Done()
+	r.store.checked.Wait()
+	return &entity.Location{}, nil
+}
+
+type tripRepo struct {
+	repository.TripRepository
+	trip *entity.Trip
+}
+
+func (r tripRepo) FindByID(context.Context, uuid.UUID) (*entity.Trip, error) {
+	return r.trip, nil
+}
+
+type driverRepo struct {
+	repository.DriverRepository
+	driver *entity.Driver
+}
+
+func (r driverRepo) FindByUserID(context.Context, uuid.UUID) (*entity.Driver, error) {
+	return r.driver, nil
+}
+
+type storage struct{ service.StorageService }
+
+func (storage) ObjectExists(context.Context, string) (bool, error) { return true, nil }
+
+func (storage) GenerateDownloadURL(_ context.Context, key string) (string, error) {
+	return "https://files.example.com/" + key, nil
+}
+
+type numbering struct{ service.NumberGenerator }
+
+func (numbering) Next(context.Context, uuid.UUID, entity.DocumentType, time.Time) (string, error) {
+	return "POD-2610-00001", nil
+}
+
+type publisher struct{}
+
+func (publisher) Publish(context.Context, ...service.RealtimeEvent) error { return nil }
+
+func TestDeliveryAndFailedAttemptExcludeEachOther(t *testing.T) {
+	driver := &entity.Driver{ID: uuid.New()}
+	shipmentID := uuid.New()
+	stop := entity.TripStop{ID: uuid.New(), Type: entity.StopTypeDelivery, ShipmentID: shipmentID}
+	trip := &entity.Trip{ID: uuid.New(), DriverID: driver.ID, Status: entity.TripStatusInProgress, Stops: []entity.TripStop{stop}}
+	s := &store{shipment: entity.Shipment{ID: shipmentID, Status: entity.ShipmentStatusInTransit, Attempt: 1}}
+	s.checked.Add(2)
+
+	uc := NewProofOfDeliveryUseCase(podRepo{store: s}, attemptRepo{store: s}, tripRepo{trip: trip}, shipmentRepo{store: s},
+		locationRepo{store: s}, driverRepo{driver: driver}, nil, storage{}, nil, nil, publisher{}, numbering{},
+		transactor{store: s}, 0, 0)
+
+	now := time.Now()
+	var submitErr, failErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, submitErr = uc.Submit(context.Background(), SubmitInput{
+			TripID:       trip.ID,
+			StopID:       stop.ID,
+			SignatureKey: keyPrefix(trip.ID, stop.ID) + "signature-1.png",
+			CapturedAt:   now,
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		_, failErr = uc.Fail(context.Background(), FailInput{
+			TripID:      trip.ID,
+			StopID:      stop.ID,
+			Reason:      entity.FailureNobodyHome,
+			PhotoKey:    attemptKeyPrefix(trip.ID, stop.ID) + "photo-1.jpg",
+			AttemptedAt: now,
+		})
+	}()
+	wg.Wait()
+
+	if (submitErr == nil) == (failErr == nil) {
+		t.Fatalf("submit err = %v, fail err = %v; want exactly one outcome recorded", submitErr, failErr)
+	}
+	for _, err := range []error{submitErr, failErr} {
+		if err != nil && !errors.Is(err, errs.ErrConflict) {
+			t.Errorf("err = %v, want ErrConflict", err)
+		}
+	}
+	if s.pods+s.attempts != 1 {
+		t.Errorf("%d POD(s) and %d attempt(s) recorded, want one of them", s.pods, s.attempts)
+	}
+	want := entity.ShipmentStatusDelivered
+	if failErr == nil {
+		want = entity.ShipmentStatusFailed
+	}
+	if s.shipment.Status != want {
+		t.Errorf("shipment status = %s, want %s", s.shipment.Status, want)
+	}
+}
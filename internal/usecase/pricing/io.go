@@ -0,0 +1,103 @@
+package pricing
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// RateCardInput represents a new rate card version
+type RateCardInput struct {
+	OrganizationID   *uuid.UUID
+	Name             string
+	Currency         string
+	EffectiveFrom    time.Time
+	EffectiveTo      *time.Time
+	VolumetricFactor float64
+	Zones            []entity.RateZone
+	Lanes            []entity.RateLane
+	Fuel             *entity.FuelSurcharge
+	Accessorials     []entity.AccessorialRate
+}
+
+// ListRateCardsInput represents criteria for listing rate cards
+type ListRateCardsInput struct {
+	OrganizationID *uuid.UUID
+	StandardOnly   bool
+	EffectiveAt    *time.Time
+	Search         string
+	Limit          int
+	Offset         int
+}
+
+// RateCardOutput represents rate card output data
+type RateCardOutput struct {
+	ID               uuid.UUID
+	OrganizationID   *uuid.UUID
+	Name             string
+	Version          int
+	Currency         string
+	EffectiveFrom    time.Time
+	EffectiveTo      *time.Time
+	VolumetricFactor float64
+	Zones            []entity.RateZone
+	Lanes            []entity.RateLane
+	Fuel             *entity.FuelSurcharge
+	Accessorials     []entity.AccessorialRate
+	CreatedAt        time.Time
+}
+
+// DieselPriceInput represents a reference diesel price from a date on
+type DieselPriceInput struct {
+	EffectiveDate time.Time
+	PricePerLitre entity.Money
+}
+
+// DieselPriceOutput represents diesel price output data
+type DieselPriceOutput struct {
+	ID            uuid.UUID
+	EffectiveDate time.Time
+	PricePerLitre entity.Money
+	CreatedAt     time.Time
+}
+
+// PriceShipmentInput represents options for quoting a booked shipment
+type PriceShipmentInput struct {
+	VehicleType  entity.VehicleType
+	DistanceKm   *float64 // estimated from the locations' coordinates when nil
+	Accessorials []entity.AccessorialQuantity
+	PricedAt     *time.Time // defaults to the pickup window start, or now
+}
+
+// AddressInput represents one end of a simulated lane
+type AddressInput struct {
+	Province  string
+	Postcode  string
+	Latitude  *float64
+	Longitude *float64
+}
+
+// SimulateInput represents a what-if quote for sales
+type SimulateInput struct {
+	RateCardID     *uuid.UUID // price against this version, even if not yet effective
+	OrganizationID *uuid.UUID // otherwise the customer's effective card, then the standard tariff
+	Origin         AddressInput
+	Destination    AddressInput
+	VehicleType    entity.VehicleType
+	DistanceKm     *float64
+	Load           entity.Load
+	Accessorials   []entity.AccessorialQuantity
+	PricedAt       *time.Time
+}
+
+// QuoteOutput represents a line-itemized quote
+type QuoteOutput struct {
+	entity.Quote
+	ShipmentID        *uuid.UUID
+	DistanceKm        float64
+	DistanceEstimated bool
+	DieselPrice       *entity.Money
+	PricedAt          time.Time
+}
@@ -0,0 +1,219 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// PricingUseCase quotes shipments against rate cards
+type PricingUseCase struct {
+	rateCardRepo repository.RateCardRepository
+	dieselRepo   repository.DieselPriceRepository
+	shipmentRepo repository.ShipmentRepository
+	locationRepo repository.LocationRepository
+	estimator    service.TravelEstimator
+}
+
+// NewPricingUseCase creates a new pricing use case
+func NewPricingUseCase(
+	rateCardRepo repository.RateCardRepository,
+	dieselRepo repository.DieselPriceRepository,
+	shipmentRepo repository.ShipmentRepository,
+	locationRepo repository.LocationRepository,
+	estimator service.TravelEstimator,
+) *PricingUseCase {
+	return &PricingUseCase{
+		rateCardRepo: rateCardRepo,
+		dieselRepo:   dieselRepo,
+		shipmentRepo: shipmentRepo,
+		locationRepo: locationRepo,
+		estimator:    estimator,
+	}
+}
+
+// PriceShipment quotes a booked shipment against its customer's rate card effective at the pricing
+// date, falling back to the standard tariff
+func (uc *PricingUseCase) PriceShipment(ctx context.Context, shipmentID uuid.UUID, input PriceShipmentInput) (*QuoteOutput, error) {
+	shipment, err := uc.shipmentRepo.FindByID(ctx, shipmentID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("shipment repository: find by id: %w", err)
+	}
+
+	return uc.priceShipment(ctx, shipment, input)
+}
+
+// Charge prices a delivered shipment for billing with the vehicle type that carried it.
+// The distance is estimated from the locations' coordinates.
+func (uc *PricingUseCase) Charge(ctx context.Context, shipment *entity.Shipment, vehicleType entity.VehicleType) (*entity.Quote, error) {
+	output, err := uc.priceShipment(ctx, shipment, PriceShipmentInput{VehicleType: vehicleType})
+	if err != nil {
+		return nil, err
+	}
+	return &output.Quote, nil
+}
+
+// priceShipment quotes a shipment between its saved locations
+func (uc *PricingUseCase) priceShipment(ctx context.Context, shipment *entity.Shipment, input PriceShipmentInput) (*QuoteOutput, error) {
+	pickup, err := uc.findLocation(ctx, shipment.PickupLocationID)
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := uc.findLocation(ctx, shipment.DeliveryLocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	pricedAt := time.Now()
+	switch {
+	case input.PricedAt != nil:
+		pricedAt = *input.PricedAt
+	case shipment.PickupFrom != nil:
+		pricedAt = *shipment.PickupFrom
+	}
+
+	output, err := uc.quote(ctx, nil, &shipment.OrganizationID, pricedAt, input.DistanceKm,
+		toAddress(pickup), toAddress(delivery), entity.PricingRequest{
+			OriginProvince:      pickup.Province,
+			OriginPostcode:      pickup.Postcode,
+			DestinationProvince: delivery.Province,
+			DestinationPostcode: delivery.Postcode,
+			VehicleType:         input.VehicleType,
+			Load:                shipment.Load(),
+			Accessorials:        input.Accessorials,
+		})
+	if err != nil {
+		return nil, err
+	}
+	output.ShipmentID = &shipment.ID
+	return output, nil
+}
+
+// Simulate quotes a hypothetical shipment, optionally against a specific (even future) rate card version
+func (uc *PricingUseCase) Simulate(ctx context.Context, input SimulateInput) (*QuoteOutput, error) {
+	pricedAt := time.Now()
+	if input.PricedAt != nil {
+		pricedAt = *input.PricedAt
+	}
+
+	return uc.quote(ctx, input.RateCardID, input.OrganizationID, pricedAt, input.DistanceKm,
+		input.Origin, input.Destination, entity.PricingRequest{
+			OriginProvince:      input.Origin.Province,
+			OriginPostcode:      input.Origin.Postcode,
+			DestinationProvince: input.Destination.Province,
+			DestinationPostcode: input.Destination.Postcode,
+			VehicleType:         input.VehicleType,
+			Load:                input.Load,
+			Accessorials:        input.Accessorials,
+		})
+}
+
+// quote resolves the card, distance and diesel price, then prices the request
+func (uc *PricingUseCase) quote(
+	ctx context.Context,
+	rateCardID, organizationID *uuid.UUID,
+	pricedAt time.Time,
+	distanceKm *float64,
+	origin, destination AddressInput,
+	req entity.PricingRequest,
+) (*QuoteOutput, error) {
+	card, err := uc.resolveCard(ctx, rateCardID, organizationID, pricedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &QuoteOutput{PricedAt: pricedAt}
+	if distanceKm != nil {
+		output.DistanceKm = *distanceKm
+	} else {
+		if origin.Latitude == nil || origin.Longitude == nil || destination.Latitude == nil || destination.Longitude == nil {
+			return nil, errs.ValidationErrors{"distance_km": {"required_without_coordinates"}}
+		}
+		meters, _, err := uc.estimator.Estimate(ctx, *origin.Latitude, *origin.Longitude, *destination.Latitude, *destination.Longitude)
+		if err != nil {
+			return nil, fmt.Errorf("travel estimator: estimate: %w", err)
+		}
+		output.DistanceKm = math.Round(meters/100) / 10 // 0.1 km
+		output.DistanceEstimated = true
+	}
+	req.DistanceKm = output.DistanceKm
+
+	var diesel *entity.DieselPrice
+	if card.Fuel != nil {
+		diesel, err = uc.dieselRepo.FindEffective(ctx, pricedAt)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return nil, fmt.Errorf("%w: no diesel price on or before %s", errs.ErrNoApplicableRate, pricedAt.Format("2006-01-02"))
+			}
+			return nil, fmt.Errorf("diesel price repository: find effective: %w", err)
+		}
+		output.DieselPrice = &diesel.PricePerLitre
+	}
+
+	quote, err := card.Price(req, diesel)
+	if err != nil {
+		return nil, err
+	}
+	output.Quote = *quote
+	return output, nil
+}
+
+// resolveCard picks the requested version, else the organization's effective card, else the standard tariff
+func (uc *PricingUseCase) resolveCard(ctx context.Context, rateCardID, organizationID *uuid.UUID, at time.Time) (*entity.RateCard, error) {
+	if rateCardID != nil {
+		card, err := uc.rateCardRepo.FindByID(ctx, *rateCardID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return nil, errs.ErrNotFound
+			}
+			return nil, fmt.Errorf("rate card repository: find by id: %w", err)
+		}
+		return card, nil
+	}
+
+	if organizationID != nil {
+		card, err := uc.rateCardRepo.FindEffective(ctx, organizationID, at)
+		if err == nil {
+			return card, nil
+		}
+		if !errors.Is(err, errs.ErrNotFound) {
+			return nil, fmt.Errorf("rate card repository: find effective: %w", err)
+		}
+	}
+
+	card, err := uc.rateCardRepo.FindEffective(ctx, nil, at)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, fmt.Errorf("%w: no rate card effective at %s", errs.ErrNoApplicableRate, at.Format(time.RFC3339))
+		}
+		return nil, fmt.Errorf("rate card repository: find effective: %w", err)
+	}
+	return card, nil
+}
+
+func (uc *PricingUseCase) findLocation(ctx context.Context, id uuid.UUID) (*entity.Location, error) {
+	location, err := uc.locationRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("location repository: find by id: %w", err)
+	}
+	return location, nil
+}
+
+func toAddress(l *entity.Location) AddressInput {
+	return AddressInput{Province: l.Province, Postcode: l.Postcode, Latitude: l.Latitude, Longitude: l.Longitude}
+}
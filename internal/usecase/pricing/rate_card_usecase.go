@@ -0,0 +1,216 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// RateCardUseCase manages versioned rate cards and the diesel price table
+type RateCardUseCase struct {
+	rateCardRepo repository.RateCardRepository
+	dieselRepo   repository.DieselPriceRepository
+	orgRepo      repository.OrganizationRepository
+}
+
+// NewRateCardUseCase creates a new rate card use case
+func NewRateCardUseCase(
+	rateCardRepo repository.RateCardRepository,
+	dieselRepo repository.DieselPriceRepository,
+	orgRepo repository.OrganizationRepository,
+) *RateCardUseCase {
+	return &RateCardUseCase{
+		rateCardRepo: rateCardRepo,
+		dieselRepo:   dieselRepo,
+		orgRepo:      orgRepo,
+	}
+}
+
+// Create publishes a new version of a rate card. The version number follows the latest one
+// with the same name for the same organization.
+func (uc *RateCardUseCase) Create(ctx context.Context, input RateCardInput) (*RateCardOutput, error) {
+	if input.OrganizationID != nil {
+		if _, err := uc.orgRepo.FindByID(ctx, *input.OrganizationID); err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return nil, errs.ErrNotFound
+			}
+			return nil, fmt.Errorf("organization repository: find by id: %w", err)
+		}
+	}
+
+	if err := validateRules(input); err != nil {
+		return nil, err
+	}
+
+	latest, err := uc.rateCardRepo.LatestVersion(ctx, input.OrganizationID, input.Name)
+	if err != nil {
+		return nil, fmt.Errorf("rate card repository: latest version: %w", err)
+	}
+
+	card := &entity.RateCard{
+		OrganizationID:   input.OrganizationID,
+		Name:             input.Name,
+		Version:          latest + 1,
+		Currency:         input.Currency,
+		EffectiveFrom:    input.EffectiveFrom,
+		EffectiveTo:      input.EffectiveTo,
+		VolumetricFactor: input.VolumetricFactor,
+		Zones:            input.Zones,
+		Lanes:            input.Lanes,
+		Fuel:             input.Fuel,
+		Accessorials:     input.Accessorials,
+	}
+	if card.Currency == "" {
+		card.Currency = "THB"
+	}
+
+	if err := uc.rateCardRepo.Create(ctx, card); err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			return nil, errs.ErrConflict
+		}
+		return nil, fmt.Errorf("rate card repository: create rate card: %w", err)
+	}
+
+	return toRateCardOutput(card), nil
+}
+
+// Get returns a rate card version by ID
+func (uc *RateCardUseCase) Get(ctx context.Context, id uuid.UUID) (*RateCardOutput, error) {
+	card, err := uc.rateCardRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("rate card repository: find by id: %w", err)
+	}
+	return toRateCardOutput(card), nil
+}
+
+// List returns rate cards matching the input criteria
+func (uc *RateCardUseCase) List(ctx context.Context, input ListRateCardsInput) ([]*RateCardOutput, int64, error) {
+	cards, total, err := uc.rateCardRepo.List(ctx, repository.RateCardFilter{
+		OrganizationID: input.OrganizationID,
+		StandardOnly:   input.StandardOnly,
+		EffectiveAt:    input.EffectiveAt,
+		Search:         input.Search,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("rate card repository: list rate cards: %w", err)
+	}
+
+	outputs := make([]*RateCardOutput, len(cards))
+	for i, c := range cards {
+		outputs[i] = toRateCardOutput(c)
+	}
+	return outputs, total, nil
+}
+
+// CreateDieselPrice records the reference diesel price from a date on
+func (uc *RateCardUseCase) CreateDieselPrice(ctx context.Context, input DieselPriceInput) (*DieselPriceOutput, error) {
+	price := &entity.DieselPrice{
+		EffectiveDate: input.EffectiveDate,
+		PricePerLitre: input.PricePerLitre,
+	}
+	if err := uc.dieselRepo.Create(ctx, price); err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			return nil, errs.ErrConflict
+		}
+		return nil, fmt.Errorf("diesel price repository: create diesel price: %w", err)
+	}
+	return toDieselPriceOutput(price), nil
+}
+
+// ListDieselPrices returns the diesel price table, newest first
+func (uc *RateCardUseCase) ListDieselPrices(ctx context.Context, limit, offset int) ([]*DieselPriceOutput, int64, error) {
+	prices, total, err := uc.dieselRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("diesel price repository: list diesel prices: %w", err)
+	}
+
+	outputs := make([]*DieselPriceOutput, len(prices))
+	for i, p := range prices {
+		outputs[i] = toDieselPriceOutput(p)
+	}
+	return outputs, total, nil
+}
+
+// validateRules checks references between zones, lanes and accessorials
+func validateRules(input RateCardInput) error {
+	validationErrs := make(errs.ValidationErrors)
+
+	if input.EffectiveTo != nil && !input.EffectiveTo.After(input.EffectiveFrom) {
+		validationErrs["effective_to"] = []string{"before_effective_from"}
+	}
+
+	zones := map[string]bool{entity.AnyZone: true}
+	for i, z := range input.Zones {
+		if zones[z.Code] {
+			validationErrs[fmt.Sprintf("zones[%d].code", i)] = []string{"duplicate_code"}
+		}
+		zones[z.Code] = true
+	}
+
+	for i, l := range input.Lanes {
+		if !zones[l.OriginZone] {
+			validationErrs[fmt.Sprintf("lanes[%d].origin_zone", i)] = []string{"unknown_zone"}
+		}
+		if !zones[l.DestinationZone] {
+			validationErrs[fmt.Sprintf("lanes[%d].destination_zone", i)] = []string{"unknown_zone"}
+		}
+		unbounded := 0
+		for _, b := range l.DistanceBands {
+			if b.UpToKm == 0 {
+				unbounded++
+			}
+		}
+		if unbounded > 1 {
+			validationErrs[fmt.Sprintf("lanes[%d].distance_bands", i)] = []string{"multiple_unbounded_bands"}
+		}
+	}
+
+	codes := make(map[string]bool, len(input.Accessorials))
+	for i, a := range input.Accessorials {
+		if codes[a.Code] {
+			validationErrs[fmt.Sprintf("accessorials[%d].code", i)] = []string{"duplicate_code"}
+		}
+		codes[a.Code] = true
+	}
+
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+	return nil
+}
+
+func toRateCardOutput(c *entity.RateCard) *RateCardOutput {
+	return &RateCardOutput{
+		ID:               c.ID,
+		OrganizationID:   c.OrganizationID,
+		Name:             c.Name,
+		Version:          c.Version,
+		Currency:         c.Currency,
+		EffectiveFrom:    c.EffectiveFrom,
+		EffectiveTo:      c.EffectiveTo,
+		VolumetricFactor: c.VolumetricFactor,
+		Zones:            c.Zones,
+		Lanes:            c.Lanes,
+		Fuel:             c.Fuel,
+		Accessorials:     c.Accessorials,
+		CreatedAt:        c.CreatedAt,
+	}
+}
+
+func toDieselPriceOutput(p *entity.DieselPrice) *DieselPriceOutput {
+	return &DieselPriceOutput{
+		ID:            p.ID,
+		EffectiveDate: p.EffectiveDate,
+		PricePerLitre: p.PricePerLitre,
+		CreatedAt:     p.CreatedAt,
+	}
+}
@@ -0,0 +1,53 @@
+package publictracking
+
+import "time"
+
+// TrackInput represents a consignee's request for a tracking page.
+// Postcode or PhoneSuffix answer the challenge when one is required.
+type TrackInput struct {
+	TrackingNumber string
+	Postcode       string
+	PhoneSuffix    string // last four digits of the recipient's phone number
+}
+
+// TrackingEventOutput represents one milestone on the tracking page
+type TrackingEventOutput struct {
+	Code       string // order_received, scheduled, picked_up, in_transit, delayed, arrived_at_destination, delivered, delivery_failed, returned_to_sender, cancelled
+	OccurredAt time.Time
+	District   string
+	Province   string
+}
+
+// TrackingPageOutput represents what a consignee may see of a shipment, followed through the reattempt and
+// return legs booked after failed deliveries. It names no people other than the masked recipient and no exact
+// addresses or positions. Reschedulable is set while the next attempt awaits a new date from the consignee.
+type TrackingPageOutput struct {
+	TrackingNumber string
+	Status         string // order_received, scheduled, in_transit, at_destination, delivered, delivery_failed, returning_to_sender, returned_to_sender, cancelled
+	Recipient      string // masked, e.g. "S****** J*****"
+	District       string
+	Province       string
+	ETAFrom        *time.Time
+	ETATo          *time.Time
+	DeliveredAt    *time.Time
+	Reschedulable  bool
+	Events         []TrackingEventOutput // newest first
+}
+
+// RescheduleInput represents a consignee's choice of when a failed delivery should be attempted again:
+// either a whole calendar day, or a window. The challenge must always be answered.
+type RescheduleInput struct {
+	TrackingNumber string
+	Postcode       string
+	PhoneSuffix    string
+	Date           *time.Time // calendar day in Thai time
+	DeliverFrom    *time.Time
+	DeliverTo      *time.Time
+}
+
+// RescheduleOutput represents the agreed delivery window of the next attempt
+type RescheduleOutput struct {
+	TrackingNumber string
+	DeliverFrom    time.Time
+	DeliverTo      time.Time
+}
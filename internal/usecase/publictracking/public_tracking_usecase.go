@@ -0,0 +1,544 @@
+package publictracking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"tms-core-service/internal/domain/cache"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultCacheTTL is how long a tracking page is cached when not configured
+	DefaultCacheTTL = time.Minute
+
+	// pageKeyPrefix keys cached tracking pages
+	pageKeyPrefix = "tracking:page:"
+
+	// etaSpread widens a predicted arrival into the window shown to consignees
+	etaSpread = 30 * time.Minute
+
+	// etaRounding rounds the window's ends outwards to readable times
+	etaRounding = 15 * time.Minute
+
+	// phoneSuffixLength is how many trailing phone digits answer the challenge
+	phoneSuffixLength = 4
+
+	// rescheduleNotice is how far ahead a rescheduled delivery window must end at the earliest
+	rescheduleNotice = 2 * time.Hour
+
+	// rescheduleHorizon is how far ahead a failed delivery may be rescheduled
+	rescheduleHorizon = 14 * 24 * time.Hour
+)
+
+// Event codes shown on the tracking page
+const (
+	EventOrderReceived        = "order_received"
+	EventScheduled            = "scheduled"
+	EventPickedUp             = "picked_up"
+	EventInTransit            = "in_transit"
+	EventDelayed              = "delayed"
+	EventArrivedAtDestination = "arrived_at_destination"
+	EventDelivered            = "delivered"
+	EventDeliveryFailed       = "delivery_failed"
+	EventReturnedToSender     = "returned_to_sender"
+	EventCancelled            = "cancelled"
+)
+
+// cachedPage is a tracking page as cached, together with the answers to its challenge
+type cachedPage struct {
+	Page        *TrackingPageOutput
+	Postcode    string
+	PhoneSuffix string
+}
+
+// PublicTrackingUseCase builds the privacy-filtered tracking pages consignees see without an account
+type PublicTrackingUseCase struct {
+	shipmentRepo     repository.ShipmentRepository
+	tripRepo         repository.TripRepository
+	locationRepo     repository.LocationRepository
+	delayRepo        repository.StopDelayRepository
+	attemptRepo      repository.DeliveryAttemptRepository
+	cacheRepo        cache.CacheRepository
+	numbering        service.NumberGenerator
+	publisher        service.EventPublisher
+	requireChallenge bool
+	cacheTTL         time.Duration
+}
+
+// NewPublicTrackingUseCase creates a new public tracking use case. With requireChallenge set, a page is only
+// shown to callers who give the delivery postcode or the last digits of the recipient's phone number.
+func NewPublicTrackingUseCase(
+	shipmentRepo repository.ShipmentRepository,
+	tripRepo repository.TripRepository,
+	locationRepo repository.LocationRepository,
+	delayRepo repository.StopDelayRepository,
+	attemptRepo repository.DeliveryAttemptRepository,
+	cacheRepo cache.CacheRepository,
+	numbering service.NumberGenerator,
+	publisher service.EventPublisher,
+	requireChallenge bool,
+	cacheTTL time.Duration,
+) *PublicTrackingUseCase {
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultCacheTTL
+	}
+	return &PublicTrackingUseCase{
+		shipmentRepo:     shipmentRepo,
+		tripRepo:         tripRepo,
+		locationRepo:     locationRepo,
+		delayRepo:        delayRepo,
+		attemptRepo:      attemptRepo,
+		cacheRepo:        cacheRepo,
+		numbering:        numbering,
+		publisher:        publisher,
+		requireChallenge: requireChallenge,
+		cacheTTL:         cacheTTL,
+	}
+}
+
+// Track returns the tracking page of a shipment, followed to the latest leg booked after failed deliveries.
+// A number whose check character is wrong was mistyped and is rejected before it is looked up.
+// Pages are cached under the latest leg's status and last update, so a status change invalidates them at once;
+// progress that does not change the status, such as a new ETA, shows up when the cached page expires.
+func (uc *PublicTrackingUseCase) Track(ctx context.Context, input TrackInput) (*TrackingPageOutput, error) {
+	shipment, err := uc.findShipment(ctx, input.TrackingNumber)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := uc.chain(ctx, shipment)
+	if err != nil {
+		return nil, err
+	}
+	current := chain[len(chain)-1]
+
+	key := fmt.Sprintf("%s%s:%s:%s:%d", pageKeyPrefix, chain[0].ID, current.ID, current.Status, current.UpdatedAt.UnixNano())
+	cached, err := uc.loadPage(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if cached == nil {
+		cached, err = uc.buildPage(ctx, chain)
+		if err != nil {
+			return nil, err
+		}
+		// a page that cannot be cached is still served
+		if err := uc.cacheRepo.Set(ctx, key, cached, uc.cacheTTL); err != nil {
+			log.Printf("[ERROR] public tracking: cache repository: set page: %v", err)
+		}
+	}
+
+	if uc.requireChallenge && !cached.answers(input) {
+		return nil, errs.ErrChallengeFailed
+	}
+	return cached.Page, nil
+}
+
+// Reschedule sets when a failed delivery is attempted again, as chosen by the consignee. The challenge is
+// always required, whatever the configuration. Only a reattempt that has not been planned onto a trip yet
+// can be rescheduled; a return to the sender cannot.
+func (uc *PublicTrackingUseCase) Reschedule(ctx context.Context, input RescheduleInput) (*RescheduleOutput, error) {
+	from, to, err := rescheduleWindow(input, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	shipment, err := uc.findShipment(ctx, input.TrackingNumber)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := uc.chain(ctx, shipment)
+	if err != nil {
+		return nil, err
+	}
+	original, current := chain[0], chain[len(chain)-1]
+
+	delivery, err := uc.locationRepo.FindByID(ctx, original.DeliveryLocationID)
+	if err != nil {
+		return nil, fmt.Errorf("location repository: find by id: %w", err)
+	}
+	challenge := cachedPage{Postcode: delivery.Postcode, PhoneSuffix: phoneSuffix(delivery.ContactPhone)}
+	if !challenge.answers(TrackInput{Postcode: input.Postcode, PhoneSuffix: input.PhoneSuffix}) {
+		return nil, errs.ErrChallengeFailed
+	}
+
+	if current.Leg != entity.ShipmentLegReattempt || current.Status != entity.ShipmentStatusPending {
+		return nil, errs.ErrResourceLocked
+	}
+	// The update only applies while the leg is still pending, so a leg planned since it was loaded is not moved
+	if err := uc.shipmentRepo.UpdateDeliveryWindow(ctx, current.ID, from, to); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrResourceLocked
+		}
+		return nil, fmt.Errorf("shipment repository: update delivery window: %w", err)
+	}
+
+	// the new window is already saved, so a failed notification is only logged
+	err = uc.publisher.Publish(ctx, service.RealtimeEvent{
+		Channels: []string{
+			service.Channel(service.ChannelShipment, current.ID),
+			service.Channel(service.ChannelOrganization, current.OrganizationID),
+		},
+		Type: "shipment.rescheduled",
+		Data: map[string]interface{}{
+			"shipment_id":  current.ID,
+			"reference":    current.Reference,
+			"attempt":      current.Attempt,
+			"deliver_from": from,
+			"deliver_to":   to,
+		},
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		log.Printf("[ERROR] realtime: publish: %v", err)
+	}
+
+	return &RescheduleOutput{TrackingNumber: original.TrackingNumber, DeliverFrom: from, DeliverTo: to}, nil
+}
+
+// findShipment looks a shipment up by tracking number, rejecting mistyped numbers first
+func (uc *PublicTrackingUseCase) findShipment(ctx context.Context, trackingNumber string) (*entity.Shipment, error) {
+	number := strings.ToUpper(strings.TrimSpace(trackingNumber))
+	plausible, err := uc.numbering.Plausible(ctx, entity.DocumentShipment, number)
+	if err != nil {
+		return nil, fmt.Errorf("number generator: check tracking number: %w", err)
+	}
+	if !plausible {
+		return nil, errs.ValidationErrors{"tracking_number": {"invalid_check_digit"}}
+	}
+
+	shipment, err := uc.shipmentRepo.FindByTrackingNumber(ctx, number)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("shipment repository: find by tracking number: %w", err)
+	}
+	return shipment, nil
+}
+
+// chain returns the shipments from the original booking to its latest leg, following the reattempt and return
+// legs booked after failed deliveries. Any leg's tracking number leads to the whole chain.
+func (uc *PublicTrackingUseCase) chain(ctx context.Context, shipment *entity.Shipment) ([]*entity.Shipment, error) {
+	for shipment.ParentID != nil {
+		parent, err := uc.shipmentRepo.FindByID(ctx, *shipment.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("shipment repository: find by id: %w", err)
+		}
+		shipment = parent
+	}
+
+	chain := []*entity.Shipment{shipment}
+	for shipment.Status == entity.ShipmentStatusFailed {
+		attempt, err := uc.attemptRepo.FindByShipmentID(ctx, shipment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("delivery attempt repository: find by shipment id: %w", err)
+		}
+		shipment, err = uc.shipmentRepo.FindByID(ctx, attempt.LegID)
+		if err != nil {
+			// a deleted leg ends the chain
+			if errors.Is(err, errs.ErrNotFound) {
+				break
+			}
+			return nil, fmt.Errorf("shipment repository: find by id: %w", err)
+		}
+		chain = append(chain, shipment)
+	}
+	return chain, nil
+}
+
+// loadPage returns the cached page under key, or nil when there is none
+func (uc *PublicTrackingUseCase) loadPage(ctx context.Context, key string) (*cachedPage, error) {
+	data, err := uc.cacheRepo.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("cache repository: get page: %w", err)
+	}
+	if data == "" {
+		return nil, nil
+	}
+
+	var cached cachedPage
+	if err := json.Unmarshal([]byte(data), &cached); err != nil {
+		return nil, nil // unreadable entries are rebuilt
+	}
+	return &cached, nil
+}
+
+// buildPage assembles the timeline from the status history, trip stops and detected delays of every leg
+// of the shipment; the status and expected window are those of the latest leg
+func (uc *PublicTrackingUseCase) buildPage(ctx context.Context, chain []*entity.Shipment) (*cachedPage, error) {
+	original, current := chain[0], chain[len(chain)-1]
+
+	// legs run between the original's two locations, in either direction
+	locations, err := uc.locationRepo.FindByIDs(ctx, []uuid.UUID{original.PickupLocationID, original.DeliveryLocationID})
+	if err != nil {
+		return nil, fmt.Errorf("location repository: find by ids: %w", err)
+	}
+	byID := make(map[uuid.UUID]*entity.Location, len(locations))
+	for _, l := range locations {
+		byID[l.ID] = l
+	}
+	delivery := byID[original.DeliveryLocationID]
+	if delivery == nil {
+		delivery = &entity.Location{}
+	}
+
+	page := &TrackingPageOutput{
+		TrackingNumber: original.TrackingNumber,
+		Recipient:      maskName(recipientName(delivery)),
+		District:       delivery.District,
+		Province:       delivery.Province,
+		Events:         []TrackingEventOutput{{Code: EventOrderReceived, OccurredAt: original.CreatedAt}},
+	}
+
+	var pickedUp bool
+	var deliveryStop *entity.TripStop
+	for _, shipment := range chain {
+		pickedUp, deliveryStop, err = uc.addLegEvents(ctx, page, shipment, byID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(page.Events, func(i, j int) bool {
+		return page.Events[i].OccurredAt.After(page.Events[j].OccurredAt)
+	})
+
+	returning := current.Leg == entity.ShipmentLegReturn
+	switch {
+	case current.Status == entity.ShipmentStatusCancelled:
+		page.Status = EventCancelled
+	case returning && current.Status == entity.ShipmentStatusDelivered:
+		page.Status = EventReturnedToSender
+	case returning:
+		page.Status = "returning_to_sender"
+	case current.Status == entity.ShipmentStatusDelivered:
+		page.Status = EventDelivered
+	case current.Status == entity.ShipmentStatusFailed:
+		page.Status = EventDeliveryFailed
+	case deliveryStop != nil && deliveryStop.ArrivedAt != nil:
+		page.Status = "at_destination"
+	case pickedUp || current.Status == entity.ShipmentStatusInTransit:
+		page.Status = EventInTransit
+	case current.Status == entity.ShipmentStatusPlanned:
+		page.Status = EventScheduled
+	case current.ParentID != nil:
+		page.Status = EventDeliveryFailed // the next attempt is not planned yet
+	default:
+		page.Status = EventOrderReceived
+	}
+	page.Reschedulable = current.Leg == entity.ShipmentLegReattempt && current.Status == entity.ShipmentStatusPending
+
+	switch page.Status {
+	case EventCancelled, EventDelivered, EventReturnedToSender, "returning_to_sender":
+	default:
+		if deliveryStop != nil && deliveryStop.ETA != nil {
+			from := deliveryStop.ETA.Add(-etaSpread).Truncate(etaRounding)
+			to := roundUp(deliveryStop.ETA.Add(etaSpread), etaRounding)
+			page.ETAFrom, page.ETATo = &from, &to
+		} else if current.Status != entity.ShipmentStatusFailed {
+			page.ETAFrom, page.ETATo = current.DeliverFrom, current.DeliverTo
+		}
+	}
+
+	return &cachedPage{
+		Page:        page,
+		Postcode:    delivery.Postcode,
+		PhoneSuffix: phoneSuffix(delivery.ContactPhone),
+	}, nil
+}
+
+// addLegEvents adds the milestones of one leg to the page and reports whether the leg was picked up and
+// which of its trip stops delivers it
+func (uc *PublicTrackingUseCase) addLegEvents(ctx context.Context, page *TrackingPageOutput, shipment *entity.Shipment, locations map[uuid.UUID]*entity.Location) (bool, *entity.TripStop, error) {
+	pickup, delivery := locations[shipment.PickupLocationID], locations[shipment.DeliveryLocationID]
+	if pickup == nil {
+		pickup = &entity.Location{}
+	}
+	if delivery == nil {
+		delivery = &entity.Location{}
+	}
+	returning := shipment.Leg == entity.ShipmentLegReturn
+
+	history, err := uc.shipmentRepo.ListStatusHistory(ctx, shipment.ID)
+	if err != nil {
+		return false, nil, fmt.Errorf("shipment repository: list status history: %w", err)
+	}
+	for _, change := range history {
+		event := TrackingEventOutput{OccurredAt: change.ChangedAt}
+		switch change.Status {
+		case entity.ShipmentStatusPlanned:
+			event.Code = EventScheduled
+		case entity.ShipmentStatusInTransit:
+			event.Code = EventInTransit
+		case entity.ShipmentStatusDelivered:
+			if returning {
+				event.Code = EventReturnedToSender
+				break
+			}
+			event.Code = EventDelivered
+			event.District, event.Province = delivery.District, delivery.Province
+			deliveredAt := change.ChangedAt
+			page.DeliveredAt = &deliveredAt
+		case entity.ShipmentStatusFailed:
+			event.Code = EventDeliveryFailed
+			event.District, event.Province = delivery.District, delivery.Province
+		case entity.ShipmentStatusCancelled:
+			event.Code = EventCancelled
+		default:
+			continue // moving back to pending is internal re-planning
+		}
+		page.Events = append(page.Events, event)
+	}
+
+	trip, err := uc.tripRepo.FindByShipment(ctx, shipment.ID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return false, nil, nil
+		}
+		return false, nil, fmt.Errorf("trip repository: find by shipment: %w", err)
+	}
+
+	var pickedUp bool
+	var deliveryStop *entity.TripStop
+	for i := range trip.Stops {
+		stop := &trip.Stops[i]
+		if stop.ShipmentID != shipment.ID {
+			continue
+		}
+		switch {
+		case stop.Type == entity.StopTypePickup && stop.DepartedAt != nil:
+			pickedUp = true
+			page.Events = append(page.Events, TrackingEventOutput{
+				Code:       EventPickedUp,
+				OccurredAt: *stop.DepartedAt,
+				District:   pickup.District,
+				Province:   pickup.Province,
+			})
+		case stop.Type == entity.StopTypeDelivery:
+			deliveryStop = stop
+			if stop.ArrivedAt != nil {
+				page.Events = append(page.Events, TrackingEventOutput{
+					Code:       EventArrivedAtDestination,
+					OccurredAt: *stop.ArrivedAt,
+					District:   delivery.District,
+					Province:   delivery.Province,
+				})
+			}
+		}
+	}
+
+	delays, err := uc.delayRepo.ListByTrip(ctx, trip.ID)
+	if err != nil {
+		return false, nil, fmt.Errorf("stop delay repository: list by trip: %w", err)
+	}
+	for _, d := range delays {
+		if d.ShipmentID == shipment.ID {
+			page.Events = append(page.Events, TrackingEventOutput{Code: EventDelayed, OccurredAt: d.DetectedAt})
+		}
+	}
+	return pickedUp, deliveryStop, nil
+}
+
+// answers reports whether the input gives the delivery postcode or the recipient's phone digits
+func (p *cachedPage) answers(input TrackInput) bool {
+	postcode := strings.TrimSpace(input.Postcode)
+	suffix := strings.TrimSpace(input.PhoneSuffix)
+	return (postcode != "" && postcode == p.Postcode) || (suffix != "" && suffix == p.PhoneSuffix)
+}
+
+// rescheduleWindow returns the delivery window a consignee asked for: a whole calendar day, or the window given.
+// It must end at least rescheduleNotice from now and start within rescheduleHorizon.
+func rescheduleWindow(input RescheduleInput, now time.Time) (time.Time, time.Time, error) {
+	var from, to time.Time
+	fromField, toField := "deliver_from", "deliver_to"
+	switch {
+	case input.Date != nil:
+		from = timeutil.StartOfDay(*input.Date)
+		to = from.AddDate(0, 0, 1)
+		fromField, toField = "date", "date"
+	case input.DeliverFrom != nil && input.DeliverTo != nil:
+		from, to = *input.DeliverFrom, *input.DeliverTo
+		if !to.After(from) {
+			return time.Time{}, time.Time{}, errs.ValidationErrors{"deliver_to": {"before_from"}}
+		}
+	default:
+		return time.Time{}, time.Time{}, errs.ValidationErrors{"date": {"required"}}
+	}
+
+	if to.Before(now.Add(rescheduleNotice)) {
+		return time.Time{}, time.Time{}, errs.ValidationErrors{toField: {"too_soon"}}
+	}
+	if from.After(now.Add(rescheduleHorizon)) {
+		return time.Time{}, time.Time{}, errs.ValidationErrors{fromField: {"too_far"}}
+	}
+	return from, to, nil
+}
+
+// recipientName returns the delivery contact, or the location's name when it has none
+func recipientName(l *entity.Location) string {
+	if l.ContactName != "" {
+		return l.ContactName
+	}
+	return l.Name
+}
+
+// maskName keeps the first letter of each word, e.g. "Somchai Jaidee" becomes "S****** J*****"
+func maskName(name string) string {
+	words := strings.Fields(name)
+	for i, w := range words {
+		var masked []rune
+		first := true
+		for j, r := range w {
+			switch {
+			case j == 0:
+				masked = append(masked, r)
+			case unicode.Is(unicode.Mn, r):
+				// Thai vowel and tone marks combine with the letter before them; keep only the first letter's
+				if first {
+					masked = append(masked, r)
+				}
+			default:
+				first = false
+				masked = append(masked, '*')
+			}
+		}
+		words[i] = string(masked)
+	}
+	return strings.Join(words, " ")
+}
+
+// phoneSuffix returns the last digits of a phone number, or "" when it has too few
+func phoneSuffix(phone string) string {
+	var digits []rune
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits = append(digits, r)
+		}
+	}
+	if len(digits) < phoneSuffixLength {
+		return ""
+	}
+	return string(digits[len(digits)-phoneSuffixLength:])
+}
+
+// roundUp rounds t up to a multiple of d
+func roundUp(t time.Time, d time.Duration) time.Time {
+	if r := t.Truncate(d); !r.Equal(t) {
+		return r.Add(d)
+	}
+	return t
+}
@@ -0,0 +1,229 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// channelKinds lists the kinds of channel clients may subscribe to
+var channelKinds = map[string]bool{
+	service.ChannelTrip:         true,
+	service.ChannelShipment:     true,
+	service.ChannelVehicle:      true,
+	service.ChannelOrganization: true,
+	service.ChannelDriver:       true,
+	service.ChannelCarrier:      true,
+	service.ChannelUser:         true,
+	service.ChannelDispatch:     true,
+}
+
+// RealtimeUseCase manages client subscriptions to realtime channels and publishes live vehicle positions
+type RealtimeUseCase struct {
+	subscriber  service.EventSubscriber
+	publisher   service.EventPublisher
+	userRepo    repository.UserRepository
+	carrierRepo repository.CarrierRepository
+	driverRepo  repository.DriverRepository
+	tripRepo    repository.TripRepository
+}
+
+// NewRealtimeUseCase creates a new realtime use case
+func NewRealtimeUseCase(
+	subscriber service.EventSubscriber,
+	publisher service.EventPublisher,
+	userRepo repository.UserRepository,
+	carrierRepo repository.CarrierRepository,
+	driverRepo repository.DriverRepository,
+	tripRepo repository.TripRepository,
+) *RealtimeUseCase {
+	return &RealtimeUseCase{
+		subscriber:  subscriber,
+		publisher:   publisher,
+		userRepo:    userRepo,
+		carrierRepo: carrierRepo,
+		driverRepo:  driverRepo,
+		tripRepo:    tripRepo,
+	}
+}
+
+// Connect opens a subscription of the user to the given channels; more can be added later
+func (uc *RealtimeUseCase) Connect(ctx context.Context, userID uuid.UUID, channels []string) (service.Subscription, error) {
+	if err := uc.Authorize(ctx, userID, channels); err != nil {
+		return nil, err
+	}
+
+	sub := uc.subscriber.Connect()
+	if err := sub.Subscribe(channels...); err != nil {
+		sub.Close()
+		return nil, err
+	}
+	return sub, nil
+}
+
+// Subscribe adds channels to an open subscription of the user
+func (uc *RealtimeUseCase) Subscribe(ctx context.Context, userID uuid.UUID, sub service.Subscription, channels []string) error {
+	if err := uc.Authorize(ctx, userID, channels); err != nil {
+		return err
+	}
+	return sub.Subscribe(channels...)
+}
+
+// Unsubscribe removes channels from an open subscription
+func (uc *RealtimeUseCase) Unsubscribe(sub service.Subscription, channels []string) {
+	sub.Unsubscribe(channels...)
+}
+
+// ObservePositions publishes the newest fix of each vehicle in an ingested batch on the vehicle's channel
+func (uc *RealtimeUseCase) ObservePositions(ctx context.Context, positions []entity.VehiclePosition) error {
+	newest := make(map[uuid.UUID]entity.VehiclePosition)
+	for _, p := range positions {
+		if n, ok := newest[p.VehicleID]; !ok || p.RecordedAt.After(n.RecordedAt) {
+			newest[p.VehicleID] = p
+		}
+	}
+
+	events := make([]service.RealtimeEvent, 0, len(newest))
+	for _, p := range newest {
+		events = append(events, service.RealtimeEvent{
+			Channels: []string{service.Channel(service.ChannelVehicle, p.VehicleID)},
+			Type:     "vehicle.position",
+			Data: map[string]interface{}{
+				"vehicle_id":  p.VehicleID,
+				"lat":         p.Latitude,
+				"lng":         p.Longitude,
+				"speed_kmh":   p.SpeedKmh,
+				"heading":     p.Heading,
+				"recorded_at": p.RecordedAt,
+			},
+			OccurredAt: p.RecordedAt,
+		})
+	}
+	// live positions are best effort; a Redis hiccup must not fail GPS ingestion
+	if err := uc.publisher.Publish(ctx, events...); err != nil {
+		log.Printf("[ERROR] realtime: publish positions: %v", err)
+	}
+	return nil
+}
+
+// Authorize checks that every channel is "<kind>:<uuid>" of a known kind and that the user may follow it.
+// A user follows their own user channel. Carrier users follow their carrier and the trips awarded to it,
+// drivers their own driver channel and the trips they drive. Staff follow everything but other users' and
+// carriers' channels; anyone else follows nothing more. It returns errs.ErrForbidden for a channel the user
+// may not follow.
+func (uc *RealtimeUseCase) Authorize(ctx context.Context, userID uuid.UUID, channels []string) error {
+	type channel struct {
+		kind string
+		id   uuid.UUID
+	}
+	parsed := make([]channel, len(channels))
+	for i, c := range channels {
+		kind, value, ok := strings.Cut(c, ":")
+		if !ok || !channelKinds[kind] {
+			return errs.ValidationErrors{"channels": {"unknown_channel"}}
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return errs.ValidationErrors{"channels": {"invalid_id"}}
+		}
+		parsed[i] = channel{kind, id}
+	}
+	if len(parsed) == 0 {
+		return nil
+	}
+
+	p, err := uc.principal(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, c := range parsed {
+		allowed, err := uc.allowed(ctx, p, c.kind, c.id)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return errs.ErrForbidden
+		}
+	}
+	return nil
+}
+
+// principal is who a user acts for
+type principal struct {
+	userID    uuid.UUID
+	staff     bool
+	carrierID *uuid.UUID // set for carrier users
+	driverID  *uuid.UUID // set for drivers
+}
+
+func (uc *RealtimeUseCase) principal(ctx context.Context, userID uuid.UUID) (*principal, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrForbidden
+		}
+		return nil, fmt.Errorf("user repository: find by id: %w", err)
+	}
+	p := &principal{userID: userID, staff: user.IsStaff()}
+
+	carrier, err := uc.carrierRepo.FindByUserID(ctx, userID)
+	switch {
+	case err == nil:
+		p.carrierID = &carrier.ID
+	case !errors.Is(err, errs.ErrNotFound):
+		return nil, fmt.Errorf("carrier repository: find by user id: %w", err)
+	}
+
+	driver, err := uc.driverRepo.FindByUserID(ctx, userID)
+	switch {
+	case err == nil:
+		p.driverID = &driver.ID
+	case !errors.Is(err, errs.ErrNotFound):
+		return nil, fmt.Errorf("driver repository: find by user id: %w", err)
+	}
+	return p, nil
+}
+
+// allowed reports whether the principal may follow one channel
+func (uc *RealtimeUseCase) allowed(ctx context.Context, p *principal, kind string, id uuid.UUID) (bool, error) {
+	switch kind {
+	case service.ChannelUser:
+		return id == p.userID, nil
+	case service.ChannelCarrier:
+		return p.carrierID != nil && *p.carrierID == id, nil
+	case service.ChannelDriver:
+		if p.driverID != nil {
+			return *p.driverID == id, nil
+		}
+		return p.staff, nil
+	case service.ChannelTrip:
+		if p.staff {
+			return true, nil
+		}
+		trip, err := uc.tripRepo.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return false, nil
+			}
+			return false, fmt.Errorf("trip repository: find by id: %w", err)
+		}
+		if p.carrierID != nil && trip.CarrierID != nil && *trip.CarrierID == *p.carrierID {
+			return true, nil
+		}
+		if p.driverID != nil && (trip.DriverID == *p.driverID || (trip.CoDriverID != nil && *trip.CoDriverID == *p.driverID)) {
+			return true, nil
+		}
+		return false, nil
+	}
+	// shipments, vehicles, organizations and dispatch queues
+	return p.staff, nil
+}
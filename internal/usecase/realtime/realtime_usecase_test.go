@@ -0,0 +1,139 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	staff map[uuid.UUID]bool
+}
+
+func (r fakeUserRepo) FindByID(_ context.Context, id uuid.UUID) (*entity.User, error) {
+	role := entity.UserRoleUser
+	if r.staff[id] {
+		role = entity.UserRoleStaff
+	}
+	return &entity.User{ID: id, Role: role}, nil
+}
+
+type fakeCarrierRepo struct {
+	repository.CarrierRepository
+	byUser map[uuid.UUID]*entity.Carrier
+}
+
+func (r fakeCarrierRepo) FindByUserID(_ context.Context, userID uuid.UUID) (*entity.Carrier, error) {
+	if c, ok := r.byUser[userID]; ok {
+		return c, nil
+	}
+	return nil, errs.ErrNotFound
+}
+
+type fakeDriverRepo struct {
+	repository.DriverRepository
+	byUser map[uuid.UUID]*entity.Driver
+}
+
+func (r fakeDriverRepo) FindByUserID(_ context.Context, userID uuid.UUID) (*entity.Driver, error) {
+	if d, ok := r.byUser[userID]; ok {
+		return d, nil
+	}
+	return nil, errs.ErrNotFound
+}
+
+type fakeTripRepo struct {
+	repository.TripRepository
+	trips map[uuid.UUID]*entity.Trip
+}
+
+func (r fakeTripRepo) FindByID(_ context.Context, id uuid.UUID) (*entity.Trip, error) {
+	if t, ok := r.trips[id]; ok {
+		return t, nil
+	}
+	return nil, errs.ErrNotFound
+}
+
+func TestAuthorize(t *testing.T) {
+	staff, carrierUser, driverUser, registered := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	carrier := &entity.Carrier{ID: uuid.New()}
+	driver := &entity.Driver{ID: uuid.New(), UserID: driverUser}
+	awarded := &entity.Trip{ID: uuid.New(), DriverID: uuid.New(), CarrierID: &carrier.ID}
+	driven := &entity.Trip{ID: uuid.New(), DriverID: uuid.New(), CoDriverID: &driver.ID}
+	other := &entity.Trip{ID: uuid.New(), DriverID: uuid.New()}
+
+	uc := NewRealtimeUseCase(nil, nil,
+		fakeUserRepo{staff: map[uuid.UUID]bool{staff: true}},
+		fakeCarrierRepo{byUser: map[uuid.UUID]*entity.Carrier{carrierUser: carrier}},
+		fakeDriverRepo{byUser: map[uuid.UUID]*entity.Driver{driverUser: driver}},
+		fakeTripRepo{trips: map[uuid.UUID]*entity.Trip{awarded.ID: awarded, driven.ID: driven, other.ID: other}},
+	)
+
+	trip := func(t *entity.Trip) string { return service.Channel(service.ChannelTrip, t.ID) }
+	anyOrg := service.Channel(service.ChannelOrganization, uuid.New())
+
+	for _, tc := range []struct {
+		name    string
+		userID  uuid.UUID
+		channel string
+		allowed bool
+	}{
+		{"own user channel", carrierUser, service.Channel(service.ChannelUser, carrierUser), true},
+		{"another user's channel", staff, service.Channel(service.ChannelUser, carrierUser), false},
+		{"staff follow organizations", staff, anyOrg, true},
+		{"staff follow any trip", staff, trip(other), true},
+		{"staff follow drivers", staff, service.Channel(service.ChannelDriver, driver.ID), true},
+		{"staff are no carrier", staff, service.Channel(service.ChannelCarrier, carrier.ID), false},
+		{"registered user follows own user channel", registered, service.Channel(service.ChannelUser, registered), true},
+		{"registered user follows no organization", registered, anyOrg, false},
+		{"registered user follows no trip", registered, trip(other), false},
+		{"registered user follows no shipment", registered, service.Channel(service.ChannelShipment, uuid.New()), false},
+		{"registered user follows no driver", registered, service.Channel(service.ChannelDriver, driver.ID), false},
+		{"registered user follows no dispatch queue", registered, service.Channel(service.ChannelDispatch, uuid.New()), false},
+		{"own carrier", carrierUser, service.Channel(service.ChannelCarrier, carrier.ID), true},
+		{"another carrier", carrierUser, service.Channel(service.ChannelCarrier, uuid.New()), false},
+		{"trip awarded to the carrier", carrierUser, trip(awarded), true},
+		{"trip of someone else", carrierUser, trip(other), false},
+		{"unknown trip", carrierUser, service.Channel(service.ChannelTrip, uuid.New()), false},
+		{"carrier follows no organization", carrierUser, anyOrg, false},
+		{"own driver channel", driverUser, service.Channel(service.ChannelDriver, driver.ID), true},
+		{"another driver", driverUser, service.Channel(service.ChannelDriver, uuid.New()), false},
+		{"trip as co-driver", driverUser, trip(driven), true},
+		{"trip driven by another", driverUser, trip(awarded), false},
+		{"driver follows no vehicle", driverUser, service.Channel(service.ChannelVehicle, uuid.New()), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := uc.Authorize(context.Background(), tc.userID, []string{tc.channel})
+			switch {
+			case tc.allowed && err != nil:
+				t.Errorf("err = %v, want allowed", err)
+			case !tc.allowed && !errors.Is(err, errs.ErrForbidden):
+				t.Errorf("err = %v, want ErrForbidden", err)
+			}
+		})
+	}
+}
+
+func TestAuthorizeRejectsMalformedChannels(t *testing.T) {
+	uc := NewRealtimeUseCase(nil, nil, fakeUserRepo{}, fakeCarrierRepo{}, fakeDriverRepo{}, fakeTripRepo{})
+
+	for channel, want := range map[string]string{
+		"fleet:" + uuid.NewString(): "unknown_channel",
+		"trip":                      "unknown_channel",
+		"trip:42":                   "invalid_id",
+	} {
+		var validationErrs errs.ValidationErrors
+		err := uc.Authorize(context.Background(), uuid.New(), []string{channel})
+		if !errors.As(err, &validationErrs) || len(validationErrs["channels"]) == 0 || validationErrs["channels"][0] != want {
+			t.Errorf("%s: err = %v, want %s", channel, err, want)
+		}
+	}
+}
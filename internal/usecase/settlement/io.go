@@ -0,0 +1,142 @@
+package settlement
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// PayRuleInput represents a driver pay rule to create or replace
+type PayRuleInput struct {
+	Name               string
+	VehicleType        entity.VehicleType
+	BasePerTrip        entity.Money
+	PerKm              entity.Money
+	PerDrop            entity.Money
+	OvernightAllowance entity.Money
+	OvertimeRate       entity.Money
+	StandardHours      float64
+	EffectiveFrom      time.Time
+	EffectiveTo        *time.Time
+}
+
+// ListPayRulesInput represents criteria for listing driver pay rules
+type ListPayRulesInput struct {
+	VehicleType *entity.VehicleType
+	EffectiveAt *time.Time
+	Limit       int
+	Offset      int
+}
+
+// PayRuleOutput represents driver pay rule output data
+type PayRuleOutput struct {
+	ID                 uuid.UUID
+	Name               string
+	VehicleType        entity.VehicleType
+	BasePerTrip        entity.Money
+	PerKm              entity.Money
+	PerDrop            entity.Money
+	OvernightAllowance entity.Money
+	OvertimeRate       entity.Money
+	StandardHours      float64
+	EffectiveFrom      time.Time
+	EffectiveTo        *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// AdjustmentInput represents an advance or deduction taken off a settlement
+type AdjustmentInput struct {
+	Kind        entity.AdjustmentKind
+	Description string
+	Amount      entity.Money
+}
+
+// CreateSettlementInput represents a request to settle a driver's completed trips of a period
+type CreateSettlementInput struct {
+	DriverID    uuid.UUID
+	PeriodFrom  time.Time // first day, inclusive
+	PeriodTo    time.Time // last day, inclusive
+	Adjustments []AdjustmentInput
+	Notes       string
+}
+
+// ListSettlementsInput represents criteria for listing driver settlements
+type ListSettlementsInput struct {
+	DriverID *uuid.UUID
+	Status   *entity.SettlementStatus
+	From     *time.Time
+	To       *time.Time
+	Search   string
+	Limit    int
+	Offset   int
+}
+
+// ExportInput represents the pay period exported for payroll
+type ExportInput struct {
+	PeriodFrom time.Time
+	PeriodTo   time.Time
+}
+
+// SettlementLineOutput represents the pay for one trip
+type SettlementLineOutput struct {
+	Sequence      int
+	TripID        uuid.UUID
+	TripNumber    string
+	CoDriver      bool
+	VehicleType   entity.VehicleType
+	PayRuleID     uuid.UUID
+	StartedAt     time.Time
+	EndedAt       time.Time
+	DistanceKm    float64
+	Drops         int
+	Overnights    int
+	OvertimeHours float64
+	BasePay       entity.Money
+	DistancePay   entity.Money
+	DropPay       entity.Money
+	OvernightPay  entity.Money
+	OvertimePay   entity.Money
+	Amount        entity.Money
+}
+
+// AdjustmentOutput represents an advance or deduction
+type AdjustmentOutput struct {
+	ID          uuid.UUID
+	Kind        entity.AdjustmentKind
+	Description string
+	Amount      entity.Money
+	CreatedAt   time.Time
+}
+
+// SettlementOutput represents driver settlement output data
+type SettlementOutput struct {
+	ID          uuid.UUID
+	Number      string
+	DriverID    uuid.UUID
+	Status      entity.SettlementStatus
+	PeriodFrom  time.Time
+	PeriodTo    time.Time
+	Lines       []SettlementLineOutput
+	Adjustments []AdjustmentOutput
+	GrossPay    entity.Money
+	Advances    entity.Money
+	Deductions  entity.Money
+	NetPay      entity.Money
+	Notes       string
+	ApprovedAt  *time.Time
+	ApprovedBy  *uuid.UUID
+	VoidedAt    *time.Time
+	VoidReason  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ExportOutput represents a payroll file
+type ExportOutput struct {
+	Filename string
+	Content  []byte
+	Count    int // settlements in the file
+}
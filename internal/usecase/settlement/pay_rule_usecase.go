@@ -0,0 +1,134 @@
+package settlement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// PayRuleUseCase manages the rules that price a driver's trips
+type PayRuleUseCase struct {
+	payRuleRepo repository.DriverPayRuleRepository
+}
+
+// NewPayRuleUseCase creates a new driver pay rule use case
+func NewPayRuleUseCase(payRuleRepo repository.DriverPayRuleRepository) *PayRuleUseCase {
+	return &PayRuleUseCase{payRuleRepo: payRuleRepo}
+}
+
+// Create adds a pay rule. Settlements drafted afterwards pay trips starting in its effective period by it.
+func (uc *PayRuleUseCase) Create(ctx context.Context, input PayRuleInput) (*PayRuleOutput, error) {
+	if err := validatePayRule(input); err != nil {
+		return nil, err
+	}
+
+	rule := &entity.DriverPayRule{}
+	applyPayRule(rule, input)
+	if err := uc.payRuleRepo.Create(ctx, rule); err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			return nil, errs.ErrConflict
+		}
+		return nil, fmt.Errorf("driver pay rule repository: create pay rule: %w", err)
+	}
+	return toPayRuleOutput(rule), nil
+}
+
+// Update replaces a pay rule, e.g. to end it. Settlements already drafted keep the pay they worked out.
+func (uc *PayRuleUseCase) Update(ctx context.Context, id uuid.UUID, input PayRuleInput) (*PayRuleOutput, error) {
+	if err := validatePayRule(input); err != nil {
+		return nil, err
+	}
+
+	rule, err := uc.payRuleRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("driver pay rule repository: find by id: %w", err)
+	}
+
+	applyPayRule(rule, input)
+	if err := uc.payRuleRepo.Update(ctx, rule); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("driver pay rule repository: update pay rule: %w", err)
+	}
+	return toPayRuleOutput(rule), nil
+}
+
+// Get returns a pay rule by ID
+func (uc *PayRuleUseCase) Get(ctx context.Context, id uuid.UUID) (*PayRuleOutput, error) {
+	rule, err := uc.payRuleRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("driver pay rule repository: find by id: %w", err)
+	}
+	return toPayRuleOutput(rule), nil
+}
+
+// List returns pay rules matching the input criteria
+func (uc *PayRuleUseCase) List(ctx context.Context, input ListPayRulesInput) ([]*PayRuleOutput, int64, error) {
+	rules, total, err := uc.payRuleRepo.List(ctx, repository.DriverPayRuleFilter{
+		VehicleType: input.VehicleType,
+		EffectiveAt: input.EffectiveAt,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("driver pay rule repository: list pay rules: %w", err)
+	}
+
+	outputs := make([]*PayRuleOutput, len(rules))
+	for i, r := range rules {
+		outputs[i] = toPayRuleOutput(r)
+	}
+	return outputs, total, nil
+}
+
+func validatePayRule(input PayRuleInput) error {
+	if input.EffectiveTo != nil && !input.EffectiveTo.After(input.EffectiveFrom) {
+		return errs.ValidationErrors{"effective_to": {"before_effective_from"}}
+	}
+	if input.OvertimeRate > 0 && input.StandardHours <= 0 {
+		return errs.ValidationErrors{"standard_hours": {"required_with_overtime_rate"}}
+	}
+	return nil
+}
+
+func applyPayRule(rule *entity.DriverPayRule, input PayRuleInput) {
+	rule.Name = input.Name
+	rule.VehicleType = input.VehicleType
+	rule.BasePerTrip = input.BasePerTrip
+	rule.PerKm = input.PerKm
+	rule.PerDrop = input.PerDrop
+	rule.OvernightAllowance = input.OvernightAllowance
+	rule.OvertimeRate = input.OvertimeRate
+	rule.StandardHours = input.StandardHours
+	rule.EffectiveFrom = input.EffectiveFrom
+	rule.EffectiveTo = input.EffectiveTo
+}
+
+func toPayRuleOutput(r *entity.DriverPayRule) *PayRuleOutput {
+	return &PayRuleOutput{
+		ID:                 r.ID,
+		Name:               r.Name,
+		VehicleType:        r.VehicleType,
+		BasePerTrip:        r.BasePerTrip,
+		PerKm:              r.PerKm,
+		PerDrop:            r.PerDrop,
+		OvernightAllowance: r.OvernightAllowance,
+		OvertimeRate:       r.OvertimeRate,
+		StandardHours:      r.StandardHours,
+		EffectiveFrom:      r.EffectiveFrom,
+		EffectiveTo:        r.EffectiveTo,
+		CreatedAt:          r.CreatedAt,
+		UpdatedAt:          r.UpdatedAt,
+	}
+}
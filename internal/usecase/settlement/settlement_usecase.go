@@ -0,0 +1,494 @@
+package settlement
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+// exportPageSize is how many settlements the payroll export reads at a time
+const exportPageSize = 200
+
+// SettlementUseCase handles working out drivers' pay for their completed trips and releasing it to payroll
+type SettlementUseCase struct {
+	settlementRepo repository.DriverSettlementRepository
+	payRuleRepo    repository.DriverPayRuleRepository
+	driverRepo     repository.DriverRepository
+	vehicleRepo    repository.VehicleRepository
+	locationRepo   repository.LocationRepository
+	estimator      service.TravelEstimator
+	numbering      service.NumberGenerator
+	transactor     repository.Transactor
+}
+
+// NewSettlementUseCase creates a new driver settlement use case
+func NewSettlementUseCase(
+	settlementRepo repository.DriverSettlementRepository,
+	payRuleRepo repository.DriverPayRuleRepository,
+	driverRepo repository.DriverRepository,
+	vehicleRepo repository.VehicleRepository,
+	locationRepo repository.LocationRepository,
+	estimator service.TravelEstimator,
+	numbering service.NumberGenerator,
+	transactor repository.Transactor,
+) *SettlementUseCase {
+	return &SettlementUseCase{
+		settlementRepo: settlementRepo,
+		payRuleRepo:    payRuleRepo,
+		driverRepo:     driverRepo,
+		vehicleRepo:    vehicleRepo,
+		locationRepo:   locationRepo,
+		estimator:      estimator,
+		numbering:      numbering,
+		transactor:     transactor,
+	}
+}
+
+// CreateDraft settles every completed trip the driver drove or co-drove that was planned to start in the
+// period and is not on another live settlement of the driver. Each trip is paid by the rule effective when
+// it started for its vehicle type.
+func (uc *SettlementUseCase) CreateDraft(ctx context.Context, input CreateSettlementInput) (*SettlementOutput, error) {
+	periodFrom := timeutil.CalendarDay(input.PeriodFrom)
+	periodTo := timeutil.CalendarDay(input.PeriodTo)
+	if periodTo.Before(periodFrom) {
+		return nil, errs.ValidationErrors{"period_to": {"before_period_from"}}
+	}
+	if err := validateAdjustments(input.Adjustments); err != nil {
+		return nil, err
+	}
+	from := timeutil.StartOfDay(periodFrom)
+	to := timeutil.StartOfDay(periodTo.AddDate(0, 0, 1))
+
+	var settlement *entity.DriverSettlement
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		// The driver row serializes drafts so no trip is paid twice
+		driver, err := uc.driverRepo.FindByIDForUpdate(ctx, input.DriverID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return errs.ErrNotFound
+			}
+			return fmt.Errorf("driver repository: find by id for update: %w", err)
+		}
+
+		trips, err := uc.settlementRepo.ListSettleableTrips(ctx, driver.ID, from, to)
+		if err != nil {
+			return fmt.Errorf("driver settlement repository: list settleable trips: %w", err)
+		}
+		if len(trips) == 0 {
+			return errs.ValidationErrors{"period": {"no_settleable_trips"}}
+		}
+
+		settlement = &entity.DriverSettlement{
+			DriverID:   driver.ID,
+			Status:     entity.SettlementStatusDraft,
+			PeriodFrom: periodFrom,
+			PeriodTo:   periodTo,
+			Notes:      input.Notes,
+		}
+		if err := uc.addTrips(ctx, settlement, trips); err != nil {
+			return err
+		}
+		for _, a := range input.Adjustments {
+			if err := settlement.AddAdjustment(entity.SettlementAdjustment{Kind: a.Kind, Description: a.Description, Amount: a.Amount}); err != nil {
+				return err
+			}
+		}
+
+		if err := uc.settlementRepo.Create(ctx, settlement); err != nil {
+			return fmt.Errorf("driver settlement repository: create settlement: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSettlementOutput(settlement), nil
+}
+
+// AddAdjustment takes an advance or deduction off a draft settlement
+func (uc *SettlementUseCase) AddAdjustment(ctx context.Context, id uuid.UUID, input AdjustmentInput) (*SettlementOutput, error) {
+	if err := validateAdjustments([]AdjustmentInput{input}); err != nil {
+		return nil, err
+	}
+	return uc.change(ctx, id, func(_ context.Context, s *entity.DriverSettlement, _ time.Time) error {
+		return s.AddAdjustment(entity.SettlementAdjustment{Kind: input.Kind, Description: input.Description, Amount: input.Amount})
+	})
+}
+
+// RemoveAdjustment drops an advance or deduction from a draft settlement
+func (uc *SettlementUseCase) RemoveAdjustment(ctx context.Context, id, adjustmentID uuid.UUID) (*SettlementOutput, error) {
+	return uc.change(ctx, id, func(_ context.Context, s *entity.DriverSettlement, _ time.Time) error {
+		return s.RemoveAdjustment(adjustmentID)
+	})
+}
+
+// Approve numbers a draft settlement and releases it to payroll.
+// Numbers are taken inside the transaction so the sequence has no gaps.
+func (uc *SettlementUseCase) Approve(ctx context.Context, id, approvedBy uuid.UUID) (*SettlementOutput, error) {
+	return uc.change(ctx, id, func(ctx context.Context, s *entity.DriverSettlement, now time.Time) error {
+		if s.Status != entity.SettlementStatusDraft {
+			return errs.ErrInvalidStatusTransition
+		}
+		number, err := uc.numbering.Next(ctx, uuid.Nil, entity.DocumentDriverSettlement, now)
+		if err != nil {
+			return fmt.Errorf("number generator: next: %w", err)
+		}
+		return s.Approve(number, approvedBy, now)
+	})
+}
+
+// Void cancels a settlement so its trips can be settled again. Approved numbers stay used.
+func (uc *SettlementUseCase) Void(ctx context.Context, id uuid.UUID, reason string) (*SettlementOutput, error) {
+	return uc.change(ctx, id, func(_ context.Context, s *entity.DriverSettlement, now time.Time) error {
+		return s.Void(reason, now)
+	})
+}
+
+// Get returns a settlement with its lines and adjustments by ID
+func (uc *SettlementUseCase) Get(ctx context.Context, id uuid.UUID) (*SettlementOutput, error) {
+	s, err := uc.settlementRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("driver settlement repository: find by id: %w", err)
+	}
+	return toSettlementOutput(s), nil
+}
+
+// List returns settlements matching the input criteria
+func (uc *SettlementUseCase) List(ctx context.Context, input ListSettlementsInput) ([]*SettlementOutput, int64, error) {
+	settlements, total, err := uc.settlementRepo.List(ctx, repository.DriverSettlementFilter{
+		DriverID: input.DriverID,
+		Status:   input.Status,
+		From:     input.From,
+		To:       input.To,
+		Search:   input.Search,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("driver settlement repository: list settlements: %w", err)
+	}
+
+	outputs := make([]*SettlementOutput, len(settlements))
+	for i, s := range settlements {
+		outputs[i] = toSettlementOutput(s)
+	}
+	return outputs, total, nil
+}
+
+// Export writes the approved settlements whose period overlaps the pay period as a payroll CSV,
+// one row per settlement with the pay components summed over its trips. The file starts with a
+// byte order mark so spreadsheet programs read Thai names as UTF-8.
+func (uc *SettlementUseCase) Export(ctx context.Context, input ExportInput) (*ExportOutput, error) {
+	periodFrom := timeutil.CalendarDay(input.PeriodFrom)
+	periodTo := timeutil.CalendarDay(input.PeriodTo)
+	if periodTo.Before(periodFrom) {
+		return nil, errs.ValidationErrors{"period_to": {"before_period_from"}}
+	}
+
+	status := entity.SettlementStatusApproved
+	filter := repository.DriverSettlementFilter{Status: &status, From: &periodFrom, To: &periodTo}
+	var settlements []*entity.DriverSettlement
+	for {
+		page, total, err := uc.settlementRepo.List(ctx, filter, exportPageSize, len(settlements))
+		if err != nil {
+			return nil, fmt.Errorf("driver settlement repository: list settlements: %w", err)
+		}
+		settlements = append(settlements, page...)
+		if len(page) == 0 || int64(len(settlements)) >= total {
+			break
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{
+		"settlement_number", "driver_id", "driver_name", "license_number", "employment_type",
+		"period_from", "period_to", "trips", "distance_km", "base_pay", "distance_pay", "drop_pay",
+		"overnight_pay", "overtime_pay", "gross_pay", "advances", "deductions", "net_pay", "approved_at",
+	})
+
+	drivers := make(map[uuid.UUID]*entity.Driver)
+	for i := len(settlements) - 1; i >= 0; i-- { // oldest first
+		s := settlements[i]
+		driver, ok := drivers[s.DriverID]
+		if !ok {
+			var err error
+			driver, err = uc.driverRepo.FindByID(ctx, s.DriverID)
+			if err != nil && !errors.Is(err, errs.ErrNotFound) {
+				return nil, fmt.Errorf("driver repository: find by id: %w", err)
+			}
+			drivers[s.DriverID] = driver
+		}
+
+		var name, license, employment string
+		if driver != nil {
+			license, employment = driver.LicenseNumber, string(driver.EmploymentType)
+			if driver.User != nil {
+				name = strings.TrimSpace(driver.User.FirstName + " " + driver.User.LastName)
+			}
+		}
+
+		var distance float64
+		var base, distancePay, drops, overnight, overtime entity.Money
+		for _, l := range s.Lines {
+			distance += l.DistanceKm
+			base += l.BasePay
+			distancePay += l.DistancePay
+			drops += l.DropPay
+			overnight += l.OvernightPay
+			overtime += l.OvertimePay
+		}
+
+		approvedAt := ""
+		if s.ApprovedAt != nil {
+			approvedAt = s.ApprovedAt.In(timeutil.Thailand).Format(time.RFC3339)
+		}
+		_ = w.Write([]string{
+			s.Number, s.DriverID.String(), name, license, employment,
+			s.PeriodFrom.Format(time.DateOnly), s.PeriodTo.Format(time.DateOnly), strconv.Itoa(len(s.Lines)),
+			strconv.FormatFloat(math.Round(distance*10)/10, 'f', 1, 64),
+			base.String(), distancePay.String(), drops.String(), overnight.String(), overtime.String(),
+			s.GrossPay.String(), s.Advances.String(), s.Deductions.String(), s.NetPay.String(), approvedAt,
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("write payroll csv: %w", err)
+	}
+
+	return &ExportOutput{
+		Filename: fmt.Sprintf("driver-payroll-%s-%s.csv", periodFrom.Format("20060102"), periodTo.Format("20060102")),
+		Content:  buf.Bytes(),
+		Count:    len(settlements),
+	}, nil
+}
+
+// change loads and locks a settlement, applies fn and saves the result in one transaction
+func (uc *SettlementUseCase) change(ctx context.Context, id uuid.UUID, fn func(context.Context, *entity.DriverSettlement, time.Time) error) (*SettlementOutput, error) {
+	var s *entity.DriverSettlement
+
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		s, err = uc.settlementRepo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return errs.ErrNotFound
+			}
+			return fmt.Errorf("driver settlement repository: find by id for update: %w", err)
+		}
+
+		if err := fn(ctx, s, time.Now()); err != nil {
+			return err
+		}
+		if err := uc.settlementRepo.Update(ctx, s); err != nil {
+			return fmt.Errorf("driver settlement repository: update settlement: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSettlementOutput(s), nil
+}
+
+// addTrips measures each trip and pays it by the rule for its vehicle type, then works out the overtime
+// on the driver's working days
+func (uc *SettlementUseCase) addTrips(ctx context.Context, s *entity.DriverSettlement, trips []*entity.Trip) error {
+	var locationIDs []uuid.UUID
+	for _, t := range trips {
+		for _, stop := range t.Stops {
+			locationIDs = append(locationIDs, stop.LocationID)
+		}
+	}
+	locations, err := uc.locationRepo.FindByIDs(ctx, locationIDs)
+	if err != nil {
+		return fmt.Errorf("location repository: find by ids: %w", err)
+	}
+	byID := make(map[uuid.UUID]*entity.Location, len(locations))
+	for _, l := range locations {
+		byID[l.ID] = l
+	}
+
+	vehicleTypes := make(map[uuid.UUID]entity.VehicleType)
+	rules := make(map[uuid.UUID]*entity.DriverPayRule)
+	for _, t := range trips {
+		vehicleType, ok := vehicleTypes[t.VehicleID]
+		if !ok {
+			vehicle, err := uc.vehicleRepo.FindByID(ctx, t.VehicleID)
+			if err != nil && !errors.Is(err, errs.ErrNotFound) {
+				return fmt.Errorf("vehicle repository: find by id: %w", err)
+			}
+			if vehicle != nil {
+				vehicleType = vehicle.Type
+			}
+			vehicleTypes[t.VehicleID] = vehicleType
+		}
+
+		line := entity.SettlementLine{
+			TripID:      t.ID,
+			TripNumber:  t.Number,
+			CoDriver:    t.DriverID != s.DriverID,
+			VehicleType: vehicleType,
+		}
+		line.StartedAt, line.EndedAt = tripTimes(t)
+		for _, stop := range t.Stops {
+			if stop.Type == entity.StopTypeDelivery {
+				line.Drops++
+			}
+		}
+		if line.DistanceKm, err = uc.distanceKm(ctx, t, byID); err != nil {
+			return err
+		}
+
+		rule, err := uc.payRuleRepo.FindEffective(ctx, vehicleType, line.StartedAt)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return errs.ValidationErrors{"trips." + t.Number: {"no_pay_rule"}}
+			}
+			return fmt.Errorf("driver pay rule repository: find effective: %w", err)
+		}
+		s.AddTrip(line, rule, timeutil.Thailand)
+		rules[rule.ID] = rule
+	}
+	s.PayOvertime(rules, timeutil.Thailand)
+	return nil
+}
+
+// distanceKm estimates the road distance between consecutive stops, to 0.1 km.
+// Stops at locations without coordinates are left out.
+func (uc *SettlementUseCase) distanceKm(ctx context.Context, t *entity.Trip, locations map[uuid.UUID]*entity.Location) (float64, error) {
+	var meters float64
+	var prev *entity.Location
+	for _, stop := range t.Stops {
+		loc := locations[stop.LocationID]
+		if loc == nil || !loc.HasCoordinates() {
+			continue
+		}
+		if prev != nil && prev.ID != loc.ID {
+			m, _, err := uc.estimator.Estimate(ctx, *prev.Latitude, *prev.Longitude, *loc.Latitude, *loc.Longitude)
+			if err != nil {
+				return 0, fmt.Errorf("travel estimator: estimate: %w", err)
+			}
+			meters += m
+		}
+		prev = loc
+	}
+	return math.Round(meters/100) / 10, nil
+}
+
+// tripTimes returns when the work on a trip started and ended: the arrival at the first stop and the
+// departure from the last, falling back to the planned times where the driver did not record them
+func tripTimes(t *entity.Trip) (time.Time, time.Time) {
+	start, end := t.PlannedStart, t.PlannedEnd
+	if len(t.Stops) > 0 {
+		first, last := t.Stops[0], t.Stops[len(t.Stops)-1]
+		if first.ArrivedAt != nil {
+			start = *first.ArrivedAt
+		}
+		if last.DepartedAt != nil {
+			end = *last.DepartedAt
+		} else if last.ArrivedAt != nil {
+			end = *last.ArrivedAt
+		}
+	}
+	if end.Before(start) {
+		end = start
+	}
+	return start, end
+}
+
+func validateAdjustments(adjustments []AdjustmentInput) error {
+	validationErrs := make(errs.ValidationErrors)
+	for i, a := range adjustments {
+		if a.Kind != entity.AdjustmentAdvance && a.Kind != entity.AdjustmentDeduction {
+			validationErrs[fmt.Sprintf("adjustments[%d].kind", i)] = []string{"invalid"}
+		}
+		if strings.TrimSpace(a.Description) == "" {
+			validationErrs[fmt.Sprintf("adjustments[%d].description", i)] = []string{"required"}
+		}
+		if a.Amount <= 0 {
+			validationErrs[fmt.Sprintf("adjustments[%d].amount", i)] = []string{"must_be_positive"}
+		}
+	}
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+	return nil
+}
+
+func toSettlementOutput(s *entity.DriverSettlement) *SettlementOutput {
+	lines := make([]SettlementLineOutput, len(s.Lines))
+	for i, l := range s.Lines {
+		lines[i] = SettlementLineOutput{
+			Sequence:      l.Sequence,
+			TripID:        l.TripID,
+			TripNumber:    l.TripNumber,
+			CoDriver:      l.CoDriver,
+			VehicleType:   l.VehicleType,
+			PayRuleID:     l.PayRuleID,
+			StartedAt:     l.StartedAt,
+			EndedAt:       l.EndedAt,
+			DistanceKm:    l.DistanceKm,
+			Drops:         l.Drops,
+			Overnights:    l.Overnights,
+			OvertimeHours: l.OvertimeHours,
+			BasePay:       l.BasePay,
+			DistancePay:   l.DistancePay,
+			DropPay:       l.DropPay,
+			OvernightPay:  l.OvernightPay,
+			OvertimePay:   l.OvertimePay,
+			Amount:        l.Amount,
+		}
+	}
+
+	adjustments := make([]AdjustmentOutput, len(s.Adjustments))
+	for i, a := range s.Adjustments {
+		adjustments[i] = AdjustmentOutput{
+			ID:          a.ID,
+			Kind:        a.Kind,
+			Description: a.Description,
+			Amount:      a.Amount,
+			CreatedAt:   a.CreatedAt,
+		}
+	}
+
+	return &SettlementOutput{
+		ID:          s.ID,
+		Number:      s.Number,
+		DriverID:    s.DriverID,
+		Status:      s.Status,
+		PeriodFrom:  s.PeriodFrom,
+		PeriodTo:    s.PeriodTo,
+		Lines:       lines,
+		Adjustments: adjustments,
+		GrossPay:    s.GrossPay,
+		Advances:    s.Advances,
+		Deductions:  s.Deductions,
+		NetPay:      s.NetPay,
+		Notes:       s.Notes,
+		ApprovedAt:  s.ApprovedAt,
+		ApprovedBy:  s.ApprovedBy,
+		VoidedAt:    s.VoidedAt,
+		VoidReason:  s.VoidReason,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
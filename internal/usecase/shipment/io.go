@@ -0,0 +1,94 @@
+package shipment
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// ShipmentInput represents data for creating or updating a shipment
+type ShipmentInput struct {
+	Reference          string
+	PickupLocationID   uuid.UUID
+	DeliveryLocationID uuid.UUID
+	WeightKg           float64
+	VolumeM3           float64
+	Pallets            int
+	PickupFrom         *time.Time
+	PickupTo           *time.Time
+	DeliverFrom        *time.Time
+	DeliverTo          *time.Time
+	Notes              string
+}
+
+// ListShipmentsInput represents criteria for listing shipments
+type ListShipmentsInput struct {
+	OrganizationID *uuid.UUID
+	Status         *entity.ShipmentStatus
+	Search         string
+	Limit          int
+	Offset         int
+}
+
+// ShipmentOutput represents shipment output data
+type ShipmentOutput struct {
+	ID                 uuid.UUID
+	OrganizationID     uuid.UUID
+	TrackingNumber     string
+	Reference          string
+	PickupLocationID   uuid.UUID
+	DeliveryLocationID uuid.UUID
+	WeightKg           float64
+	VolumeM3           float64
+	Pallets            int
+	PickupFrom         *time.Time
+	PickupTo           *time.Time
+	DeliverFrom        *time.Time
+	DeliverTo          *time.Time
+	Status             entity.ShipmentStatus
+	Notes              string
+	ParentID           *uuid.UUID
+	Leg                entity.ShipmentLeg
+	Attempt            int
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// Kinds of shipment timeline events
+const (
+	TimelineCreated        = "created"
+	TimelineStatusChanged  = "status_changed"
+	TimelineStopArrived    = "stop_arrived"
+	TimelineStopDeparted   = "stop_departed"
+	TimelineDelayed        = "delayed"
+	TimelineIncident       = "incident"
+	TimelineDeliveryFailed = "delivery_failed"
+)
+
+// TimelineIncidentOutput summarizes an open incident on a shipment timeline
+type TimelineIncidentOutput struct {
+	ID       uuid.UUID
+	Number   string
+	Category entity.IncidentCategory
+	Severity entity.IncidentSeverity
+	Status   entity.IncidentStatus
+	DueAt    time.Time
+	Overdue  bool
+}
+
+// TimelineEventOutput represents one event in the life of a shipment. Status is set on status changes,
+// StopType on stop events, ETA on delays, Incident on incidents, and FailureReason and LegID on failed deliveries.
+type TimelineEventOutput struct {
+	Type          string
+	OccurredAt    time.Time
+	Status        entity.ShipmentStatus
+	TripID        *uuid.UUID
+	StopID        *uuid.UUID
+	StopType      entity.StopType
+	ETA           *time.Time
+	Incident      *TimelineIncidentOutput
+	FailureReason entity.FailureReason
+	LegID         *uuid.UUID // the reattempt or return leg booked after a failed delivery
+}
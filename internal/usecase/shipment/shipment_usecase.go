@@ -0,0 +1,339 @@
+package shipment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+// timelineIncidentLimit bounds how many open incidents a shipment timeline shows
+const timelineIncidentLimit = 100
+
+// ShipmentUseCase handles shipment order operations
+type ShipmentUseCase struct {
+	shipmentRepo repository.ShipmentRepository
+	orgRepo      repository.OrganizationRepository
+	locationRepo repository.LocationRepository
+	tripRepo     repository.TripRepository
+	delayRepo    repository.StopDelayRepository
+	incidentRepo repository.IncidentRepository
+	attemptRepo  repository.DeliveryAttemptRepository
+	numbering    service.NumberGenerator
+}
+
+// NewShipmentUseCase creates a new shipment use case
+func NewShipmentUseCase(
+	shipmentRepo repository.ShipmentRepository,
+	orgRepo repository.OrganizationRepository,
+	locationRepo repository.LocationRepository,
+	tripRepo repository.TripRepository,
+	delayRepo repository.StopDelayRepository,
+	incidentRepo repository.IncidentRepository,
+	attemptRepo repository.DeliveryAttemptRepository,
+	numbering service.NumberGenerator,
+) *ShipmentUseCase {
+	return &ShipmentUseCase{
+		shipmentRepo: shipmentRepo,
+		orgRepo:      orgRepo,
+		locationRepo: locationRepo,
+		tripRepo:     tripRepo,
+		delayRepo:    delayRepo,
+		incidentRepo: incidentRepo,
+		attemptRepo:  attemptRepo,
+		numbering:    numbering,
+	}
+}
+
+// Create books a new shipment for an organization
+func (uc *ShipmentUseCase) Create(ctx context.Context, organizationID uuid.UUID, input ShipmentInput) (*ShipmentOutput, error) {
+	_, err := uc.orgRepo.FindByID(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("organization repository: find by id: %w", err)
+	}
+
+	shipment := &entity.Shipment{
+		OrganizationID: organizationID,
+		Status:         entity.ShipmentStatusPending,
+		Leg:            entity.ShipmentLegOriginal,
+		Attempt:        1,
+	}
+	if err := uc.applyInput(ctx, shipment, input); err != nil {
+		return nil, err
+	}
+
+	// tracking numbers need not be gap-free, so the number is issued on its own rather than with the shipment
+	shipment.TrackingNumber, err = uc.numbering.Next(ctx, organizationID, entity.DocumentShipment, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("number generator: next tracking number: %w", err)
+	}
+	if err := uc.shipmentRepo.Create(ctx, shipment); err != nil {
+		return nil, fmt.Errorf("shipment repository: create shipment: %w", err)
+	}
+
+	return toShipmentOutput(shipment), nil
+}
+
+// Get returns a shipment by ID
+func (uc *ShipmentUseCase) Get(ctx context.Context, id uuid.UUID) (*ShipmentOutput, error) {
+	shipment, err := uc.findShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toShipmentOutput(shipment), nil
+}
+
+// List returns shipments matching the input criteria
+func (uc *ShipmentUseCase) List(ctx context.Context, input ListShipmentsInput) ([]*ShipmentOutput, int64, error) {
+	shipments, total, err := uc.shipmentRepo.List(ctx, repository.ShipmentFilter{
+		OrganizationID: input.OrganizationID,
+		Status:         input.Status,
+		Search:         input.Search,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("shipment repository: list shipments: %w", err)
+	}
+
+	outputs := make([]*ShipmentOutput, len(shipments))
+	for i, s := range shipments {
+		outputs[i] = toShipmentOutput(s)
+	}
+	return outputs, total, nil
+}
+
+// Update updates a shipment that has not been planned yet
+func (uc *ShipmentUseCase) Update(ctx context.Context, id uuid.UUID, input ShipmentInput) (*ShipmentOutput, error) {
+	shipment, err := uc.findShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if shipment.Status != entity.ShipmentStatusPending {
+		return nil, errs.ErrResourceLocked
+	}
+
+	if err := uc.applyInput(ctx, shipment, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.shipmentRepo.Update(ctx, shipment); err != nil {
+		return nil, fmt.Errorf("shipment repository: update shipment: %w", err)
+	}
+
+	return toShipmentOutput(shipment), nil
+}
+
+// Delete removes a shipment that has not been planned yet
+func (uc *ShipmentUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+	shipment, err := uc.findShipment(ctx, id)
+	if err != nil {
+		return err
+	}
+	if shipment.Status != entity.ShipmentStatusPending {
+		return errs.ErrResourceLocked
+	}
+
+	if err := uc.shipmentRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("shipment repository: delete shipment: %w", err)
+	}
+	return nil
+}
+
+// Timeline returns the events in the life of a shipment, newest first: its status changes, the arrivals and
+// departures of its trip stops, detected delays, a failed delivery and its open incidents
+func (uc *ShipmentUseCase) Timeline(ctx context.Context, id uuid.UUID) ([]TimelineEventOutput, error) {
+	shipment, err := uc.findShipment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := uc.shipmentRepo.ListStatusHistory(ctx, shipment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("shipment repository: list status history: %w", err)
+	}
+	events := []TimelineEventOutput{{Type: TimelineCreated, OccurredAt: shipment.CreatedAt}}
+	for _, change := range history {
+		events = append(events, TimelineEventOutput{
+			Type:       TimelineStatusChanged,
+			OccurredAt: change.ChangedAt,
+			Status:     change.Status,
+		})
+	}
+
+	trip, err := uc.tripRepo.FindByShipment(ctx, shipment.ID)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return nil, fmt.Errorf("trip repository: find by shipment: %w", err)
+	}
+	if trip != nil {
+		for i := range trip.Stops {
+			stop := &trip.Stops[i]
+			if stop.ShipmentID != shipment.ID {
+				continue
+			}
+			if stop.ArrivedAt != nil {
+				events = append(events, stopEvent(TimelineStopArrived, *stop.ArrivedAt, stop))
+			}
+			if stop.DepartedAt != nil {
+				events = append(events, stopEvent(TimelineStopDeparted, *stop.DepartedAt, stop))
+			}
+		}
+
+		attempt, err := uc.attemptRepo.FindByShipmentID(ctx, shipment.ID)
+		if err != nil && !errors.Is(err, errs.ErrNotFound) {
+			return nil, fmt.Errorf("delivery attempt repository: find by shipment id: %w", err)
+		}
+		if attempt != nil {
+			stop, _ := trip.Stop(attempt.StopID)
+			event := stopEvent(TimelineDeliveryFailed, attempt.AttemptedAt, stop)
+			event.FailureReason = attempt.Reason
+			event.LegID = &attempt.LegID
+			events = append(events, event)
+		}
+
+		delays, err := uc.delayRepo.ListByTrip(ctx, trip.ID)
+		if err != nil {
+			return nil, fmt.Errorf("stop delay repository: list by trip: %w", err)
+		}
+		for _, d := range delays {
+			if d.ShipmentID != shipment.ID {
+				continue
+			}
+			stop, _ := trip.Stop(d.StopID)
+			event := stopEvent(TimelineDelayed, d.DetectedAt, stop)
+			eta := d.ETA
+			event.ETA = &eta
+			events = append(events, event)
+		}
+	}
+
+	incidents, _, err := uc.incidentRepo.List(ctx, repository.IncidentFilter{ShipmentID: &shipment.ID, Open: true}, timelineIncidentLimit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("incident repository: list incidents: %w", err)
+	}
+	now := time.Now()
+	for _, i := range incidents {
+		events = append(events, TimelineEventOutput{
+			Type:       TimelineIncident,
+			OccurredAt: i.OccurredAt,
+			TripID:     i.TripID,
+			StopID:     i.StopID,
+			Incident: &TimelineIncidentOutput{
+				ID:       i.ID,
+				Number:   i.Number,
+				Category: i.Category,
+				Severity: i.Severity,
+				Status:   i.Status,
+				DueAt:    i.DueAt,
+				Overdue:  i.Overdue(now),
+			},
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt.After(events[j].OccurredAt)
+	})
+	return events, nil
+}
+
+// applyInput checks that both locations are in the organization's address book and copies the input
+func (uc *ShipmentUseCase) applyInput(ctx context.Context, shipment *entity.Shipment, input ShipmentInput) error {
+	locations, err := uc.locationRepo.FindByIDs(ctx, []uuid.UUID{input.PickupLocationID, input.DeliveryLocationID})
+	if err != nil {
+		return fmt.Errorf("location repository: find by ids: %w", err)
+	}
+	owned := make(map[uuid.UUID]bool, len(locations))
+	for _, l := range locations {
+		owned[l.ID] = l.OrganizationID == shipment.OrganizationID
+	}
+
+	validationErrs := make(errs.ValidationErrors)
+	if !owned[input.PickupLocationID] {
+		validationErrs["pickup_location_id"] = append(validationErrs["pickup_location_id"], "unknown_location")
+	}
+	if !owned[input.DeliveryLocationID] {
+		validationErrs["delivery_location_id"] = append(validationErrs["delivery_location_id"], "unknown_location")
+	}
+	if input.PickupFrom != nil && input.PickupTo != nil && input.PickupTo.Before(*input.PickupFrom) {
+		validationErrs["pickup_to"] = append(validationErrs["pickup_to"], "before_pickup_from")
+	}
+	if input.DeliverFrom != nil && input.DeliverTo != nil && input.DeliverTo.Before(*input.DeliverFrom) {
+		validationErrs["deliver_to"] = append(validationErrs["deliver_to"], "before_deliver_from")
+	}
+	if len(validationErrs) > 0 {
+		return validationErrs
+	}
+
+	shipment.Reference = input.Reference
+	shipment.PickupLocationID = input.PickupLocationID
+	shipment.DeliveryLocationID = input.DeliveryLocationID
+	shipment.WeightKg = input.WeightKg
+	shipment.VolumeM3 = input.VolumeM3
+	shipment.Pallets = input.Pallets
+	shipment.PickupFrom = input.PickupFrom
+	shipment.PickupTo = input.PickupTo
+	shipment.DeliverFrom = input.DeliverFrom
+	shipment.DeliverTo = input.DeliverTo
+	shipment.Notes = input.Notes
+	return nil
+}
+
+// findShipment loads a shipment and normalizes repository errors
+func (uc *ShipmentUseCase) findShipment(ctx context.Context, id uuid.UUID) (*entity.Shipment, error) {
+	shipment, err := uc.shipmentRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("shipment repository: find by id: %w", err)
+	}
+	return shipment, nil
+}
+
+func toShipmentOutput(s *entity.Shipment) *ShipmentOutput {
+	return &ShipmentOutput{
+		ID:                 s.ID,
+		OrganizationID:     s.OrganizationID,
+		TrackingNumber:     s.TrackingNumber,
+		Reference:          s.Reference,
+		PickupLocationID:   s.PickupLocationID,
+		DeliveryLocationID: s.DeliveryLocationID,
+		WeightKg:           s.WeightKg,
+		VolumeM3:           s.VolumeM3,
+		Pallets:            s.Pallets,
+		PickupFrom:         s.PickupFrom,
+		PickupTo:           s.PickupTo,
+		DeliverFrom:        s.DeliverFrom,
+		DeliverTo:          s.DeliverTo,
+		Status:             s.Status,
+		Notes:              s.Notes,
+		ParentID:           s.ParentID,
+		Leg:                s.Leg,
+		Attempt:            s.Attempt,
+		CreatedAt:          s.CreatedAt,
+		UpdatedAt:          s.UpdatedAt,
+	}
+}
+
+// stopEvent builds a timeline event at a trip stop; the stop may be nil when it was removed from the trip
+func stopEvent(eventType string, at time.Time, stop *entity.TripStop) TimelineEventOutput {
+	event := TimelineEventOutput{Type: eventType, OccurredAt: at}
+	if stop != nil {
+		event.TripID = &stop.TripID
+		event.StopID = &stop.ID
+		event.StopType = stop.Type
+	}
+	return event
+}
@@ -0,0 +1,93 @@
+package shipment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+type fakeOrganizationRepo struct {
+	repository.OrganizationRepository
+}
+
+func (fakeOrganizationRepo) FindByID(_ context.Context, id uuid.UUID) (*entity.Organization, error) {
+	return &entity.Organization{ID: id}, nil
+}
+
+type fakeLocationRepo struct {
+	repository.LocationRepository
+	organizationID uuid.UUID
+}
+
+func (r fakeLocationRepo) FindByIDs(_ context.Context, ids []uuid.UUID) ([]*entity.Location, error) {
+	locations := make([]*entity.Location, len(ids))
+	for i, id := range ids {
+		locations[i] = &entity.Location{ID: id, OrganizationID: r.organizationID}
+	}
+	return locations, nil
+}
+
+// takenNumbers refuses tracking numbers that are already in use, as the unique index does
+type takenNumbers struct {
+	repository.ShipmentRepository
+	taken map[string]bool
+}
+
+func (r *takenNumbers) Create(_ context.Context, s *entity.Shipment) error {
+	if r.taken[s.TrackingNumber] {
+		return errs.ErrConflict
+	}
+	r.taken[s.TrackingNumber] = true
+	s.ID = uuid.New()
+	return nil
+}
+
+type sequence struct {
+	service.NumberGenerator
+	next int
+}
+
+func (g *sequence) Next(context.Context, uuid.UUID, entity.DocumentType, time.Time) (string, error) {
+	g.next++
+	return fmt.Sprintf("TH%06d", g.next), nil
+}
+
+func TestCreateIssuesOneTrackingNumber(t *testing.T) {
+	organizationID := uuid.New()
+	numbers := &sequence{}
+	uc := NewShipmentUseCase(&takenNumbers{taken: map[string]bool{}}, fakeOrganizationRepo{}, fakeLocationRepo{organizationID: organizationID}, nil, nil, nil, nil, numbers)
+
+	for _, want := range []string{"TH000001", "TH000002"} {
+		output, err := uc.Create(context.Background(), organizationID, ShipmentInput{PickupLocationID: uuid.New(), DeliveryLocationID: uuid.New()})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if output.TrackingNumber != want {
+			t.Errorf("tracking number = %s, want %s", output.TrackingNumber, want)
+		}
+	}
+}
+
+func TestCreateDoesNotRetryATakenTrackingNumber(t *testing.T) {
+	organizationID := uuid.New()
+	numbers := &sequence{}
+	repo := &takenNumbers{taken: map[string]bool{"TH000001": true}}
+	uc := NewShipmentUseCase(repo, fakeOrganizationRepo{}, fakeLocationRepo{organizationID: organizationID}, nil, nil, nil, nil, numbers)
+
+	_, err := uc.Create(context.Background(), organizationID, ShipmentInput{PickupLocationID: uuid.New(), DeliveryLocationID: uuid.New()})
+	if !errors.Is(err, errs.ErrConflict) {
+		t.Errorf("err = %v, want ErrConflict", err)
+	}
+	if numbers.next != 1 {
+		t.Errorf("issued %d numbers, want 1: sequences do not collide, so a taken number is a bug to surface", numbers.next)
+	}
+}
@@ -0,0 +1,85 @@
+package tender
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// TenderInput represents a request to offer a shipment or a trip to carriers.
+// CarrierIDs are in waterfall order; a single carrier makes a direct tender.
+type TenderInput struct {
+	ShipmentID     *uuid.UUID
+	TripID         *uuid.UUID
+	CarrierIDs     []uuid.UUID
+	ResponseWindow time.Duration // zero uses DefaultResponseWindow
+	VehicleType    entity.VehicleType
+	Notes          string
+}
+
+// ListTendersInput represents criteria for listing tenders
+type ListTendersInput struct {
+	Status     *entity.TenderStatus
+	ShipmentID *uuid.UUID
+	TripID     *uuid.UUID
+	CarrierID  *uuid.UUID
+	Limit      int
+	Offset     int
+}
+
+// ListCarrierTendersInput represents criteria for a carrier listing the tenders offered to it
+type ListCarrierTendersInput struct {
+	Status *entity.TenderStatus
+	Limit  int
+	Offset int
+}
+
+// OfferOutput represents one carrier's offer within a tender
+type OfferOutput struct {
+	ID          uuid.UUID
+	CarrierID   uuid.UUID
+	Rank        int
+	Status      entity.TenderOfferStatus
+	OfferedAt   *time.Time
+	Deadline    *time.Time
+	RespondedAt *time.Time
+	Reason      string
+}
+
+// EventOutput represents a recorded tender state change
+type EventOutput struct {
+	Type       entity.TenderEventType
+	CarrierID  *uuid.UUID
+	Note       string
+	OccurredAt time.Time
+}
+
+// TenderOutput represents tender output data for the shipper's planners
+type TenderOutput struct {
+	ID               uuid.UUID
+	ShipmentID       *uuid.UUID
+	TripID           *uuid.UUID
+	Status           entity.TenderStatus
+	ResponseWindow   time.Duration
+	Summary          entity.TenderSummary
+	Notes            string
+	AwardedCarrierID *uuid.UUID
+	Offers           []OfferOutput
+	Events           []EventOutput
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// CarrierTenderOutput represents a tender as seen by a carrier: the work and its own offer only
+type CarrierTenderOutput struct {
+	ID         uuid.UUID
+	ShipmentID *uuid.UUID
+	TripID     *uuid.UUID
+	Status     entity.TenderStatus
+	Summary    entity.TenderSummary
+	Notes      string
+	Offer      OfferOutput
+	Awarded    bool
+}
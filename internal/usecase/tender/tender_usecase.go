@@ -0,0 +1,566 @@
+package tender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultResponseWindow is how long each carrier has to answer when the tender does not say
+	DefaultResponseWindow = 2 * time.Hour
+
+	// MinResponseWindow and MaxResponseWindow bound the time each carrier has to answer
+	MinResponseWindow = 5 * time.Minute
+	MaxResponseWindow = 72 * time.Hour
+
+	// MaxCarriers bounds the length of a waterfall
+	MaxCarriers = 20
+
+	// sweepBatchSize bounds how many overdue tenders one sweep rolls over
+	sweepBatchSize = 100
+)
+
+// TenderUseCase handles offering shipments and trips to carriers and the carriers' answers
+type TenderUseCase struct {
+	tenderRepo     repository.TenderRepository
+	carrierRepo    repository.CarrierRepository
+	shipmentRepo   repository.ShipmentRepository
+	tripRepo       repository.TripRepository
+	locationRepo   repository.LocationRepository
+	complianceRepo repository.ComplianceDocumentRepository
+	transactor     repository.Transactor
+	blockingTypes  []entity.ComplianceDocumentType
+}
+
+// NewTenderUseCase creates a new tender use case.
+// A carrier cannot be offered work while one of its documents of the blockingTypes has expired.
+func NewTenderUseCase(
+	tenderRepo repository.TenderRepository,
+	carrierRepo repository.CarrierRepository,
+	shipmentRepo repository.ShipmentRepository,
+	tripRepo repository.TripRepository,
+	locationRepo repository.LocationRepository,
+	complianceRepo repository.ComplianceDocumentRepository,
+	transactor repository.Transactor,
+	blockingTypes []entity.ComplianceDocumentType,
+) *TenderUseCase {
+	return &TenderUseCase{
+		tenderRepo:     tenderRepo,
+		carrierRepo:    carrierRepo,
+		shipmentRepo:   shipmentRepo,
+		tripRepo:       tripRepo,
+		locationRepo:   locationRepo,
+		complianceRepo: complianceRepo,
+		transactor:     transactor,
+		blockingTypes:  blockingTypes,
+	}
+}
+
+// Create offers a pending shipment or a planned trip to the first carrier of the waterfall
+func (uc *TenderUseCase) Create(ctx context.Context, input TenderInput) (*TenderOutput, error) {
+	window := input.ResponseWindow
+	if window == 0 {
+		window = DefaultResponseWindow
+	}
+	if err := validateInput(input, window); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tender := &entity.Tender{
+		ShipmentID:     input.ShipmentID,
+		TripID:         input.TripID,
+		ResponseWindow: window,
+		Notes:          input.Notes,
+	}
+
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if input.ShipmentID != nil {
+			tender.Summary, err = uc.shipmentSummary(ctx, *input.ShipmentID)
+		} else {
+			tender.Summary, err = uc.tripSummary(ctx, *input.TripID)
+		}
+		if err != nil {
+			return err
+		}
+		tender.Summary.VehicleType = input.VehicleType
+
+		if err := uc.checkCarriers(ctx, input.CarrierIDs, tender.Summary, now); err != nil {
+			return err
+		}
+
+		for i, id := range input.CarrierIDs {
+			tender.Offers = append(tender.Offers, entity.TenderOffer{
+				CarrierID: id,
+				Rank:      i + 1,
+				Status:    entity.TenderOfferQueued,
+			})
+		}
+		tender.Start(now)
+
+		if err := uc.tenderRepo.Create(ctx, tender); err != nil {
+			// the partial unique index allows one live tender per shipment or trip
+			if errors.Is(err, errs.ErrConflict) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("tender repository: create tender: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toTenderOutput(tender), nil
+}
+
+// Get returns a tender with its offers and history by ID
+func (uc *TenderUseCase) Get(ctx context.Context, id uuid.UUID) (*TenderOutput, error) {
+	tender, err := uc.findTender(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toTenderOutput(tender), nil
+}
+
+// List returns tenders matching the input criteria
+func (uc *TenderUseCase) List(ctx context.Context, input ListTendersInput) ([]*TenderOutput, int64, error) {
+	tenders, total, err := uc.tenderRepo.List(ctx, repository.TenderFilter{
+		Status:     input.Status,
+		ShipmentID: input.ShipmentID,
+		TripID:     input.TripID,
+		CarrierID:  input.CarrierID,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("tender repository: list tenders: %w", err)
+	}
+
+	outputs := make([]*TenderOutput, len(tenders))
+	for i, t := range tenders {
+		outputs[i] = toTenderOutput(t)
+	}
+	return outputs, total, nil
+}
+
+// Cancel withdraws an open tender
+func (uc *TenderUseCase) Cancel(ctx context.Context, id uuid.UUID, reason string) (*TenderOutput, error) {
+	return uc.change(ctx, id, func(_ context.Context, tender *entity.Tender, now time.Time) error {
+		return tender.Cancel(reason, now)
+	})
+}
+
+// ExpireOverdue rolls over every open tender whose current offer is past its deadline
+// and returns how many tenders changed. It is run periodically by a background worker.
+func (uc *TenderUseCase) ExpireOverdue(ctx context.Context) (int, error) {
+	ids, err := uc.tenderRepo.ListOverdueIDs(ctx, time.Now(), sweepBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("tender repository: list overdue ids: %w", err)
+	}
+
+	expired := 0
+	for _, id := range ids {
+		changed := false
+		err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+			tender, err := uc.tenderRepo.FindByIDForUpdate(ctx, id)
+			if err != nil {
+				return fmt.Errorf("tender repository: find by id for update: %w", err)
+			}
+			// The carrier may have answered since the overdue list was read
+			if changed = tender.ExpireOverdue(time.Now()); !changed {
+				return nil
+			}
+			if err := uc.tenderRepo.Update(ctx, tender); err != nil {
+				return fmt.Errorf("tender repository: update tender: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return expired, err
+		}
+		if changed {
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+// ListForCarrier returns the tenders offered to the carrier the user acts for
+func (uc *TenderUseCase) ListForCarrier(ctx context.Context, userID uuid.UUID, input ListCarrierTendersInput) ([]*CarrierTenderOutput, int64, error) {
+	carrier, err := service.CarrierForUser(ctx, uc.carrierRepo, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tenders, total, err := uc.tenderRepo.List(ctx, repository.TenderFilter{
+		Status:    input.Status,
+		CarrierID: &carrier.ID,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("tender repository: list tenders: %w", err)
+	}
+
+	outputs := make([]*CarrierTenderOutput, len(tenders))
+	for i, t := range tenders {
+		outputs[i] = toCarrierTenderOutput(t, carrier.ID)
+	}
+	return outputs, total, nil
+}
+
+// GetForCarrier returns a tender offered to the carrier the user acts for.
+// Tenders not yet offered to the carrier are reported as not found.
+func (uc *TenderUseCase) GetForCarrier(ctx context.Context, userID, id uuid.UUID) (*CarrierTenderOutput, error) {
+	carrier, err := service.CarrierForUser(ctx, uc.carrierRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := uc.findTender(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !visibleTo(tender, carrier.ID) {
+		return nil, errs.ErrNotFound
+	}
+	return toCarrierTenderOutput(tender, carrier.ID), nil
+}
+
+// Accept awards the tender to the carrier the user acts for.
+// An accepted shipment is taken off the planning board by marking it planned.
+func (uc *TenderUseCase) Accept(ctx context.Context, userID, id uuid.UUID) (*CarrierTenderOutput, error) {
+	carrier, err := service.CarrierForUser(ctx, uc.carrierRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := uc.respond(ctx, carrier, id, func(ctx context.Context, tender *entity.Tender, now time.Time) error {
+		if err := carrier.EnsureTenderable(now, ""); err != nil {
+			return err
+		}
+		if err := tender.Accept(carrier.ID, now); err != nil {
+			return err
+		}
+		return uc.claimSubject(ctx, tender, carrier.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toCarrierTenderOutput(tender, carrier.ID), nil
+}
+
+// Reject records the refusal of the carrier the user acts for and rolls the tender over to the next carrier
+func (uc *TenderUseCase) Reject(ctx context.Context, userID, id uuid.UUID, reason string) (*CarrierTenderOutput, error) {
+	carrier, err := service.CarrierForUser(ctx, uc.carrierRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := uc.respond(ctx, carrier, id, func(_ context.Context, tender *entity.Tender, now time.Time) error {
+		return tender.Reject(carrier.ID, reason, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toCarrierTenderOutput(tender, carrier.ID), nil
+}
+
+// respond applies a carrier's answer, hiding tenders the carrier has not been offered
+func (uc *TenderUseCase) respond(ctx context.Context, carrier *entity.Carrier, id uuid.UUID, apply func(context.Context, *entity.Tender, time.Time) error) (*entity.Tender, error) {
+	var result *entity.Tender
+	_, err := uc.change(ctx, id, func(ctx context.Context, tender *entity.Tender, now time.Time) error {
+		if !visibleTo(tender, carrier.ID) {
+			return errs.ErrNotFound
+		}
+		result = tender
+		return apply(ctx, tender, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// change loads and locks a tender, applies fn and saves the result in one transaction
+func (uc *TenderUseCase) change(ctx context.Context, id uuid.UUID, fn func(context.Context, *entity.Tender, time.Time) error) (*TenderOutput, error) {
+	var tender *entity.Tender
+
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		tender, err = uc.tenderRepo.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return errs.ErrNotFound
+			}
+			return fmt.Errorf("tender repository: find by id for update: %w", err)
+		}
+
+		if err := fn(ctx, tender, time.Now()); err != nil {
+			return err
+		}
+		if err := uc.tenderRepo.Update(ctx, tender); err != nil {
+			return fmt.Errorf("tender repository: update tender: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toTenderOutput(tender), nil
+}
+
+// claimSubject takes the accepted shipment off the planning board, or records the award on the
+// accepted trip so that it can neither be edited nor tendered again. The shipment or trip is locked
+// and must still be plannable: it may have been planned or cancelled while the tender was open.
+func (uc *TenderUseCase) claimSubject(ctx context.Context, tender *entity.Tender, carrierID uuid.UUID) error {
+	if tender.TripID != nil {
+		trip, err := uc.tripRepo.FindByIDForUpdate(ctx, *tender.TripID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return errs.ErrResourceLocked
+			}
+			return fmt.Errorf("trip repository: find by id for update: %w", err)
+		}
+		if !trip.IsEditable() {
+			return errs.ErrResourceLocked
+		}
+		if err := uc.tripRepo.UpdateCarrier(ctx, trip.ID, carrierID, tender.ID); err != nil {
+			return fmt.Errorf("trip repository: update carrier: %w", err)
+		}
+		return nil
+	}
+
+	shipment, err := uc.shipmentRepo.FindByIDForUpdate(ctx, *tender.ShipmentID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrShipmentUnavailable
+		}
+		return fmt.Errorf("shipment repository: find by id for update: %w", err)
+	}
+	if shipment.Status != entity.ShipmentStatusPending {
+		return errs.ErrShipmentUnavailable
+	}
+	if err := uc.shipmentRepo.UpdateStatus(ctx, []uuid.UUID{shipment.ID}, entity.ShipmentStatusPlanned); err != nil {
+		return fmt.Errorf("shipment repository: update status: %w", err)
+	}
+	return nil
+}
+
+// shipmentSummary locks the shipment so that it cannot be planned while the tender is created
+func (uc *TenderUseCase) shipmentSummary(ctx context.Context, id uuid.UUID) (entity.TenderSummary, error) {
+	shipment, err := uc.shipmentRepo.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return entity.TenderSummary{}, errs.ErrNotFound
+		}
+		return entity.TenderSummary{}, fmt.Errorf("shipment repository: find by id for update: %w", err)
+	}
+	if shipment.Status != entity.ShipmentStatusPending {
+		return entity.TenderSummary{}, errs.ErrShipmentUnavailable
+	}
+
+	provinces, err := uc.provinces(ctx, shipment.PickupLocationID, shipment.DeliveryLocationID)
+	if err != nil {
+		return entity.TenderSummary{}, err
+	}
+
+	return entity.TenderSummary{
+		OriginProvince:      provinces[shipment.PickupLocationID],
+		DestinationProvince: provinces[shipment.DeliveryLocationID],
+		PickupAt:            shipment.PickupFrom,
+		DeliverBy:           shipment.DeliverTo,
+		Load:                shipment.Load(),
+	}, nil
+}
+
+// tripSummary locks the trip so that it cannot be edited while the tender is created
+func (uc *TenderUseCase) tripSummary(ctx context.Context, id uuid.UUID) (entity.TenderSummary, error) {
+	trip, err := uc.tripRepo.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return entity.TenderSummary{}, errs.ErrNotFound
+		}
+		return entity.TenderSummary{}, fmt.Errorf("trip repository: find by id for update: %w", err)
+	}
+	if !trip.IsEditable() {
+		return entity.TenderSummary{}, errs.ErrResourceLocked
+	}
+
+	found, err := uc.shipmentRepo.FindByIDs(ctx, trip.ShipmentIDs())
+	if err != nil {
+		return entity.TenderSummary{}, fmt.Errorf("shipment repository: find by ids: %w", err)
+	}
+	shipments := make(map[uuid.UUID]*entity.Shipment, len(found))
+	for _, s := range found {
+		shipments[s.ID] = s
+	}
+
+	summary := entity.TenderSummary{
+		PickupAt:  &trip.PlannedStart,
+		DeliverBy: &trip.PlannedEnd,
+		Load:      trip.PeakLoad(shipments),
+	}
+	if len(trip.Stops) > 0 {
+		first, last := trip.Stops[0].LocationID, trip.Stops[len(trip.Stops)-1].LocationID
+		provinces, err := uc.provinces(ctx, first, last)
+		if err != nil {
+			return entity.TenderSummary{}, err
+		}
+		summary.OriginProvince = provinces[first]
+		summary.DestinationProvince = provinces[last]
+	}
+	return summary, nil
+}
+
+func (uc *TenderUseCase) provinces(ctx context.Context, ids ...uuid.UUID) (map[uuid.UUID]string, error) {
+	locations, err := uc.locationRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("location repository: find by ids: %w", err)
+	}
+	provinces := make(map[uuid.UUID]string, len(locations))
+	for _, l := range locations {
+		provinces[l.ID] = l.Province
+	}
+	return provinces, nil
+}
+
+// checkCarriers verifies every carrier of the waterfall exists and can take the work, and that none of their
+// blocking compliance documents has expired
+func (uc *TenderUseCase) checkCarriers(ctx context.Context, ids []uuid.UUID, summary entity.TenderSummary, now time.Time) error {
+	carriers, err := uc.carrierRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("carrier repository: find by ids: %w", err)
+	}
+	if len(carriers) != len(ids) {
+		return errs.ErrNotFound
+	}
+
+	var provinces []string
+	for _, p := range []string{summary.OriginProvince, summary.DestinationProvince} {
+		if p != "" {
+			provinces = append(provinces, p)
+		}
+	}
+	owners := make([]entity.ComplianceOwner, len(carriers))
+	for i, c := range carriers {
+		if err := c.EnsureTenderable(now, summary.VehicleType, provinces...); err != nil {
+			return err
+		}
+		owners[i] = entity.ComplianceOwner{Type: entity.ComplianceOwnerCarrier, ID: c.ID}
+	}
+
+	if len(uc.blockingTypes) == 0 {
+		return nil
+	}
+	expired, err := uc.complianceRepo.FindExpired(ctx, owners, uc.blockingTypes, timeutil.CalendarDay(now))
+	if err != nil {
+		return fmt.Errorf("compliance document repository: find expired: %w", err)
+	}
+	return entity.ComplianceExpiredError(expired)
+}
+
+func (uc *TenderUseCase) findTender(ctx context.Context, id uuid.UUID) (*entity.Tender, error) {
+	tender, err := uc.tenderRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("tender repository: find by id: %w", err)
+	}
+	return tender, nil
+}
+
+func validateInput(input TenderInput, window time.Duration) error {
+	if (input.ShipmentID == nil) == (input.TripID == nil) {
+		return errs.ValidationErrors{"shipment_id": {"exactly_one_of_shipment_or_trip"}}
+	}
+	if window < MinResponseWindow || window > MaxResponseWindow {
+		return errs.ValidationErrors{"response_window_minutes": {"out_of_range"}}
+	}
+	if len(input.CarrierIDs) == 0 {
+		return errs.ValidationErrors{"carrier_ids": {"required"}}
+	}
+	if len(input.CarrierIDs) > MaxCarriers {
+		return errs.ValidationErrors{"carrier_ids": {"too_many"}}
+	}
+
+	seen := make(map[uuid.UUID]bool, len(input.CarrierIDs))
+	for i, id := range input.CarrierIDs {
+		if seen[id] {
+			return errs.ValidationErrors{fmt.Sprintf("carrier_ids[%d]", i): {"duplicate"}}
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// visibleTo reports whether the carrier has been offered the tender; queued offers stay hidden
+func visibleTo(tender *entity.Tender, carrierID uuid.UUID) bool {
+	offer := tender.OfferTo(carrierID)
+	return offer != nil && offer.Status != entity.TenderOfferQueued
+}
+
+func toOfferOutput(o entity.TenderOffer) OfferOutput {
+	return OfferOutput{
+		ID:          o.ID,
+		CarrierID:   o.CarrierID,
+		Rank:        o.Rank,
+		Status:      o.Status,
+		OfferedAt:   o.OfferedAt,
+		Deadline:    o.Deadline,
+		RespondedAt: o.RespondedAt,
+		Reason:      o.Reason,
+	}
+}
+
+func toTenderOutput(t *entity.Tender) *TenderOutput {
+	offers := make([]OfferOutput, len(t.Offers))
+	for i, o := range t.Offers {
+		offers[i] = toOfferOutput(o)
+	}
+	events := make([]EventOutput, len(t.Events))
+	for i, e := range t.Events {
+		events[i] = EventOutput{Type: e.Type, CarrierID: e.CarrierID, Note: e.Note, OccurredAt: e.OccurredAt}
+	}
+
+	return &TenderOutput{
+		ID:               t.ID,
+		ShipmentID:       t.ShipmentID,
+		TripID:           t.TripID,
+		Status:           t.Status,
+		ResponseWindow:   t.ResponseWindow,
+		Summary:          t.Summary,
+		Notes:            t.Notes,
+		AwardedCarrierID: t.AwardedCarrierID,
+		Offers:           offers,
+		Events:           events,
+		CreatedAt:        t.CreatedAt,
+		UpdatedAt:        t.UpdatedAt,
+	}
+}
+
+func toCarrierTenderOutput(t *entity.Tender, carrierID uuid.UUID) *CarrierTenderOutput {
+	out := &CarrierTenderOutput{
+		ID:         t.ID,
+		ShipmentID: t.ShipmentID,
+		TripID:     t.TripID,
+		Status:     t.Status,
+		Summary:    t.Summary,
+		Notes:      t.Notes,
+		Awarded:    t.AwardedCarrierID != nil && *t.AwardedCarrierID == carrierID,
+	}
+	if offer := t.OfferTo(carrierID); offer != nil {
+		out.Offer = toOfferOutput(*offer)
+	}
+	return out
+}
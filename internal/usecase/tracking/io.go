@@ -0,0 +1,72 @@
+package tracking
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PositionInput represents one GPS fix from a device. The vehicle is identified by its ID
+// or by the ID of the GPS device fitted to it.
+type PositionInput struct {
+	VehicleID  *uuid.UUID
+	DeviceID   string
+	RecordedAt time.Time
+	Latitude   float64
+	Longitude  float64
+	SpeedKmh   *float64
+	Heading    *float64
+	OdometerKm *float64
+}
+
+// RejectedPosition identifies a fix of the batch that was not accepted, by its index in the batch
+type RejectedPosition struct {
+	Index  int
+	Reason string
+}
+
+// IngestOutput represents the result of ingesting a batch of positions.
+// Duplicates within the batch are counted; repeats of fixes already stored are dropped silently.
+type IngestOutput struct {
+	Accepted   int
+	Duplicates int
+	Rejected   []RejectedPosition
+}
+
+// FleetPositionOutput represents the latest known position of a vehicle
+type FleetPositionOutput struct {
+	VehicleID     uuid.UUID
+	PlateNumber   string
+	PlateProvince string
+	Position      PositionOutput
+}
+
+// PositionOutput represents a GPS fix
+type PositionOutput struct {
+	RecordedAt time.Time
+	Latitude   float64
+	Longitude  float64
+	SpeedKmh   *float64
+	Heading    *float64
+	OdometerKm *float64
+}
+
+// TrackInput represents a request for a vehicle's track between two times
+type TrackInput struct {
+	VehicleID  uuid.UUID
+	From       time.Time
+	To         time.Time
+	ToleranceM float64 // Douglas-Peucker tolerance; zero returns every fix
+}
+
+// TrackOutput represents a vehicle's simplified track
+type TrackOutput struct {
+	VehicleID uuid.UUID
+	From      time.Time
+	To        time.Time
+	RawPoints int
+	DistanceM float64    // along the unsimplified track
+	Truncated bool       // the period had more than MaxTrackPoints fixes; only the earliest were used
+	NextFrom  *time.Time // when truncated, the time of the first fix left out; query again from there for the rest
+	Points    []PositionOutput
+}
@@ -0,0 +1,350 @@
+package tracking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"tms-core-service/internal/domain/cache"
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultToleranceM is the track simplification tolerance used when a query does not give one
+	DefaultToleranceM = 10.0
+
+	// MaxTrackRange bounds the period of a track query
+	MaxTrackRange = 7 * 24 * time.Hour
+
+	// MaxTrackPoints bounds how many fixes a track query reads
+	MaxTrackPoints = 100000
+
+	// maxClockSkew is how far in the future a device timestamp may be
+	maxClockSkew = 5 * time.Minute
+
+	// observerQueueLength is how many accepted batches may wait for the observers before Ingest blocks
+	observerQueueLength = 256
+
+	// observerTimeout bounds how long the observers may take over one batch
+	observerTimeout = 30 * time.Second
+)
+
+// Reasons a fix is rejected
+const (
+	ReasonUnknownVehicle = "unknown_vehicle"
+	ReasonUnknownDevice  = "unknown_device"
+	ReasonInFuture       = "in_future"
+	ReasonInvalid        = "invalid"
+)
+
+// PositionObserver is notified of every accepted batch of positions, e.g. to detect geofence events.
+// Observers run in the background, one batch at a time in the order the batches were accepted.
+type PositionObserver interface {
+	ObservePositions(ctx context.Context, positions []entity.VehiclePosition) error
+}
+
+// TrackingUseCase handles GPS position ingestion, live fleet positions and vehicle tracks
+type TrackingUseCase struct {
+	vehicleRepo   repository.VehicleRepository
+	positionRepo  repository.PositionRepository
+	positionCache cache.PositionCache
+	historyWriter service.PositionHistoryWriter
+	geometry      service.Geometry
+	observers     []PositionObserver
+	observed      chan []entity.VehiclePosition
+}
+
+// NewTrackingUseCase creates a new tracking use case
+func NewTrackingUseCase(
+	vehicleRepo repository.VehicleRepository,
+	positionRepo repository.PositionRepository,
+	positionCache cache.PositionCache,
+	historyWriter service.PositionHistoryWriter,
+	geometry service.Geometry,
+	observers ...PositionObserver,
+) *TrackingUseCase {
+	return &TrackingUseCase{
+		vehicleRepo:   vehicleRepo,
+		positionRepo:  positionRepo,
+		positionCache: positionCache,
+		historyWriter: historyWriter,
+		geometry:      geometry,
+		observers:     observers,
+		observed:      make(chan []entity.VehiclePosition, observerQueueLength),
+	}
+}
+
+// Run notifies the observers of accepted positions until ctx is cancelled
+func (uc *TrackingUseCase) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case positions := <-uc.observed:
+			uc.notify(ctx, positions)
+		}
+	}
+}
+
+// notify hands a batch to every observer; a failing observer does not keep the others from seeing it
+func (uc *TrackingUseCase) notify(ctx context.Context, positions []entity.VehiclePosition) {
+	ctx, cancel := context.WithTimeout(ctx, observerTimeout)
+	defer cancel()
+
+	for _, o := range uc.observers {
+		if err := o.ObservePositions(ctx, positions); err != nil && ctx.Err() == nil {
+			log.Printf("[ERROR] position observer %T: %v", o, err)
+		}
+	}
+}
+
+// Ingest accepts a batch of fixes. The latest position of each vehicle is updated at once unless
+// a newer fix is already known; history is written and observers are notified asynchronously.
+func (uc *TrackingUseCase) Ingest(ctx context.Context, inputs []PositionInput) (*IngestOutput, error) {
+	vehicles, devices, err := uc.resolveVehicles(ctx, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	type fixKey struct {
+		vehicleID uuid.UUID
+		at        int64
+	}
+	now := time.Now()
+	output := &IngestOutput{}
+	seen := make(map[fixKey]bool, len(inputs))
+	positions := make([]entity.VehiclePosition, 0, len(inputs))
+
+	for i, in := range inputs {
+		var vehicleID uuid.UUID
+		switch {
+		case in.VehicleID != nil:
+			if !vehicles[*in.VehicleID] {
+				output.Rejected = append(output.Rejected, RejectedPosition{Index: i, Reason: ReasonUnknownVehicle})
+				continue
+			}
+			vehicleID = *in.VehicleID
+		default:
+			id, ok := devices[in.DeviceID]
+			if !ok {
+				output.Rejected = append(output.Rejected, RejectedPosition{Index: i, Reason: ReasonUnknownDevice})
+				continue
+			}
+			vehicleID = id
+		}
+		if in.RecordedAt.After(now.Add(maxClockSkew)) {
+			output.Rejected = append(output.Rejected, RejectedPosition{Index: i, Reason: ReasonInFuture})
+			continue
+		}
+
+		position := entity.VehiclePosition{
+			VehicleID:  vehicleID,
+			DeviceID:   in.DeviceID,
+			RecordedAt: in.RecordedAt.UTC(),
+			Latitude:   in.Latitude,
+			Longitude:  in.Longitude,
+			SpeedKmh:   in.SpeedKmh,
+			Heading:    in.Heading,
+			OdometerKm: in.OdometerKm,
+			ReceivedAt: now,
+		}
+		// an invalid fix would fail the whole history batch it is written with
+		if !position.IsValid() {
+			output.Rejected = append(output.Rejected, RejectedPosition{Index: i, Reason: ReasonInvalid})
+			continue
+		}
+
+		key := fixKey{vehicleID, in.RecordedAt.UnixMicro()}
+		if seen[key] {
+			output.Duplicates++
+			continue
+		}
+		seen[key] = true
+		positions = append(positions, position)
+	}
+	output.Accepted = len(positions)
+	if len(positions) == 0 {
+		return output, nil
+	}
+
+	if _, err := uc.positionCache.SetLatest(ctx, newestPerVehicle(positions)); err != nil {
+		return nil, fmt.Errorf("position cache: set latest: %w", err)
+	}
+	if err := uc.historyWriter.Enqueue(ctx, positions); err != nil {
+		return nil, fmt.Errorf("position history writer: enqueue: %w", err)
+	}
+	if len(uc.observers) > 0 {
+		select {
+		case uc.observed <- positions:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return output, nil
+}
+
+// FleetPositions returns the latest positions of the given vehicles, or of every vehicle when none are given
+func (uc *TrackingUseCase) FleetPositions(ctx context.Context, vehicleIDs []uuid.UUID) ([]*FleetPositionOutput, error) {
+	var positions []entity.VehiclePosition
+	var err error
+	if len(vehicleIDs) > 0 {
+		positions, err = uc.positionCache.GetLatest(ctx, vehicleIDs)
+	} else {
+		positions, err = uc.positionCache.ListLatest(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("position cache: get latest: %w", err)
+	}
+
+	ids := make([]uuid.UUID, len(positions))
+	for i, p := range positions {
+		ids[i] = p.VehicleID
+	}
+	found, err := uc.vehicleRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("vehicle repository: find by ids: %w", err)
+	}
+	vehicles := make(map[uuid.UUID]*entity.Vehicle, len(found))
+	for _, v := range found {
+		vehicles[v.ID] = v
+	}
+
+	outputs := make([]*FleetPositionOutput, 0, len(positions))
+	for _, p := range positions {
+		v, ok := vehicles[p.VehicleID]
+		if !ok {
+			continue // deleted since it last reported
+		}
+		outputs = append(outputs, &FleetPositionOutput{
+			VehicleID:     v.ID,
+			PlateNumber:   v.PlateNumber,
+			PlateProvince: v.PlateProvince,
+			Position:      toPositionOutput(p),
+		})
+	}
+	sort.Slice(outputs, func(i, j int) bool { return outputs[i].PlateNumber < outputs[j].PlateNumber })
+	return outputs, nil
+}
+
+// Track returns a vehicle's track between two times, simplified with the Douglas-Peucker algorithm
+func (uc *TrackingUseCase) Track(ctx context.Context, input TrackInput) (*TrackOutput, error) {
+	if !input.To.After(input.From) {
+		return nil, errs.ValidationErrors{"to": {"before_from"}}
+	}
+	if input.To.Sub(input.From) > MaxTrackRange {
+		return nil, errs.ValidationErrors{"to": {"range_too_long"}}
+	}
+
+	if _, err := uc.vehicleRepo.FindByID(ctx, input.VehicleID); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("vehicle repository: find by id: %w", err)
+	}
+
+	// one fix more than the limit tells whether the period goes on, and where
+	positions, err := uc.positionRepo.ListTrack(ctx, input.VehicleID, input.From, input.To, MaxTrackPoints+1)
+	if err != nil {
+		return nil, fmt.Errorf("position repository: list track: %w", err)
+	}
+
+	output := &TrackOutput{
+		VehicleID: input.VehicleID,
+		From:      input.From,
+		To:        input.To,
+	}
+	if len(positions) > MaxTrackPoints {
+		next := positions[MaxTrackPoints].RecordedAt
+		output.Truncated = true
+		output.NextFrom = &next
+		positions = positions[:MaxTrackPoints]
+	}
+	output.RawPoints = len(positions)
+
+	path := make([]entity.Coordinate, len(positions))
+	for i, p := range positions {
+		path[i] = entity.Coordinate{Latitude: p.Latitude, Longitude: p.Longitude}
+		if i > 0 {
+			output.DistanceM += uc.geometry.Distance(path[i-1].Latitude, path[i-1].Longitude, p.Latitude, p.Longitude)
+		}
+	}
+
+	keep := uc.geometry.Simplify(path, input.ToleranceM)
+	output.Points = make([]PositionOutput, len(keep))
+	for i, idx := range keep {
+		output.Points[i] = toPositionOutput(positions[idx])
+	}
+	return output, nil
+}
+
+// resolveVehicles looks up the vehicles and GPS devices referenced by a batch
+func (uc *TrackingUseCase) resolveVehicles(ctx context.Context, inputs []PositionInput) (map[uuid.UUID]bool, map[string]uuid.UUID, error) {
+	var ids []uuid.UUID
+	var deviceIDs []string
+	seenIDs := make(map[uuid.UUID]bool)
+	seenDevices := make(map[string]bool)
+	for _, in := range inputs {
+		if in.VehicleID != nil {
+			if !seenIDs[*in.VehicleID] {
+				seenIDs[*in.VehicleID] = true
+				ids = append(ids, *in.VehicleID)
+			}
+		} else if !seenDevices[in.DeviceID] {
+			seenDevices[in.DeviceID] = true
+			deviceIDs = append(deviceIDs, in.DeviceID)
+		}
+	}
+
+	vehicles := make(map[uuid.UUID]bool, len(ids))
+	found, err := uc.vehicleRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("vehicle repository: find by ids: %w", err)
+	}
+	for _, v := range found {
+		vehicles[v.ID] = true
+	}
+
+	devices := make(map[string]uuid.UUID, len(deviceIDs))
+	found, err = uc.vehicleRepo.FindByGPSDeviceIDs(ctx, deviceIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("vehicle repository: find by gps device ids: %w", err)
+	}
+	for _, v := range found {
+		devices[*v.GPSDeviceID] = v.ID
+	}
+	return vehicles, devices, nil
+}
+
+// newestPerVehicle keeps the most recent fix of each vehicle in the batch
+func newestPerVehicle(positions []entity.VehiclePosition) []entity.VehiclePosition {
+	newest := make(map[uuid.UUID]int, len(positions))
+	for i, p := range positions {
+		if j, ok := newest[p.VehicleID]; !ok || p.RecordedAt.After(positions[j].RecordedAt) {
+			newest[p.VehicleID] = i
+		}
+	}
+	result := make([]entity.VehiclePosition, 0, len(newest))
+	for _, i := range newest {
+		result = append(result, positions[i])
+	}
+	return result
+}
+
+func toPositionOutput(p entity.VehiclePosition) PositionOutput {
+	return PositionOutput{
+		RecordedAt: p.RecordedAt,
+		Latitude:   p.Latitude,
+		Longitude:  p.Longitude,
+		SpeedKmh:   p.SpeedKmh,
+		Heading:    p.Heading,
+		OdometerKm: p.OdometerKm,
+	}
+}
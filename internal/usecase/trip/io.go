@@ -0,0 +1,74 @@
+package trip
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// StopInput represents one pickup or delivery on a trip, in visiting order
+type StopInput struct {
+	Type           entity.StopType
+	ShipmentID     uuid.UUID
+	PlannedArrival *time.Time
+	ServiceTime    *time.Duration
+}
+
+// TripInput represents data for creating or re-planning a trip
+type TripInput struct {
+	VehicleID    uuid.UUID
+	DriverID     uuid.UUID
+	CoDriverID   *uuid.UUID
+	PlannedStart time.Time
+	PlannedEnd   time.Time
+	Notes        string
+	Stops        []StopInput
+}
+
+// ListTripsInput represents criteria for listing trips
+type ListTripsInput struct {
+	Status    *entity.TripStatus
+	VehicleID *uuid.UUID
+	DriverID  *uuid.UUID
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+	Offset    int
+}
+
+// StopOutput represents trip stop output data
+type StopOutput struct {
+	ID             uuid.UUID
+	Sequence       int
+	Type           entity.StopType
+	ShipmentID     uuid.UUID
+	LocationID     uuid.UUID
+	PlannedArrival *time.Time
+	ServiceTime    *time.Duration
+	Status         entity.StopStatus
+	ArrivedAt      *time.Time
+	DepartedAt     *time.Time
+	ETA            *time.Time
+	DelayedAt      *time.Time
+}
+
+// TripOutput represents trip output data
+type TripOutput struct {
+	ID           uuid.UUID
+	Number       string
+	VehicleID    uuid.UUID
+	DriverID     uuid.UUID
+	CoDriverID   *uuid.UUID
+	Status       entity.TripStatus
+	PlannedStart time.Time
+	PlannedEnd   time.Time
+	Notes        string
+	DispatchedBy *uuid.UUID
+	CarrierID    *uuid.UUID
+	TenderID     *uuid.UUID
+	Stops        []StopOutput
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,517 @@
+package trip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+// TripUseCase handles trip planning and dispatch operations
+type TripUseCase struct {
+	tripRepo        repository.TripRepository
+	vehicleRepo     repository.VehicleRepository
+	driverRepo      repository.DriverRepository
+	shipmentRepo    repository.ShipmentRepository
+	maintenanceRepo repository.MaintenanceScheduleRepository
+	complianceRepo  repository.ComplianceDocumentRepository
+	transactor      repository.Transactor
+	publisher       service.EventPublisher
+	numbering       service.NumberGenerator
+	blockingTypes   []entity.ComplianceDocumentType
+}
+
+// NewTripUseCase creates a new trip use case.
+// A trip cannot be dispatched while a document of the blockingTypes of its vehicle or drivers has expired.
+func NewTripUseCase(
+	tripRepo repository.TripRepository,
+	vehicleRepo repository.VehicleRepository,
+	driverRepo repository.DriverRepository,
+	shipmentRepo repository.ShipmentRepository,
+	maintenanceRepo repository.MaintenanceScheduleRepository,
+	complianceRepo repository.ComplianceDocumentRepository,
+	transactor repository.Transactor,
+	publisher service.EventPublisher,
+	numbering service.NumberGenerator,
+	blockingTypes []entity.ComplianceDocumentType,
+) *TripUseCase {
+	return &TripUseCase{
+		tripRepo:        tripRepo,
+		vehicleRepo:     vehicleRepo,
+		driverRepo:      driverRepo,
+		shipmentRepo:    shipmentRepo,
+		maintenanceRepo: maintenanceRepo,
+		complianceRepo:  complianceRepo,
+		transactor:      transactor,
+		publisher:       publisher,
+		numbering:       numbering,
+		blockingTypes:   blockingTypes,
+	}
+}
+
+// Create plans a new trip and marks its shipments as planned
+func (uc *TripUseCase) Create(ctx context.Context, input TripInput) (*TripOutput, error) {
+	trip := &entity.Trip{Status: entity.TripStatusPlanned}
+
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.assign(ctx, trip, input); err != nil {
+			return err
+		}
+		// trips are run by the carrier for every organization, so they are numbered in the default format
+		number, err := uc.numbering.Next(ctx, uuid.Nil, entity.DocumentTrip, time.Now())
+		if err != nil {
+			return fmt.Errorf("number generator: next trip number: %w", err)
+		}
+		trip.Number = number
+		if err := uc.tripRepo.Create(ctx, trip); err != nil {
+			return fmt.Errorf("trip repository: create trip: %w", err)
+		}
+		if err := uc.shipmentRepo.UpdateStatus(ctx, trip.ShipmentIDs(), entity.ShipmentStatusPlanned); err != nil {
+			return fmt.Errorf("shipment repository: update status: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publishShipmentStatus(ctx, trip.ShipmentIDs(), entity.ShipmentStatusPlanned)
+	return toTripOutput(trip), nil
+}
+
+// Get returns a trip with its stops by ID
+func (uc *TripUseCase) Get(ctx context.Context, id uuid.UUID) (*TripOutput, error) {
+	trip, err := uc.findTrip(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toTripOutput(trip), nil
+}
+
+// List returns trips matching the input criteria
+func (uc *TripUseCase) List(ctx context.Context, input ListTripsInput) ([]*TripOutput, int64, error) {
+	trips, total, err := uc.tripRepo.List(ctx, repository.TripFilter{
+		Status:    input.Status,
+		VehicleID: input.VehicleID,
+		DriverID:  input.DriverID,
+		From:      input.From,
+		To:        input.To,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("trip repository: list trips: %w", err)
+	}
+
+	outputs := make([]*TripOutput, len(trips))
+	for i, t := range trips {
+		outputs[i] = toTripOutput(t)
+	}
+	return outputs, total, nil
+}
+
+// Update re-plans a trip that has not been dispatched yet.
+// Shipments dropped from the trip return to pending; newly added ones become planned.
+func (uc *TripUseCase) Update(ctx context.Context, id uuid.UUID, input TripInput) (*TripOutput, error) {
+	var trip *entity.Trip
+
+	var released []uuid.UUID
+
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		trip, err = uc.findTripForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !trip.IsEditable() {
+			return errs.ErrResourceLocked
+		}
+
+		previous := trip.ShipmentIDs()
+		if err := uc.assign(ctx, trip, input); err != nil {
+			return err
+		}
+		if err := uc.tripRepo.Update(ctx, trip); err != nil {
+			return fmt.Errorf("trip repository: update trip: %w", err)
+		}
+
+		released = difference(previous, trip.ShipmentIDs())
+		if err := uc.shipmentRepo.UpdateStatus(ctx, released, entity.ShipmentStatusPending); err != nil {
+			return fmt.Errorf("shipment repository: update status: %w", err)
+		}
+		if err := uc.shipmentRepo.UpdateStatus(ctx, trip.ShipmentIDs(), entity.ShipmentStatusPlanned); err != nil {
+			return fmt.Errorf("shipment repository: update status: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publishShipmentStatus(ctx, released, entity.ShipmentStatusPending)
+	uc.publishShipmentStatus(ctx, trip.ShipmentIDs(), entity.ShipmentStatusPlanned)
+	return toTripOutput(trip), nil
+}
+
+// UpdateStatus moves a trip through its lifecycle. The user dispatching a trip becomes its dispatcher.
+// Cancelling a trip releases its shipments back to pending.
+func (uc *TripUseCase) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.TripStatus, userID uuid.UUID) (*TripOutput, error) {
+	var trip *entity.Trip
+
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		trip, err = uc.findTripForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !trip.CanTransitionTo(status) {
+			return errs.ErrInvalidStatusTransition
+		}
+		if status == entity.TripStatusDispatched {
+			if err := uc.checkCompliance(ctx, trip, time.Now()); err != nil {
+				return err
+			}
+			if err := uc.tripRepo.UpdateDispatcher(ctx, trip.ID, userID); err != nil {
+				return fmt.Errorf("trip repository: update dispatcher: %w", err)
+			}
+			trip.DispatchedBy = &userID
+		}
+
+		if err := uc.tripRepo.UpdateStatus(ctx, trip.ID, status); err != nil {
+			return fmt.Errorf("trip repository: update status: %w", err)
+		}
+		trip.Status = status
+
+		if status == entity.TripStatusCancelled {
+			if err := uc.shipmentRepo.UpdateStatus(ctx, trip.ShipmentIDs(), entity.ShipmentStatusPending); err != nil {
+				return fmt.Errorf("shipment repository: update status: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publish(ctx, service.RealtimeEvent{
+		Channels: []string{
+			service.Channel(service.ChannelTrip, trip.ID),
+			service.Channel(service.ChannelVehicle, trip.VehicleID),
+		},
+		Type: "trip.status_changed",
+		Data: map[string]interface{}{
+			"trip_id":    trip.ID,
+			"vehicle_id": trip.VehicleID,
+			"status":     trip.Status,
+		},
+		OccurredAt: time.Now(),
+	})
+	if status == entity.TripStatusCancelled {
+		uc.publishShipmentStatus(ctx, trip.ShipmentIDs(), entity.ShipmentStatusPending)
+	}
+	return toTripOutput(trip), nil
+}
+
+// checkCompliance returns errs.ErrComplianceExpired when a blocking document of the trip's vehicle or drivers has expired
+func (uc *TripUseCase) checkCompliance(ctx context.Context, trip *entity.Trip, now time.Time) error {
+	if len(uc.blockingTypes) == 0 {
+		return nil
+	}
+	owners := []entity.ComplianceOwner{
+		{Type: entity.ComplianceOwnerVehicle, ID: trip.VehicleID},
+		{Type: entity.ComplianceOwnerDriver, ID: trip.DriverID},
+	}
+	if trip.CoDriverID != nil {
+		owners = append(owners, entity.ComplianceOwner{Type: entity.ComplianceOwnerDriver, ID: *trip.CoDriverID})
+	}
+	expired, err := uc.complianceRepo.FindExpired(ctx, owners, uc.blockingTypes, timeutil.CalendarDay(now))
+	if err != nil {
+		return fmt.Errorf("compliance document repository: find expired: %w", err)
+	}
+	return entity.ComplianceExpiredError(expired)
+}
+
+// publishShipmentStatus announces the new status of shipments to connected clients; failures are only logged
+func (uc *TripUseCase) publishShipmentStatus(ctx context.Context, ids []uuid.UUID, status entity.ShipmentStatus) {
+	if len(ids) == 0 {
+		return
+	}
+	shipments, err := uc.shipmentRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		log.Printf("[ERROR] realtime: shipment repository: find by ids: %v", err)
+		return
+	}
+
+	now := time.Now()
+	events := make([]service.RealtimeEvent, len(shipments))
+	for i, s := range shipments {
+		events[i] = service.ShipmentStatusChanged(s, status, now)
+	}
+	uc.publish(ctx, events...)
+}
+
+// publish pushes events to connected clients; the change is already saved, so failures are only logged
+func (uc *TripUseCase) publish(ctx context.Context, events ...service.RealtimeEvent) {
+	if err := uc.publisher.Publish(ctx, events...); err != nil {
+		log.Printf("[ERROR] realtime: publish: %v", err)
+	}
+}
+
+// assign validates the vehicle, drivers, shipments and stops of the input and applies them to the trip
+func (uc *TripUseCase) assign(ctx context.Context, trip *entity.Trip, input TripInput) error {
+	if !input.PlannedEnd.After(input.PlannedStart) {
+		return errs.ValidationErrors{"planned_end": {"before_planned_start"}}
+	}
+	if input.CoDriverID != nil && *input.CoDriverID == input.DriverID {
+		return errs.ValidationErrors{"co_driver_id": {"same_as_driver"}}
+	}
+
+	// The vehicle, drivers and shipments stay locked until the transaction ends, so a concurrent assignment of any
+	// of them waits for this one and then sees its trip in the overlap check. Locks are always taken in the same
+	// order (vehicle, drivers by ID, shipments by ID) to avoid deadlocks.
+	vehicle, err := uc.vehicleRepo.FindByIDForUpdate(ctx, input.VehicleID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("vehicle repository: find by id for update: %w", err)
+	}
+	if vehicle.Status != entity.VehicleStatusActive {
+		return errs.ErrVehicleUnavailable
+	}
+	// A vehicle past due for a service cannot take on trips; trips it already has are left alone
+	if trip.VehicleID != input.VehicleID {
+		overdue, err := uc.maintenanceRepo.HasOverdue(ctx, input.VehicleID, time.Now())
+		if err != nil {
+			return fmt.Errorf("maintenance schedule repository: has overdue: %w", err)
+		}
+		if overdue {
+			return errs.ErrMaintenanceOverdue
+		}
+	}
+
+	driverIDs := []uuid.UUID{input.DriverID}
+	if input.CoDriverID != nil {
+		driverIDs = append(driverIDs, *input.CoDriverID)
+	}
+	sort.Slice(driverIDs, func(i, j int) bool { return driverIDs[i].String() < driverIDs[j].String() })
+	for _, driverID := range driverIDs {
+		driver, err := uc.driverRepo.FindByIDForUpdate(ctx, driverID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return errs.ErrNotFound
+			}
+			return fmt.Errorf("driver repository: find by id for update: %w", err)
+		}
+		// The license has to stay valid until the trip is over
+		if err := driver.EnsureAssignable(input.PlannedEnd); err != nil {
+			return err
+		}
+	}
+
+	shipments, err := uc.loadShipments(ctx, trip, input.Stops)
+	if err != nil {
+		return err
+	}
+
+	stops, err := buildStops(input.Stops, shipments)
+	if err != nil {
+		return err
+	}
+
+	trip.VehicleID = input.VehicleID
+	trip.DriverID = input.DriverID
+	trip.CoDriverID = input.CoDriverID
+	trip.PlannedStart = input.PlannedStart
+	trip.PlannedEnd = input.PlannedEnd
+	trip.Notes = input.Notes
+	trip.Stops = stops
+
+	if over := trip.PeakLoad(shipments).Exceeds(vehicle.Capacity()); len(over) > 0 {
+		return fmt.Errorf("%w: %s", errs.ErrCapacityExceeded, strings.Join(over, ", "))
+	}
+
+	var excludeID *uuid.UUID
+	if trip.ID != uuid.Nil {
+		excludeID = &trip.ID
+	}
+	overlapping, err := uc.tripRepo.FindOverlapping(ctx, trip.VehicleID, trip.DriverIDs(), trip.PlannedStart, trip.PlannedEnd, excludeID)
+	if err != nil {
+		return fmt.Errorf("trip repository: find overlapping: %w", err)
+	}
+	if len(overlapping) > 0 {
+		return fmt.Errorf("%w: overlaps trip %s", errs.ErrScheduleConflict, overlapping[0].ID)
+	}
+
+	return nil
+}
+
+// loadShipments loads the shipments referenced by the stops and checks they can be planned on this trip
+func (uc *TripUseCase) loadShipments(ctx context.Context, trip *entity.Trip, stops []StopInput) (map[uuid.UUID]*entity.Shipment, error) {
+	ids := make([]uuid.UUID, 0, len(stops))
+	for _, s := range stops {
+		ids = append(ids, s.ShipmentID)
+	}
+
+	found, err := uc.shipmentRepo.FindByIDsForUpdate(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("shipment repository: find by ids for update: %w", err)
+	}
+
+	onTrip := make(map[uuid.UUID]bool)
+	for _, id := range trip.ShipmentIDs() {
+		onTrip[id] = true
+	}
+
+	shipments := make(map[uuid.UUID]*entity.Shipment, len(found))
+	for _, s := range found {
+		plannedHere := s.Status == entity.ShipmentStatusPlanned && onTrip[s.ID]
+		if s.Status != entity.ShipmentStatusPending && !plannedHere {
+			return nil, fmt.Errorf("%w: shipment %s is %s", errs.ErrShipmentUnavailable, s.ID, s.Status)
+		}
+		shipments[s.ID] = s
+	}
+	for _, id := range ids {
+		if _, ok := shipments[id]; !ok {
+			return nil, errs.ErrNotFound
+		}
+	}
+	return shipments, nil
+}
+
+// buildStops checks every shipment is delivered exactly once and picked up at most once before delivery,
+// and resolves each stop's location from its shipment
+func buildStops(inputs []StopInput, shipments map[uuid.UUID]*entity.Shipment) ([]entity.TripStop, error) {
+	pickedUp := make(map[uuid.UUID]bool)
+	delivered := make(map[uuid.UUID]bool)
+	stops := make([]entity.TripStop, len(inputs))
+
+	for i, in := range inputs {
+		shipment := shipments[in.ShipmentID]
+		stop := entity.TripStop{
+			Sequence:       i + 1,
+			Type:           in.Type,
+			ShipmentID:     in.ShipmentID,
+			PlannedArrival: in.PlannedArrival,
+			ServiceTime:    in.ServiceTime,
+			Status:         entity.StopStatusPending,
+		}
+
+		switch in.Type {
+		case entity.StopTypePickup:
+			if pickedUp[in.ShipmentID] || delivered[in.ShipmentID] {
+				return nil, stopError(i, "pickup_out_of_order")
+			}
+			pickedUp[in.ShipmentID] = true
+			stop.LocationID = shipment.PickupLocationID
+		case entity.StopTypeDelivery:
+			if delivered[in.ShipmentID] {
+				return nil, stopError(i, "duplicate_delivery")
+			}
+			delivered[in.ShipmentID] = true
+			stop.LocationID = shipment.DeliveryLocationID
+		}
+
+		if i > 0 && in.PlannedArrival != nil && stops[i-1].PlannedArrival != nil && in.PlannedArrival.Before(*stops[i-1].PlannedArrival) {
+			return nil, stopError(i, "arrival_before_previous_stop")
+		}
+		stops[i] = stop
+	}
+
+	for id := range shipments {
+		if !delivered[id] {
+			return nil, errs.ValidationErrors{"stops": {"missing_delivery"}}
+		}
+	}
+	return stops, nil
+}
+
+func stopError(index int, reason string) errs.ValidationErrors {
+	return errs.ValidationErrors{fmt.Sprintf("stops[%d]", index): {reason}}
+}
+
+// difference returns the IDs in a that are not in b
+func difference(a, b []uuid.UUID) []uuid.UUID {
+	inB := make(map[uuid.UUID]bool, len(b))
+	for _, id := range b {
+		inB[id] = true
+	}
+	var result []uuid.UUID
+	for _, id := range a {
+		if !inB[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// findTrip loads a trip and normalizes repository errors
+func (uc *TripUseCase) findTrip(ctx context.Context, id uuid.UUID) (*entity.Trip, error) {
+	trip, err := uc.tripRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("trip repository: find by id: %w", err)
+	}
+	return trip, nil
+}
+
+// findTripForUpdate loads a trip and locks it until the surrounding transaction ends
+func (uc *TripUseCase) findTripForUpdate(ctx context.Context, id uuid.UUID) (*entity.Trip, error) {
+	trip, err := uc.tripRepo.FindByIDForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("trip repository: find by id for update: %w", err)
+	}
+	return trip, nil
+}
+
+func toTripOutput(t *entity.Trip) *TripOutput {
+	stops := make([]StopOutput, len(t.Stops))
+	for i, s := range t.Stops {
+		stops[i] = StopOutput{
+			ID:             s.ID,
+			Sequence:       s.Sequence,
+			Type:           s.Type,
+			ShipmentID:     s.ShipmentID,
+			LocationID:     s.LocationID,
+			PlannedArrival: s.PlannedArrival,
+			ServiceTime:    s.ServiceTime,
+			Status:         s.Status,
+			ArrivedAt:      s.ArrivedAt,
+			DepartedAt:     s.DepartedAt,
+			ETA:            s.ETA,
+			DelayedAt:      s.DelayedAt,
+		}
+	}
+
+	return &TripOutput{
+		ID:           t.ID,
+		Number:       t.Number,
+		VehicleID:    t.VehicleID,
+		DriverID:     t.DriverID,
+		CoDriverID:   t.CoDriverID,
+		Status:       t.Status,
+		PlannedStart: t.PlannedStart,
+		PlannedEnd:   t.PlannedEnd,
+		Notes:        t.Notes,
+		DispatchedBy: t.DispatchedBy,
+		CarrierID:    t.CarrierID,
+		TenderID:     t.TenderID,
+		Stops:        stops,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+	}
+}
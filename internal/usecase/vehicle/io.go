@@ -0,0 +1,54 @@
+package vehicle
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// VehicleInput represents data for creating or updating a vehicle
+type VehicleInput struct {
+	PlateNumber   string
+	PlateProvince string
+	Type          entity.VehicleType
+	Brand         string
+	Model         string
+	Year          int
+	MaxWeightKg   float64
+	MaxVolumeM3   float64
+	MaxPallets    int
+	HomeDepot     string
+	GPSDeviceID   string
+	FuelBaseline  float64
+}
+
+// ListVehiclesInput represents criteria for listing vehicles
+type ListVehiclesInput struct {
+	Status *entity.VehicleStatus
+	Type   *entity.VehicleType
+	Search string
+	Limit  int
+	Offset int
+}
+
+// VehicleOutput represents vehicle output data
+type VehicleOutput struct {
+	ID            uuid.UUID
+	PlateNumber   string
+	PlateProvince string
+	Type          entity.VehicleType
+	Brand         string
+	Model         string
+	Year          int
+	MaxWeightKg   float64
+	MaxVolumeM3   float64
+	MaxPallets    int
+	HomeDepot     string
+	GPSDeviceID   string
+	FuelBaseline  float64
+	Status        entity.VehicleStatus
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
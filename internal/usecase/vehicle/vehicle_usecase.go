@@ -0,0 +1,167 @@
+package vehicle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+
+	"github.com/google/uuid"
+)
+
+// VehicleUseCase handles fleet vehicle operations
+type VehicleUseCase struct {
+	vehicleRepo repository.VehicleRepository
+}
+
+// NewVehicleUseCase creates a new vehicle use case
+func NewVehicleUseCase(vehicleRepo repository.VehicleRepository) *VehicleUseCase {
+	return &VehicleUseCase{vehicleRepo: vehicleRepo}
+}
+
+// Create registers a new vehicle
+func (uc *VehicleUseCase) Create(ctx context.Context, input VehicleInput) (*VehicleOutput, error) {
+	vehicle := &entity.Vehicle{Status: entity.VehicleStatusActive}
+	applyInput(vehicle, input)
+
+	if err := uc.vehicleRepo.Create(ctx, vehicle); err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			return nil, errs.ErrConflict
+		}
+		return nil, fmt.Errorf("vehicle repository: create vehicle: %w", err)
+	}
+
+	return toVehicleOutput(vehicle), nil
+}
+
+// Get returns a vehicle by ID
+func (uc *VehicleUseCase) Get(ctx context.Context, id uuid.UUID) (*VehicleOutput, error) {
+	vehicle, err := uc.findVehicle(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toVehicleOutput(vehicle), nil
+}
+
+// List returns vehicles matching the input criteria
+func (uc *VehicleUseCase) List(ctx context.Context, input ListVehiclesInput) ([]*VehicleOutput, int64, error) {
+	vehicles, total, err := uc.vehicleRepo.List(ctx, repository.VehicleFilter{
+		Status: input.Status,
+		Type:   input.Type,
+		Search: input.Search,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("vehicle repository: list vehicles: %w", err)
+	}
+
+	outputs := make([]*VehicleOutput, len(vehicles))
+	for i, v := range vehicles {
+		outputs[i] = toVehicleOutput(v)
+	}
+	return outputs, total, nil
+}
+
+// Update updates a vehicle's details
+func (uc *VehicleUseCase) Update(ctx context.Context, id uuid.UUID, input VehicleInput) (*VehicleOutput, error) {
+	vehicle, err := uc.findVehicle(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applyInput(vehicle, input)
+	if err := uc.vehicleRepo.Update(ctx, vehicle); err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			return nil, errs.ErrConflict
+		}
+		return nil, fmt.Errorf("vehicle repository: update vehicle: %w", err)
+	}
+
+	return toVehicleOutput(vehicle), nil
+}
+
+// UpdateStatus changes a vehicle's operational status
+func (uc *VehicleUseCase) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.VehicleStatus) (*VehicleOutput, error) {
+	vehicle, err := uc.findVehicle(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicle.Status = status
+	if err := uc.vehicleRepo.Update(ctx, vehicle); err != nil {
+		return nil, fmt.Errorf("vehicle repository: update vehicle: %w", err)
+	}
+
+	return toVehicleOutput(vehicle), nil
+}
+
+// Delete removes a vehicle from the fleet
+func (uc *VehicleUseCase) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := uc.vehicleRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("vehicle repository: delete vehicle: %w", err)
+	}
+	return nil
+}
+
+// findVehicle loads a vehicle and normalizes repository errors
+func (uc *VehicleUseCase) findVehicle(ctx context.Context, id uuid.UUID) (*entity.Vehicle, error) {
+	vehicle, err := uc.vehicleRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("vehicle repository: find by id: %w", err)
+	}
+	return vehicle, nil
+}
+
+func applyInput(vehicle *entity.Vehicle, input VehicleInput) {
+	vehicle.PlateNumber = input.PlateNumber
+	vehicle.PlateProvince = input.PlateProvince
+	vehicle.Type = input.Type
+	vehicle.Brand = input.Brand
+	vehicle.Model = input.Model
+	vehicle.Year = input.Year
+	vehicle.MaxWeightKg = input.MaxWeightKg
+	vehicle.MaxVolumeM3 = input.MaxVolumeM3
+	vehicle.MaxPallets = input.MaxPallets
+	vehicle.HomeDepot = input.HomeDepot
+	vehicle.FuelBaseline = input.FuelBaseline
+	vehicle.GPSDeviceID = nil
+	if input.GPSDeviceID != "" {
+		vehicle.GPSDeviceID = &input.GPSDeviceID
+	}
+}
+
+func toVehicleOutput(v *entity.Vehicle) *VehicleOutput {
+	return &VehicleOutput{
+		ID:            v.ID,
+		PlateNumber:   v.PlateNumber,
+		PlateProvince: v.PlateProvince,
+		Type:          v.Type,
+		Brand:         v.Brand,
+		Model:         v.Model,
+		Year:          v.Year,
+		MaxWeightKg:   v.MaxWeightKg,
+		MaxVolumeM3:   v.MaxVolumeM3,
+		MaxPallets:    v.MaxPallets,
+		HomeDepot:     v.HomeDepot,
+		GPSDeviceID:   stringFromPtr(v.GPSDeviceID),
+		FuelBaseline:  v.FuelBaseline,
+		Status:        v.Status,
+		CreatedAt:     v.CreatedAt,
+		UpdatedAt:     v.UpdatedAt,
+	}
+}
+
+func stringFromPtr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,151 @@
+package vehiclecost
+
+import (
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// UploadURLOutput represents a presigned upload URL and the key the receipt will be stored under
+type UploadURLOutput struct {
+	UploadURL string
+	ObjectKey string
+}
+
+// FuelLogInput represents a fuel fill-up to record.
+// A zero amount is worked out from the litres and price; the receipt key is one returned by ReceiptUploadURL.
+type FuelLogInput struct {
+	VehicleID     uuid.UUID
+	DriverID      *uuid.UUID
+	FilledAt      time.Time
+	Liters        float64
+	PricePerLiter entity.Money
+	Amount        entity.Money
+	OdometerKm    float64
+	Station       string
+	FullTank      bool
+	ReceiptKey    string
+	Notes         string
+	CreatedBy     uuid.UUID
+}
+
+// ExpenseInput represents a vehicle expense to record
+type ExpenseInput struct {
+	VehicleID   uuid.UUID
+	DriverID    *uuid.UUID
+	TripID      *uuid.UUID
+	Type        entity.ExpenseType
+	IncurredAt  time.Time
+	Amount      entity.Money
+	Description string
+	Reference   string
+	ReceiptKey  string
+	CreatedBy   uuid.UUID
+}
+
+// ListFuelLogsInput represents criteria for listing a vehicle's fill-ups
+type ListFuelLogsInput struct {
+	VehicleID uuid.UUID
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+	Offset    int
+}
+
+// ListExpensesInput represents criteria for listing a vehicle's expenses
+type ListExpensesInput struct {
+	VehicleID uuid.UUID
+	Type      *entity.ExpenseType
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+	Offset    int
+}
+
+// ReportInput represents the period, and optionally the vehicle, a cost report covers
+type ReportInput struct {
+	VehicleID  *uuid.UUID
+	PeriodFrom time.Time // first day, inclusive
+	PeriodTo   time.Time // last day, inclusive
+}
+
+// FuelLogOutput represents fuel log output data
+type FuelLogOutput struct {
+	ID                 uuid.UUID
+	VehicleID          uuid.UUID
+	DriverID           *uuid.UUID
+	FilledAt           time.Time
+	Liters             float64
+	PricePerLiter      entity.Money
+	Amount             entity.Money
+	OdometerKm         float64
+	Station            string
+	FullTank           bool
+	ReceiptKey         string
+	ReceiptURL         string
+	Notes              string
+	DistanceKm         float64
+	KmPerLiter         *float64
+	BaselineKmPerLiter float64
+	Deviation          *float64
+	Flagged            bool
+	CreatedBy          *uuid.UUID
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// ExpenseOutput represents vehicle expense output data
+type ExpenseOutput struct {
+	ID          uuid.UUID
+	VehicleID   uuid.UUID
+	DriverID    *uuid.UUID
+	TripID      *uuid.UUID
+	Type        entity.ExpenseType
+	IncurredAt  time.Time
+	Amount      entity.Money
+	Description string
+	Reference   string
+	ReceiptKey  string
+	ReceiptURL  string
+	CreatedBy   *uuid.UUID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ExpenseTotalOutput represents a vehicle's expenses of one type in a report
+type ExpenseTotalOutput struct {
+	Type   entity.ExpenseType
+	Count  int
+	Amount entity.Money
+}
+
+// VehicleCostOutput represents a vehicle's running costs over a report period.
+// KmPerLiter and CostPerKm are nil when no fill-up in the period could be rated.
+type VehicleCostOutput struct {
+	VehicleID          uuid.UUID
+	PlateNumber        string
+	VehicleType        entity.VehicleType
+	Fills              int
+	Liters             float64
+	FuelCost           entity.Money
+	DistanceKm         float64
+	KmPerLiter         *float64
+	BaselineKmPerLiter float64
+	FlaggedFills       int
+	Expenses           []ExpenseTotalOutput
+	ExpenseCost        entity.Money
+	TotalCost          entity.Money
+	CostPerKm          *entity.Money
+}
+
+// CostReportOutput represents running costs per vehicle over a period
+type CostReportOutput struct {
+	PeriodFrom  time.Time
+	PeriodTo    time.Time
+	Vehicles    []VehicleCostOutput
+	FuelCost    entity.Money
+	ExpenseCost entity.Money
+	TotalCost   entity.Money
+}
@@ -0,0 +1,614 @@
+package vehiclecost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"tms-core-service/internal/domain/entity"
+	"tms-core-service/internal/domain/errs"
+	"tms-core-service/internal/domain/repository"
+	"tms-core-service/internal/domain/service"
+	"tms-core-service/pkg/timeutil"
+
+	"github.com/google/uuid"
+)
+
+// DefaultDeviationTolerance is how far off its baseline a fill-up may rate before it is flagged when not configured
+const DefaultDeviationTolerance = 0.15
+
+// VehicleCostUseCase handles the fuel fill-ups and other running costs of fleet vehicles
+type VehicleCostUseCase struct {
+	fuelLogRepo    repository.FuelLogRepository
+	expenseRepo    repository.VehicleExpenseRepository
+	vehicleRepo    repository.VehicleRepository
+	driverRepo     repository.DriverRepository
+	tripRepo       repository.TripRepository
+	storageService service.StorageService
+	transactor     repository.Transactor
+	baselines      map[entity.VehicleType]float64
+	tolerance      float64
+}
+
+// NewVehicleCostUseCase creates a new vehicle cost use case.
+// Vehicles without their own fuel baseline are held to the baseline for their type, if any; a fill-up
+// rating off its baseline by more than tolerance is flagged, and zero uses DefaultDeviationTolerance.
+func NewVehicleCostUseCase(
+	fuelLogRepo repository.FuelLogRepository,
+	expenseRepo repository.VehicleExpenseRepository,
+	vehicleRepo repository.VehicleRepository,
+	driverRepo repository.DriverRepository,
+	tripRepo repository.TripRepository,
+	storageService service.StorageService,
+	transactor repository.Transactor,
+	baselines map[entity.VehicleType]float64,
+	tolerance float64,
+) *VehicleCostUseCase {
+	if tolerance <= 0 {
+		tolerance = DefaultDeviationTolerance
+	}
+	return &VehicleCostUseCase{
+		fuelLogRepo:    fuelLogRepo,
+		expenseRepo:    expenseRepo,
+		vehicleRepo:    vehicleRepo,
+		driverRepo:     driverRepo,
+		tripRepo:       tripRepo,
+		storageService: storageService,
+		transactor:     transactor,
+		baselines:      baselines,
+		tolerance:      tolerance,
+	}
+}
+
+// ReceiptUploadURL issues a presigned URL for a receipt photo of a vehicle's fill-up or expense
+func (uc *VehicleCostUseCase) ReceiptUploadURL(ctx context.Context, vehicleID uuid.UUID, contentType string) (*UploadURLOutput, error) {
+	if _, err := uc.findVehicle(ctx, vehicleID); err != nil {
+		return nil, err
+	}
+
+	url, key, err := service.ImageUploadURL(ctx, uc.storageService, receiptPrefix(vehicleID), contentType, "content_type")
+	if err != nil {
+		return nil, err
+	}
+	return &UploadURLOutput{UploadURL: url, ObjectKey: key}, nil
+}
+
+// RecordFuel records a fill-up. The odometer must lie between those of the vehicle's fill-ups either side.
+// A full tank is rated against the previous full tank, and the next full tank is rated again since the
+// litres bought in between have changed.
+func (uc *VehicleCostUseCase) RecordFuel(ctx context.Context, input FuelLogInput) (*FuelLogOutput, error) {
+	if err := validateFuelLog(input); err != nil {
+		return nil, err
+	}
+	if err := uc.checkReferences(ctx, input.DriverID, nil, input.VehicleID); err != nil {
+		return nil, err
+	}
+	if err := uc.checkReceipt(ctx, input.VehicleID, input.ReceiptKey); err != nil {
+		return nil, err
+	}
+
+	amount := input.Amount
+	if amount == 0 {
+		amount = input.PricePerLiter.Mul(input.Liters)
+	}
+	createdBy := input.CreatedBy
+	log := &entity.FuelLog{
+		VehicleID:     input.VehicleID,
+		DriverID:      input.DriverID,
+		FilledAt:      input.FilledAt,
+		Liters:        input.Liters,
+		PricePerLiter: input.PricePerLiter,
+		Amount:        amount,
+		OdometerKm:    input.OdometerKm,
+		Station:       strings.TrimSpace(input.Station),
+		FullTank:      input.FullTank,
+		ReceiptKey:    input.ReceiptKey,
+		Notes:         input.Notes,
+		CreatedBy:     &createdBy,
+	}
+
+	err := uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		// The vehicle row serializes its fill-ups, so each is rated against the others
+		vehicle, err := uc.vehicleRepo.FindByIDForUpdate(ctx, input.VehicleID)
+		if err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return errs.ErrNotFound
+			}
+			return fmt.Errorf("vehicle repository: find by id for update: %w", err)
+		}
+
+		prev, err := uc.adjacentFill(uc.fuelLogRepo.FindPrevious(ctx, vehicle.ID, log.FilledAt, false))
+		if err != nil {
+			return err
+		}
+		if prev != nil && log.OdometerKm < prev.OdometerKm {
+			return errs.ValidationErrors{"odometer_km": {"below_previous"}}
+		}
+		next, err := uc.adjacentFill(uc.fuelLogRepo.FindNext(ctx, vehicle.ID, log.FilledAt, false))
+		if err != nil {
+			return err
+		}
+		if next != nil && log.OdometerKm > next.OdometerKm {
+			return errs.ValidationErrors{"odometer_km": {"above_next"}}
+		}
+
+		if err := uc.rate(ctx, vehicle, log); err != nil {
+			return err
+		}
+		if err := uc.fuelLogRepo.Create(ctx, log); err != nil {
+			return fmt.Errorf("fuel log repository: create fuel log: %w", err)
+		}
+		return uc.rateNextFullTank(ctx, vehicle, log.FilledAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.toFuelLogOutput(ctx, log)
+}
+
+// GetFuel returns a fill-up by ID
+func (uc *VehicleCostUseCase) GetFuel(ctx context.Context, id uuid.UUID) (*FuelLogOutput, error) {
+	log, err := uc.findFuelLog(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.toFuelLogOutput(ctx, log)
+}
+
+// ListFuel returns a vehicle's fill-ups, latest first
+func (uc *VehicleCostUseCase) ListFuel(ctx context.Context, input ListFuelLogsInput) ([]*FuelLogOutput, int64, error) {
+	if _, err := uc.findVehicle(ctx, input.VehicleID); err != nil {
+		return nil, 0, err
+	}
+
+	logs, total, err := uc.fuelLogRepo.List(ctx, repository.VehicleCostFilter{
+		VehicleID: &input.VehicleID,
+		From:      input.From,
+		To:        input.To,
+	}, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fuel log repository: list fuel logs: %w", err)
+	}
+
+	outputs := make([]*FuelLogOutput, len(logs))
+	for i, l := range logs {
+		if outputs[i], err = uc.toFuelLogOutput(ctx, l); err != nil {
+			return nil, 0, err
+		}
+	}
+	return outputs, total, nil
+}
+
+// DeleteFuel removes a fill-up recorded in error and rates the vehicle's next full tank again
+func (uc *VehicleCostUseCase) DeleteFuel(ctx context.Context, id uuid.UUID) error {
+	log, err := uc.findFuelLog(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return uc.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		vehicle, err := uc.vehicleRepo.FindByIDForUpdate(ctx, log.VehicleID)
+		if err != nil {
+			return fmt.Errorf("vehicle repository: find by id for update: %w", err)
+		}
+		if err := uc.fuelLogRepo.Delete(ctx, log.ID); err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return errs.ErrNotFound
+			}
+			return fmt.Errorf("fuel log repository: delete fuel log: %w", err)
+		}
+		return uc.rateNextFullTank(ctx, vehicle, log.FilledAt)
+	})
+}
+
+// RecordExpense records a running cost of a vehicle other than fuel
+func (uc *VehicleCostUseCase) RecordExpense(ctx context.Context, input ExpenseInput) (*ExpenseOutput, error) {
+	if err := validateExpense(input); err != nil {
+		return nil, err
+	}
+	if _, err := uc.findVehicle(ctx, input.VehicleID); err != nil {
+		return nil, err
+	}
+	if err := uc.checkReferences(ctx, input.DriverID, input.TripID, input.VehicleID); err != nil {
+		return nil, err
+	}
+	if err := uc.checkReceipt(ctx, input.VehicleID, input.ReceiptKey); err != nil {
+		return nil, err
+	}
+
+	createdBy := input.CreatedBy
+	expense := &entity.VehicleExpense{
+		VehicleID:   input.VehicleID,
+		DriverID:    input.DriverID,
+		TripID:      input.TripID,
+		Type:        input.Type,
+		IncurredAt:  input.IncurredAt,
+		Amount:      input.Amount,
+		Description: strings.TrimSpace(input.Description),
+		Reference:   strings.TrimSpace(input.Reference),
+		ReceiptKey:  input.ReceiptKey,
+		CreatedBy:   &createdBy,
+	}
+	if err := uc.expenseRepo.Create(ctx, expense); err != nil {
+		return nil, fmt.Errorf("vehicle expense repository: create expense: %w", err)
+	}
+	return uc.toExpenseOutput(ctx, expense)
+}
+
+// GetExpense returns an expense by ID
+func (uc *VehicleCostUseCase) GetExpense(ctx context.Context, id uuid.UUID) (*ExpenseOutput, error) {
+	expense, err := uc.expenseRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("vehicle expense repository: find by id: %w", err)
+	}
+	return uc.toExpenseOutput(ctx, expense)
+}
+
+// ListExpenses returns a vehicle's expenses, latest first
+func (uc *VehicleCostUseCase) ListExpenses(ctx context.Context, input ListExpensesInput) ([]*ExpenseOutput, int64, error) {
+	if _, err := uc.findVehicle(ctx, input.VehicleID); err != nil {
+		return nil, 0, err
+	}
+
+	expenses, total, err := uc.expenseRepo.List(ctx, repository.VehicleCostFilter{
+		VehicleID: &input.VehicleID,
+		From:      input.From,
+		To:        input.To,
+	}, input.Type, input.Limit, input.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("vehicle expense repository: list expenses: %w", err)
+	}
+
+	outputs := make([]*ExpenseOutput, len(expenses))
+	for i, e := range expenses {
+		if outputs[i], err = uc.toExpenseOutput(ctx, e); err != nil {
+			return nil, 0, err
+		}
+	}
+	return outputs, total, nil
+}
+
+// DeleteExpense removes an expense recorded in error
+func (uc *VehicleCostUseCase) DeleteExpense(ctx context.Context, id uuid.UUID) error {
+	if err := uc.expenseRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return errs.ErrNotFound
+		}
+		return fmt.Errorf("vehicle expense repository: delete expense: %w", err)
+	}
+	return nil
+}
+
+// Report totals the fuel and other running costs of each vehicle over the period's calendar days, by plate
+// number. Efficiency and cost per km are over the distance rated by the period's full-tank fill-ups.
+func (uc *VehicleCostUseCase) Report(ctx context.Context, input ReportInput) (*CostReportOutput, error) {
+	periodFrom := timeutil.CalendarDay(input.PeriodFrom)
+	periodTo := timeutil.CalendarDay(input.PeriodTo)
+	if periodTo.Before(periodFrom) {
+		return nil, errs.ValidationErrors{"period_to": {"before_period_from"}}
+	}
+	if input.VehicleID != nil {
+		if _, err := uc.findVehicle(ctx, *input.VehicleID); err != nil {
+			if errors.Is(err, errs.ErrNotFound) {
+				return nil, errs.ValidationErrors{"vehicle_id": {"not_found"}}
+			}
+			return nil, err
+		}
+	}
+
+	from := timeutil.StartOfDay(periodFrom)
+	to := timeutil.StartOfDay(periodTo.AddDate(0, 0, 1))
+	filter := repository.VehicleCostFilter{VehicleID: input.VehicleID, From: &from, To: &to}
+
+	fuel, err := uc.fuelLogRepo.Summarize(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("fuel log repository: summarize fuel logs: %w", err)
+	}
+	expenses, err := uc.expenseRepo.Summarize(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("vehicle expense repository: summarize expenses: %w", err)
+	}
+
+	rows := make(map[uuid.UUID]*VehicleCostOutput)
+	row := func(vehicleID uuid.UUID) *VehicleCostOutput {
+		r, ok := rows[vehicleID]
+		if !ok {
+			r = &VehicleCostOutput{VehicleID: vehicleID, Expenses: []ExpenseTotalOutput{}}
+			rows[vehicleID] = r
+		}
+		return r
+	}
+	for _, f := range fuel {
+		r := row(f.VehicleID)
+		r.Fills = f.Fills
+		r.Liters = math.Round(f.Liters*100) / 100
+		r.FuelCost = f.Amount
+		r.DistanceKm = math.Round(f.DistanceKm*10) / 10
+		r.FlaggedFills = f.Flagged
+		if f.EfficiencyLiters > 0 {
+			kmPerLiter := math.Round(f.DistanceKm/f.EfficiencyLiters*100) / 100
+			r.KmPerLiter = &kmPerLiter
+		}
+	}
+	for _, e := range expenses {
+		r := row(e.VehicleID)
+		r.Expenses = append(r.Expenses, ExpenseTotalOutput{Type: e.Type, Count: e.Count, Amount: e.Amount})
+		r.ExpenseCost += e.Amount
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	vehicles, err := uc.vehicleRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("vehicle repository: find by ids: %w", err)
+	}
+	for _, v := range vehicles {
+		r := rows[v.ID]
+		r.PlateNumber = v.PlateNumber
+		r.VehicleType = v.Type
+		r.BaselineKmPerLiter = uc.baseline(v)
+	}
+
+	output := &CostReportOutput{PeriodFrom: periodFrom, PeriodTo: periodTo, Vehicles: make([]VehicleCostOutput, 0, len(rows))}
+	for _, r := range rows {
+		r.TotalCost = r.FuelCost + r.ExpenseCost
+		if r.DistanceKm > 0 {
+			costPerKm := entity.Money(math.Round(float64(r.TotalCost) / r.DistanceKm))
+			r.CostPerKm = &costPerKm
+		}
+		output.FuelCost += r.FuelCost
+		output.ExpenseCost += r.ExpenseCost
+		output.Vehicles = append(output.Vehicles, *r)
+	}
+	output.TotalCost = output.FuelCost + output.ExpenseCost
+	slices.SortFunc(output.Vehicles, func(a, b VehicleCostOutput) int {
+		if c := strings.Compare(a.PlateNumber, b.PlateNumber); c != 0 {
+			return c
+		}
+		return strings.Compare(a.VehicleID.String(), b.VehicleID.String())
+	})
+	return output, nil
+}
+
+// rate works out a fill-up's efficiency against the vehicle's previous full tank
+func (uc *VehicleCostUseCase) rate(ctx context.Context, vehicle *entity.Vehicle, log *entity.FuelLog) error {
+	if !log.FullTank {
+		log.ClearRating()
+		return nil
+	}
+	prev, err := uc.adjacentFill(uc.fuelLogRepo.FindPrevious(ctx, vehicle.ID, log.FilledAt, true))
+	if err != nil {
+		return err
+	}
+	if prev == nil {
+		log.ClearRating()
+		return nil
+	}
+
+	liters, err := uc.fuelLogRepo.SumLiters(ctx, vehicle.ID, prev.FilledAt, log.FilledAt)
+	if err != nil {
+		return fmt.Errorf("fuel log repository: sum liters: %w", err)
+	}
+	log.Rate(log.OdometerKm-prev.OdometerKm, liters+log.Liters, uc.baseline(vehicle), uc.tolerance)
+	return nil
+}
+
+// rateNextFullTank rates the vehicle's first full tank after the given time again, if any
+func (uc *VehicleCostUseCase) rateNextFullTank(ctx context.Context, vehicle *entity.Vehicle, after time.Time) error {
+	next, err := uc.adjacentFill(uc.fuelLogRepo.FindNext(ctx, vehicle.ID, after, true))
+	if err != nil || next == nil {
+		return err
+	}
+	if err := uc.rate(ctx, vehicle, next); err != nil {
+		return err
+	}
+	if err := uc.fuelLogRepo.Update(ctx, next); err != nil {
+		return fmt.Errorf("fuel log repository: update fuel log: %w", err)
+	}
+	return nil
+}
+
+// adjacentFill normalizes the result of FindPrevious or FindNext, reporting no fill-up as nil
+func (uc *VehicleCostUseCase) adjacentFill(log *entity.FuelLog, err error) (*entity.FuelLog, error) {
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fuel log repository: find adjacent fuel log: %w", err)
+	}
+	return log, nil
+}
+
+// baseline is the km per litre the vehicle is expected to do, zero when unknown
+func (uc *VehicleCostUseCase) baseline(vehicle *entity.Vehicle) float64 {
+	if vehicle.FuelBaseline > 0 {
+		return vehicle.FuelBaseline
+	}
+	return uc.baselines[vehicle.Type]
+}
+
+// checkReferences confirms that the driver and trip exist and that the trip was run with the vehicle
+func (uc *VehicleCostUseCase) checkReferences(ctx context.Context, driverID, tripID *uuid.UUID, vehicleID uuid.UUID) error {
+	verrs := errs.ValidationErrors{}
+	if driverID != nil {
+		if _, err := uc.driverRepo.FindByID(ctx, *driverID); err != nil {
+			if !errors.Is(err, errs.ErrNotFound) {
+				return fmt.Errorf("driver repository: find by id: %w", err)
+			}
+			verrs["driver_id"] = []string{"not_found"}
+		}
+	}
+	if tripID != nil {
+		trip, err := uc.tripRepo.FindByID(ctx, *tripID)
+		switch {
+		case errors.Is(err, errs.ErrNotFound):
+			verrs["trip_id"] = []string{"not_found"}
+		case err != nil:
+			return fmt.Errorf("trip repository: find by id: %w", err)
+		case trip.VehicleID != vehicleID:
+			verrs["trip_id"] = []string{"other_vehicle"}
+		}
+	}
+	if len(verrs) > 0 {
+		return verrs
+	}
+	return nil
+}
+
+// checkReceipt confirms that a receipt key was issued for the vehicle and that the photo has been uploaded
+func (uc *VehicleCostUseCase) checkReceipt(ctx context.Context, vehicleID uuid.UUID, key string) error {
+	if key == "" {
+		return nil
+	}
+	if !strings.HasPrefix(key, receiptPrefix(vehicleID)) {
+		return errs.ValidationErrors{"receipt_key": {"invalid"}}
+	}
+	ok, err := uc.storageService.ObjectExists(ctx, key)
+	if err != nil {
+		return fmt.Errorf("storage service: object exists: %w", err)
+	}
+	if !ok {
+		return errs.ValidationErrors{"receipt_key": {"not_uploaded"}}
+	}
+	return nil
+}
+
+func (uc *VehicleCostUseCase) findVehicle(ctx context.Context, id uuid.UUID) (*entity.Vehicle, error) {
+	vehicle, err := uc.vehicleRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("vehicle repository: find by id: %w", err)
+	}
+	return vehicle, nil
+}
+
+func (uc *VehicleCostUseCase) findFuelLog(ctx context.Context, id uuid.UUID) (*entity.FuelLog, error) {
+	log, err := uc.fuelLogRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, fmt.Errorf("fuel log repository: find by id: %w", err)
+	}
+	return log, nil
+}
+
+func (uc *VehicleCostUseCase) receiptURL(ctx context.Context, key string) (string, error) {
+	if key == "" {
+		return "", nil
+	}
+	url, err := uc.storageService.GenerateDownloadURL(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("storage service: generate download url: %w", err)
+	}
+	return url, nil
+}
+
+func (uc *VehicleCostUseCase) toFuelLogOutput(ctx context.Context, l *entity.FuelLog) (*FuelLogOutput, error) {
+	url, err := uc.receiptURL(ctx, l.ReceiptKey)
+	if err != nil {
+		return nil, err
+	}
+	return &FuelLogOutput{
+		ID:                 l.ID,
+		VehicleID:          l.VehicleID,
+		DriverID:           l.DriverID,
+		FilledAt:           l.FilledAt,
+		Liters:             l.Liters,
+		PricePerLiter:      l.PricePerLiter,
+		Amount:             l.Amount,
+		OdometerKm:         l.OdometerKm,
+		Station:            l.Station,
+		FullTank:           l.FullTank,
+		ReceiptKey:         l.ReceiptKey,
+		ReceiptURL:         url,
+		Notes:              l.Notes,
+		DistanceKm:         l.DistanceKm,
+		KmPerLiter:         l.KmPerLiter,
+		BaselineKmPerLiter: l.BaselineKmPerLiter,
+		Deviation:          l.Deviation,
+		Flagged:            l.Flagged,
+		CreatedBy:          l.CreatedBy,
+		CreatedAt:          l.CreatedAt,
+		UpdatedAt:          l.UpdatedAt,
+	}, nil
+}
+
+func (uc *VehicleCostUseCase) toExpenseOutput(ctx context.Context, e *entity.VehicleExpense) (*ExpenseOutput, error) {
+	url, err := uc.receiptURL(ctx, e.ReceiptKey)
+	if err != nil {
+		return nil, err
+	}
+	return &ExpenseOutput{
+		ID:          e.ID,
+		VehicleID:   e.VehicleID,
+		DriverID:    e.DriverID,
+		TripID:      e.TripID,
+		Type:        e.Type,
+		IncurredAt:  e.IncurredAt,
+		Amount:      e.Amount,
+		Description: e.Description,
+		Reference:   e.Reference,
+		ReceiptKey:  e.ReceiptKey,
+		ReceiptURL:  url,
+		CreatedBy:   e.CreatedBy,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}, nil
+}
+
+func validateFuelLog(input FuelLogInput) error {
+	verrs := errs.ValidationErrors{}
+	if entity.InFuture(input.FilledAt, time.Now()) {
+		verrs["filled_at"] = []string{"in_future"}
+	}
+	if input.Liters <= 0 {
+		verrs["liters"] = []string{"must_be_positive"}
+	}
+	if input.PricePerLiter <= 0 {
+		verrs["price_per_liter"] = []string{"must_be_positive"}
+	}
+	if input.Amount < 0 {
+		verrs["amount"] = []string{"must_not_be_negative"}
+	}
+	if input.OdometerKm < 0 {
+		verrs["odometer_km"] = []string{"must_not_be_negative"}
+	}
+	if len(verrs) > 0 {
+		return verrs
+	}
+	return nil
+}
+
+func validateExpense(input ExpenseInput) error {
+	verrs := errs.ValidationErrors{}
+	if entity.InFuture(input.IncurredAt, time.Now()) {
+		verrs["incurred_at"] = []string{"in_future"}
+	}
+	if input.Amount <= 0 {
+		verrs["amount"] = []string{"must_be_positive"}
+	}
+	if input.Type == entity.ExpenseOther && strings.TrimSpace(input.Description) == "" {
+		verrs["description"] = []string{"required"}
+	}
+	if len(verrs) > 0 {
+		return verrs
+	}
+	return nil
+}
+
+// receiptPrefix is the storage folder of a vehicle's receipt photos
+func receiptPrefix(vehicleID uuid.UUID) string {
+	return fmt.Sprintf("receipts/vehicles/%s/", vehicleID)
+}
@@ -9,16 +9,35 @@ import (
 type ErrorCode string
 
 const (
-	CodeValidationError    ErrorCode = "VALIDATION_ERROR"
-	CodeUnauthorized       ErrorCode = "UNAUTHORIZED"
-	CodeForbidden          ErrorCode = "FORBIDDEN"
-	CodeNotFound           ErrorCode = "NOT_FOUND"
-	CodeConflict           ErrorCode = "CONFLICT"
-	CodeInternalError      ErrorCode = "INTERNAL_SERVER_ERROR"
-	CodeBadRequest         ErrorCode = "BAD_REQUEST"
-	CodeInvalidCredentials ErrorCode = "INVALID_CREDENTIALS"
-	CodeTokenExpired       ErrorCode = "TOKEN_EXPIRED"
-	CodeTokenInvalid       ErrorCode = "TOKEN_INVALID"
+	CodeValidationError     ErrorCode = "VALIDATION_ERROR"
+	CodeUnauthorized        ErrorCode = "UNAUTHORIZED"
+	CodeForbidden           ErrorCode = "FORBIDDEN"
+	CodeNotFound            ErrorCode = "NOT_FOUND"
+	CodeConflict            ErrorCode = "CONFLICT"
+	CodeInternalError       ErrorCode = "INTERNAL_SERVER_ERROR"
+	CodeBadRequest          ErrorCode = "BAD_REQUEST"
+	CodeInvalidCredentials  ErrorCode = "INVALID_CREDENTIALS"
+	CodeTokenExpired        ErrorCode = "TOKEN_EXPIRED"
+	CodeTokenInvalid        ErrorCode = "TOKEN_INVALID"
+	CodeLicenseExpired      ErrorCode = "DRIVER_LICENSE_EXPIRED"
+	CodeDriverUnavailable   ErrorCode = "DRIVER_UNAVAILABLE"
+	CodeVehicleUnavailable  ErrorCode = "VEHICLE_UNAVAILABLE"
+	CodeMaintenanceOverdue  ErrorCode = "MAINTENANCE_OVERDUE"
+	CodeComplianceExpired   ErrorCode = "COMPLIANCE_DOCUMENT_EXPIRED"
+	CodeCapacityExceeded    ErrorCode = "CAPACITY_EXCEEDED"
+	CodeScheduleConflict    ErrorCode = "SCHEDULE_CONFLICT"
+	CodeSlotUnavailable     ErrorCode = "SLOT_UNAVAILABLE"
+	CodeShipmentUnavailable ErrorCode = "SHIPMENT_UNAVAILABLE"
+	CodeInvalidTransition   ErrorCode = "INVALID_STATUS_TRANSITION"
+	CodeResourceLocked      ErrorCode = "RESOURCE_LOCKED"
+	CodeNoApplicableRate    ErrorCode = "NO_APPLICABLE_RATE"
+	CodeCarrierIneligible   ErrorCode = "CARRIER_INELIGIBLE"
+	CodeOfferExpired        ErrorCode = "OFFER_EXPIRED"
+	CodeOutOfRange          ErrorCode = "POSITION_OUT_OF_RANGE"
+	CodeUpgradeRequired     ErrorCode = "UPGRADE_REQUIRED"
+	CodeChallengeFailed     ErrorCode = "CHALLENGE_FAILED"
+	CodeTooManyRequests     ErrorCode = "TOO_MANY_REQUESTS"
+	CodeServiceBusy         ErrorCode = "SERVICE_BUSY"
 )
 
 const (
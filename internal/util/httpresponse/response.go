@@ -54,6 +54,15 @@ func Created(c *fiber.Ctx, data interface{}, message string) error {
 	})
 }
 
+// Accepted sends an accepted response (202) for work that continues in the background
+func Accepted(c *fiber.Ctx, data interface{}, message string) error {
+	return c.Status(http.StatusAccepted).JSON(Response{
+		Success: true,
+		Message: message,
+		Data:    data,
+	})
+}
+
 // Error sends a standardized error response
 func Error(c *fiber.Ctx, err error) error {
 	// Log the full error chain for internal debugging
@@ -131,6 +140,108 @@ func mapToAPIError(err error) *apierror.APIError {
 			Message:    "Authentication token is invalid",
 			StatusCode: http.StatusUnauthorized,
 		}
+	case errors.Is(err, errs.ErrDriverLicenseExpired):
+		return &apierror.APIError{
+			Code:       apierror.CodeLicenseExpired,
+			Message:    "Driver license has expired",
+			StatusCode: http.StatusUnprocessableEntity,
+		}
+	case errors.Is(err, errs.ErrDriverUnavailable):
+		return &apierror.APIError{
+			Code:       apierror.CodeDriverUnavailable,
+			Message:    "Driver is not available for assignment",
+			StatusCode: http.StatusUnprocessableEntity,
+		}
+	case errors.Is(err, errs.ErrVehicleUnavailable):
+		return &apierror.APIError{
+			Code:       apierror.CodeVehicleUnavailable,
+			Message:    "Vehicle is not available for assignment",
+			StatusCode: http.StatusUnprocessableEntity,
+		}
+	case errors.Is(err, errs.ErrMaintenanceOverdue):
+		return &apierror.APIError{
+			Code:       apierror.CodeMaintenanceOverdue,
+			Message:    "Vehicle is overdue for maintenance",
+			StatusCode: http.StatusUnprocessableEntity,
+		}
+	case errors.Is(err, errs.ErrComplianceExpired):
+		return &apierror.APIError{
+			Code:       apierror.CodeComplianceExpired,
+			Message:    "A required compliance document has expired",
+			StatusCode: http.StatusUnprocessableEntity,
+		}
+	case errors.Is(err, errs.ErrCapacityExceeded):
+		return &apierror.APIError{
+			Code:       apierror.CodeCapacityExceeded,
+			Message:    "Planned load exceeds vehicle capacity",
+			StatusCode: http.StatusUnprocessableEntity,
+		}
+	case errors.Is(err, errs.ErrScheduleConflict):
+		return &apierror.APIError{
+			Code:       apierror.CodeScheduleConflict,
+			Message:    "Vehicle or driver is already booked for an overlapping trip",
+			StatusCode: http.StatusConflict,
+		}
+	case errors.Is(err, errs.ErrSlotUnavailable):
+		return &apierror.APIError{
+			Code:       apierror.CodeSlotUnavailable,
+			Message:    "Dock is fully booked for the requested slot",
+			StatusCode: http.StatusConflict,
+		}
+	case errors.Is(err, errs.ErrShipmentUnavailable):
+		return &apierror.APIError{
+			Code:       apierror.CodeShipmentUnavailable,
+			Message:    "Shipment is not available for planning",
+			StatusCode: http.StatusUnprocessableEntity,
+		}
+	case errors.Is(err, errs.ErrInvalidStatusTransition):
+		return &apierror.APIError{
+			Code:       apierror.CodeInvalidTransition,
+			Message:    "Status change is not allowed",
+			StatusCode: http.StatusUnprocessableEntity,
+		}
+	case errors.Is(err, errs.ErrResourceLocked):
+		return &apierror.APIError{
+			Code:       apierror.CodeResourceLocked,
+			Message:    "Resource can no longer be modified in its current status",
+			StatusCode: http.StatusUnprocessableEntity,
+		}
+	case errors.Is(err, errs.ErrNoApplicableRate):
+		return &apierror.APIError{
+			Code:       apierror.CodeNoApplicableRate,
+			Message:    "No rate card prices this shipment",
+			StatusCode: http.StatusUnprocessableEntity,
+		}
+	case errors.Is(err, errs.ErrCarrierIneligible):
+		return &apierror.APIError{
+			Code:       apierror.CodeCarrierIneligible,
+			Message:    "Carrier cannot be offered this work",
+			StatusCode: http.StatusUnprocessableEntity,
+		}
+	case errors.Is(err, errs.ErrOfferExpired):
+		return &apierror.APIError{
+			Code:       apierror.CodeOfferExpired,
+			Message:    "Tender offer has expired",
+			StatusCode: http.StatusConflict,
+		}
+	case errors.Is(err, errs.ErrOutOfRange):
+		return &apierror.APIError{
+			Code:       apierror.CodeOutOfRange,
+			Message:    "Device position is too far from the expected location",
+			StatusCode: http.StatusUnprocessableEntity,
+		}
+	case errors.Is(err, errs.ErrChallengeFailed):
+		return &apierror.APIError{
+			Code:       apierror.CodeChallengeFailed,
+			Message:    "Postcode or phone number does not match the delivery address",
+			StatusCode: http.StatusForbidden,
+		}
+	case errors.Is(err, errs.ErrServiceBusy):
+		return &apierror.APIError{
+			Code:       apierror.CodeServiceBusy,
+			Message:    "Service is busy, please try again later",
+			StatusCode: http.StatusServiceUnavailable,
+		}
 	default:
 		// Do not expose internal server errors
 		return apierror.NewInternalError("")
@@ -0,0 +1,155 @@
+package barcode
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// code128Values reads the symbol values back from modules by their bar and space widths
+func code128Values(t *testing.T, modules []bool) []int {
+	t.Helper()
+	var widths strings.Builder
+	for i := 0; i < len(modules); {
+		j := i
+		for j < len(modules) && modules[j] == modules[i] {
+			j++
+		}
+		widths.WriteString(strconv.Itoa(j - i))
+		i = j
+	}
+
+	var values []int
+	for s := widths.String(); s != ""; {
+		n := 6
+		if len(s) == 7 {
+			n = 7 // the stop pattern has a trailing bar
+		}
+		if len(s) < n {
+			t.Fatalf("trailing widths %q", s)
+		}
+		v := -1
+		for k, p := range code128Patterns {
+			if p == s[:n] {
+				v = k
+				break
+			}
+		}
+		if v < 0 {
+			t.Fatalf("no symbol with widths %q", s[:n])
+		}
+		values = append(values, v)
+		s = s[n:]
+	}
+	return values
+}
+
+func TestCode128(t *testing.T) {
+	tests := []struct {
+		text   string
+		gs1    bool
+		values []int // without checksum and stop
+	}{
+		{"AB", false, []int{code128StartB, 33, 34}},
+		{"1234", false, []int{code128StartC, 12, 34}},
+		{"123", false, []int{code128StartB, 17, 18, 19}},
+		{"TH123456", false, []int{code128StartB, 52, 40, code128CodeC, 12, 34, 56}},
+		{"12A", false, []int{code128StartB, 17, 18, 33}},
+		{"1234A", false, []int{code128StartC, 12, 34, code128CodeB, 33}},
+		{"00123", true, []int{code128StartB, code128FNC1, 16, code128CodeC, 1, 23}},
+		{"0012345678", true, []int{code128StartC, code128FNC1, 0, 12, 34, 56, 78}},
+	}
+	for _, tt := range tests {
+		encode := Code128
+		if tt.gs1 {
+			encode = GS1128
+		}
+		modules, err := encode(tt.text)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.text, err)
+		}
+		if !modules[0] || !modules[len(modules)-1] {
+			t.Errorf("%q: symbol must start and end with a bar", tt.text)
+		}
+
+		got := code128Values(t, modules)
+		if len(got) != len(tt.values)+2 {
+			t.Fatalf("%q: values = %v, want %v with checksum and stop", tt.text, got, tt.values)
+		}
+		checksum := tt.values[0]
+		for i, v := range tt.values {
+			if got[i] != v {
+				t.Fatalf("%q: values = %v, want %v with checksum and stop", tt.text, got, tt.values)
+			}
+			checksum += i * v
+		}
+		if got[len(got)-2] != checksum%103 {
+			t.Errorf("%q: checksum = %d, want %d", tt.text, got[len(got)-2], checksum%103)
+		}
+		if got[len(got)-1] != code128Stop {
+			t.Errorf("%q: last symbol = %d, want stop", tt.text, got[len(got)-1])
+		}
+	}
+}
+
+func TestCode128RejectsUnsupportedText(t *testing.T) {
+	for _, text := range []string{"", "ก", "A\tB"} {
+		if _, err := Code128(text); err == nil {
+			t.Errorf("Code128(%q): expected an error", text)
+		}
+	}
+}
+
+func TestQRCode(t *testing.T) {
+	tests := []struct {
+		size int
+		want int
+	}{
+		{14, 21},  // version 1 holds 14 bytes at level M
+		{15, 25},  // version 2
+		{213, 57}, // version 10
+	}
+	for _, tt := range tests {
+		q, err := QRCode([]byte(strings.Repeat("x", tt.size)))
+		if err != nil {
+			t.Fatalf("%d bytes: %v", tt.size, err)
+		}
+		if q.Size() != tt.want {
+			t.Errorf("%d bytes: size = %d, want %d", tt.size, q.Size(), tt.want)
+		}
+		// the finder patterns: a dark ring around a light ring around a dark 3x3 centre
+		for _, corner := range [][2]int{{0, 0}, {q.Size() - 7, 0}, {0, q.Size() - 7}} {
+			for y := 0; y < 7; y++ {
+				for x := 0; x < 7; x++ {
+					ring := min(x, y, 6-x, 6-y)
+					if want := ring != 1; q.Dark(corner[0]+x, corner[1]+y) != want {
+						t.Fatalf("%d bytes: finder pattern at %v broken at (%d,%d)", tt.size, corner, x, y)
+					}
+				}
+			}
+		}
+	}
+
+	if _, err := QRCode([]byte(strings.Repeat("x", 214))); err == nil {
+		t.Error("expected an error for data beyond version 10")
+	}
+}
+
+func TestQRCodeIsDeterministic(t *testing.T) {
+	data := []byte("https://track.example.com/t/TH2605000001")
+	a, err := QRCode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := QRCode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for y := 0; y < a.Size(); y++ {
+		for x := 0; x < a.Size(); x++ {
+			if a.Dark(x, y) != b.Dark(x, y) {
+				t.Fatalf("symbols differ at (%d,%d)", x, y)
+			}
+		}
+	}
+}
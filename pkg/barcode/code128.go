@@ -0,0 +1,115 @@
+// Package barcode encodes Code 128 and QR Code symbols as module patterns for drawing.
+// It does no rendering itself: callers draw a dark box for every set module.
+package barcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// code128Patterns holds the bar and space widths, in modules, of every Code 128 symbol value.
+// Each starts with a bar; values 103-105 are the start codes and 106 is the stop pattern.
+var code128Patterns = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	code128FNC1   = 102
+	code128CodeC  = 99
+	code128CodeB  = 100
+	code128StartB = 104
+	code128StartC = 105
+	code128Stop   = 106
+)
+
+// Code128 encodes printable ASCII text as a Code 128 symbol and returns its modules from left
+// to right, true for a bar. Runs of digits are packed two per symbol in code set C. The
+// symbol needs a quiet zone of ten modules on either side.
+func Code128(text string) ([]bool, error) {
+	return code128(text, false)
+}
+
+// GS1128 encodes GS1 element strings, each an application identifier followed by its data
+// without parentheses (e.g. "00" and an 18-digit SSCC), as a GS1-128 symbol: Code 128 with a
+// leading FNC1. Only predefined-length elements may be concatenated without a separator.
+func GS1128(elements string) ([]bool, error) {
+	return code128(elements, true)
+}
+
+func code128(text string, gs1 bool) ([]bool, error) {
+	if text == "" {
+		return nil, errors.New("code128: empty text")
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] < 32 || text[i] > 126 {
+			return nil, fmt.Errorf("code128: unsupported character at %d", i)
+		}
+	}
+
+	var values []int
+	setC := false
+	if n := digitRun(text, 0); n%2 == 0 && (n >= 4 || n == len(text)) {
+		values = append(values, code128StartC)
+		setC = true
+	} else {
+		values = append(values, code128StartB)
+	}
+	if gs1 {
+		values = append(values, code128FNC1)
+	}
+
+	for i := 0; i < len(text); {
+		n := digitRun(text, i)
+		switch {
+		case setC && n >= 2:
+			values = append(values, int(text[i]-'0')*10+int(text[i+1]-'0'))
+			i += 2
+		case setC:
+			values = append(values, code128CodeB)
+			setC = false
+		case n%2 == 0 && (n >= 6 || (n >= 4 && i+n == len(text))):
+			values = append(values, code128CodeC)
+			setC = true
+		default:
+			values = append(values, int(text[i])-32)
+			i++
+		}
+	}
+
+	checksum := values[0]
+	for i, v := range values[1:] {
+		checksum += (i + 1) * v
+	}
+	values = append(values, checksum%103, code128Stop)
+
+	var modules []bool
+	for _, v := range values {
+		bar := true
+		for _, w := range code128Patterns[v] {
+			for j := 0; j < int(w-'0'); j++ {
+				modules = append(modules, bar)
+			}
+			bar = !bar
+		}
+	}
+	return modules, nil
+}
+
+// digitRun returns how many digits follow text[i:]
+func digitRun(text string, i int) int {
+	n := 0
+	for i+n < len(text) && text[i+n] >= '0' && text[i+n] <= '9' {
+		n++
+	}
+	return n
+}
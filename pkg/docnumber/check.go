@@ -0,0 +1,44 @@
+package docnumber
+
+// Mod11 returns the modulus 11 check character of the digits in s; other characters are ignored.
+// Digits are weighted 2, 3, 4, 5, 6, 7, 2, ... from the right. It catches every single mistyped digit
+// and every swap of two adjacent digits. A remainder of 10 is written as X.
+func Mod11(s string) byte {
+	sum, weight := 0, 2
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		sum += int(c-'0') * weight
+		if weight++; weight > 7 {
+			weight = 2
+		}
+	}
+	switch r := (11 - sum%11) % 11; r {
+	case 10:
+		return 'X'
+	default:
+		return byte('0' + r)
+	}
+}
+
+// Mod10 returns the Luhn check digit of the digits in s; other characters are ignored
+func Mod10(s string) byte {
+	sum, double := 0, true
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
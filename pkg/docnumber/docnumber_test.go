@@ -0,0 +1,159 @@
+package docnumber
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMod11(t *testing.T) {
+	for s, want := range map[string]byte{
+		"123456":       '0',
+		"12345":        '5',
+		"6":            'X',
+		"TMS-12-345":   '5', // only digits count
+		"":             '0',
+		"26100004217":  '8', // 2·7+3·1+4·2+5·4+4·1+5·6+6·2 = 91, 11 - 91 mod 11 = 8
+		"2610-0004217": '8',
+	} {
+		if got := Mod11(s); got != want {
+			t.Errorf("Mod11(%q) = %c, want %c", s, got, want)
+		}
+	}
+}
+
+func TestMod11CatchesTypos(t *testing.T) {
+	const number = "2610004217"
+	check := Mod11(number)
+	for i := 0; i < len(number); i++ {
+		for d := byte('0'); d <= '9'; d++ {
+			if d == number[i] {
+				continue
+			}
+			typo := number[:i] + string(d) + number[i+1:]
+			if Mod11(typo) == check {
+				t.Errorf("mistyped %s as %s without changing the check character", number, typo)
+			}
+		}
+		if i+1 < len(number) && number[i] != number[i+1] {
+			swapped := number[:i] + string(number[i+1]) + string(number[i]) + number[i+2:]
+			if Mod11(swapped) == check {
+				t.Errorf("swapped %s to %s without changing the check character", number, swapped)
+			}
+		}
+	}
+}
+
+func TestMod10(t *testing.T) {
+	for s, want := range map[string]byte{
+		"7992739871":         '3',
+		"4111-1111-1111-111": '1',
+		"0":                  '0',
+	} {
+		if got := Mod10(s); got != want {
+			t.Errorf("Mod10(%q) = %c, want %c", s, got, want)
+		}
+	}
+}
+
+func TestFormatRender(t *testing.T) {
+	at := time.Date(2026, 3, 7, 10, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		format string
+		seq    int64
+		want   string
+		period Period
+		key    string
+	}{
+		{"TRP-{YYMM}-{seq:5}", 42, "TRP-2603-00042", PeriodMonthly, "2026-03"},
+		{"INV{BBBB}{seq:4}", 7, "INV25690007", PeriodYearly, "2026"},
+		{"{BB}{MM}{DD}-{seq:3}", 1234, "690307-1234", PeriodDaily, "2026-03-07"},
+		{"TH{seq:8}{mod11}", 4217, "TH00004217" + string(Mod11("00004217")), PeriodNever, ""},
+		{"{seq}", 9, "9", PeriodNever, ""},
+	} {
+		f, err := Parse(tc.format)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tc.format, err)
+		}
+		if got := f.Render(at, tc.seq); got != tc.want {
+			t.Errorf("%s renders %d as %q, want %q", tc.format, tc.seq, got, tc.want)
+		}
+		if f.Period() != tc.period || f.PeriodKey(at) != tc.key {
+			t.Errorf("%s: period %d key %q, want %d %q", tc.format, f.Period(), f.PeriodKey(at), tc.period, tc.key)
+		}
+		if !f.Valid(tc.want) {
+			t.Errorf("%s: %q is not valid", tc.format, tc.want)
+		}
+	}
+}
+
+func TestFormatValid(t *testing.T) {
+	f, err := Parse("TMS-{YYMM}-{seq:6}{mod11}")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	number := f.Render(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), 123)
+	if !f.HasCheck() || !f.Valid(number) {
+		t.Fatalf("%q is not valid for %s", number, f)
+	}
+
+	wrongCheck := number[:len(number)-1] + "X"
+	if number[len(number)-1] == 'X' {
+		wrongCheck = number[:len(number)-1] + "0"
+	}
+	for _, n := range []string{
+		wrongCheck,
+		"TMS-2610-00012" + string(Mod11("TMS-2610-00012")), // sequence too short
+		"TMS-2610-000123", // check character missing
+		"XXX-2610-000123" + string(Mod11("2610000123")),
+	} {
+		if f.Valid(n) {
+			t.Errorf("%q is valid for %s", n, f)
+		}
+	}
+	if !f.Matches(wrongCheck) {
+		t.Errorf("%q does not match the shape of %s", wrongCheck, f)
+	}
+	// the sequence may outgrow its padding
+	if !f.Valid(f.Render(time.Now(), 12345678)) {
+		t.Error("a sequence wider than its padding is not valid")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for format, want := range map[string]error{
+		"":                 ErrEmptyFormat,
+		"INV-{YYMM}":       ErrMissingSequence,
+		"INV-{seq}-{seq}":  ErrDuplicateSequence,
+		"INV-{seq":         ErrUnclosedPlaceholder,
+		"INV-{QQ}-{seq}":   ErrUnknownPlaceholder,
+		"INV-{seq:0}":      ErrUnknownPlaceholder,
+		"INV-{seq:13}":     ErrUnknownPlaceholder,
+		"{seq}{mod11}-A":   ErrCheckNotLast,
+		"{seq}{mod11}{MM}": ErrCheckNotLast,
+		"{MM}-{seq}":       ErrPartialPeriod,
+		"{YY}{DD}-{seq}":   ErrPartialPeriod,
+		"{BB}-{DD}{seq}":   ErrPartialPeriod,
+	} {
+		_, err := Parse(format)
+		if !errors.Is(err, want) {
+			t.Errorf("Parse(%q): err = %v, want %v", format, err, want)
+		}
+	}
+}
+
+func TestFormatPrefix(t *testing.T) {
+	for format, want := range map[string]string{
+		"TMS-{YYMM}-{seq:6}{mod11}": "TMS-",
+		"{YYYY}{MM}-{seq}":          "",
+		"INV{seq}":                  "INV",
+	} {
+		f, err := Parse(format)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", format, err)
+		}
+		if got := f.Prefix(); got != want {
+			t.Errorf("%s: prefix %q, want %q", format, got, want)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+// Package docnumber renders and checks document numbers from formats such as "TMS-{YYMM}-{seq:6}{mod11}".
+//
+// Placeholders:
+//
+//	{YYYY} {YY}     year (Gregorian)
+//	{BBBB} {BB}     year of the Thai Buddhist era (Gregorian + 543)
+//	{MM} {DD}       month and day
+//	{YYMM} {BBMM}   two-digit year followed by the month
+//	{seq:N}         sequence number, zero-padded to at least N digits
+//	{mod11}         check character over every digit before it: 0-9 or X
+//	{mod10}         Luhn check digit over every digit before it
+//
+// Everything else is copied literally. A check placeholder must come last. A format whose sequence
+// restarts must name the whole period, e.g. the year as well as the month, or its numbers repeat.
+package docnumber
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period is how often the sequence of a format starts again from 1
+type Period int
+
+const (
+	PeriodNever Period = iota
+	PeriodYearly
+	PeriodMonthly
+	PeriodDaily
+)
+
+// maxSeqWidth bounds the padding of {seq:N}
+const maxSeqWidth = 12
+
+var (
+	ErrEmptyFormat         = errors.New("docnumber: empty format")
+	ErrUnknownPlaceholder  = errors.New("docnumber: unknown placeholder")
+	ErrUnclosedPlaceholder = errors.New("docnumber: unclosed placeholder")
+	ErrMissingSequence     = errors.New("docnumber: format has no {seq} placeholder")
+	ErrCheckNotLast        = errors.New("docnumber: check placeholder must come last")
+	ErrDuplicateSequence   = errors.New("docnumber: format has more than one {seq} placeholder")
+	ErrPartialPeriod       = errors.New("docnumber: format restarts its sequence without naming the whole period")
+)
+
+type tokenKind int
+
+const (
+	tokenLiteral tokenKind = iota
+	tokenDate              // rendered with layout
+	tokenSeq
+	tokenMod11
+	tokenMod10
+)
+
+type token struct {
+	kind     tokenKind
+	text     string // literal text or placeholder name
+	width    int    // seq padding or date digits
+	buddhist bool   // date year in the Buddhist era
+}
+
+// Format is a parsed number format
+type Format struct {
+	source  string
+	tokens  []token
+	period  Period
+	pattern *regexp.Regexp
+}
+
+// dateTokens maps date placeholders to their width, reset period and the date parts they show
+var dateTokens = map[string]struct {
+	width  int
+	period Period
+	parts  uint8 // bit per period whose part of the date is shown
+}{
+	"YYYY": {4, PeriodYearly, 1 << PeriodYearly},
+	"YY":   {2, PeriodYearly, 1 << PeriodYearly},
+	"BBBB": {4, PeriodYearly, 1 << PeriodYearly},
+	"BB":   {2, PeriodYearly, 1 << PeriodYearly},
+	"MM":   {2, PeriodMonthly, 1 << PeriodMonthly},
+	"DD":   {2, PeriodDaily, 1 << PeriodDaily},
+	"YYMM": {4, PeriodMonthly, 1<<PeriodYearly | 1<<PeriodMonthly},
+	"BBMM": {4, PeriodMonthly, 1<<PeriodYearly | 1<<PeriodMonthly},
+}
+
+// Parse parses a number format. The format must contain exactly one {seq} placeholder.
+func Parse(format string) (*Format, error) {
+	if format == "" {
+		return nil, ErrEmptyFormat
+	}
+
+	f := &Format{source: format}
+	hasSeq := false
+	var parts uint8
+	rest := format
+	for rest != "" {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			f.tokens = append(f.tokens, token{kind: tokenLiteral, text: rest})
+			break
+		}
+		if open > 0 {
+			f.tokens = append(f.tokens, token{kind: tokenLiteral, text: rest[:open]})
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, ErrUnclosedPlaceholder
+		}
+		name := rest[open+1 : open+end]
+		rest = rest[open+end+1:]
+
+		switch {
+		case name == "mod11":
+			f.tokens = append(f.tokens, token{kind: tokenMod11})
+		case name == "mod10":
+			f.tokens = append(f.tokens, token{kind: tokenMod10})
+		case name == "seq" || strings.HasPrefix(name, "seq:"):
+			if hasSeq {
+				return nil, ErrDuplicateSequence
+			}
+			hasSeq = true
+			width := 1
+			if name != "seq" {
+				w, err := strconv.Atoi(strings.TrimPrefix(name, "seq:"))
+				if err != nil || w < 1 || w > maxSeqWidth {
+					return nil, fmt.Errorf("%w: {%s}", ErrUnknownPlaceholder, name)
+				}
+				width = w
+			}
+			f.tokens = append(f.tokens, token{kind: tokenSeq, width: width})
+		default:
+			d, ok := dateTokens[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: {%s}", ErrUnknownPlaceholder, name)
+			}
+			f.tokens = append(f.tokens, token{kind: tokenDate, text: name, width: d.width, buddhist: name[0] == 'B'})
+			if d.period > f.period {
+				f.period = d.period
+			}
+			parts |= d.parts
+		}
+	}
+	if !hasSeq {
+		return nil, ErrMissingSequence
+	}
+	for _, t := range f.tokens[:len(f.tokens)-1] {
+		if t.kind == tokenMod11 || t.kind == tokenMod10 {
+			return nil, ErrCheckNotLast
+		}
+	}
+	// e.g. {MM}-{seq} starts again every month and issues the numbers of a year ago
+	for p := PeriodYearly; p <= f.period; p++ {
+		if parts&(1<<p) == 0 {
+			return nil, ErrPartialPeriod
+		}
+	}
+
+	f.pattern = regexp.MustCompile("^" + f.regexp() + "$")
+	return f, nil
+}
+
+// String returns the format as given to Parse
+func (f *Format) String() string {
+	return f.source
+}
+
+// Period returns how often the format's sequence starts again
+func (f *Format) Period() Period {
+	return f.period
+}
+
+// Prefix returns the text every number of the format begins with, up to its first placeholder
+func (f *Format) Prefix() string {
+	if f.tokens[0].kind == tokenLiteral {
+		return f.tokens[0].text
+	}
+	return ""
+}
+
+// HasCheck reports whether numbers of the format end in a check character
+func (f *Format) HasCheck() bool {
+	k := f.tokens[len(f.tokens)-1].kind
+	return k == tokenMod11 || k == tokenMod10
+}
+
+// PeriodKey names the sequence period that at falls in, e.g. "2026-03" for a monthly format.
+// It is empty for formats whose sequence never restarts.
+func (f *Format) PeriodKey(at time.Time) string {
+	switch f.period {
+	case PeriodYearly:
+		return at.Format("2006")
+	case PeriodMonthly:
+		return at.Format("2006-01")
+	case PeriodDaily:
+		return at.Format("2006-01-02")
+	}
+	return ""
+}
+
+// Render returns the number with sequence value seq issued at the given time.
+// Dates are taken as they are; convert at to the wanted time zone first.
+func (f *Format) Render(at time.Time, seq int64) string {
+	var b strings.Builder
+	for _, t := range f.tokens {
+		switch t.kind {
+		case tokenLiteral:
+			b.WriteString(t.text)
+		case tokenDate:
+			b.WriteString(renderDate(t, at))
+		case tokenSeq:
+			fmt.Fprintf(&b, "%0*d", t.width, seq)
+		case tokenMod11:
+			b.WriteByte(Mod11(b.String()))
+		case tokenMod10:
+			b.WriteByte(Mod10(b.String()))
+		}
+	}
+	return b.String()
+}
+
+// Matches reports whether number has the shape of the format, ignoring its check character's value
+func (f *Format) Matches(number string) bool {
+	return f.pattern.MatchString(number)
+}
+
+// Valid reports whether number has the shape of the format and, if the format has one, a correct check character
+func (f *Format) Valid(number string) bool {
+	if !f.Matches(number) {
+		return false
+	}
+	if !f.HasCheck() {
+		return true
+	}
+	body, check := number[:len(number)-1], number[len(number)-1]
+	if f.tokens[len(f.tokens)-1].kind == tokenMod11 {
+		return Mod11(body) == check
+	}
+	return Mod10(body) == check
+}
+
+// regexp returns a pattern matching the numbers of the format
+func (f *Format) regexp() string {
+	var b strings.Builder
+	for _, t := range f.tokens {
+		switch t.kind {
+		case tokenLiteral:
+			b.WriteString(regexp.QuoteMeta(t.text))
+		case tokenDate:
+			fmt.Fprintf(&b, `\d{%d}`, t.width)
+		case tokenSeq:
+			fmt.Fprintf(&b, `\d{%d,}`, t.width)
+		case tokenMod11:
+			b.WriteString(`[0-9X]`)
+		case tokenMod10:
+			b.WriteString(`\d`)
+		}
+	}
+	return b.String()
+}
+
+func renderDate(t token, at time.Time) string {
+	year := at.Year()
+	if t.buddhist {
+		year += 543
+	}
+	switch t.text {
+	case "YYYY", "BBBB":
+		return fmt.Sprintf("%04d", year)
+	case "YY", "BB":
+		return fmt.Sprintf("%02d", year%100)
+	case "MM":
+		return fmt.Sprintf("%02d", int(at.Month()))
+	case "DD":
+		return fmt.Sprintf("%02d", at.Day())
+	default: // YYMM, BBMM
+		return fmt.Sprintf("%02d%02d", year%100, int(at.Month()))
+	}
+}
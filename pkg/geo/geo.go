@@ -0,0 +1,29 @@
+// Package geo contains small geometry helpers for WGS84 coordinates.
+package geo
+
+import "math"
+
+// earthRadiusMeters is the mean Earth radius used for great-circle distances
+const earthRadiusMeters = 6371008.8
+
+// Point is a WGS84 coordinate in decimal degrees
+type Point struct {
+	Lat float64
+	Lng float64
+}
+
+// Haversine returns the great-circle distance between two points in meters
+func Haversine(a, b Point) float64 {
+	lat1 := toRadians(a.Lat)
+	lat2 := toRadians(b.Lat)
+	dLat := lat2 - lat1
+	dLng := toRadians(b.Lng - a.Lng)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,39 @@
+package geo
+
+import "math"
+
+// Contains reports whether p lies inside the polygon given by its vertices in order.
+// The polygon is closed implicitly; points exactly on an edge may fall either way.
+func Contains(polygon []Point, p Point) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lng < (b.Lng-a.Lng)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// DistanceToPolygon returns the distance in meters from p to the nearest edge of the polygon,
+// whether p is inside or outside it
+func DistanceToPolygon(polygon []Point, p Point) float64 {
+	if len(polygon) == 0 {
+		return math.Inf(1)
+	}
+
+	// Project onto a local plane around p; geofences are small enough for the error to be negligible
+	cosLat := math.Cos(toRadians(p.Lat))
+	project := func(q Point) (float64, float64) {
+		return toRadians(q.Lng-p.Lng) * cosLat * earthRadiusMeters, toRadians(q.Lat-p.Lat) * earthRadiusMeters
+	}
+
+	best := math.Inf(1)
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		ax, ay := project(polygon[j])
+		bx, by := project(polygon[i])
+		best = math.Min(best, segmentDistance(0, 0, ax, ay, bx, by))
+	}
+	return best
+}
@@ -0,0 +1,69 @@
+package geo
+
+import "math"
+
+// Simplify reduces a path with the Douglas-Peucker algorithm and returns the indices of the points to keep,
+// in order. A point is dropped when it lies within toleranceMeters of the segment joining the points kept
+// around it. The first and last points are always kept.
+func Simplify(path []Point, toleranceMeters float64) []int {
+	n := len(path)
+	if n <= 2 || toleranceMeters <= 0 {
+		keep := make([]int, n)
+		for i := range keep {
+			keep[i] = i
+		}
+		return keep
+	}
+
+	// Project onto a local plane in meters; the error is negligible over the extent of a vehicle track
+	origin := path[0]
+	cosLat := math.Cos(toRadians(origin.Lat))
+	xs := make([]float64, n)
+	ys := make([]float64, n)
+	for i, p := range path {
+		xs[i] = toRadians(p.Lng-origin.Lng) * cosLat * earthRadiusMeters
+		ys[i] = toRadians(p.Lat-origin.Lat) * earthRadiusMeters
+	}
+
+	kept := make([]bool, n)
+	kept[0], kept[n-1] = true, true
+
+	// Iterative to keep long tracks from growing the call stack
+	type span struct{ first, last int }
+	stack := []span{{0, n - 1}}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		maxDist, index := 0.0, -1
+		for i := s.first + 1; i < s.last; i++ {
+			d := segmentDistance(xs[i], ys[i], xs[s.first], ys[s.first], xs[s.last], ys[s.last])
+			if d > maxDist {
+				maxDist, index = d, i
+			}
+		}
+		if index >= 0 && maxDist > toleranceMeters {
+			kept[index] = true
+			stack = append(stack, span{s.first, index}, span{index, s.last})
+		}
+	}
+
+	var keep []int
+	for i, k := range kept {
+		if k {
+			keep = append(keep, i)
+		}
+	}
+	return keep
+}
+
+// segmentDistance returns the distance from (px, py) to the segment (ax, ay)-(bx, by)
+func segmentDistance(px, py, ax, ay, bx, by float64) float64 {
+	dx, dy := bx-ax, by-ay
+	if dx == 0 && dy == 0 {
+		return math.Hypot(px-ax, py-ay)
+	}
+	t := ((px-ax)*dx + (py-ay)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(px-(ax+t*dx), py-(ay+t*dy))
+}
@@ -0,0 +1,35 @@
+package geo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSimplifyDropsPointsWithinTolerance(t *testing.T) {
+	// an eastbound road with a 1 m wobble and one 200 m detour; 0.001° of longitude is about 110 m here
+	path := []Point{
+		{Lat: 13.7, Lng: 100.500},
+		{Lat: 13.700005, Lng: 100.501},
+		{Lat: 13.7, Lng: 100.502},
+		{Lat: 13.7018, Lng: 100.503},
+		{Lat: 13.7, Lng: 100.504},
+		{Lat: 13.7, Lng: 100.505},
+	}
+
+	if got, want := Simplify(path, 10), []int{0, 2, 3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Simplify(10 m) = %v, want %v", got, want)
+	}
+	if got, want := Simplify(path, 500), []int{0, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Simplify(500 m) = %v, want %v", got, want)
+	}
+}
+
+func TestSimplifyKeepsEverythingWithoutTolerance(t *testing.T) {
+	path := []Point{{Lat: 13.7, Lng: 100.5}, {Lat: 13.7, Lng: 100.6}, {Lat: 13.7, Lng: 100.7}}
+	if got := Simplify(path, 0); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("Simplify(0) = %v, want every index", got)
+	}
+	if got := Simplify(nil, 10); len(got) != 0 {
+		t.Errorf("Simplify(nil) = %v, want none", got)
+	}
+}
@@ -0,0 +1,109 @@
+package loadplan
+
+import (
+	"fmt"
+	"hash/fnv"
+	"html"
+	"sort"
+	"strings"
+)
+
+// View names
+const (
+	ViewTop  = "top"  // looking down: X across, Y down
+	ViewSide = "side" // looking from the left: X across, Z up
+)
+
+// palette colors units by item so identical units share a color
+var palette = []string{
+	"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f",
+	"#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac",
+}
+
+// Rect is a unit projected onto a view, in millimeters from the view's top-left corner
+type Rect struct {
+	ItemID string
+	Unit   int
+	X      int
+	Y      int
+	Width  int
+	Height int
+	Color  string
+}
+
+// View is a 2D projection of the cargo space, with rectangles in drawing order (back to front)
+type View struct {
+	Name   string
+	Width  int
+	Height int
+	Rects  []Rect
+}
+
+// Layout returns top and side projections of the plan's placements
+func Layout(space Space, placements []Placement) []View {
+	top := View{Name: ViewTop, Width: space.Length, Height: space.Width}
+	side := View{Name: ViewSide, Width: space.Length, Height: space.Height}
+
+	// Draw lower units first in the top view and farther units first in the side view
+	byZ := append([]Placement(nil), placements...)
+	sort.SliceStable(byZ, func(i, j int) bool { return byZ[i].Z < byZ[j].Z })
+	for _, p := range byZ {
+		top.Rects = append(top.Rects, Rect{
+			ItemID: p.ItemID, Unit: p.Unit,
+			X: p.X, Y: p.Y, Width: p.Length, Height: p.Width,
+			Color: Color(p.ItemID),
+		})
+	}
+
+	byY := append([]Placement(nil), placements...)
+	sort.SliceStable(byY, func(i, j int) bool { return byY[i].Y > byY[j].Y })
+	for _, p := range byY {
+		side.Rects = append(side.Rects, Rect{
+			ItemID: p.ItemID, Unit: p.Unit,
+			X: p.X, Y: space.Height - p.Z - p.Height, Width: p.Length, Height: p.Height,
+			Color: Color(p.ItemID),
+		})
+	}
+
+	return []View{top, side}
+}
+
+// Color returns the display color of an item
+func Color(itemID string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(itemID))
+	return palette[h.Sum32()%uint32(len(palette))]
+}
+
+// SVG geometry in pixels; views are scaled to fit the width
+const (
+	svgWidth   = 800
+	svgMargin  = 20
+	svgCaption = 16
+)
+
+// SVG renders the views stacked vertically as a standalone SVG document
+func SVG(views []View) string {
+	if len(views) == 0 {
+		return ""
+	}
+
+	scale := float64(svgWidth-2*svgMargin) / float64(views[0].Width)
+	var body strings.Builder
+	y := float64(svgMargin)
+	for _, v := range views {
+		fmt.Fprintf(&body, `<text x="%d" y="%.1f" font-family="sans-serif" font-size="12">%s</text>`, svgMargin, y+12, html.EscapeString(v.Name))
+		y += svgCaption
+		fmt.Fprintf(&body, `<g transform="translate(%d %.1f) scale(%.5f)">`, svgMargin, y, scale)
+		fmt.Fprintf(&body, `<rect x="0" y="0" width="%d" height="%d" fill="#f7f7f7" stroke="#333" stroke-width="%.1f"/>`, v.Width, v.Height, 2/scale)
+		for _, r := range v.Rects {
+			fmt.Fprintf(&body, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="#222" stroke-width="%.1f"><title>%s #%d</title></rect>`,
+				r.X, r.Y, r.Width, r.Height, r.Color, 1/scale, html.EscapeString(r.ItemID), r.Unit)
+		}
+		body.WriteString(`</g>`)
+		y += float64(v.Height)*scale + svgMargin
+	}
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%.0f" viewBox="0 0 %d %.0f">%s</svg>`,
+		svgWidth, y, svgWidth, y, body.String())
+}
@@ -0,0 +1,359 @@
+// Package loadplan packs handling units into a vehicle cargo space.
+//
+// Units are placed one by one at extreme points (corners created by earlier placements),
+// filling from the front wall towards the doors and from the floor up. Units always stay
+// upright; unless their orientation is locked they may be turned 90° on the floor plane.
+// All dimensions are millimeters measured from the front-left floor corner of the cargo
+// space: X runs towards the doors, Y across the width and Z up.
+package loadplan
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Reasons a unit is left over
+const (
+	ReasonTooLarge   = "too_large"  // does not fit the empty cargo space in any allowed orientation
+	ReasonOverweight = "overweight" // would exceed the payload
+	ReasonNoSpace    = "no_space"   // no supported position is left
+)
+
+// Warnings about the balance of a plan
+const (
+	WarningLateralImbalance = "lateral_imbalance" // center of gravity is far off the centerline
+	WarningFrontAxleUnload  = "front_axle_unload" // center of gravity is behind the rear axle
+	WarningRearAxleUnload   = "rear_axle_unload"  // center of gravity is ahead of the front axle
+)
+
+const (
+	// minSupport is the share of a unit's base that must rest on the floor or on stackable units
+	minSupport = 0.8
+	// maxLateralOffset is the share of the width the center of gravity may be off the centerline
+	maxLateralOffset = 0.1
+)
+
+// ErrInvalidInput is returned when the space or a unit has non-positive dimensions
+var ErrInvalidInput = errors.New("invalid load plan input")
+
+// Space is the cargo space of a vehicle
+type Space struct {
+	Length    int
+	Width     int
+	Height    int
+	MaxWeight float64 // kg; zero means unlimited
+	// FrontAxle and RearAxle are axle positions along X; the front axle is usually negative (under the cab).
+	// Axle loads are computed only when both are set.
+	FrontAxle *int
+	RearAxle  *int
+}
+
+// Item is a handling unit type, repeated Quantity times
+type Item struct {
+	ID                string
+	Quantity          int
+	Length            int
+	Width             int
+	Height            int
+	Weight            float64 // kg per unit
+	Stackable         bool    // other units may rest on top
+	OrientationLocked bool    // must keep its length along X
+}
+
+// Placement is a unit placed in the cargo space, with its dimensions as oriented
+type Placement struct {
+	ItemID  string
+	Unit    int // 1-based index within the item's quantity
+	X, Y, Z int
+	Length  int
+	Width   int
+	Height  int
+	Rotated bool
+	Weight  float64
+}
+
+// Leftover is a unit that could not be loaded
+type Leftover struct {
+	ItemID string
+	Unit   int
+	Reason string
+}
+
+// Point is a position in the cargo space
+type Point struct {
+	X, Y, Z float64
+}
+
+// Axles are the cargo's share of the load on each axle in kg
+type Axles struct {
+	Front float64
+	Rear  float64
+}
+
+// Plan is the result of Pack
+type Plan struct {
+	Placements      []Placement
+	Leftovers       []Leftover
+	VolumeFill      float64 // percent of the cargo volume
+	WeightFill      float64 // percent of the payload; zero when unlimited
+	UsedLength      int     // distance from the front wall to the rearmost unit
+	TotalWeight     float64
+	CenterOfGravity Point
+	LateralOffset   float64 // center of gravity from the centerline, positive towards +Y
+	Axles           *Axles
+	Warnings        []string
+}
+
+type unit struct {
+	item *Item
+	n    int
+}
+
+// corner is an extreme point where the next unit may be placed
+type corner struct {
+	x, y, z int
+}
+
+type packer struct {
+	space     Space
+	placed    []Placement
+	stackable []bool // parallel to placed
+	corners   []corner
+	weight    float64
+}
+
+// Pack places as many units as possible into the space
+func Pack(space Space, items []Item) (*Plan, error) {
+	if space.Length <= 0 || space.Width <= 0 || space.Height <= 0 {
+		return nil, fmt.Errorf("%w: cargo space dimensions must be positive", ErrInvalidInput)
+	}
+
+	var units []unit
+	for i := range items {
+		it := &items[i]
+		if it.Length <= 0 || it.Width <= 0 || it.Height <= 0 || it.Weight < 0 {
+			return nil, fmt.Errorf("%w: item %s has invalid dimensions or weight", ErrInvalidInput, it.ID)
+		}
+		for n := 1; n <= it.Quantity; n++ {
+			units = append(units, unit{item: it, n: n})
+		}
+	}
+
+	// Stackable units first so non-stackable ones end up on top; larger and heavier units lower down
+	sort.SliceStable(units, func(i, j int) bool {
+		a, b := units[i].item, units[j].item
+		if a.Stackable != b.Stackable {
+			return a.Stackable
+		}
+		if va, vb := volume(a.Length, a.Width, a.Height), volume(b.Length, b.Width, b.Height); va != vb {
+			return va > vb
+		}
+		return a.Weight > b.Weight
+	})
+
+	p := &packer{space: space, corners: []corner{{}}}
+	plan := &Plan{}
+	for _, u := range units {
+		if reason := p.place(u); reason != "" {
+			plan.Leftovers = append(plan.Leftovers, Leftover{ItemID: u.item.ID, Unit: u.n, Reason: reason})
+		}
+	}
+
+	plan.Placements = p.placed
+	p.summarize(plan)
+	return plan, nil
+}
+
+// place loads a unit at the first feasible extreme point, or returns why it cannot be loaded
+func (p *packer) place(u unit) string {
+	it := u.item
+	orientations := [][2]int{{it.Length, it.Width}}
+	if !it.OrientationLocked && it.Length != it.Width {
+		orientations = append(orientations, [2]int{it.Width, it.Length})
+	}
+
+	fits := false
+	for _, o := range orientations {
+		if o[0] <= p.space.Length && o[1] <= p.space.Width && it.Height <= p.space.Height {
+			fits = true
+		}
+	}
+	if !fits {
+		return ReasonTooLarge
+	}
+	if p.space.MaxWeight > 0 && p.weight+it.Weight > p.space.MaxWeight+1e-9 {
+		return ReasonOverweight
+	}
+
+	for i, pt := range p.corners {
+		for oi, o := range orientations {
+			candidate := Placement{
+				ItemID:  it.ID,
+				Unit:    u.n,
+				X:       pt.x,
+				Y:       pt.y,
+				Z:       pt.z,
+				Length:  o[0],
+				Width:   o[1],
+				Height:  it.Height,
+				Rotated: oi == 1,
+				Weight:  it.Weight,
+			}
+			if !p.feasible(candidate) {
+				continue
+			}
+			p.commit(i, candidate, it.Stackable)
+			return ""
+		}
+	}
+	return ReasonNoSpace
+}
+
+func (p *packer) feasible(c Placement) bool {
+	if c.X+c.Length > p.space.Length || c.Y+c.Width > p.space.Width || c.Z+c.Height > p.space.Height {
+		return false
+	}
+	for i := range p.placed {
+		if overlaps(&p.placed[i], &c) {
+			return false
+		}
+	}
+	return c.Z == 0 || p.support(c) >= minSupport
+}
+
+// support returns the share of the base resting on stackable units whose top is at the base height.
+// Any contact with a non-stackable unit makes the position unsupported.
+func (p *packer) support(c Placement) float64 {
+	area := 0
+	for i := range p.placed {
+		b := &p.placed[i]
+		if b.Z+b.Height != c.Z {
+			continue
+		}
+		dx := overlap(b.X, b.X+b.Length, c.X, c.X+c.Length)
+		dy := overlap(b.Y, b.Y+b.Width, c.Y, c.Y+c.Width)
+		if dx == 0 || dy == 0 {
+			continue
+		}
+		if !p.stackable[i] {
+			return 0
+		}
+		area += dx * dy
+	}
+	return float64(area) / float64(c.Length*c.Width)
+}
+
+func (p *packer) commit(at int, c Placement, stackable bool) {
+	p.placed = append(p.placed, c)
+	p.stackable = append(p.stackable, stackable)
+	p.weight += c.Weight
+	p.corners = append(p.corners[:at], p.corners[at+1:]...)
+
+	next := []corner{
+		{x: c.X + c.Length, y: c.Y, z: c.Z},
+		{x: c.X, y: c.Y + c.Width, z: c.Z},
+	}
+	if stackable {
+		next = append(next, corner{x: c.X, y: c.Y, z: c.Z + c.Height})
+	}
+	for _, n := range next {
+		if !p.known(n) {
+			p.corners = append(p.corners, n)
+		}
+	}
+
+	// Fill from the front wall first, then from the floor up, then from the left
+	sort.Slice(p.corners, func(i, j int) bool {
+		a, b := p.corners[i], p.corners[j]
+		if a.x != b.x {
+			return a.x < b.x
+		}
+		if a.z != b.z {
+			return a.z < b.z
+		}
+		return a.y < b.y
+	})
+}
+
+// known reports whether a corner is outside the space or already listed
+func (p *packer) known(n corner) bool {
+	if n.x >= p.space.Length || n.y >= p.space.Width || n.z >= p.space.Height {
+		return true
+	}
+	for _, c := range p.corners {
+		if c == n {
+			return true
+		}
+	}
+	return false
+}
+
+// summarize computes fill, center of gravity, axle loads and balance warnings
+func (p *packer) summarize(plan *Plan) {
+	s := p.space
+	placedVolume := 0.0
+	var moment Point
+	for _, pl := range p.placed {
+		placedVolume += volume(pl.Length, pl.Width, pl.Height)
+		plan.TotalWeight += pl.Weight
+		moment.X += pl.Weight * (float64(pl.X) + float64(pl.Length)/2)
+		moment.Y += pl.Weight * (float64(pl.Y) + float64(pl.Width)/2)
+		moment.Z += pl.Weight * (float64(pl.Z) + float64(pl.Height)/2)
+		if end := pl.X + pl.Length; end > plan.UsedLength {
+			plan.UsedLength = end
+		}
+	}
+
+	plan.VolumeFill = round2(placedVolume / volume(s.Length, s.Width, s.Height) * 100)
+	if s.MaxWeight > 0 {
+		plan.WeightFill = round2(plan.TotalWeight / s.MaxWeight * 100)
+	}
+	if plan.TotalWeight <= 0 {
+		return
+	}
+
+	cog := Point{X: moment.X / plan.TotalWeight, Y: moment.Y / plan.TotalWeight, Z: moment.Z / plan.TotalWeight}
+	plan.CenterOfGravity = Point{X: round2(cog.X), Y: round2(cog.Y), Z: round2(cog.Z)}
+	plan.LateralOffset = round2(cog.Y - float64(s.Width)/2)
+	if math.Abs(plan.LateralOffset) > maxLateralOffset*float64(s.Width) {
+		plan.Warnings = append(plan.Warnings, WarningLateralImbalance)
+	}
+
+	// Treat the cargo as a beam resting on both axles: moments about the front axle give the rear share
+	if s.FrontAxle != nil && s.RearAxle != nil && *s.RearAxle != *s.FrontAxle {
+		front, rear := float64(*s.FrontAxle), float64(*s.RearAxle)
+		rearLoad := plan.TotalWeight * (cog.X - front) / (rear - front)
+		plan.Axles = &Axles{Front: round2(plan.TotalWeight - rearLoad), Rear: round2(rearLoad)}
+		switch {
+		case plan.Axles.Front < 0:
+			plan.Warnings = append(plan.Warnings, WarningFrontAxleUnload)
+		case plan.Axles.Rear < 0:
+			plan.Warnings = append(plan.Warnings, WarningRearAxleUnload)
+		}
+	}
+}
+
+func overlaps(a, b *Placement) bool {
+	return overlap(a.X, a.X+a.Length, b.X, b.X+b.Length) > 0 &&
+		overlap(a.Y, a.Y+a.Width, b.Y, b.Y+b.Width) > 0 &&
+		overlap(a.Z, a.Z+a.Height, b.Z, b.Z+b.Height) > 0
+}
+
+// overlap returns the length shared by the intervals [a0, a1) and [b0, b1)
+func overlap(a0, a1, b0, b1 int) int {
+	lo, hi := max(a0, b0), min(a1, b1)
+	if hi <= lo {
+		return 0
+	}
+	return hi - lo
+}
+
+func volume(l, w, h int) float64 {
+	return float64(l) * float64(w) * float64(h)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
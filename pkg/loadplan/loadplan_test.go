@@ -0,0 +1,139 @@
+package loadplan
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func checkPlacements(t *testing.T, space Space, placements []Placement) {
+	t.Helper()
+	for i := range placements {
+		a := &placements[i]
+		if a.X < 0 || a.Y < 0 || a.Z < 0 || a.X+a.Length > space.Length || a.Y+a.Width > space.Width || a.Z+a.Height > space.Height {
+			t.Errorf("%s #%d at (%d, %d, %d) is outside the cargo space", a.ItemID, a.Unit, a.X, a.Y, a.Z)
+		}
+		for j := i + 1; j < len(placements); j++ {
+			if overlaps(a, &placements[j]) {
+				t.Errorf("%s #%d overlaps %s #%d", a.ItemID, a.Unit, placements[j].ItemID, placements[j].Unit)
+			}
+		}
+	}
+}
+
+func TestPackPalletsOnTheFloor(t *testing.T) {
+	// a 6 m box body takes 15 euro pallets on the floor, five rows of three across
+	space := Space{Length: 6000, Width: 2400, Height: 2400, MaxWeight: 10000}
+	plan, err := Pack(space, []Item{{ID: "EUR", Quantity: 15, Length: 1200, Width: 800, Height: 1500, Weight: 400}})
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(plan.Placements) != 15 || len(plan.Leftovers) != 0 {
+		t.Fatalf("%d placed, %d left over; want all 15 placed", len(plan.Placements), len(plan.Leftovers))
+	}
+	checkPlacements(t, space, plan.Placements)
+	for _, p := range plan.Placements {
+		if p.Z != 0 {
+			t.Errorf("unit %d stacked at %d mm on a non-stackable pallet", p.Unit, p.Z)
+		}
+	}
+	if plan.UsedLength != 6000 || plan.TotalWeight != 6000 || plan.WeightFill != 60 || plan.VolumeFill != 62.5 {
+		t.Errorf("used %d mm, %v kg, %v%% weight, %v%% volume; want 6000, 6000, 60, 62.5",
+			plan.UsedLength, plan.TotalWeight, plan.WeightFill, plan.VolumeFill)
+	}
+}
+
+func TestPackStacking(t *testing.T) {
+	space := Space{Length: 1000, Width: 1000, Height: 2000}
+	cube := Item{ID: "CUBE", Quantity: 2, Length: 1000, Width: 1000, Height: 1000, Weight: 100}
+
+	cube.Stackable = true
+	plan, err := Pack(space, []Item{cube})
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(plan.Placements) != 2 || plan.Placements[1].Z != 1000 {
+		t.Fatalf("placements = %+v, want the second cube on top of the first", plan.Placements)
+	}
+
+	cube.Stackable = false
+	plan, err = Pack(space, []Item{cube})
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(plan.Leftovers) != 1 || plan.Leftovers[0].Reason != ReasonNoSpace {
+		t.Errorf("leftovers = %+v, want one cube left over for lack of space", plan.Leftovers)
+	}
+
+	// stackable units go first, so a non-stackable unit ends up on top rather than underneath
+	plan, err = Pack(space, []Item{
+		{ID: "TOP", Quantity: 1, Length: 1000, Width: 1000, Height: 1000, Weight: 50},
+		{ID: "BASE", Quantity: 1, Length: 1000, Width: 1000, Height: 1000, Weight: 50, Stackable: true},
+	})
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(plan.Placements) != 2 || plan.Placements[0].ItemID != "BASE" || plan.Placements[1].Z != 1000 {
+		t.Errorf("placements = %+v, want TOP stacked on BASE", plan.Placements)
+	}
+}
+
+func TestPackRotation(t *testing.T) {
+	space := Space{Length: 1000, Width: 2000, Height: 1000}
+	item := Item{ID: "LONG", Quantity: 1, Length: 2000, Width: 1000, Height: 500}
+
+	plan, err := Pack(space, []Item{item})
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(plan.Placements) != 1 || !plan.Placements[0].Rotated || plan.Placements[0].Length != 1000 {
+		t.Errorf("placements = %+v, want the unit turned across the width", plan.Placements)
+	}
+
+	item.OrientationLocked = true
+	plan, err = Pack(space, []Item{item})
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(plan.Leftovers) != 1 || plan.Leftovers[0].Reason != ReasonTooLarge {
+		t.Errorf("leftovers = %+v, want the locked unit too large", plan.Leftovers)
+	}
+}
+
+func TestPackPayload(t *testing.T) {
+	space := Space{Length: 6000, Width: 2400, Height: 2400, MaxWeight: 1000}
+	plan, err := Pack(space, []Item{{ID: "DRUM", Quantity: 3, Length: 600, Width: 600, Height: 900, Weight: 400}})
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+	if len(plan.Placements) != 2 || len(plan.Leftovers) != 1 || plan.Leftovers[0].Reason != ReasonOverweight {
+		t.Errorf("%d placed, leftovers %+v; want the third drum overweight", len(plan.Placements), plan.Leftovers)
+	}
+}
+
+func TestPackBalance(t *testing.T) {
+	front, rear := -1000, 4000
+	space := Space{Length: 6000, Width: 2400, Height: 2400, FrontAxle: &front, RearAxle: &rear}
+	plan, err := Pack(space, []Item{{ID: "CRATE", Quantity: 1, Length: 1000, Width: 1000, Height: 1000, Weight: 1000}})
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+
+	// the crate sits against the front-left corner: its center is 1500 mm behind the front axle of a 5 m wheelbase
+	if plan.Axles == nil || plan.Axles.Front != 700 || plan.Axles.Rear != 300 {
+		t.Errorf("axles = %+v, want 700 kg front and 300 kg rear", plan.Axles)
+	}
+	if plan.LateralOffset != -700 || !slices.Contains(plan.Warnings, WarningLateralImbalance) {
+		t.Errorf("lateral offset %v mm, warnings %v; want -700 and a lateral imbalance", plan.LateralOffset, plan.Warnings)
+	}
+}
+
+func TestPackInvalidInput(t *testing.T) {
+	if _, err := Pack(Space{Length: 1000, Width: 1000}, nil); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("flat space: err = %v, want ErrInvalidInput", err)
+	}
+	space := Space{Length: 1000, Width: 1000, Height: 1000}
+	if _, err := Pack(space, []Item{{ID: "BAD", Quantity: 1, Length: 100, Width: 100, Height: 0}}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("flat item: err = %v, want ErrInvalidInput", err)
+	}
+}
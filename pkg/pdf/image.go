@@ -0,0 +1,69 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png" // register PNG decoding for NewImage
+)
+
+// jpegQuality is used when re-encoding non-JPEG images
+const jpegQuality = 85
+
+// Image is an image embedded in a document
+type Image struct {
+	id     int
+	width  int
+	height int
+	data   []byte
+}
+
+// Width returns the image width in pixels
+func (i *Image) Width() int { return i.width }
+
+// Height returns the image height in pixels
+func (i *Image) Height() int { return i.height }
+
+// Fit returns the size of the image scaled to fit a w×h box without distortion
+func (i *Image) Fit(w, h float64) (float64, float64) {
+	if i.width == 0 || i.height == 0 {
+		return 0, 0
+	}
+	scale := w / float64(i.width)
+	if s := h / float64(i.height); s < scale {
+		scale = s
+	}
+	return float64(i.width) * scale, float64(i.height) * scale
+}
+
+// NewImage decodes a JPEG or PNG file for embedding. Baseline RGB JPEGs are embedded as-is;
+// anything else is flattened onto white and re-encoded as JPEG.
+func NewImage(data []byte) (*Image, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode image config: %w", err)
+	}
+	if format == "jpeg" && cfg.ColorModel == color.YCbCrModel {
+		return &Image{width: cfg.Width, height: cfg.Height, data: data}, nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+
+	// Transparent areas (e.g. around a signature) become white
+	bounds := src.Bounds()
+	flat := image.NewRGBA(bounds)
+	draw.Draw(flat, bounds, image.White, image.Point{}, draw.Src)
+	draw.Draw(flat, bounds, src, bounds.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("encode jpeg: %w", err)
+	}
+	return &Image{width: bounds.Dx(), height: bounds.Dy(), data: buf.Bytes()}, nil
+}
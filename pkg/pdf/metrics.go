@@ -0,0 +1,122 @@
+package pdf
+
+import "unicode"
+
+// Advance widths of printable ASCII (32-126) in 1/1000 em, from the Adobe core font metrics.
+// Latin-1 characters above 126 use defaultWidth, which is close enough for layout.
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+const defaultWidth = 556
+
+// TextWidth returns the width in points of s drawn in font at size
+func (d *Document) TextWidth(font Font, size float64, s string) float64 {
+	total := 0
+	if f := d.embedded(font); f != nil {
+		for _, r := range s {
+			total += f.font.advance(f.font.glyph(r))
+		}
+		return float64(total) * size / 1000
+	}
+
+	widths := &helveticaWidths
+	if font == HelveticaBold {
+		widths = &helveticaBoldWidths
+	}
+	b := encode(s)
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		if c >= 32 && c <= 126 {
+			total += widths[c-32]
+		} else {
+			total += defaultWidth
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// Wrap splits s into lines no wider than width, breaking at spaces where possible.
+// A word wider than the line, e.g. a run of Thai written without spaces, is broken
+// between characters, keeping vowel and tone marks with their consonant.
+func (d *Document) Wrap(font Font, size, width float64, s string) []string {
+	var lines []string
+	var line string
+	for _, word := range splitWords(s) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if d.TextWidth(font, size, candidate) <= width {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		line = ""
+		for _, cluster := range splitClusters(word) {
+			if line != "" && d.TextWidth(font, size, line+cluster) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			line += cluster
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func splitWords(s string) []string {
+	var words []string
+	start := -1
+	for i, r := range s {
+		if r == ' ' || r == '\n' || r == '\t' {
+			if start >= 0 {
+				words = append(words, s[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, s[start:])
+	}
+	return words
+}
+
+// splitClusters splits s into characters with their combining marks attached
+func splitClusters(s string) []string {
+	var clusters []string
+	start := 0
+	for i, r := range s {
+		if i > start && !unicode.Is(unicode.Mn, r) {
+			clusters = append(clusters, s[start:i])
+			start = i
+		}
+	}
+	if start < len(s) {
+		clusters = append(clusters, s[start:])
+	}
+	return clusters
+}
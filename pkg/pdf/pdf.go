@@ -0,0 +1,408 @@
+// Package pdf writes simple PDF 1.4 documents: text, lines, rectangles and JPEG images.
+// Coordinates are in points (1/72 inch) measured from the top-left corner of the page,
+// with y growing downwards.
+//
+// Text in the standard Helvetica fonts is encoded as WinAnsi, so characters outside Latin-1
+// are replaced with '?'. Embedded TrueType fonts cover whatever the font file does, e.g. Thai.
+// There is no OpenType shaping: Thai vowels and tone marks are drawn with the font's own
+// zero-width mark glyphs over the preceding consonant.
+//
+// Output is deterministic: the same drawing calls always produce the same bytes, so rendered
+// documents can be compared with golden files. Page content streams are left uncompressed.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// A4 page size in points
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Font selects one of the standard fonts every PDF reader provides or a font added with AddFont
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+var fontNames = [...]string{Helvetica: "Helvetica", HelveticaBold: "Helvetica-Bold"}
+
+// Document is a PDF under construction
+type Document struct {
+	pages  []*Page
+	images []*Image
+	fonts  []*embeddedFont
+	title  string
+}
+
+// embeddedFont is a TrueType font added to one document, with the glyphs the document uses
+type embeddedFont struct {
+	font *TrueTypeFont
+	used map[uint16]rune // glyph to the character it was drawn for, for text extraction
+}
+
+// Page is one page of a document
+type Page struct {
+	doc     *Document
+	width   float64
+	height  float64
+	content bytes.Buffer
+	images  map[*Image]bool
+}
+
+// New creates an empty document
+func New() *Document {
+	return &Document{}
+}
+
+// SetTitle sets the title shown by PDF readers
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+// AddFont embeds a TrueType font and returns the Font to draw text with
+func (d *Document) AddFont(f *TrueTypeFont) Font {
+	d.fonts = append(d.fonts, &embeddedFont{font: f, used: make(map[uint16]rune)})
+	return Font(len(fontNames) + len(d.fonts) - 1)
+}
+
+// embedded returns the embedded font behind a Font, or nil for a standard font
+func (d *Document) embedded(font Font) *embeddedFont {
+	i := int(font) - len(fontNames)
+	if i < 0 || i >= len(d.fonts) {
+		return nil
+	}
+	return d.fonts[i]
+}
+
+// AddPage appends a page of the given size in points
+func (d *Document) AddPage(width, height float64) *Page {
+	p := &Page{doc: d, width: width, height: height, images: make(map[*Image]bool)}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Width returns the page width in points
+func (p *Page) Width() float64 { return p.width }
+
+// Height returns the page height in points
+func (p *Page) Height() float64 { return p.height }
+
+// Text draws a single line of text with its baseline at y
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	var operand string
+	if f := p.doc.embedded(font); f != nil {
+		operand = f.encode(s)
+	} else {
+		operand = "(" + escape(encode(s)) + ")"
+	}
+	fmt.Fprintf(&p.content, "BT /F%d %s Tf %s %s Td %s Tj ET\n",
+		font+1, num(size), num(x), num(p.height-y), operand)
+}
+
+// TextRight draws a line of text ending at x
+func (p *Page) TextRight(x, y float64, font Font, size float64, s string) {
+	p.Text(x-p.doc.TextWidth(font, size, s), y, font, size, s)
+}
+
+// TextCenter draws a line of text centered on x
+func (p *Page) TextCenter(x, y float64, font Font, size float64, s string) {
+	p.Text(x-p.doc.TextWidth(font, size, s)/2, y, font, size, s)
+}
+
+// Line draws a straight line
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		num(width), num(x1), num(p.height-y1), num(x2), num(p.height-y2))
+}
+
+// Rect draws the outline of a rectangle whose top-left corner is at (x, y)
+func (p *Page) Rect(x, y, w, h, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n",
+		num(width), num(x), num(p.height-y-h), num(w), num(h))
+}
+
+// FillRect fills a rectangle with a gray level between 0 (black) and 1 (white)
+func (p *Page) FillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(&p.content, "q %s g %s %s %s %s re f Q\n",
+		num(gray), num(x), num(p.height-y-h), num(w), num(h))
+}
+
+// Image draws an image scaled into the w×h box whose top-left corner is at (x, y)
+func (p *Page) Image(img *Image, x, y, w, h float64) {
+	if !p.images[img] {
+		p.images[img] = true
+		if img.id == 0 {
+			p.doc.images = append(p.doc.images, img)
+			img.id = len(p.doc.images)
+		}
+	}
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /Im%d Do Q\n",
+		num(w), num(h), num(x), num(p.height-y-h), img.id)
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteTo renders the document to w
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage(A4Width, A4Height)
+	}
+
+	// Object numbers: 1 catalog, 2 page tree, 3 info, then the standard fonts, five objects
+	// per embedded font, images, and page/content pairs
+	const fontBase = 4
+	fontIDs := make([]int, len(fontNames)+len(d.fonts))
+	next := fontBase
+	for i := range fontIDs {
+		fontIDs[i] = next
+		if i < len(fontNames) {
+			next++
+		} else {
+			next += 5
+		}
+	}
+	imageBase := next
+	pageBase := imageBase + len(d.images)
+
+	ow := &objectWriter{w: w}
+	ow.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	ow.object(1, "<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pageBase+2*i)
+	}
+	ow.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	ow.object(3, fmt.Sprintf("<< /Title %s /Producer (tms-core-service) >>", textString(d.title)))
+
+	for i, name := range fontNames {
+		ow.object(fontIDs[i], fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+	}
+	for i, f := range d.fonts {
+		f.write(ow, fontIDs[len(fontNames)+i])
+	}
+
+	for i, img := range d.images {
+		ow.stream(imageBase+i, fmt.Sprintf(
+			"/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode",
+			img.width, img.height), img.data)
+	}
+
+	var fonts strings.Builder
+	for i, id := range fontIDs {
+		fmt.Fprintf(&fonts, "/F%d %d 0 R ", i+1, id)
+	}
+	for i, p := range d.pages {
+		var xobjects strings.Builder
+		for _, img := range d.images {
+			if p.images[img] {
+				fmt.Fprintf(&xobjects, "/Im%d %d 0 R ", img.id, imageBase+img.id-1)
+			}
+		}
+		ow.object(pageBase+2*i, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Contents %d 0 R /Resources << /Font << %s>> /XObject << %s>> >> >>",
+			num(p.width), num(p.height), pageBase+2*i+1, fonts.String(), xobjects.String()))
+		ow.stream(pageBase+2*i+1, "", p.content.Bytes())
+	}
+
+	count := pageBase + 2*len(d.pages)
+	xref := ow.n
+	ow.printf("xref\n0 %d\n0000000000 65535 f \n", count)
+	for i := 1; i < count; i++ {
+		ow.printf("%010d 00000 n \n", ow.offsets[i])
+	}
+	ow.printf("trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", count, xref)
+
+	return ow.n, ow.err
+}
+
+// encode converts text to a hex string of glyph IDs for the Identity-H encoding and records the glyphs used
+func (f *embeddedFont) encode(s string) string {
+	var b strings.Builder
+	b.WriteByte('<')
+	for _, r := range s {
+		if r == '\t' || r == '\n' {
+			r = ' '
+		}
+		g := f.font.glyph(r)
+		if _, ok := f.used[g]; !ok {
+			f.used[g] = r
+		}
+		fmt.Fprintf(&b, "%04X", g)
+	}
+	b.WriteByte('>')
+	return b.String()
+}
+
+// write emits the Type0 font, its CID font, descriptor, font file and ToUnicode map as objects id to id+4
+func (f *embeddedFont) write(ow *objectWriter, id int) {
+	tt := f.font
+	glyphs := make([]int, 0, len(f.used))
+	for g := range f.used {
+		glyphs = append(glyphs, int(g))
+	}
+	sort.Ints(glyphs)
+
+	var widths strings.Builder
+	for _, g := range glyphs {
+		fmt.Fprintf(&widths, "%d [%d] ", g, tt.advance(uint16(g)))
+	}
+
+	flags := 32 // nonsymbolic
+	if tt.italic != 0 {
+		flags |= 64
+	}
+
+	ow.object(id, fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		tt.name, id+1, id+4))
+	ow.object(id+1, fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> "+
+		"/FontDescriptor %d 0 R /CIDToGIDMap /Identity /DW %d /W [%s] >>",
+		tt.name, id+2, tt.advance(0), widths.String()))
+	ow.object(id+2, fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags %d /FontBBox [%d %d %d %d] /ItalicAngle %s "+
+		"/Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		tt.name, flags, tt.bbox[0], tt.bbox[1], tt.bbox[2], tt.bbox[3], num(tt.italic), tt.ascent, tt.descent, tt.capHeight, id+3))
+	ow.stream(id+3, fmt.Sprintf("/Length1 %d /Filter /FlateDecode", tt.size), tt.packed)
+	ow.stream(id+4, "", f.toUnicode(glyphs))
+}
+
+// toUnicode builds the CMap PDF readers use to copy and search text drawn with the font
+func (f *embeddedFont) toUnicode(glyphs []int) []byte {
+	var b bytes.Buffer
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	// A bfchar section holds at most 100 mappings
+	for start := 0; start < len(glyphs); start += 100 {
+		end := min(start+100, len(glyphs))
+		fmt.Fprintf(&b, "%d beginbfchar\n", end-start)
+		for _, g := range glyphs[start:end] {
+			fmt.Fprintf(&b, "<%04X> <", g)
+			for _, u := range utf16.Encode([]rune{f.used[uint16(g)]}) {
+				fmt.Fprintf(&b, "%04X", u)
+			}
+			b.WriteString(">\n")
+		}
+		b.WriteString("endbfchar\n")
+	}
+	b.WriteString("endcmap\nCMapName currentdict /CIDResource defineresource pop\nend\nend")
+	return b.Bytes()
+}
+
+// objectWriter tracks byte offsets of numbered objects for the cross-reference table
+type objectWriter struct {
+	w       io.Writer
+	n       int64
+	err     error
+	offsets map[int]int64
+}
+
+func (o *objectWriter) printf(format string, args ...any) {
+	if o.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(o.w, format, args...)
+	o.n += int64(n)
+	o.err = err
+}
+
+func (o *objectWriter) write(b []byte) {
+	if o.err != nil {
+		return
+	}
+	n, err := o.w.Write(b)
+	o.n += int64(n)
+	o.err = err
+}
+
+func (o *objectWriter) begin(id int) {
+	if o.offsets == nil {
+		o.offsets = make(map[int]int64)
+	}
+	o.offsets[id] = o.n
+	o.printf("%d 0 obj\n", id)
+}
+
+func (o *objectWriter) object(id int, body string) {
+	o.begin(id)
+	o.printf("%s\nendobj\n", body)
+}
+
+func (o *objectWriter) stream(id int, dict string, data []byte) {
+	o.begin(id)
+	o.printf("<< %s /Length %d >>\nstream\n", dict, len(data))
+	o.write(data)
+	o.printf("\nendstream\nendobj\n")
+}
+
+// num formats a coordinate compactly
+func num(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
+
+// encode converts text to WinAnsi bytes; runes outside Latin-1 become '?'
+func encode(s string) string {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			b = append(b, ' ')
+		case r < 0x20 || (r >= 0x7f && r < 0xa0) || r > 0xff:
+			b = append(b, '?')
+		default:
+			b = append(b, byte(r))
+		}
+	}
+	return string(b)
+}
+
+// textString encodes a string for the document information dictionary: PDFDocEncoding when it
+// is Latin-1, otherwise UTF-16 with a byte order mark so titles in Thai survive
+func textString(s string) string {
+	latin := true
+	for _, r := range s {
+		if r > 0xff {
+			latin = false
+			break
+		}
+	}
+	if latin {
+		return "(" + escape(encode(s)) + ")"
+	}
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	b.WriteByte('>')
+	return b.String()
+}
+
+// escape escapes a PDF literal string
+func escape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`)
+	return r.Replace(s)
+}
@@ -0,0 +1,305 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// TrueTypeFont is a parsed TrueType font ready to embed. It holds what a PDF needs: metrics,
+// the Unicode to glyph mapping and the compressed font file. It is read-only after parsing,
+// so one font may be shared by documents rendered concurrently.
+type TrueTypeFont struct {
+	packed    []byte // zlib-compressed font file
+	size      int    // uncompressed font file size
+	name      string // PostScript name
+	unitsEm   int
+	bbox      [4]int
+	ascent    int
+	descent   int
+	capHeight int
+	italic    float64
+	advances  []int // advance width per glyph in font units; the last repeats for the remaining glyphs
+	cmap      func(r rune) uint16
+}
+
+// ParseTrueType reads a TrueType (glyf-outline) font file, e.g. Sarabun or Noto Sans Thai.
+// OpenType fonts with CFF outlines and font collections are not supported.
+func ParseTrueType(data []byte) (*TrueTypeFont, error) {
+	if len(data) < 12 {
+		return nil, errors.New("truetype: file too short")
+	}
+	switch binary.BigEndian.Uint32(data) {
+	case 0x00010000, 0x74727565: // 1.0, "true"
+	case 0x4f54544f: // "OTTO"
+		return nil, errors.New("truetype: CFF outlines are not supported")
+	default:
+		return nil, errors.New("truetype: not a TrueType font")
+	}
+
+	tables := make(map[string][]byte)
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := 0; i < numTables; i++ {
+		rec := 12 + 16*i
+		if rec+16 > len(data) {
+			return nil, errors.New("truetype: truncated table directory")
+		}
+		tag := string(data[rec : rec+4])
+		offset := int(binary.BigEndian.Uint32(data[rec+8:]))
+		length := int(binary.BigEndian.Uint32(data[rec+12:]))
+		if offset < 0 || length < 0 || offset+length > len(data) {
+			return nil, fmt.Errorf("truetype: table %s out of bounds", tag)
+		}
+		tables[tag] = data[offset : offset+length]
+	}
+	for _, tag := range []string{"head", "hhea", "hmtx", "cmap", "glyf"} {
+		if tables[tag] == nil {
+			return nil, fmt.Errorf("truetype: missing %s table", tag)
+		}
+	}
+
+	f := &TrueTypeFont{size: len(data)}
+
+	head := tables["head"]
+	if len(head) < 54 {
+		return nil, errors.New("truetype: short head table")
+	}
+	f.unitsEm = int(binary.BigEndian.Uint16(head[18:]))
+	if f.unitsEm == 0 {
+		return nil, errors.New("truetype: zero units per em")
+	}
+	for i := range f.bbox {
+		f.bbox[i] = f.scale(int(int16(binary.BigEndian.Uint16(head[36+2*i:]))))
+	}
+
+	hhea := tables["hhea"]
+	if len(hhea) < 36 {
+		return nil, errors.New("truetype: short hhea table")
+	}
+	f.ascent = f.scale(int(int16(binary.BigEndian.Uint16(hhea[4:]))))
+	f.descent = f.scale(int(int16(binary.BigEndian.Uint16(hhea[6:]))))
+	f.capHeight = f.ascent
+	numMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+
+	hmtx := tables["hmtx"]
+	if numMetrics == 0 || len(hmtx) < 4*numMetrics {
+		return nil, errors.New("truetype: short hmtx table")
+	}
+	f.advances = make([]int, numMetrics)
+	for i := range f.advances {
+		f.advances[i] = int(binary.BigEndian.Uint16(hmtx[4*i:]))
+	}
+
+	if os2 := tables["OS/2"]; len(os2) >= 90 && binary.BigEndian.Uint16(os2) >= 2 {
+		if capHeight := int(int16(binary.BigEndian.Uint16(os2[88:]))); capHeight > 0 {
+			f.capHeight = f.scale(capHeight)
+		}
+	}
+	if post := tables["post"]; len(post) >= 8 {
+		f.italic = float64(int32(binary.BigEndian.Uint32(post[4:]))) / 65536
+	}
+
+	var err error
+	if f.cmap, err = parseCmap(tables["cmap"]); err != nil {
+		return nil, err
+	}
+	f.name = postScriptName(tables["name"])
+
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, fmt.Errorf("truetype: compress: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("truetype: compress: %w", err)
+	}
+	f.packed = buf.Bytes()
+	return f, nil
+}
+
+// scale converts font units to the 1000-unit em PDF uses for glyph metrics
+func (f *TrueTypeFont) scale(v int) int {
+	return v * 1000 / f.unitsEm
+}
+
+// glyph returns the glyph for a rune; runes the font lacks map to glyph 0 (.notdef)
+func (f *TrueTypeFont) glyph(r rune) uint16 {
+	return f.cmap(r)
+}
+
+// advance returns a glyph's advance width in 1/1000 em
+func (f *TrueTypeFont) advance(g uint16) int {
+	if int(g) >= len(f.advances) {
+		return f.scale(f.advances[len(f.advances)-1])
+	}
+	return f.scale(f.advances[g])
+}
+
+// parseCmap picks the Unicode subtable of the cmap, preferring full-repertoire format 12 over BMP format 4
+func parseCmap(cmap []byte) (func(rune) uint16, error) {
+	if len(cmap) < 4 {
+		return nil, errors.New("truetype: short cmap table")
+	}
+	var format4, format12 []byte
+	n := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := 0; i < n; i++ {
+		rec := 4 + 8*i
+		if rec+8 > len(cmap) {
+			break
+		}
+		platform := binary.BigEndian.Uint16(cmap[rec:])
+		encoding := binary.BigEndian.Uint16(cmap[rec+2:])
+		offset := int(binary.BigEndian.Uint32(cmap[rec+4:]))
+		if offset+4 > len(cmap) {
+			continue
+		}
+		unicode := platform == 0 || (platform == 3 && (encoding == 1 || encoding == 10))
+		if !unicode {
+			continue
+		}
+		sub := cmap[offset:]
+		switch binary.BigEndian.Uint16(sub) {
+		case 4:
+			format4 = sub
+		case 12:
+			format12 = sub
+		}
+	}
+
+	switch {
+	case format12 != nil:
+		return cmapFormat12(format12)
+	case format4 != nil:
+		return cmapFormat4(format4)
+	}
+	return nil, errors.New("truetype: no Unicode cmap subtable")
+}
+
+func cmapFormat4(sub []byte) (func(rune) uint16, error) {
+	if len(sub) < 14 {
+		return nil, errors.New("truetype: short cmap format 4")
+	}
+	segCount := int(binary.BigEndian.Uint16(sub[6:])) / 2
+	endCodes := 14
+	startCodes := endCodes + 2*segCount + 2
+	idDeltas := startCodes + 2*segCount
+	idRangeOffsets := idDeltas + 2*segCount
+	if idRangeOffsets+2*segCount > len(sub) {
+		return nil, errors.New("truetype: truncated cmap format 4")
+	}
+	u16 := func(off int) uint16 {
+		if off+2 > len(sub) {
+			return 0
+		}
+		return binary.BigEndian.Uint16(sub[off:])
+	}
+
+	return func(r rune) uint16 {
+		if r > 0xffff {
+			return 0
+		}
+		c := uint16(r)
+		for i := 0; i < segCount; i++ {
+			if c > u16(endCodes+2*i) {
+				continue
+			}
+			start := u16(startCodes + 2*i)
+			if c < start {
+				return 0
+			}
+			delta := u16(idDeltas + 2*i)
+			rangeOffset := u16(idRangeOffsets + 2*i)
+			if rangeOffset == 0 {
+				return c + delta
+			}
+			g := u16(idRangeOffsets + 2*i + int(rangeOffset) + 2*int(c-start))
+			if g == 0 {
+				return 0
+			}
+			return g + delta
+		}
+		return 0
+	}, nil
+}
+
+func cmapFormat12(sub []byte) (func(rune) uint16, error) {
+	if len(sub) < 16 {
+		return nil, errors.New("truetype: short cmap format 12")
+	}
+	groups := int(binary.BigEndian.Uint32(sub[12:]))
+	if 16+12*groups > len(sub) {
+		return nil, errors.New("truetype: truncated cmap format 12")
+	}
+
+	return func(r rune) uint16 {
+		c := uint32(r)
+		// Groups are sorted by start code
+		lo, hi := 0, groups
+		for lo < hi {
+			mid := (lo + hi) / 2
+			g := sub[16+12*mid:]
+			start, end := binary.BigEndian.Uint32(g), binary.BigEndian.Uint32(g[4:])
+			switch {
+			case c < start:
+				hi = mid
+			case c > end:
+				lo = mid + 1
+			default:
+				return uint16(binary.BigEndian.Uint32(g[8:]) + c - start)
+			}
+		}
+		return 0
+	}, nil
+}
+
+// postScriptName reads name ID 6, falling back to a generic name. PDF names may not contain
+// spaces or delimiters, so anything outside printable ASCII letters, digits and '-' is dropped.
+func postScriptName(name []byte) string {
+	const fallback = "EmbeddedFont"
+	if len(name) < 6 {
+		return fallback
+	}
+	count := int(binary.BigEndian.Uint16(name[2:]))
+	storage := int(binary.BigEndian.Uint16(name[4:]))
+
+	var raw string
+	for i := 0; i < count; i++ {
+		rec := 6 + 12*i
+		if rec+12 > len(name) {
+			break
+		}
+		platform := binary.BigEndian.Uint16(name[rec:])
+		nameID := binary.BigEndian.Uint16(name[rec+6:])
+		length := int(binary.BigEndian.Uint16(name[rec+8:]))
+		offset := storage + int(binary.BigEndian.Uint16(name[rec+10:]))
+		if nameID != 6 || offset+length > len(name) {
+			continue
+		}
+		s := name[offset : offset+length]
+		if platform == 1 {
+			raw = string(s)
+			break
+		}
+		units := make([]uint16, len(s)/2)
+		for j := range units {
+			units[j] = binary.BigEndian.Uint16(s[2*j:])
+		}
+		raw = string(utf16.Decode(units))
+		break
+	}
+
+	clean := strings.Map(func(r rune) rune {
+		if r == '-' || (r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') {
+			return r
+		}
+		return -1
+	}, raw)
+	if clean == "" {
+		return fallback
+	}
+	return clean
+}
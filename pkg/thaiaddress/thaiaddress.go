@@ -0,0 +1,388 @@
+// Package thaiaddress provides lookups over Thai administrative areas
+// (province → district → subdistrict → postcode) from an embedded CSV dataset.
+//
+// The bundled data/subdistricts.csv covers the provinces we currently serve.
+// To extend coverage, append rows in the same column order; each row is one
+// subdistrict with its postcode and approximate centroid. data/provinces.csv lists
+// all 77 provinces, so a province can be recognised even where its districts are not listed.
+package thaiaddress
+
+import (
+	"embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"tms-core-service/pkg/geo"
+)
+
+//go:embed data/subdistricts.csv data/provinces.csv
+var dataFS embed.FS
+
+// Errors returned when an address combination cannot be matched
+var (
+	ErrUnknownProvince    = errors.New("unknown province")
+	ErrUnknownDistrict    = errors.New("unknown district")
+	ErrUnknownSubdistrict = errors.New("unknown subdistrict")
+	ErrPostcodeMismatch   = errors.New("postcode does not match subdistrict")
+)
+
+// Area is a single subdistrict (tambon/khwaeng) row of the administrative dataset
+type Area struct {
+	SubdistrictTH string
+	SubdistrictEN string
+	DistrictTH    string
+	DistrictEN    string
+	ProvinceTH    string
+	ProvinceEN    string
+	Postcode      string
+	Latitude      float64
+	Longitude     float64
+}
+
+// Name is a Thai/English name pair
+type Name struct {
+	TH string
+	EN string
+}
+
+// Directory is an in-memory index over the administrative dataset
+type Directory struct {
+	areas      []Area
+	byPostcode map[string][]int
+	provinces  []Name
+}
+
+var (
+	defaultOnce sync.Once
+	defaultDir  *Directory
+)
+
+// Default returns the directory built from the embedded dataset.
+// The embedded file is part of the binary, so a parse failure is a programming error and panics.
+func Default() *Directory {
+	defaultOnce.Do(func() {
+		subdistricts, err := dataFS.Open("data/subdistricts.csv")
+		if err != nil {
+			panic(fmt.Sprintf("thaiaddress: open embedded dataset: %v", err))
+		}
+		defer subdistricts.Close()
+		provinces, err := dataFS.Open("data/provinces.csv")
+		if err != nil {
+			panic(fmt.Sprintf("thaiaddress: open embedded provinces: %v", err))
+		}
+		defer provinces.Close()
+
+		defaultDir, err = New(subdistricts, provinces)
+		if err != nil {
+			panic(fmt.Sprintf("thaiaddress: parse embedded dataset: %v", err))
+		}
+	})
+	return defaultDir
+}
+
+// New builds a directory from subdistrict CSV data with the header
+// province_th,province_en,district_th,district_en,subdistrict_th,subdistrict_en,postcode,lat,lng
+// and optional province CSV data with the header province_th,province_en.
+// Without province data, the provinces are those of the subdistricts.
+func New(subdistricts, provinces io.Reader) (*Directory, error) {
+	d, err := readSubdistricts(subdistricts)
+	if err != nil {
+		return nil, err
+	}
+	if provinces == nil {
+		d.provinces = d.areaProvinces()
+		return d, nil
+	}
+
+	reader := csv.NewReader(provinces)
+	reader.FieldsPerRecord = 2
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read provinces: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("read provinces: missing header")
+	}
+	for _, rec := range records[1:] {
+		d.provinces = append(d.provinces, Name{TH: rec[0], EN: rec[1]})
+	}
+	sortNames(d.provinces)
+	return d, nil
+}
+
+func readSubdistricts(r io.Reader) (*Directory, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 9
+
+	// Skip header
+	if _, err := reader.Read(); err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+
+	d := &Directory{byPostcode: make(map[string][]int)}
+	for {
+		rec, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read record: %w", err)
+		}
+
+		lat, err := strconv.ParseFloat(rec[7], 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse latitude %q: %w", rec[7], err)
+		}
+		lng, err := strconv.ParseFloat(rec[8], 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse longitude %q: %w", rec[8], err)
+		}
+
+		d.areas = append(d.areas, Area{
+			ProvinceTH:    rec[0],
+			ProvinceEN:    rec[1],
+			DistrictTH:    rec[2],
+			DistrictEN:    rec[3],
+			SubdistrictTH: rec[4],
+			SubdistrictEN: rec[5],
+			Postcode:      rec[6],
+			Latitude:      lat,
+			Longitude:     lng,
+		})
+		d.byPostcode[rec[6]] = append(d.byPostcode[rec[6]], len(d.areas)-1)
+	}
+
+	return d, nil
+}
+
+// Match validates a subdistrict/district/province/postcode combination.
+// Names may be given in Thai or English, with or without administrative prefixes.
+func (d *Directory) Match(subdistrict, district, province, postcode string) (*Area, error) {
+	province = normalize(province)
+	district = normalize(district)
+	subdistrict = normalize(subdistrict)
+	postcode = strings.TrimSpace(postcode)
+
+	var provinceFound, districtFound bool
+	for i := range d.areas {
+		a := &d.areas[i]
+		if !nameMatches(province, a.ProvinceTH, a.ProvinceEN) {
+			continue
+		}
+		provinceFound = true
+		if !nameMatches(district, a.DistrictTH, a.DistrictEN) {
+			continue
+		}
+		districtFound = true
+		if !nameMatches(subdistrict, a.SubdistrictTH, a.SubdistrictEN) {
+			continue
+		}
+		if a.Postcode != postcode {
+			return nil, ErrPostcodeMismatch
+		}
+		area := *a
+		return &area, nil
+	}
+
+	switch {
+	case !provinceFound:
+		return nil, ErrUnknownProvince
+	case !districtFound:
+		return nil, ErrUnknownDistrict
+	default:
+		return nil, ErrUnknownSubdistrict
+	}
+}
+
+// Search returns areas whose names or postcode start with or contain the query.
+// Prefix matches are ranked before substring matches.
+func (d *Directory) Search(query string, limit int) []Area {
+	q := normalize(query)
+	if q == "" {
+		return nil
+	}
+
+	var prefix, contains []Area
+	for _, a := range d.areas {
+		fields := []string{
+			normalize(a.SubdistrictTH), normalize(a.SubdistrictEN),
+			normalize(a.DistrictTH), normalize(a.DistrictEN),
+			normalize(a.ProvinceTH), normalize(a.ProvinceEN),
+			a.Postcode,
+		}
+		matched := false
+		for _, f := range fields {
+			if strings.HasPrefix(f, q) {
+				prefix = append(prefix, a)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+		for _, f := range fields {
+			if strings.Contains(f, q) {
+				contains = append(contains, a)
+				break
+			}
+		}
+	}
+
+	results := append(prefix, contains...)
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// Provinces returns all provinces sorted by Thai name, including those whose districts are not listed
+func (d *Directory) Provinces() []Name {
+	return append([]Name(nil), d.provinces...)
+}
+
+// Province looks a province up by its Thai or English name, with or without prefix
+func (d *Directory) Province(name string) (Name, bool) {
+	name = normalize(name)
+	for _, p := range d.provinces {
+		if nameMatches(name, p.TH, p.EN) {
+			return p, true
+		}
+	}
+	return Name{}, false
+}
+
+// areaProvinces returns the provinces of the listed subdistricts sorted by Thai name
+func (d *Directory) areaProvinces() []Name {
+	seen := make(map[string]bool)
+	var names []Name
+	for _, a := range d.areas {
+		if seen[a.ProvinceTH] {
+			continue
+		}
+		seen[a.ProvinceTH] = true
+		names = append(names, Name{TH: a.ProvinceTH, EN: a.ProvinceEN})
+	}
+	sortNames(names)
+	return names
+}
+
+// Districts returns the districts of a province sorted by Thai name
+func (d *Directory) Districts(province string) []Name {
+	province = normalize(province)
+	seen := make(map[string]bool)
+	var names []Name
+	for _, a := range d.areas {
+		if !nameMatches(province, a.ProvinceTH, a.ProvinceEN) || seen[a.DistrictTH] {
+			continue
+		}
+		seen[a.DistrictTH] = true
+		names = append(names, Name{TH: a.DistrictTH, EN: a.DistrictEN})
+	}
+	sortNames(names)
+	return names
+}
+
+// Subdistricts returns the subdistricts of a district
+func (d *Directory) Subdistricts(province, district string) []Area {
+	province = normalize(province)
+	district = normalize(district)
+	var areas []Area
+	for _, a := range d.areas {
+		if nameMatches(province, a.ProvinceTH, a.ProvinceEN) && nameMatches(district, a.DistrictTH, a.DistrictEN) {
+			areas = append(areas, a)
+		}
+	}
+	return areas
+}
+
+// ByPostcode returns the areas served by a postcode
+func (d *Directory) ByPostcode(postcode string) []Area {
+	idx := d.byPostcode[strings.TrimSpace(postcode)]
+	areas := make([]Area, len(idx))
+	for i, j := range idx {
+		areas[i] = d.areas[j]
+	}
+	return areas
+}
+
+// PostcodeCentroid returns the mean position of the subdistricts served by a postcode
+func (d *Directory) PostcodeCentroid(postcode string) (lat, lng float64, ok bool) {
+	idx := d.byPostcode[strings.TrimSpace(postcode)]
+	if len(idx) == 0 {
+		return 0, 0, false
+	}
+	for _, j := range idx {
+		lat += d.areas[j].Latitude
+		lng += d.areas[j].Longitude
+	}
+	n := float64(len(idx))
+	return lat / n, lng / n, true
+}
+
+// Nearest returns the area whose centroid is closest to the given position
+func (d *Directory) Nearest(lat, lng float64) (*Area, bool) {
+	if len(d.areas) == 0 {
+		return nil, false
+	}
+
+	target := geo.Point{Lat: lat, Lng: lng}
+	best, bestDist := 0, -1.0
+	for i, a := range d.areas {
+		dist := geo.Haversine(target, geo.Point{Lat: a.Latitude, Lng: a.Longitude})
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	area := d.areas[best]
+	return &area, true
+}
+
+// IsBangkok reports whether a province name refers to Bangkok, which uses khwaeng/khet instead of tambon/amphoe
+func IsBangkok(province string) bool {
+	return nameMatches(normalize(province), "กรุงเทพมหานคร", "Bangkok")
+}
+
+// prefixes are administrative prefixes stripped before comparing names (longest first)
+var prefixes = []string{
+	"จังหวัด", "อำเภอ", "ตำบล", "แขวง", "เขต", "จ.", "อ.", "ต.",
+	"changwat ", "amphoe ", "tambon ", "khet ", "khwaeng ",
+}
+
+// aliases maps common informal names to their canonical normalized form
+var aliases = map[string]string{
+	"กทม":        "กรุงเทพมหานคร",
+	"กทม.":       "กรุงเทพมหานคร",
+	"กรุงเทพ":    "กรุงเทพมหานคร",
+	"กรุงเทพฯ":   "กรุงเทพมหานคร",
+	"bkk":        "bangkok",
+	"krung thep": "bangkok",
+}
+
+func normalize(s string) string {
+	s = strings.ToLower(strings.Join(strings.Fields(s), " "))
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			s = strings.TrimSpace(strings.TrimPrefix(s, p))
+			break
+		}
+	}
+	if canonical, ok := aliases[s]; ok {
+		return canonical
+	}
+	return s
+}
+
+func nameMatches(normalized, th, en string) bool {
+	return normalized == th || normalized == strings.ToLower(en)
+}
+
+func sortNames(names []Name) {
+	sort.Slice(names, func(i, j int) bool { return names[i].TH < names[j].TH })
+}
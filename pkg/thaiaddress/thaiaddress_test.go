@@ -0,0 +1,128 @@
+package thaiaddress
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+const testSubdistricts = `province_th,province_en,district_th,district_en,subdistrict_th,subdistrict_en,postcode,lat,lng
+กรุงเทพมหานคร,Bangkok,พระนคร,Phra Nakhon,พระบรมมหาราชวัง,Phra Borom Maha Ratchawang,10200,13.7510,100.4927
+กรุงเทพมหานคร,Bangkok,พระนคร,Phra Nakhon,วัดราชบพิธ,Wat Ratchabophit,10200,13.7490,100.4970
+กรุงเทพมหานคร,Bangkok,ปทุมวัน,Pathum Wan,ลุมพินี,Lumphini,10330,13.7300,100.5430
+เชียงใหม่,Chiang Mai,เมืองเชียงใหม่,Mueang Chiang Mai,ศรีภูมิ,Si Phum,50200,18.7950,98.9900
+เชียงใหม่,Chiang Mai,เมืองเชียงใหม่,Mueang Chiang Mai,สุเทพ,Suthep,50200,18.7900,98.9500
+`
+
+func testDirectory(t *testing.T) *Directory {
+	t.Helper()
+	d, err := New(strings.NewReader(testSubdistricts), nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return d
+}
+
+func TestMatch(t *testing.T) {
+	d := testDirectory(t)
+	for _, tc := range []struct {
+		subdistrict, district, province, postcode string
+		want                                      string
+		err                                       error
+	}{
+		{"ลุมพินี", "ปทุมวัน", "กรุงเทพมหานคร", "10330", "Lumphini", nil},
+		{"แขวงลุมพินี", "เขตปทุมวัน", "กทม.", " 10330 ", "Lumphini", nil},
+		{"Khwaeng Lumphini", "pathum wan", "BKK", "10330", "Lumphini", nil},
+		{"ต.สุเทพ", "อ.เมืองเชียงใหม่", "จ.เชียงใหม่", "50200", "Suthep", nil},
+		{"ลุมพินี", "ปทุมวัน", "กรุงเทพมหานคร", "10200", "", ErrPostcodeMismatch},
+		{"ลุมพินี", "พระนคร", "กรุงเทพมหานคร", "10330", "", ErrUnknownSubdistrict},
+		{"ลุมพินี", "บางรัก", "กรุงเทพมหานคร", "10330", "", ErrUnknownDistrict},
+		{"ลุมพินี", "ปทุมวัน", "ภูเก็ต", "10330", "", ErrUnknownProvince},
+	} {
+		area, err := d.Match(tc.subdistrict, tc.district, tc.province, tc.postcode)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("Match(%s, %s, %s, %s): err = %v, want %v", tc.subdistrict, tc.district, tc.province, tc.postcode, err, tc.err)
+			continue
+		}
+		if err == nil && area.SubdistrictEN != tc.want {
+			t.Errorf("Match(%s, %s, %s): %s, want %s", tc.subdistrict, tc.district, tc.province, area.SubdistrictEN, tc.want)
+		}
+	}
+}
+
+func TestSearchRanksPrefixMatchesFirst(t *testing.T) {
+	d := testDirectory(t)
+
+	// "su" starts only Suthep
+	got := d.Search("su", 0)
+	if len(got) == 0 || got[0].SubdistrictEN != "Suthep" {
+		t.Fatalf("Search(su) = %v, want Suthep first", got)
+	}
+
+	got = d.Search("10200", 1)
+	if len(got) != 1 || got[0].Postcode != "10200" {
+		t.Errorf("Search(10200, 1) = %v, want one area in 10200", got)
+	}
+	if got := d.Search("  ", 0); got != nil {
+		t.Errorf("Search(blank) = %v, want nothing", got)
+	}
+}
+
+func TestDistrictsAndSubdistricts(t *testing.T) {
+	d := testDirectory(t)
+
+	districts := d.Districts("Bangkok")
+	if len(districts) != 2 || districts[0].EN != "Pathum Wan" || districts[1].EN != "Phra Nakhon" {
+		t.Errorf("Districts(Bangkok) = %v, want ปทุมวัน then พระนคร", districts)
+	}
+	if got := d.Subdistricts("กรุงเทพฯ", "เขตพระนคร"); len(got) != 2 {
+		t.Errorf("Subdistricts(พระนคร) = %d areas, want 2", len(got))
+	}
+	if got := d.ByPostcode("50200"); len(got) != 2 {
+		t.Errorf("ByPostcode(50200) = %d areas, want 2", len(got))
+	}
+}
+
+func TestPostcodeCentroidAndNearest(t *testing.T) {
+	d := testDirectory(t)
+
+	lat, lng, ok := d.PostcodeCentroid("10200")
+	if !ok || math.Abs(lat-13.75) > 1e-9 || math.Abs(lng-100.49485) > 1e-9 {
+		t.Errorf("PostcodeCentroid(10200) = %v, %v, %v; want the mean of its subdistricts", lat, lng, ok)
+	}
+	if _, _, ok := d.PostcodeCentroid("99999"); ok {
+		t.Error("PostcodeCentroid of an unknown postcode is ok")
+	}
+
+	area, ok := d.Nearest(18.79, 98.96)
+	if !ok || area.SubdistrictEN != "Suthep" {
+		t.Errorf("Nearest = %v, want Suthep", area)
+	}
+}
+
+func TestNewRejectsMalformedRows(t *testing.T) {
+	for name, data := range map[string]string{
+		"short row":    "h1,h2,h3,h4,h5,h6,h7,h8,h9\nกรุงเทพมหานคร,Bangkok\n",
+		"bad latitude": "h1,h2,h3,h4,h5,h6,h7,h8,h9\na,b,c,d,e,f,10200,north,100.5\n",
+		"empty":        "",
+	} {
+		if _, err := New(strings.NewReader(data), nil); err == nil {
+			t.Errorf("%s: New succeeded", name)
+		}
+	}
+}
+
+func TestDefaultListsEveryProvince(t *testing.T) {
+	d := Default()
+	if got := len(d.Provinces()); got != 77 {
+		t.Errorf("%d provinces, want 77", got)
+	}
+	// Krabi has no subdistricts in the bundled data but is still a province
+	if p, ok := d.Province("จังหวัดกระบี่"); !ok || p.EN != "Krabi" {
+		t.Errorf("Province(กระบี่) = %v, %v; want Krabi", p, ok)
+	}
+	if !IsBangkok("กรุงเทพฯ") || IsBangkok("Nonthaburi") {
+		t.Error("IsBangkok does not recognise Bangkok")
+	}
+}
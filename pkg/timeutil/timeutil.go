@@ -0,0 +1,19 @@
+// Package timeutil works with dates on the Thai calendar, which business days, periods and document
+// dates in the service follow.
+package timeutil
+
+import "time"
+
+// Thailand is Indochina Time; Thailand has no daylight saving, so a fixed zone avoids depending on tzdata
+var Thailand = time.FixedZone("ICT", 7*60*60)
+
+// CalendarDay returns the Thai calendar day of t as a date at midnight UTC
+func CalendarDay(t time.Time) time.Time {
+	y, m, d := t.In(Thailand).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// StartOfDay returns the instant a calendar day, given as a date at midnight UTC, begins in Thailand
+func StartOfDay(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, Thailand)
+}
@@ -0,0 +1,34 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarDay(t *testing.T) {
+	for _, tc := range []struct {
+		at   time.Time
+		want time.Time
+	}{
+		// 16:59 UTC is still 23:59 the same day in Bangkok, 17:00 is already the next
+		{time.Date(2026, 10, 17, 16, 59, 0, 0, time.UTC), time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 10, 17, 17, 0, 0, 0, time.UTC), time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 12, 31, 20, 0, 0, 0, time.UTC), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 10, 18, 0, 30, 0, 0, Thailand), time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+	} {
+		if got := CalendarDay(tc.at); !got.Equal(tc.want) {
+			t.Errorf("CalendarDay(%s) = %s, want %s", tc.at, got, tc.want)
+		}
+	}
+}
+
+func TestStartOfDay(t *testing.T) {
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	want := time.Date(2026, 10, 17, 17, 0, 0, 0, time.UTC)
+	if got := StartOfDay(day); !got.Equal(want) {
+		t.Errorf("StartOfDay(%s) = %s, want %s", day, got, want)
+	}
+	if got := CalendarDay(StartOfDay(day)); !got.Equal(day) {
+		t.Errorf("CalendarDay(StartOfDay(%s)) = %s, want the same day", day, got)
+	}
+}
@@ -0,0 +1,103 @@
+// Package vrp solves capacitated vehicle routing problems with time windows.
+//
+// Construction uses regret-2 cheapest insertion; the solution is then improved with
+// relocate, exchange and 2-opt local search, and with ruin-and-recreate perturbation.
+// Every phase stops at the time limit, so stops construction had no time left for are
+// reported as unassigned. Times are seconds from a common epoch chosen by the caller.
+package vrp
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Reasons a stop is left unassigned
+const (
+	ReasonCapacity   = "capacity_exceeded"       // demand exceeds every vehicle's capacity
+	ReasonTimeWindow = "time_window_unreachable" // no vehicle can reach it within its window and shift
+	ReasonNoVehicle  = "no_feasible_vehicle"     // feasible alone, but no route has room left
+	ReasonCancelled  = "cancelled"               // the context was cancelled before it was tried
+	ReasonTimeLimit  = "time_limit_reached"      // the time limit passed before it was tried
+)
+
+// ErrInvalidProblem is returned when the problem is malformed
+var ErrInvalidProblem = errors.New("invalid routing problem")
+
+// TimeWindow is an inclusive [Start, End] interval in which service must begin
+type TimeWindow struct {
+	Start float64
+	End   float64
+}
+
+// Depot is a start and end point for vehicles
+type Depot struct {
+	ID string
+}
+
+// Stop is a location to visit once
+type Stop struct {
+	ID      string
+	Demand  []float64   // one value per capacity dimension
+	Service float64     // service duration in seconds
+	Window  *TimeWindow // nil means any time within the vehicle's shift
+}
+
+// Vehicle is a fleet vehicle that starts and ends its route at a depot
+type Vehicle struct {
+	ID         string
+	Depot      int       // index into Problem.Depots
+	Capacity   []float64 // one value per capacity dimension; zero means unlimited
+	ShiftStart float64
+	ShiftEnd   float64 // zero means no shift end
+}
+
+// Problem is a routing instance. Distance (meters) and Duration (seconds) are square matrices
+// indexed by node: depots first in order, then stops in order.
+type Problem struct {
+	Depots   []Depot
+	Stops    []Stop
+	Vehicles []Vehicle
+	Distance [][]float64
+	Duration [][]float64
+}
+
+func (p *Problem) validate() error {
+	if len(p.Depots) == 0 {
+		return fmt.Errorf("%w: at least one depot is required", ErrInvalidProblem)
+	}
+	if len(p.Vehicles) == 0 {
+		return fmt.Errorf("%w: at least one vehicle is required", ErrInvalidProblem)
+	}
+
+	nodes := len(p.Depots) + len(p.Stops)
+	for name, m := range map[string][][]float64{"distance": p.Distance, "duration": p.Duration} {
+		if len(m) != nodes {
+			return fmt.Errorf("%w: %s matrix has %d rows, want %d", ErrInvalidProblem, name, len(m), nodes)
+		}
+		for i, row := range m {
+			if len(row) != nodes {
+				return fmt.Errorf("%w: %s matrix row %d has %d columns, want %d", ErrInvalidProblem, name, i, len(row), nodes)
+			}
+		}
+	}
+
+	dims := -1
+	for _, v := range p.Vehicles {
+		if v.Depot < 0 || v.Depot >= len(p.Depots) {
+			return fmt.Errorf("%w: vehicle %s references unknown depot %d", ErrInvalidProblem, v.ID, v.Depot)
+		}
+		if dims >= 0 && len(v.Capacity) != dims {
+			return fmt.Errorf("%w: vehicle %s has %d capacity dimensions, want %d", ErrInvalidProblem, v.ID, len(v.Capacity), dims)
+		}
+		dims = len(v.Capacity)
+	}
+	for _, s := range p.Stops {
+		if len(s.Demand) != dims {
+			return fmt.Errorf("%w: stop %s has %d demand dimensions, want %d", ErrInvalidProblem, s.ID, len(s.Demand), dims)
+		}
+		if s.Window != nil && s.Window.End < s.Window.Start {
+			return fmt.Errorf("%w: stop %s time window ends before it starts", ErrInvalidProblem, s.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package vrp
+
+import (
+	"sort"
+)
+
+// epsilon ignores improvements too small to matter and guards against float churn
+const epsilon = 1e-6
+
+// localSearch applies improving moves until none is found or time runs out
+func (s *solver) localSearch() {
+	for !s.timeUp() {
+		improved := s.relocate()
+		improved = s.exchange() || improved
+		improved = s.twoOpt() || improved
+		if !improved {
+			return
+		}
+	}
+}
+
+// relocate moves single stops to a cheaper position in the same or another route
+func (s *solver) relocate() bool {
+	improved := false
+	for a := range s.routes {
+		for i := 0; i < len(s.routes[a]); i++ {
+			if s.timeUp() {
+				return improved
+			}
+
+			si := s.routes[a][i]
+			without := remove(s.routes[a], i)
+			costWithout, ok := s.evaluate(a, without)
+			if !ok {
+				continue
+			}
+			gain := s.costs[a] - costWithout
+
+			moved := false
+			for b := range s.routes {
+				base := s.routes[b]
+				baseCost := s.costs[b]
+				if b == a {
+					base, baseCost = without, costWithout
+				}
+				for pos := 0; pos <= len(base); pos++ {
+					if b == a && pos == i {
+						continue
+					}
+					candidate := insertAt(base, pos, si)
+					cost, ok := s.evaluate(b, candidate)
+					if !ok || cost-baseCost >= gain-epsilon {
+						continue
+					}
+
+					if b == a {
+						s.routes[a], s.costs[a] = candidate, cost
+					} else {
+						s.routes[a], s.costs[a] = without, costWithout
+						s.routes[b], s.costs[b] = candidate, cost
+					}
+					moved, improved = true, true
+					break
+				}
+				if moved {
+					break
+				}
+			}
+		}
+	}
+	return improved
+}
+
+// exchange swaps two stops between different routes
+func (s *solver) exchange() bool {
+	improved := false
+	for a := range s.routes {
+		for b := a + 1; b < len(s.routes); b++ {
+			for i := 0; i < len(s.routes[a]); i++ {
+				if s.timeUp() {
+					return improved
+				}
+				for j := 0; j < len(s.routes[b]); j++ {
+					ra := replace(s.routes[a], i, s.routes[b][j])
+					rb := replace(s.routes[b], j, s.routes[a][i])
+					costA, okA := s.evaluate(a, ra)
+					if !okA {
+						continue
+					}
+					costB, okB := s.evaluate(b, rb)
+					if !okB || costA+costB >= s.costs[a]+s.costs[b]-epsilon {
+						continue
+					}
+					s.routes[a], s.costs[a] = ra, costA
+					s.routes[b], s.costs[b] = rb, costB
+					improved = true
+				}
+			}
+		}
+	}
+	return improved
+}
+
+// twoOpt reverses segments within a route to remove crossings
+func (s *solver) twoOpt() bool {
+	improved := false
+	for v := range s.routes {
+		n := len(s.routes[v])
+		for i := 0; i < n-1; i++ {
+			if s.timeUp() {
+				return improved
+			}
+			for j := i + 1; j < n; j++ {
+				candidate := reverse(s.routes[v], i, j)
+				cost, ok := s.evaluate(v, candidate)
+				if !ok || cost >= s.costs[v]-epsilon {
+					continue
+				}
+				s.routes[v], s.costs[v] = candidate, cost
+				improved = true
+			}
+		}
+	}
+	return improved
+}
+
+// snapshot is a copy of the solver state used to roll back rejected perturbations
+type snapshot struct {
+	routes  [][]int
+	costs   []float64
+	reasons map[int]string
+}
+
+func (s *solver) snapshot() snapshot {
+	snap := snapshot{
+		routes:  make([][]int, len(s.routes)),
+		costs:   append([]float64(nil), s.costs...),
+		reasons: make(map[int]string, len(s.reasons)),
+	}
+	for v, r := range s.routes {
+		snap.routes[v] = append([]int(nil), r...)
+	}
+	for k, v := range s.reasons {
+		snap.reasons[k] = v
+	}
+	return snap
+}
+
+func (s *solver) restore(snap snapshot) {
+	s.routes, s.costs, s.reasons = snap.routes, snap.costs, snap.reasons
+}
+
+// better reports whether the current state beats the snapshot: fewer unassigned stops first, then distance
+func (s *solver) better(snap snapshot) bool {
+	if len(s.reasons) != len(snap.reasons) {
+		return len(s.reasons) < len(snap.reasons)
+	}
+	total := 0.0
+	for _, c := range snap.costs {
+		total += c
+	}
+	return s.totalCost() < total-epsilon
+}
+
+// perturb runs ruin-and-recreate iterations until the time limit or the iteration cap, keeping the best solution.
+// Each iteration removes a cluster of nearby stops and re-inserts them together with any stops
+// that previously found no room.
+func (s *solver) perturb() int {
+	best := s.snapshot()
+	iterations := 0
+
+	for !s.timeUp() && (s.iterations <= 0 || iterations < s.iterations) {
+		routed := s.routed()
+		if len(routed) == 0 {
+			break
+		}
+		iterations++
+
+		k := len(routed) / 10
+		if k < 2 {
+			k = 2
+		}
+		if k > 30 {
+			k = 30
+		}
+
+		removed, ok := s.ruin(routed, k)
+		if ok {
+			var retry []int
+			for si, reason := range s.reasons {
+				if reason == ReasonNoVehicle {
+					retry = append(retry, si)
+				}
+			}
+			// map order is random; sorting keeps runs with the same seed identical
+			sort.Ints(retry)
+			s.insert(append(removed, retry...))
+			s.localSearch()
+		}
+
+		if ok && s.better(best) {
+			best = s.snapshot()
+		} else {
+			s.restore(best)
+			best = s.snapshot()
+		}
+	}
+
+	s.restore(best)
+	return iterations
+}
+
+// routed lists every stop currently on a route
+func (s *solver) routed() []int {
+	var stops []int
+	for _, r := range s.routes {
+		stops = append(stops, r...)
+	}
+	return stops
+}
+
+// ruin removes a random stop and its k-1 nearest routed neighbours
+func (s *solver) ruin(routed []int, k int) ([]int, bool) {
+	if k > len(routed) {
+		k = len(routed)
+	}
+	nDepots := len(s.p.Depots)
+	seed := routed[s.rng.Intn(len(routed))]
+
+	sort.Slice(routed, func(i, j int) bool {
+		return s.p.Distance[nDepots+seed][nDepots+routed[i]] < s.p.Distance[nDepots+seed][nDepots+routed[j]]
+	})
+	removed := append([]int(nil), routed[:k]...)
+
+	drop := make(map[int]bool, k)
+	for _, si := range removed {
+		drop[si] = true
+	}
+	for v, r := range s.routes {
+		kept := r[:0:0]
+		for _, si := range r {
+			if !drop[si] {
+				kept = append(kept, si)
+			}
+		}
+		if len(kept) == len(r) {
+			continue
+		}
+		cost, ok := s.evaluate(v, kept)
+		if !ok {
+			return nil, false
+		}
+		s.routes[v], s.costs[v] = kept, cost
+	}
+	return removed, true
+}
+
+func remove(seq []int, i int) []int {
+	out := make([]int, 0, len(seq)-1)
+	out = append(out, seq[:i]...)
+	return append(out, seq[i+1:]...)
+}
+
+func insertAt(seq []int, pos, value int) []int {
+	out := make([]int, 0, len(seq)+1)
+	out = append(out, seq[:pos]...)
+	out = append(out, value)
+	return append(out, seq[pos:]...)
+}
+
+func replace(seq []int, i, value int) []int {
+	out := append([]int(nil), seq...)
+	out[i] = value
+	return out
+}
+
+func reverse(seq []int, i, j int) []int {
+	out := append([]int(nil), seq...)
+	for l, r := i, j; l < r; l, r = l+1, r-1 {
+		out[l], out[r] = out[r], out[l]
+	}
+	return out
+}
@@ -0,0 +1,372 @@
+package vrp
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// DefaultTimeLimit bounds the search when Options.TimeLimit is zero
+const DefaultTimeLimit = 5 * time.Second
+
+// Options tunes the search
+type Options struct {
+	TimeLimit  time.Duration
+	Seed       int64
+	Iterations int // ruin-and-recreate iterations; zero runs until the time limit
+}
+
+// Visit is a scheduled stop on a route
+type Visit struct {
+	StopID       string
+	Arrival      float64
+	ServiceStart float64 // after waiting for the time window to open
+	Departure    float64
+}
+
+// Route is the planned sequence of visits of one vehicle
+type Route struct {
+	VehicleID string
+	Visits    []Visit
+	Distance  float64
+	Start     float64 // departure from the depot
+	End       float64 // return to the depot
+	Load      []float64
+}
+
+// Unassigned is a stop the solver could not place, with the reason
+type Unassigned struct {
+	StopID string
+	Reason string
+}
+
+// Solution is the result of Solve
+type Solution struct {
+	Routes        []Route
+	Unassigned    []Unassigned
+	TotalDistance float64
+	Iterations    int
+}
+
+// solver holds the working state. Each vehicle owns exactly one route, possibly empty.
+type solver struct {
+	p          *Problem
+	routes     [][]int // stop indices per vehicle
+	costs      []float64
+	reasons    map[int]string // unassigned stop -> reason
+	rng        *rand.Rand
+	ctx        context.Context
+	deadline   time.Time
+	iterations int
+}
+
+// Solve builds routes for the problem and improves them until the time limit.
+// It returns the best solution found; cancelling the context stops the search early.
+func Solve(ctx context.Context, p Problem, opts Options) (*Solution, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	if opts.TimeLimit <= 0 {
+		opts.TimeLimit = DefaultTimeLimit
+	}
+
+	s := &solver{
+		p:          &p,
+		routes:     make([][]int, len(p.Vehicles)),
+		costs:      make([]float64, len(p.Vehicles)),
+		reasons:    make(map[int]string),
+		rng:        rand.New(rand.NewSource(opts.Seed)),
+		ctx:        ctx,
+		deadline:   time.Now().Add(opts.TimeLimit),
+		iterations: opts.Iterations,
+	}
+
+	candidates := s.prefilter()
+	s.insert(candidates)
+	s.localSearch()
+	iterations := s.perturb()
+
+	return s.solution(iterations), nil
+}
+
+func (s *solver) timeUp() bool {
+	return time.Now().After(s.deadline) || s.ctx.Err() != nil
+}
+
+// evaluate returns the distance of a route for a vehicle and whether it is feasible
+func (s *solver) evaluate(v int, seq []int) (float64, bool) {
+	veh := &s.p.Vehicles[v]
+	depot := veh.Depot
+	nDepots := len(s.p.Depots)
+
+	var load [8]float64 // capacity dimensions are few; avoid allocating per evaluation
+	loads := load[:0]
+	if len(veh.Capacity) > len(load) {
+		loads = make([]float64, 0, len(veh.Capacity))
+	}
+	loads = loads[:len(veh.Capacity)]
+
+	t := veh.ShiftStart
+	prev := depot
+	cost := 0.0
+	for _, si := range seq {
+		node := nDepots + si
+		stop := &s.p.Stops[si]
+
+		cost += s.p.Distance[prev][node]
+		t += s.p.Duration[prev][node]
+		if stop.Window != nil {
+			if t < stop.Window.Start {
+				t = stop.Window.Start
+			}
+			if t > stop.Window.End {
+				return 0, false
+			}
+		}
+		t += stop.Service
+
+		for d, q := range stop.Demand {
+			loads[d] += q
+			if veh.Capacity[d] > 0 && loads[d] > veh.Capacity[d]+1e-9 {
+				return 0, false
+			}
+		}
+		prev = node
+	}
+
+	if len(seq) == 0 {
+		return 0, true
+	}
+	cost += s.p.Distance[prev][depot]
+	t += s.p.Duration[prev][depot]
+	if veh.ShiftEnd > 0 && t > veh.ShiftEnd {
+		return 0, false
+	}
+	return cost, true
+}
+
+// prefilter marks stops no vehicle can serve on its own and returns the rest
+func (s *solver) prefilter() []int {
+	var candidates []int
+	for si := range s.p.Stops {
+		servable, fits := false, false
+		for v := range s.p.Vehicles {
+			if exceeds(s.p.Stops[si].Demand, s.p.Vehicles[v].Capacity) {
+				continue
+			}
+			fits = true
+			if _, ok := s.evaluate(v, []int{si}); ok {
+				servable = true
+				break
+			}
+		}
+		switch {
+		case servable:
+			candidates = append(candidates, si)
+		case !fits:
+			s.reasons[si] = ReasonCapacity
+		default:
+			s.reasons[si] = ReasonTimeWindow
+		}
+	}
+	return candidates
+}
+
+func exceeds(demand, capacity []float64) bool {
+	for d, q := range demand {
+		if capacity[d] > 0 && q > capacity[d] {
+			return true
+		}
+	}
+	return false
+}
+
+// insertion is the cheapest feasible position of a stop in one route
+type insertion struct {
+	delta float64
+	pos   int
+	ok    bool
+}
+
+// bestInsertion finds the cheapest feasible position for a stop in a vehicle's route
+func (s *solver) bestInsertion(v, si int) insertion {
+	route := s.routes[v]
+	best := insertion{delta: math.Inf(1)}
+	seq := make([]int, len(route)+1)
+	for pos := 0; pos <= len(route); pos++ {
+		copy(seq, route[:pos])
+		seq[pos] = si
+		copy(seq[pos+1:], route[pos:])
+		cost, ok := s.evaluate(v, seq)
+		if !ok {
+			continue
+		}
+		if delta := cost - s.costs[v]; delta < best.delta {
+			best = insertion{delta: delta, pos: pos, ok: true}
+		}
+	}
+	return best
+}
+
+// insert places stops with regret-2 insertion: the stop that would lose the most by not getting
+// its best route goes first. Stops that fit nowhere are recorded as unassigned.
+func (s *solver) insert(stops []int) {
+	if len(stops) == 0 {
+		return
+	}
+
+	pending := make([]bool, len(stops))
+	for i := range pending {
+		pending[i] = true
+	}
+
+	// cache[i][v] is the best insertion of stops[i] into vehicle v's route
+	cache := make([][]insertion, len(stops))
+	for i, si := range stops {
+		if s.timeUp() {
+			s.abandon(stops, pending)
+			return
+		}
+		cache[i] = make([]insertion, len(s.p.Vehicles))
+		for v := range s.p.Vehicles {
+			cache[i][v] = s.bestInsertion(v, si)
+		}
+	}
+
+	for remaining := len(stops); remaining > 0; remaining-- {
+		if s.timeUp() {
+			s.abandon(stops, pending)
+			return
+		}
+
+		pick, pickVehicle := -1, -1
+		pickRegret, pickDelta := -1.0, math.Inf(1)
+		for i := range stops {
+			if !pending[i] {
+				continue
+			}
+			first, second := math.Inf(1), math.Inf(1)
+			firstVehicle := -1
+			for v, ins := range cache[i] {
+				if !ins.ok {
+					continue
+				}
+				if ins.delta < first {
+					first, second, firstVehicle = ins.delta, first, v
+				} else if ins.delta < second {
+					second = ins.delta
+				}
+			}
+			if firstVehicle < 0 {
+				continue
+			}
+			regret := second - first
+			if math.IsInf(second, 1) {
+				regret = math.MaxFloat64
+			}
+			if regret > pickRegret || (regret == pickRegret && first < pickDelta) {
+				pick, pickVehicle, pickRegret, pickDelta = i, firstVehicle, regret, first
+			}
+		}
+
+		if pick < 0 {
+			break
+		}
+
+		ins := cache[pick][pickVehicle]
+		route := s.routes[pickVehicle]
+		route = append(route, 0)
+		copy(route[ins.pos+1:], route[ins.pos:])
+		route[ins.pos] = stops[pick]
+		s.routes[pickVehicle] = route
+		s.costs[pickVehicle] += ins.delta
+		pending[pick] = false
+		delete(s.reasons, stops[pick])
+
+		for i, si := range stops {
+			if pending[i] {
+				cache[i][pickVehicle] = s.bestInsertion(pickVehicle, si)
+			}
+		}
+	}
+
+	for i, si := range stops {
+		if pending[i] {
+			s.reasons[si] = ReasonNoVehicle
+		}
+	}
+}
+
+// abandon records the stops still pending when the search stopped early
+func (s *solver) abandon(stops []int, pending []bool) {
+	reason := ReasonTimeLimit
+	if s.ctx.Err() != nil {
+		reason = ReasonCancelled
+	}
+	for i, si := range stops {
+		if pending[i] {
+			s.reasons[si] = reason
+		}
+	}
+}
+
+func (s *solver) totalCost() float64 {
+	total := 0.0
+	for _, c := range s.costs {
+		total += c
+	}
+	return total
+}
+
+// solution builds the schedule of every non-empty route
+func (s *solver) solution(iterations int) *Solution {
+	sol := &Solution{Iterations: iterations}
+	nDepots := len(s.p.Depots)
+
+	for v, seq := range s.routes {
+		if len(seq) == 0 {
+			continue
+		}
+		veh := &s.p.Vehicles[v]
+		route := Route{
+			VehicleID: veh.ID,
+			Start:     veh.ShiftStart,
+			Load:      make([]float64, len(veh.Capacity)),
+		}
+
+		t := veh.ShiftStart
+		prev := veh.Depot
+		for _, si := range seq {
+			node := nDepots + si
+			stop := &s.p.Stops[si]
+			t += s.p.Duration[prev][node]
+			route.Distance += s.p.Distance[prev][node]
+
+			visit := Visit{StopID: stop.ID, Arrival: t, ServiceStart: t}
+			if stop.Window != nil && t < stop.Window.Start {
+				visit.ServiceStart = stop.Window.Start
+			}
+			t = visit.ServiceStart + stop.Service
+			visit.Departure = t
+			route.Visits = append(route.Visits, visit)
+
+			for d, q := range stop.Demand {
+				route.Load[d] += q
+			}
+			prev = node
+		}
+		route.Distance += s.p.Distance[prev][veh.Depot]
+		route.End = t + s.p.Duration[prev][veh.Depot]
+
+		sol.Routes = append(sol.Routes, route)
+		sol.TotalDistance += route.Distance
+	}
+
+	for si := range s.p.Stops {
+		if reason, ok := s.reasons[si]; ok {
+			sol.Unassigned = append(sol.Unassigned, Unassigned{StopID: s.p.Stops[si].ID, Reason: reason})
+		}
+	}
+	return sol
+}
@@ -0,0 +1,283 @@
+package vrp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// randomProblem builds a problem on a 50 km square around one depot, driven at 10 m/s, with half of the stops
+// carrying a two-hour time window somewhere in a ten-hour shift
+func randomProblem(seed int64, stops, vehicles int) Problem {
+	rng := rand.New(rand.NewSource(seed))
+
+	type point struct{ x, y float64 }
+	points := []point{{25000, 25000}}
+	p := Problem{Depots: []Depot{{ID: "depot"}}}
+
+	for i := 0; i < stops; i++ {
+		points = append(points, point{rng.Float64() * 50000, rng.Float64() * 50000})
+		stop := Stop{
+			ID:      fmt.Sprintf("s%d", i),
+			Demand:  []float64{float64(1 + rng.Intn(10)), float64(rng.Intn(3))},
+			Service: 300,
+		}
+		if i%2 == 0 {
+			start := float64(rng.Intn(8)) * 3600
+			stop.Window = &TimeWindow{Start: start, End: start + 7200}
+		}
+		p.Stops = append(p.Stops, stop)
+	}
+	for v := 0; v < vehicles; v++ {
+		p.Vehicles = append(p.Vehicles, Vehicle{
+			ID:       fmt.Sprintf("v%d", v),
+			Capacity: []float64{100, 12},
+			ShiftEnd: 10 * 3600,
+		})
+	}
+
+	n := len(points)
+	p.Distance = make([][]float64, n)
+	p.Duration = make([][]float64, n)
+	for i := range points {
+		p.Distance[i] = make([]float64, n)
+		p.Duration[i] = make([]float64, n)
+		for j := range points {
+			d := math.Hypot(points[i].x-points[j].x, points[i].y-points[j].y)
+			p.Distance[i][j] = d
+			p.Duration[i][j] = d / 10
+		}
+	}
+	return p
+}
+
+// checkFeasible verifies every stop is routed or unassigned exactly once and that every route keeps to
+// capacities, time windows and shifts
+func checkFeasible(t *testing.T, p Problem, sol *Solution) {
+	t.Helper()
+
+	stops := make(map[string]Stop, len(p.Stops))
+	for _, s := range p.Stops {
+		stops[s.ID] = s
+	}
+	vehicles := make(map[string]Vehicle, len(p.Vehicles))
+	for _, v := range p.Vehicles {
+		vehicles[v.ID] = v
+	}
+
+	seen := make(map[string]int)
+	for _, r := range sol.Routes {
+		veh := vehicles[r.VehicleID]
+		load := make([]float64, len(veh.Capacity))
+		for _, visit := range r.Visits {
+			seen[visit.StopID]++
+			stop := stops[visit.StopID]
+			for d, q := range stop.Demand {
+				load[d] += q
+			}
+			if w := stop.Window; w != nil && (visit.ServiceStart < w.Start || visit.ServiceStart > w.End) {
+				t.Errorf("stop %s served at %.0f, outside [%.0f, %.0f]", visit.StopID, visit.ServiceStart, w.Start, w.End)
+			}
+			if visit.ServiceStart < visit.Arrival {
+				t.Errorf("stop %s served at %.0f before arriving at %.0f", visit.StopID, visit.ServiceStart, visit.Arrival)
+			}
+		}
+		for d, c := range veh.Capacity {
+			if c > 0 && load[d] > c+1e-9 {
+				t.Errorf("vehicle %s carries %.0f in dimension %d, capacity %.0f", r.VehicleID, load[d], d, c)
+			}
+			if math.Abs(load[d]-r.Load[d]) > 1e-9 {
+				t.Errorf("vehicle %s reports load %.0f in dimension %d, want %.0f", r.VehicleID, r.Load[d], d, load[d])
+			}
+		}
+		if veh.ShiftEnd > 0 && r.End > veh.ShiftEnd {
+			t.Errorf("vehicle %s returns at %.0f, after its shift ends at %.0f", r.VehicleID, r.End, veh.ShiftEnd)
+		}
+	}
+	for _, u := range sol.Unassigned {
+		seen[u.StopID]++
+	}
+	for _, s := range p.Stops {
+		if seen[s.ID] != 1 {
+			t.Errorf("stop %s appears %d times, want 1", s.ID, seen[s.ID])
+		}
+	}
+}
+
+func TestSolveFeasible(t *testing.T) {
+	for _, tc := range []struct{ stops, vehicles int }{{10, 2}, {60, 5}, {150, 8}} {
+		t.Run(fmt.Sprintf("%d stops", tc.stops), func(t *testing.T) {
+			p := randomProblem(int64(tc.stops), tc.stops, tc.vehicles)
+			sol, err := Solve(context.Background(), p, Options{TimeLimit: time.Minute, Seed: 1, Iterations: 50})
+			if err != nil {
+				t.Fatalf("Solve: %v", err)
+			}
+			checkFeasible(t, p, sol)
+			if len(sol.Routes) == 0 {
+				t.Fatal("no routes built")
+			}
+		})
+	}
+}
+
+func TestSolveDeterministic(t *testing.T) {
+	p := randomProblem(7, 80, 6)
+	opts := Options{TimeLimit: time.Minute, Seed: 42, Iterations: 100}
+
+	first, err := Solve(context.Background(), p, opts)
+	if err != nil {
+		t.Fatalf("Solve: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		again, err := Solve(context.Background(), p, opts)
+		if err != nil {
+			t.Fatalf("Solve: %v", err)
+		}
+		if !reflect.DeepEqual(first, again) {
+			t.Fatalf("run %d differs: %.1f m vs %.1f m", i+2, again.TotalDistance, first.TotalDistance)
+		}
+	}
+}
+
+func TestSolveUnassignedReasons(t *testing.T) {
+	// depot and three stops on a line, 1 km apart at 10 m/s
+	line := [][]float64{
+		{0, 1000, 2000, 3000},
+		{1000, 0, 1000, 2000},
+		{2000, 1000, 0, 1000},
+		{3000, 2000, 1000, 0},
+	}
+	duration := make([][]float64, len(line))
+	for i, row := range line {
+		duration[i] = make([]float64, len(row))
+		for j, d := range row {
+			duration[i][j] = d / 10
+		}
+	}
+
+	p := Problem{
+		Depots: []Depot{{ID: "depot"}},
+		Stops: []Stop{
+			{ID: "heavy", Demand: []float64{20}},
+			{ID: "early", Demand: []float64{1}, Window: &TimeWindow{Start: 0, End: 100}},
+			{ID: "ok", Demand: []float64{10}},
+		},
+		Vehicles: []Vehicle{{ID: "v", Capacity: []float64{10}}},
+		Distance: line,
+		Duration: duration,
+	}
+
+	sol, err := Solve(context.Background(), p, Options{TimeLimit: time.Minute, Iterations: 10})
+	if err != nil {
+		t.Fatalf("Solve: %v", err)
+	}
+	checkFeasible(t, p, sol)
+
+	reasons := make(map[string]string)
+	for _, u := range sol.Unassigned {
+		reasons[u.StopID] = u.Reason
+	}
+	want := map[string]string{"heavy": ReasonCapacity, "early": ReasonTimeWindow}
+	if !reflect.DeepEqual(reasons, want) {
+		t.Errorf("unassigned = %v, want %v", reasons, want)
+	}
+}
+
+func TestSolveNoRoomLeft(t *testing.T) {
+	p := randomProblem(3, 20, 1)
+	p.Vehicles[0].Capacity = []float64{15, 0}
+
+	sol, err := Solve(context.Background(), p, Options{TimeLimit: time.Minute, Iterations: 10})
+	if err != nil {
+		t.Fatalf("Solve: %v", err)
+	}
+	checkFeasible(t, p, sol)
+	if len(sol.Unassigned) == 0 {
+		t.Fatal("expected stops without room")
+	}
+	for _, u := range sol.Unassigned {
+		if u.Reason != ReasonNoVehicle && u.Reason != ReasonTimeWindow {
+			t.Errorf("stop %s: reason %s", u.StopID, u.Reason)
+		}
+	}
+}
+
+func TestSolveStopsAtTimeLimit(t *testing.T) {
+	p := randomProblem(5, 300, 10)
+
+	start := time.Now()
+	sol, err := Solve(context.Background(), p, Options{TimeLimit: time.Nanosecond})
+	if err != nil {
+		t.Fatalf("Solve: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Solve took %s past a 1ns limit", elapsed)
+	}
+	checkFeasible(t, p, sol)
+	if len(sol.Routes) != 0 {
+		t.Errorf("%d routes built after the time limit", len(sol.Routes))
+	}
+	for _, u := range sol.Unassigned {
+		if u.Reason != ReasonTimeLimit && u.Reason != ReasonTimeWindow {
+			t.Errorf("stop %s: reason %s, want %s", u.StopID, u.Reason, ReasonTimeLimit)
+		}
+	}
+}
+
+func TestSolveCancelled(t *testing.T) {
+	p := randomProblem(9, 50, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sol, err := Solve(ctx, p, Options{TimeLimit: time.Minute})
+	if err != nil {
+		t.Fatalf("Solve: %v", err)
+	}
+	checkFeasible(t, p, sol)
+	for _, u := range sol.Unassigned {
+		if u.Reason != ReasonCancelled && u.Reason != ReasonTimeWindow {
+			t.Errorf("stop %s: reason %s, want %s", u.StopID, u.Reason, ReasonCancelled)
+		}
+	}
+}
+
+func TestSolveInvalidProblem(t *testing.T) {
+	valid := randomProblem(1, 3, 1)
+
+	for name, mutate := range map[string]func(p *Problem){
+		"no depot":         func(p *Problem) { p.Depots = nil },
+		"no vehicle":       func(p *Problem) { p.Vehicles = nil },
+		"short matrix":     func(p *Problem) { p.Distance = p.Distance[1:] },
+		"unknown depot":    func(p *Problem) { p.Vehicles[0].Depot = 3 },
+		"demand dimension": func(p *Problem) { p.Stops[0].Demand = []float64{1} },
+		"inverted window":  func(p *Problem) { p.Stops[0].Window = &TimeWindow{Start: 10, End: 5} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := valid
+			p.Stops = append([]Stop(nil), valid.Stops...)
+			p.Vehicles = append([]Vehicle(nil), valid.Vehicles...)
+			mutate(&p)
+			if _, err := Solve(context.Background(), p, Options{}); !errors.Is(err, ErrInvalidProblem) {
+				t.Errorf("err = %v, want ErrInvalidProblem", err)
+			}
+		})
+	}
+}
+
+func BenchmarkSolve(b *testing.B) {
+	for _, tc := range []struct{ stops, vehicles int }{{50, 4}, {200, 12}, {500, 30}} {
+		p := randomProblem(int64(tc.stops), tc.stops, tc.vehicles)
+		b.Run(fmt.Sprintf("%d stops", tc.stops), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := Solve(context.Background(), p, Options{TimeLimit: time.Minute, Seed: int64(i), Iterations: 10}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}